@@ -21,6 +21,7 @@ const (
 type Region string
 
 // DRClusterSpec defines the desired state of DRCluster
+// +kubebuilder:validation:XValidation:rule="self.s3ProfileName == oldSelf.s3ProfileName || (has(self.s3ProfileMigration) && self.s3ProfileMigration.source == oldSelf.s3ProfileName && self.s3ProfileMigration.target == self.s3ProfileName)",message="s3ProfileName is immutable, other than to switch it to the target of s3ProfileMigration"
 type DRClusterSpec struct {
 	// CIDRs is a list of CIDR strings. An admin can use this field to indicate
 	// the CIDRs that are used or could potentially be used for the nodes in
@@ -28,9 +29,22 @@ type DRClusterSpec struct {
 	// operation for sync/Metro DR.
 	CIDRs []string `json:"cidrs,omitempty"`
 
+	// CIDRGroups are named groups of the CIDRs of the cluster, such as those of its storage network and of its public
+	// network, for a fence operation to fence off some of the groups only
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	CIDRGroups []CIDRGroup `json:"cidrGroups,omitempty"`
+
 	// ClusterFence is a string that determines the desired fencing state of the cluster.
 	ClusterFence ClusterFenceState `json:"clusterFence,omitempty"`
 
+	// FenceCIDRGroups are the names of the CIDR groups a fence operation fences off, each with NetworkFences of its
+	// own, for instance to fence off the storage network of the cluster without cutting its management traffic. All
+	// the CIDRs of the cluster are fenced off if none is named. Changes apply to the next fence operation.
+	// +optional
+	FenceCIDRGroups []string `json:"fenceCIDRGroups,omitempty"`
+
 	// Region of a managed cluster determines it DR group.
 	// All managed clusters in a region are considered to be in a sync group.
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="region is immutable"
@@ -43,9 +57,116 @@ type DRClusterSpec struct {
 	// that are active on this managed cluster, their PV related cluster state
 	// is stored to S3 profiles of all other drclusters in the same
 	// DRPolicy to enable recovery or relocate actions to those managed clusters.
+	// It is immutable, other than to switch it to the target of s3ProfileMigration.
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="s3ProfileName is immutable"
 	S3ProfileName string `json:"s3ProfileName"`
+
+	// S3ProfileMigration is the migration of s3ProfileName, which the hub operator sets in the same update as it
+	// switches s3ProfileName from the source to the target, once the metadata of every DRPC of the cluster is
+	// migrated to the target, see the s3-profile-migration annotation of the DRPC
+	// +optional
+	S3ProfileMigration *S3ProfileMigration `json:"s3ProfileMigration,omitempty"`
+
+	// Fencing holds the storage specific details used to fence this cluster, when
+	// no NetworkFenceClass is available for its storage. Supersedes the storage
+	// annotations on the DRCluster resource.
+	// +optional
+	Fencing *FencingSpec `json:"fencing,omitempty"`
+
+	// FenceVerification, if set, verifies that this cluster lost access to its storage once the NetworkFences that
+	// fence it report success, before the cluster is reported fenced
+	// +optional
+	FenceVerification *FenceVerificationSpec `json:"fenceVerification,omitempty"`
+
+	// ViewRefresh overrides the refresh intervals of the ManagedClusterViews of the objects on this cluster, that
+	// are otherwise set by the ramen config
+	// +optional
+	ViewRefresh *ViewRefreshSpec `json:"viewRefresh,omitempty"`
+}
+
+// S3ProfileMigration is the migration of the S3 profile of a DRCluster
+type S3ProfileMigration struct {
+	// Source is the S3 profile migrated from
+	// +kubebuilder:validation:MinLength=1
+	Source string `json:"source"`
+
+	// Target is the S3 profile migrated to
+	// +kubebuilder:validation:MinLength=1
+	Target string `json:"target"`
+}
+
+// CIDRGroup is a named group of the CIDRs of a cluster
+type CIDRGroup struct {
+	// Name of the group, which the NetworkFences of the group are named after
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=32
+	Name string `json:"name"`
+
+	// CIDRs of the group, each of which is to be one of the CIDRs of the cluster
+	// +kubebuilder:validation:MinItems=1
+	CIDRs []string `json:"cidrs"`
+}
+
+// ViewRefreshSpec holds the refresh intervals of the ManagedClusterViews of the objects on a cluster. The controllers
+// switch the views of the cluster to the active interval while an action involving the cluster, like a failover,
+// relocate, fence or unfence, is in progress, and back to the steady interval once the action completes.
+type ViewRefreshSpec struct {
+	// SteadyIntervalSeconds is the refresh interval of the views when no action involving the cluster is in progress
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SteadyIntervalSeconds int32 `json:"steadyIntervalSeconds,omitempty"`
+
+	// ActiveIntervalSeconds is the refresh interval of the views while an action involving the cluster is in progress
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ActiveIntervalSeconds int32 `json:"activeIntervalSeconds,omitempty"`
+}
+
+// FenceVerificationSpec defines the probe that verifies that a fenced cluster lost access to its storage, as a
+// defense against fencing drivers that report success before the storage stops serving the cluster
+type FenceVerificationSpec struct {
+	// Probe is the template of a batch/v1 Job, created on the peer cluster that fenced this cluster, that succeeds
+	// once it verifies with the storage that this cluster lost access to it, as by finding its CIDRs in the storage
+	// client blocklist, and fails otherwise. The Job is expected not to retry, that is to set a backoffLimit of 0.
+	// The template is rendered with .ClusterName, the name of the peer cluster, .FencedClusterName, the name of
+	// this cluster, and .CIDRs, the CIDRs of this cluster the fence operation fences off.
+	Probe ManifestTemplate `json:"probe"`
+
+	// TimeoutSeconds is how long the probe may run before the verification fails
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// FencingSpec defines the storage driver, credentials and parameters of the
+// NetworkFence resource that fences a cluster
+type FencingSpec struct {
+	// Driver is the name of the CSI driver that performs the fencing operation
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[a-zA-Z0-9]([a-zA-Z0-9.-]*[a-zA-Z0-9])?$')",message="driver must be a CSI driver name of alphanumerics, '-' and '.', beginning and ending with an alphanumeric"
+	Driver string `json:"driver"`
+
+	// SecretName is the name of the secret with the credentials for the driver
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[a-z0-9]([a-z0-9-]*[a-z0-9])?([.][a-z0-9]([a-z0-9-]*[a-z0-9])?)*$')",message="secretName must be a DNS subdomain"
+	SecretName string `json:"secretName"`
+
+	// SecretNamespace is the namespace of the secret with the credentials for the driver
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[a-z0-9]([a-z0-9-]*[a-z0-9])?$')",message="secretNamespace must be a DNS label"
+	SecretNamespace string `json:"secretNamespace"`
+
+	// Parameters are driver specific parameters passed to the fencing operation.
+	// Parameters are further validated by the DRCluster controller for known drivers.
+	// +kubebuilder:validation:MaxProperties=32
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k.matches('^[a-zA-Z][a-zA-Z0-9._-]*$'))",message="parameter names must start with a letter and contain only letters, digits, '.', '_' or '-'"
+	// +kubebuilder:validation:XValidation:rule="self.all(k, size(self[k]) > 0)",message="parameter values must not be empty"
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 const (
@@ -59,6 +180,31 @@ const (
 	// Fencing CR to fence off this cluster
 	// has been created
 	DRClusterConditionTypeFenced = "Fenced"
+
+	// klusterlet agents of the peer cluster, where the fencing
+	// CR is created, appear to be unavailable
+	DRClusterConditionTypeAgentUnavailable = "AgentUnavailable"
+
+	// DRCluster ManifestWork was split into multiple ManifestWorks, as it
+	// exceeded the ManifestWork size limit, or, if false with the
+	// ManifestTooLarge reason, could not be delivered, as a single manifest
+	// of it exceeds the limit
+	DRClusterConditionTypeManifestWorkSplit = "ManifestWorkSplit"
+
+	// S3 profile of the cluster passed the last periodic health probe
+	DRClusterConditionTypeS3ProfileAvailable = "S3ProfileAvailable"
+
+	// S3 profile of the cluster passed its last validation, when the ramen config
+	// validates the S3 profiles asynchronously
+	DRClusterConditionTypeS3ProfileValidated = "S3ProfileValidated"
+
+	// S3 profile of the cluster is not in the ramen config, as it was removed
+	// or renamed while the cluster references it
+	DRClusterConditionTypeDanglingS3ProfileReference = "DanglingS3ProfileReference"
+
+	// ramen operator of the cluster reported no errors recently, as the
+	// operand errors of its DRClusterConfig
+	DRClusterConditionTypeOperandHealthy = "OperandHealthy"
 )
 
 type DRClusterPhase string
@@ -109,11 +255,136 @@ type ClusterMaintenanceMode struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// UnfenceAcknowledgment records that the storage on the peer cluster acknowledged the unfence operation of a
+// NetworkFence, before the NetworkFence is cleaned up
+type UnfenceAcknowledgment struct {
+	// Peer cluster where the NetworkFence was created
+	Cluster string `json:"cluster"`
+
+	// NetworkFenceClass of the NetworkFence, empty for a NetworkFence without a class
+	//+optional
+	NetworkFenceClass string `json:"networkFenceClass,omitempty"`
+
+	// Time the acknowledgment was observed
+	Time metav1.Time `json:"time"`
+}
+
+// DRClusterConfigResult is the result of applying the DRClusterConfig of a DRCluster, as read back from the managed
+// cluster
+type DRClusterConfigResult struct {
+	// ObservedGeneration of the DRClusterConfig on the managed cluster that the result was read from
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ProcessedSchedules are the replication schedules of the DRClusterConfig once the managed cluster processed its
+	// current generation
+	//+optional
+	ProcessedSchedules []string `json:"processedSchedules,omitempty"`
+
+	// PendingSchedules are the replication schedules required by the DRPolicies of the cluster that are not processed
+	//+optional
+	PendingSchedules []string `json:"pendingSchedules,omitempty"`
+
+	// StorageClasses discovered on the managed cluster
+	//+optional
+	StorageClasses []string `json:"storageClasses,omitempty"`
+
+	// VolumeSnapshotClasses discovered on the managed cluster
+	//+optional
+	VolumeSnapshotClasses []string `json:"volumeSnapshotClasses,omitempty"`
+
+	// VolumeGroupSnapshotClasses discovered on the managed cluster
+	//+optional
+	VolumeGroupSnapshotClasses []string `json:"volumeGroupSnapshotClasses,omitempty"`
+
+	// VolumeReplicationClasses discovered on the managed cluster
+	//+optional
+	VolumeReplicationClasses []string `json:"volumeReplicationClasses,omitempty"`
+
+	// VolumeGroupReplicationClasses discovered on the managed cluster
+	//+optional
+	VolumeGroupReplicationClasses []string `json:"volumeGroupReplicationClasses,omitempty"`
+
+	// NetworkFenceClasses discovered on the managed cluster
+	//+optional
+	NetworkFenceClasses []string `json:"networkFenceClasses,omitempty"`
+
+	// VRGSchemaVersion is the version of the VolumeReplicationGroup spec the managed cluster supports, or 0 if it
+	// reports none, as the clusters of the versions before the first one do not
+	//+optional
+	VRGSchemaVersion int `json:"vrgSchemaVersion,omitempty"`
+
+	// PeerConnected is the status of the PeerConnected condition of the DRClusterConfig, or empty if it reports none
+	//+optional
+	PeerConnected metav1.ConditionStatus `json:"peerConnected,omitempty"`
+
+	// Errors reported by the conditions of the DRClusterConfig that are false, each in the form "<type>: <message>"
+	//+optional
+	Errors []string `json:"errors,omitempty"`
+
+	// OperandErrors are the most recent errors the ramen operator of the managed cluster reported, most recent first
+	//+optional
+	OperandErrors []OperandError `json:"operandErrors,omitempty"`
+}
+
 // DRClusterStatus defines the observed state of DRCluster
 type DRClusterStatus struct {
 	Phase            DRClusterPhase           `json:"phase,omitempty"`
 	Conditions       []metav1.Condition       `json:"conditions,omitempty"`
 	MaintenanceModes []ClusterMaintenanceMode `json:"maintenanceModes,omitempty"`
+
+	// UnfenceAcknowledgments of the NetworkFences of the current unfence operation
+	//+optional
+	UnfenceAcknowledgments []UnfenceAcknowledgment `json:"unfenceAcknowledgments,omitempty"`
+
+	// ActionCheckpoint records the fence or unfence operation that was in flight when the operator
+	// stopped, to be resumed by the operator that takes over
+	//+optional
+	ActionCheckpoint *ActionCheckpoint `json:"actionCheckpoint,omitempty"`
+
+	// ClusterConfig is the result of applying the DRClusterConfig of the cluster
+	//+optional
+	ClusterConfig *DRClusterConfigResult `json:"clusterConfig,omitempty"`
+
+	// FenceVerification is the result of the verification of the current fence operation
+	//+optional
+	FenceVerification *FenceVerificationStatus `json:"fenceVerification,omitempty"`
+
+	// FencedCIDRGroups are the CIDR groups the current fence operation fences off, if it does not fence off all the
+	// CIDRs of the cluster, for the unfence operation to unfence the same groups
+	//+optional
+	FencedCIDRGroups []CIDRGroup `json:"fencedCIDRGroups,omitempty"`
+}
+
+// FenceVerificationResult is the result of the verification of a fence operation
+// +kubebuilder:validation:Enum=Pending;Verified;Failed
+type FenceVerificationResult string
+
+const (
+	// The probe verifying the fence operation is running
+	FenceVerificationPending = FenceVerificationResult("Pending")
+
+	// The probe verified that the cluster lost access to its storage
+	FenceVerificationVerified = FenceVerificationResult("Verified")
+
+	// The probe found that the cluster did not lose access to its storage, or did not complete in time
+	FenceVerificationFailed = FenceVerificationResult("Failed")
+)
+
+// FenceVerificationStatus is the result of the probe verifying a fence operation
+type FenceVerificationStatus struct {
+	// Peer cluster where the probe runs
+	Cluster string `json:"cluster"`
+
+	// Result of the verification
+	Result FenceVerificationResult `json:"result"`
+
+	// Message describing the result
+	//+optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime of the probe
+	StartTime metav1.Time `json:"startTime"`
 }
 
 //+kubebuilder:object:root=true
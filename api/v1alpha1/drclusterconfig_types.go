@@ -34,6 +34,15 @@ type DRClusterConfigSpec struct {
 const (
 	DRClusterConfigConfigurationProcessed string = "Processed"
 	DRClusterConfigS3Reachable            string = "Reachable"
+
+	// DRClusterConfigFencingAvailable is false if the csi-addons APIs used to fence clusters, NetworkFence and its
+	// classes, are not installed on the cluster
+	DRClusterConfigFencingAvailable string = "FencingAvailable"
+
+	// DRClusterConfigPeerConnected is false if the mirroring daemons of any of the mirrored Ceph block pools of the
+	// cluster report that they are not healthy, as when they are disconnected from the peer cluster. It is not
+	// reported if the cluster has no mirrored pools.
+	DRClusterConfigPeerConnected string = "PeerConnected"
 )
 
 // DRClusterConfigStatus defines the observed state of DRClusterConfig
@@ -65,6 +74,36 @@ type DRClusterConfigStatus struct {
 
 	// StorageAccessDetails lists the storage access information for each storage provisioner detected on the cluster.
 	StorageAccessDetails []StorageAccessDetail `json:"storageAccessDetails,omitempty"`
+
+	// VRGSchemaVersion is the version of the VolumeReplicationGroup spec the ramen operator of the cluster supports.
+	// The hub leaves the fields of later versions out of the VRGs it generates for the cluster.
+	//+optional
+	VRGSchemaVersion int `json:"vrgSchemaVersion,omitempty"`
+
+	// OperandErrors are the most recent errors the ramen operator of the cluster reported, most recent first, for
+	// the hub to surface them on the DRCluster. Errors are dropped once they are not reported for a day.
+	//+kubebuilder:validation:MaxItems=10
+	//+optional
+	OperandErrors []OperandError `json:"operandErrors,omitempty"`
+}
+
+// OperandErrorsMax is the number of most recent errors of the ramen operator of a cluster its DRClusterConfig reports
+const OperandErrorsMax = 10
+
+// OperandError is an error the ramen operator of a cluster reported while reconciling a resource
+type OperandError struct {
+	// Time the error was last reported
+	Time metav1.Time `json:"time"`
+
+	// Source of the error, in the form "<kind> <namespace>/<name>"
+	Source string `json:"source"`
+
+	// Message of the error
+	Message string `json:"message"`
+
+	// Count of the times the error was reported
+	//+optional
+	Count int32 `json:"count,omitempty"`
 }
 
 // StorageAccessDetail contains storage access information for a specific storage provisioner.
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DRClusterMigrationSpec requests the rename of a DRCluster, as its ManagedCluster was renamed. The DRCluster of the
+// new name is created with the spec of the DRCluster renamed, if it does not exist, and the ManifestWorks, DRPolicies
+// and DRPlacementControls of the DRCluster renamed are migrated to it before the DRCluster renamed is deleted.
+// +kubebuilder:validation:XValidation:rule="self.oldName != self.newName", message="newName must differ from oldName"
+// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="spec is immutable"
+type DRClusterMigrationSpec struct {
+	// OldName is the name of the DRCluster to rename
+	// +kubebuilder:validation:Required
+	OldName string `json:"oldName"`
+
+	// NewName is the name of the DRCluster to migrate to, that of the ManagedCluster renamed
+	// +kubebuilder:validation:Required
+	NewName string `json:"newName"`
+}
+
+// DRClusterMigrationPhase is the progress of a DRClusterMigration
+// +kubebuilder:validation:Enum=Validating;Transferring;UpdatingReferences;Verifying;Completed;Failed
+type DRClusterMigrationPhase string
+
+// Valid values for DRClusterMigrationPhase, in the order of the migration
+const (
+	// DRClusterMigrationValidating is set until the DRCluster of the new name is validated
+	DRClusterMigrationValidating = DRClusterMigrationPhase("Validating")
+
+	// DRClusterMigrationTransferring is set while the ManifestWorks of the DRCluster renamed are copied to the
+	// namespace of the new name
+	DRClusterMigrationTransferring = DRClusterMigrationPhase("Transferring")
+
+	// DRClusterMigrationUpdatingReferences is set while the DRPolicies and DRPlacementControls are updated to the new
+	// name
+	DRClusterMigrationUpdatingReferences = DRClusterMigrationPhase("UpdatingReferences")
+
+	// DRClusterMigrationVerifying is set until nothing refers to the DRCluster renamed, which is then deleted
+	DRClusterMigrationVerifying = DRClusterMigrationPhase("Verifying")
+
+	// DRClusterMigrationCompleted is set once the DRCluster renamed is deleted
+	DRClusterMigrationCompleted = DRClusterMigrationPhase("Completed")
+
+	// DRClusterMigrationFailed is set if the DRCluster cannot be renamed, before anything was migrated
+	DRClusterMigrationFailed = DRClusterMigrationPhase("Failed")
+)
+
+// DRClusterMigrationStatus defines the observed state of DRClusterMigration
+type DRClusterMigrationStatus struct {
+	// Phase is the progress of the migration
+	// +optional
+	Phase DRClusterMigrationPhase `json:"phase,omitempty"`
+
+	// Message details the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ManifestWorks are the names of the ManifestWorks transferred to the namespace of the new name
+	// +optional
+	ManifestWorks []string `json:"manifestWorks,omitempty"`
+
+	// DRPolicies are the names of the DRPolicies updated to the new name
+	// +optional
+	DRPolicies []string `json:"drPolicies,omitempty"`
+
+	// DRPlacementControls are the namespaced names of the DRPlacementControls updated to the new name
+	// +optional
+	DRPlacementControls []string `json:"drPlacementControls,omitempty"`
+
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=drcmigration
+// +kubebuilder:printcolumn:JSONPath=".spec.oldName",name=old,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.newName",name=new,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name=phase,type=string
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
+
+// DRClusterMigration is the Schema for the drclustermigrations API
+type DRClusterMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DRClusterMigrationSpec   `json:"spec,omitempty"`
+	Status DRClusterMigrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DRClusterMigrationList contains a list of DRClusterMigration
+type DRClusterMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DRClusterMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DRClusterMigration{}, &DRClusterMigrationList{})
+}
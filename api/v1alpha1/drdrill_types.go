@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DRDrillSpec requests a disaster recovery drill of a DRPlacementControl of the same namespace: the PVCs and kube
+// objects of its workload are restored from their latest replica and capture into an isolated namespace of the target
+// cluster, without touching the workload or its placement, and the namespace is deleted once the restore is verified.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="spec is immutable"
+type DRDrillSpec struct {
+	// DRPCName is the name of the DRPlacementControl to drill
+	// +kubebuilder:validation:Required
+	DRPCName string `json:"drpcName"`
+
+	// TargetCluster is the cluster to restore on. Defaults to the peer cluster of the cluster the workload is placed on.
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// Namespace is the namespace of the target cluster to restore into, which is created by the drill and must not
+	// exist. Defaults to the namespace of the workload suffixed with -drill.
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// RetainFor is how long the restored namespace is kept for inspection once restored, before it is deleted
+	// +optional
+	RetainFor *metav1.Duration `json:"retainFor,omitempty"`
+
+	// Timeout after which the drill fails if the workload is not restored. Defaults to 30m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// DRDrillPhase is the progress of a DRDrill
+// +kubebuilder:validation:Enum=Restoring;Restored;TearingDown;Succeeded;Failed
+type DRDrillPhase string
+
+// Valid values for DRDrillPhase, in the order of the drill
+const (
+	// DRDrillRestoring is set while the workload is restored into the drill namespace
+	DRDrillRestoring = DRDrillPhase("Restoring")
+
+	// DRDrillRestored is set once the workload is restored, while the drill namespace is retained
+	DRDrillRestored = DRDrillPhase("Restored")
+
+	// DRDrillTearingDown is set while the drill namespace is deleted
+	DRDrillTearingDown = DRDrillPhase("TearingDown")
+
+	// DRDrillSucceeded is set once the drill namespace of a restored workload is deleted
+	DRDrillSucceeded = DRDrillPhase("Succeeded")
+
+	// DRDrillFailed is set once the drill namespace of a workload that could not be restored is deleted, or if the
+	// drill is rejected
+	DRDrillFailed = DRDrillPhase("Failed")
+)
+
+// DRDrillStatus defines the observed state of DRDrill
+type DRDrillStatus struct {
+	// Phase is the progress of the drill
+	// +optional
+	Phase DRDrillPhase `json:"phase,omitempty"`
+
+	// Message details the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetCluster is the cluster the workload is restored on
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// Namespace is the namespace of the target cluster the workload is restored into
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// StartTime is when the restore started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// RestoredTime is when the workload was restored, unset if it was not
+	// +optional
+	RestoredTime *metav1.Time `json:"restoredTime,omitempty"`
+
+	// CompletionTime is when the drill namespace was deleted
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// RestoredPVCs are the names of the PVCs restored from their latest replica
+	// +optional
+	RestoredPVCs []string `json:"restoredPVCs,omitempty"`
+
+	// SkippedPVCs are the names of the PVCs that are not restorable without promoting their replica, hence only
+	// by a failover
+	// +optional
+	SkippedPVCs []string `json:"skippedPVCs,omitempty"`
+
+	// KubeObjectsRestored is set once the kube objects are restored from their latest capture
+	// +optional
+	KubeObjectsRestored bool `json:"kubeObjectsRestored,omitempty"`
+
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=drdrill
+// +kubebuilder:printcolumn:JSONPath=".spec.drpcName",name=drpc,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.targetCluster",name=target,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name=phase,type=string
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
+
+// DRDrill is the Schema for the drdrills API
+type DRDrill struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DRDrillSpec   `json:"spec,omitempty"`
+	Status DRDrillStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DRDrillList contains a list of DRDrill
+type DRDrillList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DRDrill `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DRDrill{}, &DRDrillList{})
+}
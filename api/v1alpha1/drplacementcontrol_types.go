@@ -80,6 +80,51 @@ const (
 	// GlobalActionConsensus condition indicates whether all DRPCs sharing the same global VGR label
 	// agree on the DR action and target cluster.
 	ConditionGlobalAction = "GlobalAction"
+
+	// LocalFailover condition reports whether a managed cluster executed a pre-approved local failover
+	// while the hub was unreachable, and whether the hub has since reconciled with it.
+	ConditionLocalFailover = "LocalFailover"
+
+	// ClusterUnavailable condition indicates that ManifestWork operations for a managed cluster are held until
+	// the cluster, which may be hibernated or temporarily detached, is available again.
+	ConditionClusterUnavailable = "ClusterUnavailable"
+
+	// FailoverDependencies condition indicates whether the DRPC failover dependencies are satisfied.
+	ConditionFailoverDependencies = "FailoverDependencies"
+
+	// AgentUnavailable condition warns that the klusterlet agents of the failover cluster appear to be unavailable,
+	// hence ManifestWork changes for the failover may stall until they recover.
+	ConditionAgentUnavailable = "AgentUnavailable"
+
+	// VRGViewUnavailable condition reports that the VRG of the DRPC cannot be read from a cluster of the DRPolicy
+	// through its ManagedClusterView, with the reason distinguishing a view that has not reported a result yet, is
+	// stale, is not permitted to read the VRG, or cannot refresh as the cluster is unreachable.
+	ConditionVRGViewUnavailable = "VRGViewUnavailable"
+
+	// Qualified condition indicates whether the replication round-trip between the clusters of the DRPolicy,
+	// requested by the qualification of the DRPC, succeeded before the workload was first protected.
+	ConditionQualified = "Qualified"
+
+	// RPOViolated condition indicates whether the last group sync of the workload is older than the RPO target of
+	// the DRPolicy.
+	ConditionRPOViolated = "RPOViolated"
+
+	// PolicyMigration condition reports the progress of the migration of the DRPC to the DRPolicy its drPolicyRef
+	// was changed to.
+	ConditionPolicyMigration = "PolicyMigration"
+
+	// ManifestWorkSplit condition indicates whether a ManifestWork of the DRPC was split into multiple ManifestWorks,
+	// as it exceeded the ManifestWork size limit, or could not be delivered, as a single manifest of it exceeds it.
+	ConditionManifestWorkSplit = "ManifestWorkSplit"
+
+	// AutoFailover condition reports whether the hub requested a failover of the workload as the cluster it runs on
+	// was unavailable for longer than the grace period of the autoFailover of the DRPC, and otherwise what the
+	// failover waits for.
+	ConditionAutoFailover = "AutoFailover"
+
+	// PreflightPassed condition reports whether the preflight checks of the last failover or relocate passed before
+	// the action started, or failed and the action waits for them to pass, or is forced.
+	ConditionPreflightPassed = "PreflightPassed"
 )
 
 const (
@@ -125,6 +170,9 @@ const (
 	ProgressionDeleted                             = ProgressionStatus("Deleted")
 	ProgressionActionPaused                        = ProgressionStatus("Paused")
 	ProgressionTestingFailover                     = ProgressionStatus("TestingFailover")
+	ProgressionWaitOnFailoverDependencies          = ProgressionStatus("WaitOnFailoverDependencies")
+	ProgressionQualifying                          = ProgressionStatus("Qualifying")
+	ProgressionWaitOnPreflightChecks               = ProgressionStatus("WaitOnPreflightChecks")
 )
 
 // DRPlacementControlSpec defines the desired state of DRPlacementControl
@@ -142,9 +190,10 @@ type DRPlacementControlSpec struct {
 	// +kubebuilder:validation:Optional
 	ProtectedNamespaces *[]string `json:"protectedNamespaces,omitempty"`
 
-	// DRPolicyRef is the reference to the DRPolicy participating in the DR replication for this DRPC
+	// DRPolicyRef is the reference to the DRPolicy participating in the DR replication for this DRPC.
+	// Changing it migrates the DRPC to the referenced DRPolicy once the migration is validated, as reported by the
+	// PolicyMigration condition.
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="drPolicyRef is immutable"
 	DRPolicyRef v1.ObjectReference `json:"drPolicyRef"`
 
 	// PreferredCluster is the cluster name that the user preferred to run the application on
@@ -170,18 +219,153 @@ type DRPlacementControlSpec struct {
 	// +kubebuilder:validation:Optional
 	DryRun bool `json:"dryRun,omitempty"`
 
+	// Force starts a Failover or Relocate even if its preflight checks, reported in the PreflightPassed condition,
+	// fail. It applies to the actions started while it is set.
+	// +kubebuilder:validation:Optional
+	Force bool `json:"force,omitempty"`
+
+	// AllowStaleData starts a planned Failover or a Relocate even if the last group sync of the workload is older
+	// than the staleDataThreshold of the DRPolicy. It applies to the actions started while it is set.
+	// +kubebuilder:validation:Optional
+	AllowStaleData bool `json:"allowStaleData,omitempty"`
+
+	// RestoreCaptureGeneration selects the capture generation, recorded with an S3 profile with versioning, whose
+	// metadata a failover or relocate restores, instead of the latest metadata. It should be unset once the action
+	// completes, so that a later action restores the latest metadata.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	RestoreCaptureGeneration int64 `json:"restoreCaptureGeneration,omitempty"`
+
+	// QuiesceStrategy is how the workload is quiesced on the current cluster before the final sync of a relocation.
+	// OverridePodDisruptionBudgets lets the pods protected by strict pod disruption budgets be disrupted, restoring
+	// the budgets once the final sync completes.
+	// +kubebuilder:validation:Optional
+	QuiesceStrategy QuiesceStrategy `json:"quiesceStrategy,omitempty"`
+
 	// +optional
 	KubeObjectProtection *KubeObjectProtectionSpec `json:"kubeObjectProtection,omitempty"`
 
 	// +optional
 	VolSyncSpec *VolSyncSpec `json:"volSyncSpec,omitempty"`
 
+	// TemplateRef is the reference to the DRPlacementControlTemplate providing the defaults of the pvcSelector,
+	// kubeObjectProtection, volSyncSpec and finalizationHooks not set in this DRPC
+	// +optional
+	TemplateRef *v1.ObjectReference `json:"templateRef,omitempty"`
+
 	// RetainNamespaceSCCAcrossPeers controls whether Security Context Constraints (SCC) annotations
 	// should be retained when creating namespaces on secondary clusters during DR enablement.
 	// This flag works in conjunction with the RamenConfig flag of the same name.
 	// Both flags must be true for SCC annotations to be retained.
 	// +optional
 	RetainNamespaceSCCAcrossPeers bool `json:"retainNamespaceSCCAcrossPeers,omitempty"`
+
+	// LocalFailover pre-approves an autonomous failover to a managed cluster, executed by the dr-cluster
+	// operator on that cluster when the hub has been unreachable for longer than the configured threshold.
+	// Intended for edge topologies with intermittent hub connectivity.
+	// +optional
+	LocalFailover *LocalFailoverSpec `json:"localFailover,omitempty"`
+
+	// FailoverDependencies gates failover of this DRPC on other DRPCs having failed over first, and on external
+	// endpoints being healthy, to order recovery of multi-tier applications protected by separate DRPCs.
+	// +optional
+	FailoverDependencies *FailoverDependencies `json:"failoverDependencies,omitempty"`
+
+	// FinalizationHooks are executed on the current primary cluster when the DRPC is deleted, before protection
+	// is removed. Annotate the DRPC with drplacementcontrol.ramendr.openshift.io/skip-finalization-hooks=true to
+	// skip them.
+	// +optional
+	FinalizationHooks *FinalizationHooksSpec `json:"finalizationHooks,omitempty"`
+
+	// Qualification runs a synthetic replication round-trip between the clusters of the DRPolicy when the DRPC is
+	// first created, before the workload is protected, and records the measured latency and throughput in status.
+	// +optional
+	Qualification *QualificationSpec `json:"qualification,omitempty"`
+
+	// AutoFailover opts the DRPC into a failover requested by the hub once the cluster the workload runs on is
+	// unavailable for longer than a grace period. Without it, the failover is to be requested by the user.
+	// +optional
+	AutoFailover *AutoFailoverSpec `json:"autoFailover,omitempty"`
+}
+
+// AutoFailoverSpec configures the failover the hub requests, by setting the action of the DRPC to Failover, once the
+// ManagedCluster the workload runs on is unavailable for longer than GracePeriod. The failover of a workload of a
+// DRPolicy of synchronous replication is requested only if the DRPolicy fences the cluster automatically, or the
+// cluster is fenced already.
+type AutoFailoverSpec struct {
+	// GracePeriod the ManagedCluster is to be unavailable for before the failover is requested, 10m if unset
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+	// FailoverCluster the workload fails over to, the other cluster of the DRPolicy if unset
+	// +optional
+	FailoverCluster string `json:"failoverCluster,omitempty"`
+
+	// RequireAcknowledgment holds the failover, once the grace period elapsed, until the DRPC is annotated with
+	// drplacementcontrol.ramendr.openshift.io/auto-failover-acknowledged set to the name of the unavailable cluster
+	// +optional
+	RequireAcknowledgment bool `json:"requireAcknowledgment,omitempty"`
+}
+
+// QualificationSpec configures the replication round-trip that qualifies the clusters of the DRPolicy. A test
+// object is uploaded to and downloaded from the S3 store of each cluster, the path the cluster data of the workload
+// is replicated through, by the hub.
+type QualificationSpec struct {
+	// SizeBytes of the test object
+	// +kubebuilder:default=1048576
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16777216
+	// +optional
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
+	// Required holds the protection of the workload until the qualification succeeds, retrying it, instead of only
+	// reporting its failure
+	// +optional
+	Required bool `json:"required,omitempty"`
+}
+
+// FailoverDependencies are evaluated by the hub before executing a failover
+type FailoverDependencies struct {
+	// DRPCs that must have failed over to the same failover cluster before this DRPC fails over
+	// +optional
+	DRPCs []DRPCReference `json:"drpcs,omitempty"`
+
+	// Probes are HTTP endpoints that must respond with a 2xx status code before this DRPC fails over
+	// +optional
+	Probes []FailoverProbe `json:"probes,omitempty"`
+}
+
+// DRPCReference refers to a DRPC in the namespace of the referring DRPC
+type DRPCReference struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+}
+
+// FailoverProbe is an HTTP endpoint probed by the hub
+type FailoverProbe struct {
+	// URL of the endpoint to probe with an HTTP GET request. Its host must be allowed by the failoverProbes
+	// configuration of the hub.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// TimeoutSeconds for each probe request
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// LocalFailoverSpec is a pre-approved failover plan that a managed cluster may execute on its own
+type LocalFailoverSpec struct {
+	// FailoverCluster is the cluster that is allowed to promote the workload locally
+	// +kubebuilder:validation:Required
+	FailoverCluster string `json:"failoverCluster"`
+
+	// HubUnreachableThreshold is how long the hub heartbeat, and the heartbeat of the primary VRG, must be stale before
+	// the failover is executed
+	// +kubebuilder:validation:Required
+	HubUnreachableThreshold metav1.Duration `json:"hubUnreachableThreshold"`
 }
 
 // PlacementDecision defines the decision made by controller
@@ -212,6 +396,14 @@ type VRGResourceMeta struct {
 	//+optional
 	ProtectedPVCs []string `json:"protectedpvcs,omitempty"`
 
+	// List of PVCs that match the PVC selector, but are excluded from protection by their annotation
+	//+optional
+	ExcludedPVCs []string `json:"excludedpvcs,omitempty"`
+
+	// List of pod disruption budgets overridden to quiesce the workload for the final sync
+	//+optional
+	OverriddenPDBs []string `json:"overriddenpdbs,omitempty"`
+
 	// List of CGs that are protected by the VRG resource
 	//+optional
 	PVCGroups []Groups `json:"pvcgroups,omitempty"`
@@ -263,6 +455,275 @@ type DRPlacementControlStatus struct {
 	// lastKubeObjectProtectionTime is the time of the most recent successful kube object protection
 	//+optional
 	LastKubeObjectProtectionTime *metav1.Time `json:"lastKubeObjectProtectionTime,omitempty"`
+
+	// readiness is the DR readiness score of the workload, recomputed periodically when continuous
+	// validation is enabled in the ramen config
+	//+optional
+	Readiness *DRReadiness `json:"readiness,omitempty"`
+
+	// actionCheckpoint records the action that was in flight when the operator stopped, to be
+	// resumed by the operator that takes over
+	//+optional
+	ActionCheckpoint *ActionCheckpoint `json:"actionCheckpoint,omitempty"`
+
+	// progress is the progress of the last Deploy, Failover or Relocate of the DRPC, by the progressions it went
+	// through
+	//+optional
+	Progress *ActionProgress `json:"progress,omitempty"`
+
+	// actionHistory records the most recent Deploy, Failover and Relocate actions of the DRPC, the latest last
+	//+optional
+	//+kubebuilder:validation:MaxItems=10
+	ActionHistory []ActionRecord `json:"actionHistory,omitempty"`
+
+	// qualification is the result of the most recent replication round-trip of the qualification of the DRPC
+	//+optional
+	Qualification *QualificationStatus `json:"qualification,omitempty"`
+
+	// s3ProfileMigration is the progress of the migration of the metadata of the DRPC requested with the
+	// s3-profile-migration annotation
+	//+optional
+	S3ProfileMigration *S3ProfileMigrationStatus `json:"s3ProfileMigration,omitempty"`
+
+	// observedDRPolicy is the name of the DRPolicy the DRPC is reconciled with, which differs from its drPolicyRef
+	// while the migration to the DRPolicy of the drPolicyRef is not validated
+	//+optional
+	ObservedDRPolicy string `json:"observedDRPolicy,omitempty"`
+
+	// SelectedFailoverCluster is the cluster the DRPC selected to fail over to, for a Failover requested without
+	// failoverCluster. It is kept while the Failover is requested without failoverCluster.
+	//+optional
+	SelectedFailoverCluster string `json:"selectedFailoverCluster,omitempty"`
+}
+
+// QualificationStatus is the result of a replication round-trip between the clusters of a DRPolicy
+type QualificationStatus struct {
+	// Succeeded is true if the round-trip succeeded with the S3 stores of all clusters
+	Succeeded bool `json:"succeeded"`
+
+	// Time the round-trip completed
+	Time metav1.Time `json:"time"`
+
+	// Clusters are the results of the round-trip with the S3 store of each cluster
+	//+optional
+	Clusters []QualificationResult `json:"clusters,omitempty"`
+}
+
+// QualificationResult is the result of the round-trip of the test object with the S3 store of a cluster
+type QualificationResult struct {
+	// Cluster whose S3 store the test object was replicated through
+	Cluster string `json:"cluster"`
+
+	// S3ProfileName of the S3 store of the cluster
+	S3ProfileName string `json:"s3ProfileName"`
+
+	// UploadLatency is the time taken to upload the test object
+	//+optional
+	UploadLatency metav1.Duration `json:"uploadLatency,omitempty"`
+
+	// DownloadLatency is the time taken to download the test object
+	//+optional
+	DownloadLatency metav1.Duration `json:"downloadLatency,omitempty"`
+
+	// ThroughputBytesPerSecond of the round-trip, the size of the test object transferred twice over the sum of the
+	// upload and download latencies
+	//+optional
+	ThroughputBytesPerSecond int64 `json:"throughputBytesPerSecond,omitempty"`
+
+	// Error of the round-trip, if it failed
+	//+optional
+	Error string `json:"error,omitempty"`
+}
+
+// S3ProfileMigrationStatus is the progress of the migration of the metadata of a DRPC from the S3 profile of a
+// cluster to another S3 profile
+type S3ProfileMigrationStatus struct {
+	// Source is the S3 profile the metadata is copied from
+	Source string `json:"source"`
+
+	// Target is the S3 profile the metadata is copied to, which the VRGs upload to as well as the source while the
+	// metadata is migrated, and which the DRClusters of the source are switched to once every DRPC of them is migrated
+	Target string `json:"target"`
+
+	// DualWrite is true once the secret of the target is deployed to the clusters, for the VRGs to upload to the
+	// target as well as the source
+	//+optional
+	DualWrite bool `json:"dualWrite,omitempty"`
+
+	// DualWriteGeneration is the generation of the primary VRG from which it uploads to the target as well as the
+	// source
+	//+optional
+	DualWriteGeneration int64 `json:"dualWriteGeneration,omitempty"`
+
+	// LastKey is the key of the last object copied to the target and verified there, which an interrupted copy
+	// resumes after
+	//+optional
+	LastKey string `json:"lastKey,omitempty"`
+
+	// ObjectsCopied is the number of objects copied to the target and verified there
+	//+optional
+	ObjectsCopied int `json:"objectsCopied,omitempty"`
+
+	// Verified is true once every object of the source was copied to the target and verified there, while the
+	// primary VRG uploads to both
+	//+optional
+	Verified bool `json:"verified,omitempty"`
+
+	// KubeObjectsCaptured is true once the primary VRG captured the kube objects to the target, or if it does not
+	// protect the kube objects
+	//+optional
+	KubeObjectsCaptured bool `json:"kubeObjectsCaptured,omitempty"`
+
+	// Migrated is true once the metadata is verified and the kube objects are captured on the target
+	Migrated bool `json:"migrated"`
+
+	// Time the migration was last attempted
+	Time metav1.Time `json:"time"`
+
+	// Error of the last attempt, if it failed
+	//+optional
+	Error string `json:"error,omitempty"`
+}
+
+// ActionCheckpoint is a resumable marker of an action that was in flight when the hub operator stopped, for
+// example during its upgrade. It records the state the action reached in the memory of the operator, which the
+// operator had not persisted in the status yet as its reconcile was interrupted. The operator that takes over
+// resumes the action from the checkpoint if the spec did not change since, and clears the checkpoint.
+type ActionCheckpoint struct {
+	// Action that was in flight, Failover or Relocate for a DRPC, Fenced or Unfenced for a DRCluster
+	Action string `json:"action"`
+
+	// TargetCluster of the action of a DRPC
+	//+optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// Generation of the spec the action was reconciling
+	Generation int64 `json:"generation"`
+
+	// Phase the action reached
+	Phase string `json:"phase"`
+
+	// Progression the action of a DRPC reached
+	//+optional
+	Progression string `json:"progression,omitempty"`
+
+	// Time the checkpoint was taken
+	Time metav1.Time `json:"time"`
+}
+
+// ActionProgress is the progress of an action of a DRPC: the progressions it went through, in order, and an
+// estimate of how far along it is
+type ActionProgress struct {
+	// Action is Deploy, Failover or Relocate
+	Action string `json:"action"`
+
+	// Percentage is the estimated completion of the action, from the last of its progressions reached in their usual
+	// order. It is 100 once the action completed.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=100
+	Percentage int32 `json:"percentage"`
+
+	// Phases are the progressions of the action, in the order they started. The last one is in progress, unless the
+	// action completed. The earliest are dropped beyond 32 progressions.
+	//+optional
+	//+kubebuilder:validation:MaxItems=32
+	Phases []ActionProgressPhase `json:"phases,omitempty"`
+}
+
+// ActionProgressPhase is a progression of an action of a DRPC and when it started and ended
+type ActionProgressPhase struct {
+	// Name of the progression
+	Name ProgressionStatus `json:"name"`
+
+	// StartTime is when the progression started
+	StartTime metav1.Time `json:"startTime"`
+
+	// EndTime is when the next progression started, unset while the progression is in progress
+	//+optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// ActionOutcome is the outcome of an action of a DRPC
+// +kubebuilder:validation:Enum=InProgress;Succeeded;Superseded
+type ActionOutcome string
+
+const (
+	// ActionOutcomeInProgress is the outcome of the action in progress
+	ActionOutcomeInProgress = ActionOutcome("InProgress")
+
+	// ActionOutcomeSucceeded is the outcome of an action that completed
+	ActionOutcomeSucceeded = ActionOutcome("Succeeded")
+
+	// ActionOutcomeSuperseded is the outcome of an action that was replaced by another before it completed
+	ActionOutcomeSuperseded = ActionOutcome("Superseded")
+)
+
+// ActionRecord is the record of an action of a DRPC in its action history
+type ActionRecord struct {
+	// Action is Deploy, Failover or Relocate
+	Action string `json:"action"`
+
+	// RequestedBy is who requested the action: the field manager that last set the action of the DRPC, or the value
+	// of the drplacementcontrol.ramendr.openshift.io/action-requested-by annotation of the DRPC if it set it too
+	//+optional
+	RequestedBy string `json:"requestedBy,omitempty"`
+
+	// RequestTime is when the action was requested, when the field manager last set the action of the DRPC, or
+	// otherwise when the action started
+	RequestTime metav1.Time `json:"requestTime"`
+
+	// StartTime is when the action started
+	StartTime metav1.Time `json:"startTime"`
+
+	// SourceCluster is the cluster the workload ran on before the action
+	//+optional
+	SourceCluster string `json:"sourceCluster,omitempty"`
+
+	// TargetCluster is the cluster the action moves or deploys the workload to
+	//+optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// Duration of the action, once it completed
+	//+optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Outcome of the action
+	Outcome ActionOutcome `json:"outcome"`
+}
+
+// Names of the components of the DR readiness score
+const (
+	ReadinessComponentS3MetadataFresh = "S3MetadataFresh"
+	ReadinessComponentPeerHealth      = "PeerHealth"
+	ReadinessComponentClassMatching   = "ClassMatching"
+	ReadinessComponentFencing         = "FencingReadiness"
+	ReadinessComponentAgentHealth     = "AgentHealth"
+)
+
+// DRReadiness is the readiness of a workload to failover or relocate
+type DRReadiness struct {
+	// Score is the percentage, from 0 to 100, of the components that are ready
+	Score int32 `json:"score"`
+
+	// Components is the breakdown of the score
+	//+optional
+	Components []DRReadinessComponent `json:"components,omitempty"`
+
+	// LastEvaluationTime is when the score was last computed
+	LastEvaluationTime metav1.Time `json:"lastEvaluationTime"`
+}
+
+// DRReadinessComponent is the result of one of the checks of the DR readiness score
+type DRReadinessComponent struct {
+	// Name of the check
+	Name string `json:"name"`
+
+	// Ready is true if the check passed
+	Ready bool `json:"ready"`
+
+	// Message describes the result of the check
+	//+optional
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DRPlacementControlActionSpec requests a Failover or Relocate of a DRPlacementControl of the same namespace. Creating
+// an action requires permissions on drplacementcontrolactions only, so that users can be granted to trigger DR actions
+// without being granted to change the protection settings of the DRPlacementControl.
+// +kubebuilder:validation:XValidation:rule="self.action != 'Failover' || has(self.failoverCluster)", message="failoverCluster is required to Failover"
+// +kubebuilder:validation:XValidation:rule="self.action != 'Relocate' || has(self.preferredCluster)", message="preferredCluster is required to Relocate"
+// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="spec is immutable"
+type DRPlacementControlActionSpec struct {
+	// DRPCName is the name of the DRPlacementControl to act on
+	// +kubebuilder:validation:Required
+	DRPCName string `json:"drpcName"`
+
+	// Action is either Failover or Relocate operation
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Failover;Relocate
+	Action DRAction `json:"action"`
+
+	// FailoverCluster is the cluster name to failover the application to
+	// +optional
+	FailoverCluster string `json:"failoverCluster,omitempty"`
+
+	// PreferredCluster is the cluster name to relocate the application to
+	// +optional
+	PreferredCluster string `json:"preferredCluster,omitempty"`
+
+	// Force sets force in the spec of the DRPlacementControl, to start the action even if its preflight checks fail
+	// +optional
+	Force bool `json:"force,omitempty"`
+}
+
+// DRPlacementControlActionPhase is the outcome of a DRPlacementControlAction
+// +kubebuilder:validation:Enum=Applied;Rejected
+type DRPlacementControlActionPhase string
+
+// Valid values for DRPlacementControlActionPhase
+const (
+	// DRPlacementControlActionApplied is set once the action is set in the spec of the DRPlacementControl, whose
+	// status reports its progress
+	DRPlacementControlActionApplied = DRPlacementControlActionPhase("Applied")
+
+	// DRPlacementControlActionRejected is set if the action cannot be set in the spec of the DRPlacementControl
+	DRPlacementControlActionRejected = DRPlacementControlActionPhase("Rejected")
+)
+
+// DRPlacementControlActionStatus defines the observed state of DRPlacementControlAction
+type DRPlacementControlActionStatus struct {
+	// Phase is Applied or Rejected once the action is processed, and empty until then
+	// +optional
+	Phase DRPlacementControlActionPhase `json:"phase,omitempty"`
+
+	// Message details the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=drpcaction
+// +kubebuilder:printcolumn:JSONPath=".spec.drpcName",name=drpc,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.action",name=action,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name=phase,type=string
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name=Age,type=date
+
+// DRPlacementControlAction is the Schema for the drplacementcontrolactions API
+type DRPlacementControlAction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DRPlacementControlActionSpec   `json:"spec,omitempty"`
+	Status DRPlacementControlActionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DRPlacementControlActionList contains a list of DRPlacementControlAction
+type DRPlacementControlActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DRPlacementControlAction `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DRPlacementControlAction{}, &DRPlacementControlActionList{})
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DRPlacementControlTemplateSpec defines the protection settings that DRPlacementControls referencing the template
+// default to. Settings of a DRPlacementControl override those of its template, and changes to the template apply to
+// the DRPlacementControls referencing it, except for pvcSelector which applies to DRPlacementControls not yet protecting
+// their workload.
+type DRPlacementControlTemplateSpec struct {
+	// Label selector to identify all the PVCs that need DR protection, used by DRPlacementControls with an empty
+	// pvcSelector
+	// +optional
+	PVCSelector *metav1.LabelSelector `json:"pvcSelector,omitempty"`
+
+	// KubeObjectProtection settings, each used by DRPlacementControls that do not set it
+	// +optional
+	KubeObjectProtection *KubeObjectProtectionSpec `json:"kubeObjectProtection,omitempty"`
+
+	// VolSyncSpec used by DRPlacementControls without a volSyncSpec
+	// +optional
+	VolSyncSpec *VolSyncSpec `json:"volSyncSpec,omitempty"`
+
+	// FinalizationHooks used by DRPlacementControls without finalizationHooks
+	// +optional
+	FinalizationHooks *FinalizationHooksSpec `json:"finalizationHooks,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=drpctemplate
+
+// DRPlacementControlTemplate is the Schema for the drplacementcontroltemplates API
+type DRPlacementControlTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DRPlacementControlTemplateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DRPlacementControlTemplateList contains a list of DRPlacementControlTemplate
+type DRPlacementControlTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DRPlacementControlTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DRPlacementControlTemplate{}, &DRPlacementControlTemplateList{})
+}
@@ -10,13 +10,16 @@ import (
 // DRPolicySpec defines the desired state of DRPolicy
 // +kubebuilder:validation:XValidation:rule="has(oldSelf.replicationClassSelector) == has(self.replicationClassSelector)", message="replicationClassSelector is immutable"
 // +kubebuilder:validation:XValidation:rule="has(oldSelf.volumeSnapshotClassSelector) == has(self.volumeSnapshotClassSelector)", message="volumeSnapshotClassSelector is immutable"
+// +kubebuilder:validation:XValidation:rule="self.drClusters == oldSelf.drClusters || (has(self.drClusterRename) && size(self.drClusters) == size(oldSelf.drClusters) && self.drClusterRename.oldName in oldSelf.drClusters && !(self.drClusterRename.oldName in self.drClusters) && !(self.drClusterRename.newName in oldSelf.drClusters) && self.drClusters.all(c, c in oldSelf.drClusters || c == self.drClusterRename.newName))", message="drClusters is immutable, other than to rename the DRCluster of drClusterRename"
 type DRPolicySpec struct {
 	// scheduling Interval for replicating Persistent Volume
 	// data to a peer cluster. Interval is typically in the
 	// form <num><m,h,d>. Here <num> is a number, 'm' means
 	// minutes, 'h' means hours and 'd' stands for days.
+	// Alternatively, a standard 5-field cron expression, e.g.
+	// "0 */4 * * 1-5", replicates at the times it matches.
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Pattern=`^(|\d+[mhd])$`
+	// +kubebuilder:validation:Pattern=`^(|\d+[mhd]|\S+( \S+){4})$`
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="schedulingInterval is immutable"
 	SchedulingInterval string `json:"schedulingInterval"`
 
@@ -29,6 +32,13 @@ type DRPolicySpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="replicationClassSelector is immutable"
 	ReplicationClassSelector metav1.LabelSelector `json:"replicationClassSelector"`
 
+	// ReplicationClassNames are the names of the VolumeReplicationClasses and VolumeGroupReplicationClasses, of any
+	// of the clusters of the policy, the workloads of the policy are to be replicated with. Of the classes of a cluster
+	// that match the provisioner and the schedulingInterval of a PVC, the one named is selected, rather than the one
+	// annotated as the default. It is passed in to the VRG.
+	//+optional
+	ReplicationClassNames []string `json:"replicationClassNames,omitempty"`
+
 	// Label selector to identify all the VolumeSnapshotClasses.
 	// This selector is assumed to be the same for all subscriptions that
 	// need DR protection. It will be passed in to the VRG when it is created
@@ -44,11 +54,161 @@ type DRPolicySpec struct {
 	//+optional
 	VolumeGroupSnapshotClassSelector metav1.LabelSelector `json:"volumeGroupSnapshotClassSelector,omitempty"`
 
-	// List of DRCluster resources that are governed by this policy
+	// List of the 2 DRCluster resources that are governed by this policy. A workload is protected on one of the
+	// clusters towards the other. It is immutable, other than to rename the DRCluster of drClusterRename, see
+	// DRClusterMigration.
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:items:MaxLength=253
 	// +kubebuilder:validation:XValidation:rule="size(self) == 2", message="drClusters requires a list of 2 clusters"
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="drClusters is immutable"
 	DRClusters []string `json:"drClusters"`
+
+	// DRClusterRename is the rename of one of the drClusters, which the DRClusterMigration renaming the DRCluster
+	// sets in the same update as it renames it in drClusters. drClusters cannot change otherwise.
+	//+optional
+	DRClusterRename *DRClusterRename `json:"drClusterRename,omitempty"`
+
+	// TopologyMappings map the topology, such as the zones and regions, of the nodes of one cluster of the policy to
+	// that of the other. They are passed in to the VRG, to rewrite the node affinity of the PVs it restores.
+	//+optional
+	TopologyMappings []TopologyMapping `json:"topologyMappings,omitempty"`
+
+	// S3UploadPolicy is to how many of the S3 profiles of the clusters of the policy the metadata of a workload is
+	// to be uploaded for it to be protected: All of them, the default, or a Quorum of them. It is passed in to the
+	// VRG, which uploads to the profiles concurrently.
+	//+optional
+	S3UploadPolicy S3UploadPolicy `json:"s3UploadPolicy,omitempty"`
+
+	// RPOTarget is the recovery point objective of the workloads of the policy: the age the last group sync of a
+	// workload is not to exceed. A DRPC whose lastGroupSyncTime is older reports its RPOViolated condition true.
+	//+optional
+	//+kubebuilder:validation:Format=duration
+	RPOTarget *metav1.Duration `json:"rpoTarget,omitempty"`
+
+	// StaleDataThreshold is the age of the last group sync of a workload of the policy beyond which a planned
+	// Failover, while the cluster of the workload is available, or a Relocate of it is refused, unless its DRPC sets
+	// allowStaleData. A Failover from an unavailable cluster is not refused.
+	//+optional
+	//+kubebuilder:validation:Format=duration
+	StaleDataThreshold *metav1.Duration `json:"staleDataThreshold,omitempty"`
+
+	// BackupWindows are the daily windows of time the storage of the clusters of the policy backs up the volumes it
+	// replicates in. Kube object captures and final syncs of the workloads of the policy due in a window are delayed
+	// until it closes, not to contend with the backups. Each window is to leave a schedulingInterval of the day
+	// outside of the windows. They are passed in to the VRG.
+	//+optional
+	BackupWindows []BackupWindow `json:"backupWindows,omitempty"`
+
+	// StorageClassSchedulingIntervals override the schedulingInterval of the PVCs whose StorageClass matches their
+	// selectors, for storage backends to replicate at a cadence of their own. The first one to match a StorageClass
+	// applies. They are passed in to the VRG, and their intervals are added to the replication schedules of the
+	// clusters.
+	//+optional
+	StorageClassSchedulingIntervals []StorageClassSchedulingInterval `json:"storageClassSchedulingIntervals,omitempty"`
+
+	// VolSync tunes the replication of the PVCs of the policy that are replicated by VolSync, for storage teams to
+	// tune the replication of a policy rather than the VolSync objects on the clusters. It is passed in to the VRG.
+	//+optional
+	VolSync *VolSyncTuning `json:"volSync,omitempty"`
+
+	// Fencing is whether a failover of a workload of the policy fences the cluster it fails over from: Automatic
+	// fences it, by setting the clusterFence of its DRCluster to Fenced, and waits for it to be fenced, Manual waits
+	// for it to be fenced out of band, and Disabled does not wait for it to be fenced. It is Manual for a policy of
+	// synchronous replication, and Disabled for one of asynchronous replication, if unset.
+	//+optional
+	Fencing FencingPreference `json:"fencing,omitempty"`
+
+	// HubProtection, if set, protects the hub resources of the workloads of the policy to the S3 profiles of its
+	// clusters on the schedulingInterval of the policy, or every 5m for a policy of synchronous replication, for the
+	// hub to be recovered from them
+	//+optional
+	HubProtection *HubProtection `json:"hubProtection,omitempty"`
+}
+
+// DRClusterRename is the rename of a DRCluster of a DRPolicy
+type DRClusterRename struct {
+	// OldName of the DRCluster
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	OldName string `json:"oldName"`
+
+	// NewName of the DRCluster
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	NewName string `json:"newName"`
+}
+
+// HubProtection selects the hub resources of the workloads of a DRPolicy that are protected in addition to their
+// DRPCs, and the Placements and PlacementRules of the DRPCs
+type HubProtection struct {
+	// SecretSelector selects the Secrets of the namespaces of the DRPCs that are protected, such as the Secrets of
+	// their channels. None are if unset. The data of the Secrets is uploaded to the S3 profiles as is, unless they
+	// encrypt the objects.
+	//+optional
+	SecretSelector *metav1.LabelSelector `json:"secretSelector,omitempty"`
+}
+
+// FencingPreference is whether a failover fences the cluster it fails over from
+// +kubebuilder:validation:Enum=Automatic;Manual;Disabled
+type FencingPreference string
+
+// These are the valid values for FencingPreference
+const (
+	// Automatic, the failover requests the cluster to be fenced, and waits for it to be fenced
+	FencingPreferenceAutomatic = FencingPreference("Automatic")
+
+	// Manual, the failover waits for the cluster to be fenced out of band
+	FencingPreferenceManual = FencingPreference("Manual")
+
+	// Disabled, the failover does not wait for the cluster to be fenced
+	FencingPreferenceDisabled = FencingPreference("Disabled")
+)
+
+// StorageClassSchedulingInterval is the scheduling interval of the PVCs of the StorageClasses its selector matches
+type StorageClassSchedulingInterval struct {
+	// StorageClassSelector selects the StorageClasses by their labels
+	// +kubebuilder:validation:Required
+	StorageClassSelector metav1.LabelSelector `json:"storageClassSelector"`
+
+	// SchedulingInterval of the PVCs of the StorageClasses selected, in the form <num><m,h,d>, or a standard 5-field
+	// cron expression
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^(\d+[mhd]|\S+( \S+){4})$`
+	SchedulingInterval string `json:"schedulingInterval"`
+}
+
+// BackupWindow is a daily window of time
+type BackupWindow struct {
+	// Start of the window, as hh:mm in UTC
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01]\d|2[0-3]):[0-5]\d$`
+	Start string `json:"start"`
+
+	// Duration of the window
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Format=duration
+	Duration metav1.Duration `json:"duration"`
+}
+
+// TopologyMapping maps the value of a topology label of the nodes of one cluster, such as its zone, to the value of
+// the label on the nodes of another cluster. A PV restored to a cluster has the values of its node affinity for the
+// label rewritten from those of the cluster it was protected on; the mappings of both directions are to be listed
+// for the PVs to be restored either way.
+type TopologyMapping struct {
+	// Key of the topology label, such as topology.kubernetes.io/zone or topology.kubernetes.io/region
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// From is the value of the label on the nodes of the cluster the PV was protected on
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+
+	// To is the value of the label on the nodes of the cluster the PV is restored to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
 }
 
 // DRPolicyStatus defines the observed state of DRPolicy
@@ -64,6 +224,42 @@ type DRPolicyStatus struct {
 	// sync replication details between the clusters in the policy
 	//+optional
 	Sync Sync `json:"sync,omitempty"`
+
+	// Workloads is the aggregate status of the workloads protected by the policy, that is of the DRPCs that refer
+	// to it, refreshed periodically
+	//+optional
+	Workloads *DRPolicyWorkloadsStatus `json:"workloads,omitempty"`
+
+	// HubProtection is the status of the protection of the hub resources of the workloads of the policy
+	//+optional
+	HubProtection *HubProtectionStatus `json:"hubProtection,omitempty"`
+}
+
+// HubProtectionStatus is the status of the last protection of the hub resources of the workloads of a DRPolicy
+type HubProtectionStatus struct {
+	// LastProtectionTime is the time the hub resources were last uploaded to all the S3 profiles of the policy
+	LastProtectionTime metav1.Time `json:"lastProtectionTime"`
+
+	// Resources is the number of hub resources last uploaded
+	Resources int `json:"resources"`
+}
+
+// DRPolicyWorkloadsStatus is the aggregate status of the DRPCs that refer to a DRPolicy
+type DRPolicyWorkloadsStatus struct {
+	// Bound is the number of DRPCs that refer to the policy
+	Bound int `json:"bound"`
+
+	// Healthy is the number of the bound DRPCs whose workload is protected, as reported by their Protected condition
+	Healthy int `json:"healthy"`
+
+	// OldestLastGroupSyncTime is the oldest lastGroupSyncTime reported by the bound DRPCs, which bounds the data
+	// loss of a failover of all the workloads of the policy
+	//+optional
+	OldestLastGroupSyncTime *metav1.Time `json:"oldestLastGroupSyncTime,omitempty"`
+
+	// RPOViolated is the number of the bound DRPCs whose lastGroupSyncTime is older than the RPO target of the policy
+	//+optional
+	RPOViolated int `json:"rpoViolated,omitempty"`
 }
 
 // for RDR
@@ -133,6 +329,22 @@ type PeerClass struct {
 
 const (
 	DRPolicyValidated string = `Validated`
+
+	// S3 profiles of all the clusters of the policy passed the last periodic health probe
+	DRPolicyConditionTypeS3ProfilesAvailable = "S3ProfilesAvailable"
+
+	// All the DRPCs that refer to the policy are protected
+	DRPolicyConditionTypeReplicationHealthy = "ReplicationHealthy"
+
+	// The mirroring daemons of all the clusters of the policy that report them are connected to their peers
+	DRPolicyConditionTypePeerConnected = "PeerConnected"
+
+	// The hub resources of the workloads of the policy were last uploaded to all the S3 profiles of the policy
+	DRPolicyConditionTypeHubProtected = "HubProtected"
+
+	// The policy replicates between the same clusters as an older policy, with an incompatible scheduling interval or
+	// class selection
+	DRPolicyConditionTypeConflicting = "Conflicting"
 )
 
 // +kubebuilder:object:root=true
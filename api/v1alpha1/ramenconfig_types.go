@@ -5,6 +5,7 @@ package v1alpha1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
@@ -42,6 +43,13 @@ type S3StoreProfile struct {
 	// Name of this S3 profile
 	S3ProfileName string `json:"s3ProfileName"`
 
+	// Type of the object store of this profile, S3 if unset. For an AzureBlob profile, s3CompatibleEndpoint is the
+	// blob service endpoint of the storage account, s3Bucket is the name of its container, s3Region is unused, and
+	// s3SecretRef references a secret with the storage account name and key with the keys AZURE_STORAGE_ACCOUNT_NAME
+	// and AZURE_STORAGE_ACCOUNT_KEY respectively. Kube objects are protected with S3 profiles only.
+	//+optional
+	Type ObjectStoreType `json:"type,omitempty"`
+
 	// Name of the S3 bucket to protect and recover PV related cluster-data of
 	// subscriptions protected by this DR policy.  This S3 bucket name is used
 	// across all DR policies that use this S3 profile. Objects deposited in
@@ -59,7 +67,9 @@ type S3StoreProfile struct {
 
 	// S3CompatibleEndpoint is the object store endpoint for this S3 profile.
 	// If the scheme is not specified, "https://" is assumed. Using "http://"
-	// is insecure and should be used for testing purposes only.
+	// is insecure and should be used for testing purposes only. The host may
+	// be a DNS name, an IPv4 address, or an IPv6 address enclosed in brackets,
+	// optionally followed by a port, like "https://[fd00::10]:9000".
 	S3CompatibleEndpoint string `json:"s3CompatibleEndpoint"`
 
 	// S3 Region; the AWS go client SDK does not have a default region; hence,
@@ -69,15 +79,217 @@ type S3StoreProfile struct {
 
 	// Reference to the secret that contains the S3 access key id and s3 secret
 	// access key with the keys AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
-	// respectively.
+	// respectively. The secret is in the operator namespace if its namespace is
+	// unset, and may be in one of the s3SecretNamespaces otherwise.
 	S3SecretRef v1.SecretReference `json:"s3SecretRef"`
+
+	// S3SecretImpersonation is the service account that the hub operator impersonates to read the secret of
+	// s3SecretRef, so that the owner of the credentials controls access to them by granting the service account
+	// read access to the secret. It is to be in the operator namespace or one of the S3 secret namespaces, and the
+	// hub operator is to be granted the impersonation of it in its namespace.
+	//+optional
+	S3SecretImpersonation *ServiceAccountReference `json:"s3SecretImpersonation,omitempty"`
 	//+optional
 	VeleroNamespaceSecretKeyRef *v1.SecretKeySelector `json:"veleroNamespaceSecretKeyRef,omitempty"`
-	// A CA bundle to use when verifying TLS connections to the provider
+	// A CA bundle to use when verifying TLS connections to the provider, in addition to the system CAs
 	//+optional
 	CACertificates []byte `json:"caCertificates,omitempty"`
+
+	// TLSClientCertificate authenticates the operators to the object store with the client certificate and private
+	// key of the keys tls.crt and tls.key of the secret of s3SecretRef, for object stores requiring mutual TLS. The
+	// keys are propagated to the managed clusters along with the credentials. Velero does not present the client
+	// certificate.
+	//+optional
+	TLSClientCertificate bool `json:"tlsClientCertificate,omitempty"`
+
+	// IPFamily the operators connect to the object store with, IPv4 or IPv6, for clusters whose network policies
+	// allow the egress of one family only. If unset, the addresses of either family are tried, in the order of the
+	// resolver. Velero connects with the addresses of either family.
+	//+optional
+	IPFamily v1.IPFamily `json:"ipFamily,omitempty"`
+
+	// Compression of the objects the operators upload with this profile, like the VRG metadata and the PV cluster
+	// data, gzip with its default level if unset. Objects are decompressed on download whether they are compressed
+	// or not, hence the compression can be changed at any time.
+	//+optional
+	Compression *ObjectCompression `json:"compression,omitempty"`
+
+	// Proxy, if set, is the HTTP proxy that the operators connect to the object store through, instead of the proxy
+	// of the HTTPS_PROXY and HTTP_PROXY environment variables of their pods, if any. Velero connects through the
+	// proxy of its own environment.
+	//+optional
+	Proxy *ObjectStoreProxy `json:"proxy,omitempty"`
+
+	// ClientSideEncryption, if set, encrypts the objects the operators upload with this profile, like the VRG
+	// metadata, before they leave the cluster. Objects uploaded before it was set remain readable. Kube objects
+	// captured by Velero cannot be encrypted by the operators, so a profile with it is rejected unless
+	// kubeObjectProtection is disabled.
+	//+optional
+	ClientSideEncryption *ClientSideEncryption `json:"clientSideEncryption,omitempty"`
+
+	// S3WebIdentity, if set, authenticates to the S3 store with temporary credentials of an IAM role assumed with
+	// the web identity token of the operator service account, like with IAM roles for service accounts (IRSA),
+	// instead of the access keys of s3SecretRef. The credentials are refreshed before they expire, including during
+	// long-lived uploads. Velero authenticates with the credentials of its own service account then, unless
+	// veleroNamespaceSecretKeyRef is set.
+	//+optional
+	S3WebIdentity *S3WebIdentity `json:"s3WebIdentity,omitempty"`
+
+	// MultipartUpload configures the upload in parts of the objects larger than a part, like the kube object
+	// captures of large workloads, to S3 object stores. Each part is retried on its own, and an upload that fails
+	// anyway is resumed by the next upload of the object, so that the parts already uploaded are not uploaded again.
+	//+optional
+	MultipartUpload *MultipartUpload `json:"multipartUpload,omitempty"`
+
+	// KeyPrefix, if set, lays out the objects the operators upload with this profile, and the kube object captures
+	// of Velero, under a key prefix of the organization, so that a bucket shared by organizations enforces their
+	// layout and the IAM policies granting access by key prefix. The objects uploaded before it was set remain
+	// under their keys; they are read, and moved under the key prefix, only if migrateLegacyObjects is set.
+	//+optional
+	KeyPrefix *S3KeyPrefix `json:"keyPrefix,omitempty"`
+
+	// Versioning, for a bucket with object versioning enabled, records the version IDs of the objects of each VRG
+	// in capture generations, so that a failover or relocate can restore the metadata of a previous generation
+	// when the latest one is corrupt
+	//+optional
+	Versioning *S3Versioning `json:"versioning,omitempty"`
+}
+
+// S3Versioning is the recording of the capture generations of the objects of an S3StoreProfile
+type S3Versioning struct {
+	// Interval between capture generations, 1h if unset. A generation is recorded only if objects changed since
+	// the previous one.
+	//+optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Generations is the number of capture generations retained, 24 if unset. The lifecycle rules of the bucket
+	// should retain the noncurrent versions of the objects for at least as long.
+	//+optional
+	Generations int `json:"generations,omitempty"`
+}
+
+// S3KeyPrefix is the key prefix of the objects of an S3StoreProfile
+type S3KeyPrefix struct {
+	// Template of the key prefix, like "{org}/{env}/{cluster}/", whose placeholders {org}, {env} and {cluster} are
+	// replaced with organization, environment and cluster respectively. It ends with a slash, and neither starts
+	// with one nor has empty segments.
+	Template string `json:"template"`
+
+	// Organization replaces the {org} placeholder of the template
+	//+optional
+	Organization string `json:"organization,omitempty"`
+
+	// Environment replaces the {env} placeholder of the template
+	//+optional
+	Environment string `json:"environment,omitempty"`
+
+	// Cluster replaces the {cluster} placeholder of the template. Every cluster reads the objects of the profile
+	// under the same key prefix, hence it names the cluster the profile is of, rather than the cluster reading it.
+	//+optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// MigrateLegacyObjects reads the objects uploaded before the key prefix was set, under their keys without it,
+	// if they are not under the key prefix, and moves them under the key prefix as they are read. Listing and
+	// deleting objects include the legacy objects too.
+	//+optional
+	MigrateLegacyObjects bool `json:"migrateLegacyObjects,omitempty"`
+}
+
+// MultipartUpload is the upload in parts of the large objects uploaded with an S3StoreProfile
+type MultipartUpload struct {
+	// PartSize is the size of the parts, from 5Mi to 5Gi, 5Mi if unset. Objects no larger than a part are uploaded
+	// whole.
+	//+optional
+	PartSize *resource.Quantity `json:"partSize,omitempty"`
+
+	// PartRetries is the number of times a part that fails to upload is retried, with an exponential backoff, before
+	// the upload fails, 3 if unset
+	//+optional
+	PartRetries *int `json:"partRetries,omitempty"`
+}
+
+// S3WebIdentity is an IAM role assumed with a web identity token to access the S3 store of an S3StoreProfile
+type S3WebIdentity struct {
+	// RoleARN is the ARN of the IAM role to assume
+	RoleARN string `json:"roleARN"`
+
+	// TokenFile is the path of the projected service account token in the operator pod,
+	// /var/run/secrets/eks.amazonaws.com/serviceaccount/token if unset
+	//+optional
+	TokenFile string `json:"tokenFile,omitempty"`
+
+	// RoleSessionName identifies the sessions of the assumed role, the profile name if unset
+	//+optional
+	RoleSessionName string `json:"roleSessionName,omitempty"`
+}
+
+// ClientSideEncryption selects the key encryption key that wraps the data keys of the objects encrypted with an
+// S3StoreProfile. Each object is encrypted with its own data key.
+type ClientSideEncryption struct {
+	// KeyProvider of the key encryption key, Secret if unset, or the name of another key provider, like an external
+	// KMS, registered with the operator. The Secret key provider reads a base64 encoded 256-bit key with the key
+	// RAMEN_ENCRYPTION_KEY from the secret referenced by keySecretRef.
+	//+optional
+	KeyProvider string `json:"keyProvider,omitempty"`
+
+	// KeySecretRef references the secret with the key encryption key of the Secret key provider, which is required
+	// to be another secret than the one with the credentials of the profile, so that a leak of either does not expose
+	// the other. It is propagated to the managed clusters like the secret of the credentials.
+	//+optional
+	KeySecretRef *v1.SecretReference `json:"keySecretRef,omitempty"`
+
+	// KeyID identifies the key encryption key to the key provider, and is recorded with each encrypted object
+	//+optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// AllowUnencryptedObjects downloads the objects that are not encrypted as is, so that the objects uploaded
+	// before the encryption was enabled remain readable while they are uploaded again encrypted. Objects that are not
+	// encrypted are rejected otherwise, so that they cannot be substituted for encrypted ones.
+	//+optional
+	AllowUnencryptedObjects bool `json:"allowUnencryptedObjects,omitempty"`
+}
+
+// ObjectCompression is the compression of the objects uploaded with an S3StoreProfile
+type ObjectCompression struct {
+	// Algorithm of the compression, Gzip if unset, or None to upload the objects uncompressed
+	//+optional
+	Algorithm ObjectCompressionAlgorithm `json:"algorithm,omitempty"`
+
+	// Level of the compression, from 1, the fastest, to 9, the smallest, the default level of the algorithm if unset
+	//+optional
+	Level int `json:"level,omitempty"`
+}
+
+// ObjectCompressionAlgorithm is an algorithm of an ObjectCompression
+type ObjectCompressionAlgorithm string
+
+const (
+	ObjectCompressionGzip ObjectCompressionAlgorithm = "Gzip"
+	ObjectCompressionNone ObjectCompressionAlgorithm = "None"
+)
+
+// ObjectStoreProxy is an HTTP proxy to connect to the object store of an S3StoreProfile through
+type ObjectStoreProxy struct {
+	// URL of the proxy, like http://proxy.example.com:3128, with the credentials of the proxy, if any, as its user
+	// info
+	URL string `json:"url"`
+}
+
+// ServiceAccountReference refers to a service account
+type ServiceAccountReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
 }
 
+// ObjectStoreType is the type of the object store of an S3StoreProfile, S3, AzureBlob or the type of another object
+// store backend registered with the operator
+type ObjectStoreType string
+
+const (
+	ObjectStoreTypeS3        ObjectStoreType = "S3"
+	ObjectStoreTypeAzureBlob ObjectStoreType = "AzureBlob"
+)
+
 // ControllerMetrics defines the controller metrics configuration
 type ControllerMetrics struct {
 	// BindAddress is the TCP address that the controller should bind to
@@ -128,6 +340,12 @@ type RamenConfig struct {
 	// Map of S3 store profiles
 	S3StoreProfiles []S3StoreProfile `json:"s3StoreProfiles,omitempty"`
 
+	// S3SecretNamespaces are the namespaces, besides the operator namespace, that the secrets of the S3 store
+	// profiles may be in, so that the credentials can be owned by other teams than the DR admins. The secrets are
+	// propagated to the operator namespace of the managed clusters.
+	//+optional
+	S3SecretNamespaces []string `json:"s3SecretNamespaces,omitempty"`
+
 	// MaxConcurrentReconciles is the maximum number of concurrent Reconciles which can be run.
 	// Defaults to 1.
 	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
@@ -173,6 +391,11 @@ type RamenConfig struct {
 		// from source to destination. Should be Snapshot/Direct
 		// default: Snapshot
 		DestinationCopyMethod string `json:"destinationCopyMethod,omitempty"`
+
+		// ReplicationNetworkPolicy delivers to the managed clusters a NetworkPolicy per workload that allows
+		// ingress to its VolSync movers only from the CIDRs of the peer DRClusters, on the rsync-tls port. With
+		// Submariner, the CIDRs of the peers must include the addresses their replication traffic egresses from.
+		ReplicationNetworkPolicy bool `json:"replicationNetworkPolicy,omitempty"`
 	} `json:"volSync,omitempty"`
 
 	KubeObjectProtection struct {
@@ -198,6 +421,288 @@ type RamenConfig struct {
 	// should be retained when creating namespaces on secondary clusters during DR enablement.
 	// +optional
 	RetainNamespaceSCCAcrossPeers bool `json:"retainNamespaceSCCAcrossPeers,omitempty"`
+
+	// MetadataPropagation selects the labels and annotations of DRPCs and DRClusters that are
+	// copied onto the ManifestWorks generated for them, and onto the resources they deliver
+	// to the managed clusters.
+	// +optional
+	MetadataPropagation MetadataPropagation `json:"metadataPropagation,omitempty"`
+
+	// DrClusterManifests are additional objects deployed to every managed cluster using the
+	// drcluster ManifestWork. They cannot override the objects that Ramen deploys: a template
+	// that renders an object with the same kind, namespace and name as one of those is rejected.
+	// +optional
+	DrClusterManifests []ManifestTemplate `json:"drClusterManifests,omitempty"`
+
+	// ManagedClusterStatusSource selects how the hub reads the NetworkFences and VRGs on the
+	// managed clusters. ManagedClusterView, the default, creates a ManagedClusterView for each.
+	// ManifestWorkFeedback reads their status from the status feedback of the ManifestWorks that
+	// deliver them, and requires the RawFeedbackJsonString feature gate of the work agent. Objects
+	// whose status feedback is incomplete, for example as it exceeds the feedback size limit, are
+	// read using ManagedClusterViews. ClusterProxy reads them, and the DRClusterConfigs, classes,
+	// namespaces and recipes on the managed clusters, directly through the OCM cluster-proxy addon,
+	// configured by ClusterProxy.
+	// +optional
+	ManagedClusterStatusSource ManagedClusterStatusSource `json:"managedClusterStatusSource,omitempty"`
+
+	// ClusterProxy configures the access to the managed clusters through the OCM cluster-proxy addon
+	// +optional
+	ClusterProxy ClusterProxyConfig `json:"clusterProxy,omitempty"`
+
+	// ContinuousValidation periodically recomputes the DR readiness score of each DRPC, reported in
+	// its status and in the dr_readiness_score metric
+	// +optional
+	ContinuousValidation ContinuousValidation `json:"continuousValidation,omitempty"`
+
+	// ManagedClusterViews configures the ManagedClusterViews the hub creates to read objects on
+	// the managed clusters, trading the freshness of their status for the load on the managed clusters
+	// +optional
+	ManagedClusterViews ManagedClusterViewsConfig `json:"managedClusterViews,omitempty"`
+
+	// APIClient is the client-side budget of the requests of ramen to the apiserver. The calls of each
+	// controller are counted in the api_client_calls_total metric.
+	// +optional
+	APIClient APIClientConfig `json:"apiClient,omitempty"`
+
+	// DRPCFairQueuing schedules the reconciles of the DRPCs in turn across tenants, so that a tenant with many DRPCs
+	// does not starve the others under load. The backlog of each tenant is reported in the drpc_queue_depth metric.
+	// +optional
+	DRPCFairQueuing DRPCFairQueuing `json:"drpcFairQueuing,omitempty"`
+
+	// S3ProfileHealthCheck periodically probes the S3 profiles, so that an object store outage is found before a
+	// failover needs the metadata. The health of the profiles is reported in the S3ProfileAvailable condition of
+	// the DRClusters, the S3ProfilesAvailable condition of the DRPolicies, and the s3_profile_available metric.
+	// +optional
+	S3ProfileHealthCheck S3ProfileHealthCheck `json:"s3ProfileHealthCheck,omitempty"`
+
+	// S3GarbageCollection deletes, or reports, the metadata left in the S3 profiles by the VRGs of deleted DRPCs
+	// +optional
+	S3GarbageCollection S3GarbageCollection `json:"s3GarbageCollection,omitempty"`
+
+	// S3ProfileValidation configures the validation of the S3 profiles of the DRClusters as they are reconciled
+	// +optional
+	S3ProfileValidation S3ProfileValidation `json:"s3ProfileValidation,omitempty"`
+
+	// MaintenanceModes configures the MaintenanceModes the hub activates on the managed clusters for failovers
+	// +optional
+	MaintenanceModes MaintenanceModesConfig `json:"maintenanceModes,omitempty"`
+
+	// ManifestTransport selects how the hub delivers the resources it generates, like VRGs, NetworkFences and
+	// DRClusterConfigs, to the managed clusters, for environments where the work distribution of OCM is not permitted
+	// +optional
+	ManifestTransport ManifestTransport `json:"manifestTransport,omitempty"`
+
+	// FailoverProbes restricts the endpoints the hub probes for the failover dependencies of the DRPCs
+	// +optional
+	FailoverProbes FailoverProbesConfig `json:"failoverProbes,omitempty"`
+}
+
+// FailoverProbesConfig restricts the failover probes of the DRPCs, which are issued from the hub
+type FailoverProbesConfig struct {
+	// AllowedHosts are the hosts, optionally with a port, the URLs of the failover probes may refer to. Probes of
+	// other hosts are not issued, and hold the failover of their DRPC, so none are issued if unset.
+	// +optional
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+}
+
+// ManifestTransportMode is how the resources generated for the managed clusters are delivered to them
+// +kubebuilder:validation:Enum=ManifestWork;Export
+type ManifestTransportMode string
+
+const (
+	// ManifestTransportModeManifestWork delivers the resources in ManifestWorks, applied by the OCM work agent
+	ManifestTransportModeManifestWork ManifestTransportMode = "ManifestWork"
+
+	// ManifestTransportModeExport renders the resources into files, published by a GitOps pipeline
+	ManifestTransportModeExport ManifestTransportMode = "Export"
+)
+
+// ManifestTransport configures the delivery of the resources generated for the managed clusters
+type ManifestTransport struct {
+	// Mode is ManifestWork, the default, or Export. In the Export mode, the manifests of each ManifestWork are
+	// rendered into <directory>/<cluster>/<manifestwork>.yaml instead, for a sidecar of the hub operator, like
+	// git-sync or an OCI artifact pusher, to publish them to a Git repository or an OCI artifact per cluster, that a
+	// GitOps agent on the cluster applies. The status of the resources is still read back with ManagedClusterViews.
+	// +optional
+	Mode ManifestTransportMode `json:"mode,omitempty"`
+
+	// Directory the manifests are rendered into in the Export mode, typically a volume shared with the sidecar
+	// +optional
+	Directory string `json:"directory,omitempty"`
+}
+
+// MaintenanceModesConfig configures the MaintenanceModes activated on a cluster for the DRPCs failing over to it,
+// which are deactivated once none of the DRPCs they were activated for is still failing over to the cluster
+type MaintenanceModesConfig struct {
+	// Lifetime is how long after a DRPC started its failover a MaintenanceMode is kept active for it, 24h if unset.
+	// A MaintenanceMode is deactivated once it outlived the failovers of all its DRPCs, even if they have not
+	// completed, for a failover that is stuck or was abandoned not to degrade the storage indefinitely.
+	// +optional
+	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+}
+
+// S3ProfileValidation configures the validation of the S3 profile of a DRCluster, which connects to it and lists its
+// objects, as the DRCluster is reconciled
+type S3ProfileValidation struct {
+	// Async validates the S3 profile of a DRCluster in the background, and reports the result in its
+	// S3ProfileValidated condition, instead of failing the reconcile of the DRCluster until the S3 profile is valid.
+	// Other changes of the spec of the DRCluster, like its CIDRs or fencing, are then processed while its S3 store is
+	// unavailable.
+	// +optional
+	Async bool `json:"async,omitempty"`
+}
+
+// S3GarbageCollection configures the garbage collection of the metadata of the VRGs of the DRPCs deleted by the hub,
+// which is deleted once it was orphaned for the retention period
+type S3GarbageCollection struct {
+	// Enabled turns on the garbage collection
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Interval between audits of the S3 profiles for orphaned metadata, 24h if unset
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// RetentionPeriod is how long orphaned metadata is kept before it is deleted, 168h if unset
+	// +optional
+	RetentionPeriod *metav1.Duration `json:"retentionPeriod,omitempty"`
+
+	// DryRun reports the orphaned metadata due for deletion in the logs and metrics without deleting it
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// S3ProfileHealthCheck configures the periodic probe of the S3 profiles, which puts, lists and deletes an object
+type S3ProfileHealthCheck struct {
+	// Enabled turns on the probe of the S3 profiles
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Interval between probes of the S3 profiles, 5m if unset
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// DRPCFairQueuing configures the fair queuing of the reconciles of the DRPCs across tenants
+type DRPCFairQueuing struct {
+	// Enabled turns on the fair queuing, the reconciles of the DRPCs are queued first in, first out otherwise
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// TenantLabel is the label of the DRPCs whose value is their tenant. DRPCs without the label, or all DRPCs if
+	// unset, are of the tenant of their namespace.
+	// +optional
+	TenantLabel string `json:"tenantLabel,omitempty"`
+}
+
+// APIClientConfig configures the client-side rate limit of the requests to the apiserver
+type APIClientConfig struct {
+	// QPS is the sustained number of requests per second, the controller-runtime default if unset
+	// +optional
+	QPS float32 `json:"qps,omitempty"`
+
+	// Burst is the number of requests allowed over QPS in a burst, the controller-runtime default if unset
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+}
+
+// ManagedClusterViewsConfig configures the ManagedClusterViews of all kinds, and overrides it per kind
+type ManagedClusterViewsConfig struct {
+	// UpdateIntervalSeconds is the interval at which the managed clusters refresh the views, the
+	// default of the view agent if unset
+	// +optional
+	UpdateIntervalSeconds int32 `json:"updateIntervalSeconds,omitempty"`
+
+	// Kinds overrides the configuration for the views of objects of a kind
+	// +optional
+	Kinds []ManagedClusterViewKindConfig `json:"kinds,omitempty"`
+}
+
+// ManagedClusterViewKindConfig configures the ManagedClusterViews of objects of a kind
+type ManagedClusterViewKindConfig struct {
+	// Kind of the viewed objects, for example VolumeReplicationGroup
+	Kind string `json:"kind"`
+
+	// UpdateIntervalSeconds overrides the refresh interval of the views of the kind
+	// +optional
+	UpdateIntervalSeconds int32 `json:"updateIntervalSeconds,omitempty"`
+
+	// Resource is the resource type of the kind, for example volumereplicationgroups, that the views
+	// name in their scope in addition to the kind, when the view agent cannot map the kind itself
+	// +optional
+	Resource string `json:"resource,omitempty"`
+
+	// Namespaces restricts the views of namespaced objects of the kind to these namespaces. Objects
+	// in other namespaces are not read. Views of cluster scoped objects are not restricted.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// ContinuousValidation configures the periodic computation of the DR readiness score of DRPCs
+type ContinuousValidation struct {
+	// Enabled turns on the computation of the DR readiness score
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Interval between computations of the score of a DRPC, 5m if unset
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// ManagedClusterStatusSource is the source of the status of objects on managed clusters
+// +kubebuilder:validation:Enum=ManagedClusterView;ManifestWorkFeedback;ClusterProxy
+type ManagedClusterStatusSource string
+
+const (
+	ManagedClusterStatusSourceView         ManagedClusterStatusSource = "ManagedClusterView"
+	ManagedClusterStatusSourceFeedback     ManagedClusterStatusSource = "ManifestWorkFeedback"
+	ManagedClusterStatusSourceClusterProxy ManagedClusterStatusSource = "ClusterProxy"
+)
+
+// ClusterProxyConfig configures the read-only access of the hub to the managed clusters through the user server
+// of the cluster-proxy addon. Ramen authenticates to a managed cluster with the token of a ManagedServiceAccount,
+// and impersonates a user that is granted read access on it. Objects that cannot be read through the cluster-proxy,
+// for example on managed clusters without the token, are read using ManagedClusterViews. The views of the objects read
+// through the cluster-proxy are kept, as their events trigger the reconciles of the DRPCs and DRClusters.
+type ClusterProxyConfig struct {
+	// ServerURL is the URL of the user server of the cluster-proxy addon, for example
+	// https://cluster-proxy-addon-user.multicluster-engine.svc:9092
+	ServerURL string `json:"serverURL,omitempty"`
+
+	// CAFile is the path, in the ramen hub operator pod, of the CA bundle of the user server
+	// +optional
+	CAFile string `json:"caFile,omitempty"`
+
+	// ManagedServiceAccountName is the name of the ManagedServiceAccount, and of the secret with its token
+	// in the namespace of each managed cluster on the hub
+	ManagedServiceAccountName string `json:"managedServiceAccountName,omitempty"`
+
+	// ImpersonateUser is the user, granted read access on the managed clusters, that ramen impersonates.
+	// Ramen reads as the ManagedServiceAccount if unset.
+	// +optional
+	ImpersonateUser string `json:"impersonateUser,omitempty"`
+}
+
+// ManifestTemplate is a Go text template of a single Kubernetes object, in YAML or JSON. The
+// template is rendered with .ClusterName, the name of the managed cluster, and
+// .DrClusterOperatorNamespace, the namespace of the dr-cluster operator.
+type ManifestTemplate struct {
+	// Name identifies the template in errors
+	Name string `json:"name"`
+
+	// Template of the object
+	Template string `json:"template"`
+}
+
+// MetadataPropagation lists the label and annotation keys to propagate
+type MetadataPropagation struct {
+	// Labels is the list of label keys to propagate
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// Annotations is the list of annotation keys to propagate
+	// +optional
+	Annotations []string `json:"annotations,omitempty"`
 }
 
 func init() {
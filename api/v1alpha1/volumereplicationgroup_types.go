@@ -7,6 +7,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -35,6 +36,11 @@ const (
 	UnknownState State = "Unknown"
 )
 
+// VRGSchemaVersion is the version of the VolumeReplicationGroup spec of this API. It is incremented as fields that
+// the operators of the earlier versions do not support are added to the spec, for the hub to leave them out of the
+// VRGs of the managed clusters of earlier versions.
+const VRGSchemaVersion = 3
+
 // VRGAsyncSpec has the parameters associated with RegionalDR
 type VRGAsyncSpec struct {
 	// Label selector to identify the VolumeReplicationClass resources
@@ -43,6 +49,12 @@ type VRGAsyncSpec struct {
 	//+optional
 	ReplicationClassSelector metav1.LabelSelector `json:"replicationClassSelector,omitempty"`
 
+	// ReplicationClassNames are the names of the VolumeReplicationClasses and VolumeGroupReplicationClasses to select,
+	// of those that match the provisioner and the schedulingInterval of a PVC, rather than the one annotated as the
+	// default.
+	//+optional
+	ReplicationClassNames []string `json:"replicationClassNames,omitempty"`
+
 	// Label selector to identify the VolumeSnapshotClass resources
 	// that are scanned to select an appropriate VolumeSnapshotClass
 	// for the VolumeReplication resource when using VolSync.
@@ -59,10 +71,25 @@ type VRGAsyncSpec struct {
 	// data to a peer cluster. Interval is typically in the
 	// form <num><m,h,d>. Here <num> is a number, 'm' means
 	// minutes, 'h' means hours and 'd' stands for days.
+	// Alternatively, a standard 5-field cron expression.
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Pattern=`^\d+[mhd]$`
+	// +kubebuilder:validation:Pattern=`^(\d+[mhd]|\S+( \S+){4})$`
 	SchedulingInterval string `json:"schedulingInterval"`
 
+	// BackupWindows are the daily windows of time the storage backs up the volumes it replicates in. Kube object
+	// captures due in a window are delayed until it closes.
+	//+optional
+	BackupWindows []BackupWindow `json:"backupWindows,omitempty"`
+
+	// StorageClassSchedulingIntervals override the schedulingInterval of the PVCs whose StorageClass matches their
+	// selectors. The first one to match a StorageClass applies.
+	//+optional
+	StorageClassSchedulingIntervals []StorageClassSchedulingInterval `json:"storageClassSchedulingIntervals,omitempty"`
+
+	// VolSync tunes the replication of the PVCs replicated by VolSync
+	//+optional
+	VolSync *VolSyncTuning `json:"volSync,omitempty"`
+
 	// PeerClasses is a list of common StorageClasses across the clusters in a policy that have related
 	// sync relationships. This is ONLY modified post creation, if the workload that is protected
 	// creates a PVC using a newer StorageClass that is determined to be common across the peers.
@@ -135,6 +162,35 @@ type VolSyncSpec struct {
 	MoverConfig []MoverConfig `json:"moverConfig,omitempty"`
 }
 
+// VolSyncTuning tunes the VolSync replication of PVCs, as applied to their ReplicationSources and
+// ReplicationDestinations
+type VolSyncTuning struct {
+	// CopyMethod of the ReplicationDestinations: Direct to replicate into the PVC the workload recovers with, or
+	// Snapshot to replicate into a PVC of the ReplicationDestination, that is snapshotted after each sync. Overrides
+	// the volSync destinationCopyMethod of the ramen config.
+	// +kubebuilder:validation:Enum=Direct;Snapshot
+	//+optional
+	CopyMethod string `json:"copyMethod,omitempty"`
+
+	// MoverResources are the compute resources of the mover containers, such as their CPU and memory requests. As
+	// VolSync does not update the resources of the movers of a ReplicationSource or ReplicationDestination, changes
+	// apply to their next syncs.
+	//+optional
+	MoverResources *corev1.ResourceRequirements `json:"moverResources,omitempty"`
+
+	// CacheCapacity is the capacity of the PVC the mover of a ReplicationSource syncs from, that holds the
+	// point-in-time copy of the PVC replicated, which defaults to the capacity of the PVC. It is to be at least the
+	// capacity of the PVCs replicated.
+	//+optional
+	CacheCapacity *resource.Quantity `json:"cacheCapacity,omitempty"`
+
+	// PrivilegedMovers, if true, runs the movers privileged, for them to preserve the ownership and permissions of the
+	// files they replicate, and if false, unprivileged, by annotating the namespaces of the PVCs replicated with the
+	// volsync.backube/privileged-movers annotation. The annotation is left as is if unset.
+	//+optional
+	PrivilegedMovers *bool `json:"privilegedMovers,omitempty"`
+}
+
 type MoverConfig struct {
 	// MoverSecurityContext allows specifying the PodSecurityContext that will
 	// be used by the data mover
@@ -174,6 +230,34 @@ const (
 	VRGActionRelocate = VRGAction("Relocate")
 )
 
+// QuiesceStrategy is how the workload is quiesced while its VRG prepares for and runs the final sync of a relocation
+// +kubebuilder:validation:Enum=Default;OverridePodDisruptionBudgets
+type QuiesceStrategy string
+
+// These are the valid values for QuiesceStrategy
+const (
+	// Default, the pod disruption budgets of the workload are left as they are
+	QuiesceStrategyDefault = QuiesceStrategy("Default")
+
+	// OverridePodDisruptionBudgets, the pod disruption budgets in the namespaces of the protected PVCs are overridden
+	// to allow all their pods to be disrupted until the final sync completes, and restored afterwards
+	QuiesceStrategyOverridePodDisruptionBudgets = QuiesceStrategy("OverridePodDisruptionBudgets")
+)
+
+// S3UploadPolicy is to how many of the S3 profiles of a VRG its metadata is to be uploaded for it to be protected
+// +kubebuilder:validation:Enum=All;Quorum
+type S3UploadPolicy string
+
+// These are the valid values for S3UploadPolicy
+const (
+	// All, the metadata is protected once uploaded to all the S3 profiles
+	S3UploadPolicyAll = S3UploadPolicy("All")
+
+	// Quorum, the metadata is protected once uploaded to a majority of the S3 profiles, and the uploads to the
+	// other profiles are retried
+	S3UploadPolicyQuorum = S3UploadPolicy("Quorum")
+)
+
 type KubeObjectProtectionSpec struct {
 	// Preferred time between captures
 	//+optional
@@ -191,6 +275,37 @@ type KubeObjectProtectionSpec struct {
 	// Label selector to identify all the kube objects that need DR protection.
 	// +optional
 	KubeObjectSelector *metav1.LabelSelector `json:"kubeObjectSelector,omitempty"`
+
+	// Differential capture, if set, uploads only the kube objects changed since the last full capture every
+	// captureInterval, and takes full captures every baselineInterval instead
+	//+optional
+	Differential *KubeObjectsDifferentialSpec `json:"differential,omitempty"`
+
+	// Names of the Helm releases of the protected namespaces. The state of each release, its Helm release secrets,
+	// is captured with the kube objects even if not selected otherwise, and the recovered kube objects of the release
+	// are re-adopted into it, so that the release can be upgraded on the cluster it is recovered to.
+	//+optional
+	HelmReleases []string `json:"helmReleases,omitempty"`
+
+	// Namespace quotas, if set, captures the ResourceQuotas and LimitRanges of the protected namespaces even if not
+	// selected otherwise, and recovers them before the other kube objects, once the quotas of the cluster recovered to
+	// are verified not to be exceeded by the recovered volumes.
+	//+optional
+	NamespaceQuotas bool `json:"namespaceQuotas,omitempty"`
+}
+
+// KubeObjectsDifferentialSpec configures differential kube objects capture, reducing object store traffic for
+// large, mostly-static namespaces. Recovery applies the latest differential capture over its full capture.
+type KubeObjectsDifferentialSpec struct {
+	// Preferred time between full captures, defaults to 24h
+	//+optional
+	//+kubebuilder:validation:Format=duration
+	BaselineInterval *metav1.Duration `json:"baselineInterval,omitempty"`
+
+	// Resources compared by differential captures, as plural resource names qualified by their group unless in the
+	// core group, e.g. configmaps or deployments.apps. Changes to other resources are captured by full captures only.
+	//+kubebuilder:validation:MinItems=1
+	Resources []string `json:"resources"`
 }
 
 type RecipeRef struct {
@@ -203,7 +318,10 @@ type RecipeRef struct {
 	Name string `json:"name,omitempty"`
 }
 
-const KubeObjectProtectionCaptureIntervalDefault = 5 * time.Minute
+const (
+	KubeObjectProtectionCaptureIntervalDefault     = 5 * time.Minute
+	KubeObjectsDifferentialBaselineIntervalDefault = 24 * time.Hour
+)
 
 // VolumeReplicationGroup (VRG) spec declares the desired schedule for data
 // replication and replication state of all PVCs identified via the given
@@ -256,6 +374,26 @@ type VolumeReplicationGroupSpec struct {
 	// When true, no permanent changes are made on the failover cluster.
 	//+optional
 	DryRun bool `json:"dryRun,omitempty"`
+
+	// RestoreCaptureGeneration selects the capture generation, recorded with an S3 profile with versioning, to
+	// restore the metadata from, instead of the latest metadata
+	//+kubebuilder:validation:Minimum=0
+	//+optional
+	RestoreCaptureGeneration int64 `json:"restoreCaptureGeneration,omitempty"`
+
+	// QuiesceStrategy is how the workload is quiesced while preparing for and running the final sync
+	//+optional
+	QuiesceStrategy QuiesceStrategy `json:"quiesceStrategy,omitempty"`
+
+	// S3UploadPolicy is to how many of the S3 profiles the metadata is to be uploaded for it to be protected.
+	// Defaults to All.
+	//+optional
+	S3UploadPolicy S3UploadPolicy `json:"s3UploadPolicy,omitempty"`
+
+	// LaggingS3Profiles are the S3 profiles the metadata of the primary VRG was last reported not to be fully
+	// uploaded to. They are restored from only if restoring from the other S3 profiles fails.
+	//+optional
+	LaggingS3Profiles []string `json:"laggingS3Profiles,omitempty"`
 	//+optional
 	KubeObjectProtection *KubeObjectProtectionSpec `json:"kubeObjectProtection,omitempty"`
 
@@ -266,6 +404,53 @@ type VolumeReplicationGroupSpec struct {
 	// You can use a recipe to filter and coordinate the order of the resources that are protected.
 	//+optional
 	ProtectedNamespaces *[]string `json:"protectedNamespaces,omitempty"`
+
+	// LocalFailover is the pre-approved failover plan, set by the hub on the VRG of the plan's failover cluster and
+	// on the primary VRG. A secondary VRG promotes itself when both the hub heartbeat and the heartbeat of the
+	// primary VRG are stale beyond the plan's threshold. A primary VRG uploads its heartbeat.
+	//+optional
+	LocalFailover *LocalFailoverSpec `json:"localFailover,omitempty"`
+
+	// FinalizationHooks are executed by the primary VRG when it is deleted, before protection is removed
+	//+optional
+	FinalizationHooks *FinalizationHooksSpec `json:"finalizationHooks,omitempty"`
+
+	// PVTopologyMappings rewrite the topology constraints in the node affinity of the PVs restored by the VRG, from
+	// the topology of the cluster the PVs were protected on to that of this cluster
+	//+optional
+	PVTopologyMappings []TopologyMapping `json:"pvTopologyMappings,omitempty"`
+
+	// Drill, if set, makes the VRG a drill of another VRG of its namespace, set by the hub for a DRDrill: instead of
+	// protecting PVCs, the VRG restores the PVCs and kube objects of the other VRG from their latest replica and
+	// capture into the drill namespace, leaving the other VRG and its workload untouched
+	//+optional
+	Drill *VRGDrillSpec `json:"drill,omitempty"`
+}
+
+// VRGDrillSpec is the VRG drilled, and the namespace it is restored into
+type VRGDrillSpec struct {
+	// SourceVRGName is the name of the VRG drilled, in the namespace of the drill VRG
+	// +kubebuilder:validation:Required
+	SourceVRGName string `json:"sourceVRGName"`
+
+	// Namespace is the namespace restored into, created by the drill VRG and deleted with it
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+}
+
+// FinalizationHooksSpec declares Recipe hooks executed on the primary cluster before protection is removed on
+// deletion, for example to take a final backup or to deregister the application
+type FinalizationHooksSpec struct {
+	// Hooks of the Recipe referenced by kubeObjectProtection, in the form hookName/operationName, executed in order
+	// +kubebuilder:validation:MinItems=1
+	Hooks []string `json:"hooks"`
+
+	// TimeoutSeconds after deletion starts, after which protection is removed even if the hooks did not succeed.
+	// Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=600
+	//+optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
 }
 
 type Identifier struct {
@@ -371,9 +556,22 @@ type KubeObjectsCaptureIdentifier struct {
 	StartGeneration int64       `json:"startGeneration,omitempty"`
 }
 
+// KubeObjectsDifferentialCaptureIdentifier identifies a differential capture of the objects changed since the full
+// capture it is relative to
+type KubeObjectsDifferentialCaptureIdentifier struct {
+	// Number of the full capture the differential capture is relative to
+	BaselineNumber int64 `json:"baselineNumber"`
+	//+nullable
+	StartTime metav1.Time `json:"startTime,omitempty"`
+	// Number of objects changed, created or deleted since the full capture
+	Changed int32 `json:"changed,omitempty"`
+}
+
 type KubeObjectProtectionStatus struct {
 	//+optional
 	CaptureToRecoverFrom *KubeObjectsCaptureIdentifier `json:"captureToRecoverFrom,omitempty"`
+	//+optional
+	DifferentialCaptureToRecoverFrom *KubeObjectsDifferentialCaptureIdentifier `json:"differentialCaptureToRecoverFrom,omitempty"`
 }
 
 // VolSyncReplicationDestinationInfo defines the configuration details for a PVC
@@ -396,6 +594,16 @@ type VolumeReplicationGroupStatus struct {
 
 	// All the protected pvcs
 	ProtectedPVCs []ProtectedPVC `json:"protectedPVCs,omitempty"`
+
+	// excludedPVCs lists the namespaced names of the PVCs that match the PVC selector, but are excluded from
+	// protection by their annotation
+	//+optional
+	ExcludedPVCs []string `json:"excludedPVCs,omitempty"`
+
+	// overriddenPDBs lists the namespaced names of the pod disruption budgets overridden to quiesce the workload,
+	// which are restored once the final sync completes
+	//+optional
+	OverriddenPDBs []string `json:"overriddenPDBs,omitempty"`
 	// List of CGs that are protected by the VRG resource
 	//+optional
 	PVCGroups []Groups `json:"pvcgroups,omitempty"`
@@ -410,6 +618,10 @@ type VolumeReplicationGroupStatus struct {
 	// observedGeneration is the last generation change the operator has dealt with
 	//+optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// observedSpecHash is the value of the spec hash annotation, set by the hub, at observedGeneration
+	//+optional
+	ObservedSpecHash string `json:"observedSpecHash,omitempty"`
 	//+nullable
 	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
 	//+optional
@@ -430,6 +642,87 @@ type VolumeReplicationGroupStatus struct {
 	// successful synchronization of all PVCs
 	//+optional
 	LastGroupSyncBytes *int64 `json:"lastGroupSyncBytes,omitempty"`
+
+	// localFailover records the evaluation and execution of a pre-approved local failover plan
+	//+optional
+	LocalFailover *LocalFailoverStatus `json:"localFailover,omitempty"`
+
+	// drill reports the restore of a drill VRG
+	//+optional
+	Drill *VRGDrillStatus `json:"drill,omitempty"`
+
+	// s3ProfileUploads reports the result of the latest uploads of the metadata to each of the S3 profiles
+	//+optional
+	S3ProfileUploads []S3ProfileUploadStatus `json:"s3ProfileUploads,omitempty"`
+}
+
+// S3ProfileUploadStatus is the result of the latest uploads of the metadata of a VRG to an S3 profile
+type S3ProfileUploadStatus struct {
+	// S3ProfileName is the name of the S3 profile
+	S3ProfileName string `json:"s3ProfileName"`
+
+	// Uploaded is false if an upload of the metadata to the profile failed in the latest reconcile that uploaded
+	// any, in which case the profile lags behind the others until the upload is retried successfully
+	Uploaded bool `json:"uploaded"`
+
+	// Message is the error of the failed upload
+	//+optional
+	Message string `json:"message,omitempty"`
+}
+
+// VRGDrillPhase is the progress of the restore of a drill VRG
+// +kubebuilder:validation:Enum=Restoring;Restored;Failed
+type VRGDrillPhase string
+
+const (
+	VRGDrillRestoring = VRGDrillPhase("Restoring")
+	VRGDrillRestored  = VRGDrillPhase("Restored")
+	VRGDrillFailed    = VRGDrillPhase("Failed")
+)
+
+// VRGDrillStatus reports the restore of a drill VRG
+type VRGDrillStatus struct {
+	// Phase is Restored once the restorable PVCs and the kube objects are restored, and Failed if they cannot be
+	//+optional
+	Phase VRGDrillPhase `json:"phase,omitempty"`
+
+	// Message details the phase
+	//+optional
+	Message string `json:"message,omitempty"`
+
+	// RestoredPVCs are the names of the PVCs restored from the latest snapshot of their replica
+	//+optional
+	RestoredPVCs []string `json:"restoredPVCs,omitempty"`
+
+	// SkippedPVCs are the names of the PVCs replicated by VolumeReplication, whose replica is restorable only by
+	// promoting it, that is by a failover
+	//+optional
+	SkippedPVCs []string `json:"skippedPVCs,omitempty"`
+
+	// KubeObjectsRestored is set once the kube objects are restored from their latest capture
+	//+optional
+	KubeObjectsRestored bool `json:"kubeObjectsRestored,omitempty"`
+}
+
+// LocalFailoverStatus records the state of a local failover plan as observed by the managed cluster
+type LocalFailoverStatus struct {
+	// LastHubHeartbeat is the most recent hub heartbeat found in the S3 stores
+	//+optional
+	LastHubHeartbeat *metav1.Time `json:"lastHubHeartbeat,omitempty"`
+
+	// LastPrimaryHeartbeat is the most recent heartbeat of the primary VRG found in the S3 stores
+	//+optional
+	LastPrimaryHeartbeat *metav1.Time `json:"lastPrimaryHeartbeat,omitempty"`
+
+	// ExecutedTime is when the managed cluster promoted the VRG on its own, while the hub and the primary cluster
+	// were unreachable
+	//+optional
+	ExecutedTime *metav1.Time `json:"executedTime,omitempty"`
+
+	// ExecutedGeneration is the generation of the VRG spec when the local failover was executed. The local failover
+	// is dropped once the hub changes the spec, reasserting its ownership of the VRG.
+	//+optional
+	ExecutedGeneration int64 `json:"executedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -14,6 +14,101 @@ import (
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIClientConfig) DeepCopyInto(out *APIClientConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIClientConfig.
+func (in *APIClientConfig) DeepCopy() *APIClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(APIClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionCheckpoint) DeepCopyInto(out *ActionCheckpoint) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionCheckpoint.
+func (in *ActionCheckpoint) DeepCopy() *ActionCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionProgress) DeepCopyInto(out *ActionProgress) {
+	*out = *in
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]ActionProgressPhase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionProgress.
+func (in *ActionProgress) DeepCopy() *ActionProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionProgressPhase) DeepCopyInto(out *ActionProgressPhase) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionProgressPhase.
+func (in *ActionProgressPhase) DeepCopy() *ActionProgressPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionProgressPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionRecord) DeepCopyInto(out *ActionRecord) {
+	*out = *in
+	in.RequestTime.DeepCopyInto(&out.RequestTime)
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionRecord.
+func (in *ActionRecord) DeepCopy() *ActionRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Async) DeepCopyInto(out *Async) {
 	*out = *in
@@ -36,6 +131,82 @@ func (in *Async) DeepCopy() *Async {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoFailoverSpec) DeepCopyInto(out *AutoFailoverSpec) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoFailoverSpec.
+func (in *AutoFailoverSpec) DeepCopy() *AutoFailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoFailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupWindow) DeepCopyInto(out *BackupWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupWindow.
+func (in *BackupWindow) DeepCopy() *BackupWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CIDRGroup) DeepCopyInto(out *CIDRGroup) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CIDRGroup.
+func (in *CIDRGroup) DeepCopy() *CIDRGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(CIDRGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientSideEncryption) DeepCopyInto(out *ClientSideEncryption) {
+	*out = *in
+	if in.KeySecretRef != nil {
+		in, out := &in.KeySecretRef, &out.KeySecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientSideEncryption.
+func (in *ClientSideEncryption) DeepCopy() *ClientSideEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientSideEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterMaintenanceMode) DeepCopyInto(out *ClusterMaintenanceMode) {
 	*out = *in
@@ -58,6 +229,41 @@ func (in *ClusterMaintenanceMode) DeepCopy() *ClusterMaintenanceMode {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProxyConfig) DeepCopyInto(out *ClusterProxyConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProxyConfig.
+func (in *ClusterProxyConfig) DeepCopy() *ClusterProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContinuousValidation) DeepCopyInto(out *ContinuousValidation) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContinuousValidation.
+func (in *ContinuousValidation) DeepCopy() *ContinuousValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(ContinuousValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerHealth) DeepCopyInto(out *ControllerHealth) {
 	*out = *in
@@ -174,6 +380,73 @@ func (in *DRClusterConfigList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterConfigResult) DeepCopyInto(out *DRClusterConfigResult) {
+	*out = *in
+	if in.ProcessedSchedules != nil {
+		in, out := &in.ProcessedSchedules, &out.ProcessedSchedules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingSchedules != nil {
+		in, out := &in.PendingSchedules, &out.PendingSchedules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageClasses != nil {
+		in, out := &in.StorageClasses, &out.StorageClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeSnapshotClasses != nil {
+		in, out := &in.VolumeSnapshotClasses, &out.VolumeSnapshotClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeGroupSnapshotClasses != nil {
+		in, out := &in.VolumeGroupSnapshotClasses, &out.VolumeGroupSnapshotClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeReplicationClasses != nil {
+		in, out := &in.VolumeReplicationClasses, &out.VolumeReplicationClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeGroupReplicationClasses != nil {
+		in, out := &in.VolumeGroupReplicationClasses, &out.VolumeGroupReplicationClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkFenceClasses != nil {
+		in, out := &in.NetworkFenceClasses, &out.NetworkFenceClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OperandErrors != nil {
+		in, out := &in.OperandErrors, &out.OperandErrors
+		*out = make([]OperandError, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterConfigResult.
+func (in *DRClusterConfigResult) DeepCopy() *DRClusterConfigResult {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterConfigResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DRClusterConfigSpec) DeepCopyInto(out *DRClusterConfigSpec) {
 	*out = *in
@@ -241,6 +514,13 @@ func (in *DRClusterConfigStatus) DeepCopyInto(out *DRClusterConfigStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OperandErrors != nil {
+		in, out := &in.OperandErrors, &out.OperandErrors
+		*out = make([]OperandError, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterConfigStatus.
@@ -286,75 +566,255 @@ func (in *DRClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DRClusterSpec) DeepCopyInto(out *DRClusterSpec) {
+func (in *DRClusterMigration) DeepCopyInto(out *DRClusterMigration) {
 	*out = *in
-	if in.CIDRs != nil {
-		in, out := &in.CIDRs, &out.CIDRs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterSpec.
-func (in *DRClusterSpec) DeepCopy() *DRClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterMigration.
+func (in *DRClusterMigration) DeepCopy() *DRClusterMigration {
 	if in == nil {
 		return nil
 	}
-	out := new(DRClusterSpec)
+	out := new(DRClusterMigration)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRClusterMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DRClusterStatus) DeepCopyInto(out *DRClusterStatus) {
+func (in *DRClusterMigrationList) DeepCopyInto(out *DRClusterMigrationList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.MaintenanceModes != nil {
-		in, out := &in.MaintenanceModes, &out.MaintenanceModes
-		*out = make([]ClusterMaintenanceMode, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DRClusterMigration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterStatus.
-func (in *DRClusterStatus) DeepCopy() *DRClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterMigrationList.
+func (in *DRClusterMigrationList) DeepCopy() *DRClusterMigrationList {
 	if in == nil {
 		return nil
 	}
-	out := new(DRClusterStatus)
+	out := new(DRClusterMigrationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRClusterMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DRPlacementControl) DeepCopyInto(out *DRPlacementControl) {
+func (in *DRClusterMigrationSpec) DeepCopyInto(out *DRClusterMigrationSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControl.
-func (in *DRPlacementControl) DeepCopy() *DRPlacementControl {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterMigrationSpec.
+func (in *DRClusterMigrationSpec) DeepCopy() *DRClusterMigrationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DRPlacementControl)
+	out := new(DRClusterMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterMigrationStatus) DeepCopyInto(out *DRClusterMigrationStatus) {
+	*out = *in
+	if in.ManifestWorks != nil {
+		in, out := &in.ManifestWorks, &out.ManifestWorks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DRPolicies != nil {
+		in, out := &in.DRPolicies, &out.DRPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DRPlacementControls != nil {
+		in, out := &in.DRPlacementControls, &out.DRPlacementControls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterMigrationStatus.
+func (in *DRClusterMigrationStatus) DeepCopy() *DRClusterMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterRename) DeepCopyInto(out *DRClusterRename) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterRename.
+func (in *DRClusterRename) DeepCopy() *DRClusterRename {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterRename)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterSpec) DeepCopyInto(out *DRClusterSpec) {
+	*out = *in
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CIDRGroups != nil {
+		in, out := &in.CIDRGroups, &out.CIDRGroups
+		*out = make([]CIDRGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FenceCIDRGroups != nil {
+		in, out := &in.FenceCIDRGroups, &out.FenceCIDRGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.S3ProfileMigration != nil {
+		in, out := &in.S3ProfileMigration, &out.S3ProfileMigration
+		*out = new(S3ProfileMigration)
+		**out = **in
+	}
+	if in.Fencing != nil {
+		in, out := &in.Fencing, &out.Fencing
+		*out = new(FencingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FenceVerification != nil {
+		in, out := &in.FenceVerification, &out.FenceVerification
+		*out = new(FenceVerificationSpec)
+		**out = **in
+	}
+	if in.ViewRefresh != nil {
+		in, out := &in.ViewRefresh, &out.ViewRefresh
+		*out = new(ViewRefreshSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterSpec.
+func (in *DRClusterSpec) DeepCopy() *DRClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterStatus) DeepCopyInto(out *DRClusterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaintenanceModes != nil {
+		in, out := &in.MaintenanceModes, &out.MaintenanceModes
+		*out = make([]ClusterMaintenanceMode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnfenceAcknowledgments != nil {
+		in, out := &in.UnfenceAcknowledgments, &out.UnfenceAcknowledgments
+		*out = make([]UnfenceAcknowledgment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ActionCheckpoint != nil {
+		in, out := &in.ActionCheckpoint, &out.ActionCheckpoint
+		*out = new(ActionCheckpoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterConfig != nil {
+		in, out := &in.ClusterConfig, &out.ClusterConfig
+		*out = new(DRClusterConfigResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FenceVerification != nil {
+		in, out := &in.FenceVerification, &out.FenceVerification
+		*out = new(FenceVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FencedCIDRGroups != nil {
+		in, out := &in.FencedCIDRGroups, &out.FencedCIDRGroups
+		*out = make([]CIDRGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterStatus.
+func (in *DRClusterStatus) DeepCopy() *DRClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRDrill) DeepCopyInto(out *DRDrill) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRDrill.
+func (in *DRDrill) DeepCopy() *DRDrill {
+	if in == nil {
+		return nil
+	}
+	out := new(DRDrill)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DRPlacementControl) DeepCopyObject() runtime.Object {
+func (in *DRDrill) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -362,31 +822,31 @@ func (in *DRPlacementControl) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DRPlacementControlList) DeepCopyInto(out *DRPlacementControlList) {
+func (in *DRDrillList) DeepCopyInto(out *DRDrillList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DRPlacementControl, len(*in))
+		*out = make([]DRDrill, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlList.
-func (in *DRPlacementControlList) DeepCopy() *DRPlacementControlList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRDrillList.
+func (in *DRDrillList) DeepCopy() *DRDrillList {
 	if in == nil {
 		return nil
 	}
-	out := new(DRPlacementControlList)
+	out := new(DRDrillList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DRPlacementControlList) DeepCopyObject() runtime.Object {
+func (in *DRDrillList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -394,99 +854,99 @@ func (in *DRPlacementControlList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DRPlacementControlSpec) DeepCopyInto(out *DRPlacementControlSpec) {
+func (in *DRDrillSpec) DeepCopyInto(out *DRDrillSpec) {
 	*out = *in
-	out.PlacementRef = in.PlacementRef
-	if in.ProtectedNamespaces != nil {
-		in, out := &in.ProtectedNamespaces, &out.ProtectedNamespaces
-		*out = new([]string)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]string, len(*in))
-			copy(*out, *in)
-		}
-	}
-	out.DRPolicyRef = in.DRPolicyRef
-	in.PVCSelector.DeepCopyInto(&out.PVCSelector)
-	if in.KubeObjectProtection != nil {
-		in, out := &in.KubeObjectProtection, &out.KubeObjectProtection
-		*out = new(KubeObjectProtectionSpec)
-		(*in).DeepCopyInto(*out)
+	if in.RetainFor != nil {
+		in, out := &in.RetainFor, &out.RetainFor
+		*out = new(v1.Duration)
+		**out = **in
 	}
-	if in.VolSyncSpec != nil {
-		in, out := &in.VolSyncSpec, &out.VolSyncSpec
-		*out = new(VolSyncSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlSpec.
-func (in *DRPlacementControlSpec) DeepCopy() *DRPlacementControlSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRDrillSpec.
+func (in *DRDrillSpec) DeepCopy() *DRDrillSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DRPlacementControlSpec)
+	out := new(DRDrillSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DRPlacementControlStatus) DeepCopyInto(out *DRPlacementControlStatus) {
+func (in *DRDrillStatus) DeepCopyInto(out *DRDrillStatus) {
 	*out = *in
-	if in.ActionStartTime != nil {
-		in, out := &in.ActionStartTime, &out.ActionStartTime
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
 		*out = (*in).DeepCopy()
 	}
-	if in.ActionDuration != nil {
-		in, out := &in.ActionDuration, &out.ActionDuration
-		*out = new(v1.Duration)
-		**out = **in
-	}
-	out.PreferredDecision = in.PreferredDecision
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	in.ResourceConditions.DeepCopyInto(&out.ResourceConditions)
-	if in.LastUpdateTime != nil {
-		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+	if in.RestoredTime != nil {
+		in, out := &in.RestoredTime, &out.RestoredTime
 		*out = (*in).DeepCopy()
 	}
-	if in.LastGroupSyncTime != nil {
-		in, out := &in.LastGroupSyncTime, &out.LastGroupSyncTime
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
-	if in.LastGroupSyncDuration != nil {
-		in, out := &in.LastGroupSyncDuration, &out.LastGroupSyncDuration
-		*out = new(v1.Duration)
-		**out = **in
+	if in.RestoredPVCs != nil {
+		in, out := &in.RestoredPVCs, &out.RestoredPVCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.LastGroupSyncBytes != nil {
-		in, out := &in.LastGroupSyncBytes, &out.LastGroupSyncBytes
-		*out = new(int64)
-		**out = **in
+	if in.SkippedPVCs != nil {
+		in, out := &in.SkippedPVCs, &out.SkippedPVCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.LastKubeObjectProtectionTime != nil {
-		in, out := &in.LastKubeObjectProtectionTime, &out.LastKubeObjectProtectionTime
-		*out = (*in).DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRDrillStatus.
+func (in *DRDrillStatus) DeepCopy() *DRDrillStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(DRDrillStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlStatus.
-func (in *DRPlacementControlStatus) DeepCopy() *DRPlacementControlStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPCFairQueuing) DeepCopyInto(out *DRPCFairQueuing) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPCFairQueuing.
+func (in *DRPCFairQueuing) DeepCopy() *DRPCFairQueuing {
 	if in == nil {
 		return nil
 	}
-	out := new(DRPlacementControlStatus)
+	out := new(DRPCFairQueuing)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DRPolicy) DeepCopyInto(out *DRPolicy) {
+func (in *DRPCReference) DeepCopyInto(out *DRPCReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPCReference.
+func (in *DRPCReference) DeepCopy() *DRPCReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPCReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControl) DeepCopyInto(out *DRPlacementControl) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -494,18 +954,18 @@ func (in *DRPolicy) DeepCopyInto(out *DRPolicy) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPolicy.
-func (in *DRPolicy) DeepCopy() *DRPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControl.
+func (in *DRPlacementControl) DeepCopy() *DRPlacementControl {
 	if in == nil {
 		return nil
 	}
-	out := new(DRPolicy)
+	out := new(DRPlacementControl)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DRPolicy) DeepCopyObject() runtime.Object {
+func (in *DRPlacementControl) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -513,100 +973,777 @@ func (in *DRPolicy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DRPolicyList) DeepCopyInto(out *DRPolicyList) {
+func (in *DRPlacementControlAction) DeepCopyInto(out *DRPlacementControlAction) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlAction.
+func (in *DRPlacementControlAction) DeepCopy() *DRPlacementControlAction {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRPlacementControlAction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlActionList) DeepCopyInto(out *DRPlacementControlActionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DRPolicy, len(*in))
+		*out = make([]DRPlacementControlAction, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPolicyList.
-func (in *DRPolicyList) DeepCopy() *DRPolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlActionList.
+func (in *DRPlacementControlActionList) DeepCopy() *DRPlacementControlActionList {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlActionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRPlacementControlActionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlActionSpec) DeepCopyInto(out *DRPlacementControlActionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlActionSpec.
+func (in *DRPlacementControlActionSpec) DeepCopy() *DRPlacementControlActionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlActionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlActionStatus) DeepCopyInto(out *DRPlacementControlActionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlActionStatus.
+func (in *DRPlacementControlActionStatus) DeepCopy() *DRPlacementControlActionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlActionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlList) DeepCopyInto(out *DRPlacementControlList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DRPlacementControl, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlList.
+func (in *DRPlacementControlList) DeepCopy() *DRPlacementControlList {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRPlacementControlList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlSpec) DeepCopyInto(out *DRPlacementControlSpec) {
+	*out = *in
+	out.PlacementRef = in.PlacementRef
+	if in.ProtectedNamespaces != nil {
+		in, out := &in.ProtectedNamespaces, &out.ProtectedNamespaces
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	out.DRPolicyRef = in.DRPolicyRef
+	in.PVCSelector.DeepCopyInto(&out.PVCSelector)
+	if in.KubeObjectProtection != nil {
+		in, out := &in.KubeObjectProtection, &out.KubeObjectProtection
+		*out = new(KubeObjectProtectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolSyncSpec != nil {
+		in, out := &in.VolSyncSpec, &out.VolSyncSpec
+		*out = new(VolSyncSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	if in.LocalFailover != nil {
+		in, out := &in.LocalFailover, &out.LocalFailover
+		*out = new(LocalFailoverSpec)
+		**out = **in
+	}
+	if in.FailoverDependencies != nil {
+		in, out := &in.FailoverDependencies, &out.FailoverDependencies
+		*out = new(FailoverDependencies)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FinalizationHooks != nil {
+		in, out := &in.FinalizationHooks, &out.FinalizationHooks
+		*out = new(FinalizationHooksSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Qualification != nil {
+		in, out := &in.Qualification, &out.Qualification
+		*out = new(QualificationSpec)
+		**out = **in
+	}
+	if in.AutoFailover != nil {
+		in, out := &in.AutoFailover, &out.AutoFailover
+		*out = new(AutoFailoverSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlSpec.
+func (in *DRPlacementControlSpec) DeepCopy() *DRPlacementControlSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlStatus) DeepCopyInto(out *DRPlacementControlStatus) {
+	*out = *in
+	if in.ActionStartTime != nil {
+		in, out := &in.ActionStartTime, &out.ActionStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ActionDuration != nil {
+		in, out := &in.ActionDuration, &out.ActionDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	out.PreferredDecision = in.PreferredDecision
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.ResourceConditions.DeepCopyInto(&out.ResourceConditions)
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastGroupSyncTime != nil {
+		in, out := &in.LastGroupSyncTime, &out.LastGroupSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastGroupSyncDuration != nil {
+		in, out := &in.LastGroupSyncDuration, &out.LastGroupSyncDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LastGroupSyncBytes != nil {
+		in, out := &in.LastGroupSyncBytes, &out.LastGroupSyncBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LastKubeObjectProtectionTime != nil {
+		in, out := &in.LastKubeObjectProtectionTime, &out.LastKubeObjectProtectionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(DRReadiness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActionCheckpoint != nil {
+		in, out := &in.ActionCheckpoint, &out.ActionCheckpoint
+		*out = new(ActionCheckpoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(ActionProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActionHistory != nil {
+		in, out := &in.ActionHistory, &out.ActionHistory
+		*out = make([]ActionRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Qualification != nil {
+		in, out := &in.Qualification, &out.Qualification
+		*out = new(QualificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3ProfileMigration != nil {
+		in, out := &in.S3ProfileMigration, &out.S3ProfileMigration
+		*out = new(S3ProfileMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlStatus.
+func (in *DRPlacementControlStatus) DeepCopy() *DRPlacementControlStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlTemplate) DeepCopyInto(out *DRPlacementControlTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlTemplate.
+func (in *DRPlacementControlTemplate) DeepCopy() *DRPlacementControlTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRPlacementControlTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlTemplateList) DeepCopyInto(out *DRPlacementControlTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DRPlacementControlTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlTemplateList.
+func (in *DRPlacementControlTemplateList) DeepCopy() *DRPlacementControlTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRPlacementControlTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPlacementControlTemplateSpec) DeepCopyInto(out *DRPlacementControlTemplateSpec) {
+	*out = *in
+	if in.PVCSelector != nil {
+		in, out := &in.PVCSelector, &out.PVCSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeObjectProtection != nil {
+		in, out := &in.KubeObjectProtection, &out.KubeObjectProtection
+		*out = new(KubeObjectProtectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolSyncSpec != nil {
+		in, out := &in.VolSyncSpec, &out.VolSyncSpec
+		*out = new(VolSyncSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FinalizationHooks != nil {
+		in, out := &in.FinalizationHooks, &out.FinalizationHooks
+		*out = new(FinalizationHooksSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlTemplateSpec.
+func (in *DRPlacementControlTemplateSpec) DeepCopy() *DRPlacementControlTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPlacementControlTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPolicy) DeepCopyInto(out *DRPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPolicy.
+func (in *DRPolicy) DeepCopy() *DRPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPolicyList) DeepCopyInto(out *DRPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DRPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPolicyList.
+func (in *DRPolicyList) DeepCopy() *DRPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPolicySpec) DeepCopyInto(out *DRPolicySpec) {
+	*out = *in
+	in.ReplicationClassSelector.DeepCopyInto(&out.ReplicationClassSelector)
+	if in.ReplicationClassNames != nil {
+		in, out := &in.ReplicationClassNames, &out.ReplicationClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.VolumeSnapshotClassSelector.DeepCopyInto(&out.VolumeSnapshotClassSelector)
+	in.VolumeGroupSnapshotClassSelector.DeepCopyInto(&out.VolumeGroupSnapshotClassSelector)
+	if in.DRClusters != nil {
+		in, out := &in.DRClusters, &out.DRClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DRClusterRename != nil {
+		in, out := &in.DRClusterRename, &out.DRClusterRename
+		*out = new(DRClusterRename)
+		**out = **in
+	}
+	if in.TopologyMappings != nil {
+		in, out := &in.TopologyMappings, &out.TopologyMappings
+		*out = make([]TopologyMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.RPOTarget != nil {
+		in, out := &in.RPOTarget, &out.RPOTarget
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.StaleDataThreshold != nil {
+		in, out := &in.StaleDataThreshold, &out.StaleDataThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BackupWindows != nil {
+		in, out := &in.BackupWindows, &out.BackupWindows
+		*out = make([]BackupWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageClassSchedulingIntervals != nil {
+		in, out := &in.StorageClassSchedulingIntervals, &out.StorageClassSchedulingIntervals
+		*out = make([]StorageClassSchedulingInterval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolSync != nil {
+		in, out := &in.VolSync, &out.VolSync
+		*out = new(VolSyncTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HubProtection != nil {
+		in, out := &in.HubProtection, &out.HubProtection
+		*out = new(HubProtection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPolicySpec.
+func (in *DRPolicySpec) DeepCopy() *DRPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPolicyStatus) DeepCopyInto(out *DRPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Async.DeepCopyInto(&out.Async)
+	in.Sync.DeepCopyInto(&out.Sync)
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = new(DRPolicyWorkloadsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HubProtection != nil {
+		in, out := &in.HubProtection, &out.HubProtection
+		*out = new(HubProtectionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPolicyStatus.
+func (in *DRPolicyStatus) DeepCopy() *DRPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRPolicyWorkloadsStatus) DeepCopyInto(out *DRPolicyWorkloadsStatus) {
+	*out = *in
+	if in.OldestLastGroupSyncTime != nil {
+		in, out := &in.OldestLastGroupSyncTime, &out.OldestLastGroupSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPolicyWorkloadsStatus.
+func (in *DRPolicyWorkloadsStatus) DeepCopy() *DRPolicyWorkloadsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRPolicyWorkloadsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRReadiness) DeepCopyInto(out *DRReadiness) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]DRReadinessComponent, len(*in))
+		copy(*out, *in)
+	}
+	in.LastEvaluationTime.DeepCopyInto(&out.LastEvaluationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRReadiness.
+func (in *DRReadiness) DeepCopy() *DRReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(DRReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRReadinessComponent) DeepCopyInto(out *DRReadinessComponent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRReadinessComponent.
+func (in *DRReadinessComponent) DeepCopy() *DRReadinessComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(DRReadinessComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverDependencies) DeepCopyInto(out *FailoverDependencies) {
+	*out = *in
+	if in.DRPCs != nil {
+		in, out := &in.DRPCs, &out.DRPCs
+		*out = make([]DRPCReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]FailoverProbe, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverDependencies.
+func (in *FailoverDependencies) DeepCopy() *FailoverDependencies {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverDependencies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverProbe) DeepCopyInto(out *FailoverProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverProbe.
+func (in *FailoverProbe) DeepCopy() *FailoverProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverProbesConfig) DeepCopyInto(out *FailoverProbesConfig) {
+	*out = *in
+	if in.AllowedHosts != nil {
+		in, out := &in.AllowedHosts, &out.AllowedHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverProbesConfig.
+func (in *FailoverProbesConfig) DeepCopy() *FailoverProbesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverProbesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FenceVerificationSpec) DeepCopyInto(out *FenceVerificationSpec) {
+	*out = *in
+	out.Probe = in.Probe
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FenceVerificationSpec.
+func (in *FenceVerificationSpec) DeepCopy() *FenceVerificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FenceVerificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FenceVerificationStatus) DeepCopyInto(out *FenceVerificationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FenceVerificationStatus.
+func (in *FenceVerificationStatus) DeepCopy() *FenceVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FenceVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FencingSpec) DeepCopyInto(out *FencingSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FencingSpec.
+func (in *FencingSpec) DeepCopy() *FencingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FencingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FinalizationHooksSpec) DeepCopyInto(out *FinalizationHooksSpec) {
+	*out = *in
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FinalizationHooksSpec.
+func (in *FinalizationHooksSpec) DeepCopy() *FinalizationHooksSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DRPolicyList)
+	out := new(FinalizationHooksSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DRPolicyList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DRPolicySpec) DeepCopyInto(out *DRPolicySpec) {
+func (in *Groups) DeepCopyInto(out *Groups) {
 	*out = *in
-	in.ReplicationClassSelector.DeepCopyInto(&out.ReplicationClassSelector)
-	in.VolumeSnapshotClassSelector.DeepCopyInto(&out.VolumeSnapshotClassSelector)
-	in.VolumeGroupSnapshotClassSelector.DeepCopyInto(&out.VolumeGroupSnapshotClassSelector)
-	if in.DRClusters != nil {
-		in, out := &in.DRClusters, &out.DRClusters
+	if in.Grouped != nil {
+		in, out := &in.Grouped, &out.Grouped
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPolicySpec.
-func (in *DRPolicySpec) DeepCopy() *DRPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Groups.
+func (in *Groups) DeepCopy() *Groups {
 	if in == nil {
 		return nil
 	}
-	out := new(DRPolicySpec)
+	out := new(Groups)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DRPolicyStatus) DeepCopyInto(out *DRPolicyStatus) {
+func (in *HubProtection) DeepCopyInto(out *HubProtection) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.SecretSelector != nil {
+		in, out := &in.SecretSelector, &out.SecretSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
-	in.Async.DeepCopyInto(&out.Async)
-	in.Sync.DeepCopyInto(&out.Sync)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPolicyStatus.
-func (in *DRPolicyStatus) DeepCopy() *DRPolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubProtection.
+func (in *HubProtection) DeepCopy() *HubProtection {
 	if in == nil {
 		return nil
 	}
-	out := new(DRPolicyStatus)
+	out := new(HubProtection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Groups) DeepCopyInto(out *Groups) {
+func (in *HubProtectionStatus) DeepCopyInto(out *HubProtectionStatus) {
 	*out = *in
-	if in.Grouped != nil {
-		in, out := &in.Grouped, &out.Grouped
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	in.LastProtectionTime.DeepCopyInto(&out.LastProtectionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Groups.
-func (in *Groups) DeepCopy() *Groups {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HubProtectionStatus.
+func (in *HubProtectionStatus) DeepCopy() *HubProtectionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Groups)
+	out := new(HubProtectionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -665,6 +1802,16 @@ func (in *KubeObjectProtectionSpec) DeepCopyInto(out *KubeObjectProtectionSpec)
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Differential != nil {
+		in, out := &in.Differential, &out.Differential
+		*out = new(KubeObjectsDifferentialSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HelmReleases != nil {
+		in, out := &in.HelmReleases, &out.HelmReleases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeObjectProtectionSpec.
@@ -685,6 +1832,11 @@ func (in *KubeObjectProtectionStatus) DeepCopyInto(out *KubeObjectProtectionStat
 		*out = new(KubeObjectsCaptureIdentifier)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DifferentialCaptureToRecoverFrom != nil {
+		in, out := &in.DifferentialCaptureToRecoverFrom, &out.DifferentialCaptureToRecoverFrom
+		*out = new(KubeObjectsDifferentialCaptureIdentifier)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeObjectProtectionStatus.
@@ -714,6 +1866,88 @@ func (in *KubeObjectsCaptureIdentifier) DeepCopy() *KubeObjectsCaptureIdentifier
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeObjectsDifferentialCaptureIdentifier) DeepCopyInto(out *KubeObjectsDifferentialCaptureIdentifier) {
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeObjectsDifferentialCaptureIdentifier.
+func (in *KubeObjectsDifferentialCaptureIdentifier) DeepCopy() *KubeObjectsDifferentialCaptureIdentifier {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeObjectsDifferentialCaptureIdentifier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeObjectsDifferentialSpec) DeepCopyInto(out *KubeObjectsDifferentialSpec) {
+	if in.BaselineInterval != nil {
+		in, out := &in.BaselineInterval, &out.BaselineInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeObjectsDifferentialSpec.
+func (in *KubeObjectsDifferentialSpec) DeepCopy() *KubeObjectsDifferentialSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeObjectsDifferentialSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalFailoverSpec) DeepCopyInto(out *LocalFailoverSpec) {
+	*out = *in
+	out.HubUnreachableThreshold = in.HubUnreachableThreshold
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalFailoverSpec.
+func (in *LocalFailoverSpec) DeepCopy() *LocalFailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalFailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalFailoverStatus) DeepCopyInto(out *LocalFailoverStatus) {
+	*out = *in
+	if in.LastHubHeartbeat != nil {
+		in, out := &in.LastHubHeartbeat, &out.LastHubHeartbeat
+		*out = (*in).DeepCopy()
+	}
+	if in.LastPrimaryHeartbeat != nil {
+		in, out := &in.LastPrimaryHeartbeat, &out.LastPrimaryHeartbeat
+		*out = (*in).DeepCopy()
+	}
+	if in.ExecutedTime != nil {
+		in, out := &in.ExecutedTime, &out.ExecutedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalFailoverStatus.
+func (in *LocalFailoverStatus) DeepCopy() *LocalFailoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalFailoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MaintenanceMode) DeepCopyInto(out *MaintenanceMode) {
 	*out = *in
@@ -805,37 +2039,225 @@ func (in *MaintenanceModeStatus) DeepCopyInto(out *MaintenanceModeStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceModeStatus.
-func (in *MaintenanceModeStatus) DeepCopy() *MaintenanceModeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceModeStatus.
+func (in *MaintenanceModeStatus) DeepCopy() *MaintenanceModeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceModeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceModesConfig) DeepCopyInto(out *MaintenanceModesConfig) {
+	*out = *in
+	if in.Lifetime != nil {
+		in, out := &in.Lifetime, &out.Lifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceModesConfig.
+func (in *MaintenanceModesConfig) DeepCopy() *MaintenanceModesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceModesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedClusterViewKindConfig) DeepCopyInto(out *ManagedClusterViewKindConfig) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterViewKindConfig.
+func (in *ManagedClusterViewKindConfig) DeepCopy() *ManagedClusterViewKindConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedClusterViewKindConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedClusterViewsConfig) DeepCopyInto(out *ManagedClusterViewsConfig) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]ManagedClusterViewKindConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterViewsConfig.
+func (in *ManagedClusterViewsConfig) DeepCopy() *ManagedClusterViewsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedClusterViewsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestTemplate) DeepCopyInto(out *ManifestTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestTemplate.
+func (in *ManifestTemplate) DeepCopy() *ManifestTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestTransport) DeepCopyInto(out *ManifestTransport) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestTransport.
+func (in *ManifestTransport) DeepCopy() *ManifestTransport {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestTransport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataPropagation) DeepCopyInto(out *MetadataPropagation) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataPropagation.
+func (in *MetadataPropagation) DeepCopy() *MetadataPropagation {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataPropagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MoverConfig) DeepCopyInto(out *MoverConfig) {
+	*out = *in
+	if in.MoverSecurityContext != nil {
+		in, out := &in.MoverSecurityContext, &out.MoverSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MoverServiceAccount != nil {
+		in, out := &in.MoverServiceAccount, &out.MoverServiceAccount
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MoverConfig.
+func (in *MoverConfig) DeepCopy() *MoverConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MoverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultipartUpload) DeepCopyInto(out *MultipartUpload) {
+	*out = *in
+	if in.PartSize != nil {
+		in, out := &in.PartSize, &out.PartSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.PartRetries != nil {
+		in, out := &in.PartRetries, &out.PartRetries
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipartUpload.
+func (in *MultipartUpload) DeepCopy() *MultipartUpload {
+	if in == nil {
+		return nil
+	}
+	out := new(MultipartUpload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectCompression) DeepCopyInto(out *ObjectCompression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectCompression.
+func (in *ObjectCompression) DeepCopy() *ObjectCompression {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectCompression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreProxy) DeepCopyInto(out *ObjectStoreProxy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreProxy.
+func (in *ObjectStoreProxy) DeepCopy() *ObjectStoreProxy {
 	if in == nil {
 		return nil
 	}
-	out := new(MaintenanceModeStatus)
+	out := new(ObjectStoreProxy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MoverConfig) DeepCopyInto(out *MoverConfig) {
+func (in *OperandError) DeepCopyInto(out *OperandError) {
 	*out = *in
-	if in.MoverSecurityContext != nil {
-		in, out := &in.MoverSecurityContext, &out.MoverSecurityContext
-		*out = new(corev1.PodSecurityContext)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.MoverServiceAccount != nil {
-		in, out := &in.MoverServiceAccount, &out.MoverServiceAccount
-		*out = new(string)
-		**out = **in
-	}
+	in.Time.DeepCopyInto(&out.Time)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MoverConfig.
-func (in *MoverConfig) DeepCopy() *MoverConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandError.
+func (in *OperandError) DeepCopy() *OperandError {
 	if in == nil {
 		return nil
 	}
-	out := new(MoverConfig)
+	out := new(OperandError)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1048,6 +2470,59 @@ func (in *ProtectedVolumeReplicationGroupListStatus) DeepCopy() *ProtectedVolume
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualificationResult) DeepCopyInto(out *QualificationResult) {
+	*out = *in
+	out.UploadLatency = in.UploadLatency
+	out.DownloadLatency = in.DownloadLatency
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualificationResult.
+func (in *QualificationResult) DeepCopy() *QualificationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(QualificationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualificationSpec) DeepCopyInto(out *QualificationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualificationSpec.
+func (in *QualificationSpec) DeepCopy() *QualificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QualificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualificationStatus) DeepCopyInto(out *QualificationStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]QualificationResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualificationStatus.
+func (in *QualificationStatus) DeepCopy() *QualificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(QualificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RamenConfig) DeepCopyInto(out *RamenConfig) {
 	*out = *in
@@ -1066,10 +2541,32 @@ func (in *RamenConfig) DeepCopyInto(out *RamenConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.S3SecretNamespaces != nil {
+		in, out := &in.S3SecretNamespaces, &out.S3SecretNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.DrClusterOperator = in.DrClusterOperator
 	out.VolSync = in.VolSync
 	out.KubeObjectProtection = in.KubeObjectProtection
 	out.MultiNamespace = in.MultiNamespace
+	in.MetadataPropagation.DeepCopyInto(&out.MetadataPropagation)
+	if in.DrClusterManifests != nil {
+		in, out := &in.DrClusterManifests, &out.DrClusterManifests
+		*out = make([]ManifestTemplate, len(*in))
+		copy(*out, *in)
+	}
+	out.ClusterProxy = in.ClusterProxy
+	in.ContinuousValidation.DeepCopyInto(&out.ContinuousValidation)
+	in.ManagedClusterViews.DeepCopyInto(&out.ManagedClusterViews)
+	out.APIClient = in.APIClient
+	out.DRPCFairQueuing = in.DRPCFairQueuing
+	in.S3ProfileHealthCheck.DeepCopyInto(&out.S3ProfileHealthCheck)
+	in.S3GarbageCollection.DeepCopyInto(&out.S3GarbageCollection)
+	out.S3ProfileValidation = in.S3ProfileValidation
+	in.MaintenanceModes.DeepCopyInto(&out.MaintenanceModes)
+	out.ManifestTransport = in.ManifestTransport
+	in.FailoverProbes.DeepCopyInto(&out.FailoverProbes)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RamenConfig.
@@ -1294,168 +2791,379 @@ func (in *ReplicationGroupSourceList) DeepCopyInto(out *ReplicationGroupSourceLi
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationGroupSourceList.
-func (in *ReplicationGroupSourceList) DeepCopy() *ReplicationGroupSourceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationGroupSourceList.
+func (in *ReplicationGroupSourceList) DeepCopy() *ReplicationGroupSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationGroupSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplicationGroupSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationGroupSourceSpec) DeepCopyInto(out *ReplicationGroupSourceSpec) {
+	*out = *in
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = new(ReplicationSourceTriggerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeGroupSnapshotSource != nil {
+		in, out := &in.VolumeGroupSnapshotSource, &out.VolumeGroupSnapshotSource
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RSSpec != nil {
+		in, out := &in.RSSpec, &out.RSSpec
+		*out = make([]VolSyncReplicationSourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationGroupSourceSpec.
+func (in *ReplicationGroupSourceSpec) DeepCopy() *ReplicationGroupSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationGroupSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationGroupSourceStatus) DeepCopyInto(out *ReplicationGroupSourceStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSyncStartTime != nil {
+		in, out := &in.LastSyncStartTime, &out.LastSyncStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSyncDuration != nil {
+		in, out := &in.LastSyncDuration, &out.LastSyncDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NextSyncTime != nil {
+		in, out := &in.NextSyncTime, &out.NextSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReplicationSources != nil {
+		in, out := &in.ReplicationSources, &out.ReplicationSources
+		*out = make([]*corev1.ObjectReference, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1.ObjectReference)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationGroupSourceStatus.
+func (in *ReplicationGroupSourceStatus) DeepCopy() *ReplicationGroupSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationGroupSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSourceTriggerSpec) DeepCopyInto(out *ReplicationSourceTriggerSpec) {
+	*out = *in
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceTriggerSpec.
+func (in *ReplicationSourceTriggerSpec) DeepCopy() *ReplicationSourceTriggerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSourceTriggerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RsyncTLSConfig) DeepCopyInto(out *RsyncTLSConfig) {
+	*out = *in
+	if in.TLSSecretRef != nil {
+		in, out := &in.TLSSecretRef, &out.TLSSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RsyncTLSConfig.
+func (in *RsyncTLSConfig) DeepCopy() *RsyncTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RsyncTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3GarbageCollection) DeepCopyInto(out *S3GarbageCollection) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetentionPeriod != nil {
+		in, out := &in.RetentionPeriod, &out.RetentionPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3GarbageCollection.
+func (in *S3GarbageCollection) DeepCopy() *S3GarbageCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(S3GarbageCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3KeyPrefix) DeepCopyInto(out *S3KeyPrefix) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3KeyPrefix.
+func (in *S3KeyPrefix) DeepCopy() *S3KeyPrefix {
+	if in == nil {
+		return nil
+	}
+	out := new(S3KeyPrefix)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3ProfileHealthCheck) DeepCopyInto(out *S3ProfileHealthCheck) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3ProfileHealthCheck.
+func (in *S3ProfileHealthCheck) DeepCopy() *S3ProfileHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(S3ProfileHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3ProfileMigration) DeepCopyInto(out *S3ProfileMigration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3ProfileMigration.
+func (in *S3ProfileMigration) DeepCopy() *S3ProfileMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(S3ProfileMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3ProfileMigrationStatus) DeepCopyInto(out *S3ProfileMigrationStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3ProfileMigrationStatus.
+func (in *S3ProfileMigrationStatus) DeepCopy() *S3ProfileMigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationGroupSourceList)
+	out := new(S3ProfileMigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ReplicationGroupSourceList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3ProfileUploadStatus) DeepCopyInto(out *S3ProfileUploadStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3ProfileUploadStatus.
+func (in *S3ProfileUploadStatus) DeepCopy() *S3ProfileUploadStatus {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(S3ProfileUploadStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationGroupSourceSpec) DeepCopyInto(out *ReplicationGroupSourceSpec) {
+func (in *S3ProfileValidation) DeepCopyInto(out *S3ProfileValidation) {
 	*out = *in
-	if in.Trigger != nil {
-		in, out := &in.Trigger, &out.Trigger
-		*out = new(ReplicationSourceTriggerSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VolumeGroupSnapshotSource != nil {
-		in, out := &in.VolumeGroupSnapshotSource, &out.VolumeGroupSnapshotSource
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RSSpec != nil {
-		in, out := &in.RSSpec, &out.RSSpec
-		*out = make([]VolSyncReplicationSourceSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationGroupSourceSpec.
-func (in *ReplicationGroupSourceSpec) DeepCopy() *ReplicationGroupSourceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3ProfileValidation.
+func (in *S3ProfileValidation) DeepCopy() *S3ProfileValidation {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationGroupSourceSpec)
+	out := new(S3ProfileValidation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationGroupSourceStatus) DeepCopyInto(out *ReplicationGroupSourceStatus) {
+func (in *S3StoreProfile) DeepCopyInto(out *S3StoreProfile) {
 	*out = *in
-	if in.LastSyncTime != nil {
-		in, out := &in.LastSyncTime, &out.LastSyncTime
-		*out = (*in).DeepCopy()
+	out.S3SecretRef = in.S3SecretRef
+	if in.S3SecretImpersonation != nil {
+		in, out := &in.S3SecretImpersonation, &out.S3SecretImpersonation
+		*out = new(ServiceAccountReference)
+		**out = **in
 	}
-	if in.LastSyncStartTime != nil {
-		in, out := &in.LastSyncStartTime, &out.LastSyncStartTime
-		*out = (*in).DeepCopy()
+	if in.VeleroNamespaceSecretKeyRef != nil {
+		in, out := &in.VeleroNamespaceSecretKeyRef, &out.VeleroNamespaceSecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.LastSyncDuration != nil {
-		in, out := &in.LastSyncDuration, &out.LastSyncDuration
-		*out = new(v1.Duration)
+	if in.CACertificates != nil {
+		in, out := &in.CACertificates, &out.CACertificates
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(ObjectCompression)
 		**out = **in
 	}
-	if in.NextSyncTime != nil {
-		in, out := &in.NextSyncTime, &out.NextSyncTime
-		*out = (*in).DeepCopy()
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ObjectStoreProxy)
+		**out = **in
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ClientSideEncryption != nil {
+		in, out := &in.ClientSideEncryption, &out.ClientSideEncryption
+		*out = new(ClientSideEncryption)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ReplicationSources != nil {
-		in, out := &in.ReplicationSources, &out.ReplicationSources
-		*out = make([]*corev1.ObjectReference, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(corev1.ObjectReference)
-				**out = **in
-			}
-		}
+	if in.S3WebIdentity != nil {
+		in, out := &in.S3WebIdentity, &out.S3WebIdentity
+		*out = new(S3WebIdentity)
+		**out = **in
+	}
+	if in.MultipartUpload != nil {
+		in, out := &in.MultipartUpload, &out.MultipartUpload
+		*out = new(MultipartUpload)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyPrefix != nil {
+		in, out := &in.KeyPrefix, &out.KeyPrefix
+		*out = new(S3KeyPrefix)
+		**out = **in
+	}
+	if in.Versioning != nil {
+		in, out := &in.Versioning, &out.Versioning
+		*out = new(S3Versioning)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationGroupSourceStatus.
-func (in *ReplicationGroupSourceStatus) DeepCopy() *ReplicationGroupSourceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3StoreProfile.
+func (in *S3StoreProfile) DeepCopy() *S3StoreProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationGroupSourceStatus)
+	out := new(S3StoreProfile)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationSourceTriggerSpec) DeepCopyInto(out *ReplicationSourceTriggerSpec) {
+func (in *S3Versioning) DeepCopyInto(out *S3Versioning) {
 	*out = *in
-	if in.Schedule != nil {
-		in, out := &in.Schedule, &out.Schedule
-		*out = new(string)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceTriggerSpec.
-func (in *ReplicationSourceTriggerSpec) DeepCopy() *ReplicationSourceTriggerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Versioning.
+func (in *S3Versioning) DeepCopy() *S3Versioning {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationSourceTriggerSpec)
+	out := new(S3Versioning)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RsyncTLSConfig) DeepCopyInto(out *RsyncTLSConfig) {
+func (in *S3WebIdentity) DeepCopyInto(out *S3WebIdentity) {
 	*out = *in
-	if in.TLSSecretRef != nil {
-		in, out := &in.TLSSecretRef, &out.TLSSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RsyncTLSConfig.
-func (in *RsyncTLSConfig) DeepCopy() *RsyncTLSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3WebIdentity.
+func (in *S3WebIdentity) DeepCopy() *S3WebIdentity {
 	if in == nil {
 		return nil
 	}
-	out := new(RsyncTLSConfig)
+	out := new(S3WebIdentity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *S3StoreProfile) DeepCopyInto(out *S3StoreProfile) {
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
 	*out = *in
-	out.S3SecretRef = in.S3SecretRef
-	if in.VeleroNamespaceSecretKeyRef != nil {
-		in, out := &in.VeleroNamespaceSecretKeyRef, &out.VeleroNamespaceSecretKeyRef
-		*out = new(corev1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CACertificates != nil {
-		in, out := &in.CACertificates, &out.CACertificates
-		*out = make([]byte, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3StoreProfile.
-func (in *S3StoreProfile) DeepCopy() *S3StoreProfile {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
 	if in == nil {
 		return nil
 	}
-	out := new(S3StoreProfile)
+	out := new(ServiceAccountReference)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1480,6 +3188,22 @@ func (in *StorageAccessDetail) DeepCopy() *StorageAccessDetail {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClassSchedulingInterval) DeepCopyInto(out *StorageClassSchedulingInterval) {
+	*out = *in
+	in.StorageClassSelector.DeepCopyInto(&out.StorageClassSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClassSchedulingInterval.
+func (in *StorageClassSchedulingInterval) DeepCopy() *StorageClassSchedulingInterval {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClassSchedulingInterval)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageIdentifiers) DeepCopyInto(out *StorageIdentifiers) {
 	*out = *in
@@ -1519,12 +3243,64 @@ func (in *Sync) DeepCopy() *Sync {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyMapping) DeepCopyInto(out *TopologyMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyMapping.
+func (in *TopologyMapping) DeepCopy() *TopologyMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnfenceAcknowledgment) DeepCopyInto(out *UnfenceAcknowledgment) {
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnfenceAcknowledgment.
+func (in *UnfenceAcknowledgment) DeepCopy() *UnfenceAcknowledgment {
+	if in == nil {
+		return nil
+	}
+	out := new(UnfenceAcknowledgment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VRGAsyncSpec) DeepCopyInto(out *VRGAsyncSpec) {
 	*out = *in
 	in.ReplicationClassSelector.DeepCopyInto(&out.ReplicationClassSelector)
+	if in.ReplicationClassNames != nil {
+		in, out := &in.ReplicationClassNames, &out.ReplicationClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.VolumeSnapshotClassSelector.DeepCopyInto(&out.VolumeSnapshotClassSelector)
 	in.VolumeGroupSnapshotClassSelector.DeepCopyInto(&out.VolumeGroupSnapshotClassSelector)
+	if in.BackupWindows != nil {
+		in, out := &in.BackupWindows, &out.BackupWindows
+		*out = make([]BackupWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageClassSchedulingIntervals != nil {
+		in, out := &in.StorageClassSchedulingIntervals, &out.StorageClassSchedulingIntervals
+		*out = make([]StorageClassSchedulingInterval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolSync != nil {
+		in, out := &in.VolSync, &out.VolSync
+		*out = new(VolSyncTuning)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PeerClasses != nil {
 		in, out := &in.PeerClasses, &out.PeerClasses
 		*out = make([]PeerClass, len(*in))
@@ -1567,6 +3343,46 @@ func (in *VRGConditions) DeepCopy() *VRGConditions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VRGDrillSpec) DeepCopyInto(out *VRGDrillSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRGDrillSpec.
+func (in *VRGDrillSpec) DeepCopy() *VRGDrillSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VRGDrillSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VRGDrillStatus) DeepCopyInto(out *VRGDrillStatus) {
+	*out = *in
+	if in.RestoredPVCs != nil {
+		in, out := &in.RestoredPVCs, &out.RestoredPVCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkippedPVCs != nil {
+		in, out := &in.SkippedPVCs, &out.SkippedPVCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRGDrillStatus.
+func (in *VRGDrillStatus) DeepCopy() *VRGDrillStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VRGDrillStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VRGResourceMeta) DeepCopyInto(out *VRGResourceMeta) {
 	*out = *in
@@ -1575,6 +3391,16 @@ func (in *VRGResourceMeta) DeepCopyInto(out *VRGResourceMeta) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExcludedPVCs != nil {
+		in, out := &in.ExcludedPVCs, &out.ExcludedPVCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OverriddenPDBs != nil {
+		in, out := &in.OverriddenPDBs, &out.OverriddenPDBs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.PVCGroups != nil {
 		in, out := &in.PVCGroups, &out.PVCGroups
 		*out = make([]Groups, len(*in))
@@ -1616,6 +3442,21 @@ func (in *VRGSyncSpec) DeepCopy() *VRGSyncSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ViewRefreshSpec) DeepCopyInto(out *ViewRefreshSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ViewRefreshSpec.
+func (in *ViewRefreshSpec) DeepCopy() *ViewRefreshSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ViewRefreshSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolSyncReplicationDestinationInfo) DeepCopyInto(out *VolSyncReplicationDestinationInfo) {
 	*out = *in
@@ -1720,6 +3561,36 @@ func (in *VolSyncSpec) DeepCopy() *VolSyncSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncTuning) DeepCopyInto(out *VolSyncTuning) {
+	*out = *in
+	if in.MoverResources != nil {
+		in, out := &in.MoverResources, &out.MoverResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CacheCapacity != nil {
+		in, out := &in.CacheCapacity, &out.CacheCapacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.PrivilegedMovers != nil {
+		in, out := &in.PrivilegedMovers, &out.PrivilegedMovers
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncTuning.
+func (in *VolSyncTuning) DeepCopy() *VolSyncTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeReplicationGroup) DeepCopyInto(out *VolumeReplicationGroup) {
 	*out = *in
@@ -1799,6 +3670,11 @@ func (in *VolumeReplicationGroupSpec) DeepCopyInto(out *VolumeReplicationGroupSp
 		(*in).DeepCopyInto(*out)
 	}
 	in.VolSync.DeepCopyInto(&out.VolSync)
+	if in.LaggingS3Profiles != nil {
+		in, out := &in.LaggingS3Profiles, &out.LaggingS3Profiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.KubeObjectProtection != nil {
 		in, out := &in.KubeObjectProtection, &out.KubeObjectProtection
 		*out = new(KubeObjectProtectionSpec)
@@ -1813,6 +3689,26 @@ func (in *VolumeReplicationGroupSpec) DeepCopyInto(out *VolumeReplicationGroupSp
 			copy(*out, *in)
 		}
 	}
+	if in.LocalFailover != nil {
+		in, out := &in.LocalFailover, &out.LocalFailover
+		*out = new(LocalFailoverSpec)
+		**out = **in
+	}
+	if in.FinalizationHooks != nil {
+		in, out := &in.FinalizationHooks, &out.FinalizationHooks
+		*out = new(FinalizationHooksSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PVTopologyMappings != nil {
+		in, out := &in.PVTopologyMappings, &out.PVTopologyMappings
+		*out = make([]TopologyMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drill != nil {
+		in, out := &in.Drill, &out.Drill
+		*out = new(VRGDrillSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationGroupSpec.
@@ -1835,6 +3731,16 @@ func (in *VolumeReplicationGroupStatus) DeepCopyInto(out *VolumeReplicationGroup
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExcludedPVCs != nil {
+		in, out := &in.ExcludedPVCs, &out.ExcludedPVCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OverriddenPDBs != nil {
+		in, out := &in.OverriddenPDBs, &out.OverriddenPDBs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.PVCGroups != nil {
 		in, out := &in.PVCGroups, &out.PVCGroups
 		*out = make([]Groups, len(*in))
@@ -1872,6 +3778,21 @@ func (in *VolumeReplicationGroupStatus) DeepCopyInto(out *VolumeReplicationGroup
 		*out = new(int64)
 		**out = **in
 	}
+	if in.LocalFailover != nil {
+		in, out := &in.LocalFailover, &out.LocalFailover
+		*out = new(LocalFailoverStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drill != nil {
+		in, out := &in.Drill, &out.Drill
+		*out = new(VRGDrillStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3ProfileUploads != nil {
+		in, out := &in.S3ProfileUploads, &out.S3ProfileUploads
+		*out = make([]S3ProfileUploadStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationGroupStatus.
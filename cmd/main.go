@@ -37,6 +37,7 @@ import (
 	gppv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
 	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
@@ -132,8 +133,11 @@ func configureController() error {
 	return nil
 }
 
-func newManager(options *ctrl.Options) (ctrl.Manager, error) {
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), *options)
+func newManager(options *ctrl.Options, ramenConfig *ramendrv1alpha1.RamenConfig) (ctrl.Manager, error) {
+	restConfig := ctrl.GetConfigOrDie()
+	controllers.ConfigureAPIClientBudget(restConfig, ramenConfig)
+
+	mgr, err := ctrl.NewManager(restConfig, *options)
 	if err != nil {
 		return mgr, fmt.Errorf("starting new manager failed %w", err)
 	}
@@ -169,21 +173,32 @@ func setupReconcilersCluster(mgr ctrl.Manager, ramenConfig *ramendrv1alpha1.Rame
 		os.Exit(1)
 	}
 
+	operandErrors := rmnutil.NewOperandErrorReporter()
+
 	if err := (&controllers.VolumeReplicationGroupReconciler{
 		Client:         mgr.GetClient(),
 		APIReader:      mgr.GetAPIReader(),
 		Log:            ctrl.Log.WithName("vrg"),
 		ObjStoreGetter: controllers.S3ObjectStoreGetter(),
 		Scheme:         mgr.GetScheme(),
+		OperandErrors:  operandErrors,
 	}).SetupWithManager(mgr, ramenConfig); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "VolumeReplicationGroup")
 		os.Exit(1)
 	}
 
+	csiAddonsInstalled, err := rmnutil.KindsInstalled(mgr.GetRESTMapper(), rmnutil.CSIAddonsFencingKinds...)
+	if err != nil {
+		setupLog.Error(err, "unable to detect csi-addons APIs", "controller", "DRClusterConfig")
+		os.Exit(1)
+	}
+
 	if err := (&controllers.DRClusterConfigReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log.WithName("drcc"),
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		Log:                  ctrl.Log.WithName("drcc"),
+		CSIAddonsUnavailable: !csiAddonsInstalled,
+		OperandErrors:        operandErrors,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DRClusterConfig")
 		os.Exit(1)
@@ -213,16 +228,69 @@ func setupReconcilersCluster(mgr ctrl.Manager, ramenConfig *ramendrv1alpha1.Rame
 	}
 }
 
+// newManagedClusterViewGetter returns the reader of objects on managed clusters selected by the ramen config
+func newManagedClusterViewGetter(mgr ctrl.Manager, ramenConfig *ramendrv1alpha1.RamenConfig,
+	viewIntervals *rmnutil.ClusterViewIntervals, viewRegistry *rmnutil.ManagedClusterViewRegistry, consumer string,
+) rmnutil.ManagedClusterViewGetter {
+	mcvGetter := rmnutil.ManagedClusterViewGetterImpl{
+		Client:    controllers.NewAPIUsageClient(mgr.GetClient(), "mcv"),
+		APIReader: controllers.NewAPIUsageReader(mgr.GetAPIReader(), "mcv"),
+		Config:    ramenConfig.ManagedClusterViews,
+		Intervals: viewIntervals,
+		Registry:  viewRegistry,
+		Consumer:  consumer,
+
+		ReadObserver: controllers.ObserveManagedClusterViewRead,
+	}
+
+	switch ramenConfig.ManagedClusterStatusSource {
+	case ramendrv1alpha1.ManagedClusterStatusSourceFeedback:
+		return rmnutil.ManifestWorkFeedbackGetter{ManagedClusterViewGetterImpl: mcvGetter}
+	case ramendrv1alpha1.ManagedClusterStatusSourceClusterProxy:
+		return rmnutil.NewClusterProxyGetter(mcvGetter, ramenConfig.ClusterProxy)
+	}
+
+	return mcvGetter
+}
+
 func setupReconcilersHub(mgr ctrl.Manager, ramenConfig *ramendrv1alpha1.RamenConfig) {
+	// Index fields that are required to list ManifestWorks by parent DRPC or by type
+	if err := rmnutil.IndexFieldsForManifestWorks(context.Background(), mgr.GetFieldIndexer(),
+		controllers.DRPCNameAnnotation, controllers.DRPCNamespaceAnnotation); err != nil {
+		setupLog.Error(err, "unable to index fields for controller", "controller", "DRPlacementControl")
+		os.Exit(1)
+	}
+
+	// S3 profiles may read their secrets as the service accounts they impersonate
+	controllers.ConfigureS3SecretImpersonation(mgr.GetConfig(),
+		client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+
+	// DRCluster and DRPC reconciles switch the refresh intervals of the views of the clusters of their actions
+	viewIntervals := rmnutil.NewClusterViewIntervals()
+
+	// DRPolicy, DRCluster and DRPC reconciles share the views of the objects they read, which are deleted once the
+	// last of them releases them
+	viewRegistry := rmnutil.NewManagedClusterViewRegistry()
+
+	// DRCluster and DRPC reconciles are enqueued as the results of the views they subscribe to change
+	viewSubscriptions := rmnutil.NewManagedClusterViewSubscriptions()
+
+	// DRCluster and DRPC reconciles record the state of their actions, checkpointed as the operator stops
+	actionTracker := controllers.NewActionTracker()
+
+	// DRPolicy and DRCluster reconciles share the cache of cluster configuration and class reads
+	drpMCVGetter := rmnutil.NewCachingManagedClusterViewGetter(
+		newManagedClusterViewGetter(mgr, ramenConfig, viewIntervals, viewRegistry, "drp"),
+		rmnutil.DefaultManagedClusterViewCacheTTLs)
+	drcMCVGetter := drpMCVGetter.WithGetter(
+		newManagedClusterViewGetter(mgr, ramenConfig, viewIntervals, viewRegistry, "drc"))
+
 	if err := (&controllers.DRPolicyReconciler{
-		Client:    mgr.GetClient(),
-		APIReader: mgr.GetAPIReader(),
-		Log:       ctrl.Log.WithName("drp"),
-		Scheme:    mgr.GetScheme(),
-		MCVGetter: rmnutil.ManagedClusterViewGetterImpl{
-			Client:    mgr.GetClient(),
-			APIReader: mgr.GetAPIReader(),
-		},
+		Client:            controllers.NewAPIUsageClient(mgr.GetClient(), "drp"),
+		APIReader:         controllers.NewAPIUsageReader(mgr.GetAPIReader(), "drp"),
+		Log:               ctrl.Log.WithName("drp"),
+		Scheme:            mgr.GetScheme(),
+		MCVGetter:         drpMCVGetter,
 		ObjectStoreGetter: controllers.S3ObjectStoreGetter(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DRPolicy")
@@ -230,35 +298,162 @@ func setupReconcilersHub(mgr ctrl.Manager, ramenConfig *ramendrv1alpha1.RamenCon
 	}
 
 	if err := (&controllers.DRClusterReconciler{
-		Client:    mgr.GetClient(),
-		APIReader: mgr.GetAPIReader(),
-		Log:       ctrl.Log.WithName("drc"),
-		Scheme:    mgr.GetScheme(),
-		MCVGetter: rmnutil.ManagedClusterViewGetterImpl{
-			Client:    mgr.GetClient(),
-			APIReader: mgr.GetAPIReader(),
-		},
+		Client:            controllers.NewAPIUsageClient(mgr.GetClient(), "drc"),
+		APIReader:         controllers.NewAPIUsageReader(mgr.GetAPIReader(), "drc"),
+		Log:               ctrl.Log.WithName("drc"),
+		Scheme:            mgr.GetScheme(),
+		MCVGetter:         drcMCVGetter,
 		ObjectStoreGetter: controllers.S3ObjectStoreGetter(),
+		ViewIntervals:     viewIntervals,
+		ViewSubscriptions: viewSubscriptions,
+		ActionTracker:     actionTracker,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DRCluster")
 		os.Exit(1)
 	}
 
 	if err := (&controllers.DRPlacementControlReconciler{
-		Client:    mgr.GetClient(),
-		APIReader: mgr.GetAPIReader(),
-		Log:       ctrl.Log.WithName("drpc"),
-		MCVGetter: rmnutil.ManagedClusterViewGetterImpl{
-			Client:    mgr.GetClient(),
-			APIReader: mgr.GetAPIReader(),
-		},
-		Scheme:         mgr.GetScheme(),
-		Callback:       func(string, string) {},
-		ObjStoreGetter: controllers.S3ObjectStoreGetter(),
+		Client:            controllers.NewAPIUsageClient(mgr.GetClient(), "drpc"),
+		APIReader:         controllers.NewAPIUsageReader(mgr.GetAPIReader(), "drpc"),
+		Log:               ctrl.Log.WithName("drpc"),
+		MCVGetter:         newManagedClusterViewGetter(mgr, ramenConfig, viewIntervals, viewRegistry, "drpc"),
+		Scheme:            mgr.GetScheme(),
+		Callback:          func(string, string) {},
+		ObjStoreGetter:    controllers.S3ObjectStoreGetter(),
+		ViewIntervals:     viewIntervals,
+		ViewSubscriptions: viewSubscriptions,
+		ActionTracker:     actionTracker,
 	}).SetupWithManager(mgr, ramenConfig); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DRPlacementControl")
 		os.Exit(1)
 	}
+
+	if err := (&controllers.DRPlacementControlActionReconciler{
+		Client: controllers.NewAPIUsageClient(mgr.GetClient(), "drpcaction"),
+		Log:    ctrl.Log.WithName("drpcaction"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DRPlacementControlAction")
+		os.Exit(1)
+	}
+
+	if err := (&controllers.DRClusterMigrationReconciler{
+		Client:    controllers.NewAPIUsageClient(mgr.GetClient(), "drcmigration"),
+		APIReader: controllers.NewAPIUsageReader(mgr.GetAPIReader(), "drcmigration"),
+		Log:       ctrl.Log.WithName("drcmigration"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DRClusterMigration")
+		os.Exit(1)
+	}
+
+	if err := (&controllers.DRDrillReconciler{
+		Client:    controllers.NewAPIUsageClient(mgr.GetClient(), "drdrill"),
+		APIReader: controllers.NewAPIUsageReader(mgr.GetAPIReader(), "drdrill"),
+		Log:       ctrl.Log.WithName("drdrill"),
+		MCVGetter: newManagedClusterViewGetter(mgr, ramenConfig, viewIntervals, viewRegistry, "drdrill"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DRDrill")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&controllers.ManagedClusterViewGarbageCollector{
+		Client:    controllers.NewAPIUsageClient(mgr.GetClient(), "mcvgc"),
+		APIReader: controllers.NewAPIUsageReader(mgr.GetAPIReader(), "mcvgc"),
+		Log:       ctrl.Log.WithName("mcvgc"),
+		Interval:  controllers.ManagedClusterViewGCInterval,
+	}); err != nil {
+		setupLog.Error(err, "unable to add ManagedClusterView garbage collector")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&controllers.ActionCheckpointer{
+		Client:  mgr.GetClient(),
+		Tracker: actionTracker,
+		Log:     ctrl.Log.WithName("checkpoint"),
+		Timeout: controllers.ActionCheckpointTimeout,
+	}); err != nil {
+		setupLog.Error(err, "unable to add in-flight action checkpointer")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&controllers.DRPolicyWorkloadsStatusAggregator{
+		Client:   controllers.NewAPIUsageClient(mgr.GetClient(), "drpstatus"),
+		Log:      ctrl.Log.WithName("drpstatus"),
+		Interval: controllers.DRPolicyWorkloadsStatusInterval,
+	}); err != nil {
+		setupLog.Error(err, "unable to add DRPolicy workloads status aggregator")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&controllers.DRPolicyHubProtector{
+		Client:            controllers.NewAPIUsageClient(mgr.GetClient(), "drphub"),
+		APIReader:         controllers.NewAPIUsageReader(mgr.GetAPIReader(), "drphub"),
+		ObjectStoreGetter: controllers.S3ObjectStoreGetter(),
+		Log:               ctrl.Log.WithName("drphub"),
+		Interval:          controllers.DRPolicyHubProtectionInterval,
+	}); err != nil {
+		setupLog.Error(err, "unable to add DRPolicy hub protector")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&controllers.DRPCAutoFailover{
+		Client:   controllers.NewAPIUsageClient(mgr.GetClient(), "autofailover"),
+		Log:      ctrl.Log.WithName("autofailover"),
+		Interval: controllers.DRPCAutoFailoverInterval,
+	}); err != nil {
+		setupLog.Error(err, "unable to add DRPC auto failover")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&controllers.LocalFailoverHeartbeater{
+		Client:            controllers.NewAPIUsageClient(mgr.GetClient(), "localfailover"),
+		APIReader:         controllers.NewAPIUsageReader(mgr.GetAPIReader(), "localfailover"),
+		ObjectStoreGetter: controllers.S3ObjectStoreGetter(),
+		Log:               ctrl.Log.WithName("localfailover"),
+		Interval:          controllers.LocalFailoverHeartbeatCheckInterval,
+	}); err != nil {
+		setupLog.Error(err, "unable to add local failover heartbeater")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&controllers.S3ProfileMigrator{
+		Client:            controllers.NewAPIUsageClient(mgr.GetClient(), "s3migration"),
+		APIReader:         controllers.NewAPIUsageReader(mgr.GetAPIReader(), "s3migration"),
+		ObjectStoreGetter: controllers.S3ObjectStoreGetter(),
+		Log:               ctrl.Log.WithName("s3migration"),
+		Interval:          controllers.S3ProfileMigrationInterval,
+	}); err != nil {
+		setupLog.Error(err, "unable to add S3 profile migrator")
+		os.Exit(1)
+	}
+
+	if controllers.S3ProfileHealthCheckEnabled(ramenConfig) {
+		if err := mgr.Add(&controllers.S3ProfileHealthChecker{
+			Client:            controllers.NewAPIUsageClient(mgr.GetClient(), "s3health"),
+			APIReader:         controllers.NewAPIUsageReader(mgr.GetAPIReader(), "s3health"),
+			ObjectStoreGetter: controllers.S3ObjectStoreGetter(),
+			Log:               ctrl.Log.WithName("s3health"),
+			Interval:          controllers.S3ProfileHealthCheckInterval(ramenConfig),
+		}); err != nil {
+			setupLog.Error(err, "unable to add S3 profile health checker")
+			os.Exit(1)
+		}
+	}
+
+	if controllers.S3GarbageCollectionEnabled(ramenConfig) {
+		if err := mgr.Add(&controllers.S3GarbageCollector{
+			Client:            controllers.NewAPIUsageClient(mgr.GetClient(), "s3gc"),
+			APIReader:         controllers.NewAPIUsageReader(mgr.GetAPIReader(), "s3gc"),
+			ObjectStoreGetter: controllers.S3ObjectStoreGetter(),
+			Log:               ctrl.Log.WithName("s3gc"),
+			Interval:          controllers.S3GarbageCollectionInterval(ramenConfig),
+			RetentionPeriod:   controllers.S3GarbageCollectionRetentionPeriod(ramenConfig),
+			DryRun:            ramenConfig.S3GarbageCollection.DryRun,
+		}); err != nil {
+			setupLog.Error(err, "unable to add S3 garbage collector")
+			os.Exit(1)
+		}
+	}
 }
 
 func main() {
@@ -280,7 +475,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	mgr, err := newManager(ctrlOptions)
+	mgr, err := newManager(ctrlOptions, ramenConfig)
 	if err != nil {
 		setupLog.Error(err, "unable to Get new manager")
 		os.Exit(1)
@@ -0,0 +1,300 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// ActionCheckpointTimeout bounds the time the checkpointer takes to checkpoint the in-flight actions when the
+// operator stops. It is to be shorter than the graceful shutdown timeout of the manager.
+const ActionCheckpointTimeout = 15 * time.Second
+
+// ActionTracker tracks the phase and progression that the actions of the DRPCs and DRClusters reach in the memory
+// of their reconciles, until the reconciles persist them in the status. A reconcile interrupted as the operator stops
+// fails to persist them, and the ActionCheckpointer checkpoints them instead.
+type ActionTracker struct {
+	mu         sync.Mutex
+	reconciles int
+	drpcs      map[types.NamespacedName]rmn.ActionCheckpoint
+	drClusters map[string]rmn.ActionCheckpoint
+}
+
+func NewActionTracker() *ActionTracker {
+	return &ActionTracker{
+		drpcs:      map[types.NamespacedName]rmn.ActionCheckpoint{},
+		drClusters: map[string]rmn.ActionCheckpoint{},
+	}
+}
+
+// reconcileStart counts a reconcile in flight, until the returned function is called as the reconcile returns
+func (t *ActionTracker) reconcileStart() func() {
+	if t == nil {
+		return func() {}
+	}
+
+	t.mu.Lock()
+	t.reconciles++
+	t.mu.Unlock()
+
+	return func() {
+		t.mu.Lock()
+		t.reconciles--
+		t.mu.Unlock()
+	}
+}
+
+// reconcilesDone returns whether no reconcile is in flight
+func (t *ActionTracker) reconcilesDone() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.reconciles == 0
+}
+
+// drpcRecord records the phase and progression of the action of the DRPC in memory
+func (t *ActionTracker) drpcRecord(drpc *rmn.DRPlacementControl) {
+	if t == nil || drpc.Spec.Action == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.drpcs[client.ObjectKeyFromObject(drpc)] = rmn.ActionCheckpoint{
+		Action:        string(drpc.Spec.Action),
+		TargetCluster: drpcActionTarget(drpc),
+		Generation:    drpc.Generation,
+		Phase:         string(drpc.Status.Phase),
+		Progression:   string(drpc.Status.Progression),
+	}
+}
+
+// drpcPersisted forgets the action of the DRPC whose status was persisted
+func (t *ActionTracker) drpcPersisted(drpc *rmn.DRPlacementControl) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.drpcs, client.ObjectKeyFromObject(drpc))
+}
+
+// drClusterRecord records the phase of the fence or unfence operation of the DRCluster in memory
+func (t *ActionTracker) drClusterRecord(drCluster *rmn.DRCluster) {
+	if t == nil || drCluster.Spec.ClusterFence == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.drClusters[drCluster.GetName()] = rmn.ActionCheckpoint{
+		Action:     string(drCluster.Spec.ClusterFence),
+		Generation: drCluster.Generation,
+		Phase:      string(drCluster.Status.Phase),
+	}
+}
+
+// drClusterPersisted forgets the operation of the DRCluster whose status was persisted
+func (t *ActionTracker) drClusterPersisted(drCluster *rmn.DRCluster) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.drClusters, drCluster.GetName())
+}
+
+// unpersisted returns copies of the actions whose state is not persisted
+func (t *ActionTracker) unpersisted() (map[types.NamespacedName]rmn.ActionCheckpoint,
+	map[string]rmn.ActionCheckpoint,
+) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	drpcs := make(map[types.NamespacedName]rmn.ActionCheckpoint, len(t.drpcs))
+	for key, checkpoint := range t.drpcs {
+		drpcs[key] = checkpoint
+	}
+
+	drClusters := make(map[string]rmn.ActionCheckpoint, len(t.drClusters))
+	for name, checkpoint := range t.drClusters {
+		drClusters[name] = checkpoint
+	}
+
+	return drpcs, drClusters
+}
+
+// ActionCheckpointer checkpoints the failovers, relocations, fences and unfences whose state the reconciles did not
+// persist as the hub operator stops, for example as it is upgraded, into the status of their DRPCs and DRClusters.
+// The operator that takes over resumes each action from its checkpoint, if its spec did not change since.
+type ActionCheckpointer struct {
+	client.Client
+	Tracker *ActionTracker
+	Log     logr.Logger
+	Timeout time.Duration
+}
+
+// NeedLeaderElection runs the checkpointer only on the leader, which runs the actions
+func (c *ActionCheckpointer) NeedLeaderElection() bool {
+	return true
+}
+
+// Start waits for the operator to stop, lets the reconciles in flight return, and checkpoints the actions whose state
+// they did not persist. The manager waits for the checkpointer before it releases the leader lease, so that the
+// operator taking over reads the checkpoints.
+func (c *ActionCheckpointer) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = ActionCheckpointTimeout
+	}
+
+	checkpointCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	drainCtx, drainCancel := context.WithTimeout(checkpointCtx, timeout/2)
+	defer drainCancel()
+
+	if err := wait.PollUntilContextCancel(drainCtx, 100*time.Millisecond, true,
+		func(context.Context) (bool, error) { return c.Tracker.reconcilesDone(), nil },
+	); err != nil {
+		c.Log.Info("Reconciles still in flight, checkpointing their last recorded state")
+	}
+
+	if err := c.checkpoint(checkpointCtx, metav1.Now()); err != nil {
+		c.Log.Error(err, "Failed to checkpoint in-flight actions")
+	}
+
+	return nil
+}
+
+// checkpoint records the actions whose state is not persisted in the status of their DRPCs and DRClusters
+func (c *ActionCheckpointer) checkpoint(ctx context.Context, now metav1.Time) error {
+	drpcCheckpoints, drClusterCheckpoints := c.Tracker.unpersisted()
+
+	var errs []error
+
+	for key, checkpoint := range drpcCheckpoints {
+		checkpoint.Time = now
+		drpc := &rmn.DRPlacementControl{}
+
+		errs = append(errs, c.statusPatch(ctx, key, drpc, checkpoint, func() {
+			drpc.Status.ActionCheckpoint = &checkpoint
+		}))
+	}
+
+	for name, checkpoint := range drClusterCheckpoints {
+		checkpoint.Time = now
+		drCluster := &rmn.DRCluster{}
+
+		errs = append(errs, c.statusPatch(ctx, types.NamespacedName{Name: name}, drCluster, checkpoint, func() {
+			drCluster.Status.ActionCheckpoint = &checkpoint
+		}))
+	}
+
+	return errors.Join(errs...)
+}
+
+// statusPatch patches the status of the object with the checkpoint set by mutate, unless the object is gone or its
+// spec changed since the checkpoint was recorded
+func (c *ActionCheckpointer) statusPatch(ctx context.Context, key types.NamespacedName, obj client.Object,
+	checkpoint rmn.ActionCheckpoint, mutate func(),
+) error {
+	if err := c.Get(ctx, key, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if obj.GetGeneration() != checkpoint.Generation {
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+
+	mutate()
+
+	if err := c.Status().Patch(ctx, obj, patch); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to checkpoint %T %s: %w", obj, key, err)
+	}
+
+	c.Log.Info("Checkpointed in-flight action", "kind", fmt.Sprintf("%T", obj), "name", key,
+		"checkpoint", checkpoint)
+
+	return nil
+}
+
+// drpcActionTarget returns the cluster that the action of drpc targets
+func drpcActionTarget(drpc *rmn.DRPlacementControl) string {
+	if drpc.Spec.Action == rmn.ActionFailover {
+		return drpcFailoverCluster(drpc)
+	}
+
+	return drpc.Spec.PreferredCluster
+}
+
+// resumeDRPCFromActionCheckpoint resumes the action of drpc from its checkpoint, restoring the phase and progression
+// the action reached in the memory of the previous operator, which its interrupted reconcile did not persist. The
+// checkpoint is dropped if the spec changed since it was taken.
+func resumeDRPCFromActionCheckpoint(drpc *rmn.DRPlacementControl, tracker *ActionTracker, log logr.Logger) {
+	checkpoint := drpc.Status.ActionCheckpoint
+	if checkpoint == nil {
+		return
+	}
+
+	drpc.Status.ActionCheckpoint = nil
+
+	if checkpoint.Generation != drpc.Generation || checkpoint.Action != string(drpc.Spec.Action) {
+		log.Info("Dropping checkpoint of an action no longer requested", "checkpoint", checkpoint)
+
+		return
+	}
+
+	drpc.Status.Phase = rmn.DRState(checkpoint.Phase)
+	drpc.Status.Progression = rmn.ProgressionStatus(checkpoint.Progression)
+	tracker.drpcRecord(drpc)
+
+	log.Info("Resuming action from checkpoint", "checkpoint", checkpoint)
+}
+
+// resumeFromActionCheckpoint resumes the fence or unfence operation of the DRCluster from its checkpoint, restoring
+// the phase the operation reached in the memory of the previous operator, which its interrupted reconcile did not
+// persist. The checkpoint is dropped if the spec changed since it was taken.
+func (u *drclusterInstance) resumeFromActionCheckpoint() {
+	checkpoint := u.object.Status.ActionCheckpoint
+	if checkpoint == nil {
+		return
+	}
+
+	u.object.Status.ActionCheckpoint = nil
+
+	if checkpoint.Generation != u.object.Generation || checkpoint.Action != string(u.object.Spec.ClusterFence) {
+		u.log.Info("Dropping checkpoint of an action no longer requested", "checkpoint", checkpoint)
+
+		return
+	}
+
+	u.object.Status.Phase = rmn.DRClusterPhase(checkpoint.Phase)
+	u.actionTracker().drClusterRecord(u.object)
+
+	u.log.Info("Resuming action from checkpoint", "checkpoint", checkpoint)
+}
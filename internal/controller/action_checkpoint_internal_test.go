@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("ActionCheckpointer", func() {
+	var (
+		fakeClient   client.Client
+		tracker      *ActionTracker
+		checkpointer *ActionCheckpointer
+		drpc         *rmn.DRPlacementControl
+		drCluster    *rmn.DRCluster
+	)
+
+	get := func(obj client.Object) {
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+	}
+
+	// progress advances the failover of the DRPC in memory, as an interrupted reconcile does without persisting it
+	progress := func() {
+		d := &DRPCInstance{
+			reconciler: &DRPlacementControlReconciler{ActionTracker: tracker},
+			log:        logr.Discard(),
+			instance:   drpc.DeepCopy(),
+		}
+
+		d.setProgression(rmn.ProgressionWaitingForResourceRestore)
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+
+		drpc = &rmn.DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc", Generation: 2},
+			Spec:       rmn.DRPlacementControlSpec{Action: rmn.ActionFailover, FailoverCluster: "west"},
+			Status: rmn.DRPlacementControlStatus{
+				Phase:       rmn.FailingOver,
+				Progression: rmn.ProgressionFailingOverToCluster,
+			},
+		}
+		drCluster = &rmn.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "east", Generation: 3},
+			Spec:       rmn.DRClusterSpec{ClusterFence: rmn.ClusterFenceStateFenced},
+			Status:     rmn.DRClusterStatus{Phase: rmn.Available},
+		}
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&rmn.DRPlacementControl{}, &rmn.DRCluster{}).
+			WithObjects(drpc, drCluster).Build()
+		get(drpc)
+		get(drCluster)
+
+		tracker = NewActionTracker()
+		checkpointer = &ActionCheckpointer{Client: fakeClient, Tracker: tracker, Log: logr.Discard()}
+	})
+
+	It("checkpoints the progress of a DRPC action that its reconcile did not persist", func() {
+		progress()
+
+		Expect(checkpointer.checkpoint(context.TODO(), metav1.Now())).To(Succeed())
+
+		get(drpc)
+		Expect(drpc.Status.Progression).To(Equal(rmn.ProgressionFailingOverToCluster))
+		Expect(drpc.Status.ActionCheckpoint).ToNot(BeNil())
+		Expect(drpc.Status.ActionCheckpoint.TargetCluster).To(Equal("west"))
+		Expect(drpc.Status.ActionCheckpoint.Generation).To(Equal(int64(2)))
+		Expect(drpc.Status.ActionCheckpoint.Progression).To(Equal(string(rmn.ProgressionWaitingForResourceRestore)))
+
+		resumeDRPCFromActionCheckpoint(drpc, nil, logr.Discard())
+		Expect(drpc.Status.ActionCheckpoint).To(BeNil())
+		Expect(drpc.Status.Phase).To(Equal(rmn.FailingOver))
+		Expect(drpc.Status.Progression).To(Equal(rmn.ProgressionWaitingForResourceRestore))
+	})
+
+	It("checkpoints nothing for a DRPC action whose progress was persisted", func() {
+		progress()
+		tracker.drpcPersisted(drpc)
+
+		Expect(checkpointer.checkpoint(context.TODO(), metav1.Now())).To(Succeed())
+
+		get(drpc)
+		Expect(drpc.Status.ActionCheckpoint).To(BeNil())
+	})
+
+	It("drops the checkpoint of a DRPC whose spec changed since", func() {
+		progress()
+		Expect(checkpointer.checkpoint(context.TODO(), metav1.Now())).To(Succeed())
+		get(drpc)
+
+		drpc.Generation++
+		drpc.Spec.Action = rmn.ActionRelocate
+
+		resumeDRPCFromActionCheckpoint(drpc, nil, logr.Discard())
+		Expect(drpc.Status.ActionCheckpoint).To(BeNil())
+		Expect(drpc.Status.Progression).To(Equal(rmn.ProgressionFailingOverToCluster))
+	})
+
+	It("checkpoints the phase of a fence operation of a DRCluster that its reconcile did not persist", func() {
+		u := &drclusterInstance{
+			reconciler: &DRClusterReconciler{ActionTracker: tracker},
+			log:        logr.Discard(),
+			object:     drCluster.DeepCopy(),
+		}
+		u.setDRClusterPhase(rmn.Fencing)
+
+		Expect(checkpointer.checkpoint(context.TODO(), metav1.Now())).To(Succeed())
+
+		get(drCluster)
+		Expect(drCluster.Status.Phase).To(Equal(rmn.Available))
+		Expect(drCluster.Status.ActionCheckpoint).ToNot(BeNil())
+		Expect(drCluster.Status.ActionCheckpoint.Phase).To(Equal(string(rmn.Fencing)))
+
+		u = &drclusterInstance{log: logr.Discard(), object: drCluster}
+		u.resumeFromActionCheckpoint()
+		Expect(drCluster.Status.ActionCheckpoint).To(BeNil())
+		Expect(drCluster.Status.Phase).To(Equal(rmn.Fencing))
+	})
+
+	It("lets the reconciles in flight return before checkpointing", func() {
+		done := tracker.reconcileStart()
+		Expect(tracker.reconcilesDone()).To(BeFalse())
+
+		done()
+		Expect(tracker.reconcilesDone()).To(BeTrue())
+	})
+})
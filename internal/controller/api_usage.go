@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	apiCallSourceCache     = "cache"
+	apiCallSourceAPIServer = "apiserver"
+
+	apiVerbGet              = "get"
+	apiVerbList             = "list"
+	apiVerbCreate           = "create"
+	apiVerbUpdate           = "update"
+	apiVerbPatch            = "patch"
+	apiVerbApply            = "apply"
+	apiVerbDelete           = "delete"
+	apiVerbDeleteCollection = "deletecollection"
+)
+
+// ConfigureAPIClientBudget sets the client-side rate limit of the requests to the apiserver made with restConfig to
+// the budget in the ramen config. Requests over the budget wait in the client, and the wait is logged by client-go.
+func ConfigureAPIClientBudget(restConfig *rest.Config, ramenConfig *ramen.RamenConfig) {
+	if ramenConfig.APIClient.QPS > 0 {
+		restConfig.QPS = ramenConfig.APIClient.QPS
+	}
+
+	if ramenConfig.APIClient.Burst > 0 {
+		restConfig.Burst = int(ramenConfig.APIClient.Burst)
+	}
+}
+
+// NewAPIUsageClient returns a client that counts the calls made with c by the controller, in the
+// api_client_calls_total metric
+func NewAPIUsageClient(c client.Client, controller string) client.Client {
+	return &apiUsageClient{Client: c, controller: controller}
+}
+
+// NewAPIUsageReader returns a reader that counts the calls made with reader, which reads from the apiserver, by the
+// controller, in the api_client_calls_total metric
+func NewAPIUsageReader(reader client.Reader, controller string) client.Reader {
+	return &apiUsageReader{Reader: reader, controller: controller}
+}
+
+func countAPICall(controller, verb, source string) {
+	NewAPIClientCallsMetric(APIClientCallsLabels(controller, verb, source)).Inc()
+}
+
+// cachedReadSource returns what serves a read of obj by the client of the manager, which reads unstructured objects
+// from the apiserver and all others from its cache
+func cachedReadSource(obj runtime.Object) string {
+	if _, ok := obj.(runtime.Unstructured); ok {
+		return apiCallSourceAPIServer
+	}
+
+	return apiCallSourceCache
+}
+
+type apiUsageClient struct {
+	client.Client
+	controller string
+}
+
+func (c *apiUsageClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object,
+	opts ...client.GetOption,
+) error {
+	countAPICall(c.controller, apiVerbGet, cachedReadSource(obj))
+
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *apiUsageClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	countAPICall(c.controller, apiVerbList, cachedReadSource(list))
+
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *apiUsageClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	countAPICall(c.controller, apiVerbCreate, apiCallSourceAPIServer)
+
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *apiUsageClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	countAPICall(c.controller, apiVerbUpdate, apiCallSourceAPIServer)
+
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *apiUsageClient) Patch(ctx context.Context, obj client.Object, patch client.Patch,
+	opts ...client.PatchOption,
+) error {
+	countAPICall(c.controller, apiVerbPatch, apiCallSourceAPIServer)
+
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *apiUsageClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	countAPICall(c.controller, apiVerbApply, apiCallSourceAPIServer)
+
+	return c.Client.Apply(ctx, obj, opts...)
+}
+
+func (c *apiUsageClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	countAPICall(c.controller, apiVerbDelete, apiCallSourceAPIServer)
+
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *apiUsageClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	countAPICall(c.controller, apiVerbDeleteCollection, apiCallSourceAPIServer)
+
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *apiUsageClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *apiUsageClient) SubResource(subResource string) client.SubResourceClient {
+	return &apiUsageSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), controller: c.controller}
+}
+
+// apiUsageSubResourceClient counts the calls to a subresource, which are not served by the cache
+type apiUsageSubResourceClient struct {
+	client.SubResourceClient
+	controller string
+}
+
+func (c *apiUsageSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object,
+	opts ...client.SubResourceGetOption,
+) error {
+	countAPICall(c.controller, apiVerbGet, apiCallSourceAPIServer)
+
+	return c.SubResourceClient.Get(ctx, obj, subResource, opts...)
+}
+
+func (c *apiUsageSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object,
+	opts ...client.SubResourceCreateOption,
+) error {
+	countAPICall(c.controller, apiVerbCreate, apiCallSourceAPIServer)
+
+	return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+}
+
+func (c *apiUsageSubResourceClient) Update(ctx context.Context, obj client.Object,
+	opts ...client.SubResourceUpdateOption,
+) error {
+	countAPICall(c.controller, apiVerbUpdate, apiCallSourceAPIServer)
+
+	return c.SubResourceClient.Update(ctx, obj, opts...)
+}
+
+func (c *apiUsageSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch,
+	opts ...client.SubResourcePatchOption,
+) error {
+	countAPICall(c.controller, apiVerbPatch, apiCallSourceAPIServer)
+
+	return c.SubResourceClient.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *apiUsageSubResourceClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration,
+	opts ...client.SubResourceApplyOption,
+) error {
+	countAPICall(c.controller, apiVerbApply, apiCallSourceAPIServer)
+
+	return c.SubResourceClient.Apply(ctx, obj, opts...)
+}
+
+type apiUsageReader struct {
+	client.Reader
+	controller string
+}
+
+func (r *apiUsageReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object,
+	opts ...client.GetOption,
+) error {
+	countAPICall(r.controller, apiVerbGet, apiCallSourceAPIServer)
+
+	return r.Reader.Get(ctx, key, obj, opts...)
+}
+
+func (r *apiUsageReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	countAPICall(r.controller, apiVerbList, apiCallSourceAPIServer)
+
+	return r.Reader.List(ctx, list, opts...)
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("API usage accounting", func() {
+	calls := func(controller, verb, source string) float64 {
+		families, err := metrics.Registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		labels := APIClientCallsLabels(controller, verb, source)
+
+		for _, family := range families {
+			if family.GetName() != metricNamespace+"_"+APIClientCalls {
+				continue
+			}
+
+			for _, metric := range family.GetMetric() {
+				matched := 0
+
+				for _, label := range metric.GetLabel() {
+					if labels[label.GetName()] == label.GetValue() {
+						matched++
+					}
+				}
+
+				if matched == len(labels) {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+
+		return 0
+	}
+
+	It("counts the calls of a controller by verb and source", func() {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&rmn.DRCluster{}).Build()
+		c := NewAPIUsageClient(fakeClient, "test-usage")
+		reader := NewAPIUsageReader(fakeClient, "test-usage")
+
+		drCluster := &rmn.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+		Expect(c.Create(context.TODO(), drCluster)).To(Succeed())
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, drCluster)).To(Succeed())
+		Expect(c.Status().Update(context.TODO(), drCluster)).To(Succeed())
+		Expect(reader.List(context.TODO(), &rmn.DRClusterList{})).To(Succeed())
+
+		Expect(calls("test-usage", apiVerbCreate, apiCallSourceAPIServer)).To(Equal(1.0))
+		Expect(calls("test-usage", apiVerbGet, apiCallSourceCache)).To(Equal(1.0))
+		Expect(calls("test-usage", apiVerbUpdate, apiCallSourceAPIServer)).To(Equal(1.0))
+		Expect(calls("test-usage", apiVerbList, apiCallSourceAPIServer)).To(Equal(1.0))
+	})
+
+	It("applies the budget of the ramen config to the rest config", func() {
+		restConfig := &rest.Config{QPS: 20, Burst: 30}
+
+		ConfigureAPIClientBudget(restConfig, &rmn.RamenConfig{})
+		Expect(restConfig.QPS).To(Equal(float32(20)))
+
+		ConfigureAPIClientBudget(restConfig, &rmn.RamenConfig{APIClient: rmn.APIClientConfig{QPS: 5, Burst: 10}})
+		Expect(restConfig.QPS).To(Equal(float32(5)))
+		Expect(restConfig.Burst).To(Equal(10))
+	})
+})
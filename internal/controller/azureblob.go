@@ -0,0 +1,380 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+const (
+	azureBlobAPIVersion = "2021-08-06"
+
+	azureBlobErrorCodeBlobNotFound = "BlobNotFound"
+)
+
+// azureBlobObjectStore is an ObjectStorer of the blobs of a container of an Azure storage account, accessed through
+// the Blob service REST API with the shared key of the account. The names of the blobs are the keys of the objects,
+// so that, as in an S3 bucket, listing the blobs with a prefix lists the keys of the objects with that prefix.
+type azureBlobObjectStore struct {
+	client      *http.Client
+	endpoint    string
+	container   string
+	accountName string
+	accountKey  []byte
+	callerTag   string
+	name        string
+	compression objectCompression
+}
+
+// azureBlobError is an error response of the Blob service. It satisfies awserr.Error, so that it is treated as a
+// persistent error of the object store, like an error response of an S3 store.
+type azureBlobError struct {
+	statusCode int
+	code       string
+	message    string
+}
+
+func (e azureBlobError) Error() string {
+	return fmt.Sprintf("%s: %s, status: %d", e.code, e.message, e.statusCode)
+}
+
+func (e azureBlobError) Code() string    { return e.code }
+func (e azureBlobError) Message() string { return e.message }
+func (e azureBlobError) OrigErr() error  { return nil }
+
+func newAzureBlobObjectStore(ctx context.Context, r client.Reader, s3StoreProfile ramen.S3StoreProfile,
+	callerTag string,
+) (ObjectStorer, error) {
+	accountName, accountKey, err := GetAzureBlobSecret(ctx, r, s3StoreProfile.S3SecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := objectStoreTransport(s3StoreProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := objectStoreClientCertificateSet(ctx, r, s3StoreProfile, transport); err != nil {
+		return nil, fmt.Errorf("failed to get client certificate of profile %s for caller %s, %w",
+			s3StoreProfile.S3ProfileName, callerTag, err)
+	}
+
+	endpoint, err := objectStoreEndpoint(s3StoreProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	compression, err := objectCompressionGet(s3StoreProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azureBlobObjectStore{
+		client:      &http.Client{Transport: transport, Timeout: s3Timeout},
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		container:   s3StoreProfile.S3Bucket,
+		accountName: accountName,
+		accountKey:  accountKey,
+		callerTag:   callerTag,
+		name:        s3StoreProfile.S3ProfileName,
+		compression: compression,
+	}, nil
+}
+
+// GetAzureBlobSecret returns the storage account name and decoded key in the secret of an AzureBlob profile
+func GetAzureBlobSecret(ctx context.Context, r client.Reader, secretRef corev1.SecretReference,
+) (accountName string, accountKey []byte, err error) {
+	secret := corev1.Secret{}
+	namespacedName := types.NamespacedName{Namespace: secretRef.Namespace, Name: secretRef.Name}
+
+	if namespacedName.Namespace == "" {
+		namespacedName.Namespace = RamenOperatorNamespace()
+	}
+
+	if err := r.Get(ctx, namespacedName, &secret); err != nil {
+		return "", nil, fmt.Errorf("failed to get secret %v, %w", secretRef, err)
+	}
+
+	accountName = string(secret.Data[util.AzureStorageAccountNameKey])
+	if accountName == "" {
+		return "", nil, fmt.Errorf("secret %v has no %s", secretRef, util.AzureStorageAccountNameKey)
+	}
+
+	accountKey, err = base64.StdEncoding.DecodeString(string(secret.Data[util.AzureStorageAccountKeyKey]))
+	if err != nil || len(accountKey) == 0 {
+		return "", nil, fmt.Errorf("secret %v has no valid %s", secretRef, util.AzureStorageAccountKeyKey)
+	}
+
+	return accountName, accountKey, nil
+}
+
+// UploadObject uploads the given object as a block blob named key, json encoded and compressed as by an S3 store
+func (s *azureBlobObjectStore) UploadObject(key string, uploadContent interface{}) error {
+	encodedUploadContent, err := s.compression.Encode(uploadContent)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s:%s, %w", s.container, key, err)
+	}
+
+	headers := map[string]string{"x-ms-blob-type": "BlockBlob"}
+	if contentEncoding := s.compression.ContentEncoding(); contentEncoding != "" {
+		headers["x-ms-blob-content-encoding"] = contentEncoding
+	}
+
+	if _, err := s.do(http.MethodPut, key, nil, encodedUploadContent, headers); err != nil {
+		return processAwsError(fmt.Errorf("failed to upload data of %s:%s", s.container, key), err)
+	}
+
+	return nil
+}
+
+// DownloadObject downloads the blob named key, decompresses it if it is compressed, and decodes it into
+// downloadContent
+func (s *azureBlobObjectStore) DownloadObject(key string, downloadContent interface{}) error {
+	data, err := s.do(http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return processAwsError(fmt.Errorf("failed to download data of %s:%s", s.container, key), err)
+	}
+
+	if err := metadata.Decode(data, downloadContent); err != nil {
+		return fmt.Errorf("failed to decode data of %s:%s, %w", s.container, key, err)
+	}
+
+	return nil
+}
+
+// ListKeys lists the names of the blobs of the container with the given prefix
+func (s *azureBlobObjectStore) ListKeys(keyPrefix string) ([]string, error) {
+	keys := []string{}
+	query := url.Values{"restype": {"container"}, "comp": {"list"}, "prefix": {keyPrefix}}
+
+	for {
+		data, err := s.do(http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return nil, processAwsError(fmt.Errorf("failed to list objects in container"), err)
+		}
+
+		result := struct {
+			Blobs struct {
+				Blob []struct {
+					Name string `xml:"Name"`
+				} `xml:"Blob"`
+			} `xml:"Blobs"`
+			NextMarker string `xml:"NextMarker"`
+		}{}
+
+		if err := xml.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode the list of objects in container %s, %w", s.container, err)
+		}
+
+		for _, blob := range result.Blobs.Blob {
+			keys = append(keys, blob.Name)
+		}
+
+		if result.NextMarker == "" {
+			return keys, nil
+		}
+
+		query.Set("marker", result.NextMarker)
+	}
+}
+
+// DeleteObject deletes the blob named key, if it exists
+func (s *azureBlobObjectStore) DeleteObject(key string) error {
+	if _, err := s.do(http.MethodDelete, key, nil, nil, nil); err != nil {
+		var blobErr azureBlobError
+		if errors.As(err, &blobErr) && blobErr.code == azureBlobErrorCodeBlobNotFound {
+			return nil
+		}
+
+		return processAwsError(fmt.Errorf("failed to delete object %s", key), err)
+	}
+
+	return nil
+}
+
+func (s *azureBlobObjectStore) DeleteObjects(keys ...string) error {
+	for _, key := range keys {
+		if err := s.DeleteObject(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteObjectsWithKeyPrefix deletes the blobs of the container with the given prefix
+func (s *azureBlobObjectStore) DeleteObjectsWithKeyPrefix(keyPrefix string) error {
+	keys, err := s.ListKeys(keyPrefix)
+	if err != nil {
+		return fmt.Errorf("unable to ListKeys in DeleteObjects from endpoint %s container %s keyPrefix %s, %w",
+			s.endpoint, s.container, keyPrefix, err)
+	}
+
+	if err := s.DeleteObjects(keys...); err != nil {
+		return fmt.Errorf("unable to DeleteObjects from endpoint %s container %s keyPrefix %s, %w",
+			s.endpoint, s.container, keyPrefix, err)
+	}
+
+	return nil
+}
+
+// do sends a request signed with the shared key of the account for the blob named key, or the container if key is
+// empty, and returns the body of its response, or an azureBlobError if the service fails it
+func (s *azureBlobObjectStore) do(method, key string, query url.Values, body []byte, headers map[string]string,
+) ([]byte, error) {
+	resource := s.endpoint + "/" + url.PathEscape(s.container)
+	if key != "" {
+		resource += "/" + azureBlobNameEscape(key)
+	}
+
+	if len(query) != 0 {
+		resource += "?" + query.Encode()
+	}
+
+	request, err := http.NewRequestWithContext(context.TODO(), method, resource, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	request.ContentLength = int64(len(body))
+	request.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	request.Header.Set("x-ms-version", azureBlobAPIVersion)
+	request.Header.Set("Authorization", "SharedKey "+s.accountName+":"+
+		azureSharedKeySignature(s.accountKey, azureSharedKeyStringToSign(request, s.accountName)))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		errorResponse := struct {
+			Code    string `xml:"Code"`
+			Message string `xml:"Message"`
+		}{Code: response.Header.Get("x-ms-error-code")}
+
+		_ = xml.Unmarshal(data, &errorResponse)
+
+		return nil, azureBlobError{statusCode: response.StatusCode, code: errorResponse.Code,
+			message: errorResponse.Message}
+	}
+
+	return data, nil
+}
+
+// azureBlobNameEscape escapes each segment of a blob name, keeping the slashes delimiting them
+func azureBlobNameEscape(name string) string {
+	segments := strings.Split(name, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// azureSharedKeyStringToSign returns the string to sign of a request to the Blob service authorized by shared key
+func azureSharedKeyStringToSign(request *http.Request, accountName string) string {
+	contentLength := ""
+	if request.ContentLength != 0 {
+		contentLength = strconv.FormatInt(request.ContentLength, 10)
+	}
+
+	return strings.Join([]string{
+		request.Method,
+		request.Header.Get("Content-Encoding"),
+		request.Header.Get("Content-Language"),
+		contentLength,
+		request.Header.Get("Content-MD5"),
+		request.Header.Get("Content-Type"),
+		"", // Date, superseded by x-ms-date
+		request.Header.Get("If-Modified-Since"),
+		request.Header.Get("If-Match"),
+		request.Header.Get("If-None-Match"),
+		request.Header.Get("If-Unmodified-Since"),
+		request.Header.Get("Range"),
+		azureCanonicalizedHeaders(request.Header) + azureCanonicalizedResource(request.URL, accountName),
+	}, "\n")
+}
+
+func azureCanonicalizedHeaders(header http.Header) string {
+	names := []string{}
+
+	for name := range header {
+		if name = strings.ToLower(name); strings.HasPrefix(name, "x-ms-") {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	canonicalized := &strings.Builder{}
+	for _, name := range names {
+		fmt.Fprintf(canonicalized, "%s:%s\n", name, strings.TrimSpace(header.Get(name)))
+	}
+
+	return canonicalized.String()
+}
+
+func azureCanonicalizedResource(u *url.URL, accountName string) string {
+	canonicalized := &strings.Builder{}
+	canonicalized.WriteString("/" + accountName + u.EscapedPath())
+
+	query := u.Query()
+	names := make([]string, 0, len(query))
+
+	for name := range query {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		values := slices.Clone(query[name])
+		slices.Sort(values)
+		fmt.Fprintf(canonicalized, "\n%s:%s", strings.ToLower(name), strings.Join(values, ","))
+	}
+
+	return canonicalized.String()
+}
+
+func azureSharedKeySignature(accountKey []byte, stringToSign string) string {
+	mac := hmac.New(sha256.New, accountKey)
+	mac.Write([]byte(stringToSign))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
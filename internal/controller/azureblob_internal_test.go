@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeBlobService serves the blobs of a container of an account, as the Blob service does, two per list page
+type fakeBlobService struct {
+	mutex       sync.Mutex
+	accountName string
+	accountKey  []byte
+	container   string
+	blobs       map[string][]byte
+}
+
+func (f *fakeBlobService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if r.Header.Get("Authorization") != "SharedKey "+f.accountName+":"+
+		azureSharedKeySignature(f.accountKey, azureSharedKeyStringToSign(r, f.accountName)) {
+		w.Header().Set("x-ms-error-code", "AuthenticationFailed")
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	name, found := strings.CutPrefix(r.URL.Path, "/"+f.container+"/")
+
+	switch {
+	case !found && r.Method == http.MethodGet && r.URL.Query().Get("comp") == "list":
+		f.list(w, r.URL.Query().Get("prefix"), r.URL.Query().Get("marker"))
+	case found && r.Method == http.MethodPut:
+		f.blobs[name], _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	case found && f.blobs[name] == nil:
+		w.Header().Set("x-ms-error-code", azureBlobErrorCodeBlobNotFound)
+		w.WriteHeader(http.StatusNotFound)
+	case found && r.Method == http.MethodGet:
+		_, _ = w.Write(f.blobs[name])
+	case found && r.Method == http.MethodDelete:
+		delete(f.blobs, name)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (f *fakeBlobService) list(w http.ResponseWriter, prefix, marker string) {
+	names := []string{}
+
+	for name := range f.blobs {
+		if strings.HasPrefix(name, prefix) && name > marker {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	nextMarker := ""
+	if len(names) > 2 {
+		names, nextMarker = names[:2], names[1]
+	}
+
+	fmt.Fprint(w, "<EnumerationResults><Blobs>")
+
+	for _, name := range names {
+		fmt.Fprintf(w, "<Blob><Name>%s</Name></Blob>", name)
+	}
+
+	fmt.Fprintf(w, "</Blobs><NextMarker>%s</NextMarker></EnumerationResults>", nextMarker)
+}
+
+var _ = Describe("Azure Blob object store", func() {
+	var store *azureBlobObjectStore
+
+	BeforeEach(func() {
+		service := &fakeBlobService{
+			accountName: "account",
+			accountKey:  []byte("key"),
+			container:   "container",
+			blobs:       map[string][]byte{},
+		}
+		server := httptest.NewServer(service)
+		DeferCleanup(server.Close)
+
+		store = &azureBlobObjectStore{
+			client:      server.Client(),
+			endpoint:    server.URL,
+			container:   "container",
+			accountName: "account",
+			accountKey:  []byte("key"),
+		}
+	})
+
+	It("uploads, lists, downloads and deletes objects by key prefix", func() {
+		for _, name := range []string{"pv1", "pv2", "pv 3"} {
+			pv := corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			Expect(store.UploadObject("ns/vrg/v1.PersistentVolume/"+name, pv)).To(Succeed())
+		}
+
+		Expect(store.UploadObject("ns/vrg2/v1.PersistentVolume/pv1", corev1.PersistentVolume{})).To(Succeed())
+
+		keys, err := store.ListKeys("ns/vrg/")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keys).To(ConsistOf("ns/vrg/v1.PersistentVolume/pv1", "ns/vrg/v1.PersistentVolume/pv2",
+			"ns/vrg/v1.PersistentVolume/pv 3"))
+
+		pv := corev1.PersistentVolume{}
+		Expect(store.DownloadObject("ns/vrg/v1.PersistentVolume/pv 3", &pv)).To(Succeed())
+		Expect(pv.Name).To(Equal("pv 3"))
+
+		Expect(store.DeleteObjectsWithKeyPrefix("ns/vrg/")).To(Succeed())
+		Expect(store.DeleteObject("ns/vrg/v1.PersistentVolume/pv1")).To(Succeed())
+
+		keys, err = store.ListKeys("ns/")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keys).To(Equal([]string{"ns/vrg2/v1.PersistentVolume/pv1"}))
+
+		Expect(store.DownloadObject("ns/vrg/v1.PersistentVolume/pv1", &pv)).To(MatchError(
+			ContainSubstring(azureBlobErrorCodeBlobNotFound)))
+	})
+
+	It("fails requests not signed with the key of the account", func() {
+		store.accountKey = []byte("other")
+
+		_, err := store.ListKeys("")
+		Expect(err).To(MatchError(ContainSubstring("AuthenticationFailed")))
+	})
+})
@@ -98,4 +98,62 @@ var _ = Describe("DRCluster-CEL", func() {
 			drclusterDelete(drc)
 		})
 	})
+
+	When("a DRCluster with fencing parameters is given", func() {
+		fencing := func(parameters map[string]string) *ramen.FencingSpec {
+			return &ramen.FencingSpec{
+				Driver:          "openshift-storage.rbd.csi.ceph.com",
+				SecretName:      "fence-secret",
+				SecretNamespace: "openshift-storage",
+				Parameters:      parameters,
+			}
+		}
+
+		It("should succeed with valid parameter names", func() {
+			drc := drclusters[0].DeepCopy()
+			drc.Spec.Fencing = fencing(map[string]string{"clusterID": "openshift-storage"})
+			createDRCluster(drc)
+			drclusterDelete(drc)
+		})
+
+		It("should fail on an invalid parameter name", func() {
+			drc := drclusters[0].DeepCopy()
+			drc.Spec.Fencing = fencing(map[string]string{"cluster ID": "openshift-storage"})
+			Expect(k8sClient.Create(context.TODO(), drc)).NotTo(Succeed())
+		})
+
+		It("should fail on an empty parameter value", func() {
+			drc := drclusters[0].DeepCopy()
+			drc.Spec.Fencing = fencing(map[string]string{"clusterID": ""})
+			Expect(k8sClient.Create(context.TODO(), drc)).NotTo(Succeed())
+		})
+
+		It("should fail without a driver", func() {
+			drc := drclusters[0].DeepCopy()
+			drc.Spec.Fencing = fencing(nil)
+			drc.Spec.Fencing.Driver = ""
+			Expect(k8sClient.Create(context.TODO(), drc)).NotTo(Succeed())
+		})
+
+		It("should fail on an invalid driver name", func() {
+			drc := drclusters[0].DeepCopy()
+			drc.Spec.Fencing = fencing(nil)
+			drc.Spec.Fencing.Driver = "rbd.csi.ceph.com/"
+			Expect(k8sClient.Create(context.TODO(), drc)).NotTo(Succeed())
+		})
+
+		It("should fail on a secret name that is not a DNS subdomain", func() {
+			drc := drclusters[0].DeepCopy()
+			drc.Spec.Fencing = fencing(nil)
+			drc.Spec.Fencing.SecretName = "Fence_Secret"
+			Expect(k8sClient.Create(context.TODO(), drc)).NotTo(Succeed())
+		})
+
+		It("should fail on a secret namespace that is not a DNS label", func() {
+			drc := drclusters[0].DeepCopy()
+			drc.Spec.Fencing = fencing(nil)
+			drc.Spec.Fencing.SecretNamespace = "openshift.storage"
+			Expect(k8sClient.Create(context.TODO(), drc)).NotTo(Succeed())
+		})
+	})
 })
@@ -0,0 +1,404 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/yaml"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// DiagnosticsCollectAnnotation requests a diagnostic bundle for the annotated DRPC or DRCluster. A bundle is
+	// collected each time the annotation value changes, e.g. when set to the current time or a support case number.
+	DiagnosticsCollectAnnotation = "ramendr.openshift.io/collect-diagnostics"
+
+	// DiagnosticsCollectedAnnotation records the DiagnosticsCollectAnnotation value of the last collected bundle
+	DiagnosticsCollectedAnnotation = "ramendr.openshift.io/diagnostics-collected"
+
+	diagnosticsConfigMapSuffix = "diagnostics"
+
+	// diagnosticsConfigMapNameHashLength is the length of the hash that shortens the names of the diagnostics
+	// ConfigMaps exceeding the length limit of a name
+	diagnosticsConfigMapNameHashLength = 10
+
+	// diagnosticsMaxBytes keeps the bundle below the ConfigMap size limit of 1MiB
+	diagnosticsMaxBytes = 900 * 1024
+
+	diagnosticsMaxEvents = 100
+)
+
+// diagnosticsCollector gathers the hub objects, ManifestWorks, ManagedClusterViews, events and metrics related to
+// a DRPC or DRCluster into a ConfigMap, one YAML document per data key
+type diagnosticsCollector struct {
+	ctx       context.Context
+	client    client.Client
+	apiReader client.Reader
+	log       logr.Logger
+	data      map[string]string
+}
+
+// diagnosticsRequested returns the DiagnosticsCollectAnnotation value if a bundle was requested for it, that was
+// not yet collected
+func diagnosticsRequested(obj client.Object) (string, bool) {
+	token := obj.GetAnnotations()[DiagnosticsCollectAnnotation]
+
+	return token, token != "" && token != obj.GetAnnotations()[DiagnosticsCollectedAnnotation]
+}
+
+func newDiagnosticsCollector(ctx context.Context, c client.Client, apiReader client.Reader, log logr.Logger,
+) *diagnosticsCollector {
+	return &diagnosticsCollector{
+		ctx:       ctx,
+		client:    c,
+		apiReader: apiReader,
+		log:       log.WithName("Diagnostics"),
+		data:      map[string]string{},
+	}
+}
+
+// add records obj as YAML under key, or the error encountered retrieving or encoding it
+func (c *diagnosticsCollector) add(key string, obj interface{}, err error) {
+	if err != nil {
+		c.data[key] = fmt.Sprintf("error: %v\n", err)
+
+		return
+	}
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		c.data[key] = fmt.Sprintf("error: %v\n", err)
+
+		return
+	}
+
+	c.data[key] = string(data)
+}
+
+// addObject records a copy of obj without its managed fields, which only add noise to the bundle
+func (c *diagnosticsCollector) addObject(key string, obj client.Object) {
+	obj = obj.DeepCopyObject().(client.Object)
+	obj.SetManagedFields(nil)
+
+	c.add(key, obj, nil)
+}
+
+// addManifestWorks records ManifestWorks across all managed clusters that carry all of the annotations
+func (c *diagnosticsCollector) addManifestWorks(list *ocmworkv1.ManifestWorkList, err error,
+	annotations map[string]string,
+) {
+	if err == nil {
+		list.Items = slices.DeleteFunc(list.Items, func(mw ocmworkv1.ManifestWork) bool {
+			return !hasAnnotations(&mw, annotations)
+		})
+
+		for idx := range list.Items {
+			list.Items[idx].ManagedFields = nil
+		}
+	}
+
+	c.add("manifestworks.yaml", list, err)
+}
+
+// addManagedClusterViews records ManagedClusterViews across all managed clusters that carry all of the annotations
+func (c *diagnosticsCollector) addManagedClusterViews(annotations map[string]string) {
+	list := &viewv1beta1.ManagedClusterViewList{}
+
+	err := c.client.List(c.ctx, list)
+	if err == nil {
+		list.Items = slices.DeleteFunc(list.Items, func(mcv viewv1beta1.ManagedClusterView) bool {
+			return !hasAnnotations(&mcv, annotations)
+		})
+
+		for idx := range list.Items {
+			list.Items[idx].ManagedFields = nil
+		}
+	}
+
+	c.add("managedclusterviews.yaml", list, err)
+}
+
+// addEvents records the most recent events involving obj
+func (c *diagnosticsCollector) addEvents(obj client.Object) {
+	list := &corev1.EventList{}
+
+	err := c.apiReader.List(c.ctx, list, client.MatchingFields{"involvedObject.uid": string(obj.GetUID())})
+	if err == nil {
+		slices.SortFunc(list.Items, func(a, b corev1.Event) int {
+			return eventTime(&a).Compare(eventTime(&b).Time)
+		})
+
+		if len(list.Items) > diagnosticsMaxEvents {
+			list.Items = list.Items[len(list.Items)-diagnosticsMaxEvents:]
+		}
+
+		for idx := range list.Items {
+			list.Items[idx].ManagedFields = nil
+		}
+	}
+
+	c.add("events.yaml", list, err)
+}
+
+func eventTime(event *corev1.Event) metav1.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp
+	}
+
+	return metav1.NewTime(event.EventTime.Time)
+}
+
+type diagnosticsMetric struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+	Value  float64           `json:"value"`
+}
+
+// addMetrics records the Ramen gauge and counter metrics labeled with the object name and namespace
+func (c *diagnosticsCollector) addMetrics(name, namespace string) {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		c.add("metrics.yaml", nil, err)
+
+		return
+	}
+
+	collected := []diagnosticsMetric{}
+
+	for _, family := range families {
+		if !strings.HasPrefix(family.GetName(), metricNamespace+"_") {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			if labels[ObjName] != name || (namespace != "" && labels[ObjNamespace] != namespace) {
+				continue
+			}
+
+			collected = append(collected, diagnosticsMetric{
+				Name:   family.GetName(),
+				Labels: labels,
+				Value:  metric.GetGauge().GetValue() + metric.GetCounter().GetValue(),
+			})
+		}
+	}
+
+	c.add("metrics.yaml", collected, nil)
+}
+
+// save writes the bundle to the diagnostics ConfigMap of the owner, dropping the largest entries if the bundle
+// exceeds the ConfigMap size limit
+func (c *diagnosticsCollector) save(owner client.Object, namespace, token string, scheme *runtime.Scheme,
+) (string, error) {
+	c.truncate()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      diagnosticsConfigMapName(owner),
+			Namespace: namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(c.ctx, c.client, configMap, func() error {
+		rmnutil.AddLabel(configMap, rmnutil.CreatedByRamenLabel, "true")
+		rmnutil.AddAnnotation(configMap, DiagnosticsCollectedAnnotation, token)
+
+		configMap.Data = c.data
+
+		return controllerutil.SetOwnerReference(owner, configMap, scheme)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to save diagnostics ConfigMap %s/%s: %w", namespace, configMap.Name, err)
+	}
+
+	return configMap.Name, nil
+}
+
+// truncate replaces the largest entries with a note of their omission until the bundle fits the size limit, and drops
+// the largest entries instead once a note would not shrink them, as when the largest entries are already notes
+func (c *diagnosticsCollector) truncate() {
+	size := 0
+	for key, value := range c.data {
+		size += len(key) + len(value)
+	}
+
+	for size > diagnosticsMaxBytes && len(c.data) != 0 {
+		largest := ""
+		for key, value := range c.data {
+			if largest == "" || len(value) > len(c.data[largest]) {
+				largest = key
+			}
+		}
+
+		value := c.data[largest]
+
+		note := fmt.Sprintf("omitted: %d bytes exceed the diagnostics size limit\n", len(value))
+		if len(note) >= len(value) {
+			delete(c.data, largest)
+			size -= len(largest) + len(value)
+
+			continue
+		}
+
+		size += len(note) - len(value)
+		c.data[largest] = note
+	}
+}
+
+// diagnosticsConfigMapName returns the name of the diagnostics ConfigMap of owner, shortened with a hash of the full
+// name if it exceeds the length limit of a name
+func diagnosticsConfigMapName(owner client.Object) string {
+	name := strings.ToLower(fmt.Sprintf("%s-%s-%s",
+		owner.GetObjectKind().GroupVersionKind().Kind, owner.GetName(), diagnosticsConfigMapSuffix))
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+
+	hash := sha256.Sum256([]byte(name))
+	suffix := "-" + hex.EncodeToString(hash[:])[:diagnosticsConfigMapNameHashLength] + "-" + diagnosticsConfigMapSuffix
+	prefix := strings.TrimRight(name[:validation.DNS1123SubdomainMaxLength-len(suffix)], "-.")
+
+	return prefix + suffix
+}
+
+// diagnosticsCollectedMark records token as collected on obj, so that the bundle is not collected again until the
+// DiagnosticsCollectAnnotation changes
+func diagnosticsCollectedMark(ctx context.Context, c client.Client, obj client.Object, token string) error {
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+
+	rmnutil.AddAnnotation(obj, DiagnosticsCollectedAnnotation, token)
+
+	return c.Patch(ctx, obj, patch)
+}
+
+func hasAnnotations(obj client.Object, annotations map[string]string) bool {
+	for key, value := range annotations {
+		if obj.GetAnnotations()[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// collectDRPCDiagnostics collects a diagnostic bundle for the DRPC into a ConfigMap in the DRPC namespace, if one
+// was requested using the DiagnosticsCollectAnnotation. Collection is best effort and does not fail the reconcile.
+func (r *DRPlacementControlReconciler) collectDRPCDiagnostics(ctx context.Context, drpc *rmn.DRPlacementControl,
+	placementObj client.Object, drPolicy *rmn.DRPolicy, log logr.Logger,
+) {
+	token, requested := diagnosticsRequested(drpc)
+	if !requested {
+		return
+	}
+
+	collector := newDiagnosticsCollector(ctx, r.Client, r.APIReader, log)
+	mwu := rmnutil.MWUtil{Client: r.Client, APIReader: r.APIReader, Ctx: ctx, Log: log}
+
+	// Kind is not populated by the client on typed objects, yet it names the ConfigMap
+	drpc.SetGroupVersionKind(rmn.GroupVersion.WithKind("DRPlacementControl"))
+
+	collector.addObject("drplacementcontrol.yaml", drpc)
+	collector.addObject("drpolicy.yaml", drPolicy)
+
+	if placementObj != nil {
+		collector.addObject("placement.yaml", placementObj)
+	}
+
+	for _, clusterName := range rmnutil.DRPolicyClusterNames(drPolicy) {
+		drCluster := &rmn.DRCluster{}
+		err := r.Client.Get(ctx, client.ObjectKey{Name: clusterName}, drCluster)
+		collector.add("drcluster-"+clusterName+".yaml", drCluster, err)
+	}
+
+	annotations := map[string]string{DRPCNameAnnotation: drpc.Name, DRPCNamespaceAnnotation: drpc.Namespace}
+
+	mws, err := mwu.ListManifestWorksByParent(drpc.Namespace, drpc.Name)
+	collector.addManifestWorks(mws, err, annotations)
+	collector.addManagedClusterViews(annotations)
+	collector.addEvents(drpc)
+	collector.addMetrics(drpc.Name, drpc.Namespace)
+
+	name, err := collector.save(drpc, drpc.Namespace, token, r.Scheme)
+	if err == nil {
+		err = diagnosticsCollectedMark(ctx, r.Client, drpc, token)
+	}
+
+	if err != nil {
+		log.Info("Failed to collect diagnostics", "error", err)
+
+		return
+	}
+
+	log.Info("Collected diagnostics", "configMap", name, "token", token)
+}
+
+// collectDRClusterDiagnostics collects a diagnostic bundle for the DRCluster into a ConfigMap in the Ramen operator
+// namespace, if one was requested using the DiagnosticsCollectAnnotation. Collection is best effort and does not
+// fail the reconcile.
+func (u *drclusterInstance) collectDRClusterDiagnostics() {
+	token, requested := diagnosticsRequested(u.object)
+	if !requested {
+		return
+	}
+
+	collector := newDiagnosticsCollector(u.ctx, u.client, u.reconciler.APIReader, u.log)
+
+	u.object.SetGroupVersionKind(rmn.GroupVersion.WithKind("DRCluster"))
+
+	collector.addObject("drcluster.yaml", u.object)
+
+	drpolicies := &rmn.DRPolicyList{}
+	err := u.client.List(u.ctx, drpolicies)
+	if err == nil {
+		drpolicies.Items = slices.DeleteFunc(drpolicies.Items, func(drpolicy rmn.DRPolicy) bool {
+			return !slices.Contains(drpolicy.Spec.DRClusters, u.object.Name)
+		})
+	}
+
+	collector.add("drpolicies.yaml", drpolicies, err)
+
+	annotations := map[string]string{DRClusterNameAnnotation: u.object.Name}
+
+	mws := &ocmworkv1.ManifestWorkList{}
+	collector.addManifestWorks(mws, u.client.List(u.ctx, mws), annotations)
+	collector.addManagedClusterViews(annotations)
+	collector.addEvents(u.object)
+	collector.addMetrics(u.object.Name, "")
+
+	name, err := collector.save(u.object, RamenOperatorNamespace(), token, u.reconciler.Scheme)
+	if err == nil {
+		err = diagnosticsCollectedMark(u.ctx, u.client, u.object, token)
+	}
+
+	if err != nil {
+		u.log.Info("Failed to collect diagnostics", "error", err)
+
+		return
+	}
+
+	u.log.Info("Collected diagnostics", "configMap", name, "token", token)
+}
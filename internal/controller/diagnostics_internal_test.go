@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("Diagnostics", func() {
+	It("is requested until the requested token is collected", func() {
+		drpc := &rmn.DRPlacementControl{}
+
+		_, requested := diagnosticsRequested(drpc)
+		Expect(requested).To(BeFalse())
+
+		drpc.Annotations = map[string]string{DiagnosticsCollectAnnotation: "case-1"}
+		token, requested := diagnosticsRequested(drpc)
+		Expect(requested).To(BeTrue())
+		Expect(token).To(Equal("case-1"))
+
+		drpc.Annotations[DiagnosticsCollectedAnnotation] = "case-1"
+		_, requested = diagnosticsRequested(drpc)
+		Expect(requested).To(BeFalse())
+	})
+
+	It("omits the largest entries of a bundle exceeding the size limit", func() {
+		collector := newDiagnosticsCollector(context.TODO(), nil, nil, logr.Discard())
+		collector.data = map[string]string{
+			"manifestworks.yaml": strings.Repeat("a", 600*1024),
+			"events.yaml":        strings.Repeat("b", 400*1024),
+			"drpolicy.yaml":      "drpolicy",
+		}
+
+		collector.truncate()
+
+		Expect(collector.data["manifestworks.yaml"]).To(HavePrefix("omitted: 614400 bytes"))
+		Expect(collector.data["events.yaml"]).To(HaveLen(400 * 1024))
+		Expect(collector.data["drpolicy.yaml"]).To(Equal("drpolicy"))
+	})
+
+	It("drops the largest entries once their omission notes would not shrink the bundle", func() {
+		collector := newDiagnosticsCollector(context.TODO(), nil, nil, logr.Discard())
+		collector.data = map[string]string{}
+
+		for i := range 20000 {
+			collector.data[fmt.Sprintf("vrg-%05d.yaml", i)] = strings.Repeat("v", 64)
+		}
+
+		collector.truncate()
+
+		size := 0
+		for key, value := range collector.data {
+			size += len(key) + len(value)
+		}
+
+		Expect(size).To(BeNumerically("<=", diagnosticsMaxBytes))
+		Expect(collector.data).ToNot(BeEmpty())
+	})
+
+	It("bounds the length of the name of the diagnostics ConfigMap", func() {
+		drpc := &rmn.DRPlacementControl{
+			TypeMeta:   metav1.TypeMeta{Kind: "DRPlacementControl"},
+			ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 253)},
+		}
+
+		name := diagnosticsConfigMapName(drpc)
+		Expect(len(name)).To(BeNumerically("<=", 253))
+		Expect(name).To(HaveSuffix("-diagnostics"))
+
+		drpc.Name = strings.Repeat("a", 252) + "b"
+		Expect(diagnosticsConfigMapName(drpc)).ToNot(Equal(name))
+
+		drpc.Name = "app"
+		Expect(diagnosticsConfigMapName(drpc)).To(Equal("drplacementcontrol-app-diagnostics"))
+	})
+
+	Context("collection", func() {
+		var fakeClient client.Client
+
+		configMap := func(key types.NamespacedName) *corev1.ConfigMap {
+			cm := &corev1.ConfigMap{}
+			Expect(fakeClient.Get(context.TODO(), key, cm)).To(Succeed())
+
+			return cm
+		}
+
+		annotate := func(obj client.Object, token string) {
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+			rmnutil.AddAnnotation(obj, DiagnosticsCollectAnnotation, token)
+			Expect(fakeClient.Update(context.TODO(), obj)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(rmn.AddToScheme(scheme)).To(Succeed())
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			Expect(ocmworkv1.AddToScheme(scheme)).To(Succeed())
+			Expect(viewv1beta1.AddToScheme(scheme)).To(Succeed())
+
+			drpcAnnotations := map[string]string{DRPCNameAnnotation: "drpc", DRPCNamespaceAnnotation: "app"}
+
+			fakeClient = fake.NewClientBuilder().WithScheme(scheme).
+				WithIndex(&ocmworkv1.ManifestWork{}, rmnutil.ManifestWorkParentIndexName,
+					func(o client.Object) []string {
+						return []string{types.NamespacedName{
+							Namespace: o.GetAnnotations()[DRPCNamespaceAnnotation],
+							Name:      o.GetAnnotations()[DRPCNameAnnotation],
+						}.String()}
+					}).
+				WithIndex(&corev1.Event{}, "involvedObject.uid", func(o client.Object) []string {
+					return []string{string(o.(*corev1.Event).InvolvedObject.UID)}
+				}).
+				WithObjects(
+					&rmn.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc", UID: "drpc-uid"}},
+					&rmn.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "east", UID: "east-uid"}},
+					&ocmworkv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{
+						Namespace: "east", Name: "drpc-app-vrg-mw", Annotations: drpcAnnotations,
+					}},
+					&ocmworkv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{
+						Namespace: "east", Name: "other-app-vrg-mw",
+						Annotations: map[string]string{DRPCNameAnnotation: "other", DRPCNamespaceAnnotation: "app"},
+					}},
+					&ocmworkv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{
+						Namespace: "east", Name: "east-drclusters-mw",
+						Annotations: map[string]string{DRClusterNameAnnotation: "east"},
+					}},
+					&viewv1beta1.ManagedClusterView{ObjectMeta: metav1.ObjectMeta{
+						Namespace: "east", Name: "drpc-app-vrg-mcv", Annotations: drpcAnnotations,
+					}},
+					&corev1.Event{
+						ObjectMeta:     metav1.ObjectMeta{Namespace: "app", Name: "drpc-event"},
+						InvolvedObject: corev1.ObjectReference{UID: "drpc-uid"},
+						Message:        "failing over",
+					},
+				).Build()
+		})
+
+		It("collects a bundle of the DRPC into its namespace once per requested token", func() {
+			r := &DRPlacementControlReconciler{Client: fakeClient, APIReader: fakeClient, Scheme: fakeClient.Scheme()}
+			drPolicy := &rmn.DRPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+				Spec:       rmn.DRPolicySpec{DRClusters: []string{"east", "west"}},
+			}
+			drpc := &rmn.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc"}}
+			key := types.NamespacedName{Namespace: "app", Name: "drplacementcontrol-drpc-diagnostics"}
+
+			annotate(drpc, "case-1")
+			r.collectDRPCDiagnostics(context.TODO(), drpc, nil, drPolicy, logr.Discard())
+
+			cm := configMap(key)
+			Expect(cm.Annotations).To(HaveKeyWithValue(DiagnosticsCollectedAnnotation, "case-1"))
+			Expect(cm.OwnerReferences).To(HaveLen(1))
+			Expect(cm.Data).To(HaveKey("drplacementcontrol.yaml"))
+			Expect(cm.Data["drpolicy.yaml"]).To(ContainSubstring("name: policy"))
+			Expect(cm.Data["drcluster-east.yaml"]).To(ContainSubstring("name: east"))
+			Expect(cm.Data["drcluster-west.yaml"]).To(HavePrefix("error:"))
+			Expect(cm.Data["manifestworks.yaml"]).To(ContainSubstring("drpc-app-vrg-mw"))
+			Expect(cm.Data["manifestworks.yaml"]).ToNot(ContainSubstring("other-app-vrg-mw"))
+			Expect(cm.Data["managedclusterviews.yaml"]).To(ContainSubstring("drpc-app-vrg-mcv"))
+			Expect(cm.Data["events.yaml"]).To(ContainSubstring("failing over"))
+			Expect(cm.Data).To(HaveKey("metrics.yaml"))
+
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(drpc), drpc)).To(Succeed())
+			Expect(drpc.Annotations).To(HaveKeyWithValue(DiagnosticsCollectedAnnotation, "case-1"))
+
+			Expect(fakeClient.Delete(context.TODO(), cm)).To(Succeed())
+			r.collectDRPCDiagnostics(context.TODO(), drpc, nil, drPolicy, logr.Discard())
+			Expect(k8serrors.IsNotFound(fakeClient.Get(context.TODO(), key, &corev1.ConfigMap{}))).To(BeTrue())
+
+			annotate(drpc, "case-2")
+			r.collectDRPCDiagnostics(context.TODO(), drpc, nil, drPolicy, logr.Discard())
+			Expect(configMap(key).Annotations).To(HaveKeyWithValue(DiagnosticsCollectedAnnotation, "case-2"))
+		})
+
+		It("collects a bundle of the DRCluster into the operator namespace", func() {
+			drCluster := &rmn.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "east"}}
+			annotate(drCluster, "case-1")
+
+			u := &drclusterInstance{
+				ctx:        context.TODO(),
+				object:     drCluster,
+				client:     fakeClient,
+				log:        logr.Discard(),
+				reconciler: &DRClusterReconciler{APIReader: fakeClient, Scheme: fakeClient.Scheme()},
+			}
+			u.collectDRClusterDiagnostics()
+
+			cm := configMap(types.NamespacedName{
+				Namespace: RamenOperatorNamespace(), Name: "drcluster-east-diagnostics",
+			})
+			Expect(cm.Data["drcluster.yaml"]).To(ContainSubstring("name: east"))
+			Expect(cm.Data["manifestworks.yaml"]).To(ContainSubstring("east-drclusters-mw"))
+			Expect(cm.Data["manifestworks.yaml"]).ToNot(ContainSubstring("drpc-app-vrg-mw"))
+
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(drCluster), drCluster)).To(Succeed())
+			Expect(drCluster.Annotations).To(HaveKeyWithValue(DiagnosticsCollectedAnnotation, "case-1"))
+		})
+	})
+})
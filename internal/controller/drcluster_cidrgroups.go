@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// nfTarget is a set of CIDRs of a cluster that is fenced off by a NetworkFence of each NetworkFenceClass, which is
+// named after the target
+type nfTarget struct {
+	name  string
+	cidrs []string
+}
+
+// nfTargets returns the targets of the current fence operation of drCluster: all of its CIDRs, named after the
+// cluster, unless the operation fences off some of its CIDR groups only, each of which is then a target named after
+// the cluster and the group
+func nfTargets(drCluster *ramen.DRCluster) []nfTarget {
+	groups := drCluster.Status.FencedCIDRGroups
+	if len(groups) == 0 {
+		return []nfTarget{{name: drCluster.Name, cidrs: drCluster.Spec.CIDRs}}
+	}
+
+	targets := make([]nfTarget, 0, len(groups))
+
+	for _, group := range groups {
+		targets = append(targets, nfTarget{name: drCluster.Name + "-" + group.Name, cidrs: group.CIDRs})
+	}
+
+	return targets
+}
+
+// nfTargetsApply applies op to each of targets, attempting all of them even if some fail, and returns the errors of
+// those that failed, joined
+func nfTargetsApply(targets []nfTarget, op func(target nfTarget) error) error {
+	errs := []error{}
+
+	for _, target := range targets {
+		if err := op(target); err != nil {
+			errs = append(errs, fmt.Errorf("NetworkFence %s: %w", target.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// fenceCIDRGroups returns the CIDR groups drCluster requests a fence operation to fence off, or nil if it requests
+// all its CIDRs to be, and an error if a group it requests is not one of its CIDR groups
+func fenceCIDRGroups(drCluster *ramen.DRCluster) ([]ramen.CIDRGroup, error) {
+	groups := []ramen.CIDRGroup{}
+
+	for _, name := range drCluster.Spec.FenceCIDRGroups {
+		index := slices.IndexFunc(drCluster.Spec.CIDRGroups, func(group ramen.CIDRGroup) bool {
+			return group.Name == name
+		})
+		if index == -1 {
+			return nil, fmt.Errorf("CIDR group %s to fence is not a CIDR group of the cluster", name)
+		}
+
+		groups = append(groups, *drCluster.Spec.CIDRGroups[index].DeepCopy())
+	}
+
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	return groups, nil
+}
+
+// validateCIDRGroups returns an error if a CIDR group of drCluster has CIDRs that are not CIDRs of the cluster, as
+// only those are validated against the CIDRs the storage detects
+func validateCIDRGroups(drCluster *ramen.DRCluster) error {
+	for _, group := range drCluster.Spec.CIDRGroups {
+		unknown := []string{}
+
+		for _, cidr := range group.CIDRs {
+			if !slices.Contains(drCluster.Spec.CIDRs, cidr) {
+				unknown = append(unknown, cidr)
+			}
+		}
+
+		if len(unknown) > 0 {
+			return fmt.Errorf("CIDRs %s of CIDR group %s are not CIDRs of the cluster", strings.Join(unknown, ", "),
+				group.Name)
+		}
+	}
+
+	return nil
+}
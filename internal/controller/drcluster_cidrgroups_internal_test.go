@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRCluster CIDR groups", func() {
+	drCluster := func(fenceCIDRGroups ...string) *ramen.DRCluster {
+		return &ramen.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "east"},
+			Spec: ramen.DRClusterSpec{
+				CIDRs: []string{"10.0.0.0/24", "10.0.1.0/24", "192.168.0.0/24"},
+				CIDRGroups: []ramen.CIDRGroup{
+					{Name: "storage", CIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"}},
+					{Name: "public", CIDRs: []string{"192.168.0.0/24"}},
+				},
+				ClusterFence:    ramen.ClusterFenceStateFenced,
+				FenceCIDRGroups: fenceCIDRGroups,
+			},
+		}
+	}
+
+	It("fences off all the CIDRs of the cluster with a NetworkFence named after it if no group is requested", func() {
+		cluster := drCluster()
+
+		groups, err := fenceCIDRGroups(cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(groups).To(BeNil())
+
+		cluster.Status.FencedCIDRGroups = groups
+		Expect(nfTargets(cluster)).To(Equal([]nfTarget{{name: "east", cidrs: cluster.Spec.CIDRs}}))
+	})
+
+	It("fences off the requested groups only, with a NetworkFence of each group", func() {
+		cluster := drCluster("storage")
+
+		groups, err := fenceCIDRGroups(cluster)
+		Expect(err).ToNot(HaveOccurred())
+
+		cluster.Status.FencedCIDRGroups = groups
+		targets := nfTargets(cluster)
+		Expect(targets).To(Equal([]nfTarget{{name: "east-storage", cidrs: []string{"10.0.0.0/24", "10.0.1.0/24"}}}))
+
+		nf, err := generateNF(cluster, targets[0], "rbd")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nf.Name).To(Equal(NetworkFencePrefix + "-rbd-east-storage"))
+		Expect(nf.Spec.Cidrs).To(Equal([]string{"10.0.0.0/24", "10.0.1.0/24"}))
+	})
+
+	It("unfences the groups that were fenced off, even if the requested groups change", func() {
+		cluster := drCluster("storage")
+		cluster.Status.FencedCIDRGroups, _ = fenceCIDRGroups(cluster)
+		cluster.Spec.FenceCIDRGroups = []string{"public"}
+
+		Expect(nfTargets(cluster)[0].name).To(Equal("east-storage"))
+	})
+
+	It("rejects groups that are not groups of the cluster, or have CIDRs that are not CIDRs of the cluster", func() {
+		_, err := fenceCIDRGroups(drCluster("management"))
+		Expect(err).To(HaveOccurred())
+
+		cluster := drCluster()
+		Expect(validateCIDRGroups(cluster)).To(Succeed())
+
+		cluster.Spec.CIDRGroups[1].CIDRs = append(cluster.Spec.CIDRGroups[1].CIDRs, "172.16.0.0/24")
+		Expect(validateCIDRGroups(cluster)).ToNot(Succeed())
+	})
+})
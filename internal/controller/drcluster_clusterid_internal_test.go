@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("DRCluster clusterID change", func() {
+	var u *drclusterInstance
+
+	publish := func(clusterID string) {
+		Expect(u.mwUtil.CreateOrUpdateDRCConfigManifestWork("cluster1", ramen.DRClusterConfig{
+			TypeMeta:   metav1.TypeMeta{Kind: "DRClusterConfig", APIVersion: ramen.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+			Spec:       ramen.DRClusterConfigSpec{ClusterID: clusterID},
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(ramen.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmworkv1.Install(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		u = &drclusterInstance{
+			object: &ramen.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}},
+			log:    logr.Discard(),
+			mwUtil: &util.MWUtil{
+				Client:    fakeClient,
+				APIReader: fakeClient,
+				Ctx:       context.TODO(),
+				Log:       logr.Discard(),
+				InstName:  "cluster1",
+			},
+		}
+	})
+
+	It("publishes the clusterID of a cluster with no DRClusterConfig, or the same clusterID", func() {
+		Expect(u.clusterIDChangeCheck("id1")).To(Succeed())
+
+		publish("id1")
+		Expect(u.clusterIDChangeCheck("id1")).To(Succeed())
+	})
+
+	It("blocks a changed clusterID until the change to it is acknowledged", func() {
+		publish("id1")
+
+		err := u.clusterIDChangeCheck("id2")
+		Expect(err).To(MatchError(errClusterIDChanged))
+		Expect(err).To(MatchError(ContainSubstring("from id1 to id2")))
+
+		u.object.Annotations = map[string]string{DRClusterClusterIDChangeAnnotation: "id3"}
+		Expect(u.clusterIDChangeCheck("id2")).To(MatchError(errClusterIDChanged))
+
+		u.object.Annotations[DRClusterClusterIDChangeAnnotation] = "id2"
+		Expect(u.clusterIDChangeCheck("id2")).To(Succeed())
+
+		publish("id2")
+		Expect(u.clusterIDChangeCheck("id3")).To(MatchError(errClusterIDChanged))
+	})
+})
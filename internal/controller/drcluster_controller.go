@@ -5,11 +5,13 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"reflect"
 	"slices"
 	"strings"
+	"time"
 
 	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
 	"github.com/go-logr/logr"
@@ -34,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/statemachine"
 	"github.com/ramendr/ramen/internal/controller/util"
 )
 
@@ -46,6 +49,11 @@ type DRClusterReconciler struct {
 	MCVGetter         util.ManagedClusterViewGetter
 	ObjectStoreGetter ObjectStoreGetter
 	RateLimiter       *workqueue.TypedRateLimiter[reconcile.Request]
+	ViewIntervals     *util.ClusterViewIntervals
+	ViewSubscriptions *util.ManagedClusterViewSubscriptions
+	ActionTracker     *ActionTracker
+
+	s3ProfileValidator *s3ProfileValidator
 }
 
 // DRCluster condition reasons
@@ -63,6 +71,12 @@ const (
 	DRClusterConditionReasonUnfenceError = "UnfenceError"
 	DRClusterConditionReasonCleanError   = "CleanError"
 
+	DRClusterConditionReasonFencingUnavailable   = "FencingUnavailable"
+	DRClusterConditionReasonFencingNotConfigured = "FencingNotConfigured"
+
+	DRClusterConditionReasonViewForbidden      = "ViewForbidden"
+	DRClusterConditionReasonClusterUnreachable = "ClusterUnreachable"
+
 	DRClusterConditionReasonError        = "Error"
 	DRClusterConditionReasonErrorUnknown = "UnknownError"
 )
@@ -77,18 +91,32 @@ const (
 
 const (
 	DRClusterNameAnnotation = "drcluster.ramendr.openshift.io/drcluster-name"
+
+	// DRClusterClusterIDChangeAnnotation acknowledges the change of the clusterID of the managed cluster to its
+	// value, as the cluster is reinstalled, allowing the DRClusterConfig to publish the new clusterID
+	DRClusterClusterIDChangeAnnotation = "drcluster.ramendr.openshift.io/acknowledge-clusterid-change"
 )
 
 const (
 	NetworkFencePrefix = "network-fence"
 )
 
+const (
+	// nfViewForbiddenRequeueDelay is the delay to retry reading a NetworkFence the agent is not permitted to read
+	nfViewForbiddenRequeueDelay = 5 * time.Minute
+
+	// nfViewClusterUnreachableRequeueDelay is the delay to retry reading a NetworkFence of an unreachable cluster
+	nfViewClusterUnreachableRequeueDelay = time.Minute
+)
+
 type DRClusterMetrics struct {
 	InvalidCIDRsDetectedMetrics
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DRClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.s3ProfileValidator = newS3ProfileValidator()
+
 	// ensure next line is not greater than 120 columns
 	drpcMapFun := handler.EnqueueRequestsFromMapFunc(handler.MapFunc(
 		func(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -113,6 +141,11 @@ func (r *DRClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 			ctrl.Log.Info(fmt.Sprintf("DRCluster: Filtering ManifestWork (%s/%s)", mw.Name, mw.Namespace))
 
+			// The managed cluster may have applied an update of the ManifestWork, so drop cached reads
+			if invalidator, ok := r.MCVGetter.(util.ManagedClusterViewCacheInvalidator); ok {
+				invalidator.InvalidateManagedCluster(mw.Namespace)
+			}
+
 			return filterDRClusterMW(mw)
 		}))
 
@@ -143,6 +176,8 @@ func (r *DRClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&ramen.DRPolicy{}, drPolicyEventHandler(), builder.WithPredicates(drPolicyPredicate())).
 		Watches(&ocmworkv1.ManifestWork{}, mwMapFun, builder.WithPredicates(mwPred)).
 		Watches(&viewv1beta1.ManagedClusterView{}, mcvMapFun, builder.WithPredicates(mcvPred)).
+		Watches(&viewv1beta1.ManagedClusterView{}, r.ViewSubscriptions.EventHandler(drClusterViewSubscriber),
+			builder.WithPredicates(util.ManagedClusterViewResultVersionUpdatePredicate{})).
 		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.drClusterConfigMapMapFunc)).
 		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.drClusterSecretMapFunc),
 			builder.WithPredicates(util.CreateOrDeleteOrResourceVersionUpdatePredicate{}),
@@ -171,8 +206,13 @@ func (r *DRClusterReconciler) drClusterConfigMapMapFunc(
 }
 
 func (r *DRClusterReconciler) drClusterSecretMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	s3SessionPoolInvalidate(client.ObjectKeyFromObject(obj))
+
 	if obj.GetNamespace() != RamenOperatorNamespace() {
-		return []reconcile.Request{}
+		_, ramenConfig, err := ConfigMapGet(ctx, r.APIReader)
+		if err != nil || !s3SecretNamespaceAllowed(ramenConfig, obj.GetNamespace()) {
+			return []reconcile.Request{}
+		}
 	}
 
 	secret, ok := obj.(*corev1.Secret)
@@ -232,7 +272,7 @@ func DRPCUpdateOfInterest(oldDRPC, newDRPC *ramen.DRPlacementControl) bool {
 	}
 
 	// Process DRPC, if action was not changed, but failover cluster was
-	if oldDRPC.Spec.FailoverCluster != newDRPC.Spec.FailoverCluster {
+	if drpcFailoverCluster(oldDRPC) != drpcFailoverCluster(newDRPC) {
 		log.Info("Processing DRPC failover cluster change event",
 			"name", newDRPC.GetName(),
 			"namespace", newDRPC.GetNamespace())
@@ -266,14 +306,14 @@ func DRPCUpdateOfInterest(oldDRPC, newDRPC *ramen.DRPlacementControl) bool {
 // filterDRPC relies on the predicate DRPCIpdateOfInterest to filter out any DRPC other than ones failing over, as a
 // result the filter function just uses the failoverCluster value to start the appropriate DRCluster reconcile
 func filterDRPC(drpc *ramen.DRPlacementControl) []ctrl.Request {
-	if drpc.Spec.FailoverCluster == "" {
+	if drpcFailoverCluster(drpc) == "" {
 		return []ctrl.Request{}
 	}
 
 	return []ctrl.Request{
 		reconcile.Request{
 			NamespacedName: types.NamespacedName{
-				Name: drpc.Spec.FailoverCluster,
+				Name: drpcFailoverCluster(drpc),
 			},
 		},
 	}
@@ -335,7 +375,7 @@ func filterDRClusterSecret(ctx context.Context, reader client.Reader, secret *co
 			continue
 		}
 
-		if secret.GetName() == s3StoreProfile.S3SecretRef.Name {
+		if client.ObjectKeyFromObject(secret) == s3SecretNamespacedName(s3StoreProfile) {
 			requests = append(requests,
 				reconcile.Request{
 					NamespacedName: types.NamespacedName{Name: drcluster.GetName()},
@@ -359,9 +399,11 @@ func filterDRClusterSecret(ctx context.Context, reader client.Reader, secret *co
 // +kubebuilder:rbac:groups=apps.open-cluster-management.io,resources=placementrules,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=placements,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get
 // +kubebuilder:rbac:groups=argoproj.io,resources=applicationsets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=list;watch
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=core,resources=events,verbs=list
 
 func (r *DRClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	// TODO: Validate managedCluster name? and also ensure it is not deleted!
@@ -370,6 +412,7 @@ func (r *DRClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	log.Info("reconcile enter")
 
 	defer log.Info("reconcile exit")
+	defer r.ActionTracker.reconcileStart()()
 
 	drcluster := &ramen.DRCluster{}
 	if err := r.Client.Get(ctx, req.NamespacedName, drcluster); err != nil {
@@ -410,29 +453,59 @@ func (r DRClusterReconciler) processCreateOrUpdate(u *drclusterInstance) (ctrl.R
 
 	u.log.Info("create/update")
 
+	stageDone := timeReconcileStage(reconcileStageDRCluster, stageConfigGet)
 	_, ramenConfig, err := ConfigMapGet(u.ctx, r.APIReader)
+
+	stageDone()
+
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("config map get: %w", u.validatedSetFalseAndUpdate("ConfigMapGetFailed", err))
 	}
 
+	u.mwUtil.SetPropagatedMetadata(u.object, ramenConfig.MetadataPropagation)
+	u.mwUtil.SetReapplyToken(u.object)
+	u.mwUtil.SetStatusFeedback(ramenConfig.ManagedClusterStatusSource)
+	u.mwUtil.SetManifestTransport(ramenConfig.ManifestTransport)
+
 	if err := u.addLabelsAndFinalizers(); err != nil {
 		return ctrl.Result{}, fmt.Errorf("finalizer add update: %w", u.validatedSetFalseAndUpdate("FinalizerAddFailed", err))
 	}
 
-	if err := drClusterDeploy(u, ramenConfig); err != nil {
+	u.collectDRClusterDiagnostics()
+
+	stageDone = timeReconcileStage(reconcileStageDRCluster, stageDeploy)
+	err = drClusterDeploy(u, ramenConfig)
+
+	stageDone()
+
+	u.updateManifestWorkSplitCondition(err)
+
+	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("drclusters deploy: %w", u.validatedSetFalseAndUpdate("DrClustersDeployFailed", err))
 	}
 
 	drclusterMetrics := createDRClusterMetricsInstance(u.object)
 
+	u.resumeFromActionCheckpoint()
+
+	stageDone = timeReconcileStage(reconcileStageDRCluster, stageFenceHandling)
 	requeue, err = u.clusterFenceHandle()
+
+	stageDone()
+
 	if err != nil {
 		u.log.Info("Error during processing fencing", "error", err)
 	}
 
-	if reason, err := validateS3Profile(u.ctx, r.APIReader, r.ObjectStoreGetter, u.object, u.namespacedName.String(),
-		u.log); err != nil {
-		return ctrl.Result{}, fmt.Errorf("drclusters s3Profile validate: %w", u.validatedSetFalseAndUpdate(reason, err))
+	u.updateViewRefresh()
+
+	if err := u.s3ProfileReferenceValidate(ramenConfig); err != nil {
+		return ctrl.Result{}, fmt.Errorf("drclusters s3Profile reference validate: %w",
+			u.validatedSetFalseAndUpdate(ReasonS3ProfileNotFound, err))
+	}
+
+	if err := r.s3ProfileValidate(u, ramenConfig); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	if err := u.getDRClusterDeployedStatus(u.object); err != nil {
@@ -441,20 +514,39 @@ func (r DRClusterReconciler) processCreateOrUpdate(u *drclusterInstance) (ctrl.R
 	}
 
 	if err := u.ensureDRClusterConfig(); err != nil {
+		reason := "DRClusterConfigInProgress"
+		if errors.Is(err, errClusterIDChanged) {
+			reason = "ClusterIDChanged"
+		}
+
 		return ctrl.Result{}, fmt.Errorf(
 			"failed to ensure DRClusterConfig: %w",
-			u.validatedSetFalseAndUpdate("DRClusterConfigInProgress", err),
+			u.validatedSetFalseAndUpdate(reason, err),
 		)
 	}
 
-	if err = u.validateCIDRs(drclusterMetrics.InvalidCIDRsDetectedMetrics, u.log); err != nil {
+	stageDone = timeReconcileStage(reconcileStageDRCluster, stageCIDRValidation)
+	err = u.validateCIDRs(drclusterMetrics.InvalidCIDRsDetectedMetrics, u.log)
+
+	stageDone()
+
+	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("drclusters CIDRs validate: %w",
 			u.validatedSetFalseAndUpdate(ReasonValidationFailed, err))
 	}
 
+	if err := validateFencingSpec(u.object.Spec.Fencing); err != nil {
+		return ctrl.Result{}, fmt.Errorf("drclusters fencing validate: %w",
+			u.validatedSetFalseAndUpdate(ReasonValidationFailed, err))
+	}
+
 	setDRClusterValidatedCondition(&u.object.Status.Conditions, u.object.Generation, "Validated the cluster")
 
-	err = u.clusterMModeHandler()
+	stageDone = timeReconcileStage(reconcileStageDRCluster, stageMModeHandling)
+	err = u.clusterMModeHandler(ramenConfig)
+
+	stageDone()
+
 	if err != nil {
 		requeue = true
 
@@ -465,7 +557,7 @@ func (r DRClusterReconciler) processCreateOrUpdate(u *drclusterInstance) (ctrl.R
 		u.log.Info("failed to update status", "failure", err)
 	}
 
-	return ctrl.Result{Requeue: requeue || u.requeue}, nil
+	return ctrl.Result{Requeue: requeue || u.requeue, RequeueAfter: u.requeueAfter}, nil
 }
 
 func (u *drclusterInstance) initializeStatus() {
@@ -494,7 +586,11 @@ func (u *drclusterInstance) getDRClusterDeployedStatus(drcluster *ramen.DRCluste
 		return fmt.Errorf("missing DRCluster ManifestWork resource %v", err)
 	}
 
-	deployed := util.IsManifestInAppliedState(mw)
+	deployed, err := u.mwUtil.IsManifestWorkGroupInAppliedState(mw)
+	if err != nil {
+		return fmt.Errorf("error in fetching DRCluster ManifestWork parts %w", err)
+	}
+
 	if !deployed {
 		return fmt.Errorf("DRCluster ManifestWork is not in applied state")
 	}
@@ -514,10 +610,14 @@ func createDRClusterMetricsInstance(drcluster *ramen.DRCluster) DRClusterMetrics
 // validateCIDRsDetected ensures all CIDRs in DRCluster spec are detected
 // in StorageAccessDetails from DRClusterConfig status.
 //
-// Validation is skipped if DRClusterConfig is not found or StorageAccessDetails is empty.
+// Validation is skipped if the DRCluster has no CIDRs, DRClusterConfig is not found or StorageAccessDetails is empty.
 // The watch on ManagedClusterView/ManifestWork will trigger reconciliation when these
 // become available. Returns an error if any CIDRs in spec are not found in the detected set.
 func (u *drclusterInstance) validateCIDRsDetected() error {
+	if len(u.object.Spec.CIDRs) == 0 {
+		return nil
+	}
+
 	drcConfig, err := u.getDRCCFromCluster(u.object)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -562,6 +662,13 @@ func (u *drclusterInstance) validateCIDRs(metrics InvalidCIDRsDetectedMetrics, l
 		return err
 	}
 
+	err = validateCIDRGroups(u.object)
+	if err != nil {
+		metrics.InvalidCIDRsDetected.Set(1)
+
+		return err
+	}
+
 	err = u.validateCIDRsDetected()
 	if err != nil {
 		metrics.InvalidCIDRsDetected.Set(1)
@@ -574,6 +681,36 @@ func (u *drclusterInstance) validateCIDRs(metrics InvalidCIDRsDetectedMetrics, l
 	return nil
 }
 
+// s3ProfileValidate validates the S3 profile of the DRCluster, failing the reconcile if it is invalid, or, if the
+// ramen config validates the S3 profiles asynchronously, reports the result in its S3ProfileValidated condition
+func (r DRClusterReconciler) s3ProfileValidate(u *drclusterInstance, ramenConfig *ramen.RamenConfig) error {
+	defer timeReconcileStage(reconcileStageDRCluster, stageS3Validation)()
+
+	// The validation may run in the background, while the reconcile updates the DRCluster
+	if err := s3ProfileEncryptionValidate(ramenConfig, u.object.Spec.S3ProfileName); err != nil {
+		return fmt.Errorf("drclusters s3Profile validate: %w",
+			u.validatedSetFalseAndUpdate("s3EncryptionInvalid", err))
+	}
+
+	drcluster := u.object.DeepCopy()
+	validate := func(ctx context.Context) (string, error) {
+		return validateS3Profile(ctx, r.APIReader, r.ObjectStoreGetter, drcluster, u.namespacedName.String(), u.log)
+	}
+
+	if ramenConfig.S3ProfileValidation.Async && r.s3ProfileValidator != nil &&
+		u.object.Spec.S3ProfileName != NoS3StoreAvailable {
+		u.s3ProfileValidateAsync(validate)
+
+		return nil
+	}
+
+	if reason, err := validate(u.ctx); err != nil {
+		return fmt.Errorf("drclusters s3Profile validate: %w", u.validatedSetFalseAndUpdate(reason, err))
+	}
+
+	return nil
+}
+
 func validateS3Profile(ctx context.Context, apiReader client.Reader,
 	objectStoreGetter ObjectStoreGetter,
 	drcluster *ramen.DRCluster, listKeyPrefix string, log logr.Logger,
@@ -595,6 +732,10 @@ func s3ProfileValidate(ctx context.Context, apiReader client.Reader,
 	objectStore, _, err := objectStoreGetter.ObjectStore(
 		ctx, apiReader, s3ProfileName, "drpolicy validation", log)
 	if err != nil {
+		if errors.Is(err, errObjectStoreTransportInvalid) {
+			return "s3TransportInvalid", fmt.Errorf("%s: %w", s3ProfileName, err)
+		}
+
 		return "s3ConnectionFailed", fmt.Errorf("%s: %w", s3ProfileName, err)
 	}
 
@@ -602,6 +743,10 @@ func s3ProfileValidate(ctx context.Context, apiReader client.Reader,
 		return "s3ListFailed", fmt.Errorf("%s: %w", s3ProfileName, err)
 	}
 
+	if err := objectStoreEncryptionCheck(objectStore); err != nil {
+		return "s3EncryptionFailed", fmt.Errorf("%s: %w", s3ProfileName, err)
+	}
+
 	return "", nil
 }
 
@@ -627,6 +772,13 @@ func validateCIDRsFormat(drcluster *ramen.DRCluster, log logr.Logger) error {
 func (r DRClusterReconciler) processDeletion(u *drclusterInstance) (ctrl.Result, error) {
 	u.log.Info("delete")
 
+	_, ramenConfig, err := ConfigMapGet(u.ctx, r.APIReader)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("config map get: %w", err)
+	}
+
+	u.mwUtil.SetManifestTransport(ramenConfig.ManifestTransport)
+
 	// Undeploy manifests
 	if err := drClusterUndeploy(u.object, u.mwUtil, u.reconciler.MCVGetter, u.log); err != nil {
 		return ctrl.Result{}, fmt.Errorf("drclusters undeploy: %w", err)
@@ -644,9 +796,21 @@ func (r DRClusterReconciler) processDeletion(u *drclusterInstance) (ctrl.Result,
 		}
 	}
 
+	if err := u.fenceVerificationClean(); err != nil {
+		return ctrl.Result{}, fmt.Errorf("fence verification cleanup: %w", err)
+	}
+
 	invalidCIDRsLabels := InvalidCIDRsDetectedMetricLabels(u.object)
 	DeleteInvalidCIDRsDetectedMetric(invalidCIDRsLabels)
 
+	if r.s3ProfileValidator != nil {
+		r.s3ProfileValidator.forget(u.object.Name)
+	}
+
+	r.ViewIntervals.SetIntervals(u.object.Name, nil)
+	r.ViewIntervals.SetActive(drClusterViewRefreshHolder(u.object))
+	r.ViewSubscriptions.Unsubscribe(drClusterViewSubscriber, types.NamespacedName{Name: u.object.Name})
+
 	if err := u.finalizerRemove(); err != nil {
 		return ctrl.Result{}, fmt.Errorf("finalizer remove update: %w", err)
 	}
@@ -664,6 +828,8 @@ type drclusterInstance struct {
 	mwUtil              *util.MWUtil
 	namespacedName      types.NamespacedName
 	requeue             bool
+	requeueAfter        time.Duration
+	fencePeer           string
 }
 
 func (u *drclusterInstance) validatedSetFalseAndUpdate(reason string, err error) error {
@@ -696,16 +862,28 @@ func (u *drclusterInstance) statusUpdate() error {
 			return fmt.Errorf("failed to update drCluster status (%s/%s)", u.object.Name, u.object.Namespace)
 		}
 
+		u.actionTracker().drClusterPersisted(u.object)
+
 		u.log.Info(fmt.Sprintf("Updated drCluster Status (%s/%s)", u.object.Name, u.object.Namespace))
 
 		return nil
 	}
 
 	u.log.Info(fmt.Sprintf("Nothing to update (%s/%s)", u.object.Name, u.object.Namespace))
+	u.actionTracker().drClusterPersisted(u.object)
 
 	return nil
 }
 
+// actionTracker returns the tracker of the state of the fence or unfence operation in memory, if any
+func (u *drclusterInstance) actionTracker() *ActionTracker {
+	if u.reconciler == nil {
+		return nil
+	}
+
+	return u.reconciler.ActionTracker
+}
+
 const drClusterFinalizerName = "drclusters.ramendr.openshift.io/ramen"
 
 func (u *drclusterInstance) addLabelsAndFinalizers() error {
@@ -727,6 +905,10 @@ func (u *drclusterInstance) ensureDRClusterConfig() error {
 		return err
 	}
 
+	if err := u.clusterIDChangeCheck(drcConfig.Spec.ClusterID); err != nil {
+		return err
+	}
+
 	if err := u.mwUtil.CreateOrUpdateDRCConfigManifestWork(u.object.Name, *drcConfig); err != nil {
 		return fmt.Errorf("failed to create or update DRClusterConfig manifest on cluster %s (%w)",
 			u.object.GetName(), err)
@@ -736,9 +918,134 @@ func (u *drclusterInstance) ensureDRClusterConfig() error {
 		return fmt.Errorf("DRClusterConfig is not applied to cluster (%s)", u.object.Name)
 	}
 
+	u.clusterConfigResultUpdate(drcConfig)
+
 	return nil
 }
 
+// errClusterIDChanged is returned when the clusterID of the managed cluster differs from the one published in its
+// DRClusterConfig, and the change is not acknowledged
+var errClusterIDChanged = errors.New("clusterID of the managed cluster changed")
+
+// clusterIDChangeCheck returns an error if clusterID differs from the clusterID published in the DRClusterConfig of
+// the cluster, unless the DRCluster acknowledges the change. A clusterID changes as the cluster is reinstalled, and
+// the storage peering, the peer classes of the DRPolicies and the replication of the workloads, are bound to the
+// clusterID published.
+func (u *drclusterInstance) clusterIDChangeCheck(clusterID string) error {
+	mw, err := u.mwUtil.FindManifestWorkByType(util.MWTypeDRCConfig, u.object.GetName())
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	published, err := util.ExtractDRCConfigFromManifestWork(mw)
+	if err != nil {
+		return fmt.Errorf("failed to extract DRClusterConfig from manifestwork %s: %w", mw.GetName(), err)
+	}
+
+	if published.Spec.ClusterID == "" || published.Spec.ClusterID == clusterID {
+		return nil
+	}
+
+	if u.object.GetAnnotations()[DRClusterClusterIDChangeAnnotation] == clusterID {
+		u.log.Info("ClusterID change acknowledged", "from", published.Spec.ClusterID, "to", clusterID)
+
+		return nil
+	}
+
+	return fmt.Errorf("%w from %s to %s, while the replication state is bound to the clusterID published; "+
+		"annotate the DRCluster with %s=%s to publish it", errClusterIDChanged, published.Spec.ClusterID, clusterID,
+		DRClusterClusterIDChangeAnnotation, clusterID)
+}
+
+// clusterConfigResultUpdate sets the result of applying the DRClusterConfig in the status, as read back from the
+// cluster. The result is left as is while the DRClusterConfig cannot be read back; its view is subscribed to, so the
+// DRCluster is reconciled to refresh the result as it changes.
+func (u *drclusterInstance) clusterConfigResultUpdate(desired *ramen.DRClusterConfig) {
+	applied, err := u.getDRCCFromCluster(u.object)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			u.log.Info("Failed to read back DRClusterConfig", "error", err)
+		}
+
+		return
+	}
+
+	u.object.Status.ClusterConfig = drClusterConfigResult(desired, applied)
+	u.updateOperandHealthyCondition()
+}
+
+// updateOperandHealthyCondition reports, using the OperandHealthy condition, the most recent of the errors the ramen
+// operator of the cluster reported, for them to be seen from the hub, along with the number of errors reported
+func (u *drclusterInstance) updateOperandHealthyCondition() {
+	operandErrors := u.object.Status.ClusterConfig.OperandErrors
+	if len(operandErrors) == 0 {
+		util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+			Type:               ramen.DRClusterConditionTypeOperandHealthy,
+			Reason:             ReasonOperandHealthy,
+			ObservedGeneration: u.object.Generation,
+			Status:             metav1.ConditionTrue,
+			Message:            "Ramen operator of the cluster reported no errors recently",
+		})
+
+		return
+	}
+
+	util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+		Type:               ramen.DRClusterConditionTypeOperandHealthy,
+		Reason:             ReasonOperandErrors,
+		ObservedGeneration: u.object.Generation,
+		Status:             metav1.ConditionFalse,
+		Message: fmt.Sprintf("Ramen operator of the cluster reported %d errors recently, most recently at %s: %s: %s",
+			len(operandErrors), operandErrors[0].Time.UTC().Format(time.RFC3339), operandErrors[0].Source,
+			operandErrors[0].Message),
+	})
+}
+
+// drClusterConfigResult returns the result of applying the desired DRClusterConfig, given the DRClusterConfig read
+// back from the cluster
+func drClusterConfigResult(desired, applied *ramen.DRClusterConfig) *ramen.DRClusterConfigResult {
+	result := &ramen.DRClusterConfigResult{
+		ObservedGeneration:            applied.Generation,
+		StorageClasses:                applied.Status.StorageClasses,
+		VolumeSnapshotClasses:         applied.Status.VolumeSnapshotClasses,
+		VolumeGroupSnapshotClasses:    applied.Status.VolumeGroupSnapshotClasses,
+		VolumeReplicationClasses:      applied.Status.VolumeReplicationClasses,
+		VolumeGroupReplicationClasses: applied.Status.VolumeGroupReplicationClasses,
+		NetworkFenceClasses:           applied.Status.NetworkFenceClasses,
+		VRGSchemaVersion:              applied.Status.VRGSchemaVersion,
+		OperandErrors:                 applied.Status.OperandErrors,
+	}
+
+	condition := util.FindCondition(applied.Status.Conditions, ramen.DRClusterConfigConfigurationProcessed)
+	if condition != nil && condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == applied.Generation {
+		result.ProcessedSchedules = applied.Spec.ReplicationSchedules
+	}
+
+	if condition := util.FindCondition(applied.Status.Conditions, ramen.DRClusterConfigPeerConnected); condition != nil {
+		result.PeerConnected = condition.Status
+	}
+
+	processed := sets.NewString(result.ProcessedSchedules...)
+
+	for _, schedule := range desired.Spec.ReplicationSchedules {
+		if !processed.Has(schedule) {
+			result.PendingSchedules = append(result.PendingSchedules, schedule)
+		}
+	}
+
+	for _, condition := range applied.Status.Conditions {
+		if condition.Status == metav1.ConditionFalse {
+			result.Errors = append(result.Errors, condition.Type+": "+condition.Message)
+		}
+	}
+
+	return result
+}
+
 //nolint:funlen
 func (u *drclusterInstance) generateDRClusterConfig() (*ramen.DRClusterConfig, error) {
 	mc, err := util.NewManagedClusterInstance(u.ctx, u.client, u.object.GetName())
@@ -780,22 +1087,19 @@ func (u *drclusterInstance) generateDRClusterConfig() (*ramen.DRClusterConfig, e
 			continue
 		}
 
-		if drpolicies.Items[idx].Spec.SchedulingInterval == "" {
-			continue
-		}
-
 		if !util.DrpolicyContainsDrcluster(&drpolicies.Items[idx], u.object.GetName()) {
 			continue
 		}
 
-		if exists, ok := added[drpolicies.Items[idx].Spec.SchedulingInterval]; !ok || !exists {
-			drcConfig.Spec.ReplicationSchedules = append(
-				drcConfig.Spec.ReplicationSchedules,
-				drpolicies.Items[idx].Spec.SchedulingInterval)
+		// the storage class scheduling intervals of the policy are replicated at too
+		for _, schedulingInterval := range util.DRPolicySchedulingIntervals(&drpolicies.Items[idx]) {
+			if exists, ok := added[schedulingInterval]; !ok || !exists {
+				drcConfig.Spec.ReplicationSchedules = append(drcConfig.Spec.ReplicationSchedules, schedulingInterval)
 
-			added[drpolicies.Items[idx].Spec.SchedulingInterval] = true
+				added[schedulingInterval] = true
 
-			u.log.Info(fmt.Sprintf("added %s", drpolicies.Items[idx].Spec.SchedulingInterval))
+				u.log.Info(fmt.Sprintf("added %s", schedulingInterval))
+			}
 		}
 	}
 
@@ -815,8 +1119,20 @@ func (u *drclusterInstance) generateDRClusterConfig() (*ramen.DRClusterConfig, e
 //
 // 3) Handle Ramen driven fencing here
 func (u *drclusterInstance) clusterFenceHandle() (bool, error) {
+	if u.object.Spec.ClusterFence != ramen.ClusterFenceStateFenced {
+		if err := u.fenceVerificationClean(); err != nil {
+			return true, err
+		}
+	}
+
 	switch u.object.Spec.ClusterFence {
 	case ramen.ClusterFenceStateUnfenced:
+		if fencingNotConfigured(u.object) {
+			u.fencingNotConfiguredSkip()
+
+			return false, nil
+		}
+
 		return u.clusterUnfence()
 
 	case ramen.ClusterFenceStateManuallyFenced:
@@ -833,6 +1149,12 @@ func (u *drclusterInstance) clusterFenceHandle() (bool, error) {
 		return false, nil
 
 	case ramen.ClusterFenceStateFenced:
+		if fencingNotConfigured(u.object) {
+			u.fencingNotConfiguredSkip()
+
+			return false, nil
+		}
+
 		return u.clusterFence()
 
 	default:
@@ -847,6 +1169,37 @@ func (u *drclusterInstance) clusterFenceHandle() (bool, error) {
 	}
 }
 
+// updateViewRefresh sets the view refresh intervals of the cluster from its spec, and switches the views of the
+// cluster, and of the peer cluster that fences or unfences it, to the active interval while it is being fenced or
+// unfenced, and back once done
+func (u *drclusterInstance) updateViewRefresh() {
+	intervals := u.reconciler.ViewIntervals
+	intervals.SetIntervals(u.object.Name, u.object.Spec.ViewRefresh)
+
+	holder := drClusterViewRefreshHolder(u.object)
+
+	if u.object.Status.Phase != ramen.Fencing && u.object.Status.Phase != ramen.Unfencing {
+		intervals.SetActive(holder)
+
+		return
+	}
+
+	clusters := []string{u.object.Name}
+	if u.fencePeer != "" {
+		clusters = append(clusters, u.fencePeer)
+	}
+
+	intervals.SetActive(holder, clusters...)
+}
+
+// drClusterViewSubscriber is the subscriber of the DRCluster reconciler to the results of ManagedClusterViews
+const drClusterViewSubscriber = "DRCluster"
+
+// drClusterViewRefreshHolder returns the holder of the active view refresh intervals for the actions of drCluster
+func drClusterViewRefreshHolder(drCluster *ramen.DRCluster) string {
+	return "DRCluster/" + drCluster.Name
+}
+
 func (u *drclusterInstance) handleDeletion() (bool, error) {
 	drpolicies, err := util.GetAllDRPolicies(u.ctx, u.reconciler.APIReader)
 	if err != nil {
@@ -887,6 +1240,25 @@ func getNFClassesFromCluster(
 	annotations := make(map[string]string)
 	// annotations[AllDRPolicyAnnotation] = clusterName
 
+	if len(nfClassNames) != 0 {
+		nfClassList, err := m.ListNFClassesFromManagedCluster(clusterName, annotations)
+
+		listed, err := classListViewUsable(u.log, "NetworkFenceClass", err)
+		if err != nil {
+			return []*csiaddonsv1alpha1.NetworkFenceClass{}, err
+		}
+
+		if listed {
+			nfClasses, err := classesByName[csiaddonsv1alpha1.NetworkFenceClass](nfClassList.Items, nfClassNames,
+				"NetworkFenceClass")
+			if err != nil {
+				return []*csiaddonsv1alpha1.NetworkFenceClass{}, err
+			}
+
+			return nfClasses, pruneNFClassViews(m, u.log, clusterName, nil)
+		}
+	}
+
 	for _, nfClassName := range nfClassNames {
 		nfClass, err := m.GetNFClassFromManagedCluster(nfClassName, clusterName, annotations)
 		if err != nil {
@@ -937,6 +1309,13 @@ func (u *drclusterInstance) getDRCCFromCluster(cluster *ramen.DRCluster) (*ramen
 	annotations := make(map[string]string)
 	annotations[DRClusterNameAnnotation] = cluster.GetName()
 
+	// The view may be shared with, and annotated by, the DRPolicy reconciler, so subscribe to its result
+	u.reconciler.ViewSubscriptions.Subscribe(util.ManagedClusterViewKey{
+		Cluster: cluster.GetName(),
+		GVK:     ramen.GroupVersion.WithKind("DRClusterConfig"),
+		Name:    types.NamespacedName{Name: cluster.GetName()},
+	}, drClusterViewSubscriber, types.NamespacedName{Name: cluster.GetName()})
+
 	drcConfig, err := u.reconciler.MCVGetter.GetDRClusterConfigFromManagedCluster(cluster.GetName(), annotations)
 	if err != nil {
 		return nil, err
@@ -954,6 +1333,11 @@ func (u *drclusterInstance) getNFClassesFromDRClusterConfig(cluster *ramen.DRClu
 		return nil, err
 	}
 
+	condition := util.FindCondition(drcConfig.Status.Conditions, ramen.DRClusterConfigFencingAvailable)
+	if condition != nil && condition.Status == metav1.ConditionFalse {
+		return nil, fmt.Errorf("%w on cluster %s: %s", errFencingUnavailable, cluster.GetName(), condition.Message)
+	}
+
 	nfClasses, err := getNFClassesFromCluster(u, u.reconciler.MCVGetter, drcConfig, cluster.GetName())
 	if err != nil {
 		return nil, err
@@ -967,6 +1351,35 @@ func (u *drclusterInstance) getNFClassesFromDRClusterConfig(cluster *ramen.DRClu
 	return u.findMatchingNFClasses(nfClasses, storageClasses), nil
 }
 
+// errFencingUnavailable is returned for a peer cluster on which the csi-addons fencing APIs are not installed
+var errFencingUnavailable = errors.New("fencing is unavailable")
+
+// fencingUnavailable returns the result of a fence or unfence that failed to get the NetworkFenceClasses of the peer
+// cluster with err. If fencing is unavailable on the peer cluster, the Fenced condition reports it, and the operation
+// is retried once the DRClusterConfig of the peer cluster changes, instead of failing the reconcile.
+func (u *drclusterInstance) fencingUnavailable(err error) (bool, error) {
+	if !errors.Is(err, errFencingUnavailable) {
+		return true, fmt.Errorf("failed to get NetworkFenceClasses: %w", err)
+	}
+
+	u.log.Info("Fencing unavailable", "error", err.Error())
+
+	status := metav1.ConditionUnknown
+	if condition := util.FindCondition(u.object.Status.Conditions, ramen.DRClusterConditionTypeFenced); condition != nil {
+		status = condition.Status
+	}
+
+	util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+		Type:               ramen.DRClusterConditionTypeFenced,
+		Reason:             DRClusterConditionReasonFencingUnavailable,
+		ObservedGeneration: u.object.Generation,
+		Status:             status,
+		Message:            err.Error(),
+	})
+
+	return false, nil
+}
+
 func (u *drclusterInstance) clusterFence() (bool, error) {
 	// Ideally, here it should collect all the DRClusters available
 	// in the cluster and then match the appropriate peer cluster
@@ -988,38 +1401,66 @@ func (u *drclusterInstance) clusterFence() (bool, error) {
 			u.object.Name, err)
 	}
 
+	u.fencePeer = peerCluster.Name
+
 	nfClasses, err := u.getNFClassesFromDRClusterConfig(&peerCluster)
 	if err != nil {
-		return true, fmt.Errorf("failed to get NetworkFenceClasses: %w", err)
+		return u.fencingUnavailable(err)
 	}
 
 	// If not fencing yet, create ALL ManifestWorks for all NetworkFenceClasses
 	if !u.isFencingOrFenced() {
 		u.log.Info(fmt.Sprintf("initiating the cluster fence from the cluster %s", peerCluster.Name))
 
-		for _, nfClass := range nfClasses {
-			if err := u.createNFManifestWork(u.object, &peerCluster, u.log, nfClass); err != nil {
-				setDRClusterFencingFailedCondition(&u.object.Status.Conditions, u.object.Generation,
-					fmt.Sprintf("NetworkFence ManifestWork creation failed: %v", err))
+		u.updateAgentUnavailableCondition(peerCluster.Name)
 
-				return true, fmt.Errorf("failed to create the NetworkFence MW on cluster %s to fence %s: %w",
-					peerCluster.Name, u.object.Name, err)
-			}
+		groups, err := fenceCIDRGroups(u.object)
+		if err != nil {
+			setDRClusterFencingFailedCondition(&u.object.Status.Conditions, u.object.Generation, err.Error())
+
+			return true, err
+		}
+
+		u.object.Status.FencedCIDRGroups = groups
+		targets := nfTargets(u.object)
+
+		results := nfClassesApply(nfClasses, func(nfClass string) error {
+			return nfTargetsApply(targets, func(target nfTarget) error {
+				return u.createNFManifestWork(u.object, &peerCluster, u.log, nfClass, target)
+			})
+		})
+		if err := nfClassResultsError(results); err != nil && len(nfClassResultsFailed(results)) == len(results) {
+			setDRClusterFencingFailedCondition(&u.object.Status.Conditions, u.object.Generation,
+				"NetworkFence ManifestWork creation failed: "+nfClassResultsSummary(results))
+
+			return true, fmt.Errorf("failed to create the NetworkFence MWs on cluster %s to fence %s: %w",
+				peerCluster.Name, u.object.Name, err)
 		}
 
+		// the ManifestWorks that failed to be created are created again while checking the fence status
 		setDRClusterFencingCondition(&u.object.Status.Conditions, u.object.Generation,
-			"ManifestWork for NetworkFence fence operation created")
+			"ManifestWork for NetworkFence fence operation created: "+nfClassResultsSummary(results))
 		u.setDRClusterPhase(ramen.Fencing)
 		// just created fencing resources. Requeue and then check.
 		return true, nil
 	}
 
-	// Already fencing, check ALL NetworkFence statuses
-	for _, nfClass := range nfClasses {
-		err := u.checkFenceStatus(&peerCluster, nfClass)
-		if err != nil {
-			return true, err
-		}
+	// Already fencing, check ALL NetworkFence statuses, and retry the classes that are not fenced yet
+	targets := nfTargets(u.object)
+	results := nfClassesApply(nfClasses, func(nfClass string) error {
+		return nfTargetsApply(targets, func(target nfTarget) error {
+			return u.nfClassRetryIfFailed(&peerCluster, nfClass, target,
+				u.checkFenceStatus(&peerCluster, nfClass, target))
+		})
+	})
+	if err := nfClassResultsError(results); err != nil {
+		u.nfClassResultsConditionUpdate(results)
+
+		return true, err
+	}
+
+	if requeue, err := u.fenceVerify(&peerCluster); requeue || err != nil {
+		return true, err
 	}
 
 	// All NetworkFences succeeded
@@ -1053,38 +1494,61 @@ func (u *drclusterInstance) clusterUnfence() (bool, error) {
 			u.object.Name, err)
 	}
 
+	u.fencePeer = peerCluster.Name
+
 	nfClasses, err := u.getNFClassesFromDRClusterConfig(&peerCluster)
 	if err != nil {
-		return true, fmt.Errorf("failed to get NetworkFenceClasses: %w", err)
+		return u.fencingUnavailable(err)
 	}
 
+	// the CIDR groups the fence operation fenced off are unfenced
+	targets := nfTargets(u.object)
+
 	// If not unfencing yet, create ALL ManifestWorks for all NetworkFenceClasses
 	if !u.isUnfencingOrUnfenced() {
 		u.log.Info(fmt.Sprintf("initiating the cluster unfence from the cluster %s", peerCluster.Name))
 
-		for _, nfClass := range nfClasses {
-			if err := u.createNFManifestWork(u.object, &peerCluster, u.log, nfClass); err != nil {
-				setDRClusterUnfencingFailedCondition(&u.object.Status.Conditions, u.object.Generation,
-					fmt.Sprintf("NetworkFence ManifestWork for unfence failed: %v", err))
+		u.updateAgentUnavailableCondition(peerCluster.Name)
 
-				return true, fmt.Errorf("failed to generate NetworkFence MW on cluster %s to unfence %s: %w",
-					peerCluster.Name, u.object.Name, err)
-			}
+		u.object.Status.UnfenceAcknowledgments = nil
+
+		results := nfClassesApply(nfClasses, func(nfClass string) error {
+			return nfTargetsApply(targets, func(target nfTarget) error {
+				return u.createNFManifestWork(u.object, &peerCluster, u.log, nfClass, target)
+			})
+		})
+		if err := nfClassResultsError(results); err != nil && len(nfClassResultsFailed(results)) == len(results) {
+			setDRClusterUnfencingFailedCondition(&u.object.Status.Conditions, u.object.Generation,
+				"NetworkFence ManifestWork for unfence failed: "+nfClassResultsSummary(results))
+
+			return true, fmt.Errorf("failed to generate NetworkFence MWs on cluster %s to unfence %s: %w",
+				peerCluster.Name, u.object.Name, err)
 		}
 
+		// the ManifestWorks that failed to be updated are updated again while checking the unfence status
 		setDRClusterUnfencingCondition(&u.object.Status.Conditions, u.object.Generation,
-			"ManifestWork for NetworkFence unfence operation created")
+			"ManifestWork for NetworkFence unfence operation created: "+nfClassResultsSummary(results))
 		u.setDRClusterPhase(ramen.Unfencing)
 		// just created unfencing resources. Requeue and then check.
 		return true, nil
 	}
 
-	// Already unfencing, check ALL NetworkFence statuses
-	for _, nfClass := range nfClasses {
-		err := u.checkUnfenceStatus(&peerCluster, nfClass)
-		if err != nil {
-			return true, err
+	// Already unfencing, check ALL NetworkFence statuses, and retry the classes that are not unfenced yet
+	results := nfClassesApply(nfClasses, func(nfClass string) error {
+		err := nfTargetsApply(targets, func(target nfTarget) error {
+			return u.nfClassRetryIfFailed(&peerCluster, nfClass, target,
+				u.checkUnfenceStatus(&peerCluster, nfClass, target))
+		})
+		if err == nil {
+			u.unfenceAcknowledgmentRecord(peerCluster.Name, nfClass)
 		}
+
+		return err
+	})
+	if err := nfClassResultsError(results); err != nil {
+		u.nfClassResultsConditionUpdate(results)
+
+		return true, err
 	}
 
 	// All NetworkFences succeeded
@@ -1092,24 +1556,71 @@ func (u *drclusterInstance) clusterUnfence() (bool, error) {
 		"Cluster successfully unfenced")
 	u.advanceToNextPhase()
 
-	// once this cluster is unfenced. Clean the fencing resource.
-	return u.cleanClusters([]ramen.DRCluster{*u.object, peerCluster})
+	return u.unfenceClean(peerCluster, nfClasses)
+}
+
+// unfenceClean cleans the fencing resources once this cluster is unfenced, and the unfence of the NetworkFences of
+// every class on peerCluster was acknowledged
+func (u *drclusterInstance) unfenceClean(peerCluster ramen.DRCluster, nfClasses []string) (bool, error) {
+	if err := u.unfenceAcknowledgmentsVerify(peerCluster.Name, nfClasses); err != nil {
+		return true, err
+	}
+
+	requeue, err := u.cleanClusters([]ramen.DRCluster{*u.object, peerCluster})
+	if !requeue && err == nil {
+		u.object.Status.FencedCIDRGroups = nil
+	}
+
+	return requeue, err
+}
+
+// nfViewErrorCondition returns the reason, defaulting to reason, and message of the fencing conditions for a
+// NetworkFence view that cannot be read, or an empty message for any other error. A view the agent is not permitted
+// to read, or of an unreachable cluster, is retried after a delay, as it cannot succeed until an administrator acts
+// or the cluster is available again.
+func (u *drclusterInstance) nfViewErrorCondition(peerCluster string, err error, reason string) (string, string) {
+	switch {
+	case util.IsMCVForbidden(err):
+		u.requeueAfter = nfViewForbiddenRequeueDelay
+
+		return DRClusterConditionReasonViewForbidden,
+			fmt.Sprintf("not permitted to read NetworkFence status from cluster %s: %v", peerCluster, err)
+	case util.IsMCVClusterUnreachable(err):
+		u.requeueAfter = nfViewClusterUnreachableRequeueDelay
+
+		return DRClusterConditionReasonClusterUnreachable,
+			fmt.Sprintf("cluster %s is unreachable, NetworkFence status is unknown", peerCluster)
+	}
+
+	if age, stale := util.IsMCVStale(err); stale {
+		return reason, fmt.Sprintf("NetworkFence status from cluster %s is stale for %v", peerCluster,
+			age.Round(time.Second))
+	}
+
+	if util.IsMCVProcessing(err) {
+		return reason, fmt.Sprintf("waiting for NetworkFence status from cluster %s", peerCluster)
+	}
+
+	return reason, ""
 }
 
 func (u *drclusterInstance) checkFenceStatus(peerCluster *ramen.DRCluster,
-	networkFenceClassName string,
+	networkFenceClassName string, target nfTarget,
 ) error {
 	annotations := make(map[string]string)
 	annotations[DRClusterNameAnnotation] = u.object.Name
 
-	nf, err := u.reconciler.MCVGetter.GetNFFromManagedCluster(u.object.Name, networkFenceClassName,
+	nf, err := u.reconciler.MCVGetter.GetNFFromManagedCluster(target.name, networkFenceClassName,
 		u.object.Namespace, peerCluster.Name, annotations)
 	if err != nil {
-		// dont update the status or conditions. Return requeue, nil as
-		// this indicates that NetworkFence resource might have been not yet
-		// created in the manged cluster or MCV for it might not have been
-		// created yet. This assumption is because, drCluster does not delete
-		// the NetworkFence resource as part of fencing.
+		// dont update the status or conditions, beyond reporting a view that is processing or stale. Return
+		// requeue, nil as this indicates that NetworkFence resource might have been not yet created in the
+		// manged cluster or MCV for it might not have been created yet. This assumption is because, drCluster
+		// does not delete the NetworkFence resource as part of fencing.
+		if reason, msg := u.nfViewErrorCondition(peerCluster.Name, err, DRClusterConditionReasonFencing); msg != "" {
+			setDRClusterFencingConditionWithReason(&u.object.Status.Conditions, u.object.Generation, reason, msg)
+		}
+
 		return fmt.Errorf("failed to get NetworkFence using MCV (error: %w)", err)
 	}
 
@@ -1119,12 +1630,12 @@ func (u *drclusterInstance) checkFenceStatus(peerCluster *ramen.DRCluster,
 	}
 
 	if nf.Status.Result != csiaddonsv1alpha1.FencingOperationResultSucceeded {
-		setDRClusterFencingFailedCondition(&u.object.Status.Conditions, u.object.Generation,
-			"fencing operation not successful")
+		msg := networkFenceFailureMessage("fencing", nf)
+		setDRClusterFencingFailedCondition(&u.object.Status.Conditions, u.object.Generation, msg)
 
-		u.log.Info("Fencing operation not successful", "cluster", u.object.Name)
+		u.log.Info("Fencing operation not successful", "cluster", u.object.Name, "message", msg)
 
-		return fmt.Errorf("fencing operation result not successful")
+		return fmt.Errorf("%s", msg)
 	}
 
 	return nil
@@ -1132,16 +1643,25 @@ func (u *drclusterInstance) checkFenceStatus(peerCluster *ramen.DRCluster,
 
 // checkUnfenceStatus checks the status of a NetworkFence unfence resource via ManagedClusterView
 func (u *drclusterInstance) checkUnfenceStatus(peerCluster *ramen.DRCluster,
-	networkFenceClassName string,
+	networkFenceClassName string, target nfTarget,
 ) error {
 	annotations := make(map[string]string)
 	annotations[DRClusterNameAnnotation] = u.object.Name
 
-	nf, err := u.reconciler.MCVGetter.GetNFFromManagedCluster(u.object.Name, networkFenceClassName,
+	nf, err := u.reconciler.MCVGetter.GetNFFromManagedCluster(target.name, networkFenceClassName,
 		u.object.Namespace, peerCluster.Name, annotations)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
-			return u.requeueIfNFMWExists(peerCluster)
+			if err := u.requeueIfNFMWExists(peerCluster); err != nil {
+				return err
+			}
+
+			// already cleaned up, hence there is no incomplete unfence to hide
+			return nil
+		}
+
+		if reason, msg := u.nfViewErrorCondition(peerCluster.Name, err, DRClusterConditionReasonUnfencing); msg != "" {
+			setDRClusterUnfencingConditionWithReason(&u.object.Status.Conditions, u.object.Generation, reason, msg)
 		}
 
 		return fmt.Errorf("failed to get NetworkFence using MCV (error: %w", err)
@@ -1153,12 +1673,19 @@ func (u *drclusterInstance) checkUnfenceStatus(peerCluster *ramen.DRCluster,
 	}
 
 	if nf.Status.Result != csiaddonsv1alpha1.FencingOperationResultSucceeded {
-		setDRClusterUnfencingFailedCondition(&u.object.Status.Conditions, u.object.Generation,
-			"unfencing operation not successful")
+		msg := networkFenceFailureMessage("unfencing", nf)
+		setDRClusterUnfencingFailedCondition(&u.object.Status.Conditions, u.object.Generation, msg)
 
-		u.log.Info("Unfencing operation not successful", "cluster", u.object.Name)
+		u.log.Info("Unfencing operation not successful", "cluster", u.object.Name, "message", msg)
+
+		return fmt.Errorf("%s", msg)
+	}
+
+	if !networkFenceUnfenced(nf) {
+		setDRClusterUnfencingCondition(&u.object.Status.Conditions, u.object.Generation,
+			fmt.Sprintf("waiting for the storage on cluster %s to acknowledge the unfence", peerCluster.Name))
 
-		return fmt.Errorf("un operation result not successful")
+		return fmt.Errorf("NetworkFence status does not reflect the unfence operation yet")
 	}
 
 	return nil
@@ -1224,20 +1751,22 @@ func (u *drclusterInstance) removeFencingCR(cluster ramen.DRCluster) (bool, erro
 		return true, fmt.Errorf("failed to get NetworkFenceClasses: %w", err)
 	}
 
-	// Delete ManifestWork for each NetworkFenceClass
+	// Delete ManifestWork for each NetworkFenceClass, of each target
 	for _, nfClass := range nfClasses {
-		name := u.object.Name
-		// Append NFC name to match the naming pattern used during creation
-		if nfClass != "" {
-			name += "-" + nfClass
-		}
+		for _, target := range nfTargets(u.object) {
+			name := target.name
+			// Append NFC name to match the naming pattern used during creation
+			if nfClass != "" {
+				name += "-" + nfClass
+			}
 
-		mwName := fmt.Sprintf(util.ManifestWorkNameFormat, name, cluster.Name, util.MWTypeNF)
+			mwName := fmt.Sprintf(util.ManifestWorkNameFormat, name, cluster.Name, util.MWTypeNF)
 
-		err := u.mwUtil.DeleteManifestWork(mwName, cluster.Name)
-		if err != nil {
-			return true, fmt.Errorf("failed to delete NetworkFence MW %s from cluster %s: %w",
-				mwName, cluster.Name, err)
+			err := u.mwUtil.DeleteManifestWork(mwName, cluster.Name)
+			if err != nil {
+				return true, fmt.Errorf("failed to delete NetworkFence MW %s from cluster %s: %w",
+					mwName, cluster.Name, err)
+			}
 		}
 	}
 
@@ -1373,16 +1902,22 @@ func setDRClusterInitialCondition(conditions *[]metav1.Condition, observedGenera
 // status of it.
 // unfence = true, fence = false, clean = true
 func setDRClusterFencingCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setDRClusterFencingConditionWithReason(conditions, observedGeneration, DRClusterConditionReasonFencing, message)
+}
+
+func setDRClusterFencingConditionWithReason(conditions *[]metav1.Condition, observedGeneration int64,
+	reason, message string,
+) {
 	util.SetStatusCondition(conditions, metav1.Condition{
 		Type:               ramen.DRClusterConditionTypeFenced,
-		Reason:             DRClusterConditionReasonFencing,
+		Reason:             reason,
 		ObservedGeneration: observedGeneration,
 		Status:             metav1.ConditionFalse,
 		Message:            message,
 	})
 	util.SetStatusCondition(conditions, metav1.Condition{
 		Type:               ramen.DRClusterConditionTypeClean,
-		Reason:             DRClusterConditionReasonFencing,
+		Reason:             reason,
 		ObservedGeneration: observedGeneration,
 		Status:             metav1.ConditionTrue,
 		Message:            message,
@@ -1397,16 +1932,22 @@ func setDRClusterFencingCondition(conditions *[]metav1.Condition, observedGenera
 // due to NetworkFence CR.
 // unfence = false, fence = true, clean = false
 func setDRClusterUnfencingCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setDRClusterUnfencingConditionWithReason(conditions, observedGeneration, DRClusterConditionReasonUnfencing, message)
+}
+
+func setDRClusterUnfencingConditionWithReason(conditions *[]metav1.Condition, observedGeneration int64,
+	reason, message string,
+) {
 	util.SetStatusCondition(conditions, metav1.Condition{
 		Type:               ramen.DRClusterConditionTypeFenced,
-		Reason:             DRClusterConditionReasonUnfencing,
+		Reason:             reason,
 		ObservedGeneration: observedGeneration,
 		Status:             metav1.ConditionTrue,
 		Message:            message,
 	})
 	util.SetStatusCondition(conditions, metav1.Condition{
 		Type:               ramen.DRClusterConditionTypeClean,
-		Reason:             DRClusterConditionReasonUnfencing,
+		Reason:             reason,
 		ObservedGeneration: observedGeneration,
 		Status:             metav1.ConditionFalse,
 		Message:            message,
@@ -1581,22 +2122,25 @@ func setDRClusterCleaningFailedCondition(conditions *[]metav1.Condition, observe
 }
 
 func (u *drclusterInstance) createNFManifestWork(targetCluster *ramen.DRCluster, peerCluster *ramen.DRCluster,
-	log logr.Logger, networkFenceClassName string,
+	log logr.Logger, networkFenceClassName string, target nfTarget,
 ) error {
 	// create NetworkFence ManifestWork
 	log.Info(fmt.Sprintf("Creating NetworkFence ManifestWork on cluster %s to perform fencing op on cluster %s",
-		peerCluster.Name, targetCluster.Name))
+		peerCluster.Name, targetCluster.Name), "target", target.name)
 
-	nf, err := generateNF(targetCluster, networkFenceClassName)
+	nf, err := generateNF(targetCluster, target, networkFenceClassName)
 	if err != nil {
 		return fmt.Errorf("failed to generate network fence resource: %w", err)
 	}
 
+	// traced to the failovers that requested the fence, if any, also as the cluster is unfenced
+	util.ActionTraceAnnotate(&nf, util.ActionTraceFromAnnotations(targetCluster))
+
 	annotations := make(map[string]string)
 	annotations[DRClusterNameAnnotation] = u.object.Name
 
 	if err := u.mwUtil.CreateOrUpdateNFManifestWork(
-		u.object.Name,
+		target.name,
 		peerCluster.Name, nf, annotations); err != nil {
 		log.Error(err, "failed to create or update NetworkFence manifest")
 
@@ -1608,11 +2152,22 @@ func (u *drclusterInstance) createNFManifestWork(targetCluster *ramen.DRCluster,
 }
 
 // this function fills the storage specific details in the NetworkFence resource.
-// Currently it fills those details based on the annotations that are set on the
-// DRCluster resource. However, in future it can be changed to get the storage
-// specific details (such as driver, parameters, secret etc) from the status of
-// the DRCluster resource.
+// The details are taken from the fencing spec of the DRCluster resource, and if
+// it is not set, from the annotations that are set on the DRCluster resource.
 func fillStorageDetails(cluster *ramen.DRCluster, nf *csiaddonsv1alpha1.NetworkFence) error {
+	if fencing := cluster.Spec.Fencing; fencing != nil {
+		if err := validateFencingSpec(fencing); err != nil {
+			return err
+		}
+
+		nf.Spec.Secret.Name = fencing.SecretName
+		nf.Spec.Secret.Namespace = fencing.SecretNamespace
+		nf.Spec.Driver = fencing.Driver
+		nf.Spec.Parameters = fencing.Parameters
+
+		return nil
+	}
+
 	storageDriver, ok := cluster.Annotations[StorageAnnotationDriver]
 	if !ok {
 		return fmt.Errorf("failed to find storage driver in annotations")
@@ -1643,31 +2198,33 @@ func fillStorageDetails(cluster *ramen.DRCluster, nf *csiaddonsv1alpha1.NetworkF
 	return nil
 }
 
-// generateNF creates a NetworkFence resource for the target cluster. When a NetworkFenceClassName
+// generateNF creates a NetworkFence resource for the target CIDRs of the target cluster. When a NetworkFenceClassName
 // is provided, it's included in the resource; otherwise, it falls back to filling storage details directly.
-// The resource includes CIDRs and fence state from the DRCluster specification.
-// Resource naming pattern:
-//   - Without NetworkFenceClass: "network-fence-" + cluster name
-//   - With NetworkFenceClass: "network-fence-" + NFClass name + "-" + cluster name
-func generateNF(targetCluster *ramen.DRCluster, networkFenceClassName string) (csiaddonsv1alpha1.NetworkFence, error) {
-	if len(targetCluster.Spec.CIDRs) == 0 {
+// The resource includes the CIDRs of the target and the fence state from the DRCluster specification.
+// Resource naming pattern, where the target name is the cluster name, suffixed with the CIDR group name if the
+// target is a CIDR group of the cluster:
+//   - Without NetworkFenceClass: "network-fence-" + target name
+//   - With NetworkFenceClass: "network-fence-" + NFClass name + "-" + target name
+func generateNF(targetCluster *ramen.DRCluster, target nfTarget, networkFenceClassName string,
+) (csiaddonsv1alpha1.NetworkFence, error) {
+	if len(target.cidrs) == 0 {
 		return csiaddonsv1alpha1.NetworkFence{}, fmt.Errorf("CIDRs has no values")
 	}
 
-	resourceName := strings.Join([]string{NetworkFencePrefix, targetCluster.Name}, "-")
+	resourceName := strings.Join([]string{NetworkFencePrefix, target.name}, "-")
 
 	nf := csiaddonsv1alpha1.NetworkFence{
 		TypeMeta:   metav1.TypeMeta{Kind: "NetworkFence", APIVersion: "csiaddons.openshift.io/v1alpha1"},
 		ObjectMeta: metav1.ObjectMeta{Name: resourceName},
 		Spec: csiaddonsv1alpha1.NetworkFenceSpec{
 			FenceState: csiaddonsv1alpha1.FenceState(targetCluster.Spec.ClusterFence),
-			Cidrs:      targetCluster.Spec.CIDRs,
+			Cidrs:      target.cidrs,
 		},
 	}
 	util.AddLabel(&nf, util.CreatedByRamenLabel, "true")
 
 	if networkFenceClassName != "" {
-		nf.Name = strings.Join([]string{NetworkFencePrefix, networkFenceClassName, targetCluster.Name}, "-")
+		nf.Name = strings.Join([]string{NetworkFencePrefix, networkFenceClassName, target.name}, "-")
 		nf.Spec.NetworkFenceClassName = networkFenceClassName
 
 		return nf, nil
@@ -1713,24 +2270,18 @@ func (u *drclusterInstance) setDRClusterPhase(nextPhase ramen.DRClusterPhase) {
 		u.log.Info(fmt.Sprintf("Phase: Current '%s'. Next '%s'",
 			u.object.Status.Phase, nextPhase))
 
+		if !statemachine.DRClusterFencing.Check(u.object.Status.Phase, nextPhase) {
+			u.log.Info("Phase transition is not in the DRCluster fencing state machine",
+				"from", u.object.Status.Phase, "to", nextPhase)
+		}
+
 		u.object.Status.Phase = nextPhase
+		u.actionTracker().drClusterRecord(u.object)
 	}
 }
 
 func (u *drclusterInstance) advanceToNextPhase() {
-	lastPhase := u.getLastDRClusterPhase()
-	nextPhase := lastPhase
-
-	switch lastPhase {
-	case ramen.Fencing:
-		nextPhase = ramen.Fenced
-	case ramen.Unfencing:
-		nextPhase = ramen.Unfenced
-	case ramen.Available:
-	case ramen.Fenced:
-	case ramen.Unfenced:
-	case ramen.Starting:
-	}
+	nextPhase, _ := statemachine.DRClusterFencing.Next(u.getLastDRClusterPhase(), statemachine.DRClusterCompleted)
 
 	u.setDRClusterPhase(nextPhase)
 }
@@ -378,7 +378,7 @@ var _ = Describe("DRClusterController", func() {
 			drcluster = drclusters[0].DeepCopy()
 		})
 		When("an S3Profile is missing in config", func() {
-			It("reports NOT validated with reason s3ConnectionFailed", func() {
+			It("reports NOT validated with reason S3ProfileNotFound", func() {
 				By("creating a new DRCluster with an invalid S3Profile")
 
 				drcluster.Spec.S3ProfileName = "missing"
@@ -388,11 +388,20 @@ var _ = Describe("DRClusterController", func() {
 					apiReader,
 					drcluster,
 					metav1.ConditionFalse,
-					Equal("s3ConnectionFailed"),
+					Equal(controllers.ReasonS3ProfileNotFound),
 					Ignore(),
 					ramen.DRClusterValidated,
 					false,
 				)
+				objectConditionExpectEventually(
+					apiReader,
+					drcluster,
+					metav1.ConditionTrue,
+					Equal(controllers.ReasonS3ProfileNotFound),
+					ContainSubstring(drcluster.Name+" (missing)"),
+					ramen.DRClusterConditionTypeDanglingS3ProfileReference,
+					false,
+				)
 			})
 		})
 		When("deleting a DRCluster", func() {
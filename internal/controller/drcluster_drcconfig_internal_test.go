@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRClusterConfig result", func() {
+	desired := &ramen.DRClusterConfig{
+		Spec: ramen.DRClusterConfigSpec{ReplicationSchedules: []string{"5m", "1h"}},
+	}
+
+	applied := func(processedGeneration int64, status metav1.ConditionStatus) *ramen.DRClusterConfig {
+		return &ramen.DRClusterConfig{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       ramen.DRClusterConfigSpec{ReplicationSchedules: []string{"5m"}},
+			Status: ramen.DRClusterConfigStatus{
+				Conditions: []metav1.Condition{{
+					Type:               ramen.DRClusterConfigConfigurationProcessed,
+					Status:             status,
+					ObservedGeneration: processedGeneration,
+					Message:            "message",
+				}, {
+					Type:    ramen.DRClusterConfigS3Reachable,
+					Status:  metav1.ConditionTrue,
+					Message: "reachable",
+				}},
+				StorageClasses:      []string{"sc"},
+				NetworkFenceClasses: []string{"nfc"},
+			},
+		}
+	}
+
+	It("reports the schedules processed by the cluster, those pending and the discovered classes", func() {
+		result := drClusterConfigResult(desired, applied(2, metav1.ConditionTrue))
+		Expect(result.ObservedGeneration).To(Equal(int64(2)))
+		Expect(result.ProcessedSchedules).To(Equal([]string{"5m"}))
+		Expect(result.PendingSchedules).To(Equal([]string{"1h"}))
+		Expect(result.StorageClasses).To(Equal([]string{"sc"}))
+		Expect(result.NetworkFenceClasses).To(Equal([]string{"nfc"}))
+		Expect(result.Errors).To(BeEmpty())
+	})
+
+	It("reports no schedule processed until the current generation is processed", func() {
+		result := drClusterConfigResult(desired, applied(1, metav1.ConditionTrue))
+		Expect(result.ProcessedSchedules).To(BeEmpty())
+		Expect(result.PendingSchedules).To(Equal([]string{"5m", "1h"}))
+	})
+
+	It("reports the false conditions as errors", func() {
+		result := drClusterConfigResult(desired, applied(2, metav1.ConditionFalse))
+		Expect(result.ProcessedSchedules).To(BeEmpty())
+		Expect(result.Errors).To(Equal([]string{ramen.DRClusterConfigConfigurationProcessed + ": message"}))
+	})
+
+	It("surfaces the errors of the ramen operator of the cluster in the OperandHealthy condition", func() {
+		drcConfig := applied(2, metav1.ConditionTrue)
+		u := &drclusterInstance{object: &ramen.DRCluster{}}
+
+		u.object.Status.ClusterConfig = drClusterConfigResult(desired, drcConfig)
+		u.updateOperandHealthyCondition()
+		Expect(meta.IsStatusConditionTrue(u.object.Status.Conditions, ramen.DRClusterConditionTypeOperandHealthy)).
+			To(BeTrue())
+
+		drcConfig.Status.OperandErrors = []ramen.OperandError{{
+			Time:    metav1.Now(),
+			Source:  "VolumeReplicationGroup app/vrg",
+			Message: "DataReady: failed",
+			Count:   1,
+		}}
+		u.object.Status.ClusterConfig = drClusterConfigResult(desired, drcConfig)
+		u.updateOperandHealthyCondition()
+		Expect(u.object.Status.ClusterConfig.OperandErrors).To(Equal(drcConfig.Status.OperandErrors))
+
+		condition := meta.FindStatusCondition(u.object.Status.Conditions, ramen.DRClusterConditionTypeOperandHealthy)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("VolumeReplicationGroup app/vrg: DataReady: failed"))
+	})
+})
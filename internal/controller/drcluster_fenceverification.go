@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+// fenceVerificationTimeoutDefault is how long a fence probe may run if its verification sets no timeout
+const fenceVerificationTimeoutDefault = 600 * time.Second
+
+func fenceVerificationTimeout(verification *ramen.FenceVerificationSpec) time.Duration {
+	if verification.TimeoutSeconds <= 0 {
+		return fenceVerificationTimeoutDefault
+	}
+
+	return time.Duration(verification.TimeoutSeconds) * time.Second
+}
+
+// fenceVerificationResult returns the result of a fence probe from the status of its Job, started at startTime, at
+// now
+func fenceVerificationResult(jobStatus *batchv1.JobStatus, startTime, now time.Time, timeout time.Duration,
+) (ramen.FenceVerificationResult, string) {
+	switch {
+	case jobStatus != nil && jobStatus.Succeeded > 0:
+		return ramen.FenceVerificationVerified, "Fence probe verified that the cluster lost access to its storage"
+	case jobStatus != nil && jobStatus.Failed > 0:
+		return ramen.FenceVerificationFailed, "Fence probe found that the cluster did not lose access to its storage"
+	case now.Sub(startTime) > timeout:
+		return ramen.FenceVerificationFailed, fmt.Sprintf("Fence probe did not complete in %v", timeout)
+	default:
+		return ramen.FenceVerificationPending, "Fence probe is running"
+	}
+}
+
+// fenceVerify verifies, once the NetworkFences created on peerCluster report success, that the cluster lost access
+// to its storage by running the probe of its fence verification on peerCluster, as a defense against fencing drivers
+// that report success before the storage stops serving the cluster. Returns true until the probe verifies the fence,
+// and an error if it fails to. A failed verification is not retried, for the cluster not to be reported fenced; it is
+// cleared once the cluster is unfenced.
+func (u *drclusterInstance) fenceVerify(peerCluster *ramen.DRCluster) (bool, error) {
+	verification := u.object.Spec.FenceVerification
+	if verification == nil {
+		return false, u.fenceVerificationClean()
+	}
+
+	status := u.object.Status.FenceVerification
+	if status == nil || status.Cluster != peerCluster.Name {
+		if err := u.fenceVerificationClean(); err != nil {
+			return true, err
+		}
+
+		return true, u.fenceProbeCreate(peerCluster, verification)
+	}
+
+	if status.Result == ramen.FenceVerificationPending {
+		jobStatus, err := u.fenceProbeStatus(peerCluster)
+		if err != nil {
+			return true, err
+		}
+
+		status.Result, status.Message = fenceVerificationResult(jobStatus, status.StartTime.Time, time.Now(),
+			fenceVerificationTimeout(verification))
+	}
+
+	switch status.Result {
+	case ramen.FenceVerificationVerified:
+		return false, nil
+	case ramen.FenceVerificationFailed:
+		setDRClusterFencingFailedCondition(&u.object.Status.Conditions, u.object.Generation, status.Message)
+
+		return true, fmt.Errorf("%s", status.Message)
+	default:
+		setDRClusterFencingCondition(&u.object.Status.Conditions, u.object.Generation, status.Message)
+
+		return true, nil
+	}
+}
+
+// fenceProbeCreate renders the probe of verification and creates it on peerCluster
+func (u *drclusterInstance) fenceProbeCreate(peerCluster *ramen.DRCluster,
+	verification *ramen.FenceVerificationSpec,
+) error {
+	cidrs := []string{}
+	for _, target := range nfTargets(u.object) {
+		cidrs = append(cidrs, target.cidrs...)
+	}
+
+	data := struct {
+		ClusterName       string
+		FencedClusterName string
+		CIDRs             []string
+	}{
+		ClusterName:       peerCluster.Name,
+		FencedClusterName: u.object.Name,
+		CIDRs:             cidrs,
+	}
+
+	probe, err := manifestTemplateRender(verification.Probe, data)
+	if err != nil {
+		return fmt.Errorf("fence probe template %q: %w", verification.Probe.Name, err)
+	}
+
+	annotations := map[string]string{DRClusterNameAnnotation: u.object.Name}
+
+	if err := u.mwUtil.CreateOrUpdateFenceProbeManifestWork(u.object.Name, peerCluster.Name, probe,
+		annotations); err != nil {
+		return fmt.Errorf("failed to create the fence probe on cluster %s: %w", peerCluster.Name, err)
+	}
+
+	u.log.Info("Created fence probe", "cluster", peerCluster.Name, "probe", probe.GetName())
+
+	u.object.Status.FenceVerification = &ramen.FenceVerificationStatus{
+		Cluster:   peerCluster.Name,
+		Result:    ramen.FenceVerificationPending,
+		Message:   "Fence probe created",
+		StartTime: metav1.Now(),
+	}
+
+	setDRClusterFencingCondition(&u.object.Status.Conditions, u.object.Generation,
+		"NetworkFences succeeded, verifying the fence with probe "+probe.GetName())
+
+	return nil
+}
+
+// fenceProbeStatus returns the status of the fence probe Job on peerCluster, or nil if it is not reported yet
+func (u *drclusterInstance) fenceProbeStatus(peerCluster *ramen.DRCluster) (*batchv1.JobStatus, error) {
+	mw := &ocmworkv1.ManifestWork{}
+
+	err := u.client.Get(u.ctx, types.NamespacedName{
+		Name:      util.FenceProbeManifestWorkName(u.object.Name, peerCluster.Name),
+		Namespace: peerCluster.Name,
+	}, mw)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get the fence probe ManifestWork: %w", err)
+	}
+
+	jobStatus, err := util.ExtractFenceProbeFromManifestWork(mw)
+	if err != nil && util.IsMCVProcessing(err) {
+		return nil, nil
+	}
+
+	return jobStatus, err
+}
+
+// fenceVerificationClean deletes the fence probe of the last fence operation, once the cluster is no longer fenced,
+// or its fence is verified on another peer cluster
+func (u *drclusterInstance) fenceVerificationClean() error {
+	status := u.object.Status.FenceVerification
+	if status == nil {
+		return nil
+	}
+
+	if err := u.mwUtil.DeleteManifestWork(util.FenceProbeManifestWorkName(u.object.Name, status.Cluster),
+		status.Cluster); err != nil {
+		return fmt.Errorf("failed to delete the fence probe on cluster %s: %w", status.Cluster, err)
+	}
+
+	u.object.Status.FenceVerification = nil
+
+	return nil
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRCluster fence verification", func() {
+	startTime := time.Now()
+	timeout := time.Minute
+
+	result := func(jobStatus *batchv1.JobStatus, elapsed time.Duration) ramen.FenceVerificationResult {
+		result, _ := fenceVerificationResult(jobStatus, startTime, startTime.Add(elapsed), timeout)
+
+		return result
+	}
+
+	It("is verified once the probe succeeds", func() {
+		Expect(result(&batchv1.JobStatus{Succeeded: 1}, 2*timeout)).To(Equal(ramen.FenceVerificationVerified))
+	})
+
+	It("fails if the probe fails, or does not complete in time", func() {
+		Expect(result(&batchv1.JobStatus{Failed: 1}, 0)).To(Equal(ramen.FenceVerificationFailed))
+		Expect(result(nil, 2*timeout)).To(Equal(ramen.FenceVerificationFailed))
+		Expect(result(&batchv1.JobStatus{Active: 1}, 2*timeout)).To(Equal(ramen.FenceVerificationFailed))
+	})
+
+	It("is pending while the probe runs", func() {
+		Expect(result(nil, 0)).To(Equal(ramen.FenceVerificationPending))
+		Expect(result(&batchv1.JobStatus{Active: 1}, timeout/2)).To(Equal(ramen.FenceVerificationPending))
+	})
+
+	It("defaults the timeout of the probe", func() {
+		Expect(fenceVerificationTimeout(&ramen.FenceVerificationSpec{})).To(Equal(fenceVerificationTimeoutDefault))
+		Expect(fenceVerificationTimeout(&ramen.FenceVerificationSpec{TimeoutSeconds: 30})).To(Equal(30 * time.Second))
+	})
+
+	It("renders the probe with the fenced cluster and its CIDRs", func() {
+		probe, err := manifestTemplateRender(ramen.ManifestTemplate{
+			Name: "blocklist",
+			Template: `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: fence-probe-{{ .FencedClusterName }}
+  namespace: rook-ceph
+spec:
+  template:
+    spec:
+      containers:
+      - name: probe
+        args: ["{{ index .CIDRs 0 }}"]`,
+		}, struct {
+			ClusterName       string
+			FencedClusterName string
+			CIDRs             []string
+		}{ClusterName: "west", FencedClusterName: "east", CIDRs: []string{"10.0.0.0/24"}})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(probe.GetName()).To(Equal("fence-probe-east"))
+		Expect(probe.GetKind()).To(Equal("Job"))
+	})
+})
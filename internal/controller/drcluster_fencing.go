@@ -0,0 +1,366 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+// FencingParametersValidator validates the fencing parameters of a storage driver
+type FencingParametersValidator func(parameters map[string]string) error
+
+var (
+	fencingValidatorsMutex sync.RWMutex
+
+	// fencingValidators are keyed by a driver name suffix, as drivers are commonly deployed with a namespace
+	// prefix, e.g. openshift-storage.rbd.csi.ceph.com
+	fencingValidators = map[string]FencingParametersValidator{
+		"rbd.csi.ceph.com":    cephFencingParametersValidate,
+		"cephfs.csi.ceph.com": cephFencingParametersValidate,
+	}
+)
+
+// RegisterFencingParametersValidator registers a validator for the fencing parameters of drivers whose name ends
+// with driverSuffix, replacing any validator previously registered for the same suffix
+func RegisterFencingParametersValidator(driverSuffix string, validator FencingParametersValidator) {
+	fencingValidatorsMutex.Lock()
+	defer fencingValidatorsMutex.Unlock()
+
+	fencingValidators[driverSuffix] = validator
+}
+
+// fencingParametersValidatorGet returns the validator with the longest suffix matching the driver, or nil if
+// there is none
+func fencingParametersValidatorGet(driver string) FencingParametersValidator {
+	fencingValidatorsMutex.RLock()
+	defer fencingValidatorsMutex.RUnlock()
+
+	var (
+		match     FencingParametersValidator
+		matchSize int
+	)
+
+	for suffix, validator := range fencingValidators {
+		if (driver == suffix || strings.HasSuffix(driver, "."+suffix)) && len(suffix) > matchSize {
+			match, matchSize = validator, len(suffix)
+		}
+	}
+
+	return match
+}
+
+// validateFencingSpec validates the driver specific fencing parameters against the validator of the fencing driver,
+// if any. The driver, secret and the format of the parameters are validated by the CRD schema, so drivers without a
+// registered validator are accepted as is.
+func validateFencingSpec(fencing *ramen.FencingSpec) error {
+	if fencing == nil {
+		return nil
+	}
+
+	validator := fencingParametersValidatorGet(fencing.Driver)
+	if validator == nil {
+		return nil
+	}
+
+	if err := validator(fencing.Parameters); err != nil {
+		return fmt.Errorf("invalid fencing parameters for driver %s: %w", fencing.Driver, err)
+	}
+
+	return nil
+}
+
+func cephFencingParametersValidate(parameters map[string]string) error {
+	if parameters["clusterID"] == "" {
+		return fmt.Errorf("missing required parameter clusterID")
+	}
+
+	unknown := []string{}
+
+	for key := range parameters {
+		if key != "clusterID" {
+			unknown = append(unknown, key)
+		}
+	}
+
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+
+		return fmt.Errorf("unknown parameters %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// fencingNotConfigured returns whether drCluster cannot be fenced by ramen, as it has no CIDRs for the NetworkFences
+// to fence off, and was not fenced or unfenced by ramen since, so that it has no NetworkFences to clean up either
+func fencingNotConfigured(drCluster *ramen.DRCluster) bool {
+	if len(drCluster.Spec.CIDRs) != 0 || len(drCluster.Status.FencedCIDRGroups) != 0 {
+		return false
+	}
+
+	switch drCluster.Status.Phase {
+	case "", ramen.Starting, ramen.Available:
+		return true
+	case ramen.Unfenced:
+		return meta.IsStatusConditionTrue(drCluster.Status.Conditions, ramen.DRClusterConditionTypeClean)
+	default:
+		return false
+	}
+}
+
+// fencingNotConfiguredSkip skips a fence or unfence operation of a cluster whose fencing is not configured, without
+// discovering the NetworkFenceClasses of its peer cluster nor creating ManifestWorks or views for them, and reports
+// it clean with the FencingNotConfigured reason, logging it once. The operation is attempted once CIDRs are set.
+func (u *drclusterInstance) fencingNotConfiguredSkip() {
+	condition := util.FindCondition(u.object.Status.Conditions, ramen.DRClusterConditionTypeFenced)
+	if condition == nil || condition.Reason != DRClusterConditionReasonFencingNotConfigured {
+		u.log.Info("Fencing not configured, skipping fencing", "clusterFence", u.object.Spec.ClusterFence)
+	}
+
+	msg := "Fencing not configured: the cluster has no CIDRs to fence off"
+
+	util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+		Type:               ramen.DRClusterConditionTypeFenced,
+		Reason:             DRClusterConditionReasonFencingNotConfigured,
+		ObservedGeneration: u.object.Generation,
+		Status:             metav1.ConditionFalse,
+		Message:            msg,
+	})
+	util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+		Type:               ramen.DRClusterConditionTypeClean,
+		Reason:             DRClusterConditionReasonFencingNotConfigured,
+		ObservedGeneration: u.object.Generation,
+		Status:             metav1.ConditionTrue,
+		Message:            msg,
+	})
+	u.setDRClusterPhase(ramen.Available)
+}
+
+// updateAgentUnavailableCondition warns, using the AgentUnavailable condition, if the klusterlet agents of the peer
+// cluster that is to perform the fence or unfence operation appear to be unavailable, as the NetworkFence
+// ManifestWork would silently stall, suggesting another cluster of the DRPolicies of the cluster whose agents are
+// available. A previously raised warning is cleared once the agents are available.
+func (u *drclusterInstance) updateAgentUnavailableCondition(peerCluster string) {
+	reason := util.ManagedClusterAgentUnavailable(u.ctx, u.reconciler.APIReader, peerCluster)
+	if reason != "" {
+		alternate := util.ManagedClusterAgentAvailableAlternate(u.ctx, u.reconciler.APIReader,
+			u.fenceAlternateCandidates(peerCluster))
+
+		u.log.Info("Klusterlet agents appear unavailable", "cluster", peerCluster, "reason", reason,
+			"alternate", alternate)
+		util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+			Type:               ramen.DRClusterConditionTypeAgentUnavailable,
+			Reason:             ReasonAgentUnavailable,
+			ObservedGeneration: u.object.Generation,
+			Status:             metav1.ConditionTrue,
+			Message:            agentUnavailableMessage(reason, alternate),
+		})
+
+		return
+	}
+
+	if meta.IsStatusConditionTrue(u.object.Status.Conditions, ramen.DRClusterConditionTypeAgentUnavailable) {
+		util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+			Type:               ramen.DRClusterConditionTypeAgentUnavailable,
+			Reason:             ReasonAgentAvailable,
+			ObservedGeneration: u.object.Generation,
+			Status:             metav1.ConditionFalse,
+			Message:            "Klusterlet agents of cluster " + peerCluster + " are available",
+		})
+	}
+}
+
+// fenceAlternateCandidates returns the clusters of the DRPolicies of the cluster, other than the cluster itself and
+// its peer cluster, that could perform its fence or unfence operation in place of the peer
+func (u *drclusterInstance) fenceAlternateCandidates(peerCluster string) []string {
+	drpolicies, err := util.GetAllDRPolicies(u.ctx, u.reconciler.APIReader)
+	if err != nil {
+		return nil
+	}
+
+	candidates := []string{}
+
+	for idx := range drpolicies.Items {
+		clusters := drpolicies.Items[idx].Spec.DRClusters
+		if !slices.Contains(clusters, u.object.Name) {
+			continue
+		}
+
+		for _, cluster := range clusters {
+			if cluster != u.object.Name && cluster != peerCluster && !slices.Contains(candidates, cluster) {
+				candidates = append(candidates, cluster)
+			}
+		}
+	}
+
+	return candidates
+}
+
+// networkFenceFailureMessage returns the message of the failed fencing or unfencing operation of nf, with the message
+// the storage driver reported in its status, if any, passed through
+func networkFenceFailureMessage(operation string, nf *csiaddonsv1alpha1.NetworkFence) string {
+	msg := operation + " operation not successful"
+	if driverMessage := util.StorageMessage(nf.Status.Message); driverMessage != "" {
+		msg += ": " + driverMessage
+	}
+
+	return msg
+}
+
+// networkFenceUnfenced returns true if the status of nf reflects a completed unfence operation. A Succeeded result may
+// be left over from the preceding fence operation until the csi-addons controller processes the change to Unfenced,
+// hence the status conditions, if any, must have observed the current generation, and the message must not be that
+// of a fence operation.
+func networkFenceUnfenced(nf *csiaddonsv1alpha1.NetworkFence) bool {
+	if nf.Spec.FenceState != csiaddonsv1alpha1.Unfenced ||
+		nf.Status.Result != csiaddonsv1alpha1.FencingOperationResultSucceeded {
+		return false
+	}
+
+	for idx := range nf.Status.Conditions {
+		if observed := nf.Status.Conditions[idx].ObservedGeneration; observed != 0 && observed < nf.Generation {
+			return false
+		}
+	}
+
+	return nf.Status.Message != csiaddonsv1alpha1.FenceOperationSuccessfulMessage
+}
+
+// unfenceAcknowledgmentRecord records that the unfence of the NetworkFence of nfClass on peerCluster was acknowledged
+func (u *drclusterInstance) unfenceAcknowledgmentRecord(peerCluster, nfClass string) {
+	if u.unfenceAcknowledged(peerCluster, nfClass) {
+		return
+	}
+
+	u.object.Status.UnfenceAcknowledgments = append(u.object.Status.UnfenceAcknowledgments,
+		ramen.UnfenceAcknowledgment{Cluster: peerCluster, NetworkFenceClass: nfClass, Time: metav1.Now()})
+}
+
+func (u *drclusterInstance) unfenceAcknowledged(peerCluster, nfClass string) bool {
+	return slices.ContainsFunc(u.object.Status.UnfenceAcknowledgments, func(ack ramen.UnfenceAcknowledgment) bool {
+		return ack.Cluster == peerCluster && ack.NetworkFenceClass == nfClass
+	})
+}
+
+// unfenceAcknowledgmentsVerify returns an error unless the unfence of the NetworkFences of every class on peerCluster
+// was acknowledged, so that cleaning the NetworkFence ManifestWorks does not hide an incomplete unfence
+func (u *drclusterInstance) unfenceAcknowledgmentsVerify(peerCluster string, nfClasses []string) error {
+	for _, nfClass := range nfClasses {
+		if !u.unfenceAcknowledged(peerCluster, nfClass) {
+			return fmt.Errorf("unfence of NetworkFence of class %q on cluster %s is not acknowledged", nfClass,
+				peerCluster)
+		}
+	}
+
+	return nil
+}
+
+// nfClassResult is the result of a fence or unfence operation on the NetworkFence of a NetworkFenceClass
+type nfClassResult struct {
+	nfClass string
+	err     error
+}
+
+// nfClassesApply applies op to the NetworkFence of each of nfClasses, attempting all of them even if some fail, so
+// that a failing class neither leaves the later classes untouched nor hides their state
+func nfClassesApply(nfClasses []string, op func(nfClass string) error) []nfClassResult {
+	results := make([]nfClassResult, 0, len(nfClasses))
+
+	for _, nfClass := range nfClasses {
+		results = append(results, nfClassResult{nfClass: nfClass, err: op(nfClass)})
+	}
+
+	return results
+}
+
+// nfClassResultsFailed returns the classes of the results that failed
+func nfClassResultsFailed(results []nfClassResult) []string {
+	failed := []string{}
+
+	for _, result := range results {
+		if result.err != nil {
+			failed = append(failed, result.nfClass)
+		}
+	}
+
+	return failed
+}
+
+// nfClassResultsError returns the errors of the results that failed, joined, or nil if all succeeded
+func nfClassResultsError(results []nfClassResult) error {
+	errs := []error{}
+
+	for _, result := range results {
+		if result.err != nil {
+			errs = append(errs, fmt.Errorf("NetworkFenceClass %q: %w", result.nfClass, result.err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// nfClassResultsSummary returns the number of results that succeeded and the error of each that failed, for the
+// message of the fencing conditions
+func nfClassResultsSummary(results []nfClassResult) string {
+	failures := []string{}
+
+	for _, result := range results {
+		if result.err != nil {
+			failures = append(failures, fmt.Sprintf("%q: %v", result.nfClass, result.err))
+		}
+	}
+
+	summary := fmt.Sprintf("%d of %d NetworkFenceClasses succeeded", len(results)-len(failures), len(results))
+	if len(failures) == 0 {
+		return summary
+	}
+
+	return summary + ", pending " + strings.Join(failures, "; ")
+}
+
+// nfClassResultsConditionUpdate sets the message of the Fenced condition to the summary of results, keeping the
+// status and reason set by the operation on the class that failed last, so that the condition reports every pending
+// class rather than the last one only
+func (u *drclusterInstance) nfClassResultsConditionUpdate(results []nfClassResult) {
+	condition := util.FindCondition(u.object.Status.Conditions, ramen.DRClusterConditionTypeFenced)
+	if condition == nil {
+		return
+	}
+
+	util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+		Type:               condition.Type,
+		Reason:             condition.Reason,
+		ObservedGeneration: u.object.Generation,
+		Status:             condition.Status,
+		Message:            nfClassResultsSummary(results),
+	})
+}
+
+// nfClassRetryIfFailed creates or updates the NetworkFence ManifestWork of nfClass for target on peerCluster again if
+// the operation on its NetworkFence is not complete, with err, as the ManifestWork may have failed to be created or
+// updated when the operation was initiated, and returns err
+func (u *drclusterInstance) nfClassRetryIfFailed(peerCluster *ramen.DRCluster, nfClass string, target nfTarget,
+	err error,
+) error {
+	if err == nil {
+		return nil
+	}
+
+	if mwErr := u.createNFManifestWork(u.object, peerCluster, u.log, nfClass, target); mwErr != nil {
+		return errors.Join(err, mwErr)
+	}
+
+	return err
+}
@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ocmv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("DRCluster fencing of multiple NetworkFenceClasses", func() {
+	nfClasses := []string{"rbd", "cephfs", "nfs"}
+
+	apply := func(failing ...string) []nfClassResult {
+		applied := []string{}
+
+		results := nfClassesApply(nfClasses, func(nfClass string) error {
+			applied = append(applied, nfClass)
+
+			for _, failingClass := range failing {
+				if nfClass == failingClass {
+					return errors.New("fence failed")
+				}
+			}
+
+			return nil
+		})
+		Expect(applied).To(Equal(nfClasses))
+
+		return results
+	}
+
+	It("attempts every class past a failing one and aggregates the failures", func() {
+		results := apply("rbd", "nfs")
+
+		Expect(nfClassResultsFailed(results)).To(Equal([]string{"rbd", "nfs"}))
+		Expect(nfClassResultsError(results)).To(And(
+			MatchError(ContainSubstring(`NetworkFenceClass "rbd": fence failed`)),
+			MatchError(ContainSubstring(`NetworkFenceClass "nfs": fence failed`)),
+		))
+		Expect(nfClassResultsSummary(results)).To(Equal(
+			`1 of 3 NetworkFenceClasses succeeded, pending "rbd": fence failed; "nfs": fence failed`))
+	})
+
+	It("reports no error when every class succeeds", func() {
+		results := apply()
+
+		Expect(nfClassResultsFailed(results)).To(BeEmpty())
+		Expect(nfClassResultsError(results)).To(Succeed())
+		Expect(nfClassResultsSummary(results)).To(Equal("3 of 3 NetworkFenceClasses succeeded"))
+	})
+
+	It("reports every pending class in the Fenced condition, keeping its status and reason", func() {
+		u := &drclusterInstance{object: &ramen.DRCluster{}}
+		setDRClusterFencingFailedCondition(&u.object.Status.Conditions, 1, "fencing operation not successful")
+
+		u.nfClassResultsConditionUpdate(apply("cephfs"))
+
+		condition := util.FindCondition(u.object.Status.Conditions, ramen.DRClusterConditionTypeFenced)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(DRClusterConditionReasonFenceError))
+		Expect(condition.Message).To(Equal(`2 of 3 NetworkFenceClasses succeeded, pending "cephfs": fence failed`))
+	})
+})
+
+var _ = Describe("DRCluster fencing failure messages", func() {
+	It("passes the message of the storage driver through, with its credentials redacted", func() {
+		nf := &csiaddonsv1alpha1.NetworkFence{Status: csiaddonsv1alpha1.NetworkFenceStatus{
+			Result:  csiaddonsv1alpha1.FencingOperationResultFailed,
+			Message: "failed to fence CIDR 10.0.0.0/24: secret=abc is invalid",
+		}}
+
+		Expect(networkFenceFailureMessage("fencing", nf)).To(Equal(
+			"fencing operation not successful: failed to fence CIDR 10.0.0.0/24: secret=<redacted> is invalid"))
+
+		nf.Status.Message = ""
+		Expect(networkFenceFailureMessage("unfencing", nf)).To(Equal("unfencing operation not successful"))
+	})
+})
+
+var _ = Describe("DRCluster fencing not configured", func() {
+	newInstance := func(clusterFence ramen.ClusterFenceState, phase ramen.DRClusterPhase) *drclusterInstance {
+		return &drclusterInstance{
+			log: logr.Discard(),
+			object: &ramen.DRCluster{
+				Spec:   ramen.DRClusterSpec{ClusterFence: clusterFence},
+				Status: ramen.DRClusterStatus{Phase: phase},
+			},
+		}
+	}
+
+	It("skips the fence and unfence operations of a cluster without CIDRs", func() {
+		for _, clusterFence := range []ramen.ClusterFenceState{
+			ramen.ClusterFenceStateFenced, ramen.ClusterFenceStateUnfenced,
+		} {
+			// the reconciler is not set, so any discovery of NetworkFenceClasses would fail the test
+			u := newInstance(clusterFence, ramen.Available)
+
+			Expect(u.clusterFenceHandle()).To(BeFalse())
+			Expect(u.object.Status.Phase).To(Equal(ramen.Available))
+
+			condition := util.FindCondition(u.object.Status.Conditions, ramen.DRClusterConditionTypeFenced)
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(DRClusterConditionReasonFencingNotConfigured))
+
+			condition = util.FindCondition(u.object.Status.Conditions, ramen.DRClusterConditionTypeClean)
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(DRClusterConditionReasonFencingNotConfigured))
+		}
+	})
+
+	It("does not skip the fencing of a cluster with CIDRs, or with NetworkFences to clean up", func() {
+		u := newInstance(ramen.ClusterFenceStateUnfenced, ramen.Available)
+		Expect(fencingNotConfigured(u.object)).To(BeTrue())
+
+		u.object.Spec.CIDRs = []string{"10.0.0.0/24"}
+		Expect(fencingNotConfigured(u.object)).To(BeFalse())
+
+		for _, phase := range []ramen.DRClusterPhase{ramen.Fencing, ramen.Fenced, ramen.Unfencing, ramen.Unfenced} {
+			Expect(fencingNotConfigured(newInstance(ramen.ClusterFenceStateUnfenced, phase).object)).To(BeFalse())
+		}
+
+		u = newInstance(ramen.ClusterFenceStateUnfenced, ramen.Unfenced)
+		setDRClusterCleanCondition(&u.object.Status.Conditions, 1, "Cluster Clean")
+		Expect(fencingNotConfigured(u.object)).To(BeTrue())
+	})
+})
+
+var _ = Describe("DRCluster fencing peer klusterlet agents", func() {
+	var u *drclusterInstance
+
+	managedCluster := func(name string, available metav1.ConditionStatus) *ocmv1.ManagedCluster {
+		return &ocmv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: ocmv1.ManagedClusterStatus{Conditions: []metav1.Condition{{
+				Type: ocmv1.ManagedClusterConditionAvailable, Status: available, Message: "lease not updated",
+			}}},
+		}
+	}
+
+	instance := func(north metav1.ConditionStatus) *drclusterInstance {
+		scheme := runtime.NewScheme()
+		Expect(ramen.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmv1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&ramen.DRPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "metro"},
+				Spec:       ramen.DRPolicySpec{DRClusters: []string{"east", "west", "north"}},
+			},
+			&ramen.DRPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "other"},
+				Spec:       ramen.DRPolicySpec{DRClusters: []string{"west", "south"}},
+			},
+			managedCluster("west", metav1.ConditionFalse),
+			managedCluster("north", north),
+			managedCluster("south", metav1.ConditionTrue),
+		).Build()
+
+		return &drclusterInstance{
+			ctx:        context.TODO(),
+			log:        logr.Discard(),
+			object:     &ramen.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "east"}},
+			reconciler: &DRClusterReconciler{APIReader: fakeClient},
+		}
+	}
+
+	agentUnavailableCondition := func() *metav1.Condition {
+		return util.FindCondition(u.object.Status.Conditions, ramen.DRClusterConditionTypeAgentUnavailable)
+	}
+
+	It("warns that the agents of the peer are unavailable, suggesting a cluster of the DRPolicy", func() {
+		u = instance(metav1.ConditionTrue)
+		Expect(u.fenceAlternateCandidates("west")).To(Equal([]string{"north"}))
+
+		u.updateAgentUnavailableCondition("west")
+
+		condition := agentUnavailableCondition()
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(ReasonAgentUnavailable))
+		Expect(condition.Message).To(And(
+			ContainSubstring("cluster west is not available"),
+			ContainSubstring("cluster north is a candidate alternate"),
+		))
+	})
+
+	It("reports that no cluster of the DRPolicy has available agents", func() {
+		u = instance(metav1.ConditionFalse)
+
+		u.updateAgentUnavailableCondition("west")
+		Expect(agentUnavailableCondition().Message).To(HaveSuffix("no alternate cluster with available agents"))
+	})
+
+	It("clears the warning once the agents of the peer are available", func() {
+		u = instance(metav1.ConditionTrue)
+
+		u.updateAgentUnavailableCondition("west")
+		u.updateAgentUnavailableCondition("north")
+
+		condition := agentUnavailableCondition()
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(ReasonAgentAvailable))
+	})
+})
+
+// drcConfigUnavailableViewGetter fails to read the DRClusterConfig of any cluster
+type drcConfigUnavailableViewGetter struct {
+	util.ManagedClusterViewGetter
+}
+
+func (drcConfigUnavailableViewGetter) GetDRClusterConfigFromManagedCluster(string, map[string]string,
+) (*ramen.DRClusterConfig, error) {
+	return nil, errors.New("DRClusterConfig view unavailable")
+}
+
+var _ = Describe("DRCluster unfence acknowledgments", func() {
+	var u *drclusterInstance
+
+	BeforeEach(func() {
+		u = &drclusterInstance{
+			log: logr.Discard(),
+			object: &ramen.DRCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "east"},
+				Status: ramen.DRClusterStatus{
+					FencedCIDRGroups: []ramen.CIDRGroup{{Name: "workers", CIDRs: []string{"10.0.0.0/24"}}},
+				},
+			},
+			reconciler: &DRClusterReconciler{MCVGetter: drcConfigUnavailableViewGetter{}},
+		}
+	})
+
+	It("are recorded once per NetworkFenceClass of the peer cluster", func() {
+		u.unfenceAcknowledgmentRecord("west", "rbd")
+		u.unfenceAcknowledgmentRecord("west", "rbd")
+		u.unfenceAcknowledgmentRecord("north", "cephfs")
+
+		Expect(u.object.Status.UnfenceAcknowledgments).To(HaveLen(2))
+		Expect(u.unfenceAcknowledgmentsVerify("west", []string{"rbd"})).To(Succeed())
+		Expect(u.unfenceAcknowledgmentsVerify("west", []string{"rbd", "cephfs"})).To(MatchError(
+			`unfence of NetworkFence of class "cephfs" on cluster west is not acknowledged`))
+	})
+
+	It("block the cleanup of the NetworkFences until every class is acknowledged", func() {
+		u.unfenceAcknowledgmentRecord("west", "rbd")
+
+		requeue, err := u.unfenceClean(ramen.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "west"}},
+			[]string{"rbd", "cephfs"})
+		Expect(requeue).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring(`"cephfs" on cluster west is not acknowledged`)))
+		Expect(util.FindCondition(u.object.Status.Conditions, ramen.DRClusterConditionTypeClean)).To(BeNil())
+		Expect(u.object.Status.FencedCIDRGroups).To(HaveLen(1))
+
+		u.unfenceAcknowledgmentRecord("west", "cephfs")
+
+		// the cleanup proceeds, and is retried as the NetworkFenceClasses of the clusters cannot be read
+		requeue, err = u.unfenceClean(ramen.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "west"}},
+			[]string{"rbd", "cephfs"})
+		Expect(requeue).To(BeTrue())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(util.FindCondition(u.object.Status.Conditions, ramen.DRClusterConditionTypeClean).Reason).
+			To(Equal(DRClusterConditionReasonCleaning))
+		Expect(u.object.Status.FencedCIDRGroups).To(HaveLen(1))
+	})
+})
@@ -4,6 +4,10 @@
 package controllers
 
 import (
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/go-logr/logr"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -15,13 +19,67 @@ import (
 	"github.com/ramendr/ramen/internal/controller/util"
 )
 
+const (
+	// MModeOwnersAnnotation is the annotation of a MaintenanceMode listing, comma separated, the DRPCs, as
+	// <namespace>/<name>, failing over to the cluster it was activated for
+	MModeOwnersAnnotation = "drcluster.ramendr.openshift.io/mmode-owners"
+
+	// defaultMModeLifetime is how long after a DRPC started its failover a MaintenanceMode is kept active for it if
+	// the ramen config sets no lifetime
+	defaultMModeLifetime = 24 * time.Hour
+)
+
+// mModeLifetime returns how long after a DRPC started its failover a MaintenanceMode is kept active for it
+func mModeLifetime(ramenConfig *ramen.RamenConfig) time.Duration {
+	if lifetime := ramenConfig.MaintenanceModes.Lifetime; lifetime != nil && lifetime.Duration > 0 {
+		return lifetime.Duration
+	}
+
+	return defaultMModeLifetime
+}
+
+// mModeOwner returns the name a DRPC is listed with in the MModeOwnersAnnotation of a MaintenanceMode
+func mModeOwner(drpc *ramen.DRPlacementControl) string {
+	return drpc.GetNamespace() + "/" + drpc.GetName()
+}
+
+// mModeOwners returns the DRPCs the MaintenanceMode was activated for, or nil if it was activated before they were
+// tracked
+func mModeOwners(mMode *ramen.MaintenanceMode) []string {
+	owners := mMode.GetAnnotations()[MModeOwnersAnnotation]
+	if owners == "" {
+		return nil
+	}
+
+	return strings.Split(owners, ",")
+}
+
+// mModeTraced returns whether mMode is annotated as activated for the DRPCs, and their failovers, of trace
+func mModeTraced(mMode *ramen.MaintenanceMode, trace *util.ActionTrace) bool {
+	return slices.Equal(mModeOwners(mMode), trace.DRPCs) &&
+		slices.Equal(util.ActionTraceFromAnnotations(mMode).CorrelationIDs, trace.CorrelationIDs)
+}
+
+// mModeOwnerLifetimeRemaining returns how much longer a MaintenanceMode is kept active for the failover of drpc,
+// which is not positive once the failover outlived lifetime. The lifetime of a failover that has not started yet is
+// not running.
+func mModeOwnerLifetimeRemaining(drpc *ramen.DRPlacementControl, lifetime time.Duration, now time.Time) time.Duration {
+	if drpc.Status.ActionStartTime == nil {
+		return lifetime
+	}
+
+	return drpc.Status.ActionStartTime.Add(lifetime).Sub(now)
+}
+
 // clusterMModeHandler handles all related maintenance modes that the DRCluster needs
 // to manage
 // NOTE: Currently this is limited in implementation to just handling the Failover mode
 // during regional DR failovers, and parts of the implementation are not generic to handle
 // an arbitrary future mode
-func (u *drclusterInstance) clusterMModeHandler() error {
-	allActivations, err := u.mModeActivationsRequired()
+func (u *drclusterInstance) clusterMModeHandler(ramenConfig *ramen.RamenConfig) error {
+	lifetime := mModeLifetime(ramenConfig)
+
+	allActivations, traces, err := u.mModeActivationsRequired(lifetime)
 	if err != nil {
 		u.requeue = true
 
@@ -29,10 +87,10 @@ func (u *drclusterInstance) clusterMModeHandler() error {
 	}
 
 	if activated := checkFailoverMaintenanceActivations(*u.object, allActivations, u.log); !activated {
-		u.activateRegionalFailoverPrequisites(allActivations)
+		u.activateRegionalFailoverPrequisites(allActivations, traces)
 	}
 
-	survivors, err := u.pruneMModesActivations(allActivations)
+	survivors, err := u.pruneMModesActivations(allActivations, traces, lifetime)
 	if err != nil {
 		u.log.Error(err, "Error pruning maintenance mode manifests")
 
@@ -47,19 +105,36 @@ func (u *drclusterInstance) clusterMModeHandler() error {
 }
 
 // mModeActivationsRequired determines all required maintenance modes for the current cluster based
-// on the DRPCs that are failing over to this cluster and their required maintenance modes. It returns
-// a map of StorageIdentifiers, with the key being the <ProvisionerName>+<ReplicationID>
-func (u *drclusterInstance) mModeActivationsRequired() (map[string]ramen.StorageIdentifiers, error) {
+// on the DRPCs that are failing over to this cluster, within lifetime of the start of their failover, and their
+// required maintenance modes. It returns a map of StorageIdentifiers, with the key being the
+// <ProvisionerName>+<ReplicationID>, and a map of the trace of the failovers of the DRPCs requiring each, with the
+// same keys.
+func (u *drclusterInstance) mModeActivationsRequired(
+	lifetime time.Duration,
+) (map[string]ramen.StorageIdentifiers, map[string]*util.ActionTrace, error) {
 	allActivations := map[string]ramen.StorageIdentifiers{}
+	traces := map[string]*util.ActionTrace{}
 
 	drpcCollections, err := DRPCsFailingOverToCluster(u.client, u.log, u.object.GetName())
 	if err != nil {
 		u.requeue = true
 
-		return nil, err
+		return nil, nil, err
 	}
 
+	now := time.Now()
+
 	for _, drpcCollection := range drpcCollections {
+		remaining := mModeOwnerLifetimeRemaining(drpcCollection.drpc, lifetime, now)
+		if remaining <= 0 {
+			u.log.Info("Failover of DRPC outlived the maintenance mode lifetime, not activating maintenance modes for it",
+				"DRPCName", drpcCollection.drpc.GetName(),
+				"DRPCNamespace", drpcCollection.drpc.GetNamespace(),
+				"lifetime", lifetime)
+
+			continue
+		}
+
 		vrgs, err := u.getVRGs(drpcCollection)
 		if err != nil {
 			u.log.Info("Failed to get VRGs for DRPC that is failing over",
@@ -73,12 +148,12 @@ func (u *drclusterInstance) mModeActivationsRequired() (map[string]ramen.Storage
 
 		placementObj, err := getPlacementOrPlacementRule(u.ctx, u.client, drpcCollection.drpc, u.log)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		vrgNamespace, err := selectVRGNamespace(u.client, u.log, drpcCollection.drpc, placementObj)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		required, activationsRequired := requiresRegionalFailoverPrerequisites(
@@ -95,7 +170,16 @@ func (u *drclusterInstance) mModeActivationsRequired() (map[string]ramen.Storage
 			continue
 		}
 
+		// reconcile once the failover outlives the lifetime, to deactivate its maintenance modes
+		u.requeueAfter = minRequeueAfter(u.requeueAfter, remaining)
+
 		for key, storageIdentifiers := range activationsRequired {
+			if traces[key] == nil {
+				traces[key] = &util.ActionTrace{Action: ramen.ActionFailover}
+			}
+
+			traces[key].Add(drpcCollection.drpc)
+
 			if _, ok := allActivations[key]; ok {
 				continue
 			}
@@ -106,7 +190,7 @@ func (u *drclusterInstance) mModeActivationsRequired() (map[string]ramen.Storage
 
 	u.log.Info("Activations required", "count", len(allActivations))
 
-	return allActivations, nil
+	return allActivations, traces, nil
 }
 
 // getVRGs is a helper function to get the VRGs for the passed in DRPC and DRPolicy association
@@ -131,7 +215,8 @@ func (u *drclusterInstance) getVRGs(drpcCollection DRPCAndPolicy) (map[string]*r
 		drpcCollection.drpc,
 		drClusters,
 		vrgNamespace,
-		u.log)
+		u.log,
+		nil)
 	if err != nil {
 		return nil, err
 	}
@@ -145,16 +230,17 @@ func (u *drclusterInstance) getVRGs(drpcCollection DRPCAndPolicy) (map[string]*r
 }
 
 // activateRegionalFailoverPrequisites activates all regional failover maintenance modes as desired
-// by the passed in required activations
+// by the passed in required activations, for the failovers of the DRPCs requiring each
 func (u *drclusterInstance) activateRegionalFailoverPrequisites(
 	activationsRequired map[string]ramen.StorageIdentifiers,
+	traces map[string]*util.ActionTrace,
 ) {
-	for _, identifier := range activationsRequired {
+	for key, identifier := range activationsRequired {
 		u.log.Info("Activating maintenance mode",
 			"provisioner", identifier.StorageProvisioner,
 			"ReplciationID", identifier.ReplicationID)
 
-		if err := u.activateRegionalFailoverPrequisite(identifier); err != nil {
+		if err := u.activateRegionalFailoverPrequisite(identifier, traces[key]); err != nil {
 			u.log.Error(err, "Error activating maintenance mode",
 				"provisioner", identifier.StorageProvisioner,
 				"ReplciationID", identifier.ReplicationID)
@@ -167,8 +253,11 @@ func (u *drclusterInstance) activateRegionalFailoverPrequisites(
 }
 
 // activateRegionalFailoverPrequisite activates a regional failover maintenance mode as desired
-// for the passed in storage identifier
-func (u *drclusterInstance) activateRegionalFailoverPrequisite(identifier ramen.StorageIdentifiers) error {
+// for the passed in storage identifier, annotated with the DRPCs, and the trace of the failovers, it is activated for
+func (u *drclusterInstance) activateRegionalFailoverPrequisite(
+	identifier ramen.StorageIdentifiers,
+	trace *util.ActionTrace,
+) error {
 	mMode := ramen.MaintenanceMode{
 		TypeMeta:   metav1.TypeMeta{Kind: "MaintenanceMode", APIVersion: "ramendr.openshift.io/v1alpha1"},
 		ObjectMeta: metav1.ObjectMeta{Name: identifier.ReplicationID.ID},
@@ -180,6 +269,8 @@ func (u *drclusterInstance) activateRegionalFailoverPrequisite(identifier ramen.
 	}
 
 	util.AddLabel(&mMode, util.CreatedByRamenLabel, "true")
+	util.AddAnnotation(&mMode, MModeOwnersAnnotation, strings.Join(trace.DRPCs, ","))
+	util.ActionTraceAnnotate(&mMode, *trace)
 
 	annotations := make(map[string]string)
 	annotations[DRClusterNameAnnotation] = u.object.GetName()
@@ -197,8 +288,11 @@ func (u *drclusterInstance) activateRegionalFailoverPrequisite(identifier ramen.
 // pruneMModesActivations prunes the current active maintenance modes, and only retains
 // those that are currently required. It returns a map of maintenance mode manifest work that
 // are still required and not pruned, the keys being the targetID for the maintenance mode.
+// Those retained are updated with the DRPCs that currently require them, as their owners.
 func (u *drclusterInstance) pruneMModesActivations(
 	activationsRequired map[string]ramen.StorageIdentifiers,
+	traces map[string]*util.ActionTrace,
+	lifetime time.Duration,
 ) (map[string]*ocmworkv1.ManifestWork, error) {
 	mModeMWs, err := u.mwUtil.ListMModeManifests(u.object.GetName())
 	if err != nil {
@@ -221,11 +315,22 @@ func (u *drclusterInstance) pruneMModesActivations(
 
 		// Check if maintenance mode is still required, if not expire it
 		mModeKey := mModeRequest.Spec.StorageProvisioner + mModeRequest.Spec.TargetID
+		if identifier, ok := activationsRequired[mModeKey]; ok && !mModeTraced(mModeRequest, traces[mModeKey]) {
+			u.log.Info("Updating maintenance mode owners", "name", mModeMWs.Items[idx].GetName(),
+				"owners", traces[mModeKey].DRPCs)
+
+			if err := u.activateRegionalFailoverPrequisite(identifier, traces[mModeKey]); err != nil {
+				u.log.Error(err, "Error updating maintenance mode owners", "name", mModeMWs.Items[idx].GetName())
+
+				u.requeue = true
+			}
+		}
+
 		if _, ok := activationsRequired[mModeKey]; !ok {
 			// Before pruning verify there is no failover DRPC that still depends on this specific MaintenanceMode
 			// on this cluster. This ensures that all VRGs using this storage backend have fully transitioned to
 			// Primary before MMode is removed.
-			if u.mmodeStillNeededByFailoverDRPC(mModeRequest.Spec.StorageProvisioner, mModeRequest.Spec.TargetID) {
+			if u.mmodeStillNeededByFailoverDRPC(mModeRequest, lifetime) {
 				u.log.Info(
 					"Keeping maintenance mode activation because at least one failover DRPC still needs this MaintenanceMode",
 					"name", mModeMWs.Items[idx].GetName(),
@@ -428,7 +533,12 @@ func drClusterMModeCleanup(
 // We only keep a specific MaintenanceMode active if there's a failover DRPC using
 // that exact storage backend that is not fully available.
 // This prevents blocking unrelated storage backends.
-func (u *drclusterInstance) mmodeStillNeededByFailoverDRPC(storageProvisioner, targetID string) bool {
+// Only the DRPCs the MaintenanceMode was activated for are considered, if they are tracked, while their failover
+// is within lifetime of its start, so that a failover that was aborted, or that is stuck, does not keep it active.
+func (u *drclusterInstance) mmodeStillNeededByFailoverDRPC(mMode *ramen.MaintenanceMode, lifetime time.Duration) bool {
+	storageProvisioner, targetID := mMode.Spec.StorageProvisioner, mMode.Spec.TargetID
+	owners := mModeOwners(mMode)
+
 	drpcCollections, err := DRPCsFailingOverToCluster(u.client, u.log, u.object.GetName())
 	if err != nil {
 		u.log.Error(err, "Failed to list DRPlacementControls when deciding MMode pruning")
@@ -437,9 +547,19 @@ func (u *drclusterInstance) mmodeStillNeededByFailoverDRPC(storageProvisioner, t
 		return true
 	}
 
+	now := time.Now()
+
 	for _, drpcCollection := range drpcCollections {
 		drpc := drpcCollection.drpc
 
+		if owners != nil && !slices.Contains(owners, mModeOwner(drpc)) {
+			continue
+		}
+
+		if mModeOwnerLifetimeRemaining(drpc, lifetime, now) <= 0 {
+			continue
+		}
+
 		availableCond := meta.FindStatusCondition(drpc.Status.Conditions, ramen.ConditionAvailable)
 		if availableCond == nil ||
 			availableCond.Status != metav1.ConditionTrue ||
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRCluster maintenance mode lifetime", func() {
+	It("defaults the lifetime unless the ramen config sets one", func() {
+		ramenConfig := &ramen.RamenConfig{}
+		Expect(mModeLifetime(ramenConfig)).To(Equal(defaultMModeLifetime))
+
+		ramenConfig.MaintenanceModes.Lifetime = &metav1.Duration{Duration: time.Hour}
+		Expect(mModeLifetime(ramenConfig)).To(Equal(time.Hour))
+	})
+
+	It("runs the lifetime from the start of the failover of a DRPC", func() {
+		now := time.Now()
+		drpc := &ramen.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"}}
+		Expect(mModeOwnerLifetimeRemaining(drpc, time.Hour, now)).To(Equal(time.Hour))
+
+		drpc.Status.ActionStartTime = &metav1.Time{Time: now.Add(-45 * time.Minute)}
+		Expect(mModeOwnerLifetimeRemaining(drpc, time.Hour, now)).To(Equal(15 * time.Minute))
+
+		drpc.Status.ActionStartTime = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+		Expect(mModeOwnerLifetimeRemaining(drpc, time.Hour, now)).To(BeNumerically("<=", 0))
+	})
+
+	It("reads the DRPCs a maintenance mode was activated for", func() {
+		mMode := &ramen.MaintenanceMode{}
+		Expect(mModeOwners(mMode)).To(BeNil())
+
+		drpc := &ramen.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"}}
+		mMode.SetAnnotations(map[string]string{MModeOwnersAnnotation: mModeOwner(drpc) + ",other/app"})
+		Expect(mModeOwners(mMode)).To(Equal([]string{"ns/app", "other/app"}))
+	})
+})
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+// s3ProfileReferenceValidate reports, in the DanglingS3ProfileReference condition, whether the S3 profile of the
+// DRCluster is in ramenConfig, and returns an error if it is not. As the DRClusters are reconciled on a change of the
+// ramen config, a profile removed or renamed while DRClusters reference it is reported on each of them, listing all of
+// them, instead of as a failure to connect to the S3 store.
+func (u *drclusterInstance) s3ProfileReferenceValidate(ramenConfig *ramen.RamenConfig) error {
+	profileName := u.object.Spec.S3ProfileName
+
+	if profileName == NoS3StoreAvailable || RamenConfigS3StoreProfilePointerGet(ramenConfig, profileName) != nil {
+		if meta.IsStatusConditionTrue(u.object.Status.Conditions, ramen.DRClusterConditionTypeDanglingS3ProfileReference) {
+			util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+				Type:               ramen.DRClusterConditionTypeDanglingS3ProfileReference,
+				Reason:             ReasonS3ProfileFound,
+				ObservedGeneration: u.object.Generation,
+				Status:             metav1.ConditionFalse,
+				Message:            fmt.Sprintf("S3 profile %s is in the RamenConfig", profileName),
+			})
+		}
+
+		return nil
+	}
+
+	drClusters := &ramen.DRClusterList{}
+	if err := u.client.List(u.ctx, drClusters); err != nil {
+		return fmt.Errorf("failed to list DRClusters: %w", err)
+	}
+
+	util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+		Type:               ramen.DRClusterConditionTypeDanglingS3ProfileReference,
+		Reason:             ReasonS3ProfileNotFound,
+		ObservedGeneration: u.object.Generation,
+		Status:             metav1.ConditionTrue,
+		Message: fmt.Sprintf("S3 profile %s is not in the RamenConfig; DRClusters referencing S3 profiles not in "+
+			"the RamenConfig: %s", profileName,
+			strings.Join(danglingS3ProfileReferences(drClusters.Items, ramenConfig), ", ")),
+	})
+
+	return fmt.Errorf("s3 profile %s not found in RamenConfig", profileName)
+}
+
+// danglingS3ProfileReferences returns the DRClusters whose S3 profile is not in ramenConfig, as "name (profile)",
+// sorted by name
+func danglingS3ProfileReferences(drClusters []ramen.DRCluster, ramenConfig *ramen.RamenConfig) []string {
+	dangling := []string{}
+
+	for i := range drClusters {
+		profileName := drClusters[i].Spec.S3ProfileName
+		if profileName == NoS3StoreAvailable || RamenConfigS3StoreProfilePointerGet(ramenConfig, profileName) != nil {
+			continue
+		}
+
+		dangling = append(dangling, fmt.Sprintf("%s (%s)", drClusters[i].Name, profileName))
+	}
+
+	slices.Sort(dangling)
+
+	return dangling
+}
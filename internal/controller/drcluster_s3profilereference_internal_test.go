@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("Dangling S3 profile references", func() {
+	drCluster := func(name, profileName string) ramen.DRCluster {
+		return ramen.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       ramen.DRClusterSpec{S3ProfileName: profileName},
+		}
+	}
+
+	It("lists the DRClusters whose S3 profile is not in the ramen config", func() {
+		ramenConfig := &ramen.RamenConfig{S3StoreProfiles: []ramen.S3StoreProfile{{S3ProfileName: "s3-east"}}}
+
+		Expect(danglingS3ProfileReferences([]ramen.DRCluster{
+			drCluster("west", "s3-west"),
+			drCluster("east", "s3-east"),
+			drCluster("north", NoS3StoreAvailable),
+			drCluster("central", "s3-renamed"),
+		}, ramenConfig)).To(Equal([]string{"central (s3-renamed)", "west (s3-west)"}))
+	})
+
+	It("lists none once the S3 profiles are in the ramen config", func() {
+		ramenConfig := &ramen.RamenConfig{S3StoreProfiles: []ramen.S3StoreProfile{{S3ProfileName: "s3-west"}}}
+
+		Expect(danglingS3ProfileReferences([]ramen.DRCluster{drCluster("west", "s3-west")}, ramenConfig)).To(BeEmpty())
+	})
+})
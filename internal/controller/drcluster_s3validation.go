@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// s3ProfileRevalidationInterval is how long the result of an asynchronous validation of the S3 profile of a
+	// DRCluster is reported before the S3 profile is validated again
+	s3ProfileRevalidationInterval = time.Minute
+
+	// s3ProfileValidationPendingRequeueDelay is the delay of the reconcile of a DRCluster whose S3 profile is being
+	// validated in the background, to report the result of the validation
+	s3ProfileValidationPendingRequeueDelay = 5 * time.Second
+
+	s3ProfileValidatedReason         = "Validated"
+	s3ProfileValidationPendingReason = "ValidationPending"
+)
+
+// s3ProfileValidation is the result of the validation of an S3 profile, with the reason and error of the operation
+// that failed, if any
+type s3ProfileValidation struct {
+	profileName string
+	reason      string
+	err         error
+	time        time.Time
+	pending     bool
+}
+
+// s3ProfileValidator validates the S3 profiles of the DRClusters in the background, so that the reconcile of a
+// DRCluster does not wait for, or fail on, an S3 store that is unavailable. A DRCluster has at most one validation
+// running at a time.
+type s3ProfileValidator struct {
+	mutex       sync.Mutex
+	validations map[string]*s3ProfileValidation
+}
+
+func newS3ProfileValidator() *s3ProfileValidator {
+	return &s3ProfileValidator{validations: map[string]*s3ProfileValidation{}}
+}
+
+// validate returns the result of the last validation of the S3 profile profileName of the DRCluster named
+// drClusterName, and whether there is one. It starts validating the S3 profile again with validate in the background,
+// unless a validation is running, if there is no result for the S3 profile or the result is older than
+// s3ProfileRevalidationInterval.
+func (v *s3ProfileValidator) validate(drClusterName, profileName string, validate func() (string, error),
+) (s3ProfileValidation, bool) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	validation, ok := v.validations[drClusterName]
+	if ok && validation.pending {
+		return *validation, false
+	}
+
+	current := ok && validation.profileName == profileName
+	if current && time.Since(validation.time) < s3ProfileRevalidationInterval {
+		return *validation, true
+	}
+
+	last := s3ProfileValidation{}
+	if current {
+		last = *validation
+	}
+
+	v.validations[drClusterName] = &s3ProfileValidation{
+		profileName: profileName, reason: last.reason, err: last.err, time: last.time, pending: true,
+	}
+
+	go func() {
+		reason, err := validate()
+
+		v.mutex.Lock()
+		defer v.mutex.Unlock()
+
+		v.validations[drClusterName] = &s3ProfileValidation{
+			profileName: profileName, reason: reason, err: err, time: time.Now(),
+		}
+	}()
+
+	return last, current
+}
+
+// forget drops the result of the validation of the S3 profile of the DRCluster named drClusterName
+func (v *s3ProfileValidator) forget(drClusterName string) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	delete(v.validations, drClusterName)
+}
+
+// s3ProfileValidateAsync validates the S3 profile of the DRCluster in the background, and sets its S3ProfileValidated
+// condition to the result of the last validation. The reconcile is requeued until a validation completes.
+func (u *drclusterInstance) s3ProfileValidateAsync(validate func(ctx context.Context) (string, error)) {
+	ctx := context.WithoutCancel(u.ctx)
+
+	validation, validated := u.reconciler.s3ProfileValidator.validate(u.object.Name, u.object.Spec.S3ProfileName,
+		func() (string, error) { return validate(ctx) })
+
+	if !validated {
+		u.requeueAfter = minRequeueAfter(u.requeueAfter, s3ProfileValidationPendingRequeueDelay)
+
+		util.SetStatusConditionIfNotFound(&u.object.Status.Conditions, metav1.Condition{
+			Type:               ramen.DRClusterConditionTypeS3ProfileValidated,
+			Reason:             s3ProfileValidationPendingReason,
+			ObservedGeneration: u.object.Generation,
+			Status:             metav1.ConditionUnknown,
+			Message:            "S3 profile " + u.object.Spec.S3ProfileName + " is being validated",
+		})
+
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               ramen.DRClusterConditionTypeS3ProfileValidated,
+		Reason:             s3ProfileValidatedReason,
+		ObservedGeneration: u.object.Generation,
+		Status:             metav1.ConditionTrue,
+		Message:            "S3 profile " + u.object.Spec.S3ProfileName + " validated",
+	}
+
+	if validation.err != nil {
+		condition.Reason = validation.reason
+		condition.Status = metav1.ConditionFalse
+		condition.Message = validation.err.Error()
+	}
+
+	util.SetStatusCondition(&u.object.Status.Conditions, condition)
+}
+
+// minRequeueAfter returns the shorter of the non-zero delays a and b
+func minRequeueAfter(a, b time.Duration) time.Duration {
+	if a == 0 || b < a {
+		return b
+	}
+
+	return a
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Asynchronous S3 profile validation", func() {
+	var (
+		validator   *s3ProfileValidator
+		validations chan struct{}
+	)
+
+	validate := func(reason string, err error) func() (string, error) {
+		return func() (string, error) {
+			<-validations
+
+			return reason, err
+		}
+	}
+
+	BeforeEach(func() {
+		validator = newS3ProfileValidator()
+		validations = make(chan struct{})
+	})
+
+	It("reports the result of the validation once it completes in the background", func() {
+		_, validated := validator.validate("cluster1", "profile1", validate("s3ListFailed", errors.New("unavailable")))
+		Expect(validated).To(BeFalse())
+
+		// No other validation starts while one is running
+		_, validated = validator.validate("cluster1", "profile1", validate("", nil))
+		Expect(validated).To(BeFalse())
+
+		validations <- struct{}{}
+
+		Eventually(func() bool {
+			_, validated := validator.validate("cluster1", "profile1", validate("", nil))
+
+			return validated
+		}).Should(BeTrue())
+
+		validation, _ := validator.validate("cluster1", "profile1", validate("", nil))
+		Expect(validation.reason).To(Equal("s3ListFailed"))
+		Expect(validation.err).To(MatchError("unavailable"))
+	})
+
+	It("validates again a changed or expired S3 profile", func() {
+		validator.validations["cluster1"] = &s3ProfileValidation{profileName: "profile1", time: time.Now()}
+
+		_, validated := validator.validate("cluster1", "profile2", validate("", nil))
+		Expect(validated).To(BeFalse())
+
+		validations <- struct{}{}
+
+		Eventually(func() bool {
+			_, validated := validator.validate("cluster1", "profile2", validate("", nil))
+
+			return validated
+		}).Should(BeTrue())
+
+		validator.validations["cluster1"].time = time.Now().Add(-2 * s3ProfileRevalidationInterval)
+
+		// The last result is reported while the expired one is validated again
+		validation, validated := validator.validate("cluster1", "profile2", validate("", nil))
+		Expect(validated).To(BeTrue())
+		Expect(validation.err).ToNot(HaveOccurred())
+		Expect(validator.validations["cluster1"].pending).To(BeTrue())
+
+		validations <- struct{}{}
+	})
+})
@@ -17,6 +17,7 @@ import (
 	groupsnapv1beta1 "github.com/red-hat-storage/external-snapshotter/client/v8/apis/volumegroupsnapshot/v1beta1"
 	"golang.org/x/time/rate"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	ramen "github.com/ramendr/ramen/api/v1alpha1"
 	"github.com/ramendr/ramen/internal/controller/util"
@@ -51,6 +53,12 @@ const (
 
 	DRClusterConfigS3Reachable   = "Reachable"
 	DRClusterConfigS3Unreachable = "Unreachable"
+
+	DRClusterConfigCSIAddonsInstalled    = "CSIAddonsInstalled"
+	DRClusterConfigCSIAddonsNotInstalled = "CSIAddonsNotInstalled"
+
+	DRClusterConfigPeerConnected    = "Connected"
+	DRClusterConfigPeerDisconnected = "Disconnected"
 )
 
 // DRClusterConfigReconciler reconciles a DRClusterConfig object
@@ -59,6 +67,13 @@ type DRClusterConfigReconciler struct {
 	Scheme      *runtime.Scheme
 	Log         logr.Logger
 	RateLimiter *workqueue.TypedRateLimiter[reconcile.Request]
+
+	// CSIAddonsUnavailable is set if the csi-addons fencing APIs are not installed on the cluster at startup. The
+	// fencing classes and clients are then neither watched nor listed, and fencing is reported as unavailable.
+	CSIAddonsUnavailable bool
+
+	// OperandErrors collects the errors the reconcilers of the operator report, for the DRClusterConfig to publish
+	OperandErrors *util.OperandErrorReporter
 }
 
 //nolint:lll
@@ -71,6 +86,7 @@ type DRClusterConfigReconciler struct {
 // +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=clusterclaims,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=csiaddons.openshift.io,resources=networkfenceclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=csiaddons.openshift.io,resources=csiaddonsnodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ceph.rook.io,resources=cephblockpools,verbs=get;list;watch
 
 func (r *DRClusterConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("drcc", req.NamespacedName.Name, "rid", util.GetRID())
@@ -114,6 +130,7 @@ func (r *DRClusterConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	if util.ResourceIsDeleted(drCConfig) {
 		res, err = r.processDeletion(ctx, log, drCConfig)
 	} else {
+		drCConfig.Status.OperandErrors = r.OperandErrors.Errors(time.Now())
 		res, err = r.processCreateOrUpdate(ctx, log, drCConfig)
 
 		// Update status
@@ -169,6 +186,18 @@ func setDRClusterConfigConfigurationProcessedCondition(conditions *[]metav1.Cond
 	})
 }
 
+func setDRClusterConfigFencingAvailableCondition(conditions *[]metav1.Condition, observedGeneration int64,
+	message string, conditionStatus metav1.ConditionStatus, reason string,
+) {
+	util.SetStatusCondition(conditions, metav1.Condition{
+		Type:               ramen.DRClusterConfigFencingAvailable,
+		Reason:             reason,
+		ObservedGeneration: observedGeneration,
+		Status:             conditionStatus,
+		Message:            message,
+	})
+}
+
 func (r *DRClusterConfigReconciler) GetDRClusterConfig(ctx context.Context) (*ramen.DRClusterConfig, error) {
 	drcConfigs := &ramen.DRClusterConfigList{}
 	if err := r.Client.List(ctx, drcConfigs); err != nil {
@@ -276,7 +305,7 @@ func (r *DRClusterConfigReconciler) processCreateOrUpdate(
 	setDRClusterConfigConfigurationProcessedCondition(&drCConfig.Status.Conditions, drCConfig.Generation,
 		"Configuration processed and validated", metav1.ConditionTrue, DRClusterConfigConditionConfigurationProcessed)
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: drClusterConfigRequeueAfter(drCConfig)}, nil
 }
 
 // UpdateStatus updates DRClusterConfig status with a list of storage related classes that are marked for DR
@@ -325,6 +354,47 @@ func (r *DRClusterConfigReconciler) UpdateStatus(
 	drCConfig.Status.VolumeGroupSnapshotClasses = vgsClasses
 	slices.Sort(drCConfig.Status.VolumeGroupSnapshotClasses)
 
+	drCConfig.Status.VRGSchemaVersion = ramen.VRGSchemaVersion
+
+	if err := r.updatePeerConnectedStatus(ctx, drCConfig); err != nil {
+		return err
+	}
+
+	return r.updateFencingStatus(ctx, drCConfig)
+}
+
+// updateFencingStatus updates DRClusterConfig status with the fencing classes and storage access details, and
+// whether fencing is available. Fencing is reported as unavailable, instead of failing the reconcile, if the
+// csi-addons fencing APIs are not installed on the cluster, or were removed since startup.
+func (r *DRClusterConfigReconciler) updateFencingStatus(ctx context.Context, drCConfig *ramen.DRClusterConfig) error {
+	drCConfig.Status.NetworkFenceClasses = nil
+	drCConfig.Status.StorageAccessDetails = nil
+
+	if !r.CSIAddonsUnavailable {
+		err := r.listFencingStatus(ctx, drCConfig)
+		if err == nil {
+			setDRClusterConfigFencingAvailableCondition(&drCConfig.Status.Conditions, drCConfig.Generation,
+				"csi-addons NetworkFence APIs are installed on the cluster", metav1.ConditionTrue,
+				DRClusterConfigCSIAddonsInstalled)
+
+			return nil
+		}
+
+		if !meta.IsNoMatchError(err) {
+			return err
+		}
+
+		drCConfig.Status.NetworkFenceClasses = nil
+	}
+
+	setDRClusterConfigFencingAvailableCondition(&drCConfig.Status.Conditions, drCConfig.Generation,
+		"csi-addons NetworkFence APIs are not installed on the cluster", metav1.ConditionFalse,
+		DRClusterConfigCSIAddonsNotInstalled)
+
+	return nil
+}
+
+func (r *DRClusterConfigReconciler) listFencingStatus(ctx context.Context, drCConfig *ramen.DRClusterConfig) error {
 	nfClases, err := r.listDRSupportedNFCs(ctx)
 	if err != nil {
 		return err
@@ -569,16 +639,26 @@ func (r *DRClusterConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		rateLimiter = *r.RateLimiter
 	}
 
-	controller := ctrl.NewControllerManagedBy(mgr)
-
-	return controller.WithOptions(ctrlcontroller.Options{
+	controller := ctrl.NewControllerManagedBy(mgr).WithOptions(ctrlcontroller.Options{
 		RateLimiter: rateLimiter,
 	}).For(&ramen.DRClusterConfig{}).
 		Watches(&storagev1.StorageClass{}, drccMapFn, drccPredFn).
 		Watches(&snapv1.VolumeSnapshotClass{}, drccMapFn, drccPredFn).
 		Watches(&volrep.VolumeReplicationClass{}, drccMapFn, drccPredFn).
 		Watches(&volrep.VolumeGroupReplicationClass{}, drccMapFn, drccPredFn).
-		Watches(&groupsnapv1beta1.VolumeGroupSnapshotClass{}, drccMapFn, drccPredFn).
+		Watches(&groupsnapv1beta1.VolumeGroupSnapshotClass{}, drccMapFn, drccPredFn)
+
+	if r.OperandErrors != nil {
+		controller = controller.WatchesRawSource(source.Channel(r.OperandErrors.Events(), drccMapFn))
+	}
+
+	if r.CSIAddonsUnavailable {
+		r.Log.Info("csi-addons NetworkFence APIs are not installed, fencing classes are not watched")
+
+		return controller.Complete(r)
+	}
+
+	return controller.
 		Watches(&csiaddonsv1alpha1.NetworkFenceClass{}, drccMapFn, drccPredFn).
 		Watches(&csiaddonsv1alpha1.CSIAddonsNode{}, drccMapFn, drccPredFn).
 		Complete(r)
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// drClusterConfigPeerConnectedInterval is the interval between checks of the mirroring health of the cluster, as
+	// the Ceph block pools reporting it are not watched
+	drClusterConfigPeerConnectedInterval = 5 * time.Minute
+
+	// drClusterConfigOperandErrorsInterval is the interval between refreshes of the operand errors the
+	// DRClusterConfig reports, while it reports any, for those not reported for a while to be dropped
+	drClusterConfigOperandErrorsInterval = time.Hour
+
+	// cephMirroringHealthOK is the health the mirroring daemons of a Ceph block pool report when they are connected
+	// to their peers
+	cephMirroringHealthOK = "OK"
+)
+
+var cephBlockPoolListGVK = schema.GroupVersionKind{
+	Group:   "ceph.rook.io",
+	Version: "v1",
+	Kind:    "CephBlockPoolList",
+}
+
+// updatePeerConnectedStatus sets the PeerConnected condition of the DRClusterConfig from the mirroring health of the
+// mirrored Ceph block pools of the cluster. The condition is removed if Ceph block pools are not installed on the
+// cluster, or none of them is mirrored.
+func (r *DRClusterConfigReconciler) updatePeerConnectedStatus(ctx context.Context,
+	drCConfig *ramen.DRClusterConfig,
+) error {
+	pools := &unstructured.UnstructuredList{}
+	pools.SetGroupVersionKind(cephBlockPoolListGVK)
+
+	if err := r.Client.List(ctx, pools); err != nil {
+		if !meta.IsNoMatchError(err) {
+			return fmt.Errorf("failed to list CephBlockPools, %w", err)
+		}
+
+		meta.RemoveStatusCondition(&drCConfig.Status.Conditions, ramen.DRClusterConfigPeerConnected)
+
+		return nil
+	}
+
+	mirrored, disconnected := cephBlockPoolsMirroringHealth(pools.Items)
+	if mirrored == 0 {
+		meta.RemoveStatusCondition(&drCConfig.Status.Conditions, ramen.DRClusterConfigPeerConnected)
+
+		return nil
+	}
+
+	if len(disconnected) > 0 {
+		setDRClusterConfigPeerConnectedCondition(&drCConfig.Status.Conditions, drCConfig.Generation,
+			"Mirroring is not healthy for CephBlockPools: "+strings.Join(disconnected, ", "), metav1.ConditionFalse,
+			DRClusterConfigPeerDisconnected)
+
+		return nil
+	}
+
+	setDRClusterConfigPeerConnectedCondition(&drCConfig.Status.Conditions, drCConfig.Generation,
+		fmt.Sprintf("Mirroring is healthy for %d CephBlockPools", mirrored), metav1.ConditionTrue,
+		DRClusterConfigPeerConnected)
+
+	return nil
+}
+
+// cephBlockPoolsMirroringHealth returns the number of pools that are mirrored, and the names of those whose mirroring
+// daemons, or mirroring as a whole, are not reported healthy. A mirrored pool that reports no mirroring status yet is
+// counted as not healthy.
+func cephBlockPoolsMirroringHealth(pools []unstructured.Unstructured) (int, []string) {
+	mirrored := 0
+	disconnected := []string{}
+
+	for i := range pools {
+		pool := &pools[i]
+
+		enabled, _, _ := unstructured.NestedBool(pool.Object, "spec", "mirroring", "enabled")
+		if !enabled {
+			continue
+		}
+
+		mirrored++
+
+		daemonHealth, _, _ := unstructured.NestedString(pool.Object, "status", "mirroringStatus", "summary",
+			"daemon_health")
+		health, _, _ := unstructured.NestedString(pool.Object, "status", "mirroringStatus", "summary", "health")
+
+		if daemonHealth != cephMirroringHealthOK || health != cephMirroringHealthOK {
+			disconnected = append(disconnected, fmt.Sprintf("%s/%s (daemon health %q, health %q)",
+				pool.GetNamespace(), pool.GetName(), daemonHealth, health))
+		}
+	}
+
+	slices.Sort(disconnected)
+
+	return mirrored, disconnected
+}
+
+func setDRClusterConfigPeerConnectedCondition(conditions *[]metav1.Condition, observedGeneration int64,
+	message string, conditionStatus metav1.ConditionStatus, reason string,
+) {
+	util.SetStatusCondition(conditions, metav1.Condition{
+		Type:               ramen.DRClusterConfigPeerConnected,
+		Reason:             reason,
+		ObservedGeneration: observedGeneration,
+		Status:             conditionStatus,
+		Message:            message,
+	})
+}
+
+// drClusterConfigRequeueAfter returns the interval after which the DRClusterConfig is reconciled again to refresh the
+// status that is not watched, or zero if it reports none
+func drClusterConfigRequeueAfter(drCConfig *ramen.DRClusterConfig) time.Duration {
+	if meta.FindStatusCondition(drCConfig.Status.Conditions, ramen.DRClusterConfigPeerConnected) != nil {
+		return drClusterConfigPeerConnectedInterval
+	}
+
+	// for the errors to be dropped once they expire
+	if len(drCConfig.Status.OperandErrors) > 0 {
+		return drClusterConfigOperandErrorsInterval
+	}
+
+	return 0
+}
@@ -0,0 +1,663 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ocmv1 "open-cluster-management.io/api/cluster/v1"
+	clrapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+// drClusterMigrationRequeueInterval is the interval a migration waiting for other controllers is reconciled at
+const drClusterMigrationRequeueInterval = 10 * time.Second
+
+// errDRClusterMigrationFailed fails a migration, which is not retried
+var errDRClusterMigrationFailed = errors.New("migration failed")
+
+// DRClusterMigrationReconciler reconciles a DRClusterMigration object by renaming its DRCluster: it creates the
+// DRCluster of the new name, transfers the ManifestWorks of the old name to the namespace of the new name, updates the
+// DRPolicies and DRPlacementControls to the new name, and deletes the DRCluster of the old name once nothing refers to
+// it anymore
+type DRClusterMigrationReconciler struct {
+	client.Client
+	APIReader client.Reader
+	Log       logr.Logger
+}
+
+type drClusterMigrationInstance struct {
+	ctx    context.Context
+	client client.Client
+	log    logr.Logger
+	object *rmn.DRClusterMigration
+}
+
+type drClusterMigrationStep struct {
+	phase rmn.DRClusterMigrationPhase
+	run   func(*drClusterMigrationInstance) (string, error)
+}
+
+// drClusterMigrationSteps are run in order, each returning what it waits for, or nothing once done
+var drClusterMigrationSteps = []drClusterMigrationStep{
+	{rmn.DRClusterMigrationValidating, (*drClusterMigrationInstance).validate},
+	{rmn.DRClusterMigrationTransferring, (*drClusterMigrationInstance).transfer},
+	{rmn.DRClusterMigrationUpdatingReferences, (*drClusterMigrationInstance).updateReferences},
+	{rmn.DRClusterMigrationVerifying, (*drClusterMigrationInstance).verify},
+}
+
+//nolint:lll
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclustermigrations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclustermigrations/status,verbs=get;update;patch
+
+func (r *DRClusterMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("drcmigration", req.NamespacedName, "rid", util.GetRID())
+
+	migration := &rmn.DRClusterMigration{}
+	if err := r.Client.Get(ctx, req.NamespacedName, migration); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(fmt.Errorf("get: %w", err))
+	}
+
+	if migration.Status.Phase == rmn.DRClusterMigrationCompleted ||
+		migration.Status.Phase == rmn.DRClusterMigrationFailed || util.ResourceIsDeleted(migration) {
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("oldName", migration.Spec.OldName, "newName", migration.Spec.NewName)
+
+	if migration.Status.Phase == "" {
+		_, ramenConfig, err := ConfigMapGet(ctx, r.APIReader)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("config map get: %w", err)
+		}
+
+		// the exported manifests are read by the GitOps agents from the directory of the cluster name
+		if ramenConfig.ManifestTransport.Mode == rmn.ManifestTransportModeExport {
+			return ctrl.Result{}, r.statusUpdate(ctx, migration, rmn.DRClusterMigrationFailed,
+				"DRClusters cannot be renamed with the Export manifest transport")
+		}
+	}
+
+	m := &drClusterMigrationInstance{ctx: ctx, client: r.Client, log: log, object: migration}
+	saved := migration.Status.DeepCopy()
+
+	phase, message, err := m.migrate()
+
+	switch {
+	case errors.Is(err, errDRClusterMigrationFailed):
+		phase, message, err = rmn.DRClusterMigrationFailed, err.Error(), nil
+	case err != nil:
+		message = err.Error()
+	}
+
+	if phase != saved.Phase {
+		log.Info("DRCluster migration progressed", "phase", phase, "message", message)
+	}
+
+	if statusErr := r.statusUpdate(ctx, migration, phase, message); statusErr != nil {
+		return ctrl.Result{}, statusErr
+	}
+
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if phase != rmn.DRClusterMigrationCompleted && phase != rmn.DRClusterMigrationFailed {
+		return ctrl.Result{RequeueAfter: drClusterMigrationRequeueInterval}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *DRClusterMigrationReconciler) statusUpdate(ctx context.Context, migration *rmn.DRClusterMigration,
+	phase rmn.DRClusterMigrationPhase, message string,
+) error {
+	saved := migration.DeepCopy()
+
+	migration.Status.Phase = phase
+	migration.Status.Message = message
+	migration.Status.ObservedGeneration = migration.Generation
+
+	if equality.Semantic.DeepEqual(saved.Status, migration.Status) {
+		return nil
+	}
+
+	if err := r.Client.Status().Update(ctx, migration); err != nil {
+		return fmt.Errorf("status update: %w", err)
+	}
+
+	return nil
+}
+
+// migrate runs the steps of the migration from that of its phase, and returns the phase reached and what it waits
+// for, if not completed
+func (m *drClusterMigrationInstance) migrate() (rmn.DRClusterMigrationPhase, string, error) {
+	start := slices.IndexFunc(drClusterMigrationSteps, func(step drClusterMigrationStep) bool {
+		return step.phase == m.object.Status.Phase
+	})
+
+	for _, step := range drClusterMigrationSteps[max(start, 0):] {
+		waiting, err := step.run(m)
+		if err != nil || waiting != "" {
+			return step.phase, waiting, err
+		}
+	}
+
+	return rmn.DRClusterMigrationCompleted,
+		fmt.Sprintf("DRCluster %s renamed to %s", m.object.Spec.OldName, m.object.Spec.NewName), nil
+}
+
+// validate fails the migration if the DRCluster of the old name cannot be renamed, and waits for the DRCluster of the
+// new name, created with the spec of the old one if it does not exist, to be validated and for the actions of the
+// DRPCs of the old name to complete
+func (m *drClusterMigrationInstance) validate() (string, error) {
+	oldName, newName := m.object.Spec.OldName, m.object.Spec.NewName
+
+	oldDRCluster := &rmn.DRCluster{}
+	if err := m.client.Get(m.ctx, types.NamespacedName{Name: oldName}, oldDRCluster); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", fmt.Errorf("%w: DRCluster %s not found", errDRClusterMigrationFailed, oldName)
+		}
+
+		return "", fmt.Errorf("drcluster %s get: %w", oldName, err)
+	}
+
+	if util.ResourceIsDeleted(oldDRCluster) {
+		return "", fmt.Errorf("%w: DRCluster %s is being deleted", errDRClusterMigrationFailed, oldName)
+	}
+
+	if oldDRCluster.Spec.ClusterFence == rmn.ClusterFenceStateFenced ||
+		oldDRCluster.Spec.ClusterFence == rmn.ClusterFenceStateManuallyFenced {
+		return "", fmt.Errorf("%w: DRCluster %s is fenced", errDRClusterMigrationFailed, oldName)
+	}
+
+	if err := m.client.Get(m.ctx, types.NamespacedName{Name: newName}, &ocmv1.ManagedCluster{}); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", fmt.Errorf("%w: ManagedCluster %s not found", errDRClusterMigrationFailed, newName)
+		}
+
+		return "", fmt.Errorf("managedcluster %s get: %w", newName, err)
+	}
+
+	newDRCluster, err := m.newDRClusterEnsure(oldDRCluster)
+	if err != nil {
+		return "", err
+	}
+
+	if !meta.IsStatusConditionTrue(newDRCluster.Status.Conditions, rmn.DRClusterValidated) {
+		return fmt.Sprintf("waiting for DRCluster %s to be validated", newName), nil
+	}
+
+	drpcs, err := m.drpcsOfDRCluster(oldName)
+	if err != nil {
+		return "", err
+	}
+
+	for i := range drpcs {
+		if drpcs[i].Status.Progression != rmn.ProgressionCompleted {
+			return fmt.Sprintf("waiting for the action of DRPC %s/%s to complete", drpcs[i].Namespace, drpcs[i].Name),
+				nil
+		}
+	}
+
+	return "", nil
+}
+
+func (m *drClusterMigrationInstance) newDRClusterEnsure(oldDRCluster *rmn.DRCluster) (*rmn.DRCluster, error) {
+	newDRCluster := &rmn.DRCluster{}
+
+	err := m.client.Get(m.ctx, types.NamespacedName{Name: m.object.Spec.NewName}, newDRCluster)
+	if err == nil {
+		if util.ResourceIsDeleted(newDRCluster) {
+			return nil, fmt.Errorf("%w: DRCluster %s is being deleted", errDRClusterMigrationFailed, newDRCluster.Name)
+		}
+
+		return newDRCluster, nil
+	}
+
+	if !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("drcluster %s get: %w", m.object.Spec.NewName, err)
+	}
+
+	newDRCluster = &rmn.DRCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: m.object.Spec.NewName},
+		Spec:       *oldDRCluster.Spec.DeepCopy(),
+	}
+	newDRCluster.Spec.ClusterFence = ""
+
+	if err := m.client.Create(m.ctx, newDRCluster); err != nil {
+		return nil, fmt.Errorf("drcluster %s create: %w", newDRCluster.Name, err)
+	}
+
+	m.log.Info("Created DRCluster", "name", newDRCluster.Name)
+
+	return newDRCluster, nil
+}
+
+// drpcsOfDRCluster returns the DRPCs whose DRPolicy includes the DRCluster name, or that refer to it
+func (m *drClusterMigrationInstance) drpcsOfDRCluster(name string) ([]rmn.DRPlacementControl, error) {
+	drpolicies, err := m.drPoliciesOfDRCluster(name)
+	if err != nil {
+		return nil, err
+	}
+
+	drpcList := &rmn.DRPlacementControlList{}
+	if err := m.client.List(m.ctx, drpcList); err != nil {
+		return nil, fmt.Errorf("drpcs list: %w", err)
+	}
+
+	drpcs := []rmn.DRPlacementControl{}
+
+	for i := range drpcList.Items {
+		drpc := &drpcList.Items[i]
+
+		if slices.ContainsFunc(drpolicies, func(drpolicy rmn.DRPolicy) bool {
+			return drpolicy.Name == drpc.Spec.DRPolicyRef.Name
+		}) || drpcRefersToCluster(drpc, name) {
+			drpcs = append(drpcs, *drpc)
+		}
+	}
+
+	return drpcs, nil
+}
+
+func drpcRefersToCluster(drpc *rmn.DRPlacementControl, name string) bool {
+	return drpc.Spec.PreferredCluster == name || drpc.Spec.FailoverCluster == name ||
+		drpc.Status.PreferredDecision.ClusterName == name
+}
+
+func (m *drClusterMigrationInstance) drPoliciesOfDRCluster(name string) ([]rmn.DRPolicy, error) {
+	drpolicyList := &rmn.DRPolicyList{}
+	if err := m.client.List(m.ctx, drpolicyList); err != nil {
+		return nil, fmt.Errorf("drpolicies list: %w", err)
+	}
+
+	drpolicies := []rmn.DRPolicy{}
+
+	for i := range drpolicyList.Items {
+		if slices.Contains(util.DRPolicyClusterNames(&drpolicyList.Items[i]), name) {
+			drpolicies = append(drpolicies, drpolicyList.Items[i])
+		}
+	}
+
+	return drpolicies, nil
+}
+
+// drClusterMigrationManifestWorkTransferred returns whether a ManifestWork of the old name is transferred to the new
+// name, which it is unless it is created by the DRCluster reconciler for each DRCluster
+func drClusterMigrationManifestWorkTransferred(mw *ocmworkv1.ManifestWork) bool {
+	return mw.Name != util.DrClusterManifestWorkName &&
+		mw.Name != (&util.MWUtil{}).BuildManifestWorkName(util.MWTypeDRCConfig)
+}
+
+func (m *drClusterMigrationInstance) manifestWorksOfDRCluster(name string) ([]ocmworkv1.ManifestWork, error) {
+	mws := &ocmworkv1.ManifestWorkList{}
+	if err := m.client.List(m.ctx, mws, client.InNamespace(name),
+		client.MatchingLabels{util.CreatedByRamenLabel: "true"}); err != nil {
+		return nil, fmt.Errorf("manifestworks of %s list: %w", name, err)
+	}
+
+	return mws.Items, nil
+}
+
+// transfer copies the ManifestWorks of the old name to the namespace of the new name. They are not deleted until the
+// DRPolicies and DRPCs refer to the new name, as the DRPCs would otherwise recreate them.
+func (m *drClusterMigrationInstance) transfer() (string, error) {
+	mws, err := m.manifestWorksOfDRCluster(m.object.Spec.OldName)
+	if err != nil {
+		return "", err
+	}
+
+	transferred := []string{}
+
+	for i := range mws {
+		mw := &mws[i]
+		if !drClusterMigrationManifestWorkTransferred(mw) || util.ResourceIsDeleted(mw) {
+			continue
+		}
+
+		copied := &ocmworkv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{Name: mw.Name, Namespace: m.object.Spec.NewName}}
+
+		result, err := controllerutil.CreateOrUpdate(m.ctx, m.client, copied, func() error {
+			copied.Labels = mw.Labels
+			copied.Annotations = mw.Annotations
+			copied.Spec = *mw.Spec.DeepCopy()
+
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("manifestwork %s transfer: %w", mw.Name, err)
+		}
+
+		m.log.Info("Transferred ManifestWork", "name", mw.Name, "result", result)
+
+		transferred = append(transferred, mw.Name)
+	}
+
+	slices.Sort(transferred)
+	m.object.Status.ManifestWorks = transferred
+
+	return "", nil
+}
+
+// updateReferences renames the DRCluster in the DRPCs, their status and the decisions of their Placements or
+// PlacementRules, and then in the DRPolicies, recording the rename the DRPolicy validation requires for it. The DRPCs
+// are renamed first, for the DRPolicies to still select them as the step is retried.
+func (m *drClusterMigrationInstance) updateReferences() (string, error) {
+	oldName, newName := m.object.Spec.OldName, m.object.Spec.NewName
+
+	drpcs, err := m.drpcsOfDRCluster(oldName)
+	if err != nil {
+		return "", err
+	}
+
+	for i := range drpcs {
+		drpc := &drpcs[i]
+
+		if err := m.drpcRename(drpc); err != nil {
+			return "", err
+		}
+
+		if key := client.ObjectKeyFromObject(drpc).String(); !slices.Contains(m.object.Status.DRPlacementControls, key) {
+			m.object.Status.DRPlacementControls = append(m.object.Status.DRPlacementControls, key)
+		}
+	}
+
+	drpolicies, err := m.drPoliciesOfDRCluster(oldName)
+	if err != nil {
+		return "", err
+	}
+
+	for i := range drpolicies {
+		drpolicy := &drpolicies[i]
+		drpolicy.Spec.DRClusters[slices.Index(drpolicy.Spec.DRClusters, oldName)] = newName
+		drpolicy.Spec.DRClusterRename = &rmn.DRClusterRename{OldName: oldName, NewName: newName}
+
+		if err := m.client.Update(m.ctx, drpolicy); err != nil {
+			return "", fmt.Errorf("drpolicy %s update: %w", drpolicy.Name, err)
+		}
+
+		m.log.Info("Renamed DRCluster in DRPolicy", "name", drpolicy.Name)
+
+		if !slices.Contains(m.object.Status.DRPolicies, drpolicy.Name) {
+			m.object.Status.DRPolicies = append(m.object.Status.DRPolicies, drpolicy.Name)
+		}
+	}
+
+	return "", nil
+}
+
+// drpcRename renames the DRCluster in the decisions of the Placement or PlacementRule of drpc, which the DRPC reads
+// its current cluster from, in its preferred decision, and in its preferred and failover clusters
+func (m *drClusterMigrationInstance) drpcRename(drpc *rmn.DRPlacementControl) error {
+	oldName, newName := m.object.Spec.OldName, m.object.Spec.NewName
+
+	if _, err := m.placementDecisionsRename(drpc, true); err != nil {
+		return err
+	}
+
+	if drpc.Status.PreferredDecision.ClusterName == oldName {
+		patch := client.MergeFrom(drpc.DeepCopy())
+		drpc.Status.PreferredDecision = rmn.PlacementDecision{ClusterName: newName, ClusterNamespace: newName}
+
+		if err := m.client.Status().Patch(m.ctx, drpc, patch); err != nil {
+			return fmt.Errorf("drpc %s/%s status patch: %w", drpc.Namespace, drpc.Name, err)
+		}
+
+		m.log.Info("Renamed DRCluster in DRPC preferred decision", "namespace", drpc.Namespace, "name", drpc.Name)
+	}
+
+	if drpc.Spec.PreferredCluster != oldName && drpc.Spec.FailoverCluster != oldName {
+		return nil
+	}
+
+	patch := client.MergeFrom(drpc.DeepCopy())
+
+	if drpc.Spec.PreferredCluster == oldName {
+		drpc.Spec.PreferredCluster = newName
+	}
+
+	if drpc.Spec.FailoverCluster == oldName {
+		drpc.Spec.FailoverCluster = newName
+	}
+
+	if err := m.client.Patch(m.ctx, drpc, patch); err != nil {
+		return fmt.Errorf("drpc %s/%s patch: %w", drpc.Namespace, drpc.Name, err)
+	}
+
+	m.log.Info("Renamed DRCluster in DRPC", "namespace", drpc.Namespace, "name", drpc.Name)
+
+	return nil
+}
+
+// placementDecisionsRename returns whether a decision of the Placement or PlacementRule of drpc is of the old name,
+// renaming it first if rename
+func (m *drClusterMigrationInstance) placementDecisionsRename(drpc *rmn.DRPlacementControl, rename bool,
+) (bool, error) {
+	oldName, newName := m.object.Spec.OldName, m.object.Spec.NewName
+	key := types.NamespacedName{Namespace: drpc.Spec.PlacementRef.Namespace, Name: drpc.Spec.PlacementRef.Name}
+
+	if key.Namespace == "" {
+		key.Namespace = drpc.Namespace
+	}
+
+	plRule := &plrv1.PlacementRule{}
+	if err := m.client.Get(m.ctx, key, plRule); client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("placementrule %s get: %w", key, err)
+	} else if err == nil {
+		refers := false
+
+		for i := range plRule.Status.Decisions {
+			if plRule.Status.Decisions[i].ClusterName == oldName {
+				refers = true
+				plRule.Status.Decisions[i] = plrv1.PlacementDecision{ClusterName: newName, ClusterNamespace: newName}
+			}
+		}
+
+		if !refers || !rename {
+			return refers, nil
+		}
+
+		if err := m.client.Status().Update(m.ctx, plRule); err != nil {
+			return false, fmt.Errorf("placementrule %s status update: %w", key, err)
+		}
+
+		m.log.Info("Renamed DRCluster in PlacementRule decisions", "name", key)
+
+		return false, nil
+	}
+
+	plDecisions := &clrapiv1beta1.PlacementDecisionList{}
+	if err := m.client.List(m.ctx, plDecisions, client.InNamespace(key.Namespace),
+		client.MatchingLabels{clrapiv1beta1.PlacementLabel: key.Name}); err != nil {
+		return false, fmt.Errorf("placementdecisions of %s list: %w", key, err)
+	}
+
+	for i := range plDecisions.Items {
+		plDecision := &plDecisions.Items[i]
+		refers := false
+
+		for j := range plDecision.Status.Decisions {
+			if plDecision.Status.Decisions[j].ClusterName == oldName {
+				refers = true
+				plDecision.Status.Decisions[j].ClusterName = newName
+			}
+		}
+
+		if !refers {
+			continue
+		}
+
+		if !rename {
+			return true, nil
+		}
+
+		if err := m.client.Status().Update(m.ctx, plDecision); err != nil {
+			return false, fmt.Errorf("placementdecision %s/%s status update: %w", plDecision.Namespace,
+				plDecision.Name, err)
+		}
+
+		m.log.Info("Renamed DRCluster in PlacementDecision", "namespace", plDecision.Namespace,
+			"name", plDecision.Name)
+	}
+
+	return false, nil
+}
+
+// verify waits for nothing to refer to the old name, deletes the ManifestWorks of the old name, orphaning their
+// resources which the transferred ManifestWorks now own, and then deletes the DRCluster of the old name
+func (m *drClusterMigrationInstance) verify() (string, error) {
+	oldName := m.object.Spec.OldName
+
+	if drpolicies, err := m.drPoliciesOfDRCluster(oldName); err != nil || len(drpolicies) != 0 {
+		return fmt.Sprintf("waiting for DRPolicies to refer to %s", m.object.Spec.NewName), err
+	}
+
+	drpcs, err := m.drpcsOfDRCluster(oldName)
+	if err != nil || len(drpcs) != 0 {
+		return fmt.Sprintf("waiting for DRPCs to refer to %s", m.object.Spec.NewName), err
+	}
+
+	if waiting, err := m.placementDecisionsVerify(); err != nil || waiting != "" {
+		return waiting, err
+	}
+
+	mws, err := m.manifestWorksOfDRCluster(oldName)
+	if err != nil {
+		return "", err
+	}
+
+	remaining := []string{}
+
+	for i := range mws {
+		mw := &mws[i]
+
+		if err := m.manifestWorkOrphan(mw); err != nil {
+			return "", err
+		}
+
+		if !drClusterMigrationManifestWorkTransferred(mw) {
+			continue
+		}
+
+		remaining = append(remaining, mw.Name)
+
+		if err := m.client.Delete(m.ctx, mw); client.IgnoreNotFound(err) != nil {
+			return "", fmt.Errorf("manifestwork %s/%s delete: %w", oldName, mw.Name, err)
+		}
+	}
+
+	if len(remaining) != 0 {
+		return fmt.Sprintf("waiting for ManifestWorks %v of %s to be deleted", remaining, oldName), nil
+	}
+
+	oldDRCluster := &rmn.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: oldName}}
+	if err := m.client.Delete(m.ctx, oldDRCluster); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("drcluster %s delete: %w", oldName, err)
+	}
+
+	return fmt.Sprintf("waiting for DRCluster %s to be deleted", oldName), nil
+}
+
+// placementDecisionsVerify waits for no decision of the Placements or PlacementRules of the renamed DRPCs to be of
+// the old name
+func (m *drClusterMigrationInstance) placementDecisionsVerify() (string, error) {
+	for _, key := range m.object.Status.DRPlacementControls {
+		namespace, name, _ := strings.Cut(key, "/")
+
+		drpc := &rmn.DRPlacementControl{}
+		if err := m.client.Get(m.ctx, types.NamespacedName{Namespace: namespace, Name: name}, drpc); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return "", fmt.Errorf("drpc %s get: %w", key, err)
+		}
+
+		refers, err := m.placementDecisionsRename(drpc, false)
+		if err != nil || refers {
+			return fmt.Sprintf("waiting for the placement decisions of DRPC %s to refer to %s", key,
+				m.object.Spec.NewName), err
+		}
+	}
+
+	return "", nil
+}
+
+// manifestWorkOrphan sets the orphan delete option of mw, so that deleting it does not delete its resources
+func (m *drClusterMigrationInstance) manifestWorkOrphan(mw *ocmworkv1.ManifestWork) error {
+	if mw.Spec.DeleteOption != nil &&
+		mw.Spec.DeleteOption.PropagationPolicy == ocmworkv1.DeletePropagationPolicyTypeOrphan {
+		return nil
+	}
+
+	patch := client.MergeFrom(mw.DeepCopy())
+	mw.Spec.DeleteOption = &ocmworkv1.DeleteOption{PropagationPolicy: ocmworkv1.DeletePropagationPolicyTypeOrphan}
+
+	if err := m.client.Patch(m.ctx, mw, patch); err != nil {
+		return fmt.Errorf("manifestwork %s/%s orphan: %w", mw.Namespace, mw.Name, err)
+	}
+
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DRClusterMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rmn.DRClusterMigration{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&rmn.DRCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.drClusterMapFunc),
+			builder.WithPredicates(util.CreateOrDeleteOrResourceVersionUpdatePredicate{}),
+		).
+		Complete(r)
+}
+
+// drClusterMapFunc returns the DRClusterMigrations of the DRCluster, by its old or new name
+func (r *DRClusterMigrationReconciler) drClusterMapFunc(ctx context.Context, drcluster client.Object,
+) []reconcile.Request {
+	migrations := &rmn.DRClusterMigrationList{}
+	if err := r.Client.List(ctx, migrations); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+
+	for i := range migrations.Items {
+		spec := migrations.Items[i].Spec
+		if spec.OldName == drcluster.GetName() || spec.NewName == drcluster.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Name: migrations.Items[i].Name,
+			}})
+		}
+	}
+
+	return requests
+}
@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ocmv1 "open-cluster-management.io/api/cluster/v1"
+	clrapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("DRClusterMigration", func() {
+	const vrgMWName = "drpc-app-vrg-mw"
+
+	var (
+		fakeClient client.Client
+		reconciler *DRClusterMigrationReconciler
+	)
+
+	reconcile := func() *rmn.DRClusterMigration {
+		key := types.NamespacedName{Name: "rename"}
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+
+		migration := &rmn.DRClusterMigration{}
+		Expect(fakeClient.Get(context.TODO(), key, migration)).To(Succeed())
+
+		return migration
+	}
+
+	drpc := func() *rmn.DRPlacementControl {
+		drpc := &rmn.DRPlacementControl{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "app", Name: "drpc"}, drpc)).To(Succeed())
+
+		return drpc
+	}
+
+	placementDecision := func() *clrapiv1beta1.PlacementDecision {
+		plDecision := &clrapiv1beta1.PlacementDecision{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "app", Name: "placement-decision-1"},
+			plDecision)).To(Succeed())
+
+		return plDecision
+	}
+
+	drClusterValidate := func(name string) {
+		drCluster := &rmn.DRCluster{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Name: name}, drCluster)).To(Succeed())
+		meta.SetStatusCondition(&drCluster.Status.Conditions, metav1.Condition{
+			Type: rmn.DRClusterValidated, Status: metav1.ConditionTrue, Reason: "Succeeded",
+		})
+		Expect(fakeClient.Status().Update(context.TODO(), drCluster)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		controllerType := ControllerType
+		ControllerType = rmn.DRHubType
+		DeferCleanup(func() { ControllerType = controllerType })
+
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmv1.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmworkv1.AddToScheme(scheme)).To(Succeed())
+		Expect(clrapiv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(plrv1.AddToScheme(scheme)).To(Succeed())
+
+		configMap, err := ConfigMapNew(RamenOperatorNamespace(), HubOperatorConfigMapName, &rmn.RamenConfig{})
+		Expect(err).ToNot(HaveOccurred())
+
+		ramenLabels := map[string]string{util.CreatedByRamenLabel: "true"}
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&rmn.DRClusterMigration{}, &rmn.DRCluster{}, &rmn.DRPlacementControl{},
+				&clrapiv1beta1.PlacementDecision{}).
+			WithObjects(
+				configMap,
+				&rmn.DRClusterMigration{
+					ObjectMeta: metav1.ObjectMeta{Name: "rename"},
+					Spec:       rmn.DRClusterMigrationSpec{OldName: "east", NewName: "east-renamed"},
+				},
+				&rmn.DRCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "east"},
+					Spec:       rmn.DRClusterSpec{S3ProfileName: "s3-east", Region: "east"},
+				},
+				&ocmv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "east-renamed"}},
+				&rmn.DRPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+					Spec:       rmn.DRPolicySpec{DRClusters: []string{"east", "west"}},
+				},
+				&rmn.DRPlacementControl{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc"},
+					Spec: rmn.DRPlacementControlSpec{
+						DRPolicyRef:      corev1.ObjectReference{Name: "policy"},
+						PlacementRef:     corev1.ObjectReference{Name: "placement"},
+						PreferredCluster: "east",
+					},
+					Status: rmn.DRPlacementControlStatus{
+						Progression:       rmn.ProgressionCompleted,
+						PreferredDecision: rmn.PlacementDecision{ClusterName: "east", ClusterNamespace: "east"},
+					},
+				},
+				&clrapiv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "placement"}},
+				&clrapiv1beta1.PlacementDecision{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "app", Name: "placement-decision-1",
+						Labels: map[string]string{clrapiv1beta1.PlacementLabel: "placement"},
+					},
+					Status: clrapiv1beta1.PlacementDecisionStatus{
+						Decisions: []clrapiv1beta1.ClusterDecision{{ClusterName: "east"}},
+					},
+				},
+				&ocmworkv1.ManifestWork{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "east", Name: vrgMWName, Labels: ramenLabels},
+				},
+				&ocmworkv1.ManifestWork{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "east", Name: util.DrClusterManifestWorkName, Labels: ramenLabels},
+				},
+			).Build()
+		reconciler = &DRClusterMigrationReconciler{Client: fakeClient, APIReader: fakeClient, Log: logr.Discard()}
+	})
+
+	It("renames the DRCluster once the DRCluster of the new name is validated", func() {
+		migration := reconcile()
+		Expect(migration.Status.Phase).To(Equal(rmn.DRClusterMigrationValidating))
+
+		newDRCluster := &rmn.DRCluster{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Name: "east-renamed"}, newDRCluster)).To(Succeed())
+		Expect(newDRCluster.Spec.S3ProfileName).To(Equal("s3-east"))
+
+		drClusterValidate("east-renamed")
+
+		for range 3 {
+			migration = reconcile()
+		}
+
+		Expect(migration.Status.Phase).To(Equal(rmn.DRClusterMigrationCompleted))
+		Expect(migration.Status.ManifestWorks).To(Equal([]string{vrgMWName}))
+		Expect(migration.Status.DRPolicies).To(Equal([]string{"policy"}))
+		Expect(migration.Status.DRPlacementControls).To(Equal([]string{"app/drpc"}))
+
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "east-renamed", Name: vrgMWName},
+			&ocmworkv1.ManifestWork{})).To(Succeed())
+		Expect(k8serrors.IsNotFound(fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "east", Name: vrgMWName},
+			&ocmworkv1.ManifestWork{}))).To(BeTrue())
+
+		drClusterMW := &ocmworkv1.ManifestWork{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "east", Name: util.DrClusterManifestWorkName},
+			drClusterMW)).To(Succeed())
+		Expect(drClusterMW.Spec.DeleteOption.PropagationPolicy).To(Equal(ocmworkv1.DeletePropagationPolicyTypeOrphan))
+
+		drPolicy := &rmn.DRPolicy{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Name: "policy"}, drPolicy)).To(Succeed())
+		Expect(drPolicy.Spec.DRClusters).To(Equal([]string{"east-renamed", "west"}))
+		Expect(drPolicy.Spec.DRClusterRename).To(Equal(&rmn.DRClusterRename{OldName: "east", NewName: "east-renamed"}))
+		Expect(drpc().Spec.PreferredCluster).To(Equal("east-renamed"))
+		Expect(drpc().Status.PreferredDecision.ClusterName).To(Equal("east-renamed"))
+		Expect(placementDecision().Status.Decisions[0].ClusterName).To(Equal("east-renamed"))
+
+		Expect(k8serrors.IsNotFound(fakeClient.Get(context.TODO(), types.NamespacedName{Name: "east"},
+			&rmn.DRCluster{}))).To(BeTrue())
+	})
+
+	It("waits for the actions of the DRPCs of the DRCluster to complete", func() {
+		inProgress := drpc()
+		inProgress.Status.Progression = rmn.ProgressionFailingOverToCluster
+		Expect(fakeClient.Status().Update(context.TODO(), inProgress)).To(Succeed())
+
+		reconcile()
+		drClusterValidate("east-renamed")
+
+		migration := reconcile()
+		Expect(migration.Status.Phase).To(Equal(rmn.DRClusterMigrationValidating))
+		Expect(migration.Status.Message).To(ContainSubstring("app/drpc"))
+		Expect(drpc().Spec.PreferredCluster).To(Equal("east"))
+	})
+
+	It("waits for the placement decisions of the DRPCs to refer to the new name", func() {
+		m := &drClusterMigrationInstance{
+			ctx: context.TODO(), client: fakeClient, log: logr.Discard(),
+			object: &rmn.DRClusterMigration{
+				Spec:   rmn.DRClusterMigrationSpec{OldName: "east", NewName: "east-renamed"},
+				Status: rmn.DRClusterMigrationStatus{DRPlacementControls: []string{"app/drpc"}},
+			},
+		}
+
+		waiting, err := m.placementDecisionsVerify()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(waiting).To(ContainSubstring("placement decisions of DRPC app/drpc"))
+
+		Expect(m.drpcRename(drpc())).To(Succeed())
+		Expect(m.placementDecisionsVerify()).To(BeEmpty())
+		Expect(drpc().Status.PreferredDecision.ClusterName).To(Equal("east-renamed"))
+	})
+
+	It("fails for a fenced DRCluster", func() {
+		drCluster := &rmn.DRCluster{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Name: "east"}, drCluster)).To(Succeed())
+		drCluster.Spec.ClusterFence = rmn.ClusterFenceStateFenced
+		Expect(fakeClient.Update(context.TODO(), drCluster)).To(Succeed())
+
+		migration := reconcile()
+		Expect(migration.Status.Phase).To(Equal(rmn.DRClusterMigrationFailed))
+		Expect(k8serrors.IsNotFound(fakeClient.Get(context.TODO(), types.NamespacedName{Name: "east-renamed"},
+			&rmn.DRCluster{}))).To(BeTrue())
+	})
+})
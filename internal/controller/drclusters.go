@@ -4,16 +4,22 @@
 package controllers
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"text/template"
 
 	"github.com/go-logr/logr"
 	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
 
 	rmn "github.com/ramendr/ramen/api/v1alpha1"
 	"github.com/ramendr/ramen/internal/controller/util"
@@ -47,6 +53,14 @@ func drClusterDeploy(drClusterInstance *drclusterInstance, ramenConfig *rmn.Rame
 		}
 	}
 
+	templated, err := drClusterManifestTemplatesRender(ramenConfig, drcluster.Name,
+		append(util.DrClusterManifestWorkObjects(), objects...))
+	if err != nil {
+		return err
+	}
+
+	objects = append(objects, templated...)
+
 	annotations := make(map[string]string)
 
 	annotations[DRClusterNameAnnotation] = mwu.InstName
@@ -54,6 +68,78 @@ func drClusterDeploy(drClusterInstance *drclusterInstance, ramenConfig *rmn.Rame
 	return mwu.CreateOrUpdateDrClusterManifestWork(drcluster.Name, objects, annotations)
 }
 
+// drClusterManifestTemplatesRender renders the drcluster manifest templates in RamenConfig for the cluster, and
+// validates that each renders to a single object with an apiVersion, kind and name, that no two templates render to
+// the same object, and that none renders to one of the objects Ramen deploys, ramenObjects
+func drClusterManifestTemplatesRender(ramenConfig *rmn.RamenConfig, clusterName string,
+	ramenObjects []interface{},
+) ([]interface{}, error) {
+	data := struct {
+		ClusterName                string
+		DrClusterOperatorNamespace string
+	}{
+		ClusterName:                clusterName,
+		DrClusterOperatorNamespace: drClusterOperatorNamespaceNameOrDefault(ramenConfig),
+	}
+
+	objects := []interface{}{}
+	seen := map[string]string{}
+	generated := sets.New[string]()
+
+	for _, object := range ramenObjects {
+		generated.Insert(util.ManifestObjectKey(object))
+	}
+
+	for _, manifestTemplate := range ramenConfig.DrClusterManifests {
+		obj, err := manifestTemplateRender(manifestTemplate, data)
+		if err != nil {
+			return nil, fmt.Errorf("drcluster manifest template %q: %w", manifestTemplate.Name, err)
+		}
+
+		key := util.ManifestObjectKey(obj)
+		if generated.Has(key) {
+			return nil, fmt.Errorf("drcluster manifest template %q renders object %s, which Ramen deploys",
+				manifestTemplate.Name, key)
+		}
+
+		if other, ok := seen[key]; ok {
+			return nil, fmt.Errorf("drcluster manifest templates %q and %q render the same object %s",
+				other, manifestTemplate.Name, key)
+		}
+
+		seen[key] = manifestTemplate.Name
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+func manifestTemplateRender(manifestTemplate rmn.ManifestTemplate, data interface{}) (*unstructured.Unstructured,
+	error,
+) {
+	tmpl, err := template.New(manifestTemplate.Name).Option("missingkey=error").Parse(manifestTemplate.Template)
+	if err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+
+	rendered := &bytes.Buffer{}
+	if err := tmpl.Execute(rendered, data); err != nil {
+		return nil, fmt.Errorf("render: %w", err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(rendered.Bytes(), &obj.Object); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+		return nil, fmt.Errorf("object requires apiVersion, kind and metadata.name")
+	}
+
+	return obj, nil
+}
+
 func appendSubscriptionObject(
 	drcluster *rmn.DRCluster,
 	mwu *util.MWUtil,
@@ -115,6 +201,8 @@ func objectsToDeploy(hubOperatorRamenConfig *rmn.RamenConfig) ([]interface{}, er
 	ramenConfig := &drClusterOperatorRamenConfig
 	drClusterOperatorNamespaceName := drClusterOperatorNamespaceNameOrDefault(ramenConfig)
 	ramenConfig.LeaderElection.ResourceName = drClusterLeaderElectionResourceName
+	ramenConfig.S3StoreProfiles = s3ProfilesForDrClusterOperator(ramenConfig.S3StoreProfiles,
+		drClusterOperatorNamespaceName)
 
 	drClusterOperatorConfigMap, err := ConfigMapNew(
 		drClusterOperatorNamespaceName,
@@ -218,6 +306,54 @@ func SubscriptionFromDrClusterManifestWork(
 	return subscription, nil
 }
 
+// updateManifestWorkSplitCondition reports, using the ManifestWorkSplit condition, if the DRCluster ManifestWork
+// was split into multiple ManifestWorks as it exceeded the ManifestWork size limit, or, as reported by deployErr,
+// could not be deployed as a single manifest of it exceeds the limit
+func (u *drclusterInstance) updateManifestWorkSplitCondition(deployErr error) {
+	var tooLargeErr util.ManifestWorkTooLargeError
+
+	if errors.As(deployErr, &tooLargeErr) {
+		util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+			Type:               rmn.DRClusterConditionTypeManifestWorkSplit,
+			Reason:             ReasonManifestTooLarge,
+			ObservedGeneration: u.object.Generation,
+			Status:             metav1.ConditionFalse,
+			Message:            tooLargeErr.Error(),
+		})
+
+		return
+	}
+
+	if deployErr != nil {
+		return
+	}
+
+	parts, split := u.mwUtil.SplitManifestWorks[util.DrClusterManifestWorkName]
+	if split {
+		util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+			Type:               rmn.DRClusterConditionTypeManifestWorkSplit,
+			Reason:             ReasonManifestWorkSplit,
+			ObservedGeneration: u.object.Generation,
+			Status:             metav1.ConditionTrue,
+			Message: fmt.Sprintf("ManifestWork %s split into %d ManifestWorks as its manifests exceed %d bytes",
+				util.DrClusterManifestWorkName, parts, util.ManifestWorkSizeLimit),
+		})
+
+		return
+	}
+
+	condition := meta.FindStatusCondition(u.object.Status.Conditions, rmn.DRClusterConditionTypeManifestWorkSplit)
+	if condition != nil && condition.Reason != ReasonManifestWorkNotSplit {
+		util.SetStatusCondition(&u.object.Status.Conditions, metav1.Condition{
+			Type:               rmn.DRClusterConditionTypeManifestWorkSplit,
+			Reason:             ReasonManifestWorkNotSplit,
+			ObservedGeneration: u.object.Generation,
+			Status:             metav1.ConditionFalse,
+			Message:            "ManifestWork " + util.DrClusterManifestWorkName + " is within the size limit",
+		})
+	}
+}
+
 func drClusterUndeploy(
 	drcluster *rmn.DRCluster,
 	mwu *util.MWUtil,
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ocmv1 "open-cluster-management.io/api/cluster/v1"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("DRCluster manifest templates", func() {
+	var ramenConfig *ramen.RamenConfig
+
+	configMapTemplate := func(name, objectName string) ramen.ManifestTemplate {
+		return ramen.ManifestTemplate{Name: name, Template: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ` + objectName + `
+  namespace: "{{ .DrClusterOperatorNamespace }}"
+data:
+  cluster: "{{ .ClusterName }}"
+`}
+	}
+
+	render := func() ([]interface{}, error) {
+		return drClusterManifestTemplatesRender(ramenConfig, "east", util.DrClusterManifestWorkObjects())
+	}
+
+	BeforeEach(func() {
+		ramenConfig = &ramen.RamenConfig{}
+		ramenConfig.DrClusterOperator.NamespaceName = "ramen-dr-cluster"
+	})
+
+	It("are merged into the drcluster ManifestWork after the objects Ramen deploys", func() {
+		scheme := runtime.NewScheme()
+		Expect(ocmv1.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmworkv1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		ramenConfig.DrClusterManifests = []ramen.ManifestTemplate{configMapTemplate("edge", "edge-config")}
+
+		Expect(drClusterDeploy(&drclusterInstance{
+			ctx:    context.TODO(),
+			object: &ramen.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "east"}},
+			client: fakeClient,
+			log:    logr.Discard(),
+			mwUtil: &util.MWUtil{
+				Client: fakeClient, APIReader: fakeClient, Ctx: context.TODO(), Log: logr.Discard(), InstName: "east",
+			},
+		}, ramenConfig)).To(Succeed())
+
+		mw := &ocmworkv1.ManifestWork{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{
+			Namespace: "east", Name: util.DrClusterManifestWorkName,
+		}, mw)).To(Succeed())
+
+		manifests := mw.Spec.Workload.Manifests
+		Expect(manifests).To(HaveLen(len(util.DrClusterManifestWorkObjects()) + 1))
+		Expect(string(manifests[len(manifests)-1].Raw)).To(And(
+			ContainSubstring(`"name":"edge-config"`),
+			ContainSubstring(`"namespace":"ramen-dr-cluster"`),
+			ContainSubstring(`"cluster":"east"`),
+		))
+	})
+
+	It("reject a template that overrides an object Ramen deploys", func() {
+		ramenRole := util.DrClusterManifestWorkObjects()[0].(client.Object)
+		ramenConfig.DrClusterManifests = []ramen.ManifestTemplate{{Name: "role", Template: `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: ` + ramenRole.GetName() + `
+rules: []
+`}}
+
+		_, err := render()
+		Expect(err).To(MatchError(ContainSubstring(`template "role" renders object rbac.authorization.k8s.io/ClusterRole`)))
+	})
+
+	It("reject templates that render the same object", func() {
+		ramenConfig.DrClusterManifests = []ramen.ManifestTemplate{
+			configMapTemplate("first", "edge-config"),
+			configMapTemplate("second", "edge-config"),
+		}
+
+		_, err := render()
+		Expect(err).To(MatchError(ContainSubstring(`templates "first" and "second" render the same object`)))
+	})
+
+	It("reject a template that does not render a named object", func() {
+		ramenConfig.DrClusterManifests = []ramen.ManifestTemplate{configMapTemplate("unnamed", `""`)}
+
+		_, err := render()
+		Expect(err).To(MatchError(ContainSubstring("requires apiVersion, kind and metadata.name")))
+
+		ramenConfig.DrClusterManifests = []ramen.ManifestTemplate{{Name: "missing", Template: "{{ .Missing }}"}}
+
+		_, err = render()
+		Expect(err).To(MatchError(ContainSubstring(`template "missing": render`)))
+	})
+})
@@ -0,0 +1,482 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// drDrillRequeueInterval is the interval a drill waiting for its VRG is reconciled at
+	drDrillRequeueInterval = 15 * time.Second
+
+	// drDrillTimeoutDefault is the time a drill waits for the workload to be restored, unless its spec overrides it
+	drDrillTimeoutDefault = 30 * time.Minute
+
+	// drDrillFinalizerName delays the deletion of a DRDrill until its VRG and namespace are deleted
+	drDrillFinalizerName = "drdrills.ramendr.openshift.io/teardown"
+
+	// DRDrillAnnotation is set, to the namespaced name of the DRDrill, on the ManifestWork of its VRG
+	DRDrillAnnotation = "drdrill.ramendr.openshift.io/drdrill"
+
+	// drDrillSuffix suffixes the name of the VRG of a drill to the name of its DRPC, and the default namespace of the
+	// drill to the namespace of the workload
+	drDrillSuffix = "-drill"
+)
+
+// errDRDrillRejected fails a drill before anything is created for it
+var errDRDrillRejected = errors.New("drill rejected")
+
+// DRDrillReconciler reconciles a DRDrill object by delivering, to the target cluster, a drill VRG that restores the
+// workload of the DRPC into the drill namespace, and by deleting it, which deletes the namespace, once the workload is
+// restored and retained for as long as requested, or the drill timed out
+type DRDrillReconciler struct {
+	client.Client
+	APIReader client.Reader
+	Log       logr.Logger
+	MCVGetter util.ManagedClusterViewGetter
+}
+
+type drDrillInstance struct {
+	ctx       context.Context
+	client    client.Client
+	log       logr.Logger
+	mcvGetter util.ManagedClusterViewGetter
+	object    *rmn.DRDrill
+	mwu       *util.MWUtil
+}
+
+//nolint:lll
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drdrills,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drdrills/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drdrills/finalizers,verbs=update
+
+func (r *DRDrillReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("drdrill", req.NamespacedName, "rid", util.GetRID())
+
+	drill := &rmn.DRDrill{}
+	if err := r.Client.Get(ctx, req.NamespacedName, drill); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(fmt.Errorf("get: %w", err))
+	}
+
+	d := &drDrillInstance{
+		ctx: ctx, client: r.Client, log: log, mcvGetter: r.MCVGetter, object: drill,
+		mwu: &util.MWUtil{Client: r.Client, APIReader: r.APIReader, Ctx: ctx, Log: log},
+	}
+
+	if util.ResourceIsDeleted(drill) {
+		return d.finalize()
+	}
+
+	if drill.Status.Phase == rmn.DRDrillSucceeded || drill.Status.Phase == rmn.DRDrillFailed {
+		return ctrl.Result{}, nil
+	}
+
+	saved := drill.Status.DeepCopy()
+
+	requeueAfter, err := d.run(r.APIReader)
+	if errors.Is(err, errDRDrillRejected) {
+		now := metav1.Now()
+		drill.Status.Phase, drill.Status.Message, drill.Status.CompletionTime = rmn.DRDrillFailed, err.Error(), &now
+		err = nil
+	}
+
+	if drill.Status.Phase != saved.Phase {
+		log.Info("DR drill progressed", "phase", drill.Status.Phase, "message", drill.Status.Message)
+	}
+
+	if statusErr := d.statusUpdate(saved); statusErr != nil {
+		return ctrl.Result{}, statusErr
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, err
+}
+
+// run advances the drill from its phase, and returns the interval to requeue it after, if it is not completed
+func (d *drDrillInstance) run(apiReader client.Reader) (time.Duration, error) {
+	switch d.object.Status.Phase {
+	case "":
+		return drDrillRequeueInterval, d.start(apiReader)
+	case rmn.DRDrillRestoring:
+		return drDrillRequeueInterval, d.restoring()
+	case rmn.DRDrillRestored:
+		return d.retain(), nil
+	case rmn.DRDrillTearingDown:
+		done, err := d.teardown()
+		if err != nil || !done {
+			return drDrillRequeueInterval, err
+		}
+
+		d.complete()
+	}
+
+	return 0, nil
+}
+
+func (d *drDrillInstance) statusUpdate(saved *rmn.DRDrillStatus) error {
+	d.object.Status.ObservedGeneration = d.object.Generation
+
+	if equality.Semantic.DeepEqual(*saved, d.object.Status) {
+		return nil
+	}
+
+	if err := d.client.Status().Update(d.ctx, d.object); err != nil {
+		return fmt.Errorf("status update: %w", err)
+	}
+
+	return nil
+}
+
+// start rejects the drill if the DRPC is not in a state to be drilled, and otherwise delivers the drill VRG to the
+// target cluster: a copy of the secondary VRG of the DRPC there, which the VRG controller restores the drill namespace
+// from instead of protecting the workload
+func (d *drDrillInstance) start(apiReader client.Reader) error {
+	_, ramenConfig, err := ConfigMapGet(d.ctx, apiReader)
+	if err != nil {
+		return fmt.Errorf("config map get: %w", err)
+	}
+
+	// the exported manifests are applied by the GitOps agents, which do not delete them once the drill completes
+	if ramenConfig.ManifestTransport.Mode == rmn.ManifestTransportModeExport {
+		return fmt.Errorf("%w: drills are not supported with the Export manifest transport", errDRDrillRejected)
+	}
+
+	drpc, targetCluster, err := d.drpcAndTargetClusterGet()
+	if err != nil {
+		return err
+	}
+
+	vrgNamespace := drpc.Status.ResourceConditions.ResourceMeta.Namespace
+	d.mwu.InstName, d.mwu.TargetNamespace = drpc.Name, vrgNamespace
+
+	vrg, err := d.vrgNew(drpc, targetCluster, vrgNamespace)
+	if err != nil {
+		return err
+	}
+
+	if !controllerutil.ContainsFinalizer(d.object, drDrillFinalizerName) {
+		controllerutil.AddFinalizer(d.object, drDrillFinalizerName)
+
+		if err := d.client.Update(d.ctx, d.object); err != nil {
+			return fmt.Errorf("finalizer add: %w", err)
+		}
+	}
+
+	if _, err := d.mwu.CreateOrUpdateVRGManifestWork(vrg.Name, vrg.Namespace, targetCluster, *vrg,
+		d.annotations()); err != nil {
+		return fmt.Errorf("drill VRG manifestwork create: %w", err)
+	}
+
+	now := metav1.Now()
+	d.object.Status.TargetCluster = targetCluster
+	d.object.Status.Namespace = vrg.Spec.Drill.Namespace
+	d.object.Status.StartTime = &now
+	d.object.Status.Phase = rmn.DRDrillRestoring
+	d.object.Status.Message = fmt.Sprintf("restoring DRPC %s into namespace %s of cluster %s", drpc.Name,
+		vrg.Spec.Drill.Namespace, targetCluster)
+
+	return nil
+}
+
+// drpcAndTargetClusterGet returns the DRPC of the drill, and the cluster to restore it on, unless the DRPC is not in
+// a state to be drilled or the cluster is not one of its DRPolicy other than the one the workload is placed on
+func (d *drDrillInstance) drpcAndTargetClusterGet() (*rmn.DRPlacementControl, string, error) {
+	drpc := &rmn.DRPlacementControl{}
+	if err := d.client.Get(d.ctx, types.NamespacedName{Namespace: d.object.Namespace, Name: d.object.Spec.DRPCName},
+		drpc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, "", fmt.Errorf("%w: DRPC %s not found", errDRDrillRejected, d.object.Spec.DRPCName)
+		}
+
+		return nil, "", fmt.Errorf("drpc %s get: %w", d.object.Spec.DRPCName, err)
+	}
+
+	if util.ResourceIsDeleted(drpc) || drpc.Status.Progression != rmn.ProgressionCompleted ||
+		drpc.Status.ResourceConditions.ResourceMeta.Namespace == "" {
+		return nil, "", fmt.Errorf("%w: DRPC %s is being deleted or its action is not completed", errDRDrillRejected,
+			drpc.Name)
+	}
+
+	drPolicy := &rmn.DRPolicy{}
+	if err := d.client.Get(d.ctx, types.NamespacedName{Name: drpc.Spec.DRPolicyRef.Name}, drPolicy); err != nil {
+		return nil, "", fmt.Errorf("drpolicy %s get: %w", drpc.Spec.DRPolicyRef.Name, err)
+	}
+
+	homeCluster := drpc.Status.PreferredDecision.ClusterName
+
+	targetCluster := d.object.Spec.TargetCluster
+	if targetCluster == "" {
+		for _, cluster := range drPolicy.Spec.DRClusters {
+			if cluster != homeCluster {
+				targetCluster = cluster
+			}
+		}
+	}
+
+	if targetCluster == homeCluster || !slices.Contains(drPolicy.Spec.DRClusters, targetCluster) {
+		return nil, "", fmt.Errorf("%w: cluster %q is not a peer cluster of %s in DRPolicy %s", errDRDrillRejected,
+			targetCluster, homeCluster, drPolicy.Name)
+	}
+
+	return drpc, targetCluster, nil
+}
+
+// vrgNew returns the drill VRG, a copy of the VRG of the DRPC on targetCluster restoring the drill namespace
+func (d *drDrillInstance) vrgNew(drpc *rmn.DRPlacementControl, targetCluster, vrgNamespace string,
+) (*rmn.VolumeReplicationGroup, error) {
+	mw, err := d.mwu.FindManifestWorkByType(util.MWTypeVRG, targetCluster)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: DRPC %s has no VRG on cluster %s", errDRDrillRejected, drpc.Name, targetCluster)
+		}
+
+		return nil, fmt.Errorf("vrg manifestwork get: %w", err)
+	}
+
+	source, err := util.ExtractVRGFromManifestWork(mw)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.Spec.ProtectedNamespaces != nil && len(*source.Spec.ProtectedNamespaces) != 0 {
+		return nil, fmt.Errorf("%w: drills of DRPCs protecting multiple namespaces are not supported",
+			errDRDrillRejected)
+	}
+
+	namespace := d.object.Spec.Namespace
+	if namespace == "" {
+		namespace = vrgNamespace + drDrillSuffix
+	}
+
+	if namespace == vrgNamespace || len(namespace) > 63 {
+		return nil, fmt.Errorf("%w: namespace %q is not valid to restore into", errDRDrillRejected, namespace)
+	}
+
+	vrgName := drpc.Name + drDrillSuffix
+
+	existing, err := d.mwu.FindManifestWork(util.ManifestWorkName(vrgName, vrgNamespace, util.MWTypeVRG), targetCluster)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("drill VRG manifestwork get: %w", err)
+	}
+
+	if err == nil && existing.Annotations[DRDrillAnnotation] != d.annotations()[DRDrillAnnotation] {
+		return nil, fmt.Errorf("%w: DRPC %s is drilled by %s", errDRDrillRejected, drpc.Name,
+			existing.Annotations[DRDrillAnnotation])
+	}
+
+	vrg := &rmn.VolumeReplicationGroup{
+		TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+		ObjectMeta: metav1.ObjectMeta{Name: vrgName, Namespace: vrgNamespace},
+		Spec:       *source.Spec.DeepCopy(),
+	}
+
+	util.AddLabel(vrg, util.CreatedByRamenLabel, "true")
+
+	vrg.Spec.Drill = &rmn.VRGDrillSpec{SourceVRGName: source.Name, Namespace: namespace}
+	vrg.Spec.Action = ""
+	vrg.Spec.PrepareForFinalSync = false
+	vrg.Spec.RunFinalSync = false
+	vrg.Spec.LocalFailover = nil
+	vrg.Spec.FinalizationHooks = nil
+
+	return vrg, nil
+}
+
+func (d *drDrillInstance) annotations() map[string]string {
+	return map[string]string{DRDrillAnnotation: types.NamespacedName{
+		Namespace: d.object.Namespace, Name: d.object.Name,
+	}.String()}
+}
+
+// manifestWork returns the ManifestWork of the drill VRG on the target cluster, or nil if there is none
+func (d *drDrillInstance) manifestWork() (*ocmworkv1.ManifestWork, error) {
+	if d.object.Status.TargetCluster == "" {
+		return nil, nil
+	}
+
+	mws, err := d.mwu.ListManifestWorksByType(d.object.Status.TargetCluster, util.MWTypeVRG)
+	if err != nil {
+		return nil, fmt.Errorf("manifestworks list: %w", err)
+	}
+
+	for i := range mws.Items {
+		if mws.Items[i].Annotations[DRDrillAnnotation] == d.annotations()[DRDrillAnnotation] {
+			return &mws.Items[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// restoring reports the restore of the drill VRG, and tears the drill down once the restore failed or timed out
+func (d *drDrillInstance) restoring() error {
+	status := &d.object.Status
+
+	mw, err := d.manifestWork()
+	if err != nil {
+		return err
+	}
+
+	if mw == nil {
+		d.tearDown("drill VRG ManifestWork was deleted")
+
+		return nil
+	}
+
+	vrg, err := util.ExtractVRGFromManifestWork(mw)
+	if err != nil {
+		return err
+	}
+
+	vrgView, err := d.mcvGetter.GetVRGFromManagedCluster(vrg.Name, vrg.Namespace, status.TargetCluster,
+		d.annotations())
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("drill VRG get from cluster %s: %w", status.TargetCluster, err)
+	}
+
+	if err == nil && vrgView.Status.Drill != nil {
+		drillStatus := vrgView.Status.Drill
+		status.RestoredPVCs = drillStatus.RestoredPVCs
+		status.SkippedPVCs = drillStatus.SkippedPVCs
+		status.KubeObjectsRestored = drillStatus.KubeObjectsRestored
+		status.Message = drillStatus.Message
+
+		switch drillStatus.Phase {
+		case rmn.VRGDrillRestored:
+			now := metav1.Now()
+			status.RestoredTime = &now
+			status.Phase = rmn.DRDrillRestored
+			status.Message = fmt.Sprintf("restored into namespace %s of cluster %s", status.Namespace,
+				status.TargetCluster)
+
+			return nil
+		case rmn.VRGDrillFailed:
+			d.tearDown("restore failed: " + drillStatus.Message)
+
+			return nil
+		}
+	}
+
+	timeout := drDrillTimeoutDefault
+	if d.object.Spec.Timeout != nil {
+		timeout = d.object.Spec.Timeout.Duration
+	}
+
+	if time.Since(status.StartTime.Time) > timeout {
+		d.tearDown(fmt.Sprintf("not restored within %v: %s", timeout, status.Message))
+	}
+
+	return nil
+}
+
+// retain tears the drill down once the restored workload was retained for as long as requested, and otherwise
+// returns the interval until it is
+func (d *drDrillInstance) retain() time.Duration {
+	retainFor := time.Duration(0)
+	if d.object.Spec.RetainFor != nil {
+		retainFor = d.object.Spec.RetainFor.Duration
+	}
+
+	if remaining := time.Until(d.object.Status.RestoredTime.Add(retainFor)); remaining > 0 {
+		return remaining
+	}
+
+	d.tearDown(fmt.Sprintf("retained for %v", retainFor))
+
+	return drDrillRequeueInterval
+}
+
+func (d *drDrillInstance) tearDown(message string) {
+	d.object.Status.Phase = rmn.DRDrillTearingDown
+	d.object.Status.Message = message
+}
+
+// teardown deletes the drill VRG, whose finalizer deletes the drill namespace, and returns whether it is deleted
+func (d *drDrillInstance) teardown() (bool, error) {
+	mw, err := d.manifestWork()
+	if err != nil || mw == nil {
+		return err == nil, err
+	}
+
+	vrg, err := util.ExtractVRGFromManifestWork(mw)
+	if err != nil {
+		return false, err
+	}
+
+	if err := d.mcvGetter.DeleteVRGManagedClusterView(vrg.Name, vrg.Namespace, mw.Namespace,
+		util.MWTypeVRG); err != nil {
+		return false, fmt.Errorf("drill VRG view delete: %w", err)
+	}
+
+	d.log.Info("Deleting drill VRG", "cluster", mw.Namespace, "name", vrg.Name, "namespace", vrg.Namespace)
+
+	if err := d.mwu.DeleteManifestWork(mw.Name, mw.Namespace); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+// complete records the result of the drill once torn down: it succeeded if the workload was restored
+func (d *drDrillInstance) complete() {
+	status := &d.object.Status
+	now := metav1.Now()
+	status.CompletionTime = &now
+
+	if status.RestoredTime != nil {
+		status.Phase = rmn.DRDrillSucceeded
+		status.Message = fmt.Sprintf("restored in %v, drill namespace deleted",
+			status.RestoredTime.Sub(status.StartTime.Time).Truncate(time.Second))
+
+		return
+	}
+
+	status.Phase = rmn.DRDrillFailed
+}
+
+// finalize tears down the drill of a deleted DRDrill, and removes its finalizer once done
+func (d *drDrillInstance) finalize() (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(d.object, drDrillFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	done, err := d.teardown()
+	if err != nil || !done {
+		return ctrl.Result{RequeueAfter: drDrillRequeueInterval}, err
+	}
+
+	controllerutil.RemoveFinalizer(d.object, drDrillFinalizerName)
+
+	if err := d.client.Update(d.ctx, d.object); err != nil {
+		return ctrl.Result{}, fmt.Errorf("finalizer remove: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DRDrillReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rmn.DRDrill{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+// drillVRGViewGetter returns the drill VRGs of the managed clusters from a map, by cluster, in place of their
+// ManagedClusterViews
+type drillVRGViewGetter struct {
+	util.ManagedClusterViewGetter
+	vrgs map[string]*rmn.VolumeReplicationGroup
+}
+
+func (g drillVRGViewGetter) GetVRGFromManagedCluster(name, _, managedCluster string, _ map[string]string,
+) (*rmn.VolumeReplicationGroup, error) {
+	vrg, ok := g.vrgs[managedCluster]
+	if !ok || vrg.Name != name {
+		return nil, k8serrors.NewNotFound(schema.GroupResource{}, name)
+	}
+
+	return vrg, nil
+}
+
+func (g drillVRGViewGetter) DeleteVRGManagedClusterView(_, _, _, _ string) error {
+	return nil
+}
+
+var _ = Describe("DRDrill", func() {
+	var (
+		fakeClient client.Client
+		reconciler *DRDrillReconciler
+		vrgs       map[string]*rmn.VolumeReplicationGroup
+	)
+
+	drillKey := types.NamespacedName{Namespace: "app", Name: "drill"}
+	drillMWKey := types.NamespacedName{Namespace: "west", Name: util.ManifestWorkName("drpc-drill", "app", "vrg")}
+
+	reconcile := func() *rmn.DRDrill {
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: drillKey})
+		Expect(err).ToNot(HaveOccurred())
+
+		drill := &rmn.DRDrill{}
+		Expect(fakeClient.Get(context.TODO(), drillKey, drill)).To(Succeed())
+
+		return drill
+	}
+
+	drillVRG := func() *rmn.VolumeReplicationGroup {
+		mw := &ocmworkv1.ManifestWork{}
+		Expect(fakeClient.Get(context.TODO(), drillMWKey, mw)).To(Succeed())
+
+		vrg, err := util.ExtractVRGFromManifestWork(mw)
+		Expect(err).ToNot(HaveOccurred())
+
+		return vrg
+	}
+
+	drillVRGReport := func(phase rmn.VRGDrillPhase) {
+		vrg := drillVRG()
+		vrg.Status.Drill = &rmn.VRGDrillStatus{Phase: phase, RestoredPVCs: []string{"pvc"}, Message: string(phase)}
+		vrgs["west"] = vrg
+	}
+
+	drillMWDeleted := func() bool {
+		return k8serrors.IsNotFound(fakeClient.Get(context.TODO(), drillMWKey, &ocmworkv1.ManifestWork{}))
+	}
+
+	BeforeEach(func() {
+		controllerType := ControllerType
+		ControllerType = rmn.DRHubType
+		DeferCleanup(func() { ControllerType = controllerType })
+
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmworkv1.AddToScheme(scheme)).To(Succeed())
+
+		configMap, err := ConfigMapNew(RamenOperatorNamespace(), HubOperatorConfigMapName, &rmn.RamenConfig{})
+		Expect(err).ToNot(HaveOccurred())
+
+		secondary := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc"},
+			Spec: rmn.VolumeReplicationGroupSpec{
+				ReplicationState: rmn.Secondary,
+				S3Profiles:       []string{"s3"},
+				Action:           rmn.VRGActionRelocate,
+				VolSync: rmn.VolSyncSpec{RDSpec: []rmn.VolSyncReplicationDestinationSpec{
+					{ProtectedPVC: rmn.ProtectedPVC{Namespace: "app", Name: "pvc"}},
+				}},
+			},
+		}
+
+		manifest, err := (&util.MWUtil{}).GenerateManifest(secondary)
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&rmn.DRDrill{}).
+			WithIndex(&ocmworkv1.ManifestWork{}, util.ManifestWorkTypeIndexName, func(o client.Object) []string {
+				return []string{util.ManifestWorkType(o.GetName())}
+			}).
+			WithObjects(
+				configMap,
+				&rmn.DRDrill{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drill"},
+					Spec:       rmn.DRDrillSpec{DRPCName: "drpc"},
+				},
+				&rmn.DRPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+					Spec:       rmn.DRPolicySpec{DRClusters: []string{"east", "west"}},
+				},
+				&rmn.DRPlacementControl{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc"},
+					Spec:       rmn.DRPlacementControlSpec{DRPolicyRef: corev1.ObjectReference{Name: "policy"}},
+					Status: rmn.DRPlacementControlStatus{
+						Progression:        rmn.ProgressionCompleted,
+						PreferredDecision:  rmn.PlacementDecision{ClusterName: "east"},
+						ResourceConditions: rmn.VRGConditions{ResourceMeta: rmn.VRGResourceMeta{Namespace: "app"}},
+					},
+				},
+				&ocmworkv1.ManifestWork{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "west", Name: util.ManifestWorkName("drpc", "app", "vrg")},
+					Spec: ocmworkv1.ManifestWorkSpec{
+						Workload: ocmworkv1.ManifestsTemplate{Manifests: []ocmworkv1.Manifest{*manifest}},
+					},
+				},
+			).Build()
+
+		vrgs = map[string]*rmn.VolumeReplicationGroup{}
+		reconciler = &DRDrillReconciler{
+			Client: fakeClient, APIReader: fakeClient, Log: logr.Discard(), MCVGetter: drillVRGViewGetter{vrgs: vrgs},
+		}
+	})
+
+	It("restores the DRPC on the peer cluster, and tears the drill down once restored", func() {
+		drill := reconcile()
+		Expect(drill.Status.Phase).To(Equal(rmn.DRDrillRestoring))
+		Expect(drill.Status.TargetCluster).To(Equal("west"))
+		Expect(drill.Status.Namespace).To(Equal("app-drill"))
+		Expect(drill.Finalizers).To(ContainElement(drDrillFinalizerName))
+
+		vrg := drillVRG()
+		Expect(vrg.Spec.Drill).To(Equal(&rmn.VRGDrillSpec{SourceVRGName: "drpc", Namespace: "app-drill"}))
+		Expect(vrg.Spec.Action).To(BeEmpty())
+		Expect(vrg.Spec.VolSync.RDSpec).To(HaveLen(1))
+
+		drillVRGReport(rmn.VRGDrillRestored)
+
+		drill = reconcile()
+		Expect(drill.Status.Phase).To(Equal(rmn.DRDrillRestored))
+		Expect(drill.Status.RestoredPVCs).To(Equal([]string{"pvc"}))
+
+		drill = reconcile()
+		Expect(drill.Status.Phase).To(Equal(rmn.DRDrillTearingDown))
+
+		drill = reconcile()
+		Expect(drillMWDeleted()).To(BeTrue())
+		Expect(drill.Status.Phase).To(Equal(rmn.DRDrillTearingDown))
+
+		drill = reconcile()
+		Expect(drill.Status.Phase).To(Equal(rmn.DRDrillSucceeded))
+		Expect(drill.Status.CompletionTime).ToNot(BeNil())
+	})
+
+	It("retains the restored namespace for as long as requested", func() {
+		drill := &rmn.DRDrill{}
+		Expect(fakeClient.Get(context.TODO(), drillKey, drill)).To(Succeed())
+		drill.Spec.RetainFor = &metav1.Duration{Duration: time.Hour}
+		Expect(fakeClient.Update(context.TODO(), drill)).To(Succeed())
+
+		reconcile()
+		drillVRGReport(rmn.VRGDrillRestored)
+		reconcile()
+
+		result, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: drillKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 59*time.Minute))
+		Expect(reconcile().Status.Phase).To(Equal(rmn.DRDrillRestored))
+	})
+
+	It("fails the drill whose restore fails, once torn down", func() {
+		reconcile()
+		drillVRGReport(rmn.VRGDrillFailed)
+
+		drill := reconcile()
+		Expect(drill.Status.Phase).To(Equal(rmn.DRDrillTearingDown))
+		Expect(drill.Status.Message).To(ContainSubstring("restore failed"))
+
+		reconcile()
+		drill = reconcile()
+		Expect(drill.Status.Phase).To(Equal(rmn.DRDrillFailed))
+		Expect(drillMWDeleted()).To(BeTrue())
+	})
+
+	It("fails the drill not restored within its timeout", func() {
+		drill := reconcile()
+		drill.Status.StartTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+		Expect(fakeClient.Status().Update(context.TODO(), drill)).To(Succeed())
+
+		drill = reconcile()
+		Expect(drill.Status.Phase).To(Equal(rmn.DRDrillTearingDown))
+		Expect(drill.Status.Message).To(ContainSubstring("not restored within"))
+	})
+
+	It("rejects a drill on the cluster the workload is placed on", func() {
+		drill := &rmn.DRDrill{}
+		Expect(fakeClient.Get(context.TODO(), drillKey, drill)).To(Succeed())
+		drill.Spec.TargetCluster = "east"
+		Expect(fakeClient.Update(context.TODO(), drill)).To(Succeed())
+
+		drill = reconcile()
+		Expect(drill.Status.Phase).To(Equal(rmn.DRDrillFailed))
+		Expect(drill.Finalizers).To(BeEmpty())
+		Expect(drillMWDeleted()).To(BeTrue())
+	})
+
+	It("rejects a drill of a DRPC whose action is in progress", func() {
+		drpc := &rmn.DRPlacementControl{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "app", Name: "drpc"}, drpc)).To(Succeed())
+		drpc.Status.Progression = rmn.ProgressionFailingOverToCluster
+		Expect(fakeClient.Update(context.TODO(), drpc)).To(Succeed())
+
+		Expect(reconcile().Status.Phase).To(Equal(rmn.DRDrillFailed))
+	})
+
+	It("tears the drill down before its DRDrill is deleted", func() {
+		reconcile()
+
+		drill := &rmn.DRDrill{}
+		Expect(fakeClient.Get(context.TODO(), drillKey, drill)).To(Succeed())
+		Expect(fakeClient.Delete(context.TODO(), drill)).To(Succeed())
+
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: drillKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drillMWDeleted()).To(BeTrue())
+
+		_, err = reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: drillKey})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(k8serrors.IsNotFound(fakeClient.Get(context.TODO(), drillKey, &rmn.DRDrill{}))).To(BeTrue())
+	})
+})
@@ -19,12 +19,14 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	clrapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	ocmworkv1 "open-cluster-management.io/api/work/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	rmn "github.com/ramendr/ramen/api/v1alpha1"
 	recipecore "github.com/ramendr/ramen/internal/controller/core"
+	"github.com/ramendr/ramen/internal/controller/statemachine"
 	rmnutil "github.com/ramendr/ramen/internal/controller/util"
 )
 
@@ -48,6 +50,9 @@ const (
 	// DRPCTestFailoverDryRunAnnotationValueTrue is the value set in the test failover annotation
 	DRPCTestFailoverDryRunAnnotationValueTrue = "true"
 
+	// Annotation for the cluster the test failover (dry-run) is running on, among the clusters of the DRPolicy
+	DRPCTestFailoverClusterAnnotation = "drplacementcontrol.ramendr.openshift.io/test-failover-cluster"
+
 	// Annotation for the last action performed on the DRPC
 	DRPCLastAction = "drplacementcontrol.ramendr.openshift.io/last-action"
 )
@@ -72,6 +77,7 @@ type DRPCInstance struct {
 	ctx                  context.Context
 	log                  logr.Logger
 	instance             *rmn.DRPlacementControl
+	spec                 *rmn.DRPlacementControlSpec // instance spec with the defaults of its template applied
 	savedInstanceStatus  rmn.DRPlacementControlStatus
 	drPolicy             *rmn.DRPolicy
 	drClusters           []rmn.DRCluster
@@ -79,6 +85,7 @@ type DRPCInstance struct {
 	volSyncDisabled      bool
 	userPlacement        client.Object
 	vrgs                 map[string]*rmn.VolumeReplicationGroup
+	vrgViewErrs          map[string]error // errors reading the VRG views, by cluster
 	vrgNamespace         string
 	ramenConfig          *rmn.RamenConfig
 	mwu                  rmnutil.MWUtil
@@ -91,6 +98,12 @@ func (d *DRPCInstance) startProcessing() bool {
 
 	requeue := true
 	done, processingErr := d.processPlacement()
+	held := d.updateClusterUnavailableCondition(processingErr)
+	d.updateManifestWorkSplitCondition(processingErr)
+	d.updateVRGViewUnavailableCondition()
+	d.updateReadiness()
+	d.updateRPOViolated()
+	d.updatePolicyMigrationCondition()
 
 	if d.shouldUpdateStatus() || d.statusUpdateTimeElapsed() {
 		if err := d.reconciler.updateDRPCStatus(d.ctx, d.instance, d.userPlacement, d.log, d.vrgs); err != nil {
@@ -105,6 +118,15 @@ func (d *DRPCInstance) startProcessing() bool {
 		}
 	}
 
+	if held {
+		// Parked until the cluster is available again, which triggers a reconcile to reapply, or until the hold is
+		// rechecked in case the availability event is missed
+		d.log.Info("Holding placement processing", "reason", processingErr.Error())
+		d.requeueAfter = clusterUnavailableRequeueDelay
+
+		return !requeue
+	}
+
 	if processingErr != nil {
 		d.log.Error(processingErr, "Failed to process placement")
 
@@ -121,6 +143,8 @@ func (d *DRPCInstance) startProcessing() bool {
 func (d *DRPCInstance) processPlacement() (bool, error) {
 	d.log.Info("Process DRPC Placement", "DRAction", d.instance.Spec.Action)
 
+	d.failoverClusterSelectionReset()
+
 	// Handle dryRun flow (annotation management and cleanup)
 	shouldContinue, err := d.processTestFailoverFlowIfEnabled()
 	if err != nil {
@@ -180,6 +204,7 @@ func (d *DRPCInstance) processTestFailoverFlowIfEnabled() (bool, error) {
 			// Note: We don't update last-action/last-app-deployment-cluster annotations during dryRun,
 			// so they naturally preserve the pre-test state for revert validation
 			rmnutil.AddAnnotation(d.instance, DRPCTestFailoverDryRunAnnotation, "true")
+			rmnutil.AddAnnotation(d.instance, DRPCTestFailoverClusterAnnotation, d.instance.Spec.FailoverCluster)
 
 			if err := d.reconciler.Update(d.ctx, d.instance); err != nil {
 				return false, fmt.Errorf("failed to add test failover annotation: %w", err)
@@ -255,6 +280,7 @@ func (d *DRPCInstance) cleanupTestFailoverAnnotation(clusterName string) error {
 	// Delete DRPC test failover annotation
 	// This ensures setVRGAnnotations() won't find the annotation and re-add it to VRG
 	delete(d.instance.Annotations, DRPCTestFailoverDryRunAnnotation)
+	delete(d.instance.Annotations, DRPCTestFailoverClusterAnnotation)
 
 	// Note: No need to clear saved state - annotations already contain the pre-test state
 	// since they were not updated during dryRun
@@ -271,15 +297,18 @@ func (d *DRPCInstance) cleanupTestFailoverAnnotation(clusterName string) error {
 // detectPromotionOrRevert determines if user wants to promote test failover to real failover
 // or revert to original state, then routes to the appropriate handler.
 func (d *DRPCInstance) detectPromotionOrRevert() (bool, error) {
-	// Determine test failover cluster (peer of last-app-deployment-cluster)
+	// Determine test failover cluster, as recorded when the test failover started, or else the peer of
+	// last-app-deployment-cluster for a test failover started before the cluster was recorded
 	lastAppCluster := d.instance.GetAnnotations()[LastAppDeploymentCluster]
-	testFailoverCluster := ""
+	testFailoverCluster := d.instance.GetAnnotations()[DRPCTestFailoverClusterAnnotation]
 
 	for _, drCluster := range d.drClusters {
+		if testFailoverCluster != "" {
+			break
+		}
+
 		if drCluster.Name != lastAppCluster {
 			testFailoverCluster = drCluster.Name
-
-			break
 		}
 	}
 
@@ -514,6 +543,11 @@ func (d *DRPCInstance) RunInitialDeployment() (bool, error) {
 		return d.ensureInitialDeployActionCompleted(homeCluster)
 	}
 
+	// Qualify the clusters of the DRPolicy before the workload is first protected
+	if !deployed && !d.isQualified() {
+		return !done, fmt.Errorf("waiting for the qualification of the clusters of DRPolicy %s", d.drPolicy.GetName())
+	}
+
 	// Ensure that initial deployment is complete
 	if !deployed || !d.isUserPlRuleUpdated(homeCluster) {
 		d.setStatusInitiating()
@@ -716,18 +750,13 @@ func (d *DRPCInstance) RunFailover() (bool, error) {
 
 	const done = true
 
-	if d.instance.Spec.FailoverCluster == "" {
-		const msg = "missing value for spec.FailoverCluster"
-
-		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
-			d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), msg)
-
-		return done, fmt.Errorf(msg)
+	failoverCluster := drpcFailoverCluster(d.instance)
+	if failoverCluster == "" {
+		return d.failoverClusterSelect()
 	}
 
-	failoverCluster := d.instance.Spec.FailoverCluster
 	if ok, checkerr := d.isValidFailoverTarget(failoverCluster); !ok {
-		err := fmt.Errorf("unable to start failover, spec.FailoverCluster (%s) is not a valid Secondary target: %w",
+		err := fmt.Errorf("unable to start failover, failover cluster (%s) is not a valid Secondary target: %w",
 			failoverCluster, checkerr)
 		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
 			d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), err.Error())
@@ -790,6 +819,16 @@ func (d *DRPCInstance) RunFailover() (bool, error) {
 		return !done, nil
 	}
 
+	if !d.areFailoverDependenciesSatisfied() {
+		return !done, nil
+	}
+
+	if d.preflightDue() && !d.preflightPassed(drpcFailoverCluster(d.instance)) {
+		return !done, nil
+	}
+
+	d.updateAgentUnavailableCondition(drpcFailoverCluster(d.instance))
+
 	return d.switchToFailoverCluster()
 }
 
@@ -874,10 +913,10 @@ func (d *DRPCInstance) switchToFailoverCluster() (bool, error) {
 		d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), "Starting failover")
 	addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionPeerReady, d.instance.Generation,
 		metav1.ConditionFalse, rmn.ReasonNotStarted,
-		fmt.Sprintf("Started failover to cluster %q", d.instance.Spec.FailoverCluster))
+		fmt.Sprintf("Started failover to cluster %q", drpcFailoverCluster(d.instance)))
 	d.setProgression(rmn.ProgressionCheckingFailoverPrerequisites)
 
-	curHomeCluster := d.getCurrentHomeClusterName(d.instance.Spec.FailoverCluster, d.drClusters)
+	curHomeCluster := d.getCurrentHomeClusterName(drpcFailoverCluster(d.instance), d.drClusters)
 	if curHomeCluster == "" {
 		msg := "Invalid Failover request. Current home cluster does not exists"
 		d.log.Info(msg)
@@ -897,7 +936,7 @@ func (d *DRPCInstance) switchToFailoverCluster() (bool, error) {
 
 	d.setProgression(rmn.ProgressionFailingOverToCluster)
 
-	newHomeCluster := d.instance.Spec.FailoverCluster
+	newHomeCluster := drpcFailoverCluster(d.instance)
 
 	err := d.reconciler.retainClusterDecisionAsFailover(d.ctx, d.userPlacement, curHomeCluster)
 	if err == nil {
@@ -951,14 +990,8 @@ func (d *DRPCInstance) getCurrentHomeClusterName(toCluster string, drClusters []
 //   - bool: Indicating if prerequisites are met
 //   - error: Any error in determining the prerequisite status
 func (d *DRPCInstance) checkFailoverPrerequisites(curHomeCluster string) (bool, error) {
-	var (
-		met bool
-		err error
-	)
-
-	if d.drType == DRTypeSync {
-		met, err = d.checkMetroFailoverPrerequisites(curHomeCluster)
-	} else {
+	met, err := d.checkFencingFailoverPrerequisites(curHomeCluster)
+	if err == nil && met && d.drType != DRTypeSync {
 		met = d.checkRegionalFailoverPrerequisites()
 	}
 
@@ -981,16 +1014,46 @@ func (d *DRPCInstance) checkFailoverPrerequisites(curHomeCluster string) (bool,
 	return met, err
 }
 
-// checkMetroFailoverPrerequisites checks for any MetroDR failover prerequisites that need to be met on the
-// failoverCluster before initiating a failover from the curHomeCluster.
+// failoverFencingPreference returns whether a failover fences the cluster it fails over from, as the DRPolicy
+// prefers, or as it did before the preference was introduced if the DRPolicy does not set one: Manual for MetroDR,
+// and Disabled for RegionalDR
+func (d *DRPCInstance) failoverFencingPreference() rmn.FencingPreference {
+	return drPolicyFailoverFencingPreference(d.drPolicy, d.drType == DRTypeSync)
+}
+
+func drPolicyFailoverFencingPreference(drPolicy *rmn.DRPolicy, sync bool) rmn.FencingPreference {
+	if drPolicy.Spec.Fencing != "" {
+		return drPolicy.Spec.Fencing
+	}
+
+	if sync {
+		return rmn.FencingPreferenceManual
+	}
+
+	return rmn.FencingPreferenceDisabled
+}
+
+// checkFencingFailoverPrerequisites checks that the curHomeCluster is fenced before initiating a failover from it,
+// unless the DRPolicy disables fencing, requesting it to be fenced if the DRPolicy prefers automatic fencing.
 // Returns:
 //   - bool: Indicating if prerequisites are met
 //   - error: Any error in determining the prerequisite status
-func (d *DRPCInstance) checkMetroFailoverPrerequisites(curHomeCluster string) (bool, error) {
+func (d *DRPCInstance) checkFencingFailoverPrerequisites(curHomeCluster string) (bool, error) {
 	met := true
 
+	preference := d.failoverFencingPreference()
+	if preference == rmn.FencingPreferenceDisabled {
+		return met, nil
+	}
+
 	d.setProgression(rmn.ProgressionWaitForFencing)
 
+	if preference == rmn.FencingPreferenceAutomatic {
+		if err := d.requestClusterFence(curHomeCluster); err != nil {
+			return !met, err
+		}
+	}
+
 	fenced, err := d.checkClusterFenced(curHomeCluster, d.drClusters)
 	if err != nil {
 		return !met, err
@@ -1003,6 +1066,66 @@ func (d *DRPCInstance) checkMetroFailoverPrerequisites(curHomeCluster string) (b
 	return met, nil
 }
 
+// requestClusterFence sets the clusterFence of the DRCluster of cluster to Fenced, traced to the failover of the DRPC,
+// unless it is fenced already, or was fenced or unfenced manually, which is left to the operator
+func (d *DRPCInstance) requestClusterFence(cluster string) error {
+	for i := range d.drClusters {
+		drCluster := &d.drClusters[i]
+		if drCluster.Name != cluster {
+			continue
+		}
+
+		if drCluster.Spec.ClusterFence == rmn.ClusterFenceStateFenced {
+			return d.traceClusterFence(drCluster)
+		}
+
+		if drCluster.Spec.ClusterFence != "" && drCluster.Spec.ClusterFence != rmn.ClusterFenceStateUnfenced {
+			return nil
+		}
+
+		patch := client.MergeFrom(drCluster.DeepCopy())
+		drCluster.Spec.ClusterFence = rmn.ClusterFenceStateFenced
+
+		// the NetworkFences of the fence are traced to the failovers that requested it
+		trace := rmnutil.ActionTrace{Action: rmn.ActionFailover}
+		trace.Add(d.instance)
+		rmnutil.ActionTraceAnnotate(drCluster, trace)
+
+		if err := d.reconciler.Patch(d.ctx, drCluster, patch); err != nil {
+			return fmt.Errorf("failed to request fencing of cluster %s: %w", cluster, err)
+		}
+
+		d.log.Info("Requested fencing of the cluster failed over from", "cluster", cluster)
+
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeNormal,
+			rmnutil.EventReasonClusterFenceRequested, "Requested fencing of cluster "+cluster)
+
+		return nil
+	}
+
+	return fmt.Errorf("failed to get the DRCluster of cluster %s to fence", cluster)
+}
+
+// traceClusterFence adds the failover of the DRPC to the trace of the fence of drCluster, if it was requested by the
+// failovers of other DRPCs
+func (d *DRPCInstance) traceClusterFence(drCluster *rmn.DRCluster) error {
+	trace := rmnutil.ActionTraceFromAnnotations(drCluster)
+	if trace.Action != rmn.ActionFailover || trace.Traces(d.instance) {
+		return nil
+	}
+
+	patch := client.MergeFrom(drCluster.DeepCopy())
+
+	trace.Add(d.instance)
+	rmnutil.ActionTraceAnnotate(drCluster, trace)
+
+	if err := d.reconciler.Patch(d.ctx, drCluster, patch); err != nil {
+		return fmt.Errorf("failed to trace fencing of cluster %s: %w", drCluster.Name, err)
+	}
+
+	return nil
+}
+
 // checkRegionalFailoverPrerequisites checks for any RegionalDR failover prerequisites that need to be met on the
 // failoverCluster before initiating a failover.
 // Returns:
@@ -1011,7 +1134,7 @@ func (d *DRPCInstance) checkRegionalFailoverPrerequisites() bool {
 	d.setProgression(rmn.ProgressionWaitForStorageMaintenanceActivation)
 
 	for _, drCluster := range d.drClusters {
-		if drCluster.Name != d.instance.Spec.FailoverCluster {
+		if drCluster.Name != drpcFailoverCluster(d.instance) {
 			continue
 		}
 
@@ -1021,7 +1144,7 @@ func (d *DRPCInstance) checkRegionalFailoverPrerequisites() bool {
 			d.reconciler.APIReader,
 			[]string{drCluster.Spec.S3ProfileName},
 			d.instance.GetName(), d.vrgNamespace,
-			d.vrgs, d.instance.Spec.FailoverCluster,
+			d.vrgs, drpcFailoverCluster(d.instance),
 			d.reconciler.ObjStoreGetter, d.log); required {
 			return checkFailoverMaintenanceActivations(drCluster, activationsRequired, d.log)
 		}
@@ -1291,6 +1414,10 @@ func (d *DRPCInstance) RunRelocate() (bool, error) {
 		return !done, nil
 	}
 
+	if d.preflightDue() && !d.preflightPassed(preferredCluster) {
+		return !done, nil
+	}
+
 	// Check if current primary (that is not the preferred cluster), is ready to switch over
 	if curHomeCluster != "" && curHomeCluster != preferredCluster &&
 		!d.readyToSwitchOver(curHomeCluster, preferredCluster) {
@@ -1475,6 +1602,13 @@ func (d *DRPCInstance) prepareForFinalSync(homeCluster string) (bool, error) {
 	}
 
 	if !vrg.Status.PrepareForFinalSyncComplete {
+		if delay := rmnutil.BackupWindowDelay(d.drPolicy.Spec.BackupWindows, time.Now()); delay > 0 &&
+			!vrg.Spec.PrepareForFinalSync {
+			d.log.Info("Delaying final sync until the end of the backup window", "delay", delay)
+
+			return !done, nil
+		}
+
 		err := d.updateVRGToPrepareForFinalSync(homeCluster)
 		if err != nil {
 			return !done, err
@@ -2249,6 +2383,8 @@ func (d *DRPCInstance) updateVRGOptionalFields(vrg, vrgFromView *rmn.VolumeRepli
 	d.setVRGSpecFields(vrg)
 	d.updateVRGDRTypeSpecIfNeeded(vrg, vrgFromView)
 	d.updateMoverConfigIfNeeded(vrg)
+	d.setVRGLocalFailover(vrg, homeCluster)
+	d.downgradeVRGSpec(vrg, homeCluster)
 }
 
 // setVRGAnnotations sets all VRG annotations from DRPC
@@ -2262,11 +2398,12 @@ func (d *DRPCInstance) setVRGAnnotations(vrg *rmn.VolumeReplicationGroup, homeCl
 		rmnutil.IsSubmarinerEnabledAnnotation: d.instance.GetAnnotations()[rmnutil.IsSubmarinerEnabledAnnotation],
 		rmnutil.UseVolSyncAnnotation:          d.instance.GetAnnotations()[rmnutil.UseVolSyncAnnotation],
 		rmnutil.EnableDiffAnnotation:          d.instance.GetAnnotations()[rmnutil.EnableDiffAnnotation],
+		SkipFinalizationHooksAnnotation:       d.instance.GetAnnotations()[SkipFinalizationHooksAnnotation],
 	}
 
 	// Only set test failover annotation on the failover cluster during active test failover
 	// This annotation is used by VRG controller to enable AutoResync during test failover
-	if homeCluster == d.instance.Spec.FailoverCluster &&
+	if homeCluster == drpcFailoverCluster(d.instance) &&
 		d.instance.Spec.DryRun &&
 		d.instance.Spec.Action == rmn.ActionFailover {
 		vrg.ObjectMeta.Annotations[DRPCTestFailoverDryRunAnnotation] = DRPCTestFailoverDryRunAnnotationValueTrue
@@ -2283,12 +2420,38 @@ func (d *DRPCInstance) setVRGAnnotations(vrg *rmn.VolumeReplicationGroup, homeCl
 // setVRGSpecFields sets VRG spec fields from DRPC
 func (d *DRPCInstance) setVRGSpecFields(vrg *rmn.VolumeReplicationGroup) {
 	vrg.Spec.ProtectedNamespaces = d.instance.Spec.ProtectedNamespaces
-	vrg.Spec.S3Profiles = AvailableS3Profiles(d.drClusters)
-	vrg.Spec.KubeObjectProtection = d.instance.Spec.KubeObjectProtection
+	vrg.Spec.PVTopologyMappings = d.drPolicy.Spec.TopologyMappings
+	vrg.Spec.S3Profiles = s3ProfilesMigrating(d.instance, AvailableS3Profiles(d.drClusters))
+	vrg.Spec.KubeObjectProtection = d.spec.KubeObjectProtection
+	vrg.Spec.FinalizationHooks = d.spec.FinalizationHooks
 	vrg.Spec.VolSync.Disabled = d.volSyncDisabled
+	vrg.Spec.RestoreCaptureGeneration = d.instance.Spec.RestoreCaptureGeneration
+	vrg.Spec.QuiesceStrategy = d.instance.Spec.QuiesceStrategy
+	vrg.Spec.S3UploadPolicy = d.drPolicy.Spec.S3UploadPolicy
+	d.setVRGLaggingS3Profiles(vrg)
 	d.setVRGAction(vrg)
 }
 
+// setVRGLaggingS3Profiles sets the S3 profiles the primary VRG reports lagging behind the others, for a VRG restoring
+// its metadata to prefer the others. The profiles last set are retained while no primary VRG is reported, as when
+// its cluster is unavailable to be failed over from.
+func (d *DRPCInstance) setVRGLaggingS3Profiles(vrg *rmn.VolumeReplicationGroup) {
+	for _, vrgFromView := range d.vrgs {
+		if vrgFromView.Spec.ReplicationState != rmn.Primary {
+			continue
+		}
+
+		lagging := s3ProfilesLagging(vrgFromView)
+		if len(lagging) == 0 {
+			lagging = nil
+		}
+
+		vrg.Spec.LaggingS3Profiles = lagging
+
+		return
+	}
+}
+
 // updateVRGDRTypeSpecIfNeeded updates VRG DR type spec (Sync/Async) if needed
 func (d *DRPCInstance) updateVRGDRTypeSpecIfNeeded(vrg, vrgFromView *rmn.VolumeReplicationGroup) {
 	// If vrgFromView nil, then vrg is newly generated, Sync/Async spec is updated unconditionally
@@ -2310,18 +2473,18 @@ func (d *DRPCInstance) updateVRGDRTypeSpecIfNeeded(vrg, vrgFromView *rmn.VolumeR
 // data for workloads that have complex Security Context Constraints (SCC) settings.
 // Populate ReplicationSource and ReplicationDestination specs with MoverSecurityContext and MoverServiceAccount
 func (d *DRPCInstance) updateMoverConfigIfNeeded(vrg *rmn.VolumeReplicationGroup) {
-	if d.instance.Spec.VolSyncSpec != nil && d.drType == DRTypeAsync {
+	if d.spec.VolSyncSpec != nil && d.drType == DRTypeAsync {
 		d.updateMoverConfig(vrg)
 	}
 }
 
 // Checks if MoverConfig exists in the spec
 func (d *DRPCInstance) updateMoverConfig(vrg *rmn.VolumeReplicationGroup) {
-	if len(d.instance.Spec.VolSyncSpec.MoverConfig) == 0 {
+	if len(d.spec.VolSyncSpec.MoverConfig) == 0 {
 		return
 	}
 
-	vrg.Spec.VolSync.MoverConfig = append([]rmn.MoverConfig(nil), d.instance.Spec.VolSyncSpec.MoverConfig...)
+	vrg.Spec.VolSync.MoverConfig = append([]rmn.MoverConfig(nil), d.spec.VolSyncSpec.MoverConfig...)
 }
 
 func (d *DRPCInstance) ensurePlacement(homeCluster string) error {
@@ -2381,7 +2544,7 @@ func (d *DRPCInstance) newVRG(
 			Namespace: d.vrgNamespace,
 		},
 		Spec: rmn.VolumeReplicationGroupSpec{
-			PVCSelector:      d.instance.Spec.PVCSelector,
+			PVCSelector:      d.spec.PVCSelector,
 			ReplicationState: repState,
 		},
 	}
@@ -2396,9 +2559,13 @@ func (d *DRPCInstance) newVRG(
 func (d *DRPCInstance) newVRGSpecAsync() *rmn.VRGAsyncSpec {
 	return &rmn.VRGAsyncSpec{
 		ReplicationClassSelector:         d.drPolicy.Spec.ReplicationClassSelector,
+		ReplicationClassNames:            d.drPolicy.Spec.ReplicationClassNames,
 		VolumeSnapshotClassSelector:      d.drPolicy.Spec.VolumeSnapshotClassSelector,
 		VolumeGroupSnapshotClassSelector: d.drPolicy.Spec.VolumeGroupSnapshotClassSelector,
 		SchedulingInterval:               d.drPolicy.Spec.SchedulingInterval,
+		BackupWindows:                    d.drPolicy.Spec.BackupWindows,
+		StorageClassSchedulingIntervals:  d.drPolicy.Spec.StorageClassSchedulingIntervals,
+		VolSync:                          d.drPolicy.Spec.VolSync,
 		PeerClasses:                      d.drPolicy.Status.Async.PeerClasses,
 	}
 }
@@ -2525,6 +2692,8 @@ func (d *DRPCInstance) createOrUpdateNSForDiscoveredApps(homeCluster string) err
 	protectedNamespaces := make([]*corev1.Namespace, 0)
 
 	for _, ns := range *d.instance.Spec.ProtectedNamespaces {
+		d.viewSubscribe(homeCluster, corev1.SchemeGroupVersion.WithKind("Namespace"), types.NamespacedName{Name: ns})
+
 		protectedNamespace, err := d.reconciler.MCVGetter.GetNSFromManagedCluster(homeCluster, ns)
 		if err != nil {
 			d.log.Error(err, fmt.Sprintf("error getting namespace %s from managed cluster %s using MCV", ns, homeCluster))
@@ -2933,16 +3102,9 @@ func (d *DRPCInstance) updateManifestWork(clusterName string, vrg *rmn.VolumeRep
 		return fmt.Errorf("%w", err)
 	}
 
-	vrgClientManifest, err := d.mwu.GenerateManifest(vrg)
-	if err != nil {
-		d.log.Error(err, "failed to generate manifest")
-
-		return fmt.Errorf("failed to generate VRG manifest (%w)", err)
-	}
-
-	mw.Spec.Workload.Manifests[0] = *vrgClientManifest
-
-	return d.reconciler.Update(d.ctx, mw)
+	// Updated through the ManifestWork utilities, which hold the update while the cluster is unavailable and patch
+	// only the VRG manifest
+	return d.mwu.UpdateVRGManifestWork(vrg, mw)
 }
 
 func (d *DRPCInstance) setDRState(nextState rmn.DRState) {
@@ -2950,10 +3112,25 @@ func (d *DRPCInstance) setDRState(nextState rmn.DRState) {
 		d.log.Info(fmt.Sprintf("Phase: Current '%s'. Next '%s'",
 			d.instance.Status.Phase, nextState))
 
+		if !statemachine.DRPCAction.Check(d.instance.Status.Phase, nextState) {
+			d.log.Info("Phase transition is not in the DRPlacementControl action state machine",
+				"from", d.instance.Status.Phase, "to", nextState)
+		}
+
 		d.instance.Status.Phase = nextState
 		d.instance.Status.ObservedGeneration = d.instance.Generation
 		d.reportEvent(nextState)
+		d.actionTracker().drpcRecord(d.instance)
+	}
+}
+
+// actionTracker returns the tracker of the state of the action in memory, if any
+func (d *DRPCInstance) actionTracker() *ActionTracker {
+	if d.reconciler == nil {
+		return nil
 	}
+
+	return d.reconciler.ActionTracker
 }
 
 func updateDRPCProgression(
@@ -2970,6 +3147,7 @@ func updateDRPCProgression(
 			decisionFunction,
 			drpc.Status.Progression, nextProgression))
 
+		actionProgressUpdate(drpc, nextProgression, time.Now())
 		drpc.Status.Progression = nextProgression
 
 		return true
@@ -3047,7 +3225,9 @@ specialProgressions are used to indicate special cases irrespective of action or
 	}
 */
 func (d *DRPCInstance) setProgression(nextProgression rmn.ProgressionStatus) {
-	updateDRPCProgression(d.instance, nextProgression, d.log)
+	if updateDRPCProgression(d.instance, nextProgression, d.log) {
+		d.actionTracker().drpcRecord(d.instance)
+	}
 }
 
 func IsPreRelocateProgression(status rmn.ProgressionStatus) bool {
@@ -3243,6 +3423,8 @@ func (d *DRPCInstance) setStatusInitiating() {
 
 	d.instance.Status.ActionStartTime = &metav1.Time{Time: time.Now()}
 	d.instance.Status.ActionDuration = nil
+
+	actionHistoryStart(d.instance, d.instance.Status.ActionStartTime.Time)
 }
 
 func (d *DRPCInstance) setActionDuration() {
@@ -3253,6 +3435,8 @@ func (d *DRPCInstance) setActionDuration() {
 	duration := time.Since(d.instance.Status.ActionStartTime.Time)
 	d.instance.Status.ActionDuration = &metav1.Duration{Duration: duration}
 
+	actionHistoryComplete(d.instance, d.instance.Status.ActionStartTime.Add(duration))
+
 	d.log.Info(fmt.Sprintf("%s transition completed. Started at: %v and it took: %v",
 		fmt.Sprintf("%v", d.instance.Status.Phase), d.instance.Status.ActionStartTime, duration))
 }
@@ -3280,13 +3464,13 @@ func getCallerFunction(ancestorLevel int) string {
 }
 
 func (d *DRPCInstance) isVMRecipeInUse() bool {
-	if d.instance == nil ||
-		d.instance.Spec.KubeObjectProtection == nil ||
-		d.instance.Spec.KubeObjectProtection.RecipeRef == nil {
+	if d.spec == nil ||
+		d.spec.KubeObjectProtection == nil ||
+		d.spec.KubeObjectProtection.RecipeRef == nil {
 		return false
 	}
 
-	if d.instance.Spec.KubeObjectProtection.RecipeRef.Name != recipecore.VMRecipeName {
+	if d.spec.KubeObjectProtection.RecipeRef.Name != recipecore.VMRecipeName {
 		return false
 	}
 
@@ -3458,22 +3642,25 @@ func (d *DRPCInstance) filterSCCAnnotations(annotations map[string]string) (map[
 }
 
 func (d *DRPCInstance) ensureRecipeManifestWork(srcCluster, dstCluster string) error {
-	if d.instance.Spec.KubeObjectProtection == nil ||
-		d.instance.Spec.KubeObjectProtection.RecipeRef == nil {
+	if d.spec.KubeObjectProtection == nil ||
+		d.spec.KubeObjectProtection.RecipeRef == nil {
 		return nil
 	}
 
 	// Built-in VM recipe is embedded in the VRG controller and does not need
 	// to be propagated via ManifestWork.
-	if d.instance.Spec.KubeObjectProtection.RecipeRef.Name == recipecore.VMRecipeName &&
-		d.instance.Spec.KubeObjectProtection.RecipeRef.Namespace == RamenOperandsNamespace(*d.ramenConfig) {
+	if d.spec.KubeObjectProtection.RecipeRef.Name == recipecore.VMRecipeName &&
+		d.spec.KubeObjectProtection.RecipeRef.Namespace == RamenOperandsNamespace(*d.ramenConfig) {
 		return nil
 	}
 
-	recipeName := d.instance.Spec.KubeObjectProtection.RecipeRef.Name
-	recipeNamespace := d.instance.Spec.KubeObjectProtection.RecipeRef.Namespace
+	recipeName := d.spec.KubeObjectProtection.RecipeRef.Name
+	recipeNamespace := d.spec.KubeObjectProtection.RecipeRef.Namespace
 
 	// Always fetch the latest recipe from source cluster
+	d.viewSubscribe(srcCluster, recipev1.GroupVersion.WithKind("Recipe"),
+		types.NamespacedName{Namespace: recipeNamespace, Name: recipeName})
+
 	recipe, err := d.reconciler.MCVGetter.GetRecipeFromManagedCluster(srcCluster, recipeName, recipeNamespace)
 	if err != nil {
 		return fmt.Errorf("failed to get recipe %s/%s in cluster %s: %w", recipeNamespace, recipeName, srcCluster, err)
@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ocmv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// DRPCAutoFailoverInterval is the interval between evaluations of the clusters of the DRPCs with autoFailover
+	DRPCAutoFailoverInterval = 30 * time.Second
+
+	// AutoFailoverAcknowledgedAnnotation on a DRPC whose autoFailover requires acknowledgment acknowledges the failover
+	// from the cluster it is set to
+	AutoFailoverAcknowledgedAnnotation = "drplacementcontrol.ramendr.openshift.io/auto-failover-acknowledged"
+
+	autoFailoverDefaultGracePeriod = 10 * time.Minute
+
+	ReasonAutoFailoverClusterAvailable       = "ClusterAvailable"
+	ReasonAutoFailoverGracePeriod            = "GracePeriod"
+	ReasonAutoFailoverAwaitingAcknowledgment = "AwaitingAcknowledgment"
+	ReasonAutoFailoverBlocked                = "Blocked"
+	ReasonAutoFailoverRequested              = "FailoverRequested"
+)
+
+// DRPCAutoFailover periodically requests the failover of the DRPCs with autoFailover whose workload runs on a
+// ManagedCluster that is unavailable for longer than their grace period, by setting their action to Failover, which
+// the DRPC reconciler then executes as if the user requested it. It is not done by the DRPC reconciler, which is not
+// triggered as the grace period elapses. The progress of each DRPC is reported in its AutoFailover condition.
+type DRPCAutoFailover struct {
+	client.Client
+	Log      logr.Logger
+	Interval time.Duration
+}
+
+// NeedLeaderElection runs the auto failover only on the leader, alongside the hub reconcilers
+func (a *DRPCAutoFailover) NeedLeaderElection() bool {
+	return true
+}
+
+// Start evaluates the DRPCs every interval until ctx is done
+func (a *DRPCAutoFailover) Start(ctx context.Context) error {
+	interval := a.Interval
+	if interval <= 0 {
+		interval = DRPCAutoFailoverInterval
+	}
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := a.evaluate(ctx, time.Now()); err != nil {
+			a.Log.Error(err, "DRPC auto failover evaluation failed")
+		}
+	}, interval)
+
+	return nil
+}
+
+// evaluate requests the failover of the DRPCs with autoFailover that are due one, and updates their AutoFailover
+// condition
+func (a *DRPCAutoFailover) evaluate(ctx context.Context, now time.Time) error {
+	drpcs := &rmn.DRPlacementControlList{}
+	if err := a.List(ctx, drpcs); err != nil {
+		return fmt.Errorf("failed to list DRPCs: %w", err)
+	}
+
+	var errs []error
+
+	for i := range drpcs.Items {
+		drpc := &drpcs.Items[i]
+
+		if drpc.Spec.AutoFailover == nil || rmnutil.ResourceIsDeleted(drpc) || !autoFailoverSettled(drpc) {
+			continue
+		}
+
+		errs = append(errs, a.evaluateDRPC(ctx, drpc, now))
+	}
+
+	return errors.Join(errs...)
+}
+
+// autoFailoverSettled returns whether the last action of drpc completed, hence the cluster in its preferred decision
+// is the cluster the workload runs on
+func autoFailoverSettled(drpc *rmn.DRPlacementControl) bool {
+	return slices.Contains([]rmn.DRState{rmn.Deployed, rmn.FailedOver, rmn.Relocated}, drpc.Status.Phase) &&
+		drpc.Status.Progression == rmn.ProgressionCompleted &&
+		drpc.Status.PreferredDecision.ClusterName != ""
+}
+
+func (a *DRPCAutoFailover) evaluateDRPC(ctx context.Context, drpc *rmn.DRPlacementControl, now time.Time) error {
+	log := a.Log.WithValues("drpc", client.ObjectKeyFromObject(drpc))
+
+	condition, failoverCluster := a.autoFailoverDecide(ctx, drpc, now)
+	if condition == nil {
+		return nil
+	}
+
+	if failoverCluster != "" {
+		if err := a.failoverRequest(ctx, drpc, failoverCluster); err != nil {
+			return err
+		}
+
+		log.Info("Requested auto failover", "from", drpc.Status.PreferredDecision.ClusterName, "to", failoverCluster)
+	}
+
+	patch := client.MergeFromWithOptions(drpc.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	if !addOrUpdateCondition(&drpc.Status.Conditions, rmn.ConditionAutoFailover, drpc.Generation,
+		condition.Status, condition.Reason, condition.Message) {
+		return nil
+	}
+
+	if err := a.Status().Patch(ctx, drpc, patch); err != nil {
+		return fmt.Errorf("failed to update auto failover condition of DRPC %s: %w",
+			client.ObjectKeyFromObject(drpc), err)
+	}
+
+	return nil
+}
+
+// failoverRequest sets the action of drpc to Failover to failoverCluster, as the user would
+func (a *DRPCAutoFailover) failoverRequest(ctx context.Context, drpc *rmn.DRPlacementControl,
+	failoverCluster string,
+) error {
+	patch := client.MergeFromWithOptions(drpc.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	drpc.Spec.Action = rmn.ActionFailover
+	drpc.Spec.FailoverCluster = failoverCluster
+	rmnutil.AddAnnotation(drpc, ActionRequestedByAnnotation, "AutoFailover")
+
+	if err := a.Patch(ctx, drpc, patch); err != nil {
+		return fmt.Errorf("failed to request auto failover of DRPC %s: %w", client.ObjectKeyFromObject(drpc), err)
+	}
+
+	return nil
+}
+
+// autoFailoverDecide returns the AutoFailover condition of drpc, or nil if it is to be left as is, and the cluster
+// to fail the workload over to, if its failover is due
+//
+//nolint:cyclop,funlen
+func (a *DRPCAutoFailover) autoFailoverDecide(ctx context.Context, drpc *rmn.DRPlacementControl, now time.Time,
+) (*metav1.Condition, string) {
+	cluster := drpc.Status.PreferredDecision.ClusterName
+	condition := func(status metav1.ConditionStatus, reason, message string) *metav1.Condition {
+		return &metav1.Condition{Status: status, Reason: reason, Message: message}
+	}
+
+	unavailableSince, err := a.managedClusterUnavailableSince(ctx, cluster)
+	if err != nil {
+		return condition(metav1.ConditionFalse, ReasonAutoFailoverBlocked, err.Error()), ""
+	}
+
+	if unavailableSince == nil {
+		// the condition of the last auto failover is retained while the workload remains failed over
+		if meta.IsStatusConditionTrue(drpc.Status.Conditions, rmn.ConditionAutoFailover) &&
+			drpc.Spec.Action == rmn.ActionFailover && drpcFailoverCluster(drpc) == cluster {
+			return nil, ""
+		}
+
+		return condition(metav1.ConditionFalse, ReasonAutoFailoverClusterAvailable,
+			fmt.Sprintf("Cluster %s is available", cluster)), ""
+	}
+
+	gracePeriod := autoFailoverDefaultGracePeriod
+	if drpc.Spec.AutoFailover.GracePeriod != nil {
+		gracePeriod = drpc.Spec.AutoFailover.GracePeriod.Duration
+	}
+
+	if due := unavailableSince.Add(gracePeriod); now.Before(due) {
+		return condition(metav1.ConditionFalse, ReasonAutoFailoverGracePeriod,
+			fmt.Sprintf("Cluster %s is unavailable since %s, failover is due at %s", cluster,
+				unavailableSince.UTC().Format(time.RFC3339), due.UTC().Format(time.RFC3339))), ""
+	}
+
+	failoverCluster, err := a.autoFailoverInterlocks(ctx, drpc, cluster)
+	if err != nil {
+		return condition(metav1.ConditionFalse, ReasonAutoFailoverBlocked, err.Error()), ""
+	}
+
+	if drpc.Spec.AutoFailover.RequireAcknowledgment &&
+		drpc.GetAnnotations()[AutoFailoverAcknowledgedAnnotation] != cluster {
+		return condition(metav1.ConditionFalse, ReasonAutoFailoverAwaitingAcknowledgment,
+			fmt.Sprintf("Cluster %s is unavailable, annotate the DRPC with %s=%s to fail over to %s", cluster,
+				AutoFailoverAcknowledgedAnnotation, cluster, failoverCluster)), ""
+	}
+
+	return condition(metav1.ConditionTrue, ReasonAutoFailoverRequested,
+		fmt.Sprintf("Failover to %s requested as cluster %s was unavailable since %s", failoverCluster, cluster,
+			unavailableSince.UTC().Format(time.RFC3339))), failoverCluster
+}
+
+// managedClusterUnavailableSince returns the time the ManagedCluster became unavailable, or nil if it is available
+func (a *DRPCAutoFailover) managedClusterUnavailableSince(ctx context.Context, cluster string) (*time.Time, error) {
+	mc := &ocmv1.ManagedCluster{}
+	if err := a.Get(ctx, types.NamespacedName{Name: cluster}, mc); err != nil {
+		return nil, fmt.Errorf("failed to get ManagedCluster %s: %w", cluster, err)
+	}
+
+	if rmnutil.ManagedClusterObjectAvailable(mc) {
+		return nil, nil
+	}
+
+	since := meta.FindStatusCondition(mc.Status.Conditions, ocmv1.ManagedClusterConditionAvailable).
+		LastTransitionTime.Time
+
+	return &since, nil
+}
+
+// autoFailoverInterlocks returns the cluster to fail drpc over to from cluster, or an error if the failover is not
+// to be requested automatically: the failover cluster is not available, or the DRPolicy replicates synchronously and
+// neither fences cluster automatically nor was cluster fenced, as the workload could otherwise run on both clusters
+func (a *DRPCAutoFailover) autoFailoverInterlocks(ctx context.Context, drpc *rmn.DRPlacementControl,
+	cluster string,
+) (string, error) {
+	drPolicy, err := GetDRPolicy(ctx, a.Client, drpc, a.Log)
+	if err != nil {
+		return "", fmt.Errorf("failed to get DRPolicy: %w", err)
+	}
+
+	failoverCluster := drpc.Spec.AutoFailover.FailoverCluster
+	if failoverCluster == "" {
+		peers := slices.DeleteFunc(slices.Clone(rmnutil.DRPolicyClusterNames(drPolicy)),
+			func(name string) bool { return name == cluster })
+		if len(peers) != 1 {
+			return "", fmt.Errorf("failoverCluster is required, DRPolicy %s has %d clusters other than %s",
+				drPolicy.Name, len(peers), cluster)
+		}
+
+		failoverCluster = peers[0]
+	}
+
+	if failoverCluster == cluster {
+		return "", fmt.Errorf("failoverCluster %s is the unavailable cluster", failoverCluster)
+	}
+
+	if !rmnutil.ManagedClusterAvailable(ctx, a.Client, failoverCluster) {
+		return "", fmt.Errorf("failover cluster %s is unavailable too", failoverCluster)
+	}
+
+	sync, _, err := dRPolicySupportsMetro(drPolicy, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if DRPolicy %s supports Metro: %w", drPolicy.Name, err)
+	}
+
+	if !sync || drPolicyFailoverFencingPreference(drPolicy, sync) == rmn.FencingPreferenceAutomatic {
+		return failoverCluster, nil
+	}
+
+	drCluster := &rmn.DRCluster{}
+	if err := a.Get(ctx, types.NamespacedName{Name: cluster}, drCluster); err != nil {
+		return "", fmt.Errorf("failed to get DRCluster %s: %w", cluster, err)
+	}
+
+	if drCluster.Status.Phase != rmn.Fenced {
+		return "", fmt.Errorf("cluster %s is to be fenced before the failover, as DRPolicy %s replicates "+
+			"synchronously and does not fence it automatically", cluster, drPolicy.Name)
+	}
+
+	return failoverCluster, nil
+}
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ocmv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPCAutoFailover", func() {
+	var (
+		fakeClient   client.Client
+		autoFailover *DRPCAutoFailover
+		now          time.Time
+	)
+
+	managedCluster := func(name string, available metav1.ConditionStatus, since time.Time) *ocmv1.ManagedCluster {
+		return &ocmv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: ocmv1.ManagedClusterStatus{Conditions: []metav1.Condition{{
+				Type:               ocmv1.ManagedClusterConditionAvailable,
+				Status:             available,
+				LastTransitionTime: metav1.NewTime(since),
+				Reason:             "Test",
+			}}},
+		}
+	}
+
+	drpc := func() *rmn.DRPlacementControl {
+		drpc := &rmn.DRPlacementControl{}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: "app", Name: "drpc"}, drpc)).To(Succeed())
+
+		return drpc
+	}
+
+	autoFailoverCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(drpc().Status.Conditions, rmn.ConditionAutoFailover)
+	}
+
+	build := func(schedulingInterval string, eastAvailable metav1.ConditionStatus, objects ...client.Object) {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmv1.AddToScheme(scheme)).To(Succeed())
+
+		objects = append(objects,
+			&rmn.DRPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+				Spec:       rmn.DRPolicySpec{DRClusters: []string{"east", "west"}, SchedulingInterval: schedulingInterval},
+			},
+			managedCluster("east", eastAvailable, now.Add(-time.Hour)),
+			managedCluster("west", metav1.ConditionTrue, now.Add(-time.Hour)),
+			&rmn.DRPlacementControl{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc"},
+				Spec: rmn.DRPlacementControlSpec{
+					DRPolicyRef:      corev1.ObjectReference{Name: "policy"},
+					PreferredCluster: "east",
+					AutoFailover:     &rmn.AutoFailoverSpec{GracePeriod: &metav1.Duration{Duration: 5 * time.Minute}},
+				},
+				Status: rmn.DRPlacementControlStatus{
+					Phase:             rmn.Deployed,
+					Progression:       rmn.ProgressionCompleted,
+					PreferredDecision: rmn.PlacementDecision{ClusterName: "east"},
+				},
+			},
+		)
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+			WithStatusSubresource(&rmn.DRPlacementControl{}).Build()
+		autoFailover = &DRPCAutoFailover{Client: fakeClient, Log: logr.Discard()}
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+	})
+
+	It("reports the cluster of the workload available", func() {
+		build("5m", metav1.ConditionTrue)
+
+		Expect(autoFailover.evaluate(context.TODO(), now)).To(Succeed())
+		Expect(drpc().Spec.Action).To(BeEmpty())
+		Expect(autoFailoverCondition().Reason).To(Equal(ReasonAutoFailoverClusterAvailable))
+	})
+
+	It("waits for the grace period the cluster of the workload is unavailable for", func() {
+		build("5m", metav1.ConditionUnknown)
+
+		Expect(autoFailover.evaluate(context.TODO(), now.Add(-58*time.Minute))).To(Succeed())
+		Expect(drpc().Spec.Action).To(BeEmpty())
+		Expect(autoFailoverCondition().Reason).To(Equal(ReasonAutoFailoverGracePeriod))
+	})
+
+	It("requests the failover to the other cluster once the grace period elapsed", func() {
+		build("5m", metav1.ConditionFalse)
+
+		Expect(autoFailover.evaluate(context.TODO(), now)).To(Succeed())
+		Expect(drpc().Spec.Action).To(Equal(rmn.ActionFailover))
+		Expect(drpc().Spec.FailoverCluster).To(Equal("west"))
+
+		condition := autoFailoverCondition()
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(ReasonAutoFailoverRequested))
+	})
+
+	It("does not evaluate a DRPC whose action is in progress", func() {
+		build("5m", metav1.ConditionFalse)
+
+		inProgress := drpc()
+		inProgress.Status.Progression = rmn.ProgressionFailingOverToCluster
+		Expect(fakeClient.Status().Update(context.TODO(), inProgress)).To(Succeed())
+
+		Expect(autoFailover.evaluate(context.TODO(), now)).To(Succeed())
+		Expect(drpc().Spec.Action).To(BeEmpty())
+		Expect(autoFailoverCondition()).To(BeNil())
+	})
+
+	It("waits for the acknowledgment of the failover from the unavailable cluster, if required", func() {
+		build("5m", metav1.ConditionFalse)
+
+		acknowledged := drpc()
+		acknowledged.Spec.AutoFailover.RequireAcknowledgment = true
+		acknowledged.Annotations = map[string]string{AutoFailoverAcknowledgedAnnotation: "west"}
+		Expect(fakeClient.Update(context.TODO(), acknowledged)).To(Succeed())
+
+		Expect(autoFailover.evaluate(context.TODO(), now)).To(Succeed())
+		Expect(drpc().Spec.Action).To(BeEmpty())
+		Expect(autoFailoverCondition().Reason).To(Equal(ReasonAutoFailoverAwaitingAcknowledgment))
+
+		acknowledged = drpc()
+		acknowledged.Annotations[AutoFailoverAcknowledgedAnnotation] = "east"
+		Expect(fakeClient.Update(context.TODO(), acknowledged)).To(Succeed())
+
+		Expect(autoFailover.evaluate(context.TODO(), now)).To(Succeed())
+		Expect(drpc().Spec.Action).To(Equal(rmn.ActionFailover))
+	})
+
+	It("does not fail over to a failover cluster that is unavailable too", func() {
+		build("5m", metav1.ConditionFalse)
+
+		west := &ocmv1.ManagedCluster{}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Name: "west"}, west)).To(Succeed())
+		west.Status.Conditions[0].Status = metav1.ConditionFalse
+		Expect(fakeClient.Update(context.TODO(), west)).To(Succeed())
+
+		Expect(autoFailover.evaluate(context.TODO(), now)).To(Succeed())
+		Expect(drpc().Spec.Action).To(BeEmpty())
+		Expect(autoFailoverCondition().Reason).To(Equal(ReasonAutoFailoverBlocked))
+	})
+
+	It("requires the cluster of a workload of synchronous replication to be fenced", func() {
+		drCluster := &rmn.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "east"}}
+		build("", metav1.ConditionFalse, drCluster)
+
+		Expect(autoFailover.evaluate(context.TODO(), now)).To(Succeed())
+		Expect(drpc().Spec.Action).To(BeEmpty())
+		Expect(autoFailoverCondition().Reason).To(Equal(ReasonAutoFailoverBlocked))
+
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Name: "east"}, drCluster)).To(Succeed())
+		drCluster.Status.Phase = rmn.Fenced
+		Expect(fakeClient.Update(context.TODO(), drCluster)).To(Succeed())
+
+		Expect(autoFailover.evaluate(context.TODO(), now)).To(Succeed())
+		Expect(drpc().Spec.Action).To(Equal(rmn.ActionFailover))
+	})
+
+	It("fails a workload of synchronous replication over if its DRPolicy fences the cluster automatically", func() {
+		build("", metav1.ConditionFalse)
+
+		drPolicy := &rmn.DRPolicy{}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Name: "policy"}, drPolicy)).To(Succeed())
+		drPolicy.Spec.Fencing = rmn.FencingPreferenceAutomatic
+		Expect(fakeClient.Update(context.TODO(), drPolicy)).To(Succeed())
+
+		Expect(autoFailover.evaluate(context.TODO(), now)).To(Succeed())
+		Expect(drpc().Spec.FailoverCluster).To(Equal("west"))
+	})
+})
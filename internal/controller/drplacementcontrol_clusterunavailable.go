@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ocmv1 "open-cluster-management.io/api/cluster/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+// clusterUnavailableRequeueDelay is the delay after which ManifestWork operations held for an unavailable cluster
+// are retried, should the event of the cluster becoming available again be missed
+const clusterUnavailableRequeueDelay = time.Minute
+
+// updateClusterUnavailableCondition records ManifestWork operations held back for an unavailable cluster in
+// the ClusterUnavailable condition, and returns true if processing is held. Once processing is no longer held,
+// the condition is flipped to false to record that the held operations were reapplied.
+func (d *DRPCInstance) updateClusterUnavailableCondition(processingErr error) bool {
+	var clusterUnavailableErr rmnutil.ClusterUnavailableError
+
+	if errors.As(processingErr, &clusterUnavailableErr) {
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionClusterUnavailable, d.instance.Generation,
+			metav1.ConditionTrue, ReasonManifestWorkHeld,
+			"ManifestWork operations held until cluster "+clusterUnavailableErr.Cluster+" is available")
+
+		return true
+	}
+
+	if meta.IsStatusConditionTrue(d.instance.Status.Conditions, rmn.ConditionClusterUnavailable) &&
+		processingErr == nil {
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionClusterUnavailable, d.instance.Generation,
+			metav1.ConditionFalse, ReasonManifestWorkReapplied, "Held ManifestWork operations reapplied")
+	}
+
+	return false
+}
+
+// updateAgentUnavailableCondition warns, using the AgentUnavailable condition, if the klusterlet agents of cluster
+// appear to be unavailable, as ManifestWork changes for the cluster would silently stall, suggesting another cluster
+// of the DRPolicy, other than the current one, whose agents are available. A previously raised warning is cleared
+// once the agents are available.
+func (d *DRPCInstance) updateAgentUnavailableCondition(cluster string) {
+	reason := rmnutil.ManagedClusterAgentUnavailable(d.ctx, d.reconciler.APIReader, cluster)
+	if reason != "" {
+		candidates := []string{}
+
+		if d.drPolicy != nil {
+			candidates = slices.DeleteFunc(slices.Clone(rmnutil.DRPolicyClusterNames(d.drPolicy)),
+				func(candidate string) bool {
+					return candidate == cluster || candidate == d.instance.Status.PreferredDecision.ClusterName
+				})
+		}
+
+		alternate := rmnutil.ManagedClusterAgentAvailableAlternate(d.ctx, d.reconciler.APIReader, candidates)
+
+		d.log.Info("Klusterlet agents appear unavailable", "cluster", cluster, "reason", reason, "alternate", alternate)
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionAgentUnavailable, d.instance.Generation,
+			metav1.ConditionTrue, ReasonAgentUnavailable, agentUnavailableMessage(reason, alternate))
+
+		return
+	}
+
+	if meta.IsStatusConditionTrue(d.instance.Status.Conditions, rmn.ConditionAgentUnavailable) {
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionAgentUnavailable, d.instance.Generation,
+			metav1.ConditionFalse, ReasonAgentAvailable, "Klusterlet agents of cluster "+cluster+" are available")
+	}
+}
+
+// agentUnavailableMessage returns the reason the klusterlet agents of a cluster are unavailable, with the alternate
+// cluster whose agents are available, if any
+func agentUnavailableMessage(reason, alternate string) string {
+	if alternate == "" {
+		return reason + "; no alternate cluster with available agents"
+	}
+
+	return reason + "; cluster " + alternate + " is a candidate alternate with available agents"
+}
+
+// updateVRGViewUnavailableCondition reports, using the VRGViewUnavailable condition, why the VRG could not be read
+// from the view of a cluster, as reported by the first such cluster in name order. The condition is removed once the
+// VRG is read from the views of all clusters, so that it is present only while a view cannot be read.
+func (d *DRPCInstance) updateVRGViewUnavailableCondition() {
+	clusters := slices.Sorted(maps.Keys(d.vrgViewErrs))
+	if len(clusters) != 0 {
+		reason, message := vrgViewErrorCondition(clusters[0], d.vrgViewErrs[clusters[0]])
+		if len(clusters) > 1 {
+			message += fmt.Sprintf("; VRG views from clusters %v cannot be read either", clusters[1:])
+		}
+
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionVRGViewUnavailable, d.instance.Generation,
+			metav1.ConditionTrue, reason, message)
+
+		return
+	}
+
+	meta.RemoveStatusCondition(&d.instance.Status.Conditions, rmn.ConditionVRGViewUnavailable)
+}
+
+// vrgViewErrorCondition returns the reason and message of the VRGViewUnavailable condition for the error reading
+// the VRG view of cluster
+func vrgViewErrorCondition(cluster string, err error) (string, string) {
+	switch {
+	case rmnutil.IsMCVForbidden(err):
+		return ReasonVRGViewForbidden, fmt.Sprintf("not permitted to read the VRG from cluster %s: %v", cluster, err)
+	case rmnutil.IsMCVClusterUnreachable(err):
+		return ReasonVRGViewClusterUnreachable,
+			fmt.Sprintf("cluster %s is unreachable, the VRG view cannot refresh", cluster)
+	case rmnutil.IsMCVProcessing(err):
+		return ReasonVRGViewProcessing, fmt.Sprintf("waiting for the VRG view from cluster %s", cluster)
+	}
+
+	if age, stale := rmnutil.IsMCVStale(err); stale {
+		return ReasonVRGViewStale, fmt.Sprintf("VRG view from cluster %s is stale for %v", cluster,
+			age.Round(time.Second))
+	}
+
+	return ReasonVRGViewFailed, fmt.Sprintf("failed to read the VRG from cluster %s: %v", cluster, err)
+}
+
+// ManagedClusterPredicateFunc filters for ManagedCluster updates where the cluster became available again
+func ManagedClusterPredicateFunc() predicate.Funcs {
+	log := ctrl.Log.WithName("DRPCPredicate").WithName("ManagedCluster")
+
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			mcOld, ok := e.ObjectOld.(*ocmv1.ManagedCluster)
+			if !ok {
+				return false
+			}
+
+			mcNew, ok := e.ObjectNew.(*ocmv1.ManagedCluster)
+			if !ok {
+				return false
+			}
+
+			available := !rmnutil.ManagedClusterObjectAvailable(mcOld) && rmnutil.ManagedClusterObjectAvailable(mcNew)
+			if available {
+				log.Info("ManagedCluster available again", "name", mcNew.GetName())
+			}
+
+			return available
+		},
+	}
+}
+
+// FilterManagedCluster returns DRPCs with ManifestWork operations held for the cluster that is available again
+func (r *DRPlacementControlReconciler) FilterManagedCluster(mc *ocmv1.ManagedCluster) []ctrl.Request {
+	log := ctrl.Log.WithName("DRPCFilter").WithName("ManagedCluster").WithValues("cluster", mc.GetName())
+
+	drpcCollections, err := DRPCsUsingDRCluster(r.Client, log, &rmn.DRCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: mc.GetName()},
+	})
+	if err != nil {
+		log.Info("Failed to process filter")
+
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+
+	for idx := range drpcCollections {
+		drpc := drpcCollections[idx].drpc
+
+		if !meta.IsStatusConditionTrue(drpc.Status.Conditions, rmn.ConditionClusterUnavailable) {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: drpc.GetName(), Namespace: drpc.GetNamespace()},
+		})
+	}
+
+	return requests
+}
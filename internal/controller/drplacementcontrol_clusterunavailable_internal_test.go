@@ -0,0 +1,306 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ocmv1 "open-cluster-management.io/api/cluster/v1"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("DRPC klusterlet agents", func() {
+	var objects []client.Object
+
+	managedCluster := func(name string, available metav1.ConditionStatus) *ocmv1.ManagedCluster {
+		return &ocmv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: ocmv1.ManagedClusterStatus{Conditions: []metav1.Condition{{
+				Type: ocmv1.ManagedClusterConditionAvailable, Status: available, Message: "lease not updated",
+			}}},
+		}
+	}
+
+	instance := func() *DRPCInstance {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmv1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+		return &DRPCInstance{
+			reconciler: &DRPlacementControlReconciler{Client: fakeClient, APIReader: fakeClient},
+			ctx:        context.TODO(),
+			log:        logr.Discard(),
+			instance: &rmn.DRPlacementControl{
+				Spec: rmn.DRPlacementControlSpec{Action: rmn.ActionFailover, FailoverCluster: "west"},
+				Status: rmn.DRPlacementControlStatus{
+					PreferredDecision: rmn.PlacementDecision{ClusterName: "east"},
+				},
+			},
+			drPolicy: &rmn.DRPolicy{Spec: rmn.DRPolicySpec{DRClusters: []string{"east", "west", "north"}}},
+		}
+	}
+
+	BeforeEach(func() {
+		objects = []client.Object{
+			managedCluster("east", metav1.ConditionTrue),
+			managedCluster("west", metav1.ConditionFalse),
+			managedCluster("north", metav1.ConditionTrue),
+		}
+	})
+
+	It("warns that the agents of the failover cluster are unavailable, suggesting another cluster", func() {
+		d := instance()
+		d.updateAgentUnavailableCondition("west")
+
+		condition := meta.FindStatusCondition(d.instance.Status.Conditions, rmn.ConditionAgentUnavailable)
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(And(
+			ContainSubstring("cluster west is not available"),
+			ContainSubstring("cluster north is a candidate alternate"),
+		))
+	})
+
+	It("does not suggest the cluster the workload is failing over from", func() {
+		objects[2] = managedCluster("north", metav1.ConditionFalse)
+
+		d := instance()
+		d.updateAgentUnavailableCondition("west")
+
+		condition := meta.FindStatusCondition(d.instance.Status.Conditions, rmn.ConditionAgentUnavailable)
+		Expect(condition.Message).To(HaveSuffix("no alternate cluster with available agents"))
+	})
+
+	It("clears the warning once the agents are available", func() {
+		d := instance()
+		d.updateAgentUnavailableCondition("west")
+		d.updateAgentUnavailableCondition("north")
+
+		condition := meta.FindStatusCondition(d.instance.Status.Conditions, rmn.ConditionAgentUnavailable)
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(ReasonAgentAvailable))
+	})
+})
+
+var _ = Describe("DRPC ManifestWork operations held for an unavailable cluster", func() {
+	heldErr := fmt.Errorf("failed to create VRG ManifestWork: %w", rmnutil.ClusterUnavailableError{Cluster: "west"})
+
+	managedCluster := func(status metav1.ConditionStatus) *ocmv1.ManagedCluster {
+		return &ocmv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "west"},
+			Status: ocmv1.ManagedClusterStatus{Conditions: []metav1.Condition{{
+				Type: ocmv1.ManagedClusterConditionAvailable, Status: status,
+			}}},
+		}
+	}
+
+	It("are held, and reported reapplied once processing succeeds again", func() {
+		d := &DRPCInstance{instance: &rmn.DRPlacementControl{}}
+		condition := func() *metav1.Condition {
+			return meta.FindStatusCondition(d.instance.Status.Conditions, rmn.ConditionClusterUnavailable)
+		}
+
+		Expect(d.updateClusterUnavailableCondition(nil)).To(BeFalse())
+		Expect(condition()).To(BeNil())
+
+		Expect(d.updateClusterUnavailableCondition(heldErr)).To(BeTrue())
+		Expect(condition().Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition().Message).To(ContainSubstring("until cluster west is available"))
+
+		Expect(d.updateClusterUnavailableCondition(errors.New("VRG not ready"))).To(BeFalse())
+		Expect(condition().Status).To(Equal(metav1.ConditionTrue))
+
+		Expect(d.updateClusterUnavailableCondition(nil)).To(BeFalse())
+		Expect(condition().Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition().Reason).To(Equal(ReasonManifestWorkReapplied))
+	})
+
+	It("are reapplied as the cluster becomes available again, including from Unknown", func() {
+		predicate := ManagedClusterPredicateFunc()
+
+		Expect(predicate.Update(event.UpdateEvent{
+			ObjectOld: managedCluster(metav1.ConditionUnknown), ObjectNew: managedCluster(metav1.ConditionTrue),
+		})).To(BeTrue())
+		Expect(predicate.Update(event.UpdateEvent{
+			ObjectOld: managedCluster(metav1.ConditionTrue), ObjectNew: managedCluster(metav1.ConditionUnknown),
+		})).To(BeFalse())
+		Expect(predicate.Update(event.UpdateEvent{
+			ObjectOld: managedCluster(metav1.ConditionTrue), ObjectNew: managedCluster(metav1.ConditionTrue),
+		})).To(BeFalse())
+	})
+
+	It("include the VRG updates of an action, which are applied once the cluster is available again", func() {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmv1.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmworkv1.AddToScheme(scheme)).To(Succeed())
+
+		vrg := &rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc"},
+			Spec:       rmn.VolumeReplicationGroupSpec{ReplicationState: rmn.Primary},
+		}
+		manifest, err := (&rmnutil.MWUtil{}).GenerateManifest(vrg)
+		Expect(err).ToNot(HaveOccurred())
+
+		cluster := managedCluster(metav1.ConditionUnknown)
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			cluster,
+			&ocmworkv1.ManifestWork{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "west", Name: rmnutil.ManifestWorkName("drpc", "app", "vrg")},
+				Spec: ocmworkv1.ManifestWorkSpec{
+					Workload: ocmworkv1.ManifestsTemplate{Manifests: []ocmworkv1.Manifest{*manifest}},
+				},
+			},
+		).Build()
+
+		d := &DRPCInstance{
+			ctx: context.TODO(),
+			log: logr.Discard(),
+			mwu: rmnutil.MWUtil{
+				Client: fakeClient, APIReader: fakeClient, Ctx: context.TODO(), Log: logr.Discard(),
+				InstName: "drpc", TargetNamespace: "app",
+			},
+		}
+
+		vrg.Spec.RunFinalSync = true
+		Expect(errors.As(d.updateManifestWork("west", vrg), &rmnutil.ClusterUnavailableError{})).To(BeTrue())
+
+		found, err := d.getVRGFromManifestWork("west")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found.Spec.RunFinalSync).To(BeFalse())
+
+		cluster.Status.Conditions[0].Status = metav1.ConditionTrue
+		Expect(fakeClient.Update(context.TODO(), cluster)).To(Succeed())
+		Expect(d.updateManifestWork("west", vrg)).To(Succeed())
+
+		found, err = d.getVRGFromManifestWork("west")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found.Spec.RunFinalSync).To(BeTrue())
+	})
+
+	It("are reapplied only for the DRPCs holding them", func() {
+		drpc := func(name string, held metav1.ConditionStatus) *rmn.DRPlacementControl {
+			return &rmn.DRPlacementControl{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: name},
+				Spec:       rmn.DRPlacementControlSpec{DRPolicyRef: corev1.ObjectReference{Name: "policy"}},
+				Status: rmn.DRPlacementControlStatus{Conditions: []metav1.Condition{{
+					Type: rmn.ConditionClusterUnavailable, Status: held,
+				}}},
+			}
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+
+		r := &DRPlacementControlReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&rmn.DRPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+				Spec:       rmn.DRPolicySpec{DRClusters: []string{"east", "west"}},
+			},
+			drpc("held", metav1.ConditionTrue),
+			drpc("reapplied", metav1.ConditionFalse),
+		).Build()}
+
+		Expect(r.FilterManagedCluster(managedCluster(metav1.ConditionTrue))).To(Equal([]reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: "app", Name: "held"}},
+		}))
+	})
+})
+
+// vrgViewErrGetter returns the errors of the VRG views of the managed clusters from a map, by cluster, and a not
+// found error for the other clusters
+type vrgViewErrGetter struct {
+	rmnutil.ManagedClusterViewGetter
+	errs map[string]error
+}
+
+func (g vrgViewErrGetter) GetVRGFromManagedCluster(name, _, managedCluster string, _ map[string]string,
+) (*rmn.VolumeReplicationGroup, error) {
+	if err, ok := g.errs[managedCluster]; ok {
+		return nil, err
+	}
+
+	return nil, k8serrors.NewNotFound(schema.GroupResource{}, name)
+}
+
+var _ = Describe("DRPC VRG views", func() {
+	viewErr := func(reason rmnutil.MCVErrorReason) error {
+		return fmt.Errorf("failed to get VRG: %w", &rmnutil.MCVError{
+			Reason: reason, Age: 3 * time.Minute, Err: errors.New("view condition"),
+		})
+	}
+
+	condition := func(d *DRPCInstance) *metav1.Condition {
+		return meta.FindStatusCondition(d.instance.Status.Conditions, rmn.ConditionVRGViewUnavailable)
+	}
+
+	DescribeTable("report why the VRG cannot be read from a cluster",
+		func(reason rmnutil.MCVErrorReason, conditionReason, message string) {
+			d := &DRPCInstance{
+				instance:    &rmn.DRPlacementControl{},
+				vrgViewErrs: map[string]error{"west": viewErr(reason)},
+			}
+			d.updateVRGViewUnavailableCondition()
+
+			Expect(condition(d).Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition(d).Reason).To(Equal(conditionReason))
+			Expect(condition(d).Message).To(ContainSubstring(message))
+		},
+		Entry("processing", rmnutil.MCVErrorReasonProcessing, ReasonVRGViewProcessing, "waiting for the VRG view"),
+		Entry("stale", rmnutil.MCVErrorReasonStale, ReasonVRGViewStale, "stale for 3m0s"),
+		Entry("forbidden", rmnutil.MCVErrorReasonForbidden, ReasonVRGViewForbidden, "not permitted"),
+		Entry("cluster unreachable", rmnutil.MCVErrorReasonClusterUnreachable, ReasonVRGViewClusterUnreachable,
+			"cluster west is unreachable"),
+		Entry("other", rmnutil.MCVErrorReason("Unknown"), ReasonVRGViewFailed, "failed to read the VRG"),
+	)
+
+	It("records the view errors of the clusters the VRG cannot be read from, and clears them once read", func() {
+		drClusters := []rmn.DRCluster{
+			{ObjectMeta: metav1.ObjectMeta{Name: "east"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "west"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "north"}},
+		}
+		getter := vrgViewErrGetter{errs: map[string]error{
+			"west":  viewErr(rmnutil.MCVErrorReasonClusterUnreachable),
+			"north": viewErr(rmnutil.MCVErrorReasonStale),
+		}}
+		d := &DRPCInstance{instance: &rmn.DRPlacementControl{}, vrgViewErrs: map[string]error{}}
+
+		_, queried, _, err := getVRGsFromManagedClusters(getter, d.instance, drClusters, "app", logr.Discard(),
+			d.vrgViewErrs)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(queried).To(Equal(1))
+		Expect(d.vrgViewErrs).To(HaveLen(2))
+
+		d.updateVRGViewUnavailableCondition()
+		Expect(condition(d).Reason).To(Equal(ReasonVRGViewStale))
+		Expect(condition(d).Message).To(HaveSuffix("VRG views from clusters [west] cannot be read either"))
+
+		d.vrgViewErrs = map[string]error{}
+		d.updateVRGViewUnavailableCondition()
+		Expect(condition(d)).To(BeNil())
+	})
+})
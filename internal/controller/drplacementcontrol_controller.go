@@ -63,6 +63,11 @@ const (
 	DoNotDeletePVCAnnotation    = "drplacementcontrol.ramendr.openshift.io/do-not-delete-pvc"
 	DoNotDeletePVCAnnotationVal = "true"
 
+	// SkipFinalizationHooksAnnotation on a DRPC skips its finalization hooks when it is deleted, for example when
+	// the hooks cannot succeed and waiting for their timeout is not desired
+	SkipFinalizationHooksAnnotation    = "drplacementcontrol.ramendr.openshift.io/skip-finalization-hooks"
+	SkipFinalizationHooksAnnotationVal = "true"
+
 	IsSubmarinerEnabledAnnotation    = "drplacementcontrol.ramendr.openshift.io/is-submariner-enabled"
 	IsSubmarinerEnabledAnnotationVal = "true"
 )
@@ -89,7 +94,10 @@ type DRPlacementControlReconciler struct {
 	savedInstanceStatus            rmn.DRPlacementControlStatus
 	ObjStoreGetter                 ObjectStoreGetter
 	RateLimiter                    *workqueue.TypedRateLimiter[reconcile.Request]
+	ViewIntervals                  *rmnutil.ClusterViewIntervals
+	ViewSubscriptions              *rmnutil.ManagedClusterViewSubscriptions
 	numClustersQueriedSuccessfully int
+	ActionTracker                  *ActionTracker
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -104,16 +112,20 @@ func (r *DRPlacementControlReconciler) SetupWithManager(mgr ctrl.Manager, ramenC
 // +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrols/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrols/finalizers,verbs=update
 // +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drpolicies,verbs=get;list;watch
-// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontroltemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclusters,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=apps.open-cluster-management.io,resources=placementrules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.open-cluster-management.io,resources=placementrules/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.open-cluster-management.io,resources=placementrules/finalizers,verbs=get;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get
+// +kubebuilder:rbac:groups=addon.open-cluster-management.io,resources=managedclusteraddons,verbs=get
 // +kubebuilder:rbac:groups=work.open-cluster-management.io,resources=manifestworks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=view.open-cluster-management.io,resources=managedclusterviews,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy.open-cluster-management.io,resources=policies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy.open-cluster-management.io,resources=placementbindings,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=events,verbs=get;create;patch;update
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;create;patch;update
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;update
 // +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=placementdecisions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=placementdecisions/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=placements,verbs=get;list;watch;update
@@ -137,6 +149,7 @@ func (r *DRPlacementControlReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	logger.Info("Entering reconcile loop")
 	defer logger.Info("Exiting reconcile loop")
+	defer r.ActionTracker.reconcileStart()()
 
 	drpc := &rmn.DRPlacementControl{}
 
@@ -156,7 +169,11 @@ func (r *DRPlacementControlReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	ensureDRPCConditionsInited(&drpc.Status.Conditions, drpc.Generation, "Initialization")
 
+	stageDone := timeReconcileStage(reconcileStageDRPC, stageConfigGet)
 	_, ramenConfig, err := ConfigMapGet(ctx, r.APIReader)
+
+	stageDone()
+
 	if err != nil {
 		err = fmt.Errorf("failed to get the ramen configMap: %w", err)
 		r.recordFailure(ctx, drpc, nil, "Error", err.Error(), logger)
@@ -166,7 +183,11 @@ func (r *DRPlacementControlReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	var placementObj client.Object
 
+	stageDone = timeReconcileStage(reconcileStageDRPC, stagePlacementGet)
 	placementObj, err = getPlacementOrPlacementRule(ctx, r.Client, drpc, logger)
+
+	stageDone()
+
 	if err != nil && !(k8serrors.IsNotFound(err) && rmnutil.ResourceIsDeleted(drpc)) {
 		r.recordFailure(ctx, drpc, placementObj, "Error", err.Error(), logger)
 
@@ -215,13 +236,19 @@ func (r *DRPlacementControlReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, err
 	}
 
-	drPolicy, err := r.getAndEnsureValidDRPolicy(ctx, drpc, logger)
+	stageDone = timeReconcileStage(reconcileStageDRPC, stagePolicyValidation)
+	drPolicy, err := r.drPolicyForReconcile(ctx, drpc, logger)
+
+	stageDone()
+
 	if err != nil {
 		r.recordFailure(ctx, drpc, placementObj, "Error", err.Error(), logger)
 
 		return ctrl.Result{}, err
 	}
 
+	r.collectDRPCDiagnostics(ctx, drpc, placementObj, drPolicy, logger)
+
 	// Updates labels, finalizers and set the placement as the owner of the DRPC
 	updated, err := r.updateAndSetOwner(ctx, drpc, placementObj, logger)
 	if err != nil {
@@ -233,8 +260,15 @@ func (r *DRPlacementControlReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	// Resume an action that was in flight when the previous operator stopped, before rebuilding the DRPC state
+	resumeDRPCFromActionCheckpoint(drpc, r.ActionTracker, logger)
+
 	// Rebuild DRPC state if needed
+	stageDone = timeReconcileStage(reconcileStageDRPC, stageStatusConsistency)
 	requeue, err := r.ensureDRPCStatusConsistency(ctx, drpc, drPolicy, placementObj, logger)
+
+	stageDone()
+
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -243,7 +277,11 @@ func (r *DRPlacementControlReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{Requeue: true}, r.updateDRPCStatus(ctx, drpc, placementObj, logger, nil)
 	}
 
+	stageDone = timeReconcileStage(reconcileStageDRPC, stageInstanceCreate)
 	d, err := r.createDRPCInstance(ctx, drPolicy, drpc, placementObj, ramenConfig, logger)
+
+	stageDone()
+
 	if err != nil && !errors.Is(err, ErrInitialWaitTimeForDRPCPlacementRule) {
 		err2 := r.updateDRPCStatus(ctx, drpc, placementObj, logger, nil)
 
@@ -259,6 +297,8 @@ func (r *DRPlacementControlReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{RequeueAfter: time.Second * initialWaitTime}, nil
 	}
 
+	defer timeReconcileStage(reconcileStageDRPC, stageInstanceReconcile)()
+
 	return r.reconcileDRPCInstance(d, logger)
 }
 
@@ -416,6 +456,47 @@ func (r *DRPlacementControlReconciler) setGlobalActionMetric(
 	metric.GlobalActionStatus.Set(float64(consensus))
 }
 
+// setDRReadinessScoreMetric sets the DR readiness score metric of a DRPC, and deletes it when the DRPC has no
+// readiness score as continuous validation is disabled
+func (r *DRPlacementControlReconciler) setDRReadinessScoreMetric(drpc *rmn.DRPlacementControl, log logr.Logger) {
+	labels := DRReadinessScoreLabels(drpc)
+
+	if drpc.Status.Readiness == nil {
+		DeleteDRReadinessScoreMetric(labels)
+
+		return
+	}
+
+	log.Info(fmt.Sprintf("Setting metric: (%s)", DRReadinessScore))
+
+	NewDRReadinessScoreMetric(labels).DRReadinessScore.Set(float64(drpc.Status.Readiness.Score))
+}
+
+// setRPOViolatedMetric sets the RPO violated metric of a DRPC from its RPOViolated condition, where 1 indicates the
+// RPO target of its DRPolicy is violated, and deletes it when the DRPC has no such condition as the policy has no
+// RPO target
+func (r *DRPlacementControlReconciler) setRPOViolatedMetric(drpc *rmn.DRPlacementControl, drPolicy *rmn.DRPolicy,
+	log logr.Logger,
+) {
+	labels := RPOViolatedLabels(drPolicy, drpc)
+
+	condition := meta.FindStatusCondition(drpc.Status.Conditions, rmn.ConditionRPOViolated)
+	if condition == nil {
+		DeleteRPOViolatedMetric(labels)
+
+		return
+	}
+
+	log.Info(fmt.Sprintf("Setting metric: (%s)", RPOViolated))
+
+	violated := 0
+	if condition.Status == metav1.ConditionTrue {
+		violated = 1
+	}
+
+	NewRPOViolatedMetric(labels).RPOViolated.Set(float64(violated))
+}
+
 func (r *DRPlacementControlReconciler) setDRProgressionStateMetric(drpc *rmn.DRPlacementControl,
 	drProgressionStateMetrics *DRProgressionStateMetrics, log logr.Logger,
 ) {
@@ -463,7 +544,14 @@ func (r *DRPlacementControlReconciler) createDRPCInstance(
 		return nil, err
 	}
 
-	vrgs, cqs, _, err := getVRGsFromManagedClusters(r.MCVGetter, drpc, drClusters, vrgNamespace, log)
+	spec, err := drpcSpecTemplated(ctx, r.Client, drpc)
+	if err != nil {
+		return nil, err
+	}
+
+	vrgViewErrs := map[string]error{}
+
+	vrgs, cqs, _, err := getVRGsFromManagedClusters(r.MCVGetter, drpc, drClusters, vrgNamespace, log, vrgViewErrs)
 	if err != nil {
 		return nil, err
 	}
@@ -475,10 +563,12 @@ func (r *DRPlacementControlReconciler) createDRPCInstance(
 		ctx:             ctx,
 		log:             log,
 		instance:        drpc,
+		spec:            spec,
 		userPlacement:   placementObj,
 		drPolicy:        drPolicy,
 		drClusters:      drClusters,
 		vrgs:            vrgs,
+		vrgViewErrs:     vrgViewErrs,
 		vrgNamespace:    vrgNamespace,
 		volSyncDisabled: ramenConfig.VolSync.Disabled,
 		ramenConfig:     ramenConfig,
@@ -492,6 +582,11 @@ func (r *DRPlacementControlReconciler) createDRPCInstance(
 		},
 	}
 
+	d.mwu.SetPropagatedMetadata(drpc, ramenConfig.MetadataPropagation)
+	d.mwu.SetReapplyToken(drpc)
+	d.mwu.SetStatusFeedback(ramenConfig.ManagedClusterStatusSource)
+	d.mwu.SetManifestTransport(ramenConfig.ManifestTransport)
+
 	d.drType = DRTypeAsync
 
 	isMetro, _, err := dRPolicySupportsMetro(drPolicy, nil)
@@ -513,6 +608,8 @@ func (r *DRPlacementControlReconciler) createDRPCInstance(
 	// Save the instance status
 	d.instance.Status.DeepCopyInto(&d.savedInstanceStatus)
 
+	d.s3ProfileMigrationObserve()
+
 	return d, nil
 }
 
@@ -610,9 +707,13 @@ func (r *DRPlacementControlReconciler) reconcileDRPCInstance(d *DRPCInstance, lo
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	d.reconcileLocalFailover()
+
 	requeue := d.startProcessing()
 	log.Info("Finished processing", "Requeue?", requeue)
 
+	r.updateViewRefresh(d)
+
 	if !requeue {
 		log.Info("Done reconciling", "state", d.getLastDRState())
 		r.Callback(d.instance.Name, string(d.getLastDRState()))
@@ -637,12 +738,51 @@ func (r *DRPlacementControlReconciler) reconcileDRPCInstance(d *DRPCInstance, lo
 		afterProcessing = *d.instance.Status.LastUpdateTime
 	}
 
-	requeueTimeDuration := r.getStatusCheckDelay(beforeProcessing, afterProcessing)
+	requeueTimeDuration := d.rpoRequeueDelay(d.readinessRequeueDelay(
+		r.getStatusCheckDelay(beforeProcessing, afterProcessing)))
+	if d.requeueAfter != 0 {
+		requeueTimeDuration = min(requeueTimeDuration, d.requeueAfter)
+	}
+
 	log.Info("Requeue time", "duration", requeueTimeDuration)
 
 	return ctrl.Result{RequeueAfter: requeueTimeDuration}, nil
 }
 
+// updateViewRefresh switches the views of the clusters of the DRPC to their active refresh interval while the DRPC
+// is failing over or relocating, and back once done
+func (r *DRPlacementControlReconciler) updateViewRefresh(d *DRPCInstance) {
+	holder := drpcViewRefreshHolder(d.instance)
+
+	if d.instance.Status.Phase != rmn.FailingOver && d.instance.Status.Phase != rmn.Relocating {
+		r.ViewIntervals.SetActive(holder)
+
+		return
+	}
+
+	clusters := make([]string, 0, len(d.drClusters))
+	for i := range d.drClusters {
+		clusters = append(clusters, d.drClusters[i].Name)
+	}
+
+	r.ViewIntervals.SetActive(holder, clusters...)
+}
+
+// drpcViewSubscriber is the subscriber of the DRPC reconciler to the results of ManagedClusterViews
+const drpcViewSubscriber = "DRPlacementControl"
+
+// drpcViewRefreshHolder returns the holder of the active view refresh intervals for the actions of drpc
+func drpcViewRefreshHolder(drpc *rmn.DRPlacementControl) string {
+	return "DRPlacementControl/" + drpc.Namespace + "/" + drpc.Name
+}
+
+// viewSubscribe subscribes the DRPC to the result of the view of the object of kind gvk named name on cluster, for
+// the reads of views not annotated with the DRPC
+func (d *DRPCInstance) viewSubscribe(cluster string, gvk schema.GroupVersionKind, name types.NamespacedName) {
+	d.reconciler.ViewSubscriptions.Subscribe(rmnutil.ManagedClusterViewKey{Cluster: cluster, GVK: gvk, Name: name},
+		drpcViewSubscriber, types.NamespacedName{Namespace: d.instance.Namespace, Name: d.instance.Name})
+}
+
 func (r *DRPlacementControlReconciler) getAndEnsureValidDRPolicy(ctx context.Context,
 	drpc *rmn.DRPlacementControl, log logr.Logger,
 ) (*rmn.DRPolicy, error) {
@@ -767,6 +907,9 @@ func (r *DRPlacementControlReconciler) processDeletion(ctx context.Context,
 		return fmt.Errorf("failed to update drpc %w", err)
 	}
 
+	r.ViewIntervals.SetActive(drpcViewRefreshHolder(drpc))
+	r.ViewSubscriptions.Unsubscribe(drpcViewSubscriber, types.NamespacedName{Namespace: drpc.Namespace, Name: drpc.Name})
+
 	r.Callback(drpc.Name, "deleted")
 
 	return nil
@@ -800,6 +943,13 @@ func (r *DRPlacementControlReconciler) finalizeDRPC(ctx context.Context, drpc *r
 		TargetNamespace: vrgNamespace,
 	}
 
+	_, ramenConfig, err := ConfigMapGet(ctx, r.APIReader)
+	if err != nil {
+		return fmt.Errorf("failed to get the ramen config while finalizing DRPC (%w)", err)
+	}
+
+	mwu.SetManifestTransport(ramenConfig.ManifestTransport)
+
 	drPolicy, err := GetDRPolicy(ctx, r.Client, drpc, log)
 	if err != nil {
 		return fmt.Errorf("failed to get DRPolicy while finalizing DRPC (%w)", err)
@@ -815,10 +965,16 @@ func (r *DRPlacementControlReconciler) finalizeDRPC(ctx context.Context, drpc *r
 		return err
 	}
 
+	r.s3OrphanedPrefixRecord(ctx, drpc, drPolicy, vrgNamespace, log)
+
 	if err := deleteNamespaceManifestWorks(drpc, drPolicy, mwu, vrgNamespace); err != nil {
 		return err
 	}
 
+	if err := deleteReplicationNetworkPolicyManifestWorks(drpc, drPolicy, mwu, vrgNamespace); err != nil {
+		return err
+	}
+
 	// delete recipe manifestwork
 	for _, drClusterName := range rmnutil.DRPolicyClusterNames(drPolicy) {
 		if err := mwu.DeleteRecipeManifestWork(drClusterName); err != nil {
@@ -850,6 +1006,10 @@ func (r *DRPlacementControlReconciler) finalizeDRPC(ctx context.Context, drpc *r
 	globalActionLabels := GlobalActionLabels(drpc)
 	DeleteGlobalActionMetric(globalActionLabels)
 
+	DeleteDRReadinessScoreMetric(DRReadinessScoreLabels(drpc))
+
+	DeleteRPOViolatedMetric(RPOViolatedLabels(drPolicy, drpc))
+
 	return nil
 }
 
@@ -894,7 +1054,7 @@ func (r *DRPlacementControlReconciler) cleanupVRGs(
 	}
 
 	// Verify VRGs have been deleted
-	vrgs, _, _, err := getVRGsFromManagedClusters(r.MCVGetter, drpc, drClusters, vrgNamespace, log)
+	vrgs, _, _, err := getVRGsFromManagedClusters(r.MCVGetter, drpc, drClusters, vrgNamespace, log, nil)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve VRGs. We'll retry later. Error (%w)", err)
 	}
@@ -915,7 +1075,7 @@ func (r *DRPlacementControlReconciler) cleanupVRGs(
 	}
 
 	// delete MCVs
-	if err := r.deleteAllManagedClusterViews(drpc, rmnutil.DRPolicyClusterNames(drPolicy)); err != nil {
+	if err := r.deleteAllManagedClusterViews(ctx, drpc, rmnutil.DRPolicyClusterNames(drPolicy)); err != nil {
 		return fmt.Errorf("error in deleting MCV (%w)", err)
 	}
 
@@ -944,6 +1104,10 @@ func (r *DRPlacementControlReconciler) ensureVRGsDeleted(
 				return fmt.Errorf("wait for annotation to propagate to the VRG. Msg: %w", err)
 			}
 
+			if err := EnsureSkipFinalizationHooksAnnotation(mwu, drpc, vrg, cluster, r.Log); err != nil {
+				return fmt.Errorf("wait for annotation to propagate to the VRG. Msg: %w", err)
+			}
+
 			if err := mwu.DeleteManifestWork(mwu.BuildManifestWorkName(rmnutil.MWTypeVRG), cluster); err != nil {
 				return fmt.Errorf("failed to delete %s VRG manifestwork for cluster %q: %w", replicationState, cluster, err)
 			}
@@ -960,8 +1124,18 @@ func (r *DRPlacementControlReconciler) ensureVRGsDeleted(
 }
 
 func (r *DRPlacementControlReconciler) deleteAllManagedClusterViews(
-	drpc *rmn.DRPlacementControl, clusterNames []string,
+	ctx context.Context, drpc *rmn.DRPlacementControl, clusterNames []string,
 ) error {
+	spec, err := drpcSpecTemplated(ctx, r.Client, drpc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+
+		// the template may be deleted before the DRPC, leaving the recipe view of the DRPC itself, if any
+		spec = &drpc.Spec
+	}
+
 	// Only after the VRGs have been deleted, we delete the MCVs for the VRGs and the NS
 	for _, drClusterName := range clusterNames {
 		// Delete MCV for the VRG
@@ -976,9 +1150,9 @@ func (r *DRPlacementControlReconciler) deleteAllManagedClusterViews(
 		}
 
 		// Delete MCV for Recipe
-		if drpc.Spec.KubeObjectProtection != nil &&
-			drpc.Spec.KubeObjectProtection.RecipeRef != nil {
-			recipeRef := drpc.Spec.KubeObjectProtection.RecipeRef
+		if spec.KubeObjectProtection != nil &&
+			spec.KubeObjectProtection.RecipeRef != nil {
+			recipeRef := spec.KubeObjectProtection.RecipeRef
 
 			err = r.MCVGetter.DeleteRecipeManagedClusterView(
 				recipeRef.Name, recipeRef.Namespace, drClusterName)
@@ -1332,12 +1506,15 @@ func (r *DRPlacementControlReconciler) clonePlacementRule(ctx context.Context,
 	return clonedPlRule, nil
 }
 
+// getVRGsFromManagedClusters returns the VRGs of drpc read from the clusters through their views. If viewErrs is not
+// nil, the error reading the view of each cluster the VRG could not be read from is recorded in it.
 func getVRGsFromManagedClusters(
 	mcvGetter rmnutil.ManagedClusterViewGetter,
 	drpc *rmn.DRPlacementControl,
 	drClusters []rmn.DRCluster,
 	vrgNamespace string,
 	log logr.Logger,
+	viewErrs map[string]error,
 ) (map[string]*rmn.VolumeReplicationGroup, int, string, error) {
 	vrgs := map[string]*rmn.VolumeReplicationGroup{}
 
@@ -1365,6 +1542,28 @@ func getVRGsFromManagedClusters(
 
 			failedCluster = drCluster.Name
 
+			if viewErrs != nil {
+				viewErrs[drCluster.Name] = err
+			}
+
+			if age, stale := rmnutil.IsMCVStale(err); stale {
+				log.Info(fmt.Sprintf("VRG view from %s is stale for %v", drCluster.Name, age.Round(time.Second)))
+
+				continue
+			}
+
+			if rmnutil.IsMCVClusterUnreachable(err) {
+				log.Info(fmt.Sprintf("VRG view from %s cannot refresh, the cluster is unreachable", drCluster.Name))
+
+				continue
+			}
+
+			if rmnutil.IsMCVForbidden(err) {
+				log.Info(fmt.Sprintf("VRG view from %s is not permitted to read the VRG. err (%v).", drCluster.Name, err))
+
+				continue
+			}
+
 			log.Info(fmt.Sprintf("failed to retrieve VRG from %s. err (%v).", drCluster.Name, err))
 
 			continue
@@ -1490,6 +1689,10 @@ func (r *DRPlacementControlReconciler) updateDRPCStatus(
 
 	r.updateResourceCondition(ctx, drpc, userPlacement, log, vrgs)
 
+	if !isBeingDeleted(drpc, userPlacement) {
+		r.updateRPOViolatedCondition(ctx, drpc, log)
+	}
+
 	// set metrics if DRPC is not being deleted and if finalizer exists
 	if !isBeingDeleted(drpc, userPlacement) && controllerutil.ContainsFinalizer(drpc, DRPCFinalizer) {
 		if err := r.setDRPCMetrics(ctx, drpc, log); err != nil {
@@ -1508,6 +1711,7 @@ func (r *DRPlacementControlReconciler) updateDRPCStatus(
 
 	if reflect.DeepEqual(r.savedInstanceStatus, drpc.Status) {
 		log.Info("No need to update DRPC Status")
+		r.ActionTracker.drpcPersisted(drpc)
 
 		return nil
 	}
@@ -1519,6 +1723,8 @@ func (r *DRPlacementControlReconciler) updateDRPCStatus(
 		return fmt.Errorf("failed to update DRPC status: %w", err)
 	}
 
+	r.ActionTracker.drpcPersisted(drpc)
+
 	log.Info("Updated DRPC Status")
 
 	return nil
@@ -1564,6 +1770,8 @@ func (r *DRPlacementControlReconciler) updateResourceCondition(
 		Generation:      vrg.Generation,
 		ResourceVersion: vrg.ResourceVersion,
 		ProtectedPVCs:   extractProtectedPVCNames(vrg),
+		ExcludedPVCs:    vrg.Status.ExcludedPVCs,
+		OverriddenPDBs:  vrg.Status.OverriddenPDBs,
 	}
 
 	drpc.Status.ResourceConditions.Conditions = assignConditionsWithConflictCheck(
@@ -1787,11 +1995,15 @@ func (r *DRPlacementControlReconciler) setDRPCMetrics(ctx context.Context,
 	globalActionMetrics := r.createGlobalActionMetricsInstance(drpc)
 	r.setGlobalActionMetric(drpc, globalActionMetrics, log)
 
+	r.setDRReadinessScoreMetric(drpc, log)
+
 	drPolicy, err := GetDRPolicy(ctx, r.Client, drpc, log)
 	if err != nil {
 		return fmt.Errorf("failed to get DRPolicy %w", err)
 	}
 
+	r.setRPOViolatedMetric(drpc, drPolicy, log)
+
 	// do not set sync metrics if metro-dr
 	isMetro, _, err := dRPolicySupportsMetro(drPolicy, nil)
 	if err != nil {
@@ -2391,7 +2603,7 @@ func GetAvailableS3Profiles(ctx context.Context, client client.Client,
 		return []string{}
 	}
 
-	return AvailableS3Profiles(drClusters)
+	return s3ProfilesMigrating(drpc, AvailableS3Profiles(drClusters))
 }
 
 func AvailableS3Profiles(drClusters []rmn.DRCluster) []string {
@@ -2435,7 +2647,7 @@ func (r *DRPlacementControlReconciler) ensureDRPCStatusConsistency(
 
 	dstCluster := drpc.Spec.PreferredCluster
 	if drpc.Spec.Action == rmn.ActionFailover {
-		dstCluster = drpc.Spec.FailoverCluster
+		dstCluster = drpcFailoverCluster(drpc)
 	}
 
 	progress, msg, err := r.determineDRPCState(ctx, drpc, drPolicy, placementObj, dstCluster, log)
@@ -2530,7 +2742,7 @@ func (r *DRPlacementControlReconciler) determineDRPCState(
 	}
 
 	vrgs, successfullyQueriedClusterCount, failedCluster, err := getVRGsFromManagedClusters(
-		r.MCVGetter, drpc, drClusters, vrgNamespace, log)
+		r.MCVGetter, drpc, drClusters, vrgNamespace, log, nil)
 	if err != nil {
 		log.Info("Failed to get a list of VRGs")
 
@@ -2546,6 +2758,13 @@ func (r *DRPlacementControlReconciler) determineDRPCState(
 		TargetNamespace: vrgNamespace,
 	}
 
+	_, ramenConfig, err := ConfigMapGet(ctx, r.APIReader)
+	if err != nil {
+		return Stop, "", fmt.Errorf("failed to get the ramen config (%w)", err)
+	}
+
+	mwu.SetManifestTransport(ramenConfig.ManifestTransport)
+
 	if !ensureVRGsManagedByDRPC(log, mwu, vrgs, drpc, vrgNamespace) {
 		msg := "VRG adoption in progress"
 
@@ -2568,7 +2787,7 @@ func (r *DRPlacementControlReconciler) determineDRPCState(
 
 	if drpc.Status.Phase == rmn.WaitForUser &&
 		drpc.Spec.Action == rmn.ActionFailover &&
-		drpc.Spec.FailoverCluster != failedCluster {
+		drpcFailoverCluster(drpc) != failedCluster {
 		log.Info("Continue. The action is failover and the failoverCluster is accessible")
 
 		return Continue, "", nil
@@ -2581,7 +2800,8 @@ func (r *DRPlacementControlReconciler) determineDRPCState(
 	// with initial deploy
 	if successfullyQueriedClusterCount == 1 && len(vrgs) == 0 {
 		vrg := GetLastKnownVRGPrimaryFromS3(ctx, r.APIReader,
-			AvailableS3Profiles(drClusters), drpc.GetName(), vrgNamespace, r.ObjStoreGetter, log)
+			s3ProfilesMigrating(drpc, AvailableS3Profiles(drClusters)), drpc.GetName(), vrgNamespace,
+			r.ObjStoreGetter, log)
 		if vrg == nil {
 			// IF the failed cluster is not the dest cluster, then this could be an initial deploy
 			if failedCluster != dstCluster {
@@ -3029,7 +3249,11 @@ func (r *DRPlacementControlReconciler) twoDRPCsConflict(
 		return fmt.Errorf("failed to get protected namespaces for drpc: %v, %w", otherDRPC.Name, err)
 	}
 
-	independentVMProtection := r.drpcProtectVMInNS(drpc, otherDRPC, ramenConfig)
+	independentVMProtection, err := r.drpcsProtectVMInNS(ctx, drpc, otherDRPC, ramenConfig)
+	if err != nil {
+		return err
+	}
+
 	if independentVMProtection {
 		return nil
 	}
@@ -3096,6 +3320,30 @@ func (r *DRPlacementControlReconciler) drpcMetaAndOtherHaveVMRecipe(drpc *rmn.DR
 	return true
 }
 
+// drpcsProtectVMInNS is drpcProtectVMInNS for the DRPCs with the defaults of their templates applied. A DRPC whose
+// template is missing is compared as is, as its own reconcile reports the missing template.
+func (r *DRPlacementControlReconciler) drpcsProtectVMInNS(ctx context.Context, drpc *rmn.DRPlacementControl,
+	otherdrpc *rmn.DRPlacementControl,
+	ramenConfig *rmn.RamenConfig,
+) (bool, error) {
+	templated := make([]*rmn.DRPlacementControl, 0, 2)
+
+	for _, instance := range []*rmn.DRPlacementControl{drpc, otherdrpc} {
+		spec, err := drpcSpecTemplated(ctx, r.Client, instance)
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return false, err
+			}
+
+			spec = &instance.Spec
+		}
+
+		templated = append(templated, &rmn.DRPlacementControl{ObjectMeta: instance.ObjectMeta, Spec: *spec})
+	}
+
+	return r.drpcProtectVMInNS(templated[0], templated[1], ramenConfig), nil
+}
+
 func (r *DRPlacementControlReconciler) drpcProtectVMInNS(drpc *rmn.DRPlacementControl,
 	otherdrpc *rmn.DRPlacementControl,
 	ramenConfig *rmn.RamenConfig,
@@ -3164,6 +3412,30 @@ func EnsureDoNotDeletePVCAnnotation(
 	return nil
 }
 
+// EnsureSkipFinalizationHooksAnnotation propagates the skip-finalization-hooks annotation of the DRPC to the primary
+// VRG, before the VRG is deleted, so that the VRG does not run its finalization hooks
+func EnsureSkipFinalizationHooksAnnotation(
+	mwu rmnutil.MWUtil,
+	drpc *rmn.DRPlacementControl,
+	vrg *rmn.VolumeReplicationGroup,
+	cluster string,
+	log logr.Logger,
+) error {
+	if vrg.Spec.ReplicationState != rmn.Primary || vrg.Spec.FinalizationHooks == nil {
+		return nil
+	}
+
+	if drpc.GetAnnotations()[SkipFinalizationHooksAnnotation] != SkipFinalizationHooksAnnotationVal ||
+		vrg.GetAnnotations()[SkipFinalizationHooksAnnotation] == SkipFinalizationHooksAnnotationVal {
+		return nil
+	}
+
+	err := propagateAnnotationToVRG(mwu, cluster, SkipFinalizationHooksAnnotation,
+		SkipFinalizationHooksAnnotationVal, log)
+
+	return fmt.Errorf("annotation hasn't been propagated to cluster %s (%w)", cluster, err)
+}
+
 func IsRamenPlacementScheduler(placementObj client.Object) bool {
 	switch obj := placementObj.(type) {
 	case *plrv1.PlacementRule:
@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const failoverProbeDefaultTimeout = 5 * time.Second
+
+var errFailoverProbeNotAllowed = errors.New("failover probe not allowed")
+
+// areFailoverDependenciesSatisfied checks that all DRPCs this DRPC depends on have failed over to the same
+// cluster, and that all failover probes report healthy, before this DRPC is allowed to fail over.
+func (d *DRPCInstance) areFailoverDependenciesSatisfied() bool {
+	deps := d.instance.Spec.FailoverDependencies
+	if deps == nil {
+		return true
+	}
+
+	log := d.log.WithName("FailoverDependencies").WithValues("failoverCluster", drpcFailoverCluster(d.instance))
+
+	var pending []string
+
+	for _, ref := range deps.DRPCs {
+		if reason := d.failoverDependencyDRPCPending(ref); reason != "" {
+			pending = append(pending, reason)
+		}
+	}
+
+	var allowedHosts []string
+	if d.ramenConfig != nil {
+		allowedHosts = d.ramenConfig.FailoverProbes.AllowedHosts
+	}
+
+	for _, probe := range deps.Probes {
+		if err := runFailoverProbe(d.ctx, probe, allowedHosts); err != nil {
+			pending = append(pending, fmt.Sprintf("probe %s: %v", probe.URL, err))
+		}
+	}
+
+	if len(pending) > 0 {
+		msg := "Pending: " + strings.Join(pending, "; ")
+		log.Info(msg)
+		d.setFailoverDependenciesCondition(false, msg)
+		d.setProgression(rmn.ProgressionWaitOnFailoverDependencies)
+
+		return false
+	}
+
+	d.setFailoverDependenciesCondition(true, "Failover dependencies satisfied")
+
+	return true
+}
+
+// failoverDependencyDRPCPending returns why the referenced DRPC, of the namespace of this DRPC, has not yet failed
+// over to the failover cluster of this DRPC, or an empty string if it has
+func (d *DRPCInstance) failoverDependencyDRPCPending(ref rmn.DRPCReference) string {
+	key := types.NamespacedName{Name: ref.Name, Namespace: d.instance.Namespace}
+
+	drpc := &rmn.DRPlacementControl{}
+	if err := d.reconciler.Get(d.ctx, key, drpc); err != nil {
+		return fmt.Sprintf("DRPC %s: %v", key, err)
+	}
+
+	failoverCluster := drpcFailoverCluster(d.instance)
+
+	if drpc.Spec.Action != rmn.ActionFailover || drpcFailoverCluster(drpc) != failoverCluster {
+		return fmt.Sprintf("DRPC %s is not failing over to %s", key, failoverCluster)
+	}
+
+	if drpc.Status.Phase != rmn.FailedOver {
+		return fmt.Sprintf("DRPC %s is %s", key, drpc.Status.Phase)
+	}
+
+	return ""
+}
+
+// runFailoverProbe issues an HTTP GET to the probe URL, if its host is one of the allowed hosts, and returns an error
+// unless it responds with a 2xx status. Redirects are not followed, as they could lead to a host that is not allowed.
+func runFailoverProbe(ctx context.Context, probe rmn.FailoverProbe, allowedHosts []string) error {
+	if err := failoverProbeAllowed(probe.URL, allowedHosts); err != nil {
+		return err
+	}
+
+	timeout := failoverProbeDefaultTimeout
+	if probe.TimeoutSeconds > 0 {
+		timeout = time.Duration(probe.TimeoutSeconds) * time.Second
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probe.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unhealthy status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// failoverProbeAllowed returns an error unless rawURL is an http or https URL of one of the allowed hosts, each
+// matching either the host, or the host and port, of the URL
+func failoverProbeAllowed(rawURL string, allowedHosts []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: scheme %q", errFailoverProbeNotAllowed, u.Scheme)
+	}
+
+	if !slices.Contains(allowedHosts, u.Host) && !slices.Contains(allowedHosts, u.Hostname()) {
+		return fmt.Errorf("%w: host %q is not in the failoverProbes allowedHosts of the hub configuration",
+			errFailoverProbeNotAllowed, u.Host)
+	}
+
+	return nil
+}
+
+func (d *DRPCInstance) setFailoverDependenciesCondition(met bool, message string) {
+	status := metav1.ConditionFalse
+	reason := ReasonFailoverDependenciesPending
+
+	if met {
+		status = metav1.ConditionTrue
+		reason = ReasonFailoverDependenciesSatisfied
+	}
+
+	addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionFailoverDependencies,
+		d.instance.Generation, status, reason, message)
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("Failover dependencies", func() {
+	var (
+		server   *httptest.Server
+		status   int
+		instance *rmn.DRPlacementControl
+		config   *rmn.RamenConfig
+	)
+
+	serverHost := func() string {
+		u, err := url.Parse(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+
+		return u.Host
+	}
+
+	satisfied := func(objects ...runtime.Object) bool {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+
+		d := &DRPCInstance{
+			reconciler: &DRPlacementControlReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build(),
+			},
+			ctx:         context.TODO(),
+			log:         logr.Discard(),
+			instance:    instance,
+			ramenConfig: config,
+		}
+
+		return d.areFailoverDependenciesSatisfied()
+	}
+
+	failedOverDRPC := func(namespace string) *rmn.DRPlacementControl {
+		return &rmn.DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "db"},
+			Spec:       rmn.DRPlacementControlSpec{Action: rmn.ActionFailover, FailoverCluster: "west"},
+			Status:     rmn.DRPlacementControlStatus{Phase: rmn.FailedOver},
+		}
+	}
+
+	BeforeEach(func() {
+		status = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/redirect" {
+				http.Redirect(w, r, "/", http.StatusFound)
+
+				return
+			}
+
+			w.WriteHeader(status)
+		}))
+		DeferCleanup(server.Close)
+
+		instance = &rmn.DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "web"},
+			Spec: rmn.DRPlacementControlSpec{
+				Action:               rmn.ActionFailover,
+				FailoverCluster:      "west",
+				FailoverDependencies: &rmn.FailoverDependencies{},
+			},
+		}
+		config = &rmn.RamenConfig{}
+	})
+
+	Context("DRPCs", func() {
+		BeforeEach(func() {
+			instance.Spec.FailoverDependencies.DRPCs = []rmn.DRPCReference{{Name: "db"}}
+		})
+
+		It("are satisfied once the referenced DRPC failed over to the same cluster", func() {
+			Expect(satisfied(failedOverDRPC("app"))).To(BeTrue())
+			Expect(instance.Status.Progression).ToNot(Equal(rmn.ProgressionWaitOnFailoverDependencies))
+		})
+
+		It("are pending while the referenced DRPC fails over to another cluster", func() {
+			db := failedOverDRPC("app")
+			db.Spec.FailoverCluster = "east"
+
+			Expect(satisfied(db)).To(BeFalse())
+			Expect(instance.Status.Progression).To(Equal(rmn.ProgressionWaitOnFailoverDependencies))
+		})
+
+		It("refer only to the DRPCs of the namespace of the DRPC", func() {
+			Expect(satisfied(failedOverDRPC("other"))).To(BeFalse())
+		})
+	})
+
+	Context("probes", func() {
+		probe := func(path string) {
+			instance.Spec.FailoverDependencies.Probes = []rmn.FailoverProbe{{URL: server.URL + path}}
+		}
+
+		BeforeEach(func() {
+			probe("/")
+			config.FailoverProbes.AllowedHosts = []string{serverHost()}
+		})
+
+		It("are satisfied by a healthy endpoint of an allowed host", func() {
+			Expect(satisfied()).To(BeTrue())
+		})
+
+		It("are pending while the endpoint is unhealthy", func() {
+			status = http.StatusServiceUnavailable
+
+			Expect(satisfied()).To(BeFalse())
+		})
+
+		It("are not issued to hosts that are not allowed", func() {
+			config.FailoverProbes.AllowedHosts = []string{"db.example.com"}
+
+			Expect(satisfied()).To(BeFalse())
+			Expect(runFailoverProbe(context.TODO(), instance.Spec.FailoverDependencies.Probes[0],
+				config.FailoverProbes.AllowedHosts)).To(MatchError(errFailoverProbeNotAllowed))
+		})
+
+		It("are not issued without an allowed host configured", func() {
+			config = nil
+
+			Expect(satisfied()).To(BeFalse())
+		})
+
+		It("do not follow redirects", func() {
+			probe("/redirect")
+
+			Expect(satisfied()).To(BeFalse())
+		})
+	})
+
+	It("allows only http and https URLs of the allowed hosts, with or without their port", func() {
+		Expect(failoverProbeAllowed("https://db.example.com:8443/healthz", []string{"db.example.com"})).To(Succeed())
+		Expect(failoverProbeAllowed("https://db.example.com:8443/healthz", []string{"db.example.com:8443"})).
+			To(Succeed())
+		Expect(failoverProbeAllowed("https://db.example.com:8443/healthz", []string{"db.example.com:443"})).
+			To(MatchError(errFailoverProbeNotAllowed))
+		Expect(failoverProbeAllowed("file://db.example.com/etc/passwd", []string{"db.example.com"})).
+			To(MatchError(errFailoverProbeNotAllowed))
+	})
+})
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+// drpcFailoverCluster returns the cluster the failover of drpc is to: its spec.FailoverCluster, or else the cluster
+// selected for it, recorded in its status
+func drpcFailoverCluster(drpc *rmn.DRPlacementControl) string {
+	if drpc.Spec.FailoverCluster != "" || drpc.Spec.Action != rmn.ActionFailover {
+		return drpc.Spec.FailoverCluster
+	}
+
+	return drpc.Status.SelectedFailoverCluster
+}
+
+// failoverClusterSelectionReset forgets the cluster selected for a failover once a failover without
+// spec.FailoverCluster is no longer requested, for the next one to select a cluster again
+func (d *DRPCInstance) failoverClusterSelectionReset() {
+	if d.instance.Spec.Action != rmn.ActionFailover || d.instance.Spec.FailoverCluster != "" {
+		d.instance.Status.SelectedFailoverCluster = ""
+	}
+}
+
+// failoverClusterSelect chooses the cluster to fail over to, for a failover requested without spec.FailoverCluster,
+// and records it in the status, leaving the spec to the user. The failover starts once the DRPC is reconciled with
+// the cluster recorded.
+func (d *DRPCInstance) failoverClusterSelect() (bool, error) {
+	const done = true
+
+	failoverCluster, err := d.selectFailoverCluster()
+	if err != nil {
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+			d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), err.Error())
+
+		return !done, err
+	}
+
+	d.instance.Status.SelectedFailoverCluster = failoverCluster
+
+	d.log.Info("Selected failover cluster", "cluster", failoverCluster)
+
+	return !done, nil
+}
+
+// selectFailoverCluster returns the cluster, among the clusters of the DRPolicy other than the current home cluster of
+// the workload, that its VRG is to be failed over to: of those that are not fenced and whose VRG is a Secondary ready
+// to be promoted, the one whose VRG synced the most recently, as it loses the least data. The clusters are otherwise
+// chosen in the order of the DRPolicy, such as for a Sync policy, whose VRGs do not report a lastGroupSyncTime.
+func (d *DRPCInstance) selectFailoverCluster() (string, error) {
+	homeCluster := d.instance.GetAnnotations()[LastAppDeploymentCluster]
+	if homeCluster == "" {
+		homeCluster = d.instance.Status.PreferredDecision.ClusterName
+	}
+
+	selected := ""
+
+	var selectedSyncTime *metav1.Time
+
+	for i := range d.drClusters {
+		drCluster := &d.drClusters[i]
+		if drCluster.Name == homeCluster || rmnutil.ResourceIsDeleted(drCluster) {
+			continue
+		}
+
+		if fenced, err := d.checkClusterFenced(drCluster.Name, d.drClusters); err != nil || fenced {
+			continue
+		}
+
+		if ok, err := d.isValidFailoverTarget(drCluster.Name); !ok {
+			d.log.Info("Skipping failover cluster candidate", "cluster", drCluster.Name, "reason", err)
+
+			continue
+		}
+
+		var syncTime *metav1.Time
+		if vrg, ok := d.vrgs[drCluster.Name]; ok {
+			syncTime = vrg.Status.LastGroupSyncTime
+		}
+
+		if selected == "" || syncTime != nil && (selectedSyncTime == nil || syncTime.After(selectedSyncTime.Time)) {
+			selected = drCluster.Name
+			selectedSyncTime = syncTime
+		}
+	}
+
+	if selected == "" {
+		return "", fmt.Errorf("no cluster of DRPolicy %s other than %q is a valid failover target",
+			d.drPolicy.Name, homeCluster)
+	}
+
+	return selected, nil
+}
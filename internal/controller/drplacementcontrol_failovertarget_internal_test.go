@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+// vrgViewGetter returns the VRGs of the managed clusters from a map, in place of their ManagedClusterViews
+type vrgViewGetter struct {
+	rmnutil.ManagedClusterViewGetter
+	vrgs map[string]*rmn.VolumeReplicationGroup
+}
+
+func (g vrgViewGetter) GetVRGFromManagedCluster(_, _, managedCluster string, _ map[string]string,
+) (*rmn.VolumeReplicationGroup, error) {
+	vrg, ok := g.vrgs[managedCluster]
+	if !ok {
+		return nil, fmt.Errorf("VRG not found on cluster %s", managedCluster)
+	}
+
+	return vrg, nil
+}
+
+var _ = Describe("Failover cluster selection", func() {
+	secondary := func(syncedAgo time.Duration) *rmn.VolumeReplicationGroup {
+		vrg := &rmn.VolumeReplicationGroup{
+			Spec:   rmn.VolumeReplicationGroupSpec{ReplicationState: rmn.Secondary},
+			Status: rmn.VolumeReplicationGroupStatus{State: rmn.SecondaryState},
+		}
+
+		if syncedAgo != 0 {
+			syncTime := metav1.NewTime(time.Now().Add(-syncedAgo))
+			vrg.Status.LastGroupSyncTime = &syncTime
+		}
+
+		return vrg
+	}
+
+	drpcInstance := func(vrgs map[string]*rmn.VolumeReplicationGroup, clusters ...string) *DRPCInstance {
+		drClusters := []rmn.DRCluster{}
+		for _, cluster := range clusters {
+			drClusters = append(drClusters, rmn.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: cluster}})
+		}
+
+		return &DRPCInstance{
+			reconciler: &DRPlacementControlReconciler{MCVGetter: vrgViewGetter{vrgs: vrgs}},
+			log:        logr.Discard(),
+			instance: &rmn.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{LastAppDeploymentCluster: "east"},
+			}},
+			drPolicy:   &rmn.DRPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}},
+			drClusters: drClusters,
+			vrgs:       vrgs,
+		}
+	}
+
+	It("selects the ready Secondary that synced the most recently, other than the home cluster", func() {
+		vrgs := map[string]*rmn.VolumeReplicationGroup{
+			"east":  {Spec: rmn.VolumeReplicationGroupSpec{ReplicationState: rmn.Primary}},
+			"west":  secondary(10 * time.Minute),
+			"north": secondary(time.Minute),
+			"south": {Spec: rmn.VolumeReplicationGroupSpec{ReplicationState: rmn.Secondary}},
+		}
+
+		Expect(drpcInstance(vrgs, "east", "west", "north", "south").selectFailoverCluster()).To(Equal("north"))
+	})
+
+	It("selects the clusters in the order of the DRPolicy when none report a sync time", func() {
+		vrgs := map[string]*rmn.VolumeReplicationGroup{"west": secondary(0), "north": secondary(0)}
+
+		Expect(drpcInstance(vrgs, "east", "west", "north").selectFailoverCluster()).To(Equal("west"))
+	})
+
+	It("skips the fenced clusters, and fails when no cluster is a valid target", func() {
+		d := drpcInstance(map[string]*rmn.VolumeReplicationGroup{"west": secondary(time.Minute)}, "east", "west")
+		d.drClusters[1].Status.Conditions = []metav1.Condition{{
+			Type:   rmn.DRClusterConditionTypeFenced,
+			Status: metav1.ConditionTrue,
+		}}
+
+		_, err := d.selectFailoverCluster()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("records the cluster selected in the status, leaving the spec to the user", func() {
+		d := drpcInstance(map[string]*rmn.VolumeReplicationGroup{"west": secondary(time.Minute)}, "east", "west")
+		d.instance.Spec.Action = rmn.ActionFailover
+
+		Expect(d.failoverClusterSelect()).To(BeFalse())
+		Expect(d.instance.Spec.FailoverCluster).To(BeEmpty())
+		Expect(d.instance.Status.SelectedFailoverCluster).To(Equal("west"))
+		Expect(drpcFailoverCluster(d.instance)).To(Equal("west"))
+
+		d.failoverClusterSelectionReset()
+		Expect(d.instance.Status.SelectedFailoverCluster).To(Equal("west"))
+
+		d.instance.Spec.Action = rmn.ActionRelocate
+		d.failoverClusterSelectionReset()
+		Expect(d.instance.Status.SelectedFailoverCluster).To(BeEmpty())
+		Expect(drpcFailoverCluster(d.instance)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("DRPC failover fencing", func() {
+	var (
+		fakeClient client.Client
+		d          *DRPCInstance
+	)
+
+	newDRCluster := func(name string, clusterFence rmn.ClusterFenceState) rmn.DRCluster {
+		return rmn.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       rmn.DRClusterSpec{ClusterFence: clusterFence},
+		}
+	}
+
+	clusterFence := func(name string) rmn.ClusterFenceState {
+		drCluster := &rmn.DRCluster{}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Name: name}, drCluster)).To(Succeed())
+
+		return drCluster.Spec.ClusterFence
+	}
+
+	setup := func(drClusters ...rmn.DRCluster) {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		for i := range drClusters {
+			builder = builder.WithObjects(drClusters[i].DeepCopy())
+		}
+
+		fakeClient = builder.Build()
+
+		// the DRPC reconciles with the DRClusters it lists
+		for i := range drClusters {
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(&drClusters[i]), &drClusters[i])).
+				To(Succeed())
+		}
+
+		drpc := &rmn.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc", UID: "uid"}}
+
+		d = &DRPCInstance{
+			reconciler: &DRPlacementControlReconciler{
+				Client:        fakeClient,
+				eventRecorder: rmnutil.NewEventReporter(record.NewFakeRecorder(10)),
+			},
+			ctx:        context.TODO(),
+			log:        logr.Discard(),
+			instance:   drpc,
+			drPolicy:   &rmn.DRPolicy{},
+			drClusters: drClusters,
+			drType:     DRTypeAsync,
+		}
+	}
+
+	It("fences as before the preference was introduced if the DRPolicy sets none", func() {
+		setup()
+		Expect(d.failoverFencingPreference()).To(Equal(rmn.FencingPreferenceDisabled))
+
+		d.drType = DRTypeSync
+		Expect(d.failoverFencingPreference()).To(Equal(rmn.FencingPreferenceManual))
+
+		d.drPolicy.Spec.Fencing = rmn.FencingPreferenceAutomatic
+		Expect(d.failoverFencingPreference()).To(Equal(rmn.FencingPreferenceAutomatic))
+	})
+
+	It("does not wait for the cluster to be fenced if the DRPolicy disables fencing", func() {
+		setup(newDRCluster("east", ""))
+		d.drPolicy.Spec.Fencing = rmn.FencingPreferenceDisabled
+
+		Expect(d.checkFencingFailoverPrerequisites("east")).To(BeTrue())
+		Expect(clusterFence("east")).To(BeEmpty())
+	})
+
+	It("waits for the cluster to be fenced out of band if the DRPolicy prefers manual fencing", func() {
+		setup(newDRCluster("east", ""))
+		d.drPolicy.Spec.Fencing = rmn.FencingPreferenceManual
+
+		Expect(d.checkFencingFailoverPrerequisites("east")).To(BeFalse())
+		Expect(d.instance.Status.Progression).To(Equal(rmn.ProgressionWaitForFencing))
+		Expect(clusterFence("east")).To(BeEmpty())
+	})
+
+	It("requests the cluster to be fenced if the DRPolicy prefers automatic fencing", func() {
+		setup(newDRCluster("east", rmn.ClusterFenceStateUnfenced), newDRCluster("west", ""))
+		d.drPolicy.Spec.Fencing = rmn.FencingPreferenceAutomatic
+
+		Expect(d.checkFencingFailoverPrerequisites("east")).To(BeFalse())
+		Expect(clusterFence("east")).To(Equal(rmn.ClusterFenceStateFenced))
+		Expect(clusterFence("west")).To(BeEmpty())
+
+		drCluster := &rmn.DRCluster{}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Name: "east"}, drCluster)).To(Succeed())
+		Expect(rmnutil.ActionTraceFromAnnotations(drCluster)).To(Equal(rmnutil.ActionTrace{
+			Action: rmn.ActionFailover, DRPCs: []string{"app/drpc"}, CorrelationIDs: []string{"uid"},
+		}))
+
+		d.drClusters[0].Status.Conditions = []metav1.Condition{{
+			Type:               rmn.DRClusterConditionTypeFenced,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: d.drClusters[0].Generation,
+		}}
+		Expect(d.checkFencingFailoverPrerequisites("east")).To(BeTrue())
+	})
+
+	It("traces the fence requested by the failovers of several DRPCs to all of them", func() {
+		setup(newDRCluster("east", ""))
+		d.drPolicy.Spec.Fencing = rmn.FencingPreferenceAutomatic
+
+		Expect(d.checkFencingFailoverPrerequisites("east")).To(BeFalse())
+
+		d.instance = &rmn.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "drpc", UID: "uid2"}}
+		Expect(d.checkFencingFailoverPrerequisites("east")).To(BeFalse())
+
+		drCluster := &rmn.DRCluster{}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Name: "east"}, drCluster)).To(Succeed())
+		Expect(rmnutil.ActionTraceFromAnnotations(drCluster).DRPCs).To(Equal([]string{"app/drpc", "other/drpc"}))
+	})
+
+	It("leaves a cluster unfenced manually to the operator", func() {
+		setup(newDRCluster("east", rmn.ClusterFenceStateManuallyUnfenced))
+		d.drPolicy.Spec.Fencing = rmn.FencingPreferenceAutomatic
+
+		Expect(d.checkFencingFailoverPrerequisites("east")).To(BeFalse())
+		Expect(clusterFence("east")).To(Equal(rmn.ClusterFenceStateManuallyUnfenced))
+	})
+})
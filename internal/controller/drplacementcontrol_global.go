@@ -70,7 +70,7 @@ func (d *DRPCInstance) isGlobalActionInConsensus() bool {
 		return false
 	}
 
-	targetCluster := d.instance.Spec.FailoverCluster
+	targetCluster := drpcFailoverCluster(d.instance)
 	if action == rmn.ActionRelocate {
 		targetCluster = d.instance.Spec.PreferredCluster
 	}
@@ -97,7 +97,7 @@ func (d *DRPCInstance) isGlobalActionInConsensus() bool {
 		}
 
 		if drpc.Spec.Action != action ||
-			(action == rmn.ActionFailover && drpc.Spec.FailoverCluster != targetCluster) ||
+			(action == rmn.ActionFailover && drpcFailoverCluster(drpc) != targetCluster) ||
 			(action == rmn.ActionRelocate && drpc.Spec.PreferredCluster != targetCluster) {
 			pending = append(pending, drpc.Namespace+"/"+drpc.Name)
 		}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	// ActionRequestedByAnnotation on a DRPC records who requested its action, for its action history. Tools that
+	// request actions on behalf of users set it with the action, as the field manager of the action does not
+	// identify the user.
+	ActionRequestedByAnnotation = "drplacementcontrol.ramendr.openshift.io/action-requested-by"
+
+	// actionHistoryMax bounds the records in the action history of a DRPC
+	actionHistoryMax = 10
+)
+
+// actionHistoryStart records in the action history of drpc the action it starts at now. An action that was in
+// progress is recorded as superseded.
+func actionHistoryStart(drpc *rmn.DRPlacementControl, now time.Time) {
+	record := rmn.ActionRecord{
+		Action:    actionProgressDeploy,
+		StartTime: metav1.NewTime(now),
+		Outcome:   rmn.ActionOutcomeInProgress,
+	}
+
+	switch drpc.Spec.Action {
+	case rmn.ActionFailover:
+		record.Action = string(rmn.ActionFailover)
+		record.SourceCluster = drpc.Status.PreferredDecision.ClusterName
+		record.TargetCluster = drpcFailoverCluster(drpc)
+	case rmn.ActionRelocate:
+		record.Action = string(rmn.ActionRelocate)
+		record.SourceCluster = drpc.Status.PreferredDecision.ClusterName
+		record.TargetCluster = drpc.Spec.PreferredCluster
+	default:
+		record.TargetCluster = drpc.Spec.PreferredCluster
+	}
+
+	requestedBy, requestTime := actionRequester(drpc)
+	record.RequestedBy = requestedBy
+	record.RequestTime = metav1.NewTime(now)
+
+	if requestTime != nil {
+		record.RequestTime = *requestTime
+	}
+
+	history := drpc.Status.ActionHistory
+	if last := len(history) - 1; last >= 0 && history[last].Outcome == rmn.ActionOutcomeInProgress {
+		history[last].Outcome = rmn.ActionOutcomeSuperseded
+	}
+
+	history = append(history, record)
+
+	if excess := len(history) - actionHistoryMax; excess > 0 {
+		history = slices.Delete(history, 0, excess)
+	}
+
+	drpc.Status.ActionHistory = history
+}
+
+// actionHistoryComplete records in the action history of drpc that the action in progress completed at now
+func actionHistoryComplete(drpc *rmn.DRPlacementControl, now time.Time) {
+	last := len(drpc.Status.ActionHistory) - 1
+	if last < 0 || drpc.Status.ActionHistory[last].Outcome != rmn.ActionOutcomeInProgress {
+		return
+	}
+
+	record := &drpc.Status.ActionHistory[last]
+	record.Outcome = rmn.ActionOutcomeSucceeded
+	record.Duration = &metav1.Duration{Duration: now.Sub(record.StartTime.Time)}
+
+	if record.TargetCluster == "" {
+		record.TargetCluster = drpc.Status.PreferredDecision.ClusterName
+	}
+}
+
+// actionRequester returns who requested the action of drpc and when: the field manager that last set the action and
+// when, or the value of the ActionRequestedByAnnotation, if the same field manager set it too, as a stale annotation
+// of an earlier action is not to be attributed the action
+func actionRequester(drpc *rmn.DRPlacementControl) (string, *metav1.Time) {
+	var (
+		requestedBy string
+		requestTime *metav1.Time
+	)
+
+	for i := range drpc.ManagedFields {
+		entry := &drpc.ManagedFields[i]
+		if entry.FieldsV1 == nil || entry.Time == nil {
+			continue
+		}
+
+		action, annotation := managedFieldsSetAction(entry.FieldsV1.Raw)
+		if !action || (requestTime != nil && !entry.Time.After(requestTime.Time)) {
+			continue
+		}
+
+		requestedBy, requestTime = entry.Manager, entry.Time
+
+		if value := drpc.GetAnnotations()[ActionRequestedByAnnotation]; annotation && value != "" {
+			requestedBy = value
+		}
+	}
+
+	return requestedBy, requestTime
+}
+
+// managedFieldsSetAction returns whether the managed fields in raw include the action of a DRPC, and its
+// ActionRequestedByAnnotation
+func managedFieldsSetAction(raw []byte) (bool, bool) {
+	fields := struct {
+		Metadata struct {
+			Annotations map[string]json.RawMessage `json:"f:annotations"`
+		} `json:"f:metadata"`
+		Spec map[string]json.RawMessage `json:"f:spec"`
+	}{}
+
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return false, false
+	}
+
+	_, action := fields.Spec["f:action"]
+	_, annotation := fields.Metadata.Annotations["f:"+ActionRequestedByAnnotation]
+
+	return action, annotation
+}
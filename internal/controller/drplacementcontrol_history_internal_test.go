@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPC action history", func() {
+	var (
+		drpc *rmn.DRPlacementControl
+		now  time.Time
+	)
+
+	managedFields := func(manager string, at time.Time, raw string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:  manager,
+			Time:     &metav1.Time{Time: at},
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(raw)},
+		}
+	}
+
+	BeforeEach(func() {
+		now = time.Now().Truncate(time.Second)
+		drpc = &rmn.DRPlacementControl{
+			Spec: rmn.DRPlacementControlSpec{PreferredCluster: "east"},
+		}
+	})
+
+	It("records the deployment and its completion", func() {
+		actionHistoryStart(drpc, now)
+
+		record := drpc.Status.ActionHistory[0]
+		Expect(record.Action).To(Equal(actionProgressDeploy))
+		Expect(record.TargetCluster).To(Equal("east"))
+		Expect(record.RequestTime.Time).To(Equal(now))
+		Expect(record.Outcome).To(Equal(rmn.ActionOutcomeInProgress))
+
+		actionHistoryComplete(drpc, now.Add(time.Minute))
+
+		record = drpc.Status.ActionHistory[0]
+		Expect(record.Outcome).To(Equal(rmn.ActionOutcomeSucceeded))
+		Expect(record.Duration.Duration).To(Equal(time.Minute))
+	})
+
+	It("records the field manager that requested a failover, and supersedes the action in progress", func() {
+		actionHistoryStart(drpc, now)
+
+		drpc.Status.PreferredDecision.ClusterName = "east"
+		drpc.Spec.Action = rmn.ActionFailover
+		drpc.Spec.FailoverCluster = "west"
+		drpc.ManagedFields = []metav1.ManagedFieldsEntry{
+			managedFields("kubectl-create", now.Add(-time.Hour), `{"f:spec":{"f:preferredCluster":{}}}`),
+			managedFields("kubectl-edit", now.Add(-time.Minute), `{"f:spec":{"f:action":{},"f:failoverCluster":{}}}`),
+		}
+
+		actionHistoryStart(drpc, now)
+
+		history := drpc.Status.ActionHistory
+		Expect(history).To(HaveLen(2))
+		Expect(history[0].Outcome).To(Equal(rmn.ActionOutcomeSuperseded))
+		Expect(history[1].Action).To(Equal(string(rmn.ActionFailover)))
+		Expect(history[1].RequestedBy).To(Equal("kubectl-edit"))
+		Expect(history[1].RequestTime.Time).To(Equal(now.Add(-time.Minute)))
+		Expect(history[1].SourceCluster).To(Equal("east"))
+		Expect(history[1].TargetCluster).To(Equal("west"))
+	})
+
+	It("records the requester annotated with the action only", func() {
+		drpc.Spec.Action = rmn.ActionRelocate
+		drpc.Annotations = map[string]string{ActionRequestedByAnnotation: "AutoFailover"}
+		drpc.ManagedFields = []metav1.ManagedFieldsEntry{
+			managedFields("ramen", now.Add(-time.Hour),
+				`{"f:metadata":{"f:annotations":{"f:`+ActionRequestedByAnnotation+`":{}}},"f:spec":{"f:action":{}}}`),
+		}
+
+		actionHistoryStart(drpc, now)
+		Expect(drpc.Status.ActionHistory[0].RequestedBy).To(Equal("AutoFailover"))
+
+		drpc.ManagedFields = append(drpc.ManagedFields,
+			managedFields("kubectl-edit", now.Add(-time.Minute), `{"f:spec":{"f:action":{}}}`))
+
+		actionHistoryStart(drpc, now)
+		Expect(drpc.Status.ActionHistory[1].RequestedBy).To(Equal("kubectl-edit"))
+	})
+
+	It("bounds the records", func() {
+		for range actionHistoryMax + 2 {
+			actionHistoryStart(drpc, now)
+		}
+
+		Expect(drpc.Status.ActionHistory).To(HaveLen(actionHistoryMax))
+	})
+})
@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// LocalFailoverHeartbeatCheckInterval is the interval between checks for the local failover heartbeats that are
+	// due an upload. It is well below the heartbeat interval of the minimum threshold.
+	LocalFailoverHeartbeatCheckInterval = 30 * time.Second
+
+	// localFailoverMinThreshold guards against plans that would trigger on ordinary hub hiccups
+	localFailoverMinThreshold = 5 * time.Minute
+
+	// localFailoverHeartbeatsPerThreshold is the number of heartbeats the hub uploads within a plan's threshold
+	localFailoverHeartbeatsPerThreshold = 4
+)
+
+// localFailoverHeartbeatTimes tracks, per DRPC, when the hub last uploaded a local failover heartbeat
+var localFailoverHeartbeatTimes sync.Map
+
+func (d *DRPCInstance) localFailoverPlan() *rmn.LocalFailoverSpec {
+	return d.instance.Spec.LocalFailover
+}
+
+func (d *DRPCInstance) validateLocalFailoverPlan() error {
+	return localFailoverPlanValidate(d.localFailoverPlan(), d.drPolicy)
+}
+
+func localFailoverPlanValidate(plan *rmn.LocalFailoverSpec, drPolicy *rmn.DRPolicy) error {
+	if plan.HubUnreachableThreshold.Duration < localFailoverMinThreshold {
+		return fmt.Errorf("local failover hubUnreachableThreshold %v is less than the minimum %v",
+			plan.HubUnreachableThreshold.Duration, localFailoverMinThreshold)
+	}
+
+	if !slices.Contains(rmnutil.DRPolicyClusterNames(drPolicy), plan.FailoverCluster) {
+		return fmt.Errorf("local failover cluster %s is not in DRPolicy %s", plan.FailoverCluster, drPolicy.Name)
+	}
+
+	return nil
+}
+
+func localFailoverHeartbeatInterval(plan *rmn.LocalFailoverSpec) time.Duration {
+	return plan.HubUnreachableThreshold.Duration / localFailoverHeartbeatsPerThreshold
+}
+
+// reconcileLocalFailover reports in the DRPC status whether a managed cluster executed the DRPC local failover plan
+// while the hub was unreachable. The hub heartbeat is uploaded by the LocalFailoverHeartbeater instead, so that a
+// DRPC reconcile that stalls or fails is not mistaken for an unreachable hub.
+func (d *DRPCInstance) reconcileLocalFailover() {
+	if d.localFailoverPlan() == nil {
+		meta.RemoveStatusCondition(&d.instance.Status.Conditions, rmn.ConditionLocalFailover)
+
+		return
+	}
+
+	if err := d.validateLocalFailoverPlan(); err != nil {
+		d.log.Info("Local failover plan is invalid", "error", err)
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionLocalFailover, d.instance.Generation,
+			metav1.ConditionFalse, ReasonLocalFailoverInvalid, err.Error())
+
+		return
+	}
+
+	d.updateLocalFailoverCondition()
+}
+
+// LocalFailoverHeartbeater periodically uploads the hub heartbeat of the DRPCs with a local failover plan to the S3
+// stores of their clusters, independently of the DRPC reconciles
+type LocalFailoverHeartbeater struct {
+	client.Client
+	APIReader         client.Reader
+	ObjectStoreGetter ObjectStoreGetter
+	Log               logr.Logger
+	Interval          time.Duration
+}
+
+// NeedLeaderElection runs the heartbeater only on the leader, alongside the hub reconcilers
+func (h *LocalFailoverHeartbeater) NeedLeaderElection() bool {
+	return true
+}
+
+// Start uploads the heartbeats that are due every interval until ctx is done
+func (h *LocalFailoverHeartbeater) Start(ctx context.Context) error {
+	interval := h.Interval
+	if interval <= 0 {
+		interval = LocalFailoverHeartbeatCheckInterval
+	}
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := h.heartbeat(ctx); err != nil {
+			h.Log.Error(err, "Local failover heartbeat failed")
+		}
+	}, interval)
+
+	return nil
+}
+
+// heartbeat uploads the heartbeats of the DRPCs with a valid local failover plan that are due one
+func (h *LocalFailoverHeartbeater) heartbeat(ctx context.Context) error {
+	drpcs := &rmn.DRPlacementControlList{}
+	if err := h.List(ctx, drpcs); err != nil {
+		return fmt.Errorf("failed to list DRPCs: %w", err)
+	}
+
+	planned := sets.New[string]()
+
+	var errs []error
+
+	for i := range drpcs.Items {
+		drpc := &drpcs.Items[i]
+
+		if drpc.Spec.LocalFailover == nil || rmnutil.ResourceIsDeleted(drpc) {
+			continue
+		}
+
+		planned.Insert(client.ObjectKeyFromObject(drpc).String())
+		errs = append(errs, h.heartbeatDRPC(ctx, drpc))
+	}
+
+	localFailoverHeartbeatTimes.Range(func(key, _ any) bool {
+		if !planned.Has(key.(string)) {
+			localFailoverHeartbeatTimes.Delete(key)
+		}
+
+		return true
+	})
+
+	return errors.Join(errs...)
+}
+
+func (h *LocalFailoverHeartbeater) heartbeatDRPC(ctx context.Context, drpc *rmn.DRPlacementControl) error {
+	key := client.ObjectKeyFromObject(drpc).String()
+	log := h.Log.WithValues("drpc", key)
+
+	drPolicy, err := GetDRPolicy(ctx, h.Client, drpc, log)
+	if err != nil {
+		return fmt.Errorf("drpc %s: %w", key, err)
+	}
+
+	// An invalid plan is reported by the DRPC reconciler, and is not handed to any VRG
+	if localFailoverPlanValidate(drpc.Spec.LocalFailover, drPolicy) != nil {
+		return nil
+	}
+
+	drClusters, err := GetDRClusters(ctx, h.Client, drPolicy)
+	if err != nil {
+		return fmt.Errorf("drpc %s: %w", key, err)
+	}
+
+	placementObj, err := getPlacementOrPlacementRule(ctx, h.Client, drpc, log)
+	if err != nil {
+		return fmt.Errorf("drpc %s: %w", key, err)
+	}
+
+	vrgNamespace, err := selectVRGNamespace(h.Client, log, drpc, placementObj)
+	if err != nil {
+		return fmt.Errorf("drpc %s: %w", key, err)
+	}
+
+	return localFailoverHeartbeatUploadIfDue(ctx, h.APIReader, h.ObjectStoreGetter, drpc, drClusters, vrgNamespace,
+		log)
+}
+
+// localFailoverHeartbeatUploadIfDue uploads the plan of drpc along with a fresh hub heartbeat to all S3 stores, at
+// most once per heartbeat interval
+func localFailoverHeartbeatUploadIfDue(ctx context.Context, apiReader client.Reader,
+	objectStoreGetter ObjectStoreGetter, drpc *rmn.DRPlacementControl, drClusters []rmn.DRCluster,
+	vrgNamespace string, log logr.Logger,
+) error {
+	plan := drpc.Spec.LocalFailover
+	key := client.ObjectKeyFromObject(drpc).String()
+
+	if lastTime, ok := localFailoverHeartbeatTimes.Load(key); ok &&
+		time.Since(lastTime.(time.Time)) < localFailoverHeartbeatInterval(plan) {
+		return nil
+	}
+
+	heartbeat := LocalFailoverPlan{
+		FailoverCluster:         plan.FailoverCluster,
+		HubUnreachableThreshold: plan.HubUnreachableThreshold,
+		HubHeartbeat:            metav1.Now(),
+	}
+
+	for _, s3ProfileName := range AvailableS3Profiles(drClusters) {
+		objectStore, _, err := objectStoreGetter.ObjectStore(ctx, apiReader, s3ProfileName, key, log)
+		if err != nil {
+			return fmt.Errorf("drpc %s: failed to get object store %s for local failover heartbeat: %w",
+				key, s3ProfileName, err)
+		}
+
+		if err := localFailoverHeartbeatUpload(objectStore, vrgNamespace, drpc.Name, heartbeat); err != nil {
+			return fmt.Errorf("drpc %s: failed to upload local failover heartbeat to %s: %w", key, s3ProfileName, err)
+		}
+	}
+
+	localFailoverHeartbeatTimes.Store(key, time.Now())
+
+	return nil
+}
+
+// localFailoverHeartbeatUpload uploads heartbeat unless the plan uploaded changed since it was read, or has a more
+// recent heartbeat, so that a hub racing with another one, like the hub recovered from during a hub recovery, does
+// not overwrite the latest plan. The plan is uploaded unconditionally to an object store without conditional writes.
+func localFailoverHeartbeatUpload(objectStore ObjectStorer, vrgNamespace, vrgName string,
+	heartbeat LocalFailoverPlan,
+) error {
+	uploaded := LocalFailoverPlan{}
+
+	err := objectUpdate(objectStore, TypedObjectKey(s3PathNamePrefix(vrgNamespace, vrgName),
+		localFailoverPlanS3ObjectNameSuffix, heartbeat), &uploaded, func(exists bool) interface{} {
+		if exists && uploaded.HubHeartbeat.After(heartbeat.HubHeartbeat.Time) {
+			return nil
+		}
+
+		return heartbeat
+	})
+	if errors.Is(err, errConditionalWritesUnsupported) {
+		return LocalFailoverPlanUpload(objectStore, vrgNamespace, vrgName, heartbeat)
+	}
+
+	return err
+}
+
+// updateLocalFailoverCondition surfaces a local failover executed by a managed cluster. Once the hub observes
+// it, the DRPC must be failed over to the same cluster by the user to reconcile hub and managed cluster intent.
+func (d *DRPCInstance) updateLocalFailoverCondition() {
+	plan := d.localFailoverPlan()
+
+	vrg := d.vrgs[plan.FailoverCluster]
+	if vrg == nil || vrg.Status.LocalFailover == nil || vrg.Status.LocalFailover.ExecutedTime == nil {
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionLocalFailover, d.instance.Generation,
+			metav1.ConditionFalse, ReasonLocalFailoverArmed,
+			fmt.Sprintf("Local failover to cluster %s is armed", plan.FailoverCluster))
+
+		return
+	}
+
+	executedTime := vrg.Status.LocalFailover.ExecutedTime
+
+	if d.instance.Spec.Action == rmn.ActionFailover && drpcFailoverCluster(d.instance) == plan.FailoverCluster {
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionLocalFailover, d.instance.Generation,
+			metav1.ConditionTrue, ReasonLocalFailoverReconciled,
+			fmt.Sprintf("Local failover to cluster %s executed at %v is reconciled with the hub",
+				plan.FailoverCluster, executedTime))
+
+		return
+	}
+
+	msg := fmt.Sprintf("Cluster %s executed a local failover at %v while the hub was unreachable; "+
+		"fail over the DRPC to %s to reconcile", plan.FailoverCluster, executedTime, plan.FailoverCluster)
+
+	addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionLocalFailover, d.instance.Generation,
+		metav1.ConditionTrue, ReasonLocalFailoverExecuted, msg)
+	rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+		rmnutil.EventReasonLocalFailoverExecuted, msg)
+}
+
+// setVRGLocalFailover hands the local failover plan to the VRG of the plan's failover cluster, which executes it, and
+// to the primary VRG, which uploads the heartbeat that keeps the failover cluster from executing it while the primary
+// cluster is still running
+func (d *DRPCInstance) setVRGLocalFailover(vrg *rmn.VolumeReplicationGroup, homeCluster string) {
+	plan := d.localFailoverPlan()
+	if plan == nil || d.validateLocalFailoverPlan() != nil ||
+		(plan.FailoverCluster != homeCluster && vrg.Spec.ReplicationState != rmn.Primary) {
+		vrg.Spec.LocalFailover = nil
+
+		return
+	}
+
+	vrg.Spec.LocalFailover = plan.DeepCopy()
+}
@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("Local failover", func() {
+	plan := func(cluster string, threshold time.Duration) *rmn.LocalFailoverSpec {
+		return &rmn.LocalFailoverSpec{
+			FailoverCluster:         cluster,
+			HubUnreachableThreshold: metav1.Duration{Duration: threshold},
+		}
+	}
+
+	Context("of a DRPC", func() {
+		var (
+			d        *DRPCInstance
+			recorder *record.FakeRecorder
+		)
+
+		condition := func() *metav1.Condition {
+			return meta.FindStatusCondition(d.instance.Status.Conditions, rmn.ConditionLocalFailover)
+		}
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(10)
+			d = &DRPCInstance{
+				reconciler: &DRPlacementControlReconciler{eventRecorder: rmnutil.NewEventReporter(recorder)},
+				log:        logr.Discard(),
+				instance: &rmn.DRPlacementControl{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "edge"},
+					Spec: rmn.DRPlacementControlSpec{
+						PreferredCluster: "east",
+						LocalFailover:    plan("west", 10*time.Minute),
+					},
+				},
+				drPolicy: &rmn.DRPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+					Spec:       rmn.DRPolicySpec{DRClusters: []string{"east", "west"}},
+				},
+				vrgs: map[string]*rmn.VolumeReplicationGroup{},
+			}
+		})
+
+		It("hands the plan only to the VRG of its failover cluster and to the primary VRG", func() {
+			vrg := &rmn.VolumeReplicationGroup{Spec: rmn.VolumeReplicationGroupSpec{ReplicationState: rmn.Secondary}}
+
+			d.setVRGLocalFailover(vrg, "west")
+			Expect(vrg.Spec.LocalFailover).To(Equal(plan("west", 10*time.Minute)))
+
+			d.setVRGLocalFailover(vrg, "east")
+			Expect(vrg.Spec.LocalFailover).To(BeNil())
+
+			vrg.Spec.ReplicationState = rmn.Primary
+
+			d.setVRGLocalFailover(vrg, "east")
+			Expect(vrg.Spec.LocalFailover).To(Equal(plan("west", 10*time.Minute)))
+		})
+
+		DescribeTable("hands no invalid plan to any VRG, and reports it invalid",
+			func(invalid *rmn.LocalFailoverSpec) {
+				d.instance.Spec.LocalFailover = invalid
+				vrg := &rmn.VolumeReplicationGroup{Spec: rmn.VolumeReplicationGroupSpec{
+					LocalFailover: plan("west", 10*time.Minute),
+				}}
+
+				d.setVRGLocalFailover(vrg, invalid.FailoverCluster)
+				Expect(vrg.Spec.LocalFailover).To(BeNil())
+
+				d.reconcileLocalFailover()
+				Expect(condition().Reason).To(Equal(ReasonLocalFailoverInvalid))
+			},
+			Entry("with a threshold below the minimum", plan("west", time.Minute)),
+			Entry("with a cluster outside the DRPolicy", plan("north", 10*time.Minute)),
+		)
+
+		It("reports the plan armed until the failover cluster executes it", func() {
+			d.vrgs["west"] = &rmn.VolumeReplicationGroup{}
+
+			d.updateLocalFailoverCondition()
+			Expect(condition().Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition().Reason).To(Equal(ReasonLocalFailoverArmed))
+		})
+
+		It("reports a local failover executed while the hub was unreachable until the DRPC is failed over", func() {
+			d.vrgs["west"] = &rmn.VolumeReplicationGroup{Status: rmn.VolumeReplicationGroupStatus{
+				LocalFailover: &rmn.LocalFailoverStatus{ExecutedTime: &metav1.Time{Time: time.Now()}},
+			}}
+
+			d.updateLocalFailoverCondition()
+			Expect(condition().Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition().Reason).To(Equal(ReasonLocalFailoverExecuted))
+			Expect(recorder.Events).To(Receive(ContainSubstring(rmnutil.EventReasonLocalFailoverExecuted)))
+
+			d.instance.Spec.Action = rmn.ActionFailover
+			d.instance.Spec.FailoverCluster = "west"
+
+			d.updateLocalFailoverCondition()
+			Expect(condition().Reason).To(Equal(ReasonLocalFailoverReconciled))
+		})
+
+		It("removes the condition once the plan is removed", func() {
+			d.updateLocalFailoverCondition()
+			Expect(condition()).ToNot(BeNil())
+
+			d.instance.Spec.LocalFailover = nil
+
+			d.reconcileLocalFailover()
+			Expect(condition()).To(BeNil())
+		})
+
+		It("uploads the hub heartbeat to the S3 stores of its clusters at most once per heartbeat interval", func() {
+			objectStore := etagObjectStoreNew()
+			objectStoreGetter := qualificationObjectStoreGetter{"east": objectStore}
+			drClusters := []rmn.DRCluster{{Spec: rmn.DRClusterSpec{S3ProfileName: "east"}}}
+			key := TypedObjectKey(s3PathNamePrefix("app-vrgs", "edge"), localFailoverPlanS3ObjectNameSuffix,
+				LocalFailoverPlan{})
+
+			DeferCleanup(localFailoverHeartbeatTimes.Delete, "app/edge")
+
+			Expect(localFailoverHeartbeatUploadIfDue(context.TODO(), nil, objectStoreGetter, d.instance, drClusters,
+				"app-vrgs", logr.Discard())).To(Succeed())
+			Expect(objectStore.uploads[key]).To(Equal(1))
+
+			uploaded := LocalFailoverPlan{}
+			Expect(objectStore.DownloadObject(key, &uploaded)).To(Succeed())
+			Expect(uploaded.FailoverCluster).To(Equal("west"))
+
+			Expect(localFailoverHeartbeatUploadIfDue(context.TODO(), nil, objectStoreGetter, d.instance, drClusters,
+				"app-vrgs", logr.Discard())).To(Succeed())
+			Expect(objectStore.uploads[key]).To(Equal(1))
+		})
+	})
+
+	Context("of a VRG", func() {
+		var (
+			v           *VRGInstance
+			objectStore *etagObjectStore
+		)
+
+		heartbeat := func(ago time.Duration) {
+			Expect(LocalFailoverPlanUpload(objectStore, "app", "edge", LocalFailoverPlan{
+				FailoverCluster:         "west",
+				HubUnreachableThreshold: metav1.Duration{Duration: 10 * time.Minute},
+				HubHeartbeat:            metav1.NewTime(time.Now().Add(-ago)),
+			})).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			objectStore = etagObjectStoreNew()
+			v = &VRGInstance{
+				reconciler: &VolumeReplicationGroupReconciler{
+					eventRecorder: rmnutil.NewEventReporter(record.NewFakeRecorder(10)),
+				},
+				log:            logr.Discard(),
+				namespacedName: "app/edge",
+				instance: &rmn.VolumeReplicationGroup{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "edge"},
+					Spec: rmn.VolumeReplicationGroupSpec{
+						ReplicationState: rmn.Secondary,
+						LocalFailover:    plan("west", 10*time.Minute),
+					},
+				},
+				s3StoreAccessors: []s3StoreAccessor{{
+					ObjectStorer:   objectStore,
+					S3StoreProfile: rmn.S3StoreProfile{S3ProfileName: "west"},
+				}},
+			}
+		})
+
+		It("stays secondary while the hub heartbeat is fresh", func() {
+			heartbeat(time.Minute)
+
+			v.localFailoverEvaluate()
+			Expect(v.instance.Spec.ReplicationState).To(Equal(rmn.Secondary))
+			Expect(v.instance.Status.LocalFailover.LastHubHeartbeat).ToNot(BeNil())
+			Expect(v.instance.Status.LocalFailover.ExecutedTime).To(BeNil())
+		})
+
+		It("stays secondary while the hub heartbeat cannot be read", func() {
+			v.localFailoverEvaluate()
+			Expect(v.instance.Spec.ReplicationState).To(Equal(rmn.Secondary))
+			Expect(v.instance.Status.LocalFailover.ExecutedTime).To(BeNil())
+		})
+
+		primaryHeartbeat := func(ago time.Duration) {
+			Expect(uploadTypedObject(objectStore, s3PathNamePrefix("app", "edge"),
+				localFailoverPrimaryHeartbeatS3ObjectNameSuffix,
+				LocalFailoverPrimaryHeartbeat{Heartbeat: metav1.NewTime(time.Now().Add(-ago))})).To(Succeed())
+		}
+
+		It("stays secondary while the primary heartbeat is fresh, though the hub heartbeat is stale", func() {
+			heartbeat(11 * time.Minute)
+			primaryHeartbeat(time.Minute)
+
+			v.localFailoverEvaluate()
+			Expect(v.instance.Spec.ReplicationState).To(Equal(rmn.Secondary))
+			Expect(v.instance.Status.LocalFailover.LastPrimaryHeartbeat).ToNot(BeNil())
+			Expect(v.instance.Status.LocalFailover.ExecutedTime).To(BeNil())
+		})
+
+		It("fails over once the hub and primary heartbeats are stale beyond the threshold", func() {
+			heartbeat(11 * time.Minute)
+			primaryHeartbeat(11 * time.Minute)
+
+			v.localFailoverEvaluate()
+			Expect(v.instance.Spec.ReplicationState).To(Equal(rmn.Primary))
+			Expect(v.instance.Status.LocalFailover.ExecutedTime).ToNot(BeNil())
+		})
+
+		It("fails over once the hub heartbeat is stale beyond the threshold", func() {
+			heartbeat(11 * time.Minute)
+
+			v.localFailoverEvaluate()
+			Expect(v.instance.Spec.ReplicationState).To(Equal(rmn.Primary))
+			Expect(v.instance.Spec.Action).To(Equal(rmn.VRGActionFailover))
+			Expect(v.instance.Status.LocalFailover.ExecutedTime).ToNot(BeNil())
+		})
+
+		It("remains failed over when the hub returns, until the hub fails over the DRPC or removes the plan", func() {
+			heartbeat(11 * time.Minute)
+			v.localFailoverEvaluate()
+
+			// the work agent reverts the VRG spec to the hub's intent as the hub returns
+			heartbeat(0)
+			v.instance.Spec.ReplicationState = rmn.Secondary
+
+			v.localFailoverEvaluate()
+			Expect(v.instance.Spec.ReplicationState).To(Equal(rmn.Primary))
+
+			v.instance.Spec.LocalFailover = nil
+			v.instance.Spec.ReplicationState = rmn.Secondary
+
+			v.localFailoverEvaluate()
+			Expect(v.instance.Spec.ReplicationState).To(Equal(rmn.Secondary))
+			Expect(v.instance.Status.LocalFailover).To(BeNil())
+		})
+
+		It("follows the hub once it changes the VRG spec after the local failover", func() {
+			heartbeat(11 * time.Minute)
+			v.localFailoverEvaluate()
+			Expect(v.instance.Status.LocalFailover.ExecutedTime).ToNot(BeNil())
+
+			// the hub relocates back to the former primary cluster, keeping the plan
+			heartbeat(0)
+			v.instance.Generation++
+			v.instance.Spec.ReplicationState = rmn.Secondary
+			v.instance.Spec.Action = rmn.VRGActionRelocate
+
+			v.localFailoverEvaluate()
+			Expect(v.instance.Spec.ReplicationState).To(Equal(rmn.Secondary))
+			Expect(v.instance.Spec.Action).To(Equal(rmn.VRGActionRelocate))
+			Expect(v.instance.Status.LocalFailover.ExecutedTime).To(BeNil())
+		})
+
+		It("uploads the heartbeat of a primary VRG", func() {
+			DeferCleanup(localFailoverPrimaryHeartbeatTimes.Delete, "app/edge")
+
+			v.instance.Spec.ReplicationState = rmn.Primary
+
+			v.localFailoverEvaluate()
+			Expect(v.instance.Status.LocalFailover.ExecutedTime).To(BeNil())
+
+			heartbeat, found, err := localFailoverPrimaryHeartbeatDownload(objectStore, s3PathNamePrefix("app", "edge"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(heartbeat.Heartbeat.Time).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("keeps a secondary checking the hub heartbeat", func() {
+			Expect(v.localFailoverRequeue(ctrl.Result{}).RequeueAfter).
+				To(Equal(10 * time.Minute / localFailoverHeartbeatsPerThreshold))
+			Expect(v.localFailoverRequeue(ctrl.Result{RequeueAfter: time.Second}).RequeueAfter).To(Equal(time.Second))
+		})
+	})
+})
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+// updateManifestWorkSplitCondition reports, using the ManifestWorkSplit condition, if a ManifestWork of the DRPC
+// could not be delivered, as a single manifest of it exceeds the ManifestWork size limit, or was split into multiple
+// ManifestWorks, as it exceeded the limit. A previously reported condition is cleared once processing succeeds
+// without splitting.
+func (d *DRPCInstance) updateManifestWorkSplitCondition(processingErr error) {
+	var tooLargeErr rmnutil.ManifestWorkTooLargeError
+
+	if errors.As(processingErr, &tooLargeErr) {
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionManifestWorkSplit, d.instance.Generation,
+			metav1.ConditionFalse, ReasonManifestTooLarge, tooLargeErr.Error())
+
+		return
+	}
+
+	if len(d.mwu.SplitManifestWorks) != 0 {
+		split := []string{}
+		for _, name := range slices.Sorted(maps.Keys(d.mwu.SplitManifestWorks)) {
+			split = append(split, fmt.Sprintf("%s into %d", name, d.mwu.SplitManifestWorks[name]))
+		}
+
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionManifestWorkSplit, d.instance.Generation,
+			metav1.ConditionTrue, ReasonManifestWorkSplit,
+			fmt.Sprintf("ManifestWorks split as their manifests exceed %d bytes: %s",
+				rmnutil.ManifestWorkSizeLimit, strings.Join(split, ", ")))
+
+		return
+	}
+
+	condition := meta.FindStatusCondition(d.instance.Status.Conditions, rmn.ConditionManifestWorkSplit)
+	if condition != nil && condition.Reason != ReasonManifestWorkNotSplit && processingErr == nil {
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionManifestWorkSplit, d.instance.Generation,
+			metav1.ConditionFalse, ReasonManifestWorkNotSplit, "ManifestWorks are within the size limit")
+	}
+}
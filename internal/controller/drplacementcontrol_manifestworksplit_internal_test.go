@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("DRPC ManifestWorkSplit condition", func() {
+	var d *DRPCInstance
+
+	condition := func() (string, string) {
+		condition := meta.FindStatusCondition(d.instance.Status.Conditions, rmn.ConditionManifestWorkSplit)
+		Expect(condition).ToNot(BeNil())
+
+		return string(condition.Status), condition.Reason
+	}
+
+	BeforeEach(func() {
+		d = &DRPCInstance{log: logr.Discard(), instance: &rmn.DRPlacementControl{}}
+	})
+
+	It("is not reported while the ManifestWorks are within the size limit", func() {
+		d.updateManifestWorkSplitCondition(nil)
+		Expect(d.instance.Status.Conditions).To(BeEmpty())
+	})
+
+	It("reports a manifest exceeding the size limit, until it is delivered", func() {
+		tooLargeErr := rmnutil.ManifestWorkTooLargeError{Name: "east/vrg-mw", Size: 600000, Limit: 512000}
+
+		d.updateManifestWorkSplitCondition(fmt.Errorf("failed to update VRG: %w", tooLargeErr))
+		status, reason := condition()
+		Expect(status).To(Equal("False"))
+		Expect(reason).To(Equal(ReasonManifestTooLarge))
+
+		d.updateManifestWorkSplitCondition(nil)
+		_, reason = condition()
+		Expect(reason).To(Equal(ReasonManifestWorkNotSplit))
+	})
+
+	It("reports the ManifestWorks split into multiple ManifestWorks", func() {
+		d.mwu.SplitManifestWorks = map[string]int{"app-ns-mw": 2}
+
+		d.updateManifestWorkSplitCondition(nil)
+		status, reason := condition()
+		Expect(status).To(Equal("True"))
+		Expect(reason).To(Equal(ReasonManifestWorkSplit))
+	})
+})
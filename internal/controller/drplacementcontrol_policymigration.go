@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+// ReasonPolicyMigrationBlocked is the reason of the PolicyMigration condition while the migration of a DRPC to the
+// DRPolicy of its drPolicyRef is not valid, or not possible yet
+const ReasonPolicyMigrationBlocked = "Blocked"
+
+// drPolicyForReconcile returns the DRPolicy the DRPC is reconciled with. Once the drPolicyRef of the DRPC is changed,
+// the DRPC is reconciled with its observed DRPolicy until its migration to the DRPolicy of the drPolicyRef is
+// validated, and with the DRPolicy of the drPolicyRef from then on, which the VRGs are then updated to.
+func (r *DRPlacementControlReconciler) drPolicyForReconcile(ctx context.Context, drpc *rmn.DRPlacementControl,
+	log logr.Logger,
+) (*rmn.DRPolicy, error) {
+	drPolicy, err := r.getAndEnsureValidDRPolicy(ctx, drpc, log)
+
+	observed := drpc.Status.ObservedDRPolicy
+	if observed == "" || observed == drpc.Spec.DRPolicyRef.Name {
+		if err == nil {
+			drpc.Status.ObservedDRPolicy = drPolicy.Name
+		}
+
+		return drPolicy, err
+	}
+
+	previous := &rmn.DRPolicy{}
+	if err1 := r.Client.Get(ctx, types.NamespacedName{Name: observed}, previous); err1 != nil {
+		// the DRPC has no DRPolicy to be reconciled with other than that of its drPolicyRef
+		log.Info("Observed DRPolicy of the DRPC is not available", "drpolicy", observed, "error", err1)
+
+		previous = nil
+	}
+
+	if err == nil && previous != nil {
+		err = r.drPolicyMigrationValidate(ctx, drpc, previous, drPolicy)
+	}
+
+	if err != nil {
+		addOrUpdateCondition(&drpc.Status.Conditions, rmn.ConditionPolicyMigration, drpc.Generation,
+			metav1.ConditionFalse, ReasonPolicyMigrationBlocked,
+			fmt.Sprintf("Migration from DRPolicy %s to %s is blocked: %v", observed, drpc.Spec.DRPolicyRef.Name, err))
+
+		if previous == nil {
+			return nil, err
+		}
+
+		return previous, rmnutil.DrpolicyValidated(previous)
+	}
+
+	log.Info("Migrating the DRPC to the DRPolicy of its drPolicyRef", "from", observed, "to", drPolicy.Name)
+
+	addOrUpdateCondition(&drpc.Status.Conditions, rmn.ConditionPolicyMigration, drpc.Generation,
+		metav1.ConditionFalse, rmn.ReasonProgressing,
+		fmt.Sprintf("Migrating from DRPolicy %s to %s, updating the VRGs", observed, drPolicy.Name))
+
+	drpc.Status.ObservedDRPolicy = drPolicy.Name
+
+	return drPolicy, nil
+}
+
+// drPolicyMigrationValidate returns an error if the DRPC cannot migrate from the previous DRPolicy to drPolicy yet:
+// the DRPC is not idle, the policies differ in their clusters, replication type or the storage classes they
+// replicate, or the clusters have not processed the replication schedules of drPolicy
+func (r *DRPlacementControlReconciler) drPolicyMigrationValidate(ctx context.Context, drpc *rmn.DRPlacementControl,
+	previous, drPolicy *rmn.DRPolicy,
+) error {
+	if drpc.Status.Progression != rmn.ProgressionCompleted {
+		return fmt.Errorf("DRPC progression is %s, waiting for it to complete", drpc.Status.Progression)
+	}
+
+	if err := drPolicyMigrationCompatible(previous, drPolicy); err != nil {
+		return err
+	}
+
+	drClusters, err := GetDRClusters(ctx, r.Client, drPolicy)
+	if err != nil {
+		return err
+	}
+
+	return drPolicySchedulesProcessed(drPolicy, drClusters)
+}
+
+// drPolicyMigrationCompatible returns an error unless drPolicy has the clusters and the replication type of the
+// previous DRPolicy, and replicates all the storage classes it does
+func drPolicyMigrationCompatible(previous, drPolicy *rmn.DRPolicy) error {
+	if !rmnutil.DRPolicyClusterNamesAsASet(previous).Equal(rmnutil.DRPolicyClusterNamesAsASet(drPolicy)) {
+		return fmt.Errorf("clusters %v differ from the clusters %v of DRPolicy %s",
+			drPolicy.Spec.DRClusters, previous.Spec.DRClusters, previous.Name)
+	}
+
+	if (previous.Spec.SchedulingInterval == "") != (drPolicy.Spec.SchedulingInterval == "") {
+		return fmt.Errorf("replication type differs from that of DRPolicy %s", previous.Name)
+	}
+
+	storageClasses := func(drPolicy *rmn.DRPolicy) sets.Set[string] {
+		names := sets.New[string]()
+
+		for _, peerClass := range append(drPolicy.Status.Async.PeerClasses, drPolicy.Status.Sync.PeerClasses...) {
+			names.Insert(peerClass.StorageClassName)
+		}
+
+		return names
+	}
+
+	if missing := storageClasses(previous).Difference(storageClasses(drPolicy)); missing.Len() > 0 {
+		return fmt.Errorf("storage classes %v replicated by DRPolicy %s are not", sets.List(missing), previous.Name)
+	}
+
+	return nil
+}
+
+// drPolicySchedulesProcessed returns an error unless each of drClusters processed the replication schedules of
+// drPolicy, for the VRGs to find replication classes with the schedules once they are updated
+func drPolicySchedulesProcessed(drPolicy *rmn.DRPolicy, drClusters []rmn.DRCluster) error {
+	schedules := rmnutil.DRPolicySchedulingIntervals(drPolicy)
+
+	for i := range drClusters {
+		clusterConfig := drClusters[i].Status.ClusterConfig
+		if clusterConfig == nil {
+			return fmt.Errorf("cluster %s has not reported its configuration", drClusters[i].Name)
+		}
+
+		for _, schedule := range schedules {
+			if !slices.Contains(clusterConfig.ProcessedSchedules, schedule) {
+				return fmt.Errorf("cluster %s has not processed replication schedule %s", drClusters[i].Name,
+					schedule)
+			}
+		}
+	}
+
+	return nil
+}
+
+// updatePolicyMigrationCondition completes the migration of the DRPC to its DRPolicy once its primary VRGs report
+// that they were reconciled with the replication spec of the DRPolicy
+func (d *DRPCInstance) updatePolicyMigrationCondition() {
+	condition := rmnutil.FindCondition(d.instance.Status.Conditions, rmn.ConditionPolicyMigration)
+	if condition == nil || condition.Reason != rmn.ReasonProgressing || len(d.vrgs) == 0 {
+		return
+	}
+
+	for _, vrg := range d.vrgs {
+		if !vrgPolicyMigrated(vrg, d.drPolicy) {
+			return
+		}
+	}
+
+	addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionPolicyMigration, d.instance.Generation,
+		metav1.ConditionTrue, rmn.ReasonSuccess, "Migrated to DRPolicy "+d.drPolicy.Name)
+}
+
+// vrgPolicyMigrated returns whether vrg, unless secondary, was reconciled with the replication spec of drPolicy
+func vrgPolicyMigrated(vrg *rmn.VolumeReplicationGroup, drPolicy *rmn.DRPolicy) bool {
+	if vrg.Spec.ReplicationState != rmn.Primary {
+		return true
+	}
+
+	if vrg.Status.ObservedGeneration != vrg.Generation {
+		return false
+	}
+
+	async := vrg.Spec.Async
+	if drPolicy.Spec.SchedulingInterval == "" || async == nil {
+		return true
+	}
+
+	return async.SchedulingInterval == drPolicy.Spec.SchedulingInterval &&
+		reflect.DeepEqual(async.ReplicationClassSelector, drPolicy.Spec.ReplicationClassSelector) &&
+		reflect.DeepEqual(async.VolumeSnapshotClassSelector, drPolicy.Spec.VolumeSnapshotClassSelector)
+}
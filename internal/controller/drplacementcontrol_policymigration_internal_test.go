@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPC DRPolicy migration", func() {
+	drPolicy := func(name, interval string, clusters []string, storageClasses ...string) *rmn.DRPolicy {
+		policy := &rmn.DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       rmn.DRPolicySpec{DRClusters: clusters, SchedulingInterval: interval},
+		}
+
+		for _, storageClass := range storageClasses {
+			policy.Status.Async.PeerClasses = append(policy.Status.Async.PeerClasses,
+				rmn.PeerClass{StorageClassName: storageClass})
+		}
+
+		return policy
+	}
+
+	clusters := []string{"east", "west"}
+	previous := drPolicy("previous", "5m", clusters, "rbd")
+
+	It("allows a policy of the same clusters and replication type that replicates the same storage classes", func() {
+		Expect(drPolicyMigrationCompatible(previous, drPolicy("next", "1h", clusters, "rbd", "cephfs"))).To(Succeed())
+	})
+
+	It("rejects a policy of other clusters, replication type or storage classes", func() {
+		Expect(drPolicyMigrationCompatible(previous, drPolicy("next", "5m", []string{"east", "north"}, "rbd"))).
+			ToNot(Succeed())
+		Expect(drPolicyMigrationCompatible(previous, drPolicy("next", "", clusters, "rbd"))).ToNot(Succeed())
+		Expect(drPolicyMigrationCompatible(previous, drPolicy("next", "5m", clusters, "cephfs"))).ToNot(Succeed())
+	})
+
+	It("waits for the clusters to process the schedules of the policy", func() {
+		next := drPolicy("next", "1h", clusters, "rbd")
+		drCluster := func(name string, schedules ...string) rmn.DRCluster {
+			return rmn.DRCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: rmn.DRClusterStatus{
+					ClusterConfig: &rmn.DRClusterConfigResult{ProcessedSchedules: schedules},
+				},
+			}
+		}
+
+		Expect(drPolicySchedulesProcessed(next, []rmn.DRCluster{drCluster("east", "1h"), drCluster("west", "5m")})).
+			ToNot(Succeed())
+		Expect(drPolicySchedulesProcessed(next, []rmn.DRCluster{drCluster("east", "1h"), drCluster("west", "1h")})).
+			To(Succeed())
+	})
+
+	It("completes once the primary VRG is reconciled with the replication spec of the policy", func() {
+		next := drPolicy("next", "1h", clusters, "rbd")
+		vrg := &rmn.VolumeReplicationGroup{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec: rmn.VolumeReplicationGroupSpec{
+				ReplicationState: rmn.Primary,
+				Async:            &rmn.VRGAsyncSpec{SchedulingInterval: "5m"},
+			},
+			Status: rmn.VolumeReplicationGroupStatus{ObservedGeneration: 2},
+		}
+		Expect(vrgPolicyMigrated(vrg, next)).To(BeFalse())
+
+		vrg.Spec.Async.SchedulingInterval = "1h"
+		vrg.Generation = 3
+		Expect(vrgPolicyMigrated(vrg, next)).To(BeFalse())
+
+		vrg.Status.ObservedGeneration = 3
+		Expect(vrgPolicyMigrated(vrg, next)).To(BeTrue())
+	})
+})
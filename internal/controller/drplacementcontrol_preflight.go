@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+// drpcPreflightCheck is a check run before a Failover or Relocate starts. check returns why the action is not to
+// start to targetCluster, or an empty string if it passes.
+type drpcPreflightCheck struct {
+	name  string
+	check func(d *DRPCInstance, action rmn.DRAction, targetCluster string) string
+}
+
+// drpcPreflightChecks are the checks run before a Failover or Relocate starts, in order. A check whose state is not
+// reported yet passes, so that a missing status does not block the action.
+var drpcPreflightChecks = []drpcPreflightCheck{
+	{name: "TargetCluster", check: preflightCheckTargetCluster},
+	{name: "S3Profiles", check: preflightCheckS3Profiles},
+	{name: "PeerClasses", check: preflightCheckPeerClasses},
+	{name: "RPO", check: preflightCheckRPO},
+	{name: "StaleData", check: preflightCheckStaleData},
+	{name: "Fencing", check: preflightCheckFencing},
+}
+
+// preflightDue returns whether the action is yet to start, hence its preflight checks are to run. Once an action
+// started, it is not to be interrupted by a check failing.
+func (d *DRPCInstance) preflightDue() bool {
+	return d.getLastDRState() == rmn.Initiating && slices.Contains([]rmn.ProgressionStatus{
+		"",
+		rmn.ProgressionWaitOnGlobalAction,
+		rmn.ProgressionWaitOnFailoverDependencies,
+		rmn.ProgressionWaitOnPreflightChecks,
+	}, d.getProgression())
+}
+
+// preflightPassed runs the preflight checks of the action to targetCluster, reports them in the PreflightPassed
+// condition, and returns whether the action is to start: all the checks passed, or the user forced the action
+func (d *DRPCInstance) preflightPassed(targetCluster string) bool {
+	action := d.instance.Spec.Action
+	log := d.log.WithName("Preflight").WithValues("action", action, "targetCluster", targetCluster)
+
+	var failed []string
+
+	for _, preflightCheck := range drpcPreflightChecks {
+		if reason := preflightCheck.check(d, action, targetCluster); reason != "" {
+			failed = append(failed, fmt.Sprintf("%s: %s", preflightCheck.name, reason))
+		}
+	}
+
+	if len(failed) == 0 {
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionPreflightPassed, d.instance.Generation,
+			metav1.ConditionTrue, ReasonPreflightChecksPassed, fmt.Sprintf("%s preflight checks passed", action))
+
+		return true
+	}
+
+	msg := "Failed: " + strings.Join(failed, "; ")
+
+	if d.instance.Spec.Force {
+		log.Info("Preflight checks failed, forced", "failed", failed)
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionPreflightPassed, d.instance.Generation,
+			metav1.ConditionTrue, ReasonPreflightChecksForced, msg)
+
+		return true
+	}
+
+	log.Info("Preflight checks failed", "failed", failed)
+	addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionPreflightPassed, d.instance.Generation,
+		metav1.ConditionFalse, ReasonPreflightChecksFailed, msg)
+	d.setProgression(rmn.ProgressionWaitOnPreflightChecks)
+
+	return false
+}
+
+// preflightDRCluster returns the DRCluster of the DRPolicy named cluster, or nil if it is not found
+func (d *DRPCInstance) preflightDRCluster(cluster string) *rmn.DRCluster {
+	for i := range d.drClusters {
+		if d.drClusters[i].Name == cluster {
+			return &d.drClusters[i]
+		}
+	}
+
+	return nil
+}
+
+// preflightCheckTargetCluster checks that the ManagedCluster of the target is available and its DRCluster validated
+func preflightCheckTargetCluster(d *DRPCInstance, _ rmn.DRAction, targetCluster string) string {
+	if !rmnutil.ManagedClusterAvailable(d.ctx, d.reconciler.Client, targetCluster) {
+		return fmt.Sprintf("cluster %s is unavailable", targetCluster)
+	}
+
+	drCluster := d.preflightDRCluster(targetCluster)
+	if drCluster == nil {
+		return fmt.Sprintf("cluster %s is not a DRCluster of DRPolicy %s", targetCluster, d.drPolicy.Name)
+	}
+
+	condition := meta.FindStatusCondition(drCluster.Status.Conditions, rmn.DRClusterValidated)
+	if condition != nil && condition.Status != metav1.ConditionTrue {
+		return fmt.Sprintf("DRCluster %s is not validated: %s", targetCluster, condition.Message)
+	}
+
+	return ""
+}
+
+// preflightCheckS3Profiles checks that the S3 profile of the target is not reported unavailable, as the VRG is
+// restored from it
+func preflightCheckS3Profiles(d *DRPCInstance, _ rmn.DRAction, targetCluster string) string {
+	drCluster := d.preflightDRCluster(targetCluster)
+	if drCluster == nil {
+		return ""
+	}
+
+	condition := meta.FindStatusCondition(drCluster.Status.Conditions, rmn.DRClusterConditionTypeS3ProfileAvailable)
+	if condition != nil && condition.Status == metav1.ConditionFalse {
+		return fmt.Sprintf("S3 profile %s of DRCluster %s is unavailable: %s", drCluster.Spec.S3ProfileName,
+			targetCluster, condition.Message)
+	}
+
+	return ""
+}
+
+// preflightCheckPeerClasses checks that the peer classes the VRGs replicate with are still peer classes of the
+// DRPolicy, as the target cluster may otherwise lack the StorageClasses of the PVCs
+func preflightCheckPeerClasses(d *DRPCInstance, _ rmn.DRAction, _ string) string {
+	var missing []string
+
+	peerClassesCheck := func(vrgPeerClasses, policyPeerClasses []rmn.PeerClass) {
+		if len(policyPeerClasses) == 0 {
+			return
+		}
+
+		for i := range vrgPeerClasses {
+			peerClass := &vrgPeerClasses[i]
+			if !hasPeerClass(policyPeerClasses, peerClass.StorageClassName, peerClass.ClusterIDs) &&
+				!slices.Contains(missing, peerClass.StorageClassName) {
+				missing = append(missing, peerClass.StorageClassName)
+			}
+		}
+	}
+
+	for _, vrg := range d.vrgs {
+		if vrg.Spec.Async != nil {
+			peerClassesCheck(vrg.Spec.Async.PeerClasses, d.drPolicy.Status.Async.PeerClasses)
+		}
+
+		if vrg.Spec.Sync != nil {
+			peerClassesCheck(vrg.Spec.Sync.PeerClasses, d.drPolicy.Status.Sync.PeerClasses)
+		}
+	}
+
+	if len(missing) != 0 {
+		slices.Sort(missing)
+
+		return fmt.Sprintf("StorageClasses %s are no longer peer classes of DRPolicy %s", strings.Join(missing, ", "),
+			d.drPolicy.Name)
+	}
+
+	return ""
+}
+
+// preflightCheckRPO checks that a Failover does not lose more data than the RPO target of the DRPolicy allows
+func preflightCheckRPO(d *DRPCInstance, action rmn.DRAction, _ string) string {
+	if action != rmn.ActionFailover {
+		return ""
+	}
+
+	condition := meta.FindStatusCondition(d.instance.Status.Conditions, rmn.ConditionRPOViolated)
+	if condition != nil && condition.Status == metav1.ConditionTrue {
+		return "RPO target is violated: " + condition.Message
+	}
+
+	return ""
+}
+
+// preflightCheckStaleData checks that a planned action, a Relocate or a Failover from a cluster that is available,
+// does not recover data older than the staleDataThreshold of the DRPolicy, unless the user allows stale data. A
+// Failover from an unavailable cluster is the recovery of the workload, with the most recent data there is.
+func preflightCheckStaleData(d *DRPCInstance, action rmn.DRAction, _ string) string {
+	threshold := d.drPolicy.Spec.StaleDataThreshold
+	lastGroupSyncTime := d.instance.Status.LastGroupSyncTime
+
+	if threshold == nil || lastGroupSyncTime == nil || d.instance.Spec.AllowStaleData {
+		return ""
+	}
+
+	if action == rmn.ActionFailover {
+		homeCluster := d.instance.Status.PreferredDecision.ClusterName
+		if homeCluster == "" || !rmnutil.ManagedClusterAvailable(d.ctx, d.reconciler.Client, homeCluster) {
+			return ""
+		}
+	}
+
+	age := time.Since(lastGroupSyncTime.Time)
+	if age <= threshold.Duration {
+		return ""
+	}
+
+	return fmt.Sprintf("last group sync at %s is %v old, older than the staleDataThreshold %v of DRPolicy %s; "+
+		"set allowStaleData to %s with the stale data", lastGroupSyncTime.UTC().Format(time.RFC3339),
+		age.Truncate(time.Second), threshold.Duration, d.drPolicy.Name, strings.ToLower(string(action)))
+}
+
+// preflightCheckFencing checks, for synchronous replication, that a Failover is not to a fenced cluster and that a
+// Relocate is not while a cluster is fenced, as the workload cannot write to the storage of a fenced cluster
+func preflightCheckFencing(d *DRPCInstance, action rmn.DRAction, targetCluster string) string {
+	if sync, _, err := dRPolicySupportsMetro(d.drPolicy, nil); err != nil || !sync {
+		return ""
+	}
+
+	for i := range d.drClusters {
+		drCluster := &d.drClusters[i]
+		if drCluster.Status.Phase != rmn.Fenced {
+			continue
+		}
+
+		if action == rmn.ActionRelocate {
+			return fmt.Sprintf("DRCluster %s is fenced, unfence it before the relocate", drCluster.Name)
+		}
+
+		if drCluster.Name == targetCluster {
+			return fmt.Sprintf("failover cluster %s is fenced", targetCluster)
+		}
+	}
+
+	return ""
+}
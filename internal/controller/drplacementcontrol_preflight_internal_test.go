@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ocmv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPC preflight checks", func() {
+	var (
+		d          *DRPCInstance
+		fakeClient client.Client
+	)
+
+	preflightCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(d.instance.Status.Conditions, rmn.ConditionPreflightPassed)
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmv1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&ocmv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "east"}},
+			&ocmv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "west"}},
+		).Build()
+
+		d = &DRPCInstance{
+			reconciler: &DRPlacementControlReconciler{Client: fakeClient},
+			ctx:        context.TODO(),
+			log:        logr.Discard(),
+			instance: &rmn.DRPlacementControl{
+				Spec: rmn.DRPlacementControlSpec{Action: rmn.ActionFailover, FailoverCluster: "west"},
+				Status: rmn.DRPlacementControlStatus{
+					Phase: rmn.Initiating,
+				},
+			},
+			drPolicy: &rmn.DRPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+				Spec:       rmn.DRPolicySpec{DRClusters: []string{"east", "west"}, SchedulingInterval: "5m"},
+			},
+			drClusters: []rmn.DRCluster{
+				{ObjectMeta: metav1.ObjectMeta{Name: "east"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "west"}},
+			},
+			vrgs: map[string]*rmn.VolumeReplicationGroup{},
+		}
+	})
+
+	It("passes the action whose checks pass", func() {
+		Expect(d.preflightDue()).To(BeTrue())
+		Expect(d.preflightPassed("west")).To(BeTrue())
+
+		condition := preflightCondition()
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(ReasonPreflightChecksPassed))
+	})
+
+	It("holds the action whose checks fail", func() {
+		meta.SetStatusCondition(&d.drClusters[1].Status.Conditions, metav1.Condition{
+			Type: rmn.DRClusterValidated, Status: metav1.ConditionFalse, Reason: "Failed", Message: "s3 unreachable",
+		})
+		d.instance.Status.Conditions = append(d.instance.Status.Conditions, metav1.Condition{
+			Type: rmn.ConditionRPOViolated, Status: metav1.ConditionTrue, Reason: "Violated",
+		})
+
+		Expect(d.preflightPassed("west")).To(BeFalse())
+		Expect(d.getProgression()).To(Equal(rmn.ProgressionWaitOnPreflightChecks))
+		Expect(d.preflightDue()).To(BeTrue())
+
+		condition := preflightCondition()
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(ReasonPreflightChecksFailed))
+		Expect(condition.Message).To(ContainSubstring("TargetCluster"))
+		Expect(condition.Message).To(ContainSubstring("RPO"))
+	})
+
+	It("checks the peer classes of the VRGs are peer classes of the DRPolicy", func() {
+		d.drPolicy.Status.Async.PeerClasses = []rmn.PeerClass{
+			{StorageClassName: "rbd", ClusterIDs: []string{"east-id", "west-id"}},
+		}
+		d.vrgs["east"] = &rmn.VolumeReplicationGroup{Spec: rmn.VolumeReplicationGroupSpec{
+			Async: &rmn.VRGAsyncSpec{PeerClasses: []rmn.PeerClass{
+				{StorageClassName: "rbd", ClusterIDs: []string{"east-id", "west-id"}},
+				{StorageClassName: "cephfs", ClusterIDs: []string{"east-id", "west-id"}},
+			}},
+		}}
+
+		Expect(d.preflightPassed("west")).To(BeFalse())
+		Expect(preflightCondition().Message).To(ContainSubstring("StorageClasses cephfs"))
+	})
+
+	It("starts the action whose checks fail if forced", func() {
+		d.instance.Spec.Force = true
+		d.instance.Status.Conditions = append(d.instance.Status.Conditions, metav1.Condition{
+			Type: rmn.ConditionRPOViolated, Status: metav1.ConditionTrue, Reason: "Violated",
+		})
+
+		Expect(d.preflightPassed("west")).To(BeTrue())
+
+		condition := preflightCondition()
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(ReasonPreflightChecksForced))
+	})
+
+	Context("with a staleDataThreshold", func() {
+		BeforeEach(func() {
+			d.drPolicy.Spec.StaleDataThreshold = &metav1.Duration{Duration: time.Hour}
+			d.instance.Status.PreferredDecision.ClusterName = "east"
+			d.instance.Status.LastGroupSyncTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+		})
+
+		It("refuses a planned failover to stale data", func() {
+			Expect(d.preflightPassed("west")).To(BeFalse())
+			Expect(preflightCondition().Message).To(ContainSubstring("StaleData"))
+		})
+
+		It("refuses a relocate to stale data", func() {
+			d.instance.Spec.Action = rmn.ActionRelocate
+
+			Expect(d.preflightPassed("west")).To(BeFalse())
+			Expect(preflightCondition().Message).To(ContainSubstring("allowStaleData"))
+		})
+
+		It("starts a planned failover to stale data if allowed", func() {
+			d.instance.Spec.AllowStaleData = true
+
+			Expect(d.preflightPassed("west")).To(BeTrue())
+			Expect(preflightCondition().Reason).To(Equal(ReasonPreflightChecksPassed))
+		})
+
+		It("starts a planned failover to data within the threshold", func() {
+			d.instance.Status.LastGroupSyncTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+
+			Expect(d.preflightPassed("west")).To(BeTrue())
+		})
+
+		It("starts a failover from an unavailable cluster to stale data", func() {
+			east := &ocmv1.ManagedCluster{}
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Name: "east"}, east)).To(Succeed())
+			east.Status.Conditions = []metav1.Condition{{
+				Type: ocmv1.ManagedClusterConditionAvailable, Status: metav1.ConditionFalse, Reason: "Lost",
+				LastTransitionTime: metav1.Now(),
+			}}
+			Expect(fakeClient.Update(context.TODO(), east)).To(Succeed())
+
+			Expect(d.preflightPassed("west")).To(BeTrue())
+		})
+	})
+
+	It("does not run the checks once the action started", func() {
+		d.instance.Status.Progression = rmn.ProgressionFailingOverToCluster
+
+		Expect(d.preflightDue()).To(BeFalse())
+	})
+})
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	// actionProgressDeploy is the action of the progress of the initial deployment, as the DRPC has no action then
+	actionProgressDeploy = "Deploy"
+
+	// actionProgressPhasesMax bounds the progressions recorded in the progress of an action
+	actionProgressPhasesMax = 32
+
+	actionProgressCompleted = 100
+)
+
+// actionProgressMilestones are the progressions of each action in their usual order, from which the percentage of
+// its progress is estimated. An action may skip some of them, or go through others, which do not change it. See the
+// progressions of each action next to setProgression.
+var actionProgressMilestones = map[string][]rmn.ProgressionStatus{
+	actionProgressDeploy: {
+		rmn.ProgressionCreatingMW,
+		rmn.ProgressionUpdatingPlRule,
+		rmn.ProgressionEnsuringVolSyncSetup,
+		rmn.ProgressionSettingupVolsyncDest,
+	},
+	string(rmn.ActionFailover): {
+		rmn.ProgressionCheckingFailoverPrerequisites,
+		rmn.ProgressionWaitForFencing,
+		rmn.ProgressionWaitForStorageMaintenanceActivation,
+		rmn.ProgressionFailingOverToCluster,
+		rmn.ProgressionWaitingForResourceRestore,
+		rmn.ProgressionEnsuringVolSyncSetup,
+		rmn.ProgressionSettingupVolsyncDest,
+		rmn.ProgressionWaitForReadiness,
+		rmn.ProgressionCleanupReadiness,
+		rmn.ProgressionUpdatedPlacement,
+	},
+	string(rmn.ActionRelocate): {
+		rmn.ProgressionPreparingFinalSync,
+		rmn.ProgressionClearingPlacement,
+		rmn.ProgressionRunningFinalSync,
+		rmn.ProgressionFinalSyncComplete,
+		rmn.ProgressionEnsuringVolumesAreSecondary,
+		rmn.ProgressionWaitingForResourceRestore,
+		rmn.ProgressionWaitForReadiness,
+		rmn.ProgressionUpdatedPlacement,
+		rmn.ProgressionEnsuringVolSyncSetup,
+		rmn.ProgressionSettingupVolsyncDest,
+	},
+}
+
+// actionProgressUpdate records in the progress of drpc that its progression changes to nextProgression at now. A new
+// progress starts when the action of the DRPC changes, or as an action starts, when its progression is reset.
+func actionProgressUpdate(drpc *rmn.DRPlacementControl, nextProgression rmn.ProgressionStatus, now time.Time) {
+	action := string(drpc.Spec.Action)
+	if action == "" {
+		action = actionProgressDeploy
+	}
+
+	progress := drpc.Status.Progress
+	if progress == nil || progress.Action != action || nextProgression == "" {
+		progress = &rmn.ActionProgress{Action: action}
+		drpc.Status.Progress = progress
+	}
+
+	if nextProgression == "" {
+		return
+	}
+
+	if last := len(progress.Phases) - 1; last >= 0 && progress.Phases[last].EndTime == nil {
+		progress.Phases[last].EndTime = &metav1.Time{Time: now}
+	}
+
+	phase := rmn.ActionProgressPhase{Name: nextProgression, StartTime: metav1.NewTime(now)}
+
+	if nextProgression == rmn.ProgressionCompleted {
+		phase.EndTime = &metav1.Time{Time: now}
+		progress.Percentage = actionProgressCompleted
+	} else if milestones := actionProgressMilestones[action]; slices.Contains(milestones, nextProgression) {
+		percentage := int32(slices.Index(milestones, nextProgression) * actionProgressCompleted / len(milestones))
+		progress.Percentage = max(progress.Percentage, percentage)
+	}
+
+	progress.Phases = append(progress.Phases, phase)
+
+	if excess := len(progress.Phases) - actionProgressPhasesMax; excess > 0 {
+		progress.Phases = slices.Delete(progress.Phases, 0, excess)
+	}
+}
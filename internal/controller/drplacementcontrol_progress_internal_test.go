@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPC action progress", func() {
+	var (
+		drpc *rmn.DRPlacementControl
+		now  time.Time
+	)
+
+	progress := func(progressions ...rmn.ProgressionStatus) {
+		for _, progression := range progressions {
+			now = now.Add(time.Minute)
+			actionProgressUpdate(drpc, progression, now)
+			drpc.Status.Progression = progression
+		}
+	}
+
+	BeforeEach(func() {
+		drpc = &rmn.DRPlacementControl{}
+		now = time.Now()
+	})
+
+	It("records the timeline of the deployment", func() {
+		progress(rmn.ProgressionCreatingMW, rmn.ProgressionUpdatingPlRule)
+
+		Expect(drpc.Status.Progress.Action).To(Equal(actionProgressDeploy))
+		Expect(drpc.Status.Progress.Percentage).To(BeEquivalentTo(25))
+
+		phases := drpc.Status.Progress.Phases
+		Expect(phases).To(HaveLen(2))
+		Expect(phases[0].Name).To(Equal(rmn.ProgressionCreatingMW))
+		Expect(phases[0].EndTime.Time).To(Equal(phases[1].StartTime.Time))
+		Expect(phases[1].EndTime).To(BeNil())
+
+		progress(rmn.ProgressionCompleted)
+
+		Expect(drpc.Status.Progress.Percentage).To(BeEquivalentTo(100))
+		Expect(drpc.Status.Progress.Phases[2].EndTime).ToNot(BeNil())
+	})
+
+	It("starts the progress of a failover once it is initiated", func() {
+		progress(rmn.ProgressionCreatingMW, rmn.ProgressionCompleted)
+
+		drpc.Spec.Action = rmn.ActionFailover
+		progress("", rmn.ProgressionCheckingFailoverPrerequisites, rmn.ProgressionFailingOverToCluster)
+
+		Expect(drpc.Status.Progress.Action).To(Equal(string(rmn.ActionFailover)))
+		Expect(drpc.Status.Progress.Phases).To(HaveLen(2))
+		Expect(drpc.Status.Progress.Percentage).To(BeEquivalentTo(30))
+
+		// a progression out of the usual order does not decrease the percentage
+		progress(rmn.ProgressionWaitForFencing)
+		Expect(drpc.Status.Progress.Percentage).To(BeEquivalentTo(30))
+
+		// cleaning up after the failover completed does not either
+		progress(rmn.ProgressionCompleted, rmn.ProgressionCleaningUp)
+		Expect(drpc.Status.Progress.Percentage).To(BeEquivalentTo(100))
+	})
+
+	It("bounds the progressions recorded", func() {
+		drpc.Spec.Action = rmn.ActionRelocate
+
+		for range actionProgressPhasesMax {
+			progress(rmn.ProgressionRunningFinalSync, rmn.ProgressionPreparingFinalSync)
+		}
+
+		Expect(drpc.Status.Progress.Phases).To(HaveLen(actionProgressPhasesMax))
+		Expect(drpc.Status.Progress.Phases[actionProgressPhasesMax-1].Name).To(Equal(rmn.ProgressionPreparingFinalSync))
+	})
+})
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const qualificationDefaultSizeBytes = 1 << 20
+
+// isQualified runs the qualification of the DRPC, if it has one, the first time the workload is about to be
+// protected, and again while a required qualification fails. It returns false while a required qualification fails.
+func (d *DRPCInstance) isQualified() bool {
+	qualification := d.instance.Spec.Qualification
+	if qualification == nil {
+		return true
+	}
+
+	status := d.instance.Status.Qualification
+	if status == nil || (!status.Succeeded && qualification.Required) {
+		sizeBytes := qualification.SizeBytes
+		if sizeBytes == 0 {
+			sizeBytes = qualificationDefaultSizeBytes
+		}
+
+		status = qualifyReplication(d.ctx, d.reconciler.APIReader, d.reconciler.ObjStoreGetter, d.drClusters,
+			s3PathNamePrefix(d.vrgNamespace, d.instance.Name)+"qualification", sizeBytes, d.log)
+		d.instance.Status.Qualification = status
+		d.setQualifiedCondition(status)
+	}
+
+	if !status.Succeeded && qualification.Required {
+		d.setProgression(rmn.ProgressionQualifying)
+
+		return false
+	}
+
+	return true
+}
+
+// qualifyReplication round-trips a test object of sizeBytes random bytes with key through the S3 store of each of
+// drClusters that has one, the path the cluster data of a workload is replicated through, and returns the measured
+// latencies and throughputs
+func qualifyReplication(ctx context.Context, apiReader client.Reader, objectStoreGetter ObjectStoreGetter,
+	drClusters []rmn.DRCluster, key string, sizeBytes int64, log logr.Logger,
+) *rmn.QualificationStatus {
+	status := &rmn.QualificationStatus{Succeeded: true}
+
+	payload := make([]byte, sizeBytes)
+	_, payloadErr := rand.Read(payload)
+
+	for i := range drClusters {
+		drCluster := &drClusters[i]
+		if drCluster.Spec.S3ProfileName == NoS3StoreAvailable {
+			continue
+		}
+
+		result := rmn.QualificationResult{Cluster: drCluster.Name, S3ProfileName: drCluster.Spec.S3ProfileName}
+
+		if payloadErr != nil {
+			result.Error = fmt.Sprintf("failed to generate test object: %v", payloadErr)
+		} else {
+			qualificationRoundTripResult(ctx, apiReader, objectStoreGetter, key, payload, &result, log)
+		}
+
+		if result.Error != "" {
+			status.Succeeded = false
+		}
+
+		status.Clusters = append(status.Clusters, result)
+	}
+
+	status.Time = metav1.Now()
+
+	return status
+}
+
+func qualificationRoundTripResult(ctx context.Context, apiReader client.Reader,
+	objectStoreGetter ObjectStoreGetter, key string, payload []byte, result *rmn.QualificationResult,
+	log logr.Logger,
+) {
+	objectStore, _, err := objectStoreGetter.ObjectStore(ctx, apiReader, result.S3ProfileName, "qualification", log)
+	if err != nil {
+		result.Error = err.Error()
+
+		return
+	}
+
+	uploadLatency, downloadLatency, err := qualificationRoundTrip(objectStore, key, payload)
+	result.UploadLatency = metav1.Duration{Duration: uploadLatency}
+	result.DownloadLatency = metav1.Duration{Duration: downloadLatency}
+
+	if err != nil {
+		result.Error = err.Error()
+
+		return
+	}
+
+	if seconds := (uploadLatency + downloadLatency).Seconds(); seconds > 0 {
+		result.ThroughputBytesPerSecond = int64(float64(2*len(payload)) / seconds)
+	}
+
+	log.Info("Qualified S3 store", "cluster", result.Cluster, "profile", result.S3ProfileName,
+		"uploadLatency", uploadLatency, "downloadLatency", downloadLatency,
+		"throughputBytesPerSecond", result.ThroughputBytesPerSecond)
+}
+
+// qualificationRoundTrip uploads payload to objectStore with key, downloads and compares it, and deletes it
+func qualificationRoundTrip(objectStore ObjectStorer, key string, payload []byte,
+) (uploadLatency, downloadLatency time.Duration, err error) {
+	start := time.Now()
+
+	if err := objectStore.UploadObject(key, payload); err != nil {
+		return time.Since(start), 0, fmt.Errorf("upload failed: %w", err)
+	}
+
+	uploadLatency = time.Since(start)
+	start = time.Now()
+
+	downloaded := []byte{}
+	if err := objectStore.DownloadObject(key, &downloaded); err != nil {
+		return uploadLatency, time.Since(start), fmt.Errorf("download failed: %w", err)
+	}
+
+	downloadLatency = time.Since(start)
+
+	if !bytes.Equal(payload, downloaded) {
+		err = fmt.Errorf("downloaded object differs from the uploaded one")
+	}
+
+	if deleteErr := objectStore.DeleteObject(key); deleteErr != nil && err == nil {
+		err = fmt.Errorf("delete failed: %w", deleteErr)
+	}
+
+	return uploadLatency, downloadLatency, err
+}
+
+func (d *DRPCInstance) setQualifiedCondition(status *rmn.QualificationStatus) {
+	if status.Succeeded {
+		addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionQualified, d.instance.Generation,
+			metav1.ConditionTrue, ReasonQualificationSucceeded,
+			fmt.Sprintf("Replication round-trip succeeded with the S3 stores of %d clusters", len(status.Clusters)))
+
+		return
+	}
+
+	failures := []string{}
+
+	for _, result := range status.Clusters {
+		if result.Error != "" {
+			failures = append(failures, fmt.Sprintf("cluster %s: %s", result.Cluster, result.Error))
+		}
+	}
+
+	addOrUpdateCondition(&d.instance.Status.Conditions, rmn.ConditionQualified, d.instance.Generation,
+		metav1.ConditionFalse, ReasonQualificationFailed,
+		"Replication round-trip failed: "+strings.Join(failures, "; "))
+}
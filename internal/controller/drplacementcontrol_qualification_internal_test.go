@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// qualificationObjectStoreGetter returns the object store of a profile, failing for the profiles without one
+type qualificationObjectStoreGetter map[string]ObjectStorer
+
+func (g qualificationObjectStoreGetter) ObjectStore(_ context.Context, _ client.Reader, s3ProfileName, _ string,
+	_ logr.Logger,
+) (ObjectStorer, rmn.S3StoreProfile, error) {
+	objectStore, ok := g[s3ProfileName]
+	if !ok {
+		return nil, rmn.S3StoreProfile{}, fmt.Errorf("profile %s unreachable", s3ProfileName)
+	}
+
+	return objectStore, rmn.S3StoreProfile{S3ProfileName: s3ProfileName}, nil
+}
+
+var _ = Describe("DRPC qualification", func() {
+	const key = "ns/drpc/qualification"
+
+	var (
+		service   *fakeBlobService
+		getter    qualificationObjectStoreGetter
+		drCluster func(name, s3ProfileName string) rmn.DRCluster
+	)
+
+	BeforeEach(func() {
+		service = &fakeBlobService{
+			accountName: "account",
+			accountKey:  []byte("key"),
+			container:   "container",
+			blobs:       map[string][]byte{},
+		}
+		server := httptest.NewServer(service)
+		DeferCleanup(server.Close)
+
+		getter = qualificationObjectStoreGetter{"east": &azureBlobObjectStore{
+			client:      server.Client(),
+			endpoint:    server.URL,
+			container:   "container",
+			accountName: "account",
+			accountKey:  []byte("key"),
+		}}
+		drCluster = func(name, s3ProfileName string) rmn.DRCluster {
+			return rmn.DRCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       rmn.DRClusterSpec{S3ProfileName: s3ProfileName},
+			}
+		}
+	})
+
+	It("round-trips a test object through the S3 store of each cluster and measures it", func() {
+		status := qualifyReplication(context.TODO(), nil, getter,
+			[]rmn.DRCluster{drCluster("east", "east"), drCluster("sync", NoS3StoreAvailable)}, key, 4096, logr.Discard())
+
+		Expect(status.Succeeded).To(BeTrue())
+		Expect(status.Time.IsZero()).To(BeFalse())
+		Expect(status.Clusters).To(HaveLen(1))
+		Expect(status.Clusters[0].Cluster).To(Equal("east"))
+		Expect(status.Clusters[0].Error).To(BeEmpty())
+		Expect(status.Clusters[0].UploadLatency.Duration).To(BeNumerically(">", 0))
+		Expect(status.Clusters[0].ThroughputBytesPerSecond).To(BeNumerically(">", 0))
+		Expect(service.blobs).To(BeEmpty())
+	})
+
+	It("reports the clusters whose S3 store fails the round-trip", func() {
+		status := qualifyReplication(context.TODO(), nil, getter,
+			[]rmn.DRCluster{drCluster("east", "east"), drCluster("west", "west")}, key, 16, logr.Discard())
+
+		Expect(status.Succeeded).To(BeFalse())
+		Expect(status.Clusters).To(HaveLen(2))
+		Expect(status.Clusters[0].Error).To(BeEmpty())
+		Expect(status.Clusters[1].Error).To(ContainSubstring("profile west unreachable"))
+	})
+
+	It("holds the protection while a required qualification fails", func() {
+		d := &DRPCInstance{
+			ctx:        context.TODO(),
+			log:        logr.Discard(),
+			reconciler: &DRPlacementControlReconciler{ObjStoreGetter: getter},
+			instance: &rmn.DRPlacementControl{
+				ObjectMeta: metav1.ObjectMeta{Name: "drpc", Namespace: "ns"},
+				Spec:       rmn.DRPlacementControlSpec{Qualification: &rmn.QualificationSpec{Required: true}},
+			},
+			drClusters:   []rmn.DRCluster{drCluster("west", "west")},
+			vrgNamespace: "ns",
+		}
+
+		Expect(d.isQualified()).To(BeFalse())
+		Expect(d.instance.Status.Progression).To(Equal(rmn.ProgressionQualifying))
+		Expect(d.instance.Status.Conditions).To(ContainElement(And(
+			HaveField("Type", rmn.ConditionQualified),
+			HaveField("Status", metav1.ConditionFalse),
+		)))
+
+		d.drClusters = []rmn.DRCluster{drCluster("east", "east")}
+		Expect(d.isQualified()).To(BeTrue())
+		Expect(d.instance.Status.Qualification.Succeeded).To(BeTrue())
+
+		d.instance.Spec.Qualification.Required = false
+		d.instance.Status.Qualification = nil
+		d.drClusters = []rmn.DRCluster{drCluster("west", "west")}
+		Expect(d.isQualified()).To(BeTrue())
+		Expect(d.instance.Status.Qualification.Succeeded).To(BeFalse())
+	})
+})
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// fairQueue is the storage of a workqueue that pops the items of each tenant in turn, first in, first out within a
+// tenant, so that a tenant with many items does not starve the others. Like any workqueue storage, it is only called
+// with the lock of the workqueue held.
+type fairQueue[T comparable] struct {
+	tenantOf func(T) string
+
+	// tenants are the tenants with items, in the order their items are popped in, starting from next
+	tenants []string
+	next    int
+	items   map[string][]T
+	length  int
+}
+
+func newFairQueue[T comparable](tenantOf func(T) string) *fairQueue[T] {
+	return &fairQueue[T]{tenantOf: tenantOf, items: map[string][]T{}}
+}
+
+// Touch keeps an item that is added again in its place
+func (q *fairQueue[T]) Touch(T) {}
+
+func (q *fairQueue[T]) Push(item T) {
+	tenant := q.tenantOf(item)
+	if len(q.items[tenant]) == 0 {
+		// A tenant with new items is served after the tenants already waiting
+		q.tenants = append(q.tenants[:q.next], append([]string{tenant}, q.tenants[q.next:]...)...)
+		q.next++
+	}
+
+	q.items[tenant] = append(q.items[tenant], item)
+	q.length++
+
+	NewDRPCQueueDepthMetric(DRPCQueueDepthLabels(tenant)).Set(float64(len(q.items[tenant])))
+}
+
+func (q *fairQueue[T]) Len() int {
+	return q.length
+}
+
+func (q *fairQueue[T]) Pop() T {
+	q.next %= len(q.tenants)
+	tenant := q.tenants[q.next]
+	items := q.items[tenant]
+	item := items[0]
+
+	q.length--
+
+	if len(items) == 1 {
+		delete(q.items, tenant)
+		q.tenants = append(q.tenants[:q.next], q.tenants[q.next+1:]...)
+		DeleteDRPCQueueDepthMetric(DRPCQueueDepthLabels(tenant))
+
+		return item
+	}
+
+	q.items[tenant] = items[1:]
+	q.next++
+
+	NewDRPCQueueDepthMetric(DRPCQueueDepthLabels(tenant)).Set(float64(len(q.items[tenant])))
+
+	return item
+}
+
+// drpcTenant returns the tenant of the DRPC of a request, the value of its tenantLabel if it has one, or its namespace
+// otherwise. The DRPC is read from reader, the cache of the DRPCs, as it is called with the lock of the workqueue held.
+func drpcTenant(reader client.Reader, tenantLabel string) func(reconcile.Request) string {
+	return func(request reconcile.Request) string {
+		if tenantLabel == "" {
+			return request.Namespace
+		}
+
+		drpc := &rmn.DRPlacementControl{}
+		if err := reader.Get(context.TODO(), request.NamespacedName, drpc); err != nil {
+			return request.Namespace
+		}
+
+		if tenant, ok := drpc.GetLabels()[tenantLabel]; ok {
+			return tenant
+		}
+
+		return request.Namespace
+	}
+}
+
+// drpcFairQueueNew returns a function returning the workqueue of the DRPC controller, with the reconciles of the DRPCs
+// of each tenant queued in turn, if fair queuing is enabled, or nil for the default workqueue
+func drpcFairQueueNew(reader client.Reader, fairQueuing rmn.DRPCFairQueuing,
+) func(string, workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	if !fairQueuing.Enabled {
+		return nil
+	}
+
+	return func(name string, rateLimiter workqueue.TypedRateLimiter[reconcile.Request],
+	) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+		queue := workqueue.NewTypedWithConfig(workqueue.TypedQueueConfig[reconcile.Request]{
+			Name:  name,
+			Queue: newFairQueue(drpcTenant(reader, fairQueuing.TenantLabel)),
+		})
+
+		return workqueue.NewTypedRateLimitingQueueWithConfig(rateLimiter,
+			workqueue.TypedRateLimitingQueueConfig[reconcile.Request]{
+				Name: name,
+				DelayingQueue: workqueue.NewTypedDelayingQueueWithConfig(
+					workqueue.TypedDelayingQueueConfig[reconcile.Request]{Name: name, Queue: queue}),
+			})
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPC fair queuing", func() {
+	request := func(namespace, name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+	}
+
+	newQueue := func(tenantLabel string) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+
+		reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&rmn.DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app2", Name: "drpc", Labels: map[string]string{"tenant": "a"}},
+		}).Build()
+
+		queue := drpcFairQueueNew(reader, rmn.DRPCFairQueuing{Enabled: true, TenantLabel: tenantLabel})(
+			"drpc-fair-queuing-test", workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		DeferCleanup(queue.ShutDown)
+
+		return queue
+	}
+
+	drain := func(queue workqueue.TypedRateLimitingInterface[reconcile.Request]) []reconcile.Request {
+		requests := []reconcile.Request{}
+
+		for queue.Len() > 0 {
+			request, _ := queue.Get()
+			queue.Done(request)
+
+			requests = append(requests, request)
+		}
+
+		return requests
+	}
+
+	depth := func(tenant string) float64 {
+		families, err := metrics.Registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, family := range families {
+			if family.GetName() != metricNamespace+"_"+DRPCQueueDepth {
+				continue
+			}
+
+			for _, m := range family.GetMetric() {
+				if m.GetLabel()[0].GetValue() == tenant {
+					return m.GetGauge().GetValue()
+				}
+			}
+		}
+
+		return 0
+	}
+
+	It("reconciles the DRPCs of each namespace in turn", func() {
+		queue := newQueue("")
+
+		for _, name := range []string{"1", "2", "3"} {
+			queue.Add(request("busy", name))
+		}
+
+		queue.Add(request("quiet", "1"))
+		queue.Add(request("busy", "1"))
+		Expect(depth("busy")).To(Equal(float64(3)))
+
+		Expect(drain(queue)).To(Equal([]reconcile.Request{
+			request("busy", "1"), request("quiet", "1"), request("busy", "2"), request("busy", "3"),
+		}))
+		Expect(depth("busy")).To(BeZero())
+	})
+
+	It("serves a tenant with new DRPCs after the tenants already waiting", func() {
+		queue := newQueue("")
+
+		queue.Add(request("ns1", "1"))
+		queue.Add(request("ns1", "2"))
+		queue.Add(request("ns2", "1"))
+		queue.Add(request("ns2", "2"))
+
+		first, _ := queue.Get()
+		queue.Done(first)
+		Expect(first).To(Equal(request("ns1", "1")))
+
+		queue.Add(request("ns3", "1"))
+
+		Expect(drain(queue)).To(Equal([]reconcile.Request{
+			request("ns2", "1"), request("ns1", "2"), request("ns3", "1"), request("ns2", "2"),
+		}))
+	})
+
+	It("groups the DRPCs with the tenant label by its value", func() {
+		queue := newQueue("tenant")
+
+		queue.Add(request("app1", "drpc"))
+		queue.Add(request("app2", "drpc"))
+		queue.Add(request("app1", "drpc2"))
+		Expect(depth("a")).To(Equal(float64(1)))
+		Expect(depth("app1")).To(Equal(float64(2)))
+
+		Expect(drain(queue)).To(Equal([]reconcile.Request{
+			request("app1", "drpc"), request("app2", "drpc"), request("app1", "drpc2"),
+		}))
+	})
+})
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+// defaultContinuousValidationInterval is the interval between DR readiness computations if the ramen config sets none
+const defaultContinuousValidationInterval = 5 * time.Minute
+
+func continuousValidationEnabled(ramenConfig *rmn.RamenConfig) bool {
+	return ramenConfig != nil && ramenConfig.ContinuousValidation.Enabled
+}
+
+func continuousValidationInterval(ramenConfig *rmn.RamenConfig) time.Duration {
+	interval := ramenConfig.ContinuousValidation.Interval
+	if interval == nil || interval.Duration <= 0 {
+		return defaultContinuousValidationInterval
+	}
+
+	return interval.Duration
+}
+
+// readinessEvaluationDelay returns the time until the DR readiness score of the DRPC is due to be recomputed
+func (d *DRPCInstance) readinessEvaluationDelay(now time.Time) time.Duration {
+	readiness := d.instance.Status.Readiness
+	if readiness == nil {
+		return 0
+	}
+
+	return max(readiness.LastEvaluationTime.Add(continuousValidationInterval(d.ramenConfig)).Sub(now), 0)
+}
+
+// updateReadiness recomputes the DR readiness score of the DRPC once its interval elapses, and clears it when
+// continuous validation is disabled
+func (d *DRPCInstance) updateReadiness() {
+	if !continuousValidationEnabled(d.ramenConfig) {
+		d.instance.Status.Readiness = nil
+
+		return
+	}
+
+	now := metav1.Now()
+	if d.readinessEvaluationDelay(now.Time) > 0 {
+		return
+	}
+
+	d.instance.Status.Readiness = drReadiness(d.instance, d.drPolicy, d.drClusters, now)
+	d.log.Info("Computed DR readiness", "score", d.instance.Status.Readiness.Score)
+}
+
+// readinessRequeueDelay limits delay to the time until the DR readiness score is due to be recomputed
+func (d *DRPCInstance) readinessRequeueDelay(delay time.Duration) time.Duration {
+	if !continuousValidationEnabled(d.ramenConfig) {
+		return delay
+	}
+
+	return min(delay, max(d.readinessEvaluationDelay(time.Now()), time.Second))
+}
+
+// drReadiness combines the checks a failover or relocate of the workload depends on into a score, the percentage
+// of the checks that pass
+func drReadiness(drpc *rmn.DRPlacementControl, drPolicy *rmn.DRPolicy, drClusters []rmn.DRCluster,
+	now metav1.Time,
+) *rmn.DRReadiness {
+	components := []rmn.DRReadinessComponent{
+		readinessS3MetadataFresh(drpc),
+		readinessPeerHealth(drpc, drClusters),
+		readinessClassMatching(drPolicy),
+		readinessFencing(drPolicy, drClusters),
+		readinessAgentHealth(drpc),
+	}
+
+	ready := 0
+
+	for _, component := range components {
+		if component.Ready {
+			ready++
+		}
+	}
+
+	return &rmn.DRReadiness{
+		Score:              int32(ready * 100 / len(components)),
+		Components:         components,
+		LastEvaluationTime: now,
+	}
+}
+
+func readinessComponent(name string, ready bool, message string) rmn.DRReadinessComponent {
+	return rmn.DRReadinessComponent{Name: name, Ready: ready, Message: message}
+}
+
+// readinessS3MetadataFresh checks that the cluster data of the workload is protected in its S3 stores
+func readinessS3MetadataFresh(drpc *rmn.DRPlacementControl) rmn.DRReadinessComponent {
+	condition := meta.FindStatusCondition(drpc.Status.ResourceConditions.Conditions,
+		VRGConditionTypeClusterDataProtected)
+	if condition == nil {
+		return readinessComponent(rmn.ReadinessComponentS3MetadataFresh, false,
+			"cluster data protection is not reported by the VRG")
+	}
+
+	return readinessComponent(rmn.ReadinessComponentS3MetadataFresh, condition.Status == metav1.ConditionTrue,
+		condition.Message)
+}
+
+// readinessPeerHealth checks that the peer of the workload is ready and the DRClusters are validated
+func readinessPeerHealth(drpc *rmn.DRPlacementControl, drClusters []rmn.DRCluster) rmn.DRReadinessComponent {
+	if !meta.IsStatusConditionTrue(drpc.Status.Conditions, rmn.ConditionPeerReady) {
+		return readinessComponent(rmn.ReadinessComponentPeerHealth, false, "peer is not ready")
+	}
+
+	for i := range drClusters {
+		if !meta.IsStatusConditionTrue(drClusters[i].Status.Conditions, rmn.DRClusterValidated) {
+			return readinessComponent(rmn.ReadinessComponentPeerHealth, false,
+				fmt.Sprintf("DRCluster %s is not validated", drClusters[i].Name))
+		}
+	}
+
+	return readinessComponent(rmn.ReadinessComponentPeerHealth, true, "peer is ready")
+}
+
+// readinessClassMatching checks that the DRPolicy found classes matching across its clusters
+func readinessClassMatching(drPolicy *rmn.DRPolicy) rmn.DRReadinessComponent {
+	if len(drPolicy.Status.Async.PeerClasses)+len(drPolicy.Status.Sync.PeerClasses) == 0 {
+		return readinessComponent(rmn.ReadinessComponentClassMatching, false,
+			fmt.Sprintf("DRPolicy %s has no peer classes", drPolicy.Name))
+	}
+
+	return readinessComponent(rmn.ReadinessComponentClassMatching, true,
+		fmt.Sprintf("DRPolicy %s has peer classes", drPolicy.Name))
+}
+
+// readinessFencing checks that the DRClusters of a metro DRPolicy can be fenced and are not fenced
+func readinessFencing(drPolicy *rmn.DRPolicy, drClusters []rmn.DRCluster) rmn.DRReadinessComponent {
+	metro, _, err := dRPolicySupportsMetro(drPolicy, nil)
+	if err != nil {
+		return readinessComponent(rmn.ReadinessComponentFencing, false, err.Error())
+	}
+
+	if !metro {
+		return readinessComponent(rmn.ReadinessComponentFencing, true, "fencing is not required")
+	}
+
+	for i := range drClusters {
+		conditions := drClusters[i].Status.Conditions
+
+		fenced := rmnutil.FindCondition(conditions, rmn.DRClusterConditionTypeFenced)
+		if fenced != nil && fenced.Reason == DRClusterConditionReasonFencingUnavailable {
+			return readinessComponent(rmn.ReadinessComponentFencing, false,
+				fmt.Sprintf("fencing is unavailable on DRCluster %s", drClusters[i].Name))
+		}
+
+		if !meta.IsStatusConditionTrue(conditions, rmn.DRClusterConditionTypeClean) {
+			return readinessComponent(rmn.ReadinessComponentFencing, false,
+				fmt.Sprintf("DRCluster %s is not clean", drClusters[i].Name))
+		}
+	}
+
+	return readinessComponent(rmn.ReadinessComponentFencing, true, "fencing is available")
+}
+
+// readinessAgentHealth checks that the managed clusters and their agents are available
+func readinessAgentHealth(drpc *rmn.DRPlacementControl) rmn.DRReadinessComponent {
+	for _, conditionType := range []string{rmn.ConditionClusterUnavailable, rmn.ConditionAgentUnavailable} {
+		if condition := meta.FindStatusCondition(drpc.Status.Conditions, conditionType); condition != nil &&
+			condition.Status == metav1.ConditionTrue {
+			return readinessComponent(rmn.ReadinessComponentAgentHealth, false, condition.Message)
+		}
+	}
+
+	return readinessComponent(rmn.ReadinessComponentAgentHealth, true, "agents are available")
+}
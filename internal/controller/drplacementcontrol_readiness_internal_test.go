@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("drReadiness", func() {
+	var (
+		drpc       *rmn.DRPlacementControl
+		drPolicy   *rmn.DRPolicy
+		drClusters []rmn.DRCluster
+	)
+
+	readyCondition := func(conditionType string) metav1.Condition {
+		return metav1.Condition{Type: conditionType, Status: metav1.ConditionTrue, Reason: "Test"}
+	}
+
+	BeforeEach(func() {
+		drpc = &rmn.DRPlacementControl{
+			Status: rmn.DRPlacementControlStatus{
+				Conditions: []metav1.Condition{readyCondition(rmn.ConditionPeerReady)},
+				ResourceConditions: rmn.VRGConditions{
+					Conditions: []metav1.Condition{readyCondition(VRGConditionTypeClusterDataProtected)},
+				},
+			},
+		}
+		drPolicy = &rmn.DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+			Spec:       rmn.DRPolicySpec{SchedulingInterval: "5m"},
+			Status: rmn.DRPolicyStatus{
+				Async: rmn.Async{PeerClasses: []rmn.PeerClass{{StorageClassName: "sc"}}},
+			},
+		}
+		drClusters = []rmn.DRCluster{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+				Status: rmn.DRClusterStatus{
+					Conditions: []metav1.Condition{readyCondition(rmn.DRClusterValidated)},
+				},
+			},
+		}
+	})
+
+	It("scores 100 when every component is ready", func() {
+		readiness := drReadiness(drpc, drPolicy, drClusters, metav1.Now())
+		Expect(readiness.Score).To(Equal(int32(100)))
+		Expect(readiness.Components).To(HaveLen(5))
+	})
+
+	It("scores the percentage of ready components, reporting the ones that are not", func() {
+		drpc.Status.Conditions = append(drpc.Status.Conditions, metav1.Condition{
+			Type: rmn.ConditionAgentUnavailable, Status: metav1.ConditionTrue, Reason: "Test", Message: "unavailable",
+		})
+		drPolicy.Status.Async.PeerClasses = nil
+
+		readiness := drReadiness(drpc, drPolicy, drClusters, metav1.Now())
+		Expect(readiness.Score).To(Equal(int32(60)))
+
+		for _, component := range readiness.Components {
+			switch component.Name {
+			case rmn.ReadinessComponentAgentHealth:
+				Expect(component.Ready).To(BeFalse())
+				Expect(component.Message).To(Equal("unavailable"))
+			case rmn.ReadinessComponentClassMatching:
+				Expect(component.Ready).To(BeFalse())
+			default:
+				Expect(component.Ready).To(BeTrue())
+			}
+		}
+	})
+
+	It("requires the DRClusters of a metro DRPolicy to be fenceable and clean", func() {
+		drPolicy.Status = rmn.DRPolicyStatus{
+			Sync: rmn.Sync{PeerClasses: []rmn.PeerClass{{StorageClassName: "sc"}}},
+		}
+		drClusters[0].Status.Conditions = append(drClusters[0].Status.Conditions, metav1.Condition{
+			Type: rmn.DRClusterConditionTypeFenced, Status: metav1.ConditionFalse,
+			Reason: DRClusterConditionReasonFencingUnavailable,
+		}, readyCondition(rmn.DRClusterConditionTypeClean))
+
+		Expect(readinessFencing(drPolicy, drClusters).Ready).To(BeFalse())
+
+		drClusters[0].Status.Conditions[1].Reason = DRClusterConditionReasonUnfenced
+
+		Expect(readinessFencing(drPolicy, drClusters).Ready).To(BeTrue())
+	})
+})
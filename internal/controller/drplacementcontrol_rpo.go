@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	drpcRPOTargetExceededReason = "TargetExceeded"
+	drpcRPOWithinTargetReason   = "WithinTarget"
+	drpcRPONoSyncTimeReason     = "NoSyncTime"
+)
+
+// drpcRPOViolated returns whether the last group sync of drpc is older at now than the RPO target of drPolicy, and
+// whether it is known, that is whether the policy has a target and the DRPC a last group sync time
+func drpcRPOViolated(drPolicy *rmn.DRPolicy, drpc *rmn.DRPlacementControl, now time.Time) (bool, bool) {
+	if drPolicy.Spec.RPOTarget == nil || drpc.Status.LastGroupSyncTime == nil {
+		return false, false
+	}
+
+	return now.Sub(drpc.Status.LastGroupSyncTime.Time) > drPolicy.Spec.RPOTarget.Duration, true
+}
+
+// updateRPOViolatedCondition sets the RPOViolated condition of drpc from the RPO target of its DRPolicy, or removes
+// it if the policy has no target
+func (r *DRPlacementControlReconciler) updateRPOViolatedCondition(ctx context.Context, drpc *rmn.DRPlacementControl,
+	log logr.Logger,
+) {
+	drPolicy, err := GetDRPolicy(ctx, r.Client, drpc, log)
+	if err != nil {
+		log.Info("Failed to get DRPolicy to check the RPO target", "error", err)
+
+		return
+	}
+
+	updateDRPCRPOViolatedCondition(drpc, drPolicy, time.Now())
+}
+
+func updateDRPCRPOViolatedCondition(drpc *rmn.DRPlacementControl, drPolicy *rmn.DRPolicy, now time.Time) {
+	if drPolicy.Spec.RPOTarget == nil {
+		meta.RemoveStatusCondition(&drpc.Status.Conditions, rmn.ConditionRPOViolated)
+
+		return
+	}
+
+	target := drPolicy.Spec.RPOTarget.Duration
+
+	violated, known := drpcRPOViolated(drPolicy, drpc, now)
+
+	switch {
+	case !known:
+		addOrUpdateCondition(&drpc.Status.Conditions, rmn.ConditionRPOViolated, drpc.Generation,
+			metav1.ConditionUnknown, drpcRPONoSyncTimeReason,
+			fmt.Sprintf("No group sync reported to compare to the RPO target of %v", target))
+	case violated:
+		addOrUpdateCondition(&drpc.Status.Conditions, rmn.ConditionRPOViolated, drpc.Generation,
+			metav1.ConditionTrue, drpcRPOTargetExceededReason,
+			fmt.Sprintf("Last group sync at %s is older than the RPO target of %v",
+				drpc.Status.LastGroupSyncTime.UTC().Format(time.RFC3339), target))
+	default:
+		addOrUpdateCondition(&drpc.Status.Conditions, rmn.ConditionRPOViolated, drpc.Generation,
+			metav1.ConditionFalse, drpcRPOWithinTargetReason,
+			fmt.Sprintf("Last group sync is within the RPO target of %v", target))
+	}
+}
+
+// updateRPOViolated updates the RPOViolated condition of the DRPC as time passes since its last group sync, which
+// does not change its status otherwise
+func (d *DRPCInstance) updateRPOViolated() {
+	if d.drPolicy == nil || rmnutil.ResourceIsDeleted(d.instance) {
+		return
+	}
+
+	updateDRPCRPOViolatedCondition(d.instance, d.drPolicy, time.Now())
+}
+
+// rpoRequeueDelay limits delay to the time until the last group sync of the DRPC exceeds the RPO target of its
+// DRPolicy, so that the violation is reported when it happens rather than at the next status check
+func (d *DRPCInstance) rpoRequeueDelay(delay time.Duration) time.Duration {
+	if d.drPolicy == nil {
+		return delay
+	}
+
+	now := time.Now()
+
+	violated, known := drpcRPOViolated(d.drPolicy, d.instance, now)
+	if !known || violated {
+		return delay
+	}
+
+	due := d.instance.Status.LastGroupSyncTime.Add(d.drPolicy.Spec.RPOTarget.Duration).Sub(now)
+
+	return min(delay, max(due, 0)+time.Second)
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPC RPO target", func() {
+	var (
+		now      time.Time
+		drPolicy *rmn.DRPolicy
+		drpc     *rmn.DRPlacementControl
+	)
+
+	synced := func(ago time.Duration) {
+		syncTime := metav1.NewTime(now.Add(-ago))
+		drpc.Status.LastGroupSyncTime = &syncTime
+	}
+
+	rpoViolatedCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(drpc.Status.Conditions, rmn.ConditionRPOViolated)
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		drPolicy = &rmn.DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+			Spec:       rmn.DRPolicySpec{RPOTarget: &metav1.Duration{Duration: 10 * time.Minute}},
+		}
+		drpc = &rmn.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{Name: "drpc", Namespace: "app"}}
+	})
+
+	It("reports the RPO unknown until a group sync is reported", func() {
+		updateDRPCRPOViolatedCondition(drpc, drPolicy, now)
+
+		Expect(rpoViolatedCondition()).To(And(
+			HaveField("Status", metav1.ConditionUnknown), HaveField("Reason", drpcRPONoSyncTimeReason)))
+	})
+
+	It("reports the RPO violated once the last group sync is older than the target", func() {
+		synced(5 * time.Minute)
+		updateDRPCRPOViolatedCondition(drpc, drPolicy, now)
+		Expect(rpoViolatedCondition()).To(And(
+			HaveField("Status", metav1.ConditionFalse), HaveField("Reason", drpcRPOWithinTargetReason)))
+
+		updateDRPCRPOViolatedCondition(drpc, drPolicy, now.Add(6*time.Minute))
+		Expect(rpoViolatedCondition()).To(And(
+			HaveField("Status", metav1.ConditionTrue), HaveField("Reason", drpcRPOTargetExceededReason)))
+	})
+
+	It("removes the condition once the policy has no RPO target", func() {
+		synced(time.Hour)
+		updateDRPCRPOViolatedCondition(drpc, drPolicy, now)
+		Expect(rpoViolatedCondition()).ToNot(BeNil())
+
+		drPolicy.Spec.RPOTarget = nil
+		updateDRPCRPOViolatedCondition(drpc, drPolicy, now)
+		Expect(rpoViolatedCondition()).To(BeNil())
+	})
+
+	It("requeues the DRPC when its last group sync is due to exceed the target", func() {
+		synced(8 * time.Minute)
+		d := &DRPCInstance{instance: drpc, drPolicy: drPolicy}
+
+		Expect(d.rpoRequeueDelay(StatusCheckDelay)).To(BeNumerically("~", 2*time.Minute+time.Second, time.Second))
+		Expect(d.rpoRequeueDelay(time.Minute)).To(Equal(time.Minute))
+
+		synced(time.Hour)
+		Expect(d.rpoRequeueDelay(StatusCheckDelay)).To(Equal(StatusCheckDelay))
+	})
+})
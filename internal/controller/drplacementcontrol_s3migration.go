@@ -0,0 +1,534 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/kubeobjects/velero"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// DRPCS3ProfileMigrationAnnotation requests the migration of the metadata of the DRPC from the S3 profile of a
+// cluster to another S3 profile, with the value "<source>=<target>". The VRGs upload to the target as well as the
+// source while the metadata is copied and verified, and the DRClusters of the source are switched to the target once
+// every DRPC of them is migrated.
+const DRPCS3ProfileMigrationAnnotation = "drplacementcontrol.ramendr.openshift.io/s3-profile-migration"
+
+const (
+	// S3ProfileMigrationInterval is the interval the S3 profile migrator copies the metadata of the DRPCs at
+	S3ProfileMigrationInterval = time.Minute
+
+	// s3ProfileMigrationBatchSize is the number of objects copied between the checkpoints of the progress of a copy
+	s3ProfileMigrationBatchSize = 100
+)
+
+// s3ProfileMigrationGet returns the source and target S3 profiles of the migration the DRPC requests, if any
+func s3ProfileMigrationGet(drpc *rmn.DRPlacementControl) (string, string, bool) {
+	value, ok := drpc.GetAnnotations()[DRPCS3ProfileMigrationAnnotation]
+	if !ok {
+		return "", "", false
+	}
+
+	source, target, _ := strings.Cut(value, "=")
+
+	return source, target, true
+}
+
+// s3ProfileMigrationStatusGet returns the status of the migration the DRPC requests, if it reports it
+func s3ProfileMigrationStatusGet(drpc *rmn.DRPlacementControl) *rmn.S3ProfileMigrationStatus {
+	source, target, ok := s3ProfileMigrationGet(drpc)
+	status := drpc.Status.S3ProfileMigration
+
+	if !ok || status == nil || status.Source != source || status.Target != target {
+		return nil
+	}
+
+	return status
+}
+
+// s3ProfilesMigrating returns the S3 profiles of the DRPC, with the target of its migration added once the VRGs are
+// to upload to it as well as the source, until the DRClusters of the source are switched to it
+func s3ProfilesMigrating(drpc *rmn.DRPlacementControl, s3Profiles []string) []string {
+	status := s3ProfileMigrationStatusGet(drpc)
+	if status == nil || !status.DualWrite || !slices.Contains(s3Profiles, status.Source) ||
+		slices.Contains(s3Profiles, status.Target) {
+		return s3Profiles
+	}
+
+	return append(slices.Clone(s3Profiles), status.Target)
+}
+
+// s3ProfileMigrationValidate returns an error if the migration from source to target is not one of the S3 profile
+// of a cluster of the DRPC to a distinct S3 profile
+func s3ProfileMigrationValidate(drpc *rmn.DRPlacementControl, drClusters []rmn.DRCluster, source, target string,
+) error {
+	if source == "" || target == "" || source == target || target == NoS3StoreAvailable {
+		return fmt.Errorf("invalid %s annotation value %q, expected <source>=<target> with distinct S3 profiles",
+			DRPCS3ProfileMigrationAnnotation, drpc.GetAnnotations()[DRPCS3ProfileMigrationAnnotation])
+	}
+
+	if !slices.Contains(AvailableS3Profiles(drClusters), source) {
+		return fmt.Errorf("s3 profile %s is not the S3 profile of a cluster of the DRPolicy of the DRPC", source)
+	}
+
+	return nil
+}
+
+// s3ProfileMigrationObserve records the progress of the migration the DRPC requests that the primary VRG reports: the
+// generation from which it uploads to the target as well as the source, and whether it captured the kube objects to
+// the target since. The objects are copied by the S3ProfileMigrator, outside of the reconcile of the DRPC.
+func (d *DRPCInstance) s3ProfileMigrationObserve() {
+	source, target, ok := s3ProfileMigrationGet(d.instance)
+	if !ok {
+		d.instance.Status.S3ProfileMigration = nil
+
+		return
+	}
+
+	status := s3ProfileMigrationStatusGet(d.instance)
+	if status == nil {
+		status = &rmn.S3ProfileMigrationStatus{Source: source, Target: target, Time: metav1.Now()}
+		d.instance.Status.S3ProfileMigration = status
+	}
+
+	if !status.DualWrite || status.Migrated {
+		return
+	}
+
+	vrg := d.primaryVRG()
+	if vrg == nil {
+		return
+	}
+
+	if status.DualWriteGeneration == 0 && slices.Contains(vrg.Spec.S3Profiles, target) &&
+		vrg.Status.ObservedGeneration == vrg.Generation {
+		status.DualWriteGeneration = vrg.Generation
+		d.log.Info("VRG uploads to the S3 profile migrated to", "target", target, "generation", vrg.Generation)
+	}
+
+	if status.DualWriteGeneration != 0 && !status.KubeObjectsCaptured {
+		capture := vrg.Status.KubeObjectProtection.CaptureToRecoverFrom
+		status.KubeObjectsCaptured = vrg.Spec.KubeObjectProtection == nil ||
+			capture != nil && capture.StartGeneration >= status.DualWriteGeneration
+	}
+
+	status.Migrated = status.Verified && status.KubeObjectsCaptured
+}
+
+// primaryVRG returns the VRG of the DRPC that is primary, if any
+func (d *DRPCInstance) primaryVRG() *rmn.VolumeReplicationGroup {
+	for _, vrg := range d.vrgs {
+		if vrg.Spec.ReplicationState == rmn.Primary {
+			return vrg
+		}
+	}
+
+	return nil
+}
+
+// S3ProfileMigrator copies the metadata of the DRPCs that request the migration of an S3 profile to the target, out of
+// the reconciles of the DRPCs, and switches the DRClusters of the source to the target once every DRPC of them is
+// migrated. A copy is checkpointed in the status of the DRPC every batch of objects, and is resumed from there.
+type S3ProfileMigrator struct {
+	client.Client
+	APIReader         client.Reader
+	ObjectStoreGetter ObjectStoreGetter
+	Log               logr.Logger
+	Interval          time.Duration
+}
+
+// NeedLeaderElection runs the migrator only on the leader, alongside the hub reconcilers
+func (m *S3ProfileMigrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start migrates the DRPCs every interval until ctx is done
+func (m *S3ProfileMigrator) Start(ctx context.Context) error {
+	interval := m.Interval
+	if interval <= 0 {
+		interval = S3ProfileMigrationInterval
+	}
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := m.migrate(ctx); err != nil {
+			m.Log.Error(err, "S3 profile migration failed")
+		}
+	}, interval)
+
+	return nil
+}
+
+// migrate advances the migrations the DRPCs request, and then switches the DRClusters whose DRPCs are all migrated
+func (m *S3ProfileMigrator) migrate(ctx context.Context) error {
+	drpcs := &rmn.DRPlacementControlList{}
+	if err := m.List(ctx, drpcs); err != nil {
+		return fmt.Errorf("failed to list DRPCs: %w", err)
+	}
+
+	var errs []error
+
+	for i := range drpcs.Items {
+		drpc := &drpcs.Items[i]
+
+		if status := s3ProfileMigrationStatusGet(drpc); status == nil || status.Migrated ||
+			rmnutil.ResourceIsDeleted(drpc) {
+			continue
+		}
+
+		errs = append(errs, m.migrateDRPC(ctx, drpc))
+	}
+
+	errs = append(errs, m.drClustersSwitch(ctx, drpcs.Items))
+
+	return errors.Join(errs...)
+}
+
+// migrateDRPC advances the migration of drpc batch by batch, checkpointing its progress in its status after each
+func (m *S3ProfileMigrator) migrateDRPC(ctx context.Context, drpc *rmn.DRPlacementControl) error {
+	log := m.Log.WithValues("drpc", client.ObjectKeyFromObject(drpc).String())
+
+	for ctx.Err() == nil {
+		patch := client.MergeFrom(drpc.DeepCopy())
+		status := drpc.Status.S3ProfileMigration
+
+		done, err := m.migrateStep(ctx, drpc, log)
+
+		status.Time = metav1.Now()
+		status.Error = ""
+
+		if err != nil {
+			status.Error = err.Error()
+			log.Info("S3 profile migration failed", "source", status.Source, "target", status.Target,
+				"error", err)
+		}
+
+		status.Migrated = status.Verified && status.KubeObjectsCaptured
+
+		if patchErr := m.Status().Patch(ctx, drpc, patch); patchErr != nil {
+			return fmt.Errorf("drpc %s/%s status patch: %w", drpc.Namespace, drpc.Name, patchErr)
+		}
+
+		if err != nil || done {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// migrateStep deploys the secret of the target to the clusters, for the VRGs to upload to it as well as the source,
+// and, once the primary VRG does, copies a batch of the objects of the source that differ on the target. It returns
+// whether the migration waits for something else than itself.
+func (m *S3ProfileMigrator) migrateStep(ctx context.Context, drpc *rmn.DRPlacementControl, log logr.Logger,
+) (bool, error) {
+	const done = true
+
+	status := drpc.Status.S3ProfileMigration
+
+	drPolicy, err := GetDRPolicy(ctx, m.Client, drpc, log)
+	if err != nil {
+		return done, err
+	}
+
+	drClusters, err := GetDRClusters(ctx, m.Client, drPolicy)
+	if err != nil {
+		return done, err
+	}
+
+	if err := s3ProfileMigrationValidate(drpc, drClusters, status.Source, status.Target); err != nil {
+		return done, err
+	}
+
+	if !status.DualWrite {
+		if err := m.targetSecretDeploy(ctx, drPolicy, status.Target); err != nil {
+			return done, err
+		}
+
+		status.DualWrite = true
+		log.Info("S3 profile migration target deployed", "target", status.Target)
+
+		return done, nil
+	}
+
+	// The objects are copied only once the primary VRG uploads to the target too, for none to be missed
+	if status.DualWriteGeneration == 0 || status.Verified {
+		return done, nil
+	}
+
+	placementObj, err := getPlacementOrPlacementRule(ctx, m.Client, drpc, log)
+	if err != nil {
+		return done, err
+	}
+
+	vrgNamespace, err := selectVRGNamespace(m.Client, log, drpc, placementObj)
+	if err != nil {
+		return done, err
+	}
+
+	sourceStore, _, err := m.ObjectStoreGetter.ObjectStore(ctx, m.APIReader, status.Source, "s3 profile migration",
+		log)
+	if err != nil {
+		return done, err
+	}
+
+	targetStore, _, err := m.ObjectStoreGetter.ObjectStore(ctx, m.APIReader, status.Target, "s3 profile migration",
+		log)
+	if err != nil {
+		return done, err
+	}
+
+	if err := s3ObjectsSync(sourceStore, targetStore, vrgNamespace, drpc.GetName(), status,
+		s3ProfileMigrationBatchSize); err != nil {
+		return done, err
+	}
+
+	if status.Verified {
+		log.Info("S3 profile migration verified", "source", status.Source, "target", status.Target,
+			"objects", status.ObjectsCopied)
+	}
+
+	return status.Verified, nil
+}
+
+// targetSecretDeploy deploys the secret of the target S3 profile to the clusters of the DRPolicy, as the DRPolicy
+// reconciler does for the S3 profiles of its DRClusters
+func (m *S3ProfileMigrator) targetSecretDeploy(ctx context.Context, drPolicy *rmn.DRPolicy, target string) error {
+	_, ramenConfig, err := ConfigMapGet(ctx, m.APIReader)
+	if err != nil {
+		return fmt.Errorf("config map get: %w", err)
+	}
+
+	profile := RamenConfigS3StoreProfilePointerGet(ramenConfig, target)
+	if profile == nil {
+		return fmt.Errorf("s3 profile %s not found in RamenConfig", target)
+	}
+
+	if !ramenConfig.DrClusterOperator.DeploymentAutomationEnabled ||
+		!ramenConfig.DrClusterOperator.S3SecretDistributionEnabled {
+		return nil
+	}
+
+	secretsUtil := &rmnutil.SecretsUtil{Client: m.Client, APIReader: m.APIReader, Ctx: ctx, Log: m.Log}
+
+	for _, clusterName := range rmnutil.DRPolicyClusterNames(drPolicy) {
+		for _, secretKey := range s3ProfileSecretKeys(*profile) {
+			if err := drClusterSecretDeploy(clusterName, secretKey, secretsUtil, ramenConfig); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// drClustersSwitch switches the S3 profile of each DRCluster from the source of a migration to its target, once every
+// DRPC of the DRCluster is migrated from the source to the target, recording the migration in the DRCluster for its
+// validation to allow the switch
+func (m *S3ProfileMigrator) drClustersSwitch(ctx context.Context, drpcs []rmn.DRPlacementControl) error {
+	drPolicies := &rmn.DRPolicyList{}
+	if err := m.List(ctx, drPolicies); err != nil {
+		return fmt.Errorf("failed to list DRPolicies: %w", err)
+	}
+
+	drClusters := &rmn.DRClusterList{}
+	if err := m.List(ctx, drClusters); err != nil {
+		return fmt.Errorf("failed to list DRClusters: %w", err)
+	}
+
+	var errs []error
+
+	for i := range drClusters.Items {
+		drCluster := &drClusters.Items[i]
+
+		target := s3ProfileMigrationTarget(drCluster, drPolicies.Items, drpcs)
+		if target == "" || rmnutil.ResourceIsDeleted(drCluster) {
+			continue
+		}
+
+		source := drCluster.Spec.S3ProfileName
+		drCluster.Spec.S3ProfileName = target
+		drCluster.Spec.S3ProfileMigration = &rmn.S3ProfileMigration{Source: source, Target: target}
+
+		if err := m.Update(ctx, drCluster); err != nil {
+			errs = append(errs, fmt.Errorf("drcluster %s update: %w", drCluster.Name, err))
+
+			continue
+		}
+
+		m.Log.Info("Switched the S3 profile of DRCluster", "name", drCluster.Name, "source", source,
+			"target", target)
+	}
+
+	return errors.Join(errs...)
+}
+
+// s3ProfileMigrationTarget returns the target of the migration from the S3 profile of drCluster that every DRPC of
+// drCluster is migrated with, if there is one
+func s3ProfileMigrationTarget(drCluster *rmn.DRCluster, drPolicies []rmn.DRPolicy, drpcs []rmn.DRPlacementControl,
+) string {
+	policies := sets.New[string]()
+
+	for i := range drPolicies {
+		if slices.Contains(rmnutil.DRPolicyClusterNames(&drPolicies[i]), drCluster.Name) {
+			policies.Insert(drPolicies[i].Name)
+		}
+	}
+
+	target := ""
+
+	for i := range drpcs {
+		drpc := &drpcs[i]
+		if !policies.Has(drpc.Spec.DRPolicyRef.Name) {
+			continue
+		}
+
+		status := s3ProfileMigrationStatusGet(drpc)
+		if status == nil || !status.Migrated || status.Source != drCluster.Spec.S3ProfileName ||
+			target != "" && status.Target != target {
+			return ""
+		}
+
+		target = status.Target
+	}
+
+	return target
+}
+
+// s3ObjectsSync copies the objects owned by ramen of the VRG, after the LastKey of status, from source to target
+// if they differ there, up to limit of them, verifying each by reading it back from target. Once all are synced, it
+// deletes the objects from target that are not in source, and reports them verified in status. Objects are copied
+// decrypted and decompressed, to be encrypted and compressed as configured for the target.
+func s3ObjectsSync(source, target ObjectStorer, vrgNamespace, vrgName string, status *rmn.S3ProfileMigrationStatus,
+	limit int,
+) error {
+	keyPrefix := metadata.VolumeReplicationGroupPrefix(vrgNamespace, vrgName)
+
+	// The target is listed first, for an object uploaded to both meanwhile not to be deleted from it
+	targetKeys, err := target.ListKeys(keyPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to list objects with key prefix %s, %w", keyPrefix, err)
+	}
+
+	sourceKeys, err := source.ListKeys(keyPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to list objects with key prefix %s, %w", keyPrefix, err)
+	}
+
+	slices.Sort(sourceKeys)
+
+	// The capture generations record the versions of the objects in the source
+	captureGenerationsPrefix := metadata.TypedKeyPrefix(keyPrefix, metadata.TypeNameCaptureGeneration)
+	migrated := func(key string) bool {
+		return s3ObjectOwned(vrgNamespace, vrgName, key) && !strings.HasPrefix(key, captureGenerationsPrefix)
+	}
+
+	synced := 0
+
+	for _, key := range sourceKeys {
+		if !migrated(key) || key <= status.LastKey {
+			continue
+		}
+
+		if synced == limit {
+			return nil
+		}
+
+		copied, err := s3ObjectSync(source, target, key)
+		if err != nil {
+			return err
+		}
+
+		if copied {
+			status.ObjectsCopied++
+		}
+
+		status.LastKey = key
+		synced++
+	}
+
+	for _, key := range targetKeys {
+		if migrated(key) && !slices.Contains(sourceKeys, key) {
+			if err := target.DeleteObject(key); err != nil {
+				return fmt.Errorf("failed to delete object %s, %w", key, err)
+			}
+		}
+	}
+
+	status.LastKey = ""
+	status.Verified = true
+
+	return nil
+}
+
+// s3ObjectSync copies the object with key from source to target unless it is the same there, verifying the copy by
+// reading it back, and returns whether it copied it
+func s3ObjectSync(source, target ObjectStorer, key string) (bool, error) {
+	object := json.RawMessage{}
+	if err := source.DownloadObject(key, &object); err != nil {
+		return false, err
+	}
+
+	objectCopy := json.RawMessage{}
+	if err := target.DownloadObject(key, &objectCopy); err == nil && jsonEqual(object, objectCopy) {
+		return false, nil
+	}
+
+	if err := target.UploadObject(key, object); err != nil {
+		return false, err
+	}
+
+	objectCopy = json.RawMessage{}
+	if err := target.DownloadObject(key, &objectCopy); err != nil {
+		return false, fmt.Errorf("failed to verify copy of object %s, %w", key, err)
+	}
+
+	if !jsonEqual(object, objectCopy) {
+		return false, fmt.Errorf("copy of object %s differs from the object", key)
+	}
+
+	return true, nil
+}
+
+// s3ObjectOwned returns whether the object with key of the VRG is owned by ramen, rather than being part of a kube
+// objects capture of Velero, which the VRG captures to the target as well once it uploads to it
+func s3ObjectOwned(vrgNamespace, vrgName, key string) bool {
+	capture, ok := strings.CutPrefix(key, metadata.KubeObjectsPrefix(vrgNamespace, vrgName))
+	if !ok {
+		return true
+	}
+
+	captureNumber, capturePath, _ := strings.Cut(capture, "/")
+	if _, err := strconv.ParseInt(captureNumber, 10, 64); err != nil {
+		return true
+	}
+
+	kubeObjects := velero.RequestsManager{}
+
+	return !strings.HasPrefix(capturePath, kubeObjects.ProtectsPath()) &&
+		!strings.HasPrefix(capturePath, kubeObjects.RecoversPath())
+}
+
+func jsonEqual(a, b []byte) bool {
+	compactA, compactB := &bytes.Buffer{}, &bytes.Buffer{}
+
+	return json.Compact(compactA, a) == nil && json.Compact(compactB, b) == nil &&
+		bytes.Equal(compactA.Bytes(), compactB.Bytes())
+}
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+var _ = Describe("DRPC S3 profile migration", func() {
+	var (
+		east, old *gcObjectStore
+		d         *DRPCInstance
+		vrg       *rmn.VolumeReplicationGroup
+	)
+
+	const pvKey = "app/drpc/v1.PersistentVolume/pv1"
+
+	newStore := func() *gcObjectStore {
+		return &gcObjectStore{healthObjectStore{objects: map[string]interface{}{}}}
+	}
+
+	drCluster := func(name, s3ProfileName string) rmn.DRCluster {
+		return rmn.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       rmn.DRClusterSpec{S3ProfileName: s3ProfileName},
+		}
+	}
+
+	vrgKey := metadata.TypedKey("app/drpc/", metadata.TypeNameVolumeReplicationGroup,
+		metadata.VolumeReplicationGroupName)
+
+	BeforeEach(func() {
+		east, old = newStore(), newStore()
+
+		Expect(old.UploadObject(pvKey, corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv1"},
+		})).To(Succeed())
+		Expect(old.UploadObject(vrgKey, rmn.VolumeReplicationGroup{})).To(Succeed())
+		Expect(old.UploadObject(metadata.KubeObjectsCapturePrefix("app", "drpc", 1)+"velero/backups/b/b.json",
+			"velero")).To(Succeed())
+
+		vrg = &rmn.VolumeReplicationGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "drpc", Namespace: "app", Generation: 3},
+			Spec: rmn.VolumeReplicationGroupSpec{
+				ReplicationState:     rmn.Primary,
+				S3Profiles:           []string{"old", "west"},
+				KubeObjectProtection: &rmn.KubeObjectProtectionSpec{},
+			},
+			Status: rmn.VolumeReplicationGroupStatus{ObservedGeneration: 3},
+		}
+
+		d = &DRPCInstance{
+			ctx: context.TODO(),
+			log: logr.Discard(),
+			instance: &rmn.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{
+				Name:        "drpc",
+				Annotations: map[string]string{DRPCS3ProfileMigrationAnnotation: "old=east"},
+			}},
+			drClusters: []rmn.DRCluster{drCluster("east", "old"), drCluster("west", "west")},
+			vrgs:       map[string]*rmn.VolumeReplicationGroup{"east": vrg},
+		}
+	})
+
+	It("uploads to the target as well as the source only once the target is deployed", func() {
+		d.s3ProfileMigrationObserve()
+
+		status := d.instance.Status.S3ProfileMigration
+		Expect(status).To(HaveField("Source", "old"))
+		Expect(status).To(HaveField("Target", "east"))
+		Expect(s3ProfilesMigrating(d.instance, AvailableS3Profiles(d.drClusters))).To(Equal([]string{"old", "west"}))
+
+		status.DualWrite = true
+		Expect(s3ProfilesMigrating(d.instance, AvailableS3Profiles(d.drClusters))).To(
+			Equal([]string{"old", "west", "east"}))
+
+		d.drClusters = []rmn.DRCluster{drCluster("east", "east"), drCluster("west", "west")}
+		Expect(s3ProfilesMigrating(d.instance, AvailableS3Profiles(d.drClusters))).To(Equal([]string{"east", "west"}))
+	})
+
+	It("reports the DRPC migrated once the copy is verified and the kube objects are captured to the target", func() {
+		d.s3ProfileMigrationObserve()
+		status := d.instance.Status.S3ProfileMigration
+		status.DualWrite = true
+
+		d.s3ProfileMigrationObserve()
+		Expect(status).To(HaveField("DualWriteGeneration", int64(0)))
+
+		vrg.Spec.S3Profiles = []string{"old", "west", "east"}
+		vrg.Generation = 4
+		d.s3ProfileMigrationObserve()
+		Expect(status).To(HaveField("DualWriteGeneration", int64(0)))
+
+		vrg.Status.ObservedGeneration = 4
+		vrg.Status.KubeObjectProtection.CaptureToRecoverFrom = &rmn.KubeObjectsCaptureIdentifier{StartGeneration: 3}
+		d.s3ProfileMigrationObserve()
+		Expect(status).To(HaveField("DualWriteGeneration", int64(4)))
+		Expect(status).To(HaveField("KubeObjectsCaptured", false))
+
+		vrg.Status.KubeObjectProtection.CaptureToRecoverFrom.StartGeneration = 4
+		d.s3ProfileMigrationObserve()
+		Expect(status).To(HaveField("KubeObjectsCaptured", true))
+		Expect(status).To(HaveField("Migrated", false))
+
+		status.Verified = true
+		d.s3ProfileMigrationObserve()
+		Expect(status).To(HaveField("Migrated", true))
+
+		delete(d.instance.Annotations, DRPCS3ProfileMigrationAnnotation)
+		d.s3ProfileMigrationObserve()
+		Expect(d.instance.Status.S3ProfileMigration).To(BeNil())
+	})
+
+	It("copies the objects in batches, resuming from the last one copied, and verifies the target", func() {
+		status := &rmn.S3ProfileMigrationStatus{Source: "old", Target: "east"}
+		Expect(east.UploadObject("app/drpc/v1.PersistentVolume/pv2", corev1.PersistentVolume{})).To(Succeed())
+
+		Expect(s3ObjectsSync(old, east, "app", "drpc", status, 1)).To(Succeed())
+		Expect(status).To(HaveField("ObjectsCopied", 1))
+		Expect(status).To(HaveField("Verified", false))
+		Expect(status.LastKey).ToNot(BeEmpty())
+		Expect(east.objects).To(HaveLen(2))
+
+		Expect(s3ObjectsSync(old, east, "app", "drpc", status, 1)).To(Succeed())
+		Expect(status).To(HaveField("ObjectsCopied", 2))
+		Expect(status).To(HaveField("Verified", false))
+
+		Expect(s3ObjectsSync(old, east, "app", "drpc", status, 1)).To(Succeed())
+		Expect(status).To(HaveField("Verified", true))
+		Expect(status).To(HaveField("LastKey", ""))
+		Expect(east.objects).To(HaveLen(2))
+		Expect(east.objects).To(HaveKey(pvKey))
+		Expect(east.objects).To(HaveKey(vrgKey))
+
+		pv := corev1.PersistentVolume{}
+		Expect(east.DownloadObject(pvKey, &pv)).To(Succeed())
+		Expect(pv.Name).To(Equal("pv1"))
+	})
+
+	It("keeps the progress of a copy that fails, and copies nothing that is already on the target", func() {
+		status := &rmn.S3ProfileMigrationStatus{Source: "old", Target: "east"}
+		east.putFails = true
+
+		Expect(s3ObjectsSync(old, east, "app", "drpc", status, 10)).To(MatchError(ContainSubstring("bucket unavailable")))
+		Expect(status).To(HaveField("Verified", false))
+
+		east.putFails = false
+		Expect(east.UploadObject(pvKey, corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv1"},
+		})).To(Succeed())
+
+		Expect(s3ObjectsSync(old, east, "app", "drpc", status, 10)).To(Succeed())
+		Expect(status).To(HaveField("ObjectsCopied", 1))
+		Expect(status).To(HaveField("Verified", true))
+	})
+
+	It("switches the S3 profile of a DRCluster once every DRPC of it is migrated", func() {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+
+		east, west := drCluster("east", "old"), drCluster("west", "west")
+		drPolicy := &rmn.DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+			Spec:       rmn.DRPolicySpec{DRClusters: []string{"east", "west"}},
+		}
+		drpc := func(name string, migrated bool) rmn.DRPlacementControl {
+			return rmn.DRPlacementControl{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "app",
+					Name:        name,
+					Annotations: map[string]string{DRPCS3ProfileMigrationAnnotation: "old=east"},
+				},
+				Spec: rmn.DRPlacementControlSpec{DRPolicyRef: corev1.ObjectReference{Name: "policy"}},
+				Status: rmn.DRPlacementControlStatus{S3ProfileMigration: &rmn.S3ProfileMigrationStatus{
+					Source: "old", Target: "east", Migrated: migrated,
+				}},
+			}
+		}
+		drpcs := []rmn.DRPlacementControl{drpc("one", true), drpc("two", false)}
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&east, &west, drPolicy).Build()
+		migrator := &S3ProfileMigrator{Client: fakeClient, Log: logr.Discard()}
+
+		Expect(migrator.drClustersSwitch(context.TODO(), drpcs)).To(Succeed())
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(&east), &east)).To(Succeed())
+		Expect(east.Spec.S3ProfileName).To(Equal("old"))
+
+		drpcs[1].Status.S3ProfileMigration.Migrated = true
+		Expect(migrator.drClustersSwitch(context.TODO(), drpcs)).To(Succeed())
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(&east), &east)).To(Succeed())
+		Expect(east.Spec.S3ProfileName).To(Equal("east"))
+		Expect(east.Spec.S3ProfileMigration).To(Equal(&rmn.S3ProfileMigration{Source: "old", Target: "east"}))
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(&west), &west)).To(Succeed())
+		Expect(west.Spec.S3ProfileName).To(Equal("west"))
+	})
+})
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// drpcTemplateGet returns the DRPlacementControlTemplate referenced by drpc, or nil if it references none
+func drpcTemplateGet(ctx context.Context, reader client.Reader, drpc *rmn.DRPlacementControl,
+) (*rmn.DRPlacementControlTemplate, error) {
+	if drpc.Spec.TemplateRef == nil || drpc.Spec.TemplateRef.Name == "" {
+		return nil, nil
+	}
+
+	template := &rmn.DRPlacementControlTemplate{}
+
+	if err := reader.Get(ctx, types.NamespacedName{Name: drpc.Spec.TemplateRef.Name}, template); err != nil {
+		return nil, fmt.Errorf("failed to get DRPlacementControlTemplate %s: %w", drpc.Spec.TemplateRef.Name, err)
+	}
+
+	return template, nil
+}
+
+// drpcSpecTemplated returns the spec of drpc with the settings it does not set defaulted from its template
+func drpcSpecTemplated(ctx context.Context, reader client.Reader, drpc *rmn.DRPlacementControl,
+) (*rmn.DRPlacementControlSpec, error) {
+	template, err := drpcTemplateGet(ctx, reader, drpc)
+	if err != nil {
+		return nil, err
+	}
+
+	if template == nil {
+		return drpc.Spec.DeepCopy(), nil
+	}
+
+	return drpcSpecWithTemplate(&drpc.Spec, &template.Spec), nil
+}
+
+// drpcSpecWithTemplate returns a copy of spec with the settings it does not set defaulted from template. An empty
+// pvcSelector is defaulted, as the DRPC requires one.
+func drpcSpecWithTemplate(spec *rmn.DRPlacementControlSpec, template *rmn.DRPlacementControlTemplateSpec,
+) *rmn.DRPlacementControlSpec {
+	templated := spec.DeepCopy()
+
+	if template.PVCSelector != nil && len(spec.PVCSelector.MatchLabels) == 0 &&
+		len(spec.PVCSelector.MatchExpressions) == 0 {
+		templated.PVCSelector = *template.PVCSelector.DeepCopy()
+	}
+
+	templated.KubeObjectProtection = kubeObjectProtectionWithTemplate(spec.KubeObjectProtection,
+		template.KubeObjectProtection)
+
+	if spec.VolSyncSpec == nil {
+		templated.VolSyncSpec = template.VolSyncSpec.DeepCopy()
+	}
+
+	if spec.FinalizationHooks == nil {
+		templated.FinalizationHooks = template.FinalizationHooks.DeepCopy()
+	}
+
+	return templated
+}
+
+// kubeObjectProtectionWithTemplate returns a copy of spec with each field it does not set defaulted from template
+func kubeObjectProtectionWithTemplate(spec, template *rmn.KubeObjectProtectionSpec) *rmn.KubeObjectProtectionSpec {
+	if template == nil {
+		return spec.DeepCopy()
+	}
+
+	if spec == nil {
+		return template.DeepCopy()
+	}
+
+	templated := spec.DeepCopy()
+
+	if templated.CaptureInterval == nil && template.CaptureInterval != nil {
+		captureInterval := *template.CaptureInterval
+		templated.CaptureInterval = &captureInterval
+	}
+
+	if templated.RecipeRef == nil {
+		templated.RecipeRef = template.RecipeRef.DeepCopy()
+	}
+
+	if templated.RecipeParameters == nil {
+		templated.RecipeParameters = maps.Clone(template.RecipeParameters)
+	}
+
+	if templated.KubeObjectSelector == nil {
+		templated.KubeObjectSelector = template.KubeObjectSelector.DeepCopy()
+	}
+
+	if templated.Differential == nil {
+		templated.Differential = template.Differential.DeepCopy()
+	}
+
+	if templated.HelmReleases == nil {
+		templated.HelmReleases = slices.Clone(template.HelmReleases)
+	}
+
+	if !templated.NamespaceQuotas {
+		templated.NamespaceQuotas = template.NamespaceQuotas
+	}
+
+	return templated
+}
+
+// FilterDRPCsForTemplate returns the requests of the DRPCs referencing the DRPlacementControlTemplate
+func (r *DRPlacementControlReconciler) FilterDRPCsForTemplate(template *rmn.DRPlacementControlTemplate,
+) []ctrl.Request {
+	log := ctrl.Log.WithName("DRPCFilter").WithName("DRPlacementControlTemplate").WithValues("template", template.Name)
+
+	drpcs := &rmn.DRPlacementControlList{}
+	if err := r.List(context.TODO(), drpcs); err != nil {
+		log.Info("Failed to process DRPlacementControlTemplate filter", "error", err)
+
+		return []ctrl.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0)
+
+	for _, drpc := range drpcs.Items {
+		if drpc.Spec.TemplateRef != nil && drpc.Spec.TemplateRef.Name == template.Name {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: drpc.GetName(), Namespace: drpc.GetNamespace()},
+			})
+		}
+	}
+
+	return requests
+}
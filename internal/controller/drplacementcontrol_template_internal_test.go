@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("drpcSpecWithTemplate", func() {
+	templateSelector := metav1.LabelSelector{MatchLabels: map[string]string{"protect": "template"}}
+	template := &rmn.DRPlacementControlTemplateSpec{
+		PVCSelector: &templateSelector,
+		KubeObjectProtection: &rmn.KubeObjectProtectionSpec{
+			CaptureInterval:  &metav1.Duration{Duration: 10 * time.Minute},
+			RecipeParameters: map[string][]string{"key": {"template"}},
+		},
+		FinalizationHooks: &rmn.FinalizationHooksSpec{},
+	}
+
+	It("defaults the settings a DRPC does not set from its template", func() {
+		spec := &rmn.DRPlacementControlSpec{}
+
+		templated := drpcSpecWithTemplate(spec, template)
+		Expect(templated.PVCSelector).To(Equal(templateSelector))
+		Expect(templated.KubeObjectProtection).To(Equal(template.KubeObjectProtection))
+		Expect(templated.FinalizationHooks).ToNot(BeNil())
+		Expect(templated.VolSyncSpec).To(BeNil())
+		Expect(spec.KubeObjectProtection).To(BeNil())
+	})
+
+	It("keeps the settings a DRPC sets, defaulting each unset kube object protection setting", func() {
+		spec := &rmn.DRPlacementControlSpec{
+			PVCSelector: metav1.LabelSelector{MatchLabels: map[string]string{"protect": "drpc"}},
+			KubeObjectProtection: &rmn.KubeObjectProtectionSpec{
+				RecipeParameters: map[string][]string{"key": {"drpc"}},
+			},
+		}
+
+		templated := drpcSpecWithTemplate(spec, template)
+		Expect(templated.PVCSelector).To(Equal(spec.PVCSelector))
+		Expect(templated.KubeObjectProtection.RecipeParameters).To(Equal(spec.KubeObjectProtection.RecipeParameters))
+		Expect(templated.KubeObjectProtection.CaptureInterval).To(Equal(template.KubeObjectProtection.CaptureInterval))
+		Expect(spec.KubeObjectProtection.CaptureInterval).To(BeNil())
+	})
+})
@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+// vrgSchemaFirstVersion is the version of the VRG spec of the clusters that report none
+const vrgSchemaFirstVersion = 1
+
+// vrgSchemaField is a field of the VRG spec added in a version of its schema, along with the function that clears it
+// from a spec, returning whether it was set
+type vrgSchemaField struct {
+	version int
+	name    string
+	clear   func(spec *rmn.VolumeReplicationGroupSpec) bool
+}
+
+// vrgSchemaFields are the fields of the VRG spec added after the first version of its schema
+var vrgSchemaFields = []vrgSchemaField{
+	{
+		version: 2,
+		name:    "kubeObjectProtection.namespaceQuotas",
+		clear: func(spec *rmn.VolumeReplicationGroupSpec) bool {
+			if spec.KubeObjectProtection == nil || !spec.KubeObjectProtection.NamespaceQuotas {
+				return false
+			}
+
+			// the kube object protection of the spec is that of the DRPC
+			spec.KubeObjectProtection = spec.KubeObjectProtection.DeepCopy()
+			spec.KubeObjectProtection.NamespaceQuotas = false
+
+			return true
+		},
+	},
+	{
+		version: 2,
+		name:    "async.replicationClassNames",
+		clear: func(spec *rmn.VolumeReplicationGroupSpec) bool {
+			if spec.Async == nil || len(spec.Async.ReplicationClassNames) == 0 {
+				return false
+			}
+
+			spec.Async.ReplicationClassNames = nil
+
+			return true
+		},
+	},
+	{
+		version: 2,
+		name:    "async.backupWindows",
+		clear: func(spec *rmn.VolumeReplicationGroupSpec) bool {
+			if spec.Async == nil || len(spec.Async.BackupWindows) == 0 {
+				return false
+			}
+
+			spec.Async.BackupWindows = nil
+
+			return true
+		},
+	},
+	{
+		version: 2,
+		name:    "async.storageClassSchedulingIntervals",
+		clear: func(spec *rmn.VolumeReplicationGroupSpec) bool {
+			if spec.Async == nil || len(spec.Async.StorageClassSchedulingIntervals) == 0 {
+				return false
+			}
+
+			spec.Async.StorageClassSchedulingIntervals = nil
+
+			return true
+		},
+	},
+	{
+		version: 3,
+		name:    "async.volSync",
+		clear: func(spec *rmn.VolumeReplicationGroupSpec) bool {
+			if spec.Async == nil || spec.Async.VolSync == nil {
+				return false
+			}
+
+			spec.Async.VolSync = nil
+
+			return true
+		},
+	},
+}
+
+// vrgSpecDowngrade clears the fields of spec added after version, and returns the names of those that were set
+func vrgSpecDowngrade(spec *rmn.VolumeReplicationGroupSpec, version int) []string {
+	cleared := []string{}
+
+	for _, field := range vrgSchemaFields {
+		if field.version > version && field.clear(spec) {
+			cleared = append(cleared, field.name)
+		}
+	}
+
+	return cleared
+}
+
+// clusterVRGSchemaVersion returns the version of the VRG spec the cluster reports supporting, and whether it is known,
+// that is whether its DRClusterConfig was read back
+func clusterVRGSchemaVersion(drClusters []rmn.DRCluster, clusterName string) (int, bool) {
+	for i := range drClusters {
+		if drClusters[i].Name != clusterName {
+			continue
+		}
+
+		clusterConfig := drClusters[i].Status.ClusterConfig
+		if clusterConfig == nil {
+			return 0, false
+		}
+
+		return max(clusterConfig.VRGSchemaVersion, vrgSchemaFirstVersion), true
+	}
+
+	return 0, false
+}
+
+// downgradeVRGSpec leaves the fields of the VRG spec that the cluster does not support out of the VRG of the
+// cluster, so that a cluster running an earlier version of ramen, as during an upgrade, does not reject or ignore
+// them unawares, and warns of each field left out. The VRG is left as is while the version of the cluster is unknown.
+func (d *DRPCInstance) downgradeVRGSpec(vrg *rmn.VolumeReplicationGroup, clusterName string) {
+	version, known := clusterVRGSchemaVersion(d.drClusters, clusterName)
+	if !known || version >= rmn.VRGSchemaVersion {
+		return
+	}
+
+	cleared := vrgSpecDowngrade(&vrg.Spec, version)
+	if len(cleared) == 0 {
+		return
+	}
+
+	msg := fmt.Sprintf("Cluster %s supports VRG schema version %d of %d; left %s out of its VRG",
+		clusterName, version, rmn.VRGSchemaVersion, strings.Join(cleared, ", "))
+
+	d.log.Info(msg)
+
+	if d.reconciler != nil {
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonVRGSpecDowngraded, msg)
+	}
+}
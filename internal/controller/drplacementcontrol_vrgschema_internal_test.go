@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPC VRG schema version", func() {
+	drCluster := func(name string, clusterConfig *rmn.DRClusterConfigResult) rmn.DRCluster {
+		return rmn.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     rmn.DRClusterStatus{ClusterConfig: clusterConfig},
+		}
+	}
+
+	var (
+		kubeObjectProtection *rmn.KubeObjectProtectionSpec
+		d                    *DRPCInstance
+	)
+
+	vrg := func() *rmn.VolumeReplicationGroup {
+		return &rmn.VolumeReplicationGroup{Spec: rmn.VolumeReplicationGroupSpec{
+			KubeObjectProtection: kubeObjectProtection,
+			Async: &rmn.VRGAsyncSpec{
+				SchedulingInterval:    "5m",
+				ReplicationClassNames: []string{"array"},
+			},
+		}}
+	}
+
+	BeforeEach(func() {
+		kubeObjectProtection = &rmn.KubeObjectProtectionSpec{NamespaceQuotas: true}
+		d = &DRPCInstance{
+			log:      logr.Discard(),
+			instance: &rmn.DRPlacementControl{},
+			drClusters: []rmn.DRCluster{
+				drCluster("old", &rmn.DRClusterConfigResult{}),
+				drCluster("current", &rmn.DRClusterConfigResult{VRGSchemaVersion: rmn.VRGSchemaVersion}),
+				drCluster("unknown", nil),
+			},
+		}
+	})
+
+	It("leaves the fields of later versions out of the VRG of a cluster of an earlier version", func() {
+		downgraded := vrg()
+		d.downgradeVRGSpec(downgraded, "old")
+
+		Expect(downgraded.Spec.KubeObjectProtection.NamespaceQuotas).To(BeFalse())
+		Expect(downgraded.Spec.Async.ReplicationClassNames).To(BeNil())
+		Expect(downgraded.Spec.Async.SchedulingInterval).To(Equal("5m"))
+		Expect(kubeObjectProtection.NamespaceQuotas).To(BeTrue())
+	})
+
+	It("leaves the VRG of a cluster of the current or an unknown version as is", func() {
+		for _, clusterName := range []string{"current", "unknown"} {
+			generated := vrg()
+			d.downgradeVRGSpec(generated, clusterName)
+
+			Expect(generated).To(Equal(vrg()))
+		}
+	})
+
+	It("leaves the VolSync tuning out of the VRG of a cluster of the version before it", func() {
+		downgraded := vrg()
+		downgraded.Spec.Async.VolSync = &rmn.VolSyncTuning{CopyMethod: "Direct"}
+		d.drClusters = append(d.drClusters, drCluster("previous", &rmn.DRClusterConfigResult{VRGSchemaVersion: 2}))
+		d.downgradeVRGSpec(downgraded, "previous")
+
+		Expect(downgraded.Spec.Async.VolSync).To(BeNil())
+		Expect(downgraded.Spec.Async.ReplicationClassNames).To(Equal([]string{"array"}))
+	})
+
+	It("reports the fields left out", func() {
+		Expect(vrgSpecDowngrade(&vrg().Spec, vrgSchemaFirstVersion)).To(Equal([]string{
+			"kubeObjectProtection.namespaceQuotas", "async.replicationClassNames",
+		}))
+		Expect(vrgSpecDowngrade(&vrg().Spec, rmn.VRGSchemaVersion)).To(BeEmpty())
+	})
+})
@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	ocmv1 "open-cluster-management.io/api/cluster/v1"
 	clrapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	ocmworkv1 "open-cluster-management.io/api/work/v1"
 	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
@@ -242,6 +243,88 @@ func DRClusterPredicateFunc() predicate.Funcs {
 	return drClusterPredicate
 }
 
+// DRClusterFencePredicateFunc filters for DRCluster updates where the cluster became fenced, unfenced or clean, as
+// the DRPCs failing over from or relocating to it wait for these transitions
+func DRClusterFencePredicateFunc() predicate.Funcs {
+	log := ctrl.Log.WithName("DRPCPredicate").WithName("DRClusterFence")
+
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			drcOld, ok := e.ObjectOld.(*rmn.DRCluster)
+			if !ok {
+				return false
+			}
+
+			drcNew, ok := e.ObjectNew.(*rmn.DRCluster)
+			if !ok {
+				return false
+			}
+
+			changed := false
+
+			for _, conditionType := range []string{rmn.DRClusterConditionTypeFenced, rmn.DRClusterConditionTypeClean} {
+				if drClusterConditionTrue(drcOld, conditionType) != drClusterConditionTrue(drcNew, conditionType) {
+					changed = true
+				}
+			}
+
+			if changed {
+				log.Info("DRCluster fence state changed", "name", drcNew.GetName())
+			}
+
+			return changed
+		},
+	}
+}
+
+// drClusterConditionTrue returns whether the condition of drcluster is true for its current generation, as the
+// DRPCs read it
+func drClusterConditionTrue(drcluster *rmn.DRCluster, conditionType string) bool {
+	condition := rmnutil.FindCondition(drcluster.Status.Conditions, conditionType)
+
+	return condition != nil && condition.Status == metav1.ConditionTrue &&
+		condition.ObservedGeneration == drcluster.Generation
+}
+
+// FilterDRClusterFence returns the DRPCs whose failover cluster, preferred cluster or current cluster is the
+// DRCluster whose fence state changed
+func (r *DRPlacementControlReconciler) FilterDRClusterFence(drcluster *rmn.DRCluster) []ctrl.Request {
+	log := ctrl.Log.WithName("DRPCFilter").WithName("DRClusterFence").WithValues("cluster", drcluster.GetName())
+
+	drpcCollections, err := DRPCsUsingDRCluster(r.Client, log, drcluster)
+	if err != nil {
+		log.Info("Failed to process filter")
+
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+
+	for idx := range drpcCollections {
+		drpc := drpcCollections[idx].drpc
+
+		if drpcFailoverCluster(drpc) != drcluster.GetName() && drpc.Spec.PreferredCluster != drcluster.GetName() &&
+			drpc.Status.PreferredDecision.ClusterName != drcluster.GetName() {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: drpc.GetName(), Namespace: drpc.GetNamespace()},
+		})
+	}
+
+	return requests
+}
+
 func DRPolicyPredicateFunc() predicate.Funcs {
 	log := ctrl.Log.WithName("DRPCPredicate").WithName("DRPolicy")
 	drPolicyPredicate := predicate.Funcs{
@@ -631,7 +714,7 @@ func DRPCsFailingOverToClusterForPolicy(
 			continue
 		}
 
-		if !(drpc.Spec.Action == rmn.ActionFailover && drpc.Spec.FailoverCluster == drcluster) {
+		if !(drpc.Spec.Action == rmn.ActionFailover && drpcFailoverCluster(drpc) == drcluster) {
 			continue
 		}
 
@@ -670,7 +753,7 @@ func (r *DRPlacementControlReconciler) FilterDRPCsForDRPolicyUpdate(drpolicy *rm
 	requests := make([]reconcile.Request, 0)
 
 	for _, drpc := range drpcs.Items {
-		if drpc.Spec.DRPolicyRef.Name == drpolicy.GetName() {
+		if drpc.Spec.DRPolicyRef.Name == drpolicy.GetName() || drpc.Status.ObservedDRPolicy == drpolicy.GetName() {
 			requests = append(requests,
 				reconcile.Request{
 					NamespacedName: types.NamespacedName{
@@ -758,6 +841,20 @@ func (r *DRPlacementControlReconciler) setupWithManagerAndAddWatchers(mgr ctrl.M
 			return r.FilterDRCluster(drCluster)
 		}))
 
+	drClusterFencePred := DRClusterFencePredicateFunc()
+
+	drClusterFenceMapFun := handler.EnqueueRequestsFromMapFunc(handler.MapFunc(
+		func(ctx context.Context, obj client.Object) []reconcile.Request {
+			drCluster, ok := obj.(*rmn.DRCluster)
+			if !ok {
+				return []reconcile.Request{}
+			}
+
+			ctrl.Log.Info(fmt.Sprintf("DRPC Map: Filtering fence state of DRCluster (%s)", drCluster.Name))
+
+			return r.FilterDRClusterFence(drCluster)
+		}))
+
 	drPolicyPred := DRPolicyPredicateFunc()
 
 	drPolicyMapFun := handler.EnqueueRequestsFromMapFunc(handler.MapFunc(
@@ -772,6 +869,18 @@ func (r *DRPlacementControlReconciler) setupWithManagerAndAddWatchers(mgr ctrl.M
 			return r.FilterDRPCsForDRPolicyUpdate(drPolicy)
 		}))
 
+	drpcTemplateMapFun := handler.EnqueueRequestsFromMapFunc(handler.MapFunc(
+		func(ctx context.Context, obj client.Object) []reconcile.Request {
+			template, ok := obj.(*rmn.DRPlacementControlTemplate)
+			if !ok {
+				return []reconcile.Request{}
+			}
+
+			ctrl.Log.Info(fmt.Sprintf("DRPC Map: Filtering DRPlacementControlTemplate (%s)", template.Name))
+
+			return r.FilterDRPCsForTemplate(template)
+		}))
+
 	globalVGRDRPCPred := GlobalVGRDRPCPredicateFunc()
 
 	globalVGRDRPCMapFun := handler.EnqueueRequestsFromMapFunc(handler.MapFunc(
@@ -784,6 +893,20 @@ func (r *DRPlacementControlReconciler) setupWithManagerAndAddWatchers(mgr ctrl.M
 			return r.FilterGlobalPeerDRPCs(drpc)
 		}))
 
+	managedClusterPred := ManagedClusterPredicateFunc()
+
+	managedClusterMapFun := handler.EnqueueRequestsFromMapFunc(handler.MapFunc(
+		func(ctx context.Context, obj client.Object) []reconcile.Request {
+			mc, ok := obj.(*ocmv1.ManagedCluster)
+			if !ok {
+				return []reconcile.Request{}
+			}
+
+			ctrl.Log.Info(fmt.Sprintf("DRPC Map: Filtering ManagedCluster (%s)", mc.Name))
+
+			return r.FilterManagedCluster(mc)
+		}))
+
 	r.eventRecorder = rmnutil.NewEventReporter(mgr.GetEventRecorderFor("controller_DRPlacementControl"))
 
 	options := ctrlcontroller.Options{
@@ -793,15 +916,25 @@ func (r *DRPlacementControlReconciler) setupWithManagerAndAddWatchers(mgr ctrl.M
 		options.RateLimiter = *r.RateLimiter
 	}
 
+	if ramenConfig != nil {
+		options.NewQueue = drpcFairQueueNew(mgr.GetCache(), ramenConfig.DRPCFairQueuing)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		For(&rmn.DRPlacementControl{}).
 		Watches(&ocmworkv1.ManifestWork{}, mwMapFun, builder.WithPredicates(mwPred)).
 		Watches(&viewv1beta1.ManagedClusterView{}, mcvMapFun, builder.WithPredicates(mcvPred)).
+		Watches(&viewv1beta1.ManagedClusterView{}, r.ViewSubscriptions.EventHandler(drpcViewSubscriber),
+			builder.WithPredicates(rmnutil.ManagedClusterViewResultVersionUpdatePredicate{})).
 		Watches(&plrv1.PlacementRule{}, usrPlRuleMapFun, builder.WithPredicates(usrPlRulePred)).
 		Watches(&clrapiv1beta1.Placement{}, usrPlmntMapFun, builder.WithPredicates(usrPlmntPred)).
 		Watches(&rmn.DRCluster{}, drClusterMapFun, builder.WithPredicates(drClusterPred)).
+		Watches(&rmn.DRCluster{}, drClusterFenceMapFun, builder.WithPredicates(drClusterFencePred)).
 		Watches(&rmn.DRPolicy{}, drPolicyMapFun, builder.WithPredicates(drPolicyPred)).
+		Watches(&rmn.DRPlacementControlTemplate{}, drpcTemplateMapFun,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(&rmn.DRPlacementControl{}, globalVGRDRPCMapFun, builder.WithPredicates(globalVGRDRPCPred)).
+		Watches(&ocmv1.ManagedCluster{}, managedClusterMapFun, builder.WithPredicates(managedClusterPred)).
 		Complete(r)
 }
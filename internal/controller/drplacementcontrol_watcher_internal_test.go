@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPC requeue on DRCluster fence state", func() {
+	drCluster := func(conditions ...metav1.Condition) *rmn.DRCluster {
+		return &rmn.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "east", Generation: 2},
+			Status:     rmn.DRClusterStatus{Conditions: conditions},
+		}
+	}
+
+	condition := func(conditionType string, status metav1.ConditionStatus, generation int64) metav1.Condition {
+		return metav1.Condition{Type: conditionType, Status: status, ObservedGeneration: generation}
+	}
+
+	updated := func(drcOld, drcNew *rmn.DRCluster) bool {
+		return DRClusterFencePredicateFunc().Update(event.UpdateEvent{ObjectOld: drcOld, ObjectNew: drcNew})
+	}
+
+	It("filters the updates where the cluster becomes fenced, unfenced or clean", func() {
+		unfenced := drCluster(condition(rmn.DRClusterConditionTypeFenced, metav1.ConditionFalse, 2))
+		fenced := drCluster(condition(rmn.DRClusterConditionTypeFenced, metav1.ConditionTrue, 2))
+		fencedBefore := drCluster(condition(rmn.DRClusterConditionTypeFenced, metav1.ConditionTrue, 1))
+		clean := drCluster(condition(rmn.DRClusterConditionTypeFenced, metav1.ConditionFalse, 2),
+			condition(rmn.DRClusterConditionTypeClean, metav1.ConditionTrue, 2))
+
+		Expect(updated(unfenced, fenced)).To(BeTrue())
+		Expect(updated(fenced, unfenced)).To(BeTrue())
+		Expect(updated(unfenced, clean)).To(BeTrue())
+		Expect(updated(fencedBefore, fenced)).To(BeTrue())
+		Expect(updated(fenced, fenced.DeepCopy())).To(BeFalse())
+		Expect(updated(drCluster(), unfenced)).To(BeFalse())
+	})
+
+	It("requeues the DRPCs of the policies of the cluster that fail over, relocate or run on it", func() {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+
+		drpc := func(name, policy string, spec rmn.DRPlacementControlSpec, current string) *rmn.DRPlacementControl {
+			spec.DRPolicyRef.Name = policy
+
+			return &rmn.DRPlacementControl{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app"},
+				Spec:       spec,
+				Status:     rmn.DRPlacementControlStatus{PreferredDecision: rmn.PlacementDecision{ClusterName: current}},
+			}
+		}
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&rmn.DRPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "metro"},
+				Spec:       rmn.DRPolicySpec{DRClusters: []string{"east", "west"}},
+			},
+			&rmn.DRPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "other"},
+				Spec:       rmn.DRPolicySpec{DRClusters: []string{"north", "south"}},
+			},
+			drpc("failover", "metro", rmn.DRPlacementControlSpec{FailoverCluster: "east"}, "west"),
+			drpc("relocate", "metro", rmn.DRPlacementControlSpec{PreferredCluster: "east"}, "west"),
+			drpc("current", "metro", rmn.DRPlacementControlSpec{PreferredCluster: "west"}, "east"),
+			drpc("peer", "metro", rmn.DRPlacementControlSpec{PreferredCluster: "west"}, "west"),
+			drpc("unrelated", "other", rmn.DRPlacementControlSpec{PreferredCluster: "east"}, "north"),
+		).Build()
+
+		r := &DRPlacementControlReconciler{Client: fakeClient}
+
+		request := func(name string) reconcile.Request {
+			return reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "app"}}
+		}
+
+		Expect(r.FilterDRClusterFence(drCluster())).To(ConsistOf(
+			request("failover"), request("relocate"), request("current")))
+	})
+})
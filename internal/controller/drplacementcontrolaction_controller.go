@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+// DRPlacementControlActionReconciler reconciles a DRPlacementControlAction object by setting its action in the spec
+// of its DRPlacementControl, on behalf of users permitted to create actions but not to update DRPlacementControls
+type DRPlacementControlActionReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+//nolint:lll
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrolactions,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrolactions/status,verbs=get;update;patch
+
+func (r *DRPlacementControlActionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("drpcaction", req.NamespacedName, "rid", util.GetRID())
+
+	action := &rmn.DRPlacementControlAction{}
+	if err := r.Client.Get(ctx, req.NamespacedName, action); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(fmt.Errorf("get: %w", err))
+	}
+
+	// an action is applied once, so that it does not revert a later action or edit of the DRPC
+	if action.Status.Phase != "" {
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("drpc", action.Spec.DRPCName, "action", action.Spec.Action)
+
+	phase, message, err := r.apply(ctx, action)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.Info("DRPC action processed", "phase", phase, "message", message)
+
+	action.Status = rmn.DRPlacementControlActionStatus{
+		Phase:              phase,
+		Message:            message,
+		ObservedGeneration: action.Generation,
+	}
+
+	if err := r.Client.Status().Update(ctx, action); err != nil {
+		return ctrl.Result{}, fmt.Errorf("status update: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// apply sets the action in the spec of the DRPC, and the DRPC as an owner of the action so that the action is
+// deleted with it. It returns the phase and message of the action, or an error to retry.
+func (r *DRPlacementControlActionReconciler) apply(ctx context.Context, action *rmn.DRPlacementControlAction,
+) (rmn.DRPlacementControlActionPhase, string, error) {
+	drpc := &rmn.DRPlacementControl{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: action.Namespace, Name: action.Spec.DRPCName},
+		drpc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return rmn.DRPlacementControlActionRejected, "DRPC not found", nil
+		}
+
+		return "", "", fmt.Errorf("drpc get: %w", err)
+	}
+
+	if !drpc.GetDeletionTimestamp().IsZero() {
+		return rmn.DRPlacementControlActionRejected, "DRPC is being deleted", nil
+	}
+
+	patch := client.MergeFrom(drpc.DeepCopy())
+
+	drpc.Spec.Action = action.Spec.Action
+	drpc.Spec.Force = action.Spec.Force
+	util.AddAnnotation(drpc, ActionRequestedByAnnotation, "DRPlacementControlAction/"+action.Name)
+
+	switch action.Spec.Action {
+	case rmn.ActionFailover:
+		drpc.Spec.FailoverCluster = action.Spec.FailoverCluster
+	case rmn.ActionRelocate:
+		drpc.Spec.PreferredCluster = action.Spec.PreferredCluster
+	}
+
+	if err := r.Client.Patch(ctx, drpc, patch); err != nil {
+		if k8serrors.IsInvalid(err) {
+			return rmn.DRPlacementControlActionRejected, err.Error(), nil
+		}
+
+		return "", "", fmt.Errorf("drpc patch: %w", err)
+	}
+
+	if err := controllerutil.SetOwnerReference(drpc, action, r.Scheme); err != nil {
+		return "", "", fmt.Errorf("owner reference set: %w", err)
+	}
+
+	if err := r.Client.Update(ctx, action); err != nil {
+		return "", "", fmt.Errorf("update: %w", err)
+	}
+
+	return rmn.DRPlacementControlActionApplied,
+		fmt.Sprintf("action set in DRPC generation %d", drpc.Generation), nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DRPlacementControlActionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rmn.DRPlacementControlAction{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
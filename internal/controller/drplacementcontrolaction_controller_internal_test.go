@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPlacementControlAction", func() {
+	const namespaceName = "app"
+
+	var (
+		fakeClient client.Client
+		reconciler *DRPlacementControlActionReconciler
+	)
+
+	reconcile := func(action *rmn.DRPlacementControlAction) *rmn.DRPlacementControlAction {
+		Expect(fakeClient.Create(context.TODO(), action)).To(Succeed())
+
+		key := types.NamespacedName{Namespace: namespaceName, Name: action.Name}
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(context.TODO(), key, action)).To(Succeed())
+
+		return action
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&rmn.DRPlacementControlAction{}).
+			WithObjects(&rmn.DRPlacementControl{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "drpc"},
+				Spec:       rmn.DRPlacementControlSpec{PreferredCluster: "east"},
+			}).Build()
+		reconciler = &DRPlacementControlActionReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: scheme}
+	})
+
+	It("sets its action in the spec of its DRPC, once", func() {
+		action := reconcile(&rmn.DRPlacementControlAction{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "failover"},
+			Spec:       rmn.DRPlacementControlActionSpec{DRPCName: "drpc", Action: rmn.ActionFailover, FailoverCluster: "west"},
+		})
+		Expect(action.Status.Phase).To(Equal(rmn.DRPlacementControlActionApplied))
+		Expect(action.OwnerReferences).To(HaveLen(1))
+		Expect(action.OwnerReferences[0].Name).To(Equal("drpc"))
+
+		drpc := &rmn.DRPlacementControl{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: "drpc"},
+			drpc)).To(Succeed())
+		Expect(drpc.Spec.Action).To(Equal(rmn.ActionFailover))
+		Expect(drpc.Spec.FailoverCluster).To(Equal("west"))
+		Expect(drpc.Spec.PreferredCluster).To(Equal("east"))
+
+		drpc.Spec.Action = rmn.ActionRelocate
+		Expect(fakeClient.Update(context.TODO(), drpc)).To(Succeed())
+
+		_, err := reconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(action)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(drpc), drpc)).To(Succeed())
+		Expect(drpc.Spec.Action).To(Equal(rmn.ActionRelocate))
+	})
+
+	It("is rejected if its DRPC does not exist", func() {
+		action := reconcile(&rmn.DRPlacementControlAction{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "relocate"},
+			Spec: rmn.DRPlacementControlActionSpec{
+				DRPCName: "other", Action: rmn.ActionRelocate, PreferredCluster: "east",
+			},
+		})
+		Expect(action.Status.Phase).To(Equal(rmn.DRPlacementControlActionRejected))
+		Expect(action.OwnerReferences).To(BeEmpty())
+	})
+})
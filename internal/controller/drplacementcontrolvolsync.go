@@ -234,6 +234,63 @@ func (d *DRPCInstance) EnsureVolSyncReplicationSetup(srcCluster string) error {
 		return fmt.Errorf("%w", err)
 	}
 
+	return d.ensureReplicationNetworkPolicies()
+}
+
+// ensureReplicationNetworkPolicies delivers to each DRCluster a NetworkPolicy that allows ingress to the VolSync
+// movers of the workload only from the CIDRs of its peers, if the ramen config enables it. The NetworkPolicy of a
+// DRCluster whose peers have no CIDRs is deleted, as it would deny all replication traffic.
+func (d *DRPCInstance) ensureReplicationNetworkPolicies() error {
+	enabled := d.ramenConfig != nil && d.ramenConfig.VolSync.ReplicationNetworkPolicy
+
+	for i := range d.drClusters {
+		cluster := d.drClusters[i].Name
+
+		peerCIDRs := []string{}
+
+		for j := range d.drClusters {
+			if j != i {
+				peerCIDRs = append(peerCIDRs, d.drClusters[j].Spec.CIDRs...)
+			}
+		}
+
+		if !enabled || len(peerCIDRs) == 0 {
+			if enabled {
+				d.log.Info("Peers have no CIDRs, not restricting replication traffic", "cluster", cluster)
+			}
+
+			mwName := rmnutil.ManifestWorkName(d.instance.GetName(), d.vrgNamespace, rmnutil.MWTypeNetPol)
+			if err := d.mwu.DeleteManifestWork(mwName, cluster); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		policy := volsync.ReplicationNetworkPolicy(d.instance.GetName(), d.vrgNamespace, peerCIDRs)
+
+		if err := d.mwu.CreateOrUpdateNetworkPolicyManifestWork(d.instance.GetName(), d.vrgNamespace, cluster,
+			policy); err != nil {
+			return fmt.Errorf("failed to deliver replication NetworkPolicy to cluster %s (%w)", cluster, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteReplicationNetworkPolicyManifestWorks deletes the replication NetworkPolicy ManifestWorks of the DRPC for
+// all clusters in the DRPolicy
+func deleteReplicationNetworkPolicyManifestWorks(drpc *rmn.DRPlacementControl, drPolicy *rmn.DRPolicy,
+	mwu rmnutil.MWUtil, vrgNamespace string,
+) error {
+	mwName := rmnutil.ManifestWorkName(drpc.GetName(), vrgNamespace, rmnutil.MWTypeNetPol)
+
+	for _, clusterName := range rmnutil.DRPolicyClusterNames(drPolicy) {
+		if err := mwu.DeleteManifestWork(mwName, clusterName); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -348,7 +405,7 @@ func (d *DRPCInstance) refreshVRGSecondarySpec(srcCluster, dstCluster string) (*
 		if len(srcVRGView.Status.ProtectedPVCs) != 0 {
 			d.resetRDSpec(srcVRGView, &dstVRG)
 
-			if d.instance.Spec.VolSyncSpec != nil {
+			if d.spec.VolSyncSpec != nil {
 				d.updateMoverConfig(&dstVRG)
 			}
 		}
@@ -5,9 +5,11 @@ package controllers
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	rmn "github.com/ramendr/ramen/api/v1alpha1"
@@ -60,30 +62,52 @@ func drClusterSecretsDeploy(
 		log.Info("Received partial list", "err", err)
 	}
 
-	for _, secretName := range drPolicySecrets.List() {
-		if err := secretsUtil.AddSecretToCluster(
+	for _, secretKey := range drPolicySecrets.List() {
+		if err := drClusterSecretDeploy(clusterName, secretKey, secretsUtil, rmnCfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// drClusterSecretDeploy deploys the S3 secret with secretKey to the cluster, in the formats of ramen and of Velero
+func drClusterSecretDeploy(
+	clusterName string,
+	secretKey string,
+	secretsUtil *util.SecretsUtil,
+	rmnCfg *rmn.RamenConfig,
+) error {
+	secretNamespace, secretName := s3SecretKeySplit(secretKey)
+
+	secretUtil, err := s3SecretsUtil(secretsUtil, rmnCfg, secretKey)
+	if err != nil {
+		return fmt.Errorf("cannot read secret '%v' for drcluster '%v': %w", secretName, clusterName, err)
+	}
+
+	if err := secretUtil.AddSecretToCluster(
+		secretName,
+		clusterName,
+		secretNamespace,
+		drClusterOperatorNamespaceNameOrDefault(rmnCfg),
+		util.SecretFormatRamen,
+		"",
+	); err != nil {
+		return fmt.Errorf("cannot add secret '%v' to drcluster '%v': %w", secretName, clusterName, err)
+	}
+
+	if !rmnCfg.KubeObjectProtection.Disabled && rmnCfg.KubeObjectProtection.VeleroNamespaceName != "" &&
+		!s3SecretIsEncryptionKey(rmnCfg, secretKey) {
+		if err := secretUtil.AddSecretToCluster(
 			secretName,
 			clusterName,
-			RamenOperatorNamespace(),
+			secretNamespace,
 			drClusterOperatorNamespaceNameOrDefault(rmnCfg),
-			util.SecretFormatRamen,
-			"",
+			util.SecretFormatVelero,
+			rmnCfg.KubeObjectProtection.VeleroNamespaceName,
 		); err != nil {
-			return fmt.Errorf("cannot add secret '%v' to drcluster '%v': %w", secretName, clusterName, err)
-		}
-
-		if !rmnCfg.KubeObjectProtection.Disabled && rmnCfg.KubeObjectProtection.VeleroNamespaceName != "" {
-			if err := secretsUtil.AddSecretToCluster(
-				secretName,
-				clusterName,
-				RamenOperatorNamespace(),
-				drClusterOperatorNamespaceNameOrDefault(rmnCfg),
-				util.SecretFormatVelero,
-				rmnCfg.KubeObjectProtection.VeleroNamespaceName,
-			); err != nil {
-				return fmt.Errorf("cannot add secret '%v' to drcluster '%v' in format '%v': %w",
-					secretName, clusterName, util.SecretFormatVelero, err)
-			}
+			return fmt.Errorf("cannot add secret '%v' to drcluster '%v' in format '%v': %w",
+				secretName, clusterName, util.SecretFormatVelero, err)
 		}
 	}
 
@@ -154,7 +178,7 @@ func drClustersUndeploySecrets(
 	return nil
 }
 
-// drClusterListMustHaveSecrets lists s3 secrets that must exist on the passed in clusterName
+// drClusterListMustHaveSecrets lists the keys of the s3 secrets that must exist on the passed in clusterName
 // It optionally ignores a specified ignorePolicy, which is typically useful when a policy is being
 // deleted.
 func drClusterListMustHaveSecrets(
@@ -172,7 +196,7 @@ func drClusterListMustHaveSecrets(
 	// that should still be present. This is done as multiple profiles MAY point to the same secret
 	for _, s3Profile := range ramenConfig.S3StoreProfiles {
 		if mustHaveS3Profiles.Has(s3Profile.S3ProfileName) {
-			mustHaveS3Secrets = mustHaveS3Secrets.Insert(s3Profile.S3SecretRef.Name)
+			mustHaveS3Secrets = mustHaveS3Secrets.Insert(s3ProfileSecretKeys(s3Profile)...)
 		}
 	}
 
@@ -201,6 +225,7 @@ func drClusterListMustHaveS3Profiles(drpolicies rmn.DRPolicyList,
 	return mustHaveS3Profiles
 }
 
+// drPolicySecretNames returns the keys of the s3 secrets of the clusters of drpolicy
 func drPolicySecretNames(drpolicy *rmn.DRPolicy,
 	drclusters *rmn.DRClusterList,
 	rmnCfg *rmn.RamenConfig,
@@ -222,7 +247,7 @@ func drPolicySecretNames(drpolicy *rmn.DRPolicy,
 
 		for _, s3Profile := range rmnCfg.S3StoreProfiles {
 			if s3ProfileName == s3Profile.S3ProfileName {
-				secretNames.Insert(s3Profile.S3SecretRef.Name)
+				secretNames.Insert(s3ProfileSecretKeys(s3Profile)...)
 
 				mcProfileFound = true
 
@@ -238,27 +263,35 @@ func drPolicySecretNames(drpolicy *rmn.DRPolicy,
 	return secretNames, err
 }
 
-// Delete s3profile secret from cluster
+// Delete s3profile secret, with the key s3SecretKeyToDelete, from cluster
 func deleteSecretFromCluster(
-	s3SecretToDelete, clusterName string,
+	s3SecretKeyToDelete, clusterName string,
 	ramenConfig *rmn.RamenConfig,
 	secretsUtil *util.SecretsUtil,
 ) error {
+	s3SecretNamespace, s3SecretToDelete := s3SecretKeySplit(s3SecretKeyToDelete)
+
+	secretsUtil, err := s3SecretsUtil(secretsUtil, ramenConfig, s3SecretKeyToDelete)
+	if err != nil {
+		return fmt.Errorf("unable to read secret '%v' for drcluster '%v': %w", s3SecretToDelete, clusterName, err)
+	}
+
 	if err := secretsUtil.RemoveSecretFromCluster(
 		s3SecretToDelete,
 		clusterName,
-		RamenOperatorNamespace(),
+		s3SecretNamespace,
 		util.SecretFormatRamen,
 	); err != nil {
 		return fmt.Errorf("unable to delete secret in format '%v' for s3Profile '%v' on drcluster '%v': %w",
 			util.SecretFormatRamen, s3SecretToDelete, clusterName, err)
 	}
 
-	if !ramenConfig.KubeObjectProtection.Disabled && ramenConfig.KubeObjectProtection.VeleroNamespaceName != "" {
+	if !ramenConfig.KubeObjectProtection.Disabled && ramenConfig.KubeObjectProtection.VeleroNamespaceName != "" &&
+		!s3SecretIsEncryptionKey(ramenConfig, s3SecretKeyToDelete) {
 		if err := secretsUtil.RemoveSecretFromCluster(
 			s3SecretToDelete,
 			clusterName,
-			RamenOperatorNamespace(),
+			s3SecretNamespace,
 			util.SecretFormatVelero,
 		); err != nil {
 			return fmt.Errorf("unable to delete secret in format '%v' for s3Profile '%v' on drcluster '%v': %w",
@@ -268,3 +301,50 @@ func deleteSecretFromCluster(
 
 	return nil
 }
+
+// s3SecretKey returns the key of the secret of s3Profile in the sets of secrets to propagate, its namespaced name
+func s3SecretKey(s3Profile rmn.S3StoreProfile) string {
+	return s3SecretNamespacedName(s3Profile).String()
+}
+
+// s3ProfileSecretKeys returns the keys of the secrets of s3Profile to propagate: the secret of its credentials, if
+// it has one, and the secret of its key encryption key, if it has one
+func s3ProfileSecretKeys(s3Profile rmn.S3StoreProfile) []string {
+	secretKeys := []string{}
+
+	if s3ProfileHasSecret(s3Profile) {
+		secretKeys = append(secretKeys, s3SecretKey(s3Profile))
+	}
+
+	if namespacedName, ok := encryptionKeySecretNamespacedName(s3Profile); ok {
+		secretKeys = append(secretKeys, namespacedName.String())
+	}
+
+	return secretKeys
+}
+
+// s3SecretIsEncryptionKey returns whether the secret with secretKey holds only the key encryption key of profiles,
+// rather than credentials, which Velero has no use for
+func s3SecretIsEncryptionKey(rmnCfg *rmn.RamenConfig, secretKey string) bool {
+	isEncryptionKey := false
+
+	for _, s3Profile := range rmnCfg.S3StoreProfiles {
+		if s3ProfileHasSecret(s3Profile) && s3SecretKey(s3Profile) == secretKey {
+			return false
+		}
+
+		if namespacedName, ok := encryptionKeySecretNamespacedName(s3Profile); ok &&
+			namespacedName.String() == secretKey {
+			isEncryptionKey = true
+		}
+	}
+
+	return isEncryptionKey
+}
+
+// s3SecretKeySplit returns the namespace and name of the secret with key
+func s3SecretKeySplit(key string) (namespace, name string) {
+	namespace, name, _ = strings.Cut(key, string(types.Separator))
+
+	return namespace, name
+}
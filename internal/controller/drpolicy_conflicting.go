@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+const drPolicyConflictsWithReason = "ConflictsWith"
+
+// drPolicyOlder returns whether a was created before b, by name if they were created at the same time
+func drPolicyOlder(a, b *ramen.DRPolicy) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+
+	return a.Name < b.Name
+}
+
+func classNamesEqual(a, b []string) bool {
+	return slices.Equal(slices.Sorted(slices.Values(a)), slices.Sorted(slices.Values(b)))
+}
+
+// drPolicyIncompatibilities returns why the class selection of newer for the PVCs of its workloads is incompatible with
+// that of older, for policies of the same clusters. The schedules of the policies are merged in the DRClusterConfigs of
+// the clusters, so the classes of an interval are expected to be selected alike by every policy of the interval, and
+// classes named by a policy are expected to serve its interval only.
+func drPolicyIncompatibilities(newer, older *ramen.DRPolicy) []string {
+	newerInterval, olderInterval := newer.Spec.SchedulingInterval, older.Spec.SchedulingInterval
+
+	if (newerInterval == "") != (olderInterval == "") {
+		return []string{fmt.Sprintf("schedulingInterval %q is incompatible with %q, the clusters cannot replicate "+
+			"both synchronously and asynchronously", newerInterval, olderInterval)}
+	}
+
+	if newerInterval != olderInterval {
+		if len(newer.Spec.ReplicationClassNames) != 0 &&
+			classNamesEqual(newer.Spec.ReplicationClassNames, older.Spec.ReplicationClassNames) {
+			return []string{fmt.Sprintf("replicationClassNames select the same classes for schedulingIntervals "+
+				"%q and %q", newerInterval, olderInterval)}
+		}
+
+		return nil
+	}
+
+	incompatibilities := []string{}
+
+	if !equality.Semantic.DeepEqual(newer.Spec.ReplicationClassSelector, older.Spec.ReplicationClassSelector) {
+		incompatibilities = append(incompatibilities, "replicationClassSelector differs")
+	}
+
+	if !classNamesEqual(newer.Spec.ReplicationClassNames, older.Spec.ReplicationClassNames) {
+		incompatibilities = append(incompatibilities, "replicationClassNames differ")
+	}
+
+	if !equality.Semantic.DeepEqual(newer.Spec.VolumeSnapshotClassSelector, older.Spec.VolumeSnapshotClassSelector) {
+		incompatibilities = append(incompatibilities, "volumeSnapshotClassSelector differs")
+	}
+
+	if !equality.Semantic.DeepEqual(newer.Spec.VolumeGroupSnapshotClassSelector,
+		older.Spec.VolumeGroupSnapshotClassSelector) {
+		incompatibilities = append(incompatibilities, "volumeGroupSnapshotClassSelector differs")
+	}
+
+	if len(incompatibilities) == 0 {
+		return nil
+	}
+
+	return append(incompatibilities, fmt.Sprintf("for the same schedulingInterval %q", newerInterval))
+}
+
+// drPolicyConflict returns the oldest policy, older than drpolicy, of the same clusters, whose class selection
+// drpolicy's is incompatible with, and why, or nil if there is none. Only the newer policy of a pair reports the
+// conflict, so that the workloads of the older one are not disturbed by its creation.
+func drPolicyConflict(drpolicy *ramen.DRPolicy, drpolicies []ramen.DRPolicy) (*ramen.DRPolicy, []string) {
+	var (
+		conflicting       *ramen.DRPolicy
+		incompatibilities []string
+	)
+
+	clusterNames := util.DRPolicyClusterNamesAsASet(drpolicy)
+
+	for i := range drpolicies {
+		other := &drpolicies[i]
+
+		if other.Name == drpolicy.Name || !drPolicyOlder(other, drpolicy) ||
+			!util.DRPolicyClusterNamesAsASet(other).Equal(clusterNames) {
+			continue
+		}
+
+		if conflicting != nil && drPolicyOlder(conflicting, other) {
+			continue
+		}
+
+		if reasons := drPolicyIncompatibilities(drpolicy, other); len(reasons) != 0 {
+			conflicting, incompatibilities = other, reasons
+		}
+	}
+
+	return conflicting, incompatibilities
+}
+
+// conflictingConditionSet sets the Conflicting condition of the policy if it conflicts with an older policy of the same
+// clusters, and removes it otherwise
+func (u *drpolicyUpdater) conflictingConditionSet(drpolicies []ramen.DRPolicy) error {
+	conflicting, incompatibilities := drPolicyConflict(u.object, drpolicies)
+	if conflicting == nil {
+		if meta.RemoveStatusCondition(&u.object.Status.Conditions, ramen.DRPolicyConditionTypeConflicting) {
+			return u.statusUpdate()
+		}
+
+		return nil
+	}
+
+	return u.statusConditionSet(ramen.DRPolicyConditionTypeConflicting, metav1.ConditionTrue,
+		drPolicyConflictsWithReason,
+		fmt.Sprintf("%s: %s: %s", drPolicyConflictsWithReason, conflicting.Name, strings.Join(incompatibilities, ", ")))
+}
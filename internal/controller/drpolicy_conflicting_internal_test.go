@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPolicy Conflicting", func() {
+	created := time.Now()
+
+	drPolicy := func(name string, age time.Duration, interval string, clusters ...string) rmn.DRPolicy {
+		return rmn.DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created.Add(-age))},
+			Spec: rmn.DRPolicySpec{
+				DRClusters:               clusters,
+				SchedulingInterval:       interval,
+				ReplicationClassSelector: metav1.LabelSelector{MatchLabels: map[string]string{"class": "a"}},
+			},
+		}
+	}
+
+	It("is not reported for policies of the same clusters with different intervals", func() {
+		older := drPolicy("older", time.Hour, "1h", "east", "west")
+		newer := drPolicy("newer", 0, "5m", "west", "east")
+
+		conflicting, _ := drPolicyConflict(&newer, []rmn.DRPolicy{older, newer})
+		Expect(conflicting).To(BeNil())
+	})
+
+	It("is reported by the newer policy of an interval whose classes are selected differently", func() {
+		older := drPolicy("older", time.Hour, "5m", "east", "west")
+		newer := drPolicy("newer", 0, "5m", "west", "east")
+		newer.Spec.ReplicationClassSelector.MatchLabels["class"] = "b"
+
+		conflicting, incompatibilities := drPolicyConflict(&newer, []rmn.DRPolicy{older, newer})
+		Expect(conflicting).ToNot(BeNil())
+		Expect(conflicting.Name).To(Equal("older"))
+		Expect(incompatibilities).To(ContainElement("replicationClassSelector differs"))
+
+		conflicting, _ = drPolicyConflict(&older, []rmn.DRPolicy{older, newer})
+		Expect(conflicting).To(BeNil())
+	})
+
+	It("is reported for policies of the same clusters that replicate synchronously and asynchronously", func() {
+		older := drPolicy("older", time.Hour, "", "east", "west")
+		newer := drPolicy("newer", 0, "5m", "east", "west")
+
+		conflicting, incompatibilities := drPolicyConflict(&newer, []rmn.DRPolicy{older, newer})
+		Expect(conflicting).ToNot(BeNil())
+		Expect(incompatibilities).To(HaveLen(1))
+		Expect(incompatibilities[0]).To(ContainSubstring("synchronously and asynchronously"))
+	})
+
+	It("is reported for policies that name the same replication classes for different intervals", func() {
+		older := drPolicy("older", time.Hour, "1h", "east", "west")
+		newer := drPolicy("newer", 0, "5m", "east", "west")
+		older.Spec.ReplicationClassNames = []string{"vrc-b", "vrc-a"}
+		newer.Spec.ReplicationClassNames = []string{"vrc-a", "vrc-b"}
+
+		conflicting, _ := drPolicyConflict(&newer, []rmn.DRPolicy{older, newer})
+		Expect(conflicting).ToNot(BeNil())
+	})
+
+	It("is reported against the oldest conflicting policy of the same clusters only", func() {
+		oldest := drPolicy("oldest", 2*time.Hour, "5m", "east", "west")
+		older := drPolicy("older", time.Hour, "5m", "east", "west")
+		other := drPolicy("other", 3*time.Hour, "5m", "east", "central")
+		newer := drPolicy("newer", 0, "5m", "east", "west")
+
+		for _, policy := range []*rmn.DRPolicy{&oldest, &older, &other} {
+			policy.Spec.ReplicationClassSelector.MatchLabels["class"] = "b"
+		}
+
+		conflicting, _ := drPolicyConflict(&newer, []rmn.DRPolicy{older, newer, other, oldest})
+		Expect(conflicting).ToNot(BeNil())
+		Expect(conflicting.Name).To(Equal("oldest"))
+	})
+
+	It("orders policies created at the same time by name", func() {
+		a := drPolicy("a", 0, "5m", "east", "west")
+		b := drPolicy("b", 0, "5m", "east", "west")
+		b.Spec.VolumeSnapshotClassSelector.MatchLabels = map[string]string{"class": "b"}
+
+		conflicting, _ := drPolicyConflict(&a, []rmn.DRPolicy{a, b})
+		Expect(conflicting).To(BeNil())
+
+		conflicting, incompatibilities := drPolicyConflict(&b, []rmn.DRPolicy{a, b})
+		Expect(conflicting).ToNot(BeNil())
+		Expect(incompatibilities).To(ContainElement("volumeSnapshotClassSelector differs"))
+	})
+})
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -172,6 +173,19 @@ func (r *DRPolicyReconciler) reconcile(
 		return ctrl.Result{}, fmt.Errorf("unable to set drpolicy validation: %w", err)
 	}
 
+	if err := u.peerConnectedConditionSet(drclusters.Items); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to set drpolicy peer connected condition: %w", err)
+	}
+
+	drpolicies, err := util.GetAllDRPolicies(u.ctx, r.APIReader)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("drpolicies list: %w", err)
+	}
+
+	if err := u.conflictingConditionSet(drpolicies.Items); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to set drpolicy conflicting condition: %w", err)
+	}
+
 	if err := r.initiateDRPolicyMetrics(u.object); err != nil {
 		return ctrl.Result{}, fmt.Errorf("error in intiating policy metrics: %w", err)
 	}
@@ -240,6 +254,18 @@ func validateDRPolicy(drpolicy *ramen.DRPolicy,
 		return reason, err
 	}
 
+	if err := util.SchedulingIntervalsValidate(drpolicy); err != nil {
+		return ReasonValidationFailed, err
+	}
+
+	if err := util.BackupWindowsValidate(drpolicy); err != nil {
+		return ReasonValidationFailed, err
+	}
+
+	if err := util.StorageClassSchedulingIntervalsValidate(drpolicy); err != nil {
+		return ReasonValidationFailed, err
+	}
+
 	return "", nil
 }
 
@@ -410,7 +436,8 @@ func (u *drpolicyUpdater) deleteDRPolicy(drclusters *ramen.DRClusterList,
 
 	for i := range drpcs.Items {
 		drpc1 := &drpcs.Items[i]
-		if u.object.ObjectMeta.Name == drpc1.Spec.DRPolicyRef.Name {
+		if u.object.ObjectMeta.Name == drpc1.Spec.DRPolicyRef.Name ||
+			u.object.ObjectMeta.Name == drpc1.Status.ObservedDRPolicy {
 			return fmt.Errorf("this drpolicy is referenced in existing drpc resource name '%v' ", drpc1.Name)
 		}
 	}
@@ -529,6 +556,10 @@ func (r *DRPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&viewv1beta1.ManagedClusterView{},
 			handler.EnqueueRequestsFromMapFunc(r.mcvMapFun),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		Watches(
+			&ramen.DRPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.drPolicyPeersMapFunc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
 
@@ -562,7 +593,10 @@ func (r *DRPolicyReconciler) configMapMapFunc(ctx context.Context, configMap cli
 
 func (r *DRPolicyReconciler) secretMapFunc(ctx context.Context, secret client.Object) []reconcile.Request {
 	if secret.GetNamespace() != RamenOperatorNamespace() {
-		return []reconcile.Request{}
+		_, ramenConfig, err := ConfigMapGet(ctx, r.APIReader)
+		if err != nil || !slices.Contains(ramenConfig.S3SecretNamespaces, secret.GetNamespace()) {
+			return []reconcile.Request{}
+		}
 	}
 
 	drpolicies := &ramen.DRPolicyList{}
@@ -600,6 +634,27 @@ func (r *DRPolicyReconciler) mcvMapFun(ctx context.Context, obj client.Object) [
 	return r.getDRPoliciesForCluster(obj.GetNamespace())
 }
 
+// drPolicyPeersMapFunc returns the other DRPolicies of the clusters of a DRPolicy, for their Conflicting conditions to
+// be updated as it is created, updated or deleted
+func (r *DRPolicyReconciler) drPolicyPeersMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	drpolicy, ok := obj.(*ramen.DRPolicy)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0)
+
+	for _, clusterName := range util.DRPolicyClusterNames(drpolicy) {
+		for _, request := range r.getDRPoliciesForCluster(clusterName) {
+			if request.Name != drpolicy.GetName() && !slices.Contains(requests, request) {
+				requests = append(requests, request)
+			}
+		}
+	}
+
+	return requests
+}
+
 func (r *DRPolicyReconciler) getDRPoliciesForCluster(clusterName string) []reconcile.Request {
 	drpolicies := &ramen.DRPolicyList{}
 	if err := r.Client.List(context.TODO(), drpolicies); err != nil {
@@ -0,0 +1,351 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clrapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// DRPolicyHubProtectionInterval is the interval between checks of the policies whose hub resources are due to be
+	// protected
+	DRPolicyHubProtectionInterval = time.Minute
+
+	// drPolicyHubProtectionSyncInterval is the interval of the protection of the hub resources of a policy of
+	// synchronous replication, which has no schedulingInterval
+	drPolicyHubProtectionSyncInterval = 5 * time.Minute
+
+	// drPolicyHubProtectionKeyPrefix is the prefix of the keys of the hub resources protected. It starts with a dot so
+	// that it is not the key prefix of the metadata of a namespace.
+	drPolicyHubProtectionKeyPrefix = ".ramen-hub/"
+
+	drPolicyHubProtectedReason    = "Protected"
+	drPolicyHubUnprotectedReason  = "ProtectionFailed"
+	drPolicyHubProtectionCallerID = "drpolicy hub protection"
+)
+
+// DRPolicyHubProtector periodically uploads the hub resources of the workloads of each DRPolicy that sets
+// hubProtection, its DRPCs, their Placements or PlacementRules and the Secrets selected, to the S3 profiles of the
+// clusters of the policy, so that a hub can be recovered from the S3 stores rather than from the OCM backup alone.
+type DRPolicyHubProtector struct {
+	client.Client
+	APIReader         client.Reader
+	ObjectStoreGetter ObjectStoreGetter
+	Log               logr.Logger
+	Interval          time.Duration
+}
+
+// NeedLeaderElection runs the protector only on the leader, alongside the hub reconcilers
+func (p *DRPolicyHubProtector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs a protection pass every interval until ctx is done
+func (p *DRPolicyHubProtector) Start(ctx context.Context) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DRPolicyHubProtectionInterval
+	}
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := p.protect(ctx, time.Now()); err != nil {
+			p.Log.Error(err, "DRPolicy hub protection failed")
+		}
+	}, interval)
+
+	return nil
+}
+
+// protect protects the hub resources of each DRPolicy that sets hubProtection and is due at now
+func (p *DRPolicyHubProtector) protect(ctx context.Context, now time.Time) error {
+	drPolicies := &rmn.DRPolicyList{}
+	if err := p.List(ctx, drPolicies); err != nil {
+		return fmt.Errorf("failed to list DRPolicies: %w", err)
+	}
+
+	var errs []error
+
+	for i := range drPolicies.Items {
+		drPolicy := &drPolicies.Items[i]
+		if drPolicy.Spec.HubProtection == nil || rmnutil.ResourceIsDeleted(drPolicy) {
+			continue
+		}
+
+		due, err := drPolicyHubProtectionDue(drPolicy, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DRPolicy %s: %w", drPolicy.Name, err))
+
+			continue
+		}
+
+		if !due {
+			continue
+		}
+
+		if err := p.protectPolicy(ctx, drPolicy, now); err != nil {
+			errs = append(errs, fmt.Errorf("DRPolicy %s: %w", drPolicy.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// drPolicyHubProtectionInterval returns the interval of the protection of the hub resources of drPolicy, its
+// schedulingInterval
+func drPolicyHubProtectionInterval(drPolicy *rmn.DRPolicy) (time.Duration, error) {
+	seconds, err := rmnutil.GetSecondsFromSchedulingInterval(drPolicy)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse schedulingInterval: %w", err)
+	}
+
+	if seconds <= 0 {
+		return drPolicyHubProtectionSyncInterval, nil
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// drPolicyHubProtectionDue returns whether the hub resources of drPolicy are to be protected at now, that is whether
+// they were never protected, or last protected at least an interval of the policy ago
+func drPolicyHubProtectionDue(drPolicy *rmn.DRPolicy, now time.Time) (bool, error) {
+	status := drPolicy.Status.HubProtection
+	if status == nil {
+		return true, nil
+	}
+
+	interval, err := drPolicyHubProtectionInterval(drPolicy)
+	if err != nil {
+		return false, err
+	}
+
+	return !now.Before(status.LastProtectionTime.Add(interval)), nil
+}
+
+// drPolicyHubProtectionKey returns the key of the hub resource obj of kind protected for the policy named
+// drPolicyName
+func drPolicyHubProtectionKey(drPolicyName, kind string, obj client.Object) string {
+	return drPolicyHubProtectionKeyPrefix + drPolicyName + "/" + kind + "/" + obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// protectPolicy uploads the hub resources of drPolicy to the S3 profiles of its clusters, deleting the ones of
+// resources no longer protected, and sets its hub protection status and HubProtected condition. The status is set
+// only if the resources were uploaded to all the profiles, so that the next pass retries the others.
+func (p *DRPolicyHubProtector) protectPolicy(ctx context.Context, drPolicy *rmn.DRPolicy, now time.Time) error {
+	log := p.Log.WithValues("drpolicy", drPolicy.Name)
+
+	objects, err := p.hubResources(ctx, drPolicy)
+	if err == nil {
+		err = p.uploadHubResources(ctx, drPolicy, objects, log)
+	}
+
+	patch := client.MergeFromWithOptions(drPolicy.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	if err != nil {
+		rmnutil.GenericStatusConditionSet(drPolicy, &drPolicy.Status.Conditions,
+			rmn.DRPolicyConditionTypeHubProtected, metav1.ConditionFalse, drPolicyHubUnprotectedReason, err.Error(),
+			log.V(1))
+	} else {
+		drPolicy.Status.HubProtection = &rmn.HubProtectionStatus{
+			LastProtectionTime: metav1.NewTime(now),
+			Resources:          len(objects),
+		}
+		rmnutil.GenericStatusConditionSet(drPolicy, &drPolicy.Status.Conditions,
+			rmn.DRPolicyConditionTypeHubProtected, metav1.ConditionTrue, drPolicyHubProtectedReason,
+			fmt.Sprintf("%d hub resources uploaded to the S3 profiles of the policy", len(objects)), log.V(1))
+	}
+
+	if patchErr := p.Status().Patch(ctx, drPolicy, patch); patchErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to update status of DRPolicy %s: %w", drPolicy.Name, patchErr))
+	}
+
+	return err
+}
+
+// hubResources returns the hub resources of drPolicy by key: the DRPCs of the policy, their Placements or
+// PlacementRules, and the Secrets of their namespaces that the policy selects
+func (p *DRPolicyHubProtector) hubResources(ctx context.Context, drPolicy *rmn.DRPolicy,
+) (map[string]client.Object, error) {
+	drpcs := &rmn.DRPlacementControlList{}
+	if err := p.List(ctx, drpcs); err != nil {
+		return nil, fmt.Errorf("failed to list DRPCs: %w", err)
+	}
+
+	objects := map[string]client.Object{}
+	namespaces := sets.New[string]()
+
+	for i := range drpcs.Items {
+		drpc := &drpcs.Items[i]
+		if drpc.Spec.DRPolicyRef.Name != drPolicy.Name || rmnutil.ResourceIsDeleted(drpc) {
+			continue
+		}
+
+		placementObj, err := p.placement(ctx, drpc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get placement of DRPC %s/%s: %w", drpc.Namespace, drpc.Name, err)
+		}
+
+		for _, obj := range []client.Object{drpc, placementObj} {
+			if err := p.hubResourceAdd(objects, drPolicy.Name, obj); err != nil {
+				return nil, err
+			}
+		}
+
+		namespaces.Insert(drpc.Namespace)
+	}
+
+	selector := drPolicy.Spec.HubProtection.SecretSelector
+	if selector == nil {
+		return objects, nil
+	}
+
+	secretSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse secretSelector: %w", err)
+	}
+
+	for _, namespace := range sets.List(namespaces) {
+		secrets := &corev1.SecretList{}
+		if err := p.List(ctx, secrets, client.InNamespace(namespace),
+			client.MatchingLabelsSelector{Selector: secretSelector}); err != nil {
+			return nil, fmt.Errorf("failed to list Secrets of namespace %s: %w", namespace, err)
+		}
+
+		for i := range secrets.Items {
+			if err := p.hubResourceAdd(objects, drPolicy.Name, &secrets.Items[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+// placement returns the Placement, or else the PlacementRule, drpc refers to, as is, for it to be protected whether
+// or not it is valid for the DRPC
+func (p *DRPolicyHubProtector) placement(ctx context.Context, drpc *rmn.DRPlacementControl) (client.Object, error) {
+	key := types.NamespacedName{Namespace: drpc.Namespace, Name: drpc.Spec.PlacementRef.Name}
+
+	placement := &clrapiv1beta1.Placement{}
+
+	err := p.Get(ctx, key, placement)
+	if err == nil {
+		return placement, nil
+	}
+
+	if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	placementRule := &plrv1.PlacementRule{}
+	if err := p.Get(ctx, key, placementRule); err != nil {
+		return nil, err
+	}
+
+	return placementRule, nil
+}
+
+// hubResourceAdd adds obj to objects by its key, with its kind set for it to be restored as is, and without the
+// metadata of the hub it is read from
+func (p *DRPolicyHubProtector) hubResourceAdd(objects map[string]client.Object, drPolicyName string,
+	obj client.Object,
+) error {
+	gvk, err := apiutil.GVKForObject(obj, p.Scheme())
+	if err != nil {
+		return fmt.Errorf("failed to get kind of %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	obj = obj.DeepCopyObject().(client.Object)
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetGeneration(0)
+
+	objects[drPolicyHubProtectionKey(drPolicyName, gvk.Kind, obj)] = obj
+
+	return nil
+}
+
+// uploadHubResources uploads objects to the S3 profiles of the clusters of drPolicy, and deletes the keys of the
+// resources of the policy that are no longer protected
+func (p *DRPolicyHubProtector) uploadHubResources(ctx context.Context, drPolicy *rmn.DRPolicy,
+	objects map[string]client.Object, log logr.Logger,
+) error {
+	drClusters, err := GetDRClusters(ctx, p.Client, drPolicy)
+	if err != nil {
+		return err
+	}
+
+	prefix := drPolicyHubProtectionKeyPrefix + drPolicy.Name + "/"
+
+	var errs []error
+
+	for i := range drClusters {
+		profileName := drClusters[i].Spec.S3ProfileName
+		if profileName == NoS3StoreAvailable {
+			continue
+		}
+
+		if err := p.uploadHubResourcesToProfile(ctx, profileName, prefix, objects, log); err != nil {
+			errs = append(errs, fmt.Errorf("s3 profile %s: %w", profileName, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *DRPolicyHubProtector) uploadHubResourcesToProfile(ctx context.Context, profileName, prefix string,
+	objects map[string]client.Object, log logr.Logger,
+) error {
+	objectStore, _, err := p.ObjectStoreGetter.ObjectStore(ctx, p.APIReader, profileName,
+		drPolicyHubProtectionCallerID, log)
+	if err != nil {
+		return err
+	}
+
+	for key, obj := range objects {
+		if err := objectStore.UploadObject(key, obj); err != nil {
+			return err
+		}
+	}
+
+	keys, err := objectStore.ListKeys(prefix)
+	if err != nil {
+		return err
+	}
+
+	stale := []string{}
+
+	for _, key := range keys {
+		if _, ok := objects[key]; !ok {
+			stale = append(stale, key)
+		}
+	}
+
+	if len(stale) == 0 {
+		return nil
+	}
+
+	log.Info("Deleting hub resources no longer protected", "s3Profile", profileName, "keys", stale)
+
+	return objectStore.DeleteObjects(stale...)
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clrapiv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// hubProtectionObjectStore is an in-memory object store whose objects can be deleted by key
+type hubProtectionObjectStore struct {
+	gcObjectStore
+}
+
+func (s *hubProtectionObjectStore) DeleteObjects(keys ...string) error {
+	for _, key := range keys {
+		delete(s.objects, key)
+	}
+
+	return nil
+}
+
+var _ = Describe("DRPolicyHubProtector", func() {
+	var (
+		fakeClient client.Client
+		east, west *hubProtectionObjectStore
+		protector  *DRPolicyHubProtector
+		now        time.Time
+	)
+
+	drPolicy := func() *rmn.DRPolicy {
+		drPolicy := &rmn.DRPolicy{}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Name: "policy"}, drPolicy)).To(Succeed())
+
+		return drPolicy
+	}
+
+	newObjectStore := func() *hubProtectionObjectStore {
+		return &hubProtectionObjectStore{gcObjectStore{healthObjectStore{objects: map[string]interface{}{}}}}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(clrapiv1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(plrv1.AddToScheme(scheme)).To(Succeed())
+
+		objects := []client.Object{
+			&rmn.DRPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+				Spec: rmn.DRPolicySpec{
+					DRClusters:         []string{"east", "west"},
+					SchedulingInterval: "1h",
+					HubProtection: &rmn.HubProtection{
+						SecretSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"protect": "true"}},
+					},
+				},
+			},
+			&rmn.DRCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "east"},
+				Spec:       rmn.DRClusterSpec{S3ProfileName: "east"},
+			},
+			&rmn.DRCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "west"},
+				Spec:       rmn.DRClusterSpec{S3ProfileName: "west"},
+			},
+			&rmn.DRPlacementControl{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc"},
+				Spec: rmn.DRPlacementControlSpec{
+					DRPolicyRef:  corev1.ObjectReference{Name: "policy"},
+					PlacementRef: corev1.ObjectReference{Kind: "Placement", Name: "placement"},
+				},
+			},
+			&rmn.DRPlacementControl{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "drpc"},
+				Spec: rmn.DRPlacementControlSpec{
+					DRPolicyRef:  corev1.ObjectReference{Name: "other-policy"},
+					PlacementRef: corev1.ObjectReference{Kind: "Placement", Name: "placement"},
+				},
+			},
+			&clrapiv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "placement"}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "app", Name: "channel", Labels: map[string]string{"protect": "true"},
+			}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "unselected"}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "other", Name: "channel", Labels: map[string]string{"protect": "true"},
+			}},
+		}
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+			WithStatusSubresource(&rmn.DRPolicy{}).Build()
+
+		east, west = newObjectStore(), newObjectStore()
+		protector = &DRPolicyHubProtector{
+			Client:            fakeClient,
+			APIReader:         fakeClient,
+			ObjectStoreGetter: qualificationObjectStoreGetter{"east": east, "west": west},
+			Log:               logr.Discard(),
+		}
+		now = time.Now()
+	})
+
+	It("uploads the DRPCs of the policy, their placements and the Secrets selected to the S3 profiles", func() {
+		Expect(protector.protect(context.TODO(), now)).To(Succeed())
+
+		keys := []string{
+			".ramen-hub/policy/DRPlacementControl/app/drpc",
+			".ramen-hub/policy/Placement/app/placement",
+			".ramen-hub/policy/Secret/app/channel",
+		}
+		for _, store := range []*hubProtectionObjectStore{east, west} {
+			Expect(store.ListKeys("")).To(ConsistOf(keys))
+
+			drpc, ok := store.objects[keys[0]].(*rmn.DRPlacementControl)
+			Expect(ok).To(BeTrue())
+			Expect(drpc.Kind).To(Equal("DRPlacementControl"))
+			Expect(drpc.ResourceVersion).To(BeEmpty())
+		}
+
+		status := drPolicy().Status
+		Expect(status.HubProtection).ToNot(BeNil())
+		Expect(status.HubProtection.Resources).To(Equal(len(keys)))
+		Expect(meta.IsStatusConditionTrue(status.Conditions, rmn.DRPolicyConditionTypeHubProtected)).To(BeTrue())
+	})
+
+	It("protects the hub resources again once the schedulingInterval of the policy elapses", func() {
+		Expect(protector.protect(context.TODO(), now)).To(Succeed())
+
+		secret := &corev1.Secret{}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: "app", Name: "channel"}, secret)).
+			To(Succeed())
+		Expect(fakeClient.Delete(context.TODO(), secret)).To(Succeed())
+
+		Expect(protector.protect(context.TODO(), now.Add(30*time.Minute))).To(Succeed())
+		Expect(east.ListKeys(".ramen-hub/policy/Secret/")).To(HaveLen(1))
+
+		Expect(protector.protect(context.TODO(), now.Add(time.Hour))).To(Succeed())
+		Expect(east.ListKeys(".ramen-hub/policy/Secret/")).To(BeEmpty())
+		Expect(drPolicy().Status.HubProtection.Resources).To(Equal(2))
+	})
+
+	It("reports the hub resources unprotected while an S3 profile is unavailable", func() {
+		west.putFails = true
+
+		Expect(protector.protect(context.TODO(), now)).ToNot(Succeed())
+
+		status := drPolicy().Status
+		Expect(status.HubProtection).To(BeNil())
+		Expect(meta.IsStatusConditionFalse(status.Conditions, rmn.DRPolicyConditionTypeHubProtected)).To(BeTrue())
+
+		west.putFails = false
+
+		Expect(protector.protect(context.TODO(), now.Add(time.Minute))).To(Succeed())
+		Expect(drPolicy().Status.HubProtection).ToNot(BeNil())
+	})
+
+	It("protects the hub resources of a policy of synchronous replication every few minutes", func() {
+		policy := &rmn.DRPolicy{}
+		Expect(drPolicyHubProtectionInterval(policy)).To(Equal(drPolicyHubProtectionSyncInterval))
+
+		policy.Status.HubProtection = &rmn.HubProtectionStatus{LastProtectionTime: metav1.NewTime(now)}
+		Expect(drPolicyHubProtectionDue(policy, now.Add(time.Minute))).To(BeFalse())
+		Expect(drPolicyHubProtectionDue(policy, now.Add(drPolicyHubProtectionSyncInterval))).To(BeTrue())
+	})
+})
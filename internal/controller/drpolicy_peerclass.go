@@ -503,6 +503,18 @@ func findPeers(cls []classLists, scName string, startClsIdx int, schedule string
 	return syncPeers, asyncPeers
 }
 
+// storageClassSchedule returns the schedule of the first of the overrides that selects sClass, or schedule if none
+// does. A metro policy, without a schedule, is not overridden.
+func storageClassSchedule(sClass *storagev1.StorageClass, schedule string,
+	overrides []ramen.StorageClassSchedulingInterval,
+) string {
+	if schedule == "" {
+		return schedule
+	}
+
+	return util.StorageClassSchedulingInterval(overrides, sClass, schedule)
+}
+
 // unionStorageClasses returns a union of all StorageClass names found in all clusters in the passed in classLists
 func unionStorageClasses(cls []classLists) []string {
 	allSCs := []string{}
@@ -521,8 +533,11 @@ func unionStorageClasses(cls []classLists) []string {
 }
 
 // findAllPeers finds all PAIRs of peers in the passed in classLists. It does an exhaustive search for each scName in
-// the prior index of classLists (starting at index 0) with all clusters from that index forward
-func findAllPeers(cls []classLists, schedule string) ([]peerInfo, []peerInfo) {
+// the prior index of classLists (starting at index 0) with all clusters from that index forward. The async peers of
+// a StorageClass are found at the schedule of the first of the overrides that selects it, if any.
+func findAllPeers(cls []classLists, schedule string,
+	overrides []ramen.StorageClassSchedulingInterval,
+) ([]peerInfo, []peerInfo) {
 	syncPeers := []peerInfo{}
 	asyncPeers := []peerInfo{}
 
@@ -538,7 +553,8 @@ func findAllPeers(cls []classLists, schedule string) ([]peerInfo, []peerInfo) {
 				continue
 			}
 
-			sPeers, aPeers := findPeers(cls, cls[clsIdx].sClasses[scIdx].Name, clsIdx, schedule)
+			sPeers, aPeers := findPeers(cls, cls[clsIdx].sClasses[scIdx].Name, clsIdx,
+				storageClassSchedule(cls[clsIdx].sClasses[scIdx], schedule, overrides))
 			if len(sPeers) != 0 {
 				syncPeers = append(syncPeers, sPeers...)
 			}
@@ -573,6 +589,42 @@ func pruneClassViews(
 	return nil
 }
 
+// classListViewUsable reports whether classes are read from the list-type view of all the classes of a kind on a
+// cluster, given the error of reading that view. A view that is processing fails the read. A view that failed
+// otherwise, such as when the view agent of the cluster does not list resources, is logged, and the classes are read
+// with a view per class name instead.
+func classListViewUsable(log logr.Logger, kind string, err error) (bool, error) {
+	switch {
+	case err == nil:
+		return true, nil
+	case util.IsMCVProcessing(err):
+		return false, err
+	}
+
+	log.Info("Reading classes with a view per class, list view failed", "kind", kind, "error", err.Error())
+
+	return false, nil
+}
+
+// classesByName returns the classes in items named in classNames, in the order of classNames
+func classesByName[T any, P interface {
+	*T
+	GetName() string
+}](items []T, classNames []string, kind string) ([]P, error) {
+	classes := make([]P, 0, len(classNames))
+
+	for _, className := range classNames {
+		idx := slices.IndexFunc(items, func(item T) bool { return P(&item).GetName() == className })
+		if idx == -1 {
+			return nil, fmt.Errorf("%s %s not found in list view of cluster", kind, className)
+		}
+
+		classes = append(classes, P(&items[idx]))
+	}
+
+	return classes, nil
+}
+
 func pruneVRClassViews(
 	m util.ManagedClusterViewGetter,
 	log logr.Logger,
@@ -604,6 +656,23 @@ func getVRClassesFromCluster(
 	annotations := make(map[string]string)
 	annotations[AllDRPolicyAnnotation] = clusterName
 
+	vrClassList, err := m.ListVRClassesFromManagedCluster(clusterName, annotations)
+
+	listed, err := classListViewUsable(u.log, "VolumeReplicationClass", err)
+	if err != nil {
+		return []*volrep.VolumeReplicationClass{}, err
+	}
+
+	if listed {
+		vrClasses, err := classesByName[volrep.VolumeReplicationClass](vrClassList.Items, vrClassNames,
+			"VolumeReplicationClass")
+		if err != nil {
+			return []*volrep.VolumeReplicationClass{}, err
+		}
+
+		return vrClasses, pruneVRClassViews(m, u.log, clusterName, nil)
+	}
+
 	for _, vrcName := range vrClassNames {
 		sClass, err := m.GetVRClassFromManagedCluster(vrcName, clusterName, annotations)
 		if err != nil {
@@ -690,6 +759,22 @@ func GetSClassesFromCluster(
 	annotations := make(map[string]string)
 	annotations[AllDRPolicyAnnotation] = clusterName
 
+	sClassList, err := m.ListSClassesFromManagedCluster(clusterName, annotations)
+
+	listed, err := classListViewUsable(log, "StorageClass", err)
+	if err != nil {
+		return []*storagev1.StorageClass{}, err
+	}
+
+	if listed {
+		sClasses, err := classesByName[storagev1.StorageClass](sClassList.Items, sClassNames, "StorageClass")
+		if err != nil {
+			return []*storagev1.StorageClass{}, err
+		}
+
+		return sClasses, pruneSClassViews(m, log, clusterName, nil)
+	}
+
 	for _, scName := range sClassNames {
 		sClass, err := m.GetSClassFromManagedCluster(scName, clusterName, annotations)
 		if err != nil {
@@ -891,7 +976,8 @@ func updatePeerClasses(u *drpolicyUpdater, m util.ManagedClusterViewGetter) erro
 		cls = append(cls, clusterClasses)
 	}
 
-	syncPeers, asyncPeers := findAllPeers(cls, u.object.Spec.SchedulingInterval)
+	syncPeers, asyncPeers := findAllPeers(cls, u.object.Spec.SchedulingInterval,
+		u.object.Spec.StorageClassSchedulingIntervals)
 
 	return updatePeerClassStatus(u, syncPeers, asyncPeers)
 }
@@ -22,7 +22,7 @@ var _ = Describe("updatePeerClassesInternal", func() {
 			syncPeers []peerInfo,
 			asyncPeers []peerInfo,
 		) {
-			sPeers, aPeers := findAllPeers(cls, schedule)
+			sPeers, aPeers := findAllPeers(cls, schedule, nil)
 			Expect(sPeers).Should(HaveExactElements(syncPeers))
 			Expect(aPeers).Should(HaveExactElements(asyncPeers))
 		},
@@ -2921,3 +2921,24 @@ var _ = Describe("updatePeerClassesInternal", func() {
 		),
 	)
 })
+
+var _ = Describe("classesByName", func() {
+	items := []storagev1.StorageClass{
+		{ObjectMeta: metav1.ObjectMeta{Name: "sc1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "sc2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "sc3"}},
+	}
+
+	It("returns the named classes of a list view in the order of the names", func() {
+		classes, err := classesByName[storagev1.StorageClass](items, []string{"sc3", "sc1"}, "StorageClass")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(classes).To(HaveLen(2))
+		Expect(classes[0].Name).To(Equal("sc3"))
+		Expect(classes[1].Name).To(Equal("sc1"))
+	})
+
+	It("fails if a named class is missing from the list view", func() {
+		_, err := classesByName[storagev1.StorageClass](items, []string{"sc4"}, "StorageClass")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	drPolicyPeerConnectedReason    = "Connected"
+	drPolicyPeerDisconnectedReason = "Disconnected"
+	drPolicyPeerUnknownReason      = "Unknown"
+)
+
+// drPolicyPeerConnected returns the PeerConnected condition of drPolicy from the mirroring health its clusters report,
+// and whether any of them reports it. The condition is false if any cluster reports its mirroring daemons
+// disconnected, true if all of them report them connected, and unknown otherwise.
+func drPolicyPeerConnected(drPolicy *ramen.DRPolicy, drClusters []ramen.DRCluster,
+) (metav1.ConditionStatus, string, string, bool) {
+	disconnected := []string{}
+	unknown := []string{}
+	reported := false
+
+	for i := range drClusters {
+		drCluster := &drClusters[i]
+		if !slices.Contains(drPolicy.Spec.DRClusters, drCluster.Name) {
+			continue
+		}
+
+		peerConnected := metav1.ConditionUnknown
+		if drCluster.Status.ClusterConfig != nil && drCluster.Status.ClusterConfig.PeerConnected != "" {
+			peerConnected = drCluster.Status.ClusterConfig.PeerConnected
+			reported = true
+		}
+
+		switch peerConnected {
+		case metav1.ConditionTrue:
+		case metav1.ConditionFalse:
+			disconnected = append(disconnected, drCluster.Name)
+		default:
+			unknown = append(unknown, drCluster.Name)
+		}
+	}
+
+	switch {
+	case len(disconnected) > 0:
+		return metav1.ConditionFalse, drPolicyPeerDisconnectedReason,
+			"Mirroring is not healthy on clusters: " + strings.Join(disconnected, ", "), reported
+	case len(unknown) > 0:
+		return metav1.ConditionUnknown, drPolicyPeerUnknownReason,
+			"Mirroring health is not reported by clusters: " + strings.Join(unknown, ", "), reported
+	default:
+		return metav1.ConditionTrue, drPolicyPeerConnectedReason, "Mirroring is healthy on all clusters", reported
+	}
+}
+
+// peerConnectedConditionSet sets the PeerConnected condition of an async policy, so that mirroring that is broken
+// between its clusters is visible on the hub before a failover fails. The condition is removed from a sync policy, and
+// from a policy none of whose clusters reports mirroring health, as when they do not mirror Ceph block pools.
+func (u *drpolicyUpdater) peerConnectedConditionSet(drClusters []ramen.DRCluster) error {
+	status, reason, message, reported := drPolicyPeerConnected(u.object, drClusters)
+
+	if u.object.Spec.SchedulingInterval == "" || !reported {
+		if meta.RemoveStatusCondition(&u.object.Status.Conditions, ramen.DRPolicyConditionTypePeerConnected) {
+			return u.statusUpdate()
+		}
+
+		return nil
+	}
+
+	return u.statusConditionSet(ramen.DRPolicyConditionTypePeerConnected, status, reason, message)
+}
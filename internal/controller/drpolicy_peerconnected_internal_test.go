@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPolicy PeerConnected", func() {
+	cephBlockPool := func(name string, mirrored bool, daemonHealth, health string) unstructured.Unstructured {
+		pool := unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"mirroring": map[string]interface{}{"enabled": mirrored},
+			},
+			"status": map[string]interface{}{
+				"mirroringStatus": map[string]interface{}{
+					"summary": map[string]interface{}{"daemon_health": daemonHealth, "health": health},
+				},
+			},
+		}}
+		pool.SetNamespace("rook-ceph")
+		pool.SetName(name)
+
+		return pool
+	}
+
+	drCluster := func(name string, peerConnected metav1.ConditionStatus) rmn.DRCluster {
+		return rmn.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: rmn.DRClusterStatus{
+				ClusterConfig: &rmn.DRClusterConfigResult{PeerConnected: peerConnected},
+			},
+		}
+	}
+
+	drPolicy := &rmn.DRPolicy{Spec: rmn.DRPolicySpec{DRClusters: []string{"east", "west"}, SchedulingInterval: "5m"}}
+
+	It("reports the mirrored pools whose mirroring is not healthy", func() {
+		mirrored, disconnected := cephBlockPoolsMirroringHealth([]unstructured.Unstructured{
+			cephBlockPool("healthy", true, "OK", "OK"),
+			cephBlockPool("daemon", true, "ERROR", "OK"),
+			cephBlockPool("unmirrored", false, "", ""),
+		})
+
+		Expect(mirrored).To(Equal(2))
+		Expect(disconnected).To(HaveLen(1))
+		Expect(disconnected[0]).To(HavePrefix("rook-ceph/daemon "))
+	})
+
+	It("is false if any cluster of the policy reports mirroring disconnected", func() {
+		status, reason, message, reported := drPolicyPeerConnected(drPolicy, []rmn.DRCluster{
+			drCluster("east", metav1.ConditionTrue),
+			drCluster("west", metav1.ConditionFalse),
+			drCluster("other", metav1.ConditionFalse),
+		})
+
+		Expect(reported).To(BeTrue())
+		Expect(status).To(Equal(metav1.ConditionFalse))
+		Expect(reason).To(Equal(drPolicyPeerDisconnectedReason))
+		Expect(message).To(HaveSuffix(": west"))
+	})
+
+	It("is true only if all the clusters of the policy report mirroring connected", func() {
+		status, _, _, _ := drPolicyPeerConnected(drPolicy, []rmn.DRCluster{
+			drCluster("east", metav1.ConditionTrue),
+			drCluster("west", metav1.ConditionTrue),
+		})
+		Expect(status).To(Equal(metav1.ConditionTrue))
+
+		status, _, message, _ := drPolicyPeerConnected(drPolicy, []rmn.DRCluster{
+			drCluster("east", metav1.ConditionTrue),
+			{ObjectMeta: metav1.ObjectMeta{Name: "west"}},
+		})
+		Expect(status).To(Equal(metav1.ConditionUnknown))
+		Expect(message).To(HaveSuffix(": west"))
+	})
+
+	It("is not reported if none of the clusters of the policy reports mirroring health", func() {
+		_, _, _, reported := drPolicyPeerConnected(drPolicy, []rmn.DRCluster{
+			drCluster("east", ""),
+			{ObjectMeta: metav1.ObjectMeta{Name: "west"}},
+		})
+		Expect(reported).To(BeFalse())
+	})
+})
@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// DRPolicyWorkloadsStatusInterval is the interval between aggregations of the status of the DRPCs of the policies
+	DRPolicyWorkloadsStatusInterval = time.Minute
+
+	// drPolicyUnhealthyWorkloadsListed is how many of the DRPCs that are not protected are named in the message of
+	// the ReplicationHealthy condition of their policy
+	drPolicyUnhealthyWorkloadsListed = 5
+
+	drPolicyReplicationHealthyReason   = "Healthy"
+	drPolicyReplicationUnhealthyReason = "Unhealthy"
+	drPolicyNoWorkloadsReason          = "NoWorkloads"
+)
+
+// DRPolicyWorkloadsStatusAggregator periodically aggregates the status of the DRPCs that refer to each DRPolicy into
+// the workloads status and the ReplicationHealthy condition of the policy, so that a policy gives the DR health of
+// all the workloads it protects. It is not done by the DRPolicy reconciler, which would otherwise be triggered by
+// every status update of the DRPCs.
+type DRPolicyWorkloadsStatusAggregator struct {
+	client.Client
+	Log      logr.Logger
+	Interval time.Duration
+}
+
+// NeedLeaderElection runs the aggregator only on the leader, alongside the hub reconcilers
+func (a *DRPolicyWorkloadsStatusAggregator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs an aggregation pass every interval until ctx is done
+func (a *DRPolicyWorkloadsStatusAggregator) Start(ctx context.Context) error {
+	interval := a.Interval
+	if interval <= 0 {
+		interval = DRPolicyWorkloadsStatusInterval
+	}
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := a.aggregate(ctx); err != nil {
+			a.Log.Error(err, "DRPolicy workloads status aggregation failed")
+		}
+	}, interval)
+
+	return nil
+}
+
+// aggregate updates the workloads status of each DRPolicy from the DRPCs that refer to it
+func (a *DRPolicyWorkloadsStatusAggregator) aggregate(ctx context.Context) error {
+	drPolicies := &rmn.DRPolicyList{}
+	if err := a.List(ctx, drPolicies); err != nil {
+		return fmt.Errorf("failed to list DRPolicies: %w", err)
+	}
+
+	drpcs := &rmn.DRPlacementControlList{}
+	if err := a.List(ctx, drpcs); err != nil {
+		return fmt.Errorf("failed to list DRPCs: %w", err)
+	}
+
+	drpcsByPolicy := map[string][]*rmn.DRPlacementControl{}
+
+	for i := range drpcs.Items {
+		drpc := &drpcs.Items[i]
+		if rmnutil.ResourceIsDeleted(drpc) {
+			continue
+		}
+
+		drpcsByPolicy[drpc.Spec.DRPolicyRef.Name] = append(drpcsByPolicy[drpc.Spec.DRPolicyRef.Name], drpc)
+	}
+
+	var errs []error
+
+	ResetDRPolicyMetrics()
+
+	for i := range drPolicies.Items {
+		drPolicy := &drPolicies.Items[i]
+		if rmnutil.ResourceIsDeleted(drPolicy) {
+			continue
+		}
+
+		if err := a.statusUpdate(ctx, drPolicy, drpcsByPolicy[drPolicy.Name]); err != nil {
+			errs = append(errs, err)
+		}
+
+		drPolicyMetricsSet(drPolicy, time.Now())
+	}
+
+	return errors.Join(errs...)
+}
+
+// statusUpdate sets the workloads status and the ReplicationHealthy condition of drPolicy from its drpcs, and patches
+// its status if they changed. The patch fails if drPolicy was updated since it was read, so that the status set by
+// its reconciler in the meantime is not reverted, and is made again by the next pass.
+func (a *DRPolicyWorkloadsStatusAggregator) statusUpdate(ctx context.Context, drPolicy *rmn.DRPolicy,
+	drpcs []*rmn.DRPlacementControl,
+) error {
+	patch := client.MergeFromWithOptions(drPolicy.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	workloads, unhealthy := drPolicyWorkloadsStatus(drPolicy, drpcs, time.Now())
+	status, reason, message := drPolicyReplicationHealthy(workloads, unhealthy)
+
+	changed := !equality.Semantic.DeepEqual(drPolicy.Status.Workloads, workloads)
+	drPolicy.Status.Workloads = workloads
+
+	if rmnutil.GenericStatusConditionSet(drPolicy, &drPolicy.Status.Conditions,
+		rmn.DRPolicyConditionTypeReplicationHealthy, status, reason, message, a.Log.V(1)) {
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := a.Status().Patch(ctx, drPolicy, patch); err != nil {
+		return fmt.Errorf("failed to update status of DRPolicy %s: %w", drPolicy.Name, err)
+	}
+
+	return nil
+}
+
+// drPolicyMetricsSet reports the metrics of drPolicy from its spec and status, including the workloads status
+// aggregated last, at now
+func drPolicyMetricsSet(drPolicy *rmn.DRPolicy, now time.Time) {
+	labels := DRPolicyMetricLabels(drPolicy)
+
+	replicationType := DRPolicyReplicationTypeAsync
+	if drPolicy.Spec.SchedulingInterval == "" {
+		replicationType = DRPolicyReplicationTypeSync
+	}
+
+	NewDRPolicyReplicationTypeMetric(DRPolicyReplicationTypeLabels(drPolicy, replicationType)).Set(1)
+	NewDRPolicyPeerClassesMetric(labels).Set(
+		float64(len(drPolicy.Status.Async.PeerClasses) + len(drPolicy.Status.Sync.PeerClasses)))
+
+	validated := 0.0
+	if meta.IsStatusConditionTrue(drPolicy.Status.Conditions, rmn.DRPolicyValidated) {
+		validated = 1
+	}
+
+	NewDRPolicyValidatedMetric(labels).Set(validated)
+
+	workloads := drPolicy.Status.Workloads
+	if workloads == nil {
+		return
+	}
+
+	NewDRPolicyBoundWorkloadsMetric(labels).Set(float64(workloads.Bound))
+
+	if workloads.OldestLastGroupSyncTime != nil {
+		NewDRPolicyLastSyncAgeMetric(labels).Set(now.Sub(workloads.OldestLastGroupSyncTime.Time).Seconds())
+	}
+}
+
+// drPolicyWorkloadsStatus returns the aggregate status of drpcs of drPolicy at now, and the names of those that are
+// not protected
+func drPolicyWorkloadsStatus(drPolicy *rmn.DRPolicy, drpcs []*rmn.DRPlacementControl, now time.Time,
+) (*rmn.DRPolicyWorkloadsStatus, []string) {
+	workloads := &rmn.DRPolicyWorkloadsStatus{Bound: len(drpcs)}
+	unhealthy := []string{}
+
+	for _, drpc := range drpcs {
+		if meta.IsStatusConditionTrue(drpc.Status.Conditions, rmn.ConditionProtected) {
+			workloads.Healthy++
+		} else {
+			unhealthy = append(unhealthy, drpc.Namespace+"/"+drpc.Name)
+		}
+
+		if violated, _ := drpcRPOViolated(drPolicy, drpc, now); violated {
+			workloads.RPOViolated++
+		}
+
+		syncTime := drpc.Status.LastGroupSyncTime
+		if syncTime != nil && (workloads.OldestLastGroupSyncTime == nil ||
+			syncTime.Before(workloads.OldestLastGroupSyncTime)) {
+			workloads.OldestLastGroupSyncTime = syncTime.DeepCopy()
+		}
+	}
+
+	slices.Sort(unhealthy)
+
+	return workloads, unhealthy
+}
+
+// drPolicyReplicationHealthy returns the status, reason and message of the ReplicationHealthy condition of a policy
+// with workloads, of which those named unhealthy are not protected
+func drPolicyReplicationHealthy(workloads *rmn.DRPolicyWorkloadsStatus, unhealthy []string,
+) (metav1.ConditionStatus, string, string) {
+	switch {
+	case workloads.Bound == 0:
+		return metav1.ConditionTrue, drPolicyNoWorkloadsReason, "No DRPCs refer to the policy"
+	case len(unhealthy) == 0:
+		return metav1.ConditionTrue, drPolicyReplicationHealthyReason,
+			fmt.Sprintf("All %d DRPCs of the policy are protected", workloads.Bound)
+	}
+
+	listed := unhealthy
+	if len(listed) > drPolicyUnhealthyWorkloadsListed {
+		listed = listed[:drPolicyUnhealthyWorkloadsListed]
+	}
+
+	message := fmt.Sprintf("%d of %d DRPCs of the policy are not protected: %s", len(unhealthy), workloads.Bound,
+		strings.Join(listed, ", "))
+	if len(unhealthy) > len(listed) {
+		message += fmt.Sprintf(" and %d more", len(unhealthy)-len(listed))
+	}
+
+	return metav1.ConditionFalse, drPolicyReplicationUnhealthyReason, message
+}
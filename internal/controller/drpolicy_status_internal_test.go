@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRPolicyWorkloadsStatusAggregator", func() {
+	var (
+		fakeClient client.Client
+		aggregator *DRPolicyWorkloadsStatusAggregator
+		now        time.Time
+	)
+
+	drpc := func(name, policyName string, protected bool, syncedAgo time.Duration) *rmn.DRPlacementControl {
+		status := metav1.ConditionFalse
+		if protected {
+			status = metav1.ConditionTrue
+		}
+
+		syncTime := metav1.NewTime(now.Add(-syncedAgo))
+
+		return &rmn.DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app"},
+			Spec:       rmn.DRPlacementControlSpec{DRPolicyRef: corev1.ObjectReference{Name: policyName}},
+			Status: rmn.DRPlacementControlStatus{
+				Conditions:        []metav1.Condition{{Type: rmn.ConditionProtected, Status: status}},
+				LastGroupSyncTime: &syncTime,
+			},
+		}
+	}
+
+	drPolicy := func(name string) *rmn.DRPolicy {
+		drPolicy := &rmn.DRPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(drPolicy), drPolicy)).To(Succeed())
+
+		return drPolicy
+	}
+
+	BeforeEach(func() {
+		now = time.Now().Truncate(time.Second)
+
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(
+				&rmn.DRPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}},
+				&rmn.DRPolicy{ObjectMeta: metav1.ObjectMeta{Name: "unused"}},
+				drpc("a", "policy", true, time.Minute),
+				drpc("b", "policy", false, time.Hour),
+				drpc("c", "other", true, 2*time.Hour),
+			).
+			WithStatusSubresource(&rmn.DRPolicy{}).
+			Build()
+
+		aggregator = &DRPolicyWorkloadsStatusAggregator{Client: fakeClient, Log: logr.Discard()}
+	})
+
+	It("aggregates the status of the DRPCs of each policy", func() {
+		Expect(aggregator.aggregate(context.TODO())).To(Succeed())
+
+		policy := drPolicy("policy")
+		Expect(policy.Status.Workloads).ToNot(BeNil())
+		Expect(policy.Status.Workloads.Bound).To(Equal(2))
+		Expect(policy.Status.Workloads.Healthy).To(Equal(1))
+		Expect(policy.Status.Workloads.OldestLastGroupSyncTime.Time).To(BeTemporally("==", now.Add(-time.Hour)))
+
+		condition := meta.FindStatusCondition(policy.Status.Conditions, rmn.DRPolicyConditionTypeReplicationHealthy)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(drPolicyReplicationUnhealthyReason))
+		Expect(condition.Message).To(ContainSubstring("app/b"))
+
+		unused := drPolicy("unused")
+		Expect(unused.Status.Workloads.Bound).To(BeZero())
+		Expect(unused.Status.Workloads.OldestLastGroupSyncTime).To(BeNil())
+		Expect(meta.FindStatusCondition(unused.Status.Conditions, rmn.DRPolicyConditionTypeReplicationHealthy)).To(
+			And(HaveField("Status", metav1.ConditionTrue), HaveField("Reason", drPolicyNoWorkloadsReason)))
+	})
+
+	It("reports the policy healthy once all its DRPCs are protected", func() {
+		b := &rmn.DRPlacementControl{}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Name: "b", Namespace: "app"}, b)).To(Succeed())
+		b.Status.Conditions[0].Status = metav1.ConditionTrue
+		Expect(fakeClient.Update(context.TODO(), b)).To(Succeed())
+
+		Expect(aggregator.aggregate(context.TODO())).To(Succeed())
+
+		policy := drPolicy("policy")
+		Expect(policy.Status.Workloads.Healthy).To(Equal(2))
+		Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, rmn.DRPolicyConditionTypeReplicationHealthy)).To(
+			BeTrue())
+	})
+
+	It("counts the DRPCs whose last group sync is older than the RPO target of the policy", func() {
+		policy := drPolicy("policy")
+		Expect(policy.Status.Workloads).To(BeNil())
+
+		policy.Spec.RPOTarget = &metav1.Duration{Duration: 30 * time.Minute}
+		Expect(fakeClient.Update(context.TODO(), policy)).To(Succeed())
+
+		Expect(aggregator.aggregate(context.TODO())).To(Succeed())
+
+		Expect(drPolicy("policy").Status.Workloads.RPOViolated).To(Equal(1))
+		Expect(drPolicy("unused").Status.Workloads.RPOViolated).To(BeZero())
+	})
+
+	It("reports the metrics of each policy", func() {
+		// policyMetric returns the value of the gauge named name of the policy, if it is reported, and its labels
+		policyMetric := func(name, policyName string) (float64, map[string]string, bool) {
+			families, err := metrics.Registry.Gather()
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, family := range families {
+				if family.GetName() != metricNamespace+"_"+name {
+					continue
+				}
+
+				for _, m := range family.GetMetric() {
+					labels := map[string]string{}
+					for _, label := range m.GetLabel() {
+						labels[label.GetName()] = label.GetValue()
+					}
+
+					if labels[Policyname] == policyName {
+						return m.GetGauge().GetValue(), labels, true
+					}
+				}
+			}
+
+			return 0, nil, false
+		}
+
+		policy := drPolicy("policy")
+		policy.Spec.SchedulingInterval = "5m"
+		Expect(fakeClient.Update(context.TODO(), policy)).To(Succeed())
+
+		Expect(aggregator.aggregate(context.TODO())).To(Succeed())
+
+		value, _, ok := policyMetric(DRPolicyBoundWorkloads, "policy")
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(2.0))
+
+		value, labels, ok := policyMetric(DRPolicyReplicationType, "policy")
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(1.0))
+		Expect(labels).To(HaveKeyWithValue(ReplicationTypeLabel, DRPolicyReplicationTypeAsync))
+
+		value, _, _ = policyMetric(DRPolicyValidatedStatus, "policy")
+		Expect(value).To(BeZero())
+
+		value, _, ok = policyMetric(DRPolicyLastSyncAgeSeconds, "policy")
+		Expect(ok).To(BeTrue())
+		Expect(value).To(BeNumerically(">=", time.Hour.Seconds()))
+
+		_, labels, _ = policyMetric(DRPolicyReplicationType, "unused")
+		Expect(labels).To(HaveKeyWithValue(ReplicationTypeLabel, DRPolicyReplicationTypeSync))
+
+		_, _, ok = policyMetric(DRPolicyLastSyncAgeSeconds, "unused")
+		Expect(ok).To(BeFalse())
+
+		Expect(fakeClient.Delete(context.TODO(), drPolicy("unused"))).To(Succeed())
+		Expect(aggregator.aggregate(context.TODO())).To(Succeed())
+
+		_, _, ok = policyMetric(DRPolicyBoundWorkloads, "unused")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("lists a bounded number of the DRPCs that are not protected", func() {
+		unhealthy := []string{"app/1", "app/2", "app/3", "app/4", "app/5", "app/6", "app/7"}
+		_, _, message := drPolicyReplicationHealthy(&rmn.DRPolicyWorkloadsStatus{Bound: 8, Healthy: 1}, unhealthy)
+
+		Expect(message).To(Equal("7 of 8 DRPCs of the policy are not protected: " +
+			"app/1, app/2, app/3, app/4, app/5 and 2 more"))
+	})
+})
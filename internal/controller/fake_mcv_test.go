@@ -9,12 +9,14 @@ import (
 	"fmt"
 	"time"
 
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
 	volrep "github.com/csi-addons/kubernetes-csi-addons/api/replication.storage/v1alpha1"
 	"github.com/go-logr/logr"
 	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	recipev1 "github.com/ramendr/recipe/api/v1alpha1"
 	groupsnapv1beta1 "github.com/red-hat-storage/external-snapshotter/client/v8/apis/volumegroupsnapshot/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -43,6 +45,24 @@ func (f FakeMCVGetter) ListNFClassMCVs(managedCluster string) (*viewv1beta1.Mana
 	return &viewv1beta1.ManagedClusterViewList{}, nil
 }
 
+// errFakeListView fails the list-type class views, so that classes are read with the fake view per class name
+var errFakeListView = fmt.Errorf("list views are not supported")
+
+func (f FakeMCVGetter) ListSClassesFromManagedCluster(managedCluster string, annotations map[string]string,
+) (*storagev1.StorageClassList, error) {
+	return nil, errFakeListView
+}
+
+func (f FakeMCVGetter) ListNFClassesFromManagedCluster(managedCluster string, annotations map[string]string,
+) (*csiaddonsv1alpha1.NetworkFenceClassList, error) {
+	return nil, errFakeListView
+}
+
+func (f FakeMCVGetter) ListVRClassesFromManagedCluster(managedCluster string, annotations map[string]string,
+) (*volrep.VolumeReplicationClassList, error) {
+	return nil, errFakeListView
+}
+
 func (f FakeMCVGetter) GetVSClassFromManagedCluster(resourceName, managedCluster string, annotations map[string]string,
 ) (*snapv1.VolumeSnapshotClass, error) {
 	return nil, nil
@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// ManagedClusterViewGCInterval is the interval between passes of the ManagedClusterView garbage collector
+	ManagedClusterViewGCInterval = 10 * time.Minute
+
+	// managedClusterViewGCResourceNotFoundAge is how long a view must report its resource as not found before it is
+	// pruned, so that views of resources that are being created are left in place
+	managedClusterViewGCResourceNotFoundAge = time.Hour
+
+	mcvPruneReasonOwnerDeleted     = "OwnerDeleted"
+	mcvPruneReasonResourceNotFound = "ResourceNotFound"
+)
+
+// ManagedClusterViewGarbageCollector periodically deletes the ManagedClusterViews created by ramen that are stale,
+// as the DRPC or DRCluster they were created for was deleted, or the resource they view has not existed on the
+// managed cluster for a while. It complements the pruning done by the reconcilers, which misses views left behind
+// by deleted or renamed resources. It first restores the label and the annotations ramen sets on its views that were
+// edited or removed, as the events of a view are routed to its owner by them.
+type ManagedClusterViewGarbageCollector struct {
+	client.Client
+	APIReader client.Reader
+	Log       logr.Logger
+	Interval  time.Duration
+}
+
+// NeedLeaderElection runs the garbage collector only on the leader, alongside the hub reconcilers
+func (g *ManagedClusterViewGarbageCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs a garbage collection pass every interval until ctx is done
+func (g *ManagedClusterViewGarbageCollector) Start(ctx context.Context) error {
+	interval := g.Interval
+	if interval <= 0 {
+		interval = ManagedClusterViewGCInterval
+	}
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := g.collect(ctx); err != nil {
+			g.Log.Error(err, "ManagedClusterView garbage collection failed")
+		}
+	}, interval)
+
+	return nil
+}
+
+// collect repairs and deletes the stale ManagedClusterViews created by ramen, and reports the metrics of the views left.
+// The views are not listed by the CreatedByRamenLabel, so that a view whose label was removed is repaired as well.
+func (g *ManagedClusterViewGarbageCollector) collect(ctx context.Context) error {
+	allMCVs, err := rmnutil.ListManagedClusterViewsPaginated(ctx, g.APIReader)
+	if err != nil {
+		return fmt.Errorf("failed to list ManagedClusterViews: %w", err)
+	}
+
+	mcvs := make([]viewv1beta1.ManagedClusterView, 0, len(allMCVs.Items))
+
+	for i := range allMCVs.Items {
+		if rmnutil.IsRamenManagedClusterView(&allMCVs.Items[i]) {
+			mcvs = append(mcvs, allMCVs.Items[i])
+		}
+	}
+
+	kept := make([]viewv1beta1.ManagedClusterView, 0, len(mcvs))
+
+	var errs []error
+
+	for i := range mcvs {
+		mcv := &mcvs[i]
+		log := g.Log.WithValues("name", mcv.Name, "namespace", mcv.Namespace)
+
+		if err := g.repair(ctx, mcv, log); err != nil {
+			errs = append(errs, err)
+		}
+
+		reason, err := g.staleReason(ctx, mcv)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		if reason == "" {
+			kept = append(kept, *mcv)
+
+			continue
+		}
+
+		log.Info("Deleting stale ManagedClusterView", "reason", reason)
+
+		if err := g.Delete(ctx, mcv); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete ManagedClusterView %s/%s: %w",
+				mcv.Namespace, mcv.Name, err))
+			kept = append(kept, *mcv)
+
+			continue
+		}
+
+		NewManagedClusterViewsPrunedMetric(ManagedClusterViewsPrunedLabels(reason)).Inc()
+	}
+
+	recordManagedClusterViewsMetrics(kept, time.Now())
+
+	pruned := len(mcvs) - len(kept)
+
+	g.Log.Info("ManagedClusterView garbage collection done", "views", len(mcvs), "pruned", pruned)
+
+	return errors.Join(errs...)
+}
+
+// repair restores the CreatedByRamenLabel and the required annotations of mcv that were edited or removed
+func (g *ManagedClusterViewGarbageCollector) repair(ctx context.Context, mcv *viewv1beta1.ManagedClusterView,
+	log logr.Logger,
+) error {
+	repaired := rmnutil.RepairManagedClusterView(mcv)
+	if len(repaired) == 0 {
+		return nil
+	}
+
+	log.Info("Repairing ManagedClusterView", "keys", repaired)
+
+	if err := g.Update(ctx, mcv); err != nil {
+		return fmt.Errorf("failed to repair ManagedClusterView %s/%s: %w", mcv.Namespace, mcv.Name, err)
+	}
+
+	for _, key := range repaired {
+		NewManagedClusterViewsRepairedMetric(ManagedClusterViewsRepairedLabels(key)).Inc()
+	}
+
+	return nil
+}
+
+// staleReason returns why mcv is stale, or an empty string if it is not. A view created for a DRPC is stale once the
+// DRPC is deleted, and one created for a DRCluster, or else in the namespace of a DRCluster, once the DRCluster is
+// deleted. The DRPC reconciler manages the lifecycle of the views of its VRGs, so only views that are not created
+// for a DRPC are stale when their resource is not found.
+func (g *ManagedClusterViewGarbageCollector) staleReason(ctx context.Context, mcv *viewv1beta1.ManagedClusterView,
+) (string, error) {
+	drpcName := mcv.Annotations[DRPCNameAnnotation]
+	if drpcName != "" {
+		key := types.NamespacedName{Name: drpcName, Namespace: mcv.Annotations[DRPCNamespaceAnnotation]}
+
+		return g.ownerStaleReason(ctx, key, &rmn.DRPlacementControl{})
+	}
+
+	drClusterName := mcv.Annotations[DRClusterNameAnnotation]
+	if drClusterName == "" {
+		drClusterName = mcv.Namespace
+	}
+
+	reason, err := g.ownerStaleReason(ctx, types.NamespacedName{Name: drClusterName}, &rmn.DRCluster{})
+	if err != nil || reason != "" {
+		return reason, err
+	}
+
+	if age, notFound := rmnutil.ManagedClusterViewResourceNotFound(mcv); notFound &&
+		age >= managedClusterViewGCResourceNotFoundAge {
+		return mcvPruneReasonResourceNotFound, nil
+	}
+
+	return "", nil
+}
+
+// ownerStaleReason returns mcvPruneReasonOwnerDeleted if the owner of a view named by key does not exist
+func (g *ManagedClusterViewGarbageCollector) ownerStaleReason(ctx context.Context, key types.NamespacedName,
+	owner client.Object,
+) (string, error) {
+	if err := g.Get(ctx, key, owner); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return mcvPruneReasonOwnerDeleted, nil
+		}
+
+		return "", fmt.Errorf("failed to get %T %s: %w", owner, key, err)
+	}
+
+	return "", nil
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("ManagedClusterViewGarbageCollector", func() {
+	newMCV := func(name, namespace string, annotations map[string]string) *viewv1beta1.ManagedClusterView {
+		return &viewv1beta1.ManagedClusterView{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Labels:      map[string]string{rmnutil.CreatedByRamenLabel: "true"},
+				Annotations: annotations,
+			},
+		}
+	}
+
+	notFound := func(mcv *viewv1beta1.ManagedClusterView, since time.Time) *viewv1beta1.ManagedClusterView {
+		mcv.Status.Conditions = []metav1.Condition{{
+			Type:               viewv1beta1.ConditionViewProcessing,
+			Status:             metav1.ConditionFalse,
+			Reason:             viewv1beta1.ReasonGetResourceFailed,
+			Message:            "failed to get resource: sc not found",
+			LastTransitionTime: metav1.NewTime(since),
+		}}
+
+		return mcv
+	}
+
+	It("deletes the views whose owner or resource is gone, and keeps the others", func() {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+		Expect(viewv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		drpcAnnotations := func(name string) map[string]string {
+			return map[string]string{DRPCNameAnnotation: name, DRPCNamespaceAnnotation: "app"}
+		}
+
+		kept := []client.Object{
+			newMCV("app-app-vrg-mcv", "cluster1", drpcAnnotations("app")),
+			newMCV("cluster1-drcconfig-mcv", "cluster1", nil),
+			notFound(newMCV("new-sc-mcv", "cluster1", nil), time.Now()),
+			notFound(newMCV("other-app-vrg-mcv", "cluster1", drpcAnnotations("other")), time.Time{}),
+		}
+		stale := []client.Object{
+			newMCV("deleted-app-vrg-mcv", "cluster1", drpcAnnotations("deleted")),
+			newMCV("cluster2-drcconfig-mcv", "cluster2", nil),
+			newMCV("network-fence-cluster1-mcv", "cluster1", map[string]string{DRClusterNameAnnotation: "cluster2"}),
+			notFound(newMCV("old-sc-mcv", "cluster1", nil), time.Now().Add(-2*time.Hour)),
+		}
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(&rmn.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}).
+			WithObjects(&rmn.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "app"}}).
+			WithObjects(&rmn.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "app"}}).
+			WithObjects(kept...).WithObjects(stale...).
+			Build()
+
+		gc := &ManagedClusterViewGarbageCollector{Client: fakeClient, APIReader: fakeClient, Log: logr.Discard()}
+		Expect(gc.collect(context.TODO())).To(Succeed())
+
+		mcvs := &viewv1beta1.ManagedClusterViewList{}
+		Expect(fakeClient.List(context.TODO(), mcvs)).To(Succeed())
+
+		names := []string{}
+		for _, mcv := range mcvs.Items {
+			names = append(names, mcv.Name)
+		}
+
+		Expect(names).To(ConsistOf("app-app-vrg-mcv", "cluster1-drcconfig-mcv", "new-sc-mcv", "other-app-vrg-mcv"))
+	})
+
+	It("restores the label and the required annotations of the views before checking whether they are stale", func() {
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+		Expect(viewv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		required := map[string]string{DRPCNameAnnotation: "app", DRPCNamespaceAnnotation: "app"}
+		record, err := json.Marshal(required)
+		Expect(err).ToNot(HaveOccurred())
+
+		edited := newMCV("app-app-vrg-mcv", "cluster1", map[string]string{
+			DRPCNameAnnotation: "deleted",
+			rmnutil.ManagedClusterViewRequiredAnnotationsAnnotation: string(record),
+		})
+		edited.Labels = nil
+
+		other := &viewv1beta1.ManagedClusterView{ObjectMeta: metav1.ObjectMeta{Name: "other-mcv", Namespace: "cluster1"}}
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(&rmn.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "app"}}).
+			WithObjects(edited, other).
+			Build()
+
+		gc := &ManagedClusterViewGarbageCollector{Client: fakeClient, APIReader: fakeClient, Log: logr.Discard()}
+		Expect(gc.collect(context.TODO())).To(Succeed())
+
+		repaired := &viewv1beta1.ManagedClusterView{}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(edited), repaired)).To(Succeed())
+		Expect(repaired.Labels).To(HaveKeyWithValue(rmnutil.CreatedByRamenLabel, "true"))
+		Expect(repaired.Annotations).To(HaveKeyWithValue(DRPCNameAnnotation, "app"))
+		Expect(repaired.Annotations).To(HaveKeyWithValue(DRPCNamespaceAnnotation, "app"))
+
+		untouched := &viewv1beta1.ManagedClusterView{}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(other), untouched)).To(Succeed())
+		Expect(untouched.Labels).To(BeEmpty())
+	})
+})
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	mcvReadResultSuccess = "Success"
+	mcvReadResultError   = "Error"
+)
+
+// ObserveManagedClusterViewRead counts a read of an object on managedCluster through a view, by its result, in the
+// managed_cluster_view_reads_total metric
+func ObserveManagedClusterViewRead(managedCluster string, err error) {
+	result := mcvReadResultSuccess
+
+	if err != nil {
+		result = mcvReadResultError
+
+		if mcvErr := rmnutil.MCVErrorFrom(err); mcvErr != nil {
+			result = string(mcvErr.Reason)
+		}
+	}
+
+	NewManagedClusterViewReadsMetric(ManagedClusterViewReadsLabels(managedCluster, result)).Inc()
+}
+
+// recordManagedClusterViewsMetrics reports the number of views in mcvs of each managed cluster, and how long the view
+// of each cluster that waits the longest for a current result has been without one
+func recordManagedClusterViewsMetrics(mcvs []viewv1beta1.ManagedClusterView, now time.Time) {
+	counts := map[string]int{}
+	ages := map[string]time.Duration{}
+
+	for i := range mcvs {
+		cluster := mcvs[i].Namespace
+		counts[cluster]++
+		ages[cluster] = max(ages[cluster], managedClusterViewAwaitingResultFor(&mcvs[i], now))
+	}
+
+	ResetManagedClusterViewsMetrics()
+
+	for cluster, count := range counts {
+		NewManagedClusterViewsMetric(ManagedClusterViewsLabels(cluster)).Set(float64(count))
+		NewManagedClusterViewRefreshAgeMetric(ManagedClusterViewsLabels(cluster)).Set(ages[cluster].Seconds())
+	}
+}
+
+// managedClusterViewAwaitingResultFor returns how long mcv has been without a current result, since it was created
+// or its last refresh failed, or zero if its result is current
+func managedClusterViewAwaitingResultFor(mcv *viewv1beta1.ManagedClusterView, now time.Time) time.Duration {
+	if len(mcv.Status.Conditions) == 0 {
+		return now.Sub(mcv.CreationTimestamp.Time)
+	}
+
+	condition := mcv.Status.Conditions[0]
+	if condition.Status == metav1.ConditionTrue && condition.Reason != viewv1beta1.ReasonGetResourceFailed {
+		return 0
+	}
+
+	return now.Sub(condition.LastTransitionTime.Time)
+}
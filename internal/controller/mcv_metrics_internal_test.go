@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("ManagedClusterView metrics", func() {
+	// metric returns the value of the gauge or counter named name with labels, if it is reported
+	metric := func(name string, labels prometheus.Labels) (float64, bool) {
+		families, err := metrics.Registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, family := range families {
+			if family.GetName() != metricNamespace+"_"+name {
+				continue
+			}
+
+			for _, m := range family.GetMetric() {
+				matched := 0
+
+				for _, label := range m.GetLabel() {
+					if labels[label.GetName()] == label.GetValue() {
+						matched++
+					}
+				}
+
+				if matched == len(labels) {
+					return m.GetGauge().GetValue() + m.GetCounter().GetValue(), true
+				}
+			}
+		}
+
+		return 0, false
+	}
+
+	It("reports the views of each cluster and the longest a view of the cluster is without a result", func() {
+		now := time.Now()
+		view := func(cluster string, condition *metav1.Condition) viewv1beta1.ManagedClusterView {
+			mcv := viewv1beta1.ManagedClusterView{ObjectMeta: metav1.ObjectMeta{
+				Namespace:         cluster,
+				CreationTimestamp: metav1.NewTime(now.Add(-time.Minute)),
+			}}
+			if condition != nil {
+				mcv.Status.Conditions = []metav1.Condition{*condition}
+			}
+
+			return mcv
+		}
+		condition := func(status metav1.ConditionStatus, reason string, since time.Duration) *metav1.Condition {
+			return &metav1.Condition{
+				Type:               viewv1beta1.ConditionViewProcessing,
+				Status:             status,
+				Reason:             reason,
+				LastTransitionTime: metav1.NewTime(now.Add(-since)),
+			}
+		}
+
+		recordManagedClusterViewsMetrics([]viewv1beta1.ManagedClusterView{
+			view("metrics-cluster1", condition(metav1.ConditionTrue, viewv1beta1.ReasonGetResource, time.Hour)),
+			view("metrics-cluster1", condition(metav1.ConditionFalse, viewv1beta1.ReasonGetResourceFailed,
+				10*time.Minute)),
+			view("metrics-cluster1", nil),
+			view("metrics-cluster2", condition(metav1.ConditionTrue, viewv1beta1.ReasonGetResource, time.Hour)),
+		}, now)
+
+		value, ok := metric(ManagedClusterViews, ManagedClusterViewsLabels("metrics-cluster1"))
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(float64(3)))
+
+		value, ok = metric(ManagedClusterViewRefreshAgeSeconds, ManagedClusterViewsLabels("metrics-cluster1"))
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal((10 * time.Minute).Seconds()))
+
+		value, ok = metric(ManagedClusterViewRefreshAgeSeconds, ManagedClusterViewsLabels("metrics-cluster2"))
+		Expect(ok).To(BeTrue())
+		Expect(value).To(BeZero())
+
+		recordManagedClusterViewsMetrics(nil, now)
+
+		_, ok = metric(ManagedClusterViews, ManagedClusterViewsLabels("metrics-cluster1"))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("counts the reads through views by result", func() {
+		reads := func(result string) float64 {
+			value, _ := metric(ManagedClusterViewReads, ManagedClusterViewReadsLabels("metrics-cluster3", result))
+
+			return value
+		}
+
+		ObserveManagedClusterViewRead("metrics-cluster3", nil)
+		ObserveManagedClusterViewRead("metrics-cluster3", errors.New("failed"))
+		ObserveManagedClusterViewRead("metrics-cluster3", fmt.Errorf("wrapped: %w",
+			&rmnutil.MCVError{Reason: rmnutil.MCVErrorReasonStale, Err: errors.New("stale")}))
+
+		Expect(reads(mcvReadResultSuccess)).To(Equal(float64(1)))
+		Expect(reads(mcvReadResultError)).To(Equal(float64(1)))
+		Expect(reads(string(rmnutil.MCVErrorReasonStale))).To(Equal(float64(1)))
+	})
+})
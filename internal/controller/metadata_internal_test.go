@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http/httptest"
+
+	volrep "github.com/csi-addons/kubernetes-csi-addons/api/replication.storage/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/kubeobjects/velero"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// blobsBucket reads the blobs of a fake blob service
+type blobsBucket map[string][]byte
+
+func (b blobsBucket) Get(_ context.Context, key string) ([]byte, error) {
+	data, ok := b[key]
+	if !ok {
+		return nil, fmt.Errorf("no blob %s", key)
+	}
+
+	return data, nil
+}
+
+func (b blobsBucket) List(context.Context, string) ([]string, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+var _ = Describe("DR metadata", func() {
+	It("is stored with the keys of the metadata package", func() {
+		prefix := s3PathNamePrefix("ns", "vrg")
+		Expect(prefix).To(Equal(metadata.VolumeReplicationGroupPrefix("ns", "vrg")))
+
+		for _, objectAndTypeName := range []struct {
+			object   interface{}
+			typeName string
+		}{
+			{ramen.VolumeReplicationGroup{}, metadata.TypeNameVolumeReplicationGroup},
+			{LocalFailoverPlan{}, metadata.TypeNameLocalFailoverPlan},
+			{LocalFailoverPrimaryHeartbeat{}, metadata.TypeNameLocalFailoverPrimaryHeartbeat},
+			{corev1.PersistentVolume{}, metadata.TypeNamePersistentVolume},
+			{corev1.PersistentVolumeClaim{}, metadata.TypeNamePersistentVolumeClaim},
+			{volrep.VolumeGroupReplication{}, metadata.TypeNameVolumeGroupReplication},
+			{volrep.VolumeGroupReplicationContent{}, metadata.TypeNameVolumeGroupReplicationContent},
+		} {
+			Expect(TypedObjectKey(prefix, "a", objectAndTypeName.object)).To(Equal(
+				metadata.TypedKey(prefix, objectAndTypeName.typeName, "a")))
+		}
+
+		Expect(vrgS3ObjectNameSuffix).To(Equal(metadata.VolumeReplicationGroupName))
+		Expect(localFailoverPlanS3ObjectNameSuffix).To(Equal(metadata.LocalFailoverPlanName))
+		Expect(localFailoverPrimaryHeartbeatS3ObjectNameSuffix).To(Equal(metadata.LocalFailoverPrimaryHeartbeatName))
+
+		pathName, _, _ := kubeObjectsCapturePathNamesAndNamePrefix("ns", "vrg", 3, velero.RequestsManager{})
+		Expect(pathName).To(Equal(metadata.KubeObjectsCapturePrefix("ns", "vrg", 3)))
+		Expect(metadata.KubeObjectsDifferentialBaselineKey(pathName)).To(
+			HavePrefix(pathName + kubeObjectsDifferentialPathName))
+	})
+
+	It("is read by the metadata package whether it is compressed or encrypted", func() {
+		service := &fakeBlobService{
+			accountName: "account",
+			accountKey:  []byte("key"),
+			container:   "container",
+			blobs:       map[string][]byte{},
+		}
+		server := httptest.NewServer(service)
+		DeferCleanup(server.Close)
+
+		kek := metadata.AESKeyEncrypter(bytes.Repeat([]byte{1}, metadata.DataKeySize))
+		plan := LocalFailoverPlan{FailoverCluster: "cluster1"}
+		key := TypedObjectKey(s3PathNamePrefix("ns", "vrg"), localFailoverPlanS3ObjectNameSuffix, plan)
+
+		for _, compression := range []objectCompression{{}, {Algorithm: ramen.ObjectCompressionNone}} {
+			var objectStore ObjectStorer = &azureBlobObjectStore{
+				client:      server.Client(),
+				endpoint:    server.URL,
+				container:   "container",
+				accountName: "account",
+				accountKey:  []byte("key"),
+				compression: compression,
+			}
+
+			for _, objectStore := range []ObjectStorer{objectStore, &encryptingObjectStore{
+				ObjectStorer: objectStore,
+				keyEncrypter: kek,
+				compression:  compression,
+			}} {
+				Expect(LocalFailoverPlanUpload(objectStore, "ns", "vrg", plan)).To(Succeed())
+				Expect(service.blobs).To(HaveKey(key))
+
+				read, err := metadata.Reader{Bucket: blobsBucket(service.blobs), KeyUnwrapper: kek}.LocalFailoverPlan(
+					context.TODO(), "ns", "vrg")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(read.FailoverCluster).To(Equal("cluster1"))
+			}
+		}
+	})
+})
@@ -18,6 +18,16 @@ const (
 	DRPolicySyncIntervalSeconds = "policy_schedule_interval_seconds"
 )
 
+const (
+	DRPolicyBoundWorkloads       = "policy_bound_workloads"
+	DRPolicyPeerClasses          = "policy_peer_classes"
+	DRPolicyReplicationType      = "policy_replication_type"
+	DRPolicyValidatedStatus      = "policy_validated"
+	DRPolicyLastSyncAgeSeconds   = "policy_last_sync_age_seconds"
+	DRPolicyReplicationTypeSync  = "sync"
+	DRPolicyReplicationTypeAsync = "async"
+)
+
 const (
 	LastSyncTimestampSeconds = "last_sync_timestamp_seconds"
 	LastSyncDurationSeconds  = "last_sync_duration_seconds"
@@ -27,12 +37,52 @@ const (
 	GlobalActionStatus       = "global_action_consensus_status"
 	// Added for drpc progression state
 	DRProgressionState = "progression_state"
+	DRReadinessScore   = "dr_readiness_score"
+	RPOViolated        = "rpo_violated"
 )
 
 const (
 	InvalidCIDRsDetected = "invalid_cidrs_detected"
 )
 
+const (
+	ManagedClusterViewsPruned   = "managed_cluster_views_pruned_total"
+	ManagedClusterViewsRepaired = "managed_cluster_views_repaired_total"
+)
+
+const (
+	APIClientCalls = "api_client_calls_total"
+)
+
+const (
+	ManagedClusterViews                 = "managed_cluster_views"
+	ManagedClusterViewRefreshAgeSeconds = "managed_cluster_view_refresh_age_seconds"
+	ManagedClusterViewReads             = "managed_cluster_view_reads_total"
+)
+
+const (
+	DRPCQueueDepth = "drpc_queue_depth"
+)
+
+const (
+	S3UploadPartRetries  = "s3_upload_part_retries_total"
+	S3UploadPartsResumed = "s3_upload_parts_resumed_total"
+)
+
+const (
+	S3ProfileAvailable     = "s3_profile_available"
+	S3ProfileProbeFailures = "s3_profile_probe_failures_total"
+)
+
+const (
+	S3OrphanedPrefixes        = "s3_orphaned_prefixes"
+	S3OrphanedPrefixesDeleted = "s3_orphaned_prefixes_deleted_total"
+)
+
+const (
+	ReconcileStageDurationSeconds = "reconcile_stage_duration_seconds"
+)
+
 type SyncTimeMetrics struct {
 	LastSyncTime prometheus.Gauge
 }
@@ -68,6 +118,14 @@ type DRProgressionStateMetrics struct {
 	DRProgressionState prometheus.Gauge
 }
 
+type DRReadinessScoreMetrics struct {
+	DRReadinessScore prometheus.Gauge
+}
+
+type RPOViolatedMetrics struct {
+	RPOViolated prometheus.Gauge
+}
+
 type SyncMetrics struct {
 	SyncTimeMetrics
 	SyncDurationMetrics
@@ -81,6 +139,17 @@ const (
 	Policyname            = "policyname"
 	SchedulingInterval    = "scheduling_interval"
 	ProgressionStateLabel = "state"
+	PruneReasonLabel      = "reason"
+	ControllerLabel       = "controller"
+	VerbLabel             = "verb"
+	SourceLabel           = "source"
+	ClusterLabel          = "cluster"
+	ResultLabel           = "result"
+	S3ProfileLabel        = "s3_profile"
+	TenantLabel           = "tenant"
+	StageLabel            = "stage"
+	KeyLabel              = "key"
+	ReplicationTypeLabel  = "replication_type"
 )
 
 var (
@@ -96,6 +165,15 @@ var (
 		Policyname, // DRPolicy name
 	}
 
+	drPolicyMetricLabelNames = []string{
+		Policyname, // DRPolicy name
+	}
+
+	drPolicyReplicationTypeLabelNames = []string{
+		Policyname,           // DRPolicy name
+		ReplicationTypeLabel, // Replication type of the DRPolicy [sync|async]
+	}
+
 	syncDurationMetricLabelNames = []string{
 		ObjType,            // Name of the type of the resource [drpc]
 		ObjName,            // Name of the resoure [drpc-name]
@@ -139,6 +217,63 @@ var (
 		ObjNamespace, // Protected namespace
 		ProgressionStateLabel,
 	}
+
+	managedClusterViewsPrunedLabels = []string{
+		PruneReasonLabel, // Why the views were pruned [OwnerDeleted|ResourceNotFound]
+	}
+
+	managedClusterViewsRepairedLabels = []string{
+		KeyLabel, // Label or annotation restored on the views
+	}
+
+	apiClientCallsLabels = []string{
+		ControllerLabel, // Name of the controller making the calls [drpc|drc|drp|mcv|...]
+		VerbLabel,       // Verb of the calls [get|list|create|update|patch|apply|delete|deletecollection]
+		SourceLabel,     // What served the calls [cache|apiserver]
+	}
+
+	managedClusterViewsLabels = []string{
+		ClusterLabel, // Name of the managed cluster of the views
+	}
+
+	managedClusterViewReadsLabels = []string{
+		ClusterLabel, // Name of the managed cluster of the views
+		ResultLabel,  // Result of the reads [Success|NotFound|Processing|Stale|Forbidden|ClusterUnreachable|Error]
+	}
+
+	drpcQueueDepthLabels = []string{
+		TenantLabel, // Tenant of the DRPCs, the value of the tenant label of the DRPCs or their namespace
+	}
+
+	s3UploadPartsLabels = []string{
+		S3ProfileLabel, // Name of the S3 profile of the uploads
+	}
+
+	s3ProfileHealthLabels = []string{
+		S3ProfileLabel, // Name of the S3 profile probed
+	}
+
+	s3GarbageCollectionLabels = []string{
+		S3ProfileLabel, // Name of the S3 profile audited
+	}
+
+	reconcileStageDurationLabels = []string{
+		ControllerLabel, // Name of the controller reconciling [drc|drpc]
+		StageLabel,      // Stage of the reconcile [config_get|deploy|fence_handling|...]
+	}
+
+	drReadinessScoreLabels = []string{
+		ObjType,      // Name of the type of the resource [drpc]
+		ObjName,      // Name of the protected application [drpc-name]
+		ObjNamespace, // Protected namespace
+	}
+
+	rpoViolatedLabels = []string{
+		ObjType,      // Name of the type of the resource [drpc]
+		ObjName,      // Name of the protected application [drpc-name]
+		ObjNamespace, // Protected namespace
+		Policyname,   // DRPolicy name
+	}
 )
 
 var (
@@ -160,6 +295,51 @@ var (
 		drpolicySyncIntervalMetricLabelNames,
 	)
 
+	drPolicyBoundWorkloads = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      DRPolicyBoundWorkloads,
+			Namespace: metricNamespace,
+			Help:      "Number of DRPCs that refer to a policy",
+		},
+		drPolicyMetricLabelNames,
+	)
+
+	drPolicyPeerClasses = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      DRPolicyPeerClasses,
+			Namespace: metricNamespace,
+			Help:      "Number of sync and async peer classes of a policy",
+		},
+		drPolicyMetricLabelNames,
+	)
+
+	drPolicyReplicationType = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      DRPolicyReplicationType,
+			Namespace: metricNamespace,
+			Help:      "Replication type of a policy, in the replication_type label; always 1",
+		},
+		drPolicyReplicationTypeLabelNames,
+	)
+
+	drPolicyValidated = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      DRPolicyValidatedStatus,
+			Namespace: metricNamespace,
+			Help:      "Whether a policy is validated (1) or not (0)",
+		},
+		drPolicyMetricLabelNames,
+	)
+
+	drPolicyLastSyncAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      DRPolicyLastSyncAgeSeconds,
+			Namespace: metricNamespace,
+			Help:      "Seconds since the oldest last group sync of the DRPCs of a policy; emitted only once they report one",
+		},
+		drPolicyMetricLabelNames,
+	)
+
 	lastSyncDuration = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name:      LastSyncDurationSeconds,
@@ -222,6 +402,151 @@ var (
 		},
 		drProgressionStateMetricsLabels,
 	)
+
+	drReadinessScore = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      DRReadinessScore,
+			Namespace: metricNamespace,
+			Help:      "DR readiness score of a DRPC, from 0 to 100; emitted only when continuous validation is enabled",
+		},
+		drReadinessScoreLabels,
+	)
+
+	rpoViolated = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      RPOViolated,
+			Namespace: metricNamespace,
+			Help:      "Whether the last group sync of a DRPC is older than the RPO target of its DRPolicy (1) or not (0)",
+		},
+		rpoViolatedLabels,
+	)
+
+	managedClusterViewsPruned = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      ManagedClusterViewsPruned,
+			Namespace: metricNamespace,
+			Help:      "Number of stale ManagedClusterViews deleted by the ManagedClusterView garbage collector",
+		},
+		managedClusterViewsPrunedLabels,
+	)
+
+	managedClusterViewsRepaired = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      ManagedClusterViewsRepaired,
+			Namespace: metricNamespace,
+			Help:      "Number of labels and annotations of ManagedClusterViews restored by the garbage collector",
+		},
+		managedClusterViewsRepairedLabels,
+	)
+
+	apiClientCalls = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      APIClientCalls,
+			Namespace: metricNamespace,
+			Help:      "Number of client calls made by a controller; reads served by the cache do not reach the apiserver",
+		},
+		apiClientCallsLabels,
+	)
+
+	managedClusterViews = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      ManagedClusterViews,
+			Namespace: metricNamespace,
+			Help:      "Number of ManagedClusterViews created by ramen on a managed cluster",
+		},
+		managedClusterViewsLabels,
+	)
+
+	managedClusterViewRefreshAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      ManagedClusterViewRefreshAgeSeconds,
+			Namespace: metricNamespace,
+			Help:      "Seconds the view of a managed cluster waiting the longest for a current result has been without one",
+		},
+		managedClusterViewsLabels,
+	)
+
+	managedClusterViewReads = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      ManagedClusterViewReads,
+			Namespace: metricNamespace,
+			Help:      "Number of reads of objects on a managed cluster through ManagedClusterViews, by result",
+		},
+		managedClusterViewReadsLabels,
+	)
+
+	drpcQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      DRPCQueueDepth,
+			Namespace: metricNamespace,
+			Help:      "Number of DRPCs of a tenant waiting to be reconciled; emitted only when fair queuing is enabled",
+		},
+		drpcQueueDepthLabels,
+	)
+
+	s3UploadPartRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      S3UploadPartRetries,
+			Namespace: metricNamespace,
+			Help:      "Number of retries of the parts of multipart uploads to an S3 store that failed to upload",
+		},
+		s3UploadPartsLabels,
+	)
+
+	s3UploadPartsResumed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      S3UploadPartsResumed,
+			Namespace: metricNamespace,
+			Help:      "Number of parts of resumed multipart uploads to an S3 store that were not uploaded again",
+		},
+		s3UploadPartsLabels,
+	)
+
+	s3ProfileAvailable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      S3ProfileAvailable,
+			Namespace: metricNamespace,
+			Help:      "Whether the S3 profile passed its last health probe (1) or not (0)",
+		},
+		s3ProfileHealthLabels,
+	)
+
+	s3ProfileProbeFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      S3ProfileProbeFailures,
+			Namespace: metricNamespace,
+			Help:      "Number of health probes of the S3 profile that failed",
+		},
+		s3ProfileHealthLabels,
+	)
+
+	s3OrphanedPrefixes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      S3OrphanedPrefixes,
+			Namespace: metricNamespace,
+			Help:      "Number of key prefixes of VRGs of deleted DRPCs left in the S3 profile, as of its last audit",
+		},
+		s3GarbageCollectionLabels,
+	)
+
+	s3OrphanedPrefixesDeleted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      S3OrphanedPrefixesDeleted,
+			Namespace: metricNamespace,
+			Help:      "Number of key prefixes of VRGs of deleted DRPCs deleted from the S3 profile",
+		},
+		s3GarbageCollectionLabels,
+	)
+
+	reconcileStageDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:      ReconcileStageDurationSeconds,
+			Namespace: metricNamespace,
+			Help:      "Duration of a stage of the reconciles of a controller in seconds",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		reconcileStageDurationLabels,
+	)
 )
 
 // lastSyncTime metrics reports value from lastGrpupSyncTime taken from DRPC status
@@ -260,6 +585,45 @@ func DeleteDRPolicySyncIntervalMetrics(labels prometheus.Labels) bool {
 	return dRPolicySyncInterval.Delete(labels)
 }
 
+// DRPolicy metrics report the workloads, peer classes, replication type and validation of a DRPolicy, as aggregated
+// periodically along with the workloads status of the policy
+func DRPolicyMetricLabels(drPolicy *rmn.DRPolicy) prometheus.Labels {
+	return prometheus.Labels{Policyname: drPolicy.Name}
+}
+
+func DRPolicyReplicationTypeLabels(drPolicy *rmn.DRPolicy, replicationType string) prometheus.Labels {
+	return prometheus.Labels{Policyname: drPolicy.Name, ReplicationTypeLabel: replicationType}
+}
+
+func NewDRPolicyBoundWorkloadsMetric(labels prometheus.Labels) prometheus.Gauge {
+	return drPolicyBoundWorkloads.With(labels)
+}
+
+func NewDRPolicyPeerClassesMetric(labels prometheus.Labels) prometheus.Gauge {
+	return drPolicyPeerClasses.With(labels)
+}
+
+func NewDRPolicyReplicationTypeMetric(labels prometheus.Labels) prometheus.Gauge {
+	return drPolicyReplicationType.With(labels)
+}
+
+func NewDRPolicyValidatedMetric(labels prometheus.Labels) prometheus.Gauge {
+	return drPolicyValidated.With(labels)
+}
+
+func NewDRPolicyLastSyncAgeMetric(labels prometheus.Labels) prometheus.Gauge {
+	return drPolicyLastSyncAge.With(labels)
+}
+
+// ResetDRPolicyMetrics drops the metrics of all policies, so that those of deleted policies are not reported
+func ResetDRPolicyMetrics() {
+	drPolicyBoundWorkloads.Reset()
+	drPolicyPeerClasses.Reset()
+	drPolicyReplicationType.Reset()
+	drPolicyValidated.Reset()
+	drPolicyLastSyncAge.Reset()
+}
+
 // lastSyncDuration Metrics reports value from lastGroupSyncDuration from DRPC status
 func SyncDurationMetricLabels(drPolicy *rmn.DRPolicy, drpc *rmn.DRPlacementControl) prometheus.Labels {
 	return prometheus.Labels{
@@ -395,9 +759,187 @@ func DeleteDRPCProgressionStateMetric(labels prometheus.Labels) bool {
 	return drpcProgressionState.Delete(labels)
 }
 
+func DRReadinessScoreLabels(drpc *rmn.DRPlacementControl) prometheus.Labels {
+	return prometheus.Labels{
+		ObjType:      "DRPlacementControl",
+		ObjName:      drpc.Name,
+		ObjNamespace: drpc.Namespace,
+	}
+}
+
+func NewDRReadinessScoreMetric(labels prometheus.Labels) DRReadinessScoreMetrics {
+	return DRReadinessScoreMetrics{
+		DRReadinessScore: drReadinessScore.With(labels),
+	}
+}
+
+func DeleteDRReadinessScoreMetric(labels prometheus.Labels) bool {
+	return drReadinessScore.Delete(labels)
+}
+
+func RPOViolatedLabels(drPolicy *rmn.DRPolicy, drpc *rmn.DRPlacementControl) prometheus.Labels {
+	return prometheus.Labels{
+		ObjType:      "DRPlacementControl",
+		ObjName:      drpc.Name,
+		ObjNamespace: drpc.Namespace,
+		Policyname:   drPolicy.Name,
+	}
+}
+
+func NewRPOViolatedMetric(labels prometheus.Labels) RPOViolatedMetrics {
+	return RPOViolatedMetrics{
+		RPOViolated: rpoViolated.With(labels),
+	}
+}
+
+func DeleteRPOViolatedMetric(labels prometheus.Labels) bool {
+	return rpoViolated.Delete(labels)
+}
+
+func ManagedClusterViewsPrunedLabels(reason string) prometheus.Labels {
+	return prometheus.Labels{
+		PruneReasonLabel: reason,
+	}
+}
+
+func NewManagedClusterViewsPrunedMetric(labels prometheus.Labels) prometheus.Counter {
+	return managedClusterViewsPruned.With(labels)
+}
+
+func ManagedClusterViewsRepairedLabels(key string) prometheus.Labels {
+	return prometheus.Labels{
+		KeyLabel: key,
+	}
+}
+
+func NewManagedClusterViewsRepairedMetric(labels prometheus.Labels) prometheus.Counter {
+	return managedClusterViewsRepaired.With(labels)
+}
+
+func APIClientCallsLabels(controller, verb, source string) prometheus.Labels {
+	return prometheus.Labels{
+		ControllerLabel: controller,
+		VerbLabel:       verb,
+		SourceLabel:     source,
+	}
+}
+
+func NewAPIClientCallsMetric(labels prometheus.Labels) prometheus.Counter {
+	return apiClientCalls.With(labels)
+}
+
+func ManagedClusterViewsLabels(cluster string) prometheus.Labels {
+	return prometheus.Labels{
+		ClusterLabel: cluster,
+	}
+}
+
+func NewManagedClusterViewsMetric(labels prometheus.Labels) prometheus.Gauge {
+	return managedClusterViews.With(labels)
+}
+
+func NewManagedClusterViewRefreshAgeMetric(labels prometheus.Labels) prometheus.Gauge {
+	return managedClusterViewRefreshAge.With(labels)
+}
+
+// ResetManagedClusterViewsMetrics drops the view metrics of all managed clusters, so that those of clusters that no
+// longer have views are not reported
+func ResetManagedClusterViewsMetrics() {
+	managedClusterViews.Reset()
+	managedClusterViewRefreshAge.Reset()
+}
+
+func ManagedClusterViewReadsLabels(cluster, result string) prometheus.Labels {
+	return prometheus.Labels{
+		ClusterLabel: cluster,
+		ResultLabel:  result,
+	}
+}
+
+func NewManagedClusterViewReadsMetric(labels prometheus.Labels) prometheus.Counter {
+	return managedClusterViewReads.With(labels)
+}
+
+func DRPCQueueDepthLabels(tenant string) prometheus.Labels {
+	return prometheus.Labels{
+		TenantLabel: tenant,
+	}
+}
+
+func NewDRPCQueueDepthMetric(labels prometheus.Labels) prometheus.Gauge {
+	return drpcQueueDepth.With(labels)
+}
+
+func DeleteDRPCQueueDepthMetric(labels prometheus.Labels) bool {
+	return drpcQueueDepth.Delete(labels)
+}
+
+func S3UploadPartsLabels(s3ProfileName string) prometheus.Labels {
+	return prometheus.Labels{
+		S3ProfileLabel: s3ProfileName,
+	}
+}
+
+func NewS3UploadPartRetriesMetric(labels prometheus.Labels) prometheus.Counter {
+	return s3UploadPartRetries.With(labels)
+}
+
+func NewS3UploadPartsResumedMetric(labels prometheus.Labels) prometheus.Counter {
+	return s3UploadPartsResumed.With(labels)
+}
+
+func S3ProfileHealthLabels(s3ProfileName string) prometheus.Labels {
+	return prometheus.Labels{
+		S3ProfileLabel: s3ProfileName,
+	}
+}
+
+func NewS3ProfileAvailableMetric(labels prometheus.Labels) prometheus.Gauge {
+	return s3ProfileAvailable.With(labels)
+}
+
+func NewS3ProfileProbeFailuresMetric(labels prometheus.Labels) prometheus.Counter {
+	return s3ProfileProbeFailures.With(labels)
+}
+
+func DeleteS3ProfileHealthMetrics(labels prometheus.Labels) {
+	s3ProfileAvailable.Delete(labels)
+	s3ProfileProbeFailures.Delete(labels)
+}
+
+func S3GarbageCollectionLabels(s3ProfileName string) prometheus.Labels {
+	return prometheus.Labels{
+		S3ProfileLabel: s3ProfileName,
+	}
+}
+
+func NewS3OrphanedPrefixesMetric(labels prometheus.Labels) prometheus.Gauge {
+	return s3OrphanedPrefixes.With(labels)
+}
+
+func NewS3OrphanedPrefixesDeletedMetric(labels prometheus.Labels) prometheus.Counter {
+	return s3OrphanedPrefixesDeleted.With(labels)
+}
+
+func ReconcileStageDurationLabels(controller, stage string) prometheus.Labels {
+	return prometheus.Labels{
+		ControllerLabel: controller,
+		StageLabel:      stage,
+	}
+}
+
+func NewReconcileStageDurationMetric(labels prometheus.Labels) prometheus.Observer {
+	return reconcileStageDuration.With(labels)
+}
+
 func init() {
 	// Register custom metrics with the global prometheus registry
 	metrics.Registry.MustRegister(dRPolicySyncInterval)
+	metrics.Registry.MustRegister(drPolicyBoundWorkloads)
+	metrics.Registry.MustRegister(drPolicyPeerClasses)
+	metrics.Registry.MustRegister(drPolicyReplicationType)
+	metrics.Registry.MustRegister(drPolicyValidated)
+	metrics.Registry.MustRegister(drPolicyLastSyncAge)
 	metrics.Registry.MustRegister(lastSyncTime)
 	metrics.Registry.MustRegister(lastSyncDuration)
 	metrics.Registry.MustRegister(lastSyncDataBytes)
@@ -406,4 +948,20 @@ func init() {
 	metrics.Registry.MustRegister(globalAction)
 	metrics.Registry.MustRegister(invalidCIDRsDetected)
 	metrics.Registry.MustRegister(drpcProgressionState)
+	metrics.Registry.MustRegister(drReadinessScore)
+	metrics.Registry.MustRegister(rpoViolated)
+	metrics.Registry.MustRegister(managedClusterViewsPruned)
+	metrics.Registry.MustRegister(managedClusterViewsRepaired)
+	metrics.Registry.MustRegister(apiClientCalls)
+	metrics.Registry.MustRegister(managedClusterViews)
+	metrics.Registry.MustRegister(managedClusterViewRefreshAge)
+	metrics.Registry.MustRegister(managedClusterViewReads)
+	metrics.Registry.MustRegister(drpcQueueDepth)
+	metrics.Registry.MustRegister(s3UploadPartRetries)
+	metrics.Registry.MustRegister(s3UploadPartsResumed)
+	metrics.Registry.MustRegister(s3ProfileAvailable)
+	metrics.Registry.MustRegister(s3ProfileProbeFailures)
+	metrics.Registry.MustRegister(s3OrphanedPrefixes)
+	metrics.Registry.MustRegister(s3OrphanedPrefixesDeleted)
+	metrics.Registry.MustRegister(reconcileStageDuration)
 }
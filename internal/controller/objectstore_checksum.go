@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// errObjectCorrupt is returned, wrapped, when an object downloaded does not match its checksum or cannot be decoded
+var errObjectCorrupt = metadata.ErrObjectCorrupt
+
+// checksummingObjectStore uploads the objects to an object store along with the hash of their json encoding, and
+// verifies it as they are downloaded, so that an object truncated or altered in the store is not restored. Objects
+// uploaded before the checksums were recorded are downloaded as is.
+type checksummingObjectStore struct {
+	ObjectStorer
+}
+
+func checksummingObjectStoreNew(objectStore ObjectStorer) ObjectStorer {
+	return &checksummingObjectStore{ObjectStorer: objectStore}
+}
+
+func (s *checksummingObjectStore) UploadObject(key string, object interface{}) error {
+	envelope, err := metadata.Checksum(object)
+	if err != nil {
+		return err
+	}
+
+	return s.ObjectStorer.UploadObject(key, envelope)
+}
+
+// DownloadObject downloads the object with key into objectPointer, or returns an errObjectCorrupt error if it does not
+// match its checksum
+func (s *checksummingObjectStore) DownloadObject(key string, objectPointer interface{}) error {
+	raw := json.RawMessage{}
+	if err := s.ObjectStorer.DownloadObject(key, &raw); err != nil {
+		return err
+	}
+
+	return checksummedObjectDecode(key, raw, objectPointer)
+}
+
+// ObjectVersionIDs returns the version IDs of the latest versions of the objects of the object store
+func (s *checksummingObjectStore) ObjectVersionIDs(keyPrefix string) (map[string]string, error) {
+	versioner, err := objectVersionerOf(s.ObjectStorer)
+	if err != nil {
+		return nil, err
+	}
+
+	return versioner.ObjectVersionIDs(keyPrefix)
+}
+
+// DownloadObjectVersion downloads the version with versionID of the object with key into objectPointer, verifying its
+// checksum like DownloadObject does
+func (s *checksummingObjectStore) DownloadObjectVersion(key, versionID string, objectPointer interface{}) error {
+	versioner, err := objectVersionerOf(s.ObjectStorer)
+	if err != nil {
+		return err
+	}
+
+	raw := json.RawMessage{}
+	if err := versioner.DownloadObjectVersion(key, versionID, &raw); err != nil {
+		return err
+	}
+
+	return checksummedObjectDecode(key, raw, objectPointer)
+}
+
+func checksummedObjectDecode(key string, raw json.RawMessage, objectPointer interface{}) error {
+	object, err := metadata.Unchecksummed(key, raw)
+	if err != nil {
+		return err
+	}
+
+	return metadata.Decode(object, objectPointer)
+}
+
+// isObjectCorrupt returns whether err is, or wraps, the error of an object that does not match its checksum or cannot
+// be decoded
+func isObjectCorrupt(err error) bool {
+	return errors.Is(err, errObjectCorrupt)
+}
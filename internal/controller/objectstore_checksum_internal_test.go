@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+var _ = Describe("Checksums of objects", func() {
+	const key = "ns/vrg/v1.PersistentVolume/pv1"
+
+	var (
+		plainStore  *versionedObjectStore
+		objectStore ObjectStorer
+		pv          corev1.PersistentVolume
+	)
+
+	BeforeEach(func() {
+		plainStore = &versionedObjectStore{versions: map[string][]json.RawMessage{}, deleted: map[string]bool{}}
+		objectStore = checksummingObjectStoreNew(plainStore)
+		pv = corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv1"}}
+	})
+
+	It("uploads the objects along with their checksum and verifies it on download", func() {
+		Expect(objectStore.UploadObject(key, pv)).To(Succeed())
+
+		envelope := metadata.ChecksummedObject{}
+		Expect(plainStore.DownloadObject(key, &envelope)).To(Succeed())
+		Expect(envelope.Format).To(Equal(metadata.ChecksummedObjectFormat))
+		Expect(envelope.SHA256).To(HaveLen(64))
+
+		downloaded := corev1.PersistentVolume{}
+		Expect(objectStore.DownloadObject(key, &downloaded)).To(Succeed())
+		Expect(downloaded.Name).To(Equal("pv1"))
+
+		versioned := corev1.PersistentVolume{}
+		Expect(objectStore.(objectVersioner).DownloadObjectVersion(key, "1", &versioned)).To(Succeed())
+		Expect(versioned.Name).To(Equal("pv1"))
+	})
+
+	It("fails the download of an object altered in the store as corrupt", func() {
+		Expect(objectStore.UploadObject(key, pv)).To(Succeed())
+
+		stored := plainStore.versions[key][0]
+		plainStore.versions[key][0] = bytes.Replace(stored, []byte(`"name":"pv1"`), []byte(`"name":"pv2"`), 1)
+
+		err := objectStore.DownloadObject(key, &corev1.PersistentVolume{})
+		Expect(err).To(HaveOccurred())
+		Expect(isObjectCorrupt(err)).To(BeTrue())
+
+		_, err = downloadPVs(objectStore, "ns/vrg/")
+		Expect(isObjectCorrupt(err)).To(BeTrue())
+	})
+
+	It("downloads the objects uploaded without a checksum as is", func() {
+		Expect(plainStore.UploadObject(key, pv)).To(Succeed())
+
+		downloaded := corev1.PersistentVolume{}
+		Expect(objectStore.DownloadObject(key, &downloaded)).To(Succeed())
+		Expect(downloaded.Name).To(Equal("pv1"))
+	})
+})
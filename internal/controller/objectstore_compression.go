@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"compress/gzip"
+	"fmt"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// objectCompression is the compression of the objects uploaded with an S3 profile, gzip with its default level for
+// the zero value
+type objectCompression = metadata.Compression
+
+// objectCompressionGet returns the compression of the objects uploaded with the S3 profile, or an error if it is not
+// supported
+func objectCompressionGet(s3StoreProfile ramen.S3StoreProfile) (objectCompression, error) {
+	compression := s3StoreProfile.Compression
+	if compression == nil {
+		return objectCompression{}, nil
+	}
+
+	switch compression.Algorithm {
+	case "", ramen.ObjectCompressionGzip, ramen.ObjectCompressionNone:
+	default:
+		return objectCompression{}, fmt.Errorf("unsupported compression algorithm %s of s3 profile %s, "+
+			"expected %s or %s", compression.Algorithm, s3StoreProfile.S3ProfileName, ramen.ObjectCompressionGzip,
+			ramen.ObjectCompressionNone)
+	}
+
+	if compression.Level < 0 || compression.Level > gzip.BestCompression {
+		return objectCompression{}, fmt.Errorf("compression level %d of s3 profile %s is not between 1 and %d",
+			compression.Level, s3StoreProfile.S3ProfileName, gzip.BestCompression)
+	}
+
+	return objectCompression{Algorithm: compression.Algorithm, Level: compression.Level}, nil
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("Compression of objects", func() {
+	const (
+		key       = "ns/vrg/v1.PersistentVolume/pv1"
+		gzipMagic = "\x1f\x8b"
+	)
+
+	var (
+		service *fakeBlobService
+		store   func(compression *ramen.ObjectCompression) *azureBlobObjectStore
+		pv      corev1.PersistentVolume
+	)
+
+	BeforeEach(func() {
+		service = &fakeBlobService{
+			accountName: "account",
+			accountKey:  []byte("key"),
+			container:   "container",
+			blobs:       map[string][]byte{},
+		}
+		server := httptest.NewServer(service)
+		DeferCleanup(server.Close)
+
+		store = func(compression *ramen.ObjectCompression) *azureBlobObjectStore {
+			objectCompression, err := objectCompressionGet(ramen.S3StoreProfile{Compression: compression})
+			Expect(err).ToNot(HaveOccurred())
+
+			return &azureBlobObjectStore{
+				client:      server.Client(),
+				endpoint:    server.URL,
+				container:   "container",
+				accountName: "account",
+				accountKey:  []byte("key"),
+				compression: objectCompression,
+			}
+		}
+		pv = corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{
+			Name:        "pv1",
+			Annotations: map[string]string{"data": string(bytes.Repeat([]byte("compressible "), 1000))},
+		}}
+	})
+
+	It("compresses the uploaded objects with gzip unless disabled", func() {
+		encoded, err := json.Marshal(pv)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(store(nil).UploadObject(key, pv)).To(Succeed())
+		Expect(service.blobs[key]).To(HavePrefix(gzipMagic))
+		Expect(len(service.blobs[key])).To(BeNumerically("<", len(encoded)/10))
+
+		Expect(store(&ramen.ObjectCompression{Level: 9}).UploadObject(key, pv)).To(Succeed())
+		Expect(service.blobs[key]).To(HavePrefix(gzipMagic))
+
+		Expect(store(&ramen.ObjectCompression{Algorithm: ramen.ObjectCompressionNone}).UploadObject(key, pv)).To(
+			Succeed())
+		Expect(json.Valid(service.blobs[key])).To(BeTrue())
+	})
+
+	It("downloads the objects whether they are compressed or not", func() {
+		for _, compression := range []*ramen.ObjectCompression{nil, {Algorithm: ramen.ObjectCompressionNone}} {
+			Expect(store(compression).UploadObject(key, pv)).To(Succeed())
+
+			for _, downloader := range []*ramen.ObjectCompression{nil, {Algorithm: ramen.ObjectCompressionNone}} {
+				downloaded := corev1.PersistentVolume{}
+				Expect(store(downloader).DownloadObject(key, &downloaded)).To(Succeed())
+				Expect(downloaded.Annotations).To(Equal(pv.Annotations))
+			}
+		}
+	})
+
+	It("compresses the encrypted objects before encrypting them", func() {
+		objectStore := &encryptingObjectStore{ObjectStorer: store(nil), keyEncrypter: identityKeyEncrypter{}}
+		Expect(objectStore.UploadObject(key, pv)).To(Succeed())
+
+		envelope := encryptedObject{}
+		Expect(store(nil).DownloadObject(key, &envelope)).To(Succeed())
+		Expect(envelope.ContentEncoding).To(Equal("gzip"))
+		Expect(len(envelope.Ciphertext)).To(BeNumerically("<", len(pv.Annotations["data"])/10))
+
+		downloaded := corev1.PersistentVolume{}
+		Expect(objectStore.DownloadObject(key, &downloaded)).To(Succeed())
+		Expect(downloaded.Annotations).To(Equal(pv.Annotations))
+	})
+
+	It("rejects unsupported algorithms and levels", func() {
+		_, err := objectCompressionGet(ramen.S3StoreProfile{
+			S3ProfileName: "profile",
+			Compression:   &ramen.ObjectCompression{Algorithm: "Zstd"},
+		})
+		Expect(err).To(MatchError("unsupported compression algorithm Zstd of s3 profile profile, expected Gzip or None"))
+
+		_, err = objectCompressionGet(ramen.S3StoreProfile{
+			S3ProfileName: "profile",
+			Compression:   &ramen.ObjectCompression{Level: 10},
+		})
+		Expect(err).To(MatchError(ContainSubstring("compression level 10 of s3 profile profile is not between 1 and 9")))
+	})
+})
+
+// identityKeyEncrypter wraps data keys as they are
+type identityKeyEncrypter struct{}
+
+func (identityKeyEncrypter) WrapKey(dataKey []byte) ([]byte, error)   { return dataKey, nil }
+func (identityKeyEncrypter) UnwrapKey(wrapped []byte) ([]byte, error) { return wrapped, nil }
@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// s3ErrCodeConditionalRequestConflict is returned by S3 when a conditional upload races with another upload of the
+// same object
+const s3ErrCodeConditionalRequestConflict = "ConditionalRequestConflict"
+
+var (
+	errConditionalWritesUnsupported = errors.New("object store does not support conditional writes")
+
+	// errObjectPreconditionFailed is returned, wrapped, when an object is not uploaded because it changed since it
+	// was downloaded
+	errObjectPreconditionFailed = errors.New("object changed since it was downloaded")
+)
+
+// conditionalObjectWriter is implemented by the object stores that upload an object only if it did not change
+// since it was downloaded, so that writers racing to update the same object, like the hubs of a hub recovery, do
+// not lose each other's updates
+type conditionalObjectWriter interface {
+	// DownloadObjectWithETag downloads the object with key into objectPointer and returns its entity tag, or returns
+	// an empty entity tag, leaving objectPointer as is, if there is no object with key
+	DownloadObjectWithETag(key string, objectPointer interface{}) (string, error)
+
+	// UploadObjectIfMatch uploads object with key if the entity tag of the object with key is etag, or, if etag is
+	// empty, if there is no object with key. Returns an errObjectPreconditionFailed error otherwise.
+	UploadObjectIfMatch(key string, object interface{}, etag string) error
+}
+
+func conditionalObjectWriterOf(objectStore ObjectStorer) (conditionalObjectWriter, error) {
+	writer, ok := objectStore.(conditionalObjectWriter)
+	if !ok {
+		return nil, errConditionalWritesUnsupported
+	}
+
+	return writer, nil
+}
+
+// isObjectPreconditionFailed returns whether err is, or wraps, the error of an object not uploaded because it
+// changed since it was downloaded
+func isObjectPreconditionFailed(err error) bool {
+	return errors.Is(err, errObjectPreconditionFailed)
+}
+
+// DownloadObjectWithETag downloads the object with key, like DownloadObject does, along with its entity tag
+func (s *s3ObjectStore) DownloadObjectWithETag(key string, objectPointer interface{}) (string, error) {
+	bucket := s.s3Bucket
+
+	ctx, cancel := context.WithDeadline(context.TODO(), time.Now().Add(s3Timeout))
+	defer cancel()
+
+	output, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{Bucket: &bucket, Key: &key})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return "", nil
+		}
+
+		return "", processAwsError(fmt.Errorf("failed to download data of %s:%s", bucket, key), err)
+	}
+
+	defer output.Body.Close()
+
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read data of %s:%s, %w", bucket, key, err)
+	}
+
+	if err := metadata.Decode(data, objectPointer); err != nil {
+		return "", fmt.Errorf("failed to decode data of %s:%s, %w", bucket, key, err)
+	}
+
+	return aws.StringValue(output.ETag), nil
+}
+
+// UploadObjectIfMatch uploads object with key, like UploadObject does, with an If-Match precondition on etag, or an
+// If-None-Match one if etag is empty. The object is uploaded in a single part, as S3 evaluates the precondition of
+// a multipart upload only as it completes.
+func (s *s3ObjectStore) UploadObjectIfMatch(key string, object interface{}, etag string) error {
+	bucket := s.s3Bucket
+
+	encoded, err := s.compression.Encode(object)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s:%s, %w", bucket, key, err)
+	}
+
+	input := &s3.PutObjectInput{Bucket: &bucket, Key: &key, Body: bytes.NewReader(encoded)}
+	if contentEncoding := s.compression.ContentEncoding(); contentEncoding != "" {
+		input.ContentEncoding = aws.String(contentEncoding)
+	}
+
+	precondition := func(r *request.Request) {
+		if etag == "" {
+			r.HTTPRequest.Header.Set("If-None-Match", "*")
+		} else {
+			r.HTTPRequest.Header.Set("If-Match", etag)
+		}
+	}
+
+	ctx, cancel := context.WithDeadline(context.TODO(), time.Now().Add(s3Timeout))
+	defer cancel()
+
+	if _, err := s.client.PutObjectWithContext(ctx, input, precondition); err != nil {
+		if s3PreconditionFailed(err) {
+			return fmt.Errorf("failed to upload data of %s:%s, %w", bucket, key, errObjectPreconditionFailed)
+		}
+
+		return processAwsError(fmt.Errorf("failed to upload data of %s:%s", bucket, key), err)
+	}
+
+	return nil
+}
+
+func s3PreconditionFailed(err error) bool {
+	var requestFailure awserr.RequestFailure
+	if errors.As(err, &requestFailure) {
+		if requestFailure.StatusCode() == http.StatusPreconditionFailed {
+			return true
+		}
+
+		return requestFailure.Code() == s3ErrCodeConditionalRequestConflict
+	}
+
+	return false
+}
+
+// DownloadObjectWithETag downloads the object with key, verifying its checksum like DownloadObject does, along with
+// its entity tag
+func (s *checksummingObjectStore) DownloadObjectWithETag(key string, objectPointer interface{}) (string, error) {
+	writer, err := conditionalObjectWriterOf(s.ObjectStorer)
+	if err != nil {
+		return "", err
+	}
+
+	raw := json.RawMessage{}
+
+	etag, err := writer.DownloadObjectWithETag(key, &raw)
+	if err != nil || etag == "" {
+		return etag, err
+	}
+
+	return etag, checksummedObjectDecode(key, raw, objectPointer)
+}
+
+func (s *checksummingObjectStore) UploadObjectIfMatch(key string, object interface{}, etag string) error {
+	writer, err := conditionalObjectWriterOf(s.ObjectStorer)
+	if err != nil {
+		return err
+	}
+
+	envelope, err := metadata.Checksum(object)
+	if err != nil {
+		return err
+	}
+
+	return writer.UploadObjectIfMatch(key, envelope, etag)
+}
+
+// DownloadObjectWithETag downloads the object with key, decrypting it like DownloadObject does, along with its
+// entity tag
+func (s *encryptingObjectStore) DownloadObjectWithETag(key string, objectPointer interface{}) (string, error) {
+	writer, err := conditionalObjectWriterOf(s.ObjectStorer)
+	if err != nil {
+		return "", err
+	}
+
+	raw := json.RawMessage{}
+
+	etag, err := writer.DownloadObjectWithETag(key, &raw)
+	if err != nil || etag == "" {
+		return etag, err
+	}
+
+	return etag, s.decrypt(key, raw, objectPointer)
+}
+
+func (s *encryptingObjectStore) UploadObjectIfMatch(key string, object interface{}, etag string) error {
+	writer, err := conditionalObjectWriterOf(s.ObjectStorer)
+	if err != nil {
+		return err
+	}
+
+	envelope, err := s.encrypt(key, object)
+	if err != nil {
+		return err
+	}
+
+	return writer.UploadObjectIfMatch(key, envelope, etag)
+}
+
+// DownloadObjectWithETag downloads the object with key under the key prefix along with its entity tag. Legacy
+// objects are not migrated here, but as they are downloaded.
+func (s *prefixedObjectStore) DownloadObjectWithETag(key string, objectPointer interface{}) (string, error) {
+	writer, err := conditionalObjectWriterOf(s.ObjectStorer)
+	if err != nil {
+		return "", err
+	}
+
+	return writer.DownloadObjectWithETag(s.keyPrefix+key, objectPointer)
+}
+
+func (s *prefixedObjectStore) UploadObjectIfMatch(key string, object interface{}, etag string) error {
+	writer, err := conditionalObjectWriterOf(s.ObjectStorer)
+	if err != nil {
+		return err
+	}
+
+	return writer.UploadObjectIfMatch(s.keyPrefix+key, object, etag)
+}
+
+// objectUpdate uploads the object returned by update, given the object with key, downloaded into objectPointer if
+// there is one, unless the object changed since it was downloaded. update returns nil to leave the object as is.
+// Returns an errObjectPreconditionFailed error if the object changed, for the caller to retry with the change, or an
+// errConditionalWritesUnsupported one if the object store cannot upload an object conditionally.
+func objectUpdate(objectStore ObjectStorer, key string, objectPointer interface{},
+	update func(exists bool) interface{},
+) error {
+	writer, err := conditionalObjectWriterOf(objectStore)
+	if err != nil {
+		return err
+	}
+
+	etag, err := writer.DownloadObjectWithETag(key, objectPointer)
+	if err != nil {
+		return err
+	}
+
+	object := update(etag != "")
+	if object == nil {
+		return nil
+	}
+
+	return writer.UploadObjectIfMatch(key, object, etag)
+}
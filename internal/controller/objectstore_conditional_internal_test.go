@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// etagObjectStore tags its objects with the number of times they were uploaded, like an object store with
+// conditional writes tags them with a hash of their content
+type etagObjectStore struct {
+	ObjectStorer
+	objects map[string]json.RawMessage
+	uploads map[string]int
+}
+
+func etagObjectStoreNew() *etagObjectStore {
+	return &etagObjectStore{objects: map[string]json.RawMessage{}, uploads: map[string]int{}}
+}
+
+func (s *etagObjectStore) UploadObject(key string, object interface{}) error {
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	s.objects[key] = encoded
+	s.uploads[key]++
+
+	return nil
+}
+
+func (s *etagObjectStore) DownloadObject(key string, objectPointer interface{}) error {
+	object, ok := s.objects[key]
+	if !ok {
+		return fmt.Errorf("no such key %s", key)
+	}
+
+	return json.Unmarshal(object, objectPointer)
+}
+
+func (s *etagObjectStore) ListKeys(keyPrefix string) ([]string, error) {
+	keys := []string{}
+
+	for key := range s.objects {
+		if strings.HasPrefix(key, keyPrefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+func (s *etagObjectStore) DownloadObjectWithETag(key string, objectPointer interface{}) (string, error) {
+	object, ok := s.objects[key]
+	if !ok {
+		return "", nil
+	}
+
+	return strconv.Itoa(s.uploads[key]), json.Unmarshal(object, objectPointer)
+}
+
+func (s *etagObjectStore) UploadObjectIfMatch(key string, object interface{}, etag string) error {
+	stored := ""
+	if _, ok := s.objects[key]; ok {
+		stored = strconv.Itoa(s.uploads[key])
+	}
+
+	if etag != stored {
+		return fmt.Errorf("failed to upload %s, %w", key, errObjectPreconditionFailed)
+	}
+
+	return s.UploadObject(key, object)
+}
+
+var _ = Describe("Object store conditional writes", func() {
+	const key = "key"
+
+	It("updates an object unless it changed since it was downloaded", func() {
+		objectStore := etagObjectStoreNew()
+		object := ""
+
+		Expect(objectUpdate(objectStore, key, &object, func(exists bool) interface{} {
+			Expect(exists).To(BeFalse())
+
+			return "created"
+		})).To(Succeed())
+
+		Expect(objectUpdate(objectStore, key, &object, func(exists bool) interface{} {
+			Expect(exists).To(BeTrue())
+			Expect(object).To(Equal("created"))
+			Expect(objectStore.UploadObject(key, "raced")).To(Succeed())
+
+			return "updated"
+		})).To(Satisfy(isObjectPreconditionFailed))
+
+		Expect(objectStore.DownloadObject(key, &object)).To(Succeed())
+		Expect(object).To(Equal("raced"))
+	})
+
+	It("checksums the objects written conditionally", func() {
+		objectStore := etagObjectStoreNew()
+		checksummingStore := checksummingObjectStoreNew(objectStore)
+		object := ""
+
+		Expect(objectUpdate(checksummingStore, key, &object, func(bool) interface{} { return "created" })).To(Succeed())
+		Expect(string(objectStore.objects[key])).To(ContainSubstring(metadata.ChecksummedObjectFormat))
+		Expect(checksummingStore.DownloadObject(key, &object)).To(Succeed())
+		Expect(object).To(Equal("created"))
+	})
+
+	It("does not overwrite a more recent hub heartbeat", func() {
+		objectStore := etagObjectStoreNew()
+		now := time.Now()
+		heartbeat := func(at time.Time) LocalFailoverPlan {
+			return LocalFailoverPlan{FailoverCluster: "cluster", HubHeartbeat: metav1.NewTime(at)}
+		}
+
+		Expect(localFailoverHeartbeatUpload(objectStore, "ns", "drpc", heartbeat(now))).To(Succeed())
+		Expect(localFailoverHeartbeatUpload(objectStore, "ns", "drpc", heartbeat(now.Add(-time.Minute)))).To(Succeed())
+
+		plan := LocalFailoverPlan{}
+		Expect(localFailoverPlanDownload(objectStore, s3PathNamePrefix("ns", "drpc"), &plan)).To(Succeed())
+		Expect(plan.HubHeartbeat.Time).To(BeTemporally("~", now, time.Second))
+	})
+
+	It("records an orphaned prefix once", func() {
+		objectStore := etagObjectStoreNew()
+		now := time.Now()
+
+		record := func(orphanedTime time.Time) s3OrphanedPrefix {
+			return s3OrphanedPrefix{HubID: "hub", DRPCUID: "uid", OrphanedTime: metav1.NewTime(orphanedTime)}
+		}
+
+		Expect(s3OrphanedPrefixRecordIfAbsent(objectStore, "ns/vrg/", record(now))).To(Succeed())
+		Expect(s3OrphanedPrefixRecordIfAbsent(objectStore, "ns/vrg/", record(now.Add(time.Hour)))).To(Succeed())
+		Expect(objectStore.uploads[s3OrphanedPrefixKey("ns/vrg/")]).To(Equal(1))
+	})
+})
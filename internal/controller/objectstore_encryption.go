@@ -0,0 +1,349 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// EncryptionKeyProviderSecret reads the key encryption key from the secret of the profile
+const EncryptionKeyProviderSecret = "Secret"
+
+var errObjectNotEncrypted = errors.New("object is not encrypted")
+
+// KeyEncrypter wraps and unwraps the data keys of the encrypted objects with a key encryption key
+type KeyEncrypter interface {
+	WrapKey(dataKey []byte) ([]byte, error)
+	UnwrapKey(wrappedKey []byte) ([]byte, error)
+}
+
+// EncryptionKeyProvider returns the key encrypter of the client-side encryption of profile, reading the secret of
+// the profile with r
+type EncryptionKeyProvider func(ctx context.Context, r client.Reader, profile ramen.S3StoreProfile,
+) (KeyEncrypter, error)
+
+var (
+	encryptionKeyProvidersMutex sync.RWMutex
+	encryptionKeyProviders      = map[string]EncryptionKeyProvider{}
+)
+
+func init() {
+	RegisterEncryptionKeyProvider(EncryptionKeyProviderSecret, secretKeyEncrypter)
+}
+
+// RegisterEncryptionKeyProvider registers the key provider named name, like an external KMS, typically from the init
+// function of the package implementing it. It panics if a key provider is already registered with name.
+func RegisterEncryptionKeyProvider(name string, provider EncryptionKeyProvider) {
+	encryptionKeyProvidersMutex.Lock()
+	defer encryptionKeyProvidersMutex.Unlock()
+
+	if _, ok := encryptionKeyProviders[name]; ok {
+		panic(fmt.Sprintf("encryption key provider %s registered twice", name))
+	}
+
+	encryptionKeyProviders[name] = provider
+}
+
+// EncryptionKeyProviderGet returns the key provider registered with name, Secret if empty, and whether there is one
+func EncryptionKeyProviderGet(name string) (EncryptionKeyProvider, bool) {
+	if name == "" {
+		name = EncryptionKeyProviderSecret
+	}
+
+	encryptionKeyProvidersMutex.RLock()
+	defer encryptionKeyProvidersMutex.RUnlock()
+
+	provider, ok := encryptionKeyProviders[name]
+
+	return provider, ok
+}
+
+// secretKeyEncrypter returns a key encrypter with the base64 encoded key stored in the key secret of profile
+func secretKeyEncrypter(ctx context.Context, r client.Reader, profile ramen.S3StoreProfile,
+) (KeyEncrypter, error) {
+	namespacedName, ok := encryptionKeySecretNamespacedName(profile)
+	if !ok {
+		return nil, fmt.Errorf("s3 profile %s has no keySecretRef", profile.S3ProfileName)
+	}
+
+	if s3ProfileHasSecret(profile) && namespacedName == s3SecretNamespacedName(profile) {
+		return nil, fmt.Errorf("keySecretRef of s3 profile %s references the secret of its credentials",
+			profile.S3ProfileName)
+	}
+
+	secret := corev1.Secret{}
+
+	if err := r.Get(ctx, namespacedName, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %v, %w", namespacedName, err)
+	}
+
+	encodedKey, ok := secret.Data[util.EncryptionKeyKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %v has no %s", namespacedName, util.EncryptionKeyKey)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(string(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s of secret %v, %w", util.EncryptionKeyKey, namespacedName, err)
+	}
+
+	if len(key) != metadata.DataKeySize {
+		return nil, fmt.Errorf("%s of secret %v is %d bytes long instead of %d",
+			util.EncryptionKeyKey, namespacedName, len(key), metadata.DataKeySize)
+	}
+
+	return metadata.AESKeyEncrypter(key), nil
+}
+
+// encryptionKeySecretNamespacedName returns the namespaced name of the secret with the key encryption key of the
+// Secret key provider of profile, in the operator namespace if the profile does not set it, and whether it has one
+func encryptionKeySecretNamespacedName(profile ramen.S3StoreProfile) (types.NamespacedName, bool) {
+	encryption := profile.ClientSideEncryption
+	if encryption == nil || encryption.KeySecretRef == nil ||
+		encryption.KeyProvider != "" && encryption.KeyProvider != EncryptionKeyProviderSecret {
+		return types.NamespacedName{}, false
+	}
+
+	namespacedName := types.NamespacedName{
+		Namespace: encryption.KeySecretRef.Namespace,
+		Name:      encryption.KeySecretRef.Name,
+	}
+	if namespacedName.Namespace == "" {
+		namespacedName.Namespace = RamenOperatorNamespace()
+	}
+
+	return namespacedName, true
+}
+
+// s3ProfileEncryptionValidate returns an error if the client-side encryption of the S3 profile named s3ProfileName
+// cannot protect all the objects uploaded with it, as the kube objects captured by Velero cannot be encrypted by
+// the operators, or if the key encryption key of the Secret key provider is not in a secret of its own in a
+// permitted namespace
+func s3ProfileEncryptionValidate(ramenConfig *ramen.RamenConfig, s3ProfileName string) error {
+	profile := RamenConfigS3StoreProfilePointerGet(ramenConfig, s3ProfileName)
+	if profile == nil || profile.ClientSideEncryption == nil {
+		return nil
+	}
+
+	if !ramenConfig.KubeObjectProtection.Disabled {
+		return fmt.Errorf("s3 profile %s has clientSideEncryption, which the kube objects captured by Velero "+
+			"cannot be protected with, while kubeObjectProtection is enabled", s3ProfileName)
+	}
+
+	provider := profile.ClientSideEncryption.KeyProvider
+	if provider != "" && provider != EncryptionKeyProviderSecret {
+		return nil
+	}
+
+	namespacedName, ok := encryptionKeySecretNamespacedName(*profile)
+	if !ok {
+		return fmt.Errorf("s3 profile %s has no keySecretRef", s3ProfileName)
+	}
+
+	if s3ProfileHasSecret(*profile) && namespacedName == s3SecretNamespacedName(*profile) {
+		return fmt.Errorf("keySecretRef of s3 profile %s references the secret of its credentials", s3ProfileName)
+	}
+
+	if !s3SecretNamespaceAllowed(ramenConfig, namespacedName.Namespace) {
+		return fmt.Errorf("keySecretRef namespace %s of s3 profile %s is neither the operator namespace nor one "+
+			"of the s3 secret namespaces", namespacedName.Namespace, s3ProfileName)
+	}
+
+	return nil
+}
+
+// kubeObjectsUnencryptedStore returns the name of the first of the S3 profiles that encrypts the objects client-side,
+// which the kube objects captured by Velero would not be, or an empty string if none does
+func kubeObjectsUnencryptedStore(s3StoreAccessors []s3StoreAccessor) string {
+	for _, s3StoreAccessor := range s3StoreAccessors {
+		if s3StoreAccessor.ClientSideEncryption != nil {
+			return s3StoreAccessor.S3ProfileName
+		}
+	}
+
+	return ""
+}
+
+// encryptedObject is the envelope of an object encrypted with a data key of its own
+type encryptedObject = metadata.EncryptedObject
+
+// encryptingObjectStore encrypts the objects uploaded to and decrypts the objects downloaded from an object store.
+// Objects that are not encrypted are rejected, unless allowUnencrypted, in which case they are downloaded as is, so
+// that the objects uploaded before the encryption was enabled remain readable.
+type encryptingObjectStore struct {
+	ObjectStorer
+	keyEncrypter     KeyEncrypter
+	keyProvider      string
+	keyID            string
+	compression      objectCompression
+	allowUnencrypted bool
+}
+
+// encryptingObjectStoreNew returns objectStore encrypting the objects with the key encrypter of the client-side
+// encryption of profile, or objectStore if the profile has none
+func encryptingObjectStoreNew(ctx context.Context, r client.Reader, objectStore ObjectStorer,
+	profile ramen.S3StoreProfile,
+) (ObjectStorer, error) {
+	encryption := profile.ClientSideEncryption
+	if encryption == nil {
+		return objectStore, nil
+	}
+
+	provider, ok := EncryptionKeyProviderGet(encryption.KeyProvider)
+	if !ok {
+		return nil, fmt.Errorf("unsupported encryption key provider %s", encryption.KeyProvider)
+	}
+
+	keyEncrypter, err := provider(ctx, r, profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encryption key, %w", err)
+	}
+
+	compression, err := objectCompressionGet(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptingObjectStore{
+		ObjectStorer:     objectStore,
+		keyEncrypter:     keyEncrypter,
+		keyProvider:      encryption.KeyProvider,
+		keyID:            encryption.KeyID,
+		compression:      compression,
+		allowUnencrypted: encryption.AllowUnencryptedObjects,
+	}, nil
+}
+
+// UploadObject encrypts the compressed json encoding of object with a new data key, authenticated along with key so
+// that the object cannot be swapped with another one, and uploads it with the data key wrapped by the key encryption
+// key
+func (s *encryptingObjectStore) UploadObject(key string, object interface{}) error {
+	envelope, err := s.encrypt(key, object)
+	if err != nil {
+		return err
+	}
+
+	return s.ObjectStorer.UploadObject(key, envelope)
+}
+
+// encrypt returns the envelope of the object with key that UploadObject uploads
+func (s *encryptingObjectStore) encrypt(key string, object interface{}) (encryptedObject, error) {
+	plaintext, err := s.compression.Encode(object)
+	if err != nil {
+		return encryptedObject{}, fmt.Errorf("failed to encode object %s, %w", key, err)
+	}
+
+	envelope, err := metadata.Encrypt(key, plaintext, s.keyEncrypter)
+	if err != nil {
+		return envelope, err
+	}
+
+	envelope.KeyProvider = s.keyProvider
+	envelope.KeyID = s.keyID
+	envelope.ContentEncoding = s.compression.ContentEncoding()
+
+	return envelope, nil
+}
+
+// DownloadObject downloads the object with key, decrypting it if it is encrypted, into objectPointer
+func (s *encryptingObjectStore) DownloadObject(key string, objectPointer interface{}) error {
+	raw := json.RawMessage{}
+	if err := s.ObjectStorer.DownloadObject(key, &raw); err != nil {
+		return err
+	}
+
+	return s.decrypt(key, raw, objectPointer)
+}
+
+// ObjectVersionIDs returns the version IDs of the latest versions of the objects of the object store
+func (s *encryptingObjectStore) ObjectVersionIDs(keyPrefix string) (map[string]string, error) {
+	versioner, err := objectVersionerOf(s.ObjectStorer)
+	if err != nil {
+		return nil, err
+	}
+
+	return versioner.ObjectVersionIDs(keyPrefix)
+}
+
+// DownloadObjectVersion downloads the version with versionID of the object with key, decrypting it if it is
+// encrypted, into objectPointer
+func (s *encryptingObjectStore) DownloadObjectVersion(key, versionID string, objectPointer interface{}) error {
+	versioner, err := objectVersionerOf(s.ObjectStorer)
+	if err != nil {
+		return err
+	}
+
+	raw := json.RawMessage{}
+	if err := versioner.DownloadObjectVersion(key, versionID, &raw); err != nil {
+		return err
+	}
+
+	return s.decrypt(key, raw, objectPointer)
+}
+
+// decrypt decodes the object with key downloaded as raw, decrypting it if it is encrypted, into objectPointer. An
+// object that is not encrypted is decoded as is only if unencrypted objects are allowed.
+func (s *encryptingObjectStore) decrypt(key string, raw json.RawMessage, objectPointer interface{}) error {
+	envelope, ok := metadata.EncryptedObjectOf(raw)
+	if !ok {
+		if !s.allowUnencrypted {
+			return fmt.Errorf("%w: %s", errObjectNotEncrypted, key)
+		}
+
+		return json.Unmarshal(raw, objectPointer)
+	}
+
+	plaintext, err := metadata.Decrypt(key, envelope, s.keyEncrypter)
+	if err != nil {
+		return err
+	}
+
+	return metadata.Decode(plaintext, objectPointer)
+}
+
+// objectStoreEncryptionCheck checks that the key encrypter of objectStore, if it encrypts the objects, can wrap and
+// unwrap a data key, so that a key encryption key that is unusable, or a key provider that is unreachable, is
+// detected before objects are uploaded with it
+func objectStoreEncryptionCheck(objectStore ObjectStorer) error {
+	encryptingStore, ok := objectStore.(*encryptingObjectStore)
+	if !ok {
+		return nil
+	}
+
+	dataKey := make([]byte, metadata.DataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return err
+	}
+
+	wrappedKey, err := encryptingStore.keyEncrypter.WrapKey(dataKey)
+	if err != nil {
+		return fmt.Errorf("failed to wrap data key, %w", err)
+	}
+
+	unwrappedKey, err := encryptingStore.keyEncrypter.UnwrapKey(wrappedKey)
+	if err != nil {
+		return fmt.Errorf("failed to unwrap data key, %w", err)
+	}
+
+	if !bytes.Equal(dataKey, unwrappedKey) {
+		return fmt.Errorf("unwrapped data key differs from the wrapped one")
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+var _ = Describe("Client-side encryption of objects", func() {
+	const key = "ns/vrg/v1.PersistentVolume/pv1"
+
+	var (
+		service    *fakeBlobService
+		plainStore ObjectStorer
+		profile    ramen.S3StoreProfile
+		secret     *corev1.Secret
+	)
+
+	encryptingStore := func() ObjectStorer {
+		reader := fake.NewClientBuilder().WithObjects(secret).Build()
+
+		objectStore, err := encryptingObjectStoreNew(context.TODO(), reader, plainStore, profile)
+		Expect(err).ToNot(HaveOccurred())
+
+		return objectStore
+	}
+
+	BeforeEach(func() {
+		service = &fakeBlobService{
+			accountName: "account",
+			accountKey:  []byte("key"),
+			container:   "container",
+			blobs:       map[string][]byte{},
+		}
+		server := httptest.NewServer(service)
+		DeferCleanup(server.Close)
+
+		plainStore = &azureBlobObjectStore{
+			client:      server.Client(),
+			endpoint:    server.URL,
+			container:   "container",
+			accountName: "account",
+			accountKey:  []byte("key"),
+		}
+		profile = ramen.S3StoreProfile{
+			S3ProfileName: "profile",
+			S3SecretRef:   corev1.SecretReference{Name: "secret", Namespace: "ramen-system"},
+			ClientSideEncryption: &ramen.ClientSideEncryption{
+				KeyID:        "key1",
+				KeySecretRef: &corev1.SecretReference{Name: "key-secret", Namespace: "ramen-system"},
+			},
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "key-secret", Namespace: "ramen-system"},
+			Data: map[string][]byte{
+				util.EncryptionKeyKey: []byte(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))),
+			},
+		}
+	})
+
+	It("encrypts the uploaded objects and decrypts them on download", func() {
+		objectStore := encryptingStore()
+		Expect(objectStoreEncryptionCheck(objectStore)).To(Succeed())
+
+		pv := corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "secret-pv-name"}}
+		Expect(objectStore.UploadObject(key, pv)).To(Succeed())
+
+		envelope := encryptedObject{}
+		Expect(plainStore.DownloadObject(key, &envelope)).To(Succeed())
+		Expect(envelope.Format).To(Equal(metadata.EncryptedObjectFormat))
+		Expect(envelope.KeyID).To(Equal("key1"))
+		Expect(string(envelope.Ciphertext)).ToNot(ContainSubstring("secret-pv-name"))
+
+		pv = corev1.PersistentVolume{}
+		Expect(objectStore.DownloadObject(key, &pv)).To(Succeed())
+		Expect(pv.Name).To(Equal("secret-pv-name"))
+	})
+
+	It("rejects the objects that are not encrypted", func() {
+		Expect(plainStore.UploadObject(key, corev1.PersistentVolume{})).To(Succeed())
+
+		Expect(encryptingStore().DownloadObject(key, &corev1.PersistentVolume{})).To(
+			MatchError(errObjectNotEncrypted))
+	})
+
+	It("downloads the objects uploaded before the encryption was enabled, if allowed", func() {
+		profile.ClientSideEncryption.AllowUnencryptedObjects = true
+
+		pv := corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv1"}}
+		Expect(plainStore.UploadObject(key, pv)).To(Succeed())
+
+		pv = corev1.PersistentVolume{}
+		Expect(encryptingStore().DownloadObject(key, &pv)).To(Succeed())
+		Expect(pv.Name).To(Equal("pv1"))
+	})
+
+	It("fails to decrypt objects with another key or under another key", func() {
+		Expect(encryptingStore().UploadObject(key, corev1.PersistentVolume{})).To(Succeed())
+
+		service.blobs["ns/vrg/v1.PersistentVolume/pv2"] = service.blobs[key]
+		Expect(encryptingStore().DownloadObject("ns/vrg/v1.PersistentVolume/pv2", &corev1.PersistentVolume{})).To(
+			MatchError(ContainSubstring("failed to decrypt object")))
+
+		secret.Data[util.EncryptionKeyKey] = []byte(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("o", 32))))
+		Expect(encryptingStore().DownloadObject(key, &corev1.PersistentVolume{})).To(
+			MatchError(ContainSubstring("failed to unwrap data key")))
+	})
+
+	It("rejects profiles without a usable key", func() {
+		secret.Data[util.EncryptionKeyKey] = []byte(base64.StdEncoding.EncodeToString([]byte("short")))
+		reader := fake.NewClientBuilder().WithObjects(secret).Build()
+		_, err := encryptingObjectStoreNew(context.TODO(), reader, plainStore, profile)
+		Expect(err).To(MatchError(ContainSubstring("bytes long instead of 32")))
+
+		profile.ClientSideEncryption.KeyProvider = "Unregistered"
+		_, err = encryptingObjectStoreNew(context.TODO(), reader, plainStore, profile)
+		Expect(err).To(MatchError(ContainSubstring("unsupported encryption key provider")))
+	})
+
+	It("rejects a key that is not in a secret of its own", func() {
+		reader := fake.NewClientBuilder().WithObjects(secret).Build()
+
+		profile.ClientSideEncryption.KeySecretRef = nil
+		_, err := encryptingObjectStoreNew(context.TODO(), reader, plainStore, profile)
+		Expect(err).To(MatchError(ContainSubstring("has no keySecretRef")))
+
+		profile.ClientSideEncryption.KeySecretRef = &profile.S3SecretRef
+		_, err = encryptingObjectStoreNew(context.TODO(), reader, plainStore, profile)
+		Expect(err).To(MatchError(ContainSubstring("references the secret of its credentials")))
+	})
+
+	It("rejects profiles encrypting objects while the kube objects captured by Velero are protected", func() {
+		ramenConfig := &ramen.RamenConfig{
+			S3StoreProfiles:    []ramen.S3StoreProfile{profile},
+			S3SecretNamespaces: []string{"ramen-system"},
+		}
+		Expect(s3ProfileEncryptionValidate(ramenConfig, "profile")).To(
+			MatchError(ContainSubstring("while kubeObjectProtection is enabled")))
+
+		ramenConfig.KubeObjectProtection.Disabled = true
+		Expect(s3ProfileEncryptionValidate(ramenConfig, "profile")).To(Succeed())
+
+		ramenConfig.S3StoreProfiles[0].ClientSideEncryption.KeySecretRef = nil
+		Expect(s3ProfileEncryptionValidate(ramenConfig, "profile")).To(
+			MatchError(ContainSubstring("has no keySecretRef")))
+	})
+
+	It("propagates the key secret apart from the credentials, and not to Velero", func() {
+		ramenConfig := &ramen.RamenConfig{S3StoreProfiles: []ramen.S3StoreProfile{profile}}
+
+		Expect(s3ProfileSecretKeys(profile)).To(Equal([]string{"ramen-system/secret", "ramen-system/key-secret"}))
+		Expect(s3SecretIsEncryptionKey(ramenConfig, "ramen-system/key-secret")).To(BeTrue())
+		Expect(s3SecretIsEncryptionKey(ramenConfig, "ramen-system/secret")).To(BeFalse())
+	})
+})
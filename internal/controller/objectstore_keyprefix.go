@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var s3KeyPrefixPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// s3KeyPrefixGet returns the key prefix of the objects of the S3 profile, empty if it has none, or an error if its
+// template has an unknown placeholder, a placeholder with no value, or does not render a key prefix of non-empty
+// segments ending with a slash
+func s3KeyPrefixGet(s3StoreProfile ramen.S3StoreProfile) (string, error) {
+	spec := s3StoreProfile.KeyPrefix
+	if spec == nil {
+		return "", nil
+	}
+
+	values := map[string]string{
+		"{org}":     spec.Organization,
+		"{env}":     spec.Environment,
+		"{cluster}": spec.Cluster,
+	}
+
+	var err error
+
+	keyPrefix := s3KeyPrefixPlaceholder.ReplaceAllStringFunc(spec.Template, func(placeholder string) string {
+		value, ok := values[placeholder]
+
+		switch {
+		case !ok:
+			err = fmt.Errorf("unknown placeholder %s", placeholder)
+		case value == "":
+			err = fmt.Errorf("placeholder %s has no value", placeholder)
+		case strings.ContainsAny(value, "/{}"):
+			err = fmt.Errorf("value %q of placeholder %s is not a key segment", value, placeholder)
+		}
+
+		return value
+	})
+
+	if err == nil {
+		switch {
+		case strings.ContainsAny(keyPrefix, "{}"):
+			err = fmt.Errorf("unbalanced placeholder braces")
+		case !strings.HasSuffix(keyPrefix, "/"):
+			err = fmt.Errorf("key prefix %q does not end with a slash", keyPrefix)
+		case strings.HasPrefix(keyPrefix, "/") || strings.Contains(keyPrefix, "//"):
+			err = fmt.Errorf("key prefix %q has an empty segment", keyPrefix)
+		}
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("invalid key prefix template %q of s3 profile %s, %w",
+			spec.Template, s3StoreProfile.S3ProfileName, err)
+	}
+
+	return keyPrefix, nil
+}
+
+// s3ProfileKeyPrefix returns the key prefix of the objects of the S3 profile, whose format was checked already
+func s3ProfileKeyPrefix(s3StoreProfile ramen.S3StoreProfile) string {
+	keyPrefix, _ := s3KeyPrefixGet(s3StoreProfile)
+
+	return keyPrefix
+}
+
+// prefixedObjectStore lays out the objects of an object store under a key prefix. Objects uploaded before the key
+// prefix was set are read, and moved under the key prefix, if migrateLegacyObjects is set.
+type prefixedObjectStore struct {
+	ObjectStorer
+	keyPrefix            string
+	migrateLegacyObjects bool
+}
+
+// prefixedObjectStoreNew returns objectStore laying out the objects under the key prefix of profile, or objectStore
+// if the profile has none
+func prefixedObjectStoreNew(objectStore ObjectStorer, profile ramen.S3StoreProfile) (ObjectStorer, error) {
+	keyPrefix, err := s3KeyPrefixGet(profile)
+	if err != nil || keyPrefix == "" {
+		return objectStore, err
+	}
+
+	return &prefixedObjectStore{
+		ObjectStorer:         objectStore,
+		keyPrefix:            keyPrefix,
+		migrateLegacyObjects: profile.KeyPrefix.MigrateLegacyObjects,
+	}, nil
+}
+
+func (s *prefixedObjectStore) UploadObject(key string, object interface{}) error {
+	return s.ObjectStorer.UploadObject(s.keyPrefix+key, object)
+}
+
+// DownloadObject downloads the object with key under the key prefix, or the legacy object with key if there is none
+// and legacy objects are migrated, moving it under the key prefix. The legacy object is copied as is, so that an
+// encrypted one remains encrypted.
+func (s *prefixedObjectStore) DownloadObject(key string, objectPointer interface{}) error {
+	err := s.ObjectStorer.DownloadObject(s.keyPrefix+key, objectPointer)
+	if err == nil || !s.migrateLegacyObjects {
+		return err
+	}
+
+	raw := json.RawMessage{}
+	if legacyErr := s.ObjectStorer.DownloadObject(key, &raw); legacyErr != nil {
+		return err
+	}
+
+	if err := s.ObjectStorer.UploadObject(s.keyPrefix+key, raw); err != nil {
+		return fmt.Errorf("failed to migrate object %s under key prefix %s, %w", key, s.keyPrefix, err)
+	}
+
+	if err := s.ObjectStorer.DeleteObject(key); err != nil {
+		return fmt.Errorf("failed to delete object %s migrated under key prefix %s, %w", key, s.keyPrefix, err)
+	}
+
+	return json.Unmarshal(raw, objectPointer)
+}
+
+// ObjectVersionIDs returns the version IDs of the latest versions of the objects under the key prefix whose keys start
+// with keyPrefix, by their keys without the key prefix. Legacy objects are left out, as they are migrated only as
+// they are read.
+func (s *prefixedObjectStore) ObjectVersionIDs(keyPrefix string) (map[string]string, error) {
+	versioner, err := objectVersionerOf(s.ObjectStorer)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixedVersionIDs, err := versioner.ObjectVersionIDs(s.keyPrefix + keyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	versionIDs := make(map[string]string, len(prefixedVersionIDs))
+	for key, versionID := range prefixedVersionIDs {
+		versionIDs[strings.TrimPrefix(key, s.keyPrefix)] = versionID
+	}
+
+	return versionIDs, nil
+}
+
+func (s *prefixedObjectStore) DownloadObjectVersion(key, versionID string, objectPointer interface{}) error {
+	versioner, err := objectVersionerOf(s.ObjectStorer)
+	if err != nil {
+		return err
+	}
+
+	return versioner.DownloadObjectVersion(s.keyPrefix+key, versionID, objectPointer)
+}
+
+// ListKeys returns the keys, without the key prefix, of the objects under the key prefix whose keys start with
+// keyPrefix, along with the keys of the legacy ones if legacy objects are migrated
+func (s *prefixedObjectStore) ListKeys(keyPrefix string) ([]string, error) {
+	prefixedKeys, err := s.ObjectStorer.ListKeys(s.keyPrefix + keyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(prefixedKeys))
+	for _, key := range prefixedKeys {
+		keys = append(keys, strings.TrimPrefix(key, s.keyPrefix))
+	}
+
+	if !s.migrateLegacyObjects {
+		return keys, nil
+	}
+
+	legacyKeys, err := s.legacyKeys(keyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	keys = append(keys, legacyKeys...)
+	slices.Sort(keys)
+
+	return slices.Compact(keys), nil
+}
+
+// legacyKeys returns the keys of the legacy objects whose keys start with keyPrefix
+func (s *prefixedObjectStore) legacyKeys(keyPrefix string) ([]string, error) {
+	keys, err := s.ObjectStorer.ListKeys(keyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(keys, func(key string) bool {
+		return strings.HasPrefix(key, s.keyPrefix)
+	}), nil
+}
+
+func (s *prefixedObjectStore) DeleteObject(key string) error {
+	if err := s.ObjectStorer.DeleteObject(s.keyPrefix + key); err != nil {
+		return err
+	}
+
+	if !s.migrateLegacyObjects {
+		return nil
+	}
+
+	return s.ObjectStorer.DeleteObject(key)
+}
+
+func (s *prefixedObjectStore) DeleteObjects(keys ...string) error {
+	prefixedKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixedKeys = append(prefixedKeys, s.keyPrefix+key)
+	}
+
+	if err := s.ObjectStorer.DeleteObjects(prefixedKeys...); err != nil {
+		return err
+	}
+
+	if !s.migrateLegacyObjects {
+		return nil
+	}
+
+	return s.ObjectStorer.DeleteObjects(keys...)
+}
+
+// DeleteObjectsWithKeyPrefix deletes the objects under the key prefix whose keys start with keyPrefix, and the
+// legacy ones if legacy objects are migrated. The legacy objects are deleted by key, so that the objects under the
+// key prefix of an empty or a short keyPrefix are not deleted along with them.
+func (s *prefixedObjectStore) DeleteObjectsWithKeyPrefix(keyPrefix string) error {
+	if err := s.ObjectStorer.DeleteObjectsWithKeyPrefix(s.keyPrefix + keyPrefix); err != nil {
+		return err
+	}
+
+	if !s.migrateLegacyObjects {
+		return nil
+	}
+
+	legacyKeys, err := s.legacyKeys(keyPrefix)
+	if err != nil || len(legacyKeys) == 0 {
+		return err
+	}
+
+	return s.ObjectStorer.DeleteObjects(legacyKeys...)
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("Key prefix of the objects", func() {
+	const key = "ns/vrg/v1.PersistentVolume/pv1"
+
+	var (
+		plainStore ObjectStorer
+		profile    ramen.S3StoreProfile
+	)
+
+	prefixedStore := func() ObjectStorer {
+		objectStore, err := prefixedObjectStoreNew(plainStore, profile)
+		Expect(err).ToNot(HaveOccurred())
+
+		return objectStore
+	}
+
+	keys := func(objectStore ObjectStorer, keyPrefix string) []string {
+		keys, err := objectStore.ListKeys(keyPrefix)
+		Expect(err).ToNot(HaveOccurred())
+
+		return keys
+	}
+
+	pv := corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv1"}}
+
+	BeforeEach(func() {
+		service := &fakeBlobService{
+			accountName: "account",
+			accountKey:  []byte("key"),
+			container:   "container",
+			blobs:       map[string][]byte{},
+		}
+		server := httptest.NewServer(service)
+		DeferCleanup(server.Close)
+
+		plainStore = &azureBlobObjectStore{
+			client:      server.Client(),
+			endpoint:    server.URL,
+			container:   "container",
+			accountName: "account",
+			accountKey:  []byte("key"),
+		}
+		profile = ramen.S3StoreProfile{
+			S3ProfileName: "profile",
+			KeyPrefix: &ramen.S3KeyPrefix{
+				Template:     "dr/{org}/{env}/{cluster}/",
+				Organization: "acme",
+				Environment:  "prod",
+				Cluster:      "east",
+			},
+		}
+	})
+
+	DescribeTable("renders the template of the key prefix",
+		func(template, keyPrefix, errSubstring string) {
+			profile.KeyPrefix.Template = template
+
+			rendered, err := s3KeyPrefixGet(profile)
+			if errSubstring != "" {
+				Expect(err).To(MatchError(ContainSubstring(errSubstring)))
+
+				return
+			}
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rendered).To(Equal(keyPrefix))
+		},
+		Entry("with all the placeholders", "{org}/{env}/{cluster}/", "acme/prod/east/", ""),
+		Entry("with fixed segments", "ramen/{org}-{env}/", "ramen/acme-prod/", ""),
+		Entry("with an unknown placeholder", "{org}/{region}/", "", "unknown placeholder {region}"),
+		Entry("with no trailing slash", "{org}/{env}", "", "does not end with a slash"),
+		Entry("with a leading slash", "/{org}/", "", "empty segment"),
+		Entry("with an empty segment", "{org}//{env}/", "", "empty segment"),
+		Entry("with unbalanced braces", "{org/{env}/", "", "unbalanced placeholder braces"),
+	)
+
+	It("rejects a placeholder with no value or a value that is not a key segment", func() {
+		profile.KeyPrefix.Environment = ""
+		_, err := s3KeyPrefixGet(profile)
+		Expect(err).To(MatchError(ContainSubstring("placeholder {env} has no value")))
+
+		profile.KeyPrefix.Environment = "prod/eu"
+		_, err = s3KeyPrefixGet(profile)
+		Expect(err).To(MatchError(ContainSubstring("is not a key segment")))
+	})
+
+	It("lays out the objects under the key prefix", func() {
+		objectStore := prefixedStore()
+
+		Expect(objectStore.UploadObject(key, pv)).To(Succeed())
+		Expect(keys(plainStore, "")).To(ConsistOf("dr/acme/prod/east/" + key))
+		Expect(keys(objectStore, "ns/")).To(ConsistOf(key))
+
+		downloaded := corev1.PersistentVolume{}
+		Expect(objectStore.DownloadObject(key, &downloaded)).To(Succeed())
+		Expect(downloaded.Name).To(Equal("pv1"))
+
+		Expect(plainStore.UploadObject(key, pv)).To(Succeed())
+		Expect(objectStore.DownloadObject("ns/vrg/v1.PersistentVolume/pv2", &downloaded)).ToNot(Succeed())
+
+		Expect(objectStore.DeleteObjectsWithKeyPrefix("ns/")).To(Succeed())
+		Expect(keys(plainStore, "")).To(ConsistOf(key))
+	})
+
+	It("migrates the legacy objects under the key prefix as they are read", func() {
+		profile.KeyPrefix.MigrateLegacyObjects = true
+		objectStore := prefixedStore()
+
+		Expect(plainStore.UploadObject(key, pv)).To(Succeed())
+		Expect(plainStore.UploadObject("ns/vrg/v1.PersistentVolume/pv2", pv)).To(Succeed())
+		Expect(keys(objectStore, "ns/")).To(ConsistOf(key, "ns/vrg/v1.PersistentVolume/pv2"))
+
+		downloaded := corev1.PersistentVolume{}
+		Expect(objectStore.DownloadObject(key, &downloaded)).To(Succeed())
+		Expect(downloaded.Name).To(Equal("pv1"))
+		Expect(keys(plainStore, "")).To(ConsistOf("dr/acme/prod/east/"+key, "ns/vrg/v1.PersistentVolume/pv2"))
+
+		Expect(objectStore.DeleteObjectsWithKeyPrefix("")).To(Succeed())
+		Expect(keys(plainStore, "")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// ObjectStoreCapabilities are the optional features of the object stores of a backend
+type ObjectStoreCapabilities struct {
+	// Versioning of objects, so that an overwritten or deleted object may be recovered
+	Versioning bool
+
+	// ServerSideEncryption of objects at rest by the store
+	ServerSideEncryption bool
+
+	// Multipart uploads of large objects
+	Multipart bool
+
+	// KubeObjects capture and recovery, which are delegated to Velero through a backup storage location of the store
+	KubeObjects bool
+}
+
+// ObjectStoreBackend creates the object stores of the S3 profiles of its type
+type ObjectStoreBackend struct {
+	// New returns the object store of profile, for the caller tagged callerTag, reading the secret of the profile
+	// with r
+	New func(ctx context.Context, r client.Reader, profile ramen.S3StoreProfile, callerTag string,
+	) (ObjectStorer, error)
+
+	Capabilities ObjectStoreCapabilities
+}
+
+var (
+	objectStoreBackendsMutex sync.RWMutex
+	objectStoreBackends      = map[ramen.ObjectStoreType]ObjectStoreBackend{}
+)
+
+func init() {
+	RegisterObjectStoreBackend(ramen.ObjectStoreTypeS3, ObjectStoreBackend{
+		New: newS3ObjectStore,
+		Capabilities: ObjectStoreCapabilities{
+			Versioning: true, ServerSideEncryption: true, Multipart: true, KubeObjects: true,
+		},
+	})
+	RegisterObjectStoreBackend(ramen.ObjectStoreTypeAzureBlob, ObjectStoreBackend{
+		New:          newAzureBlobObjectStore,
+		Capabilities: ObjectStoreCapabilities{Versioning: true, ServerSideEncryption: true},
+	})
+}
+
+// RegisterObjectStoreBackend registers the backend of the S3 profiles of storeType, typically from the init function
+// of the package implementing it. It panics if a backend is already registered for storeType.
+func RegisterObjectStoreBackend(storeType ramen.ObjectStoreType, backend ObjectStoreBackend) {
+	objectStoreBackendsMutex.Lock()
+	defer objectStoreBackendsMutex.Unlock()
+
+	if _, ok := objectStoreBackends[storeType]; ok {
+		panic(fmt.Sprintf("object store backend %s registered twice", storeType))
+	}
+
+	objectStoreBackends[storeType] = backend
+}
+
+// ObjectStoreBackendGet returns the backend registered for the S3 profiles of storeType, S3 if empty, and whether
+// there is one
+func ObjectStoreBackendGet(storeType ramen.ObjectStoreType) (ObjectStoreBackend, bool) {
+	if storeType == "" {
+		storeType = ramen.ObjectStoreTypeS3
+	}
+
+	objectStoreBackendsMutex.RLock()
+	defer objectStoreBackendsMutex.RUnlock()
+
+	backend, ok := objectStoreBackends[storeType]
+
+	return backend, ok
+}
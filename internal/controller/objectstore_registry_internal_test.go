@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("Object store backends", func() {
+	const storeType = ramen.ObjectStoreType("Registered")
+
+	profile := func(storeType ramen.ObjectStoreType) *ramen.S3StoreProfile {
+		return &ramen.S3StoreProfile{
+			S3ProfileName:        "profile",
+			Type:                 storeType,
+			S3Bucket:             "bucket",
+			S3CompatibleEndpoint: "https://store.example.com",
+		}
+	}
+
+	It("defaults to the S3 backend", func() {
+		backend, ok := ObjectStoreBackendGet("")
+		Expect(ok).To(BeTrue())
+		Expect(backend.Capabilities.Multipart).To(BeTrue())
+	})
+
+	It("accepts the profiles of the registered types only", func() {
+		Expect(s3StoreProfileFormatCheck(profile(storeType))).To(MatchError(
+			ContainSubstring("unsupported object store type")))
+
+		RegisterObjectStoreBackend(storeType, ObjectStoreBackend{
+			New: func(context.Context, client.Reader, ramen.S3StoreProfile, string) (ObjectStorer, error) {
+				return nil, nil
+			},
+			Capabilities: ObjectStoreCapabilities{Versioning: true},
+		})
+
+		backend, ok := ObjectStoreBackendGet(storeType)
+		Expect(ok).To(BeTrue())
+		Expect(backend.Capabilities).To(Equal(ObjectStoreCapabilities{Versioning: true}))
+		Expect(s3StoreProfileFormatCheck(profile(storeType))).To(Succeed())
+		Expect(s3StoreProfileFormatCheck(profile(ramen.ObjectStoreTypeAzureBlob))).To(Succeed())
+
+		Expect(func() { RegisterObjectStoreBackend(storeType, backend) }).To(Panic())
+	})
+
+	It("rejects the options of a profile its object store type does not support", func() {
+		azureBlob := profile(ramen.ObjectStoreTypeAzureBlob)
+		azureBlob.MultipartUpload = &ramen.MultipartUpload{}
+
+		Expect(s3StoreProfileFormatCheck(azureBlob)).To(MatchError(
+			ContainSubstring("multipart upload is not supported by object store type AzureBlob")))
+	})
+
+	It("supports kube objects capture with S3 profiles only", func() {
+		accessor := func(storeType ramen.ObjectStoreType, name string) s3StoreAccessor {
+			return s3StoreAccessor{S3StoreProfile: ramen.S3StoreProfile{S3ProfileName: name, Type: storeType}}
+		}
+
+		Expect(kubeObjectsUnsupportedStore([]s3StoreAccessor{
+			accessor("", "default"), accessor(ramen.ObjectStoreTypeS3, "s3"),
+		})).To(BeEmpty())
+		Expect(kubeObjectsUnsupportedStore([]s3StoreAccessor{
+			accessor(ramen.ObjectStoreTypeS3, "s3"), accessor(ramen.ObjectStoreTypeAzureBlob, "azure"),
+		})).To(Equal("azure"))
+	})
+})
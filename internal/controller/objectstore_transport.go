@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+// errObjectStoreTransportInvalid is wrapped by the errors of the CA certificates, proxy, IP family or client
+// certificate of an S3 profile that cannot be used to connect to its object store
+var errObjectStoreTransportInvalid = errors.New("invalid object store transport")
+
+// objectStoreTransport returns the HTTP transport to connect to the object store of the S3 profile with, trusting
+// its CA certificates in addition to the system ones, through its proxy, if any, and with the addresses of its IP
+// family, if any
+func objectStoreTransport(s3StoreProfile ramen.S3StoreProfile) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// the objects are downloaded as uploaded, compressed or not, rather than transparently decompressed by the
+	// transport because of their content encoding, which breaks the ranged downloads of large objects
+	transport.DisableCompression = true
+
+	if len(s3StoreProfile.CACertificates) != 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if !rootCAs.AppendCertsFromPEM(s3StoreProfile.CACertificates) {
+			return nil, fmt.Errorf("%w: CA certificates of s3 profile %s have no PEM encoded certificate",
+				errObjectStoreTransportInvalid, s3StoreProfile.S3ProfileName)
+		}
+
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	}
+
+	if proxy := s3StoreProfile.Proxy; proxy != nil {
+		proxyURL, err := objectStoreProxyURL(proxy.URL)
+		if err != nil {
+			return nil, fmt.Errorf("%w: proxy of s3 profile %s, %w", errObjectStoreTransportInvalid,
+				s3StoreProfile.S3ProfileName, err)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if ipFamily := s3StoreProfile.IPFamily; ipFamily != "" {
+		network, err := objectStoreNetwork(ipFamily)
+		if err != nil {
+			return nil, fmt.Errorf("%w: s3 profile %s, %w", errObjectStoreTransportInvalid,
+				s3StoreProfile.S3ProfileName, err)
+		}
+
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		transport.DialContext = func(ctx context.Context, _, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, address)
+		}
+	}
+
+	return transport, nil
+}
+
+// objectStoreNetwork returns the network to dial the object store with the addresses of ipFamily with
+func objectStoreNetwork(ipFamily corev1.IPFamily) (string, error) {
+	switch ipFamily {
+	case corev1.IPv4Protocol:
+		return "tcp4", nil
+	case corev1.IPv6Protocol:
+		return "tcp6", nil
+	default:
+		return "", fmt.Errorf("unsupported IP family %q, expected %s or %s", ipFamily, corev1.IPv4Protocol,
+			corev1.IPv6Protocol)
+	}
+}
+
+// objectStoreClientCertificateSet sets the client certificate transport presents to the object store to the one of
+// the secret of the S3 profile, if the profile authenticates with a TLS client certificate
+func objectStoreClientCertificateSet(ctx context.Context, r client.Reader, s3StoreProfile ramen.S3StoreProfile,
+	transport *http.Transport,
+) error {
+	if !s3StoreProfile.TLSClientCertificate {
+		return nil
+	}
+
+	secret, err := s3SecretGet(ctx, r, s3StoreProfile.S3SecretRef)
+	if err != nil {
+		return err
+	}
+
+	certificate, err := tls.X509KeyPair(secret.Data[util.TLSClientCertificateKey],
+		secret.Data[util.TLSClientPrivateKeyKey])
+	if err != nil {
+		return fmt.Errorf("%w: client certificate of keys %s and %s of secret %v, %w", errObjectStoreTransportInvalid,
+			util.TLSClientCertificateKey, util.TLSClientPrivateKeyKey, s3StoreProfile.S3SecretRef, err)
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+
+	return nil
+}
+
+// objectStoreEndpoint returns the endpoint of the object store of the S3 profile, with the https scheme if it has
+// none. Its host is a DNS name, an IPv4 address, or an IPv6 address enclosed in brackets, optionally followed by a
+// port.
+func objectStoreEndpoint(s3StoreProfile ramen.S3StoreProfile) (string, error) {
+	endpoint := s3StoreProfile.S3CompatibleEndpoint
+	if endpoint == "" {
+		return "", fmt.Errorf("s3 endpoint has not been configured in s3 profile %s",
+			s3StoreProfile.S3ProfileName)
+	}
+
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+
+	if err := objectStoreEndpointCheck(endpoint); err != nil {
+		return "", fmt.Errorf("invalid s3 endpoint <%s> in "+
+			"profile %s, reason: %w", s3StoreProfile.S3CompatibleEndpoint, s3StoreProfile.S3ProfileName, err)
+	}
+
+	return endpoint, nil
+}
+
+// s3ProfileEndpoint returns the endpoint of the object store of the S3 profile, whose format was checked already
+func s3ProfileEndpoint(s3StoreProfile ramen.S3StoreProfile) string {
+	endpoint, err := objectStoreEndpoint(s3StoreProfile)
+	if err != nil {
+		return s3StoreProfile.S3CompatibleEndpoint
+	}
+
+	return endpoint
+}
+
+func objectStoreEndpointCheck(endpoint string) error {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+
+	switch endpointURL.Scheme {
+	case "http", "https":
+	default:
+		return fmt.Errorf("unsupported scheme %q, expected http or https", endpointURL.Scheme)
+	}
+
+	if endpointURL.Hostname() == "" {
+		return fmt.Errorf("no host")
+	}
+
+	if strings.Count(endpointURL.Host, ":") > 1 && !strings.HasPrefix(endpointURL.Host, "[") {
+		return fmt.Errorf("IPv6 address %s is not enclosed in brackets", endpointURL.Host)
+	}
+
+	if port := endpointURL.Port(); port != "" {
+		if number, err := strconv.ParseUint(port, 10, 16); err != nil || number == 0 {
+			return fmt.Errorf("invalid port %s", port)
+		}
+	}
+
+	return nil
+}
+
+// objectStoreProxyURL parses the URL of a proxy, returning errors that do not disclose its credentials
+func objectStoreProxyURL(rawURL string) (*url.URL, error) {
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		if urlErr := (*url.Error)(nil); errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+
+		return nil, fmt.Errorf("invalid url, %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q of url <%s>, expected http, https or socks5",
+			proxyURL.Scheme, proxyURL.Redacted())
+	}
+
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("url <%s> has no host", proxyURL.Redacted())
+	}
+
+	return proxyURL, nil
+}
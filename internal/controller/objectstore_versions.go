@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// s3NullVersionID is the version ID of the objects uploaded to a bucket without object versioning enabled
+const s3NullVersionID = "null"
+
+const (
+	defaultS3VersioningInterval    = time.Hour
+	defaultS3VersioningGenerations = 24
+)
+
+var errObjectVersioningUnsupported = errors.New("object store does not support object versioning")
+
+// objectVersioner is implemented by the object stores that read the previous versions of the objects of a bucket
+// with object versioning enabled
+type objectVersioner interface {
+	// ObjectVersionIDs returns the version ID of the latest version of each object whose key starts with keyPrefix
+	ObjectVersionIDs(keyPrefix string) (map[string]string, error)
+
+	// DownloadObjectVersion downloads the version with versionID of the object with key into objectPointer
+	DownloadObjectVersion(key, versionID string, objectPointer interface{}) error
+}
+
+func objectVersionerOf(objectStore ObjectStorer) (objectVersioner, error) {
+	versioner, ok := objectStore.(objectVersioner)
+	if !ok {
+		return nil, errObjectVersioningUnsupported
+	}
+
+	return versioner, nil
+}
+
+func s3VersioningInterval(versioning *rmn.S3Versioning) time.Duration {
+	if versioning.Interval == nil || versioning.Interval.Duration <= 0 {
+		return defaultS3VersioningInterval
+	}
+
+	return versioning.Interval.Duration
+}
+
+func s3VersioningGenerations(versioning *rmn.S3Versioning) int {
+	if versioning.Generations <= 0 {
+		return defaultS3VersioningGenerations
+	}
+
+	return versioning.Generations
+}
+
+// captureGenerations returns the capture generations recorded for the VRG, in ascending order
+func captureGenerations(objectStore ObjectStorer, vrgNamespace, vrgName string) ([]int64, error) {
+	keyPrefix := metadata.TypedKeyPrefix(metadata.VolumeReplicationGroupPrefix(vrgNamespace, vrgName),
+		metadata.TypeNameCaptureGeneration)
+
+	keys, err := objectStore.ListKeys(keyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list capture generations with key prefix %s, %w", keyPrefix, err)
+	}
+
+	generations := make([]int64, 0, len(keys))
+
+	for _, key := range keys {
+		generation, err := strconv.ParseInt(strings.TrimPrefix(key, keyPrefix), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		generations = append(generations, generation)
+	}
+
+	slices.Sort(generations)
+
+	return generations, nil
+}
+
+// captureGenerationRecorded returns whether the object with key of the VRG is recorded in its capture generations,
+// which record the objects owned by ramen, except for the capture generations themselves, and the local failover
+// plan and primary heartbeat, which the hub and the primary VRG update with their heartbeats
+func captureGenerationRecorded(vrgNamespace, vrgName, key string) bool {
+	prefix := metadata.VolumeReplicationGroupPrefix(vrgNamespace, vrgName)
+
+	return s3ObjectOwned(vrgNamespace, vrgName, key) &&
+		!strings.HasPrefix(key, metadata.TypedKeyPrefix(prefix, metadata.TypeNameCaptureGeneration)) &&
+		!strings.HasPrefix(key, metadata.TypedKeyPrefix(prefix, metadata.TypeNameLocalFailoverPlan)) &&
+		!strings.HasPrefix(key, metadata.TypedKeyPrefix(prefix, metadata.TypeNameLocalFailoverPrimaryHeartbeat))
+}
+
+// captureGenerationRecord records the version IDs of the objects of the VRG in a new capture generation, unless the
+// latest generation is more recent than the interval of versioning, or the objects did not change since. The oldest
+// generations beyond the number of generations retained are deleted. Returns the generation recorded, 0 if none
+// is, and the time before which no generation is due.
+func captureGenerationRecord(objectStore ObjectStorer, versioning *rmn.S3Versioning, vrgNamespace, vrgName string,
+	now time.Time,
+) (int64, time.Time, error) {
+	interval := s3VersioningInterval(versioning)
+
+	versioner, err := objectVersionerOf(objectStore)
+	if err != nil {
+		return 0, now, err
+	}
+
+	generations, err := captureGenerations(objectStore, vrgNamespace, vrgName)
+	if err != nil {
+		return 0, now, err
+	}
+
+	latest := metadata.CaptureGeneration{}
+
+	if len(generations) != 0 {
+		key := metadata.CaptureGenerationKey(vrgNamespace, vrgName, generations[len(generations)-1])
+		if err := objectStore.DownloadObject(key, &latest); err != nil {
+			return 0, now, fmt.Errorf("failed to download capture generation %s, %w", key, err)
+		}
+
+		if next := latest.Time.Add(interval); now.Before(next) {
+			return 0, next, nil
+		}
+	}
+
+	versionIDs, err := versioner.ObjectVersionIDs(metadata.VolumeReplicationGroupPrefix(vrgNamespace, vrgName))
+	if err != nil {
+		return 0, now, err
+	}
+
+	maps.DeleteFunc(versionIDs, func(key, _ string) bool {
+		return !captureGenerationRecorded(vrgNamespace, vrgName, key)
+	})
+
+	if len(versionIDs) == 0 || (len(generations) != 0 && maps.Equal(latest.VersionIDs, versionIDs)) {
+		return 0, now.Add(interval), nil
+	}
+
+	generation := int64(1)
+	if len(generations) != 0 {
+		generation = generations[len(generations)-1] + 1
+	}
+
+	if err := objectStore.UploadObject(metadata.CaptureGenerationKey(vrgNamespace, vrgName, generation),
+		metadata.CaptureGeneration{Generation: generation, Time: metav1.NewTime(now), VersionIDs: versionIDs},
+	); err != nil {
+		return 0, now, err
+	}
+
+	generations = append(generations, generation)
+	expired := len(generations) - s3VersioningGenerations(versioning)
+
+	if expired > 0 {
+		keys := make([]string, 0, expired)
+		for _, generation := range generations[:expired] {
+			keys = append(keys, metadata.CaptureGenerationKey(vrgNamespace, vrgName, generation))
+		}
+
+		if err := objectStore.DeleteObjects(keys...); err != nil {
+			return generation, now.Add(interval), fmt.Errorf("failed to delete expired capture generations, %w", err)
+		}
+	}
+
+	return generation, now.Add(interval), nil
+}
+
+// capturedObjectStore reads the objects of an object store as they were at a capture generation. It lists the
+// objects recorded in the generation, and downloads their versions recorded, or the latest version of the objects
+// that are not recorded, like the local failover plan. It uploads and deletes the latest versions.
+type capturedObjectStore struct {
+	ObjectStorer
+	versioner  objectVersioner
+	versionIDs map[string]string
+}
+
+// captureGenerationObjectStore returns objectStore reading the objects of the VRG as they were at generation
+func captureGenerationObjectStore(objectStore ObjectStorer, vrgNamespace, vrgName string, generation int64,
+) (ObjectStorer, error) {
+	versioner, err := objectVersionerOf(objectStore)
+	if err != nil {
+		return nil, err
+	}
+
+	captureGeneration := metadata.CaptureGeneration{}
+	key := metadata.CaptureGenerationKey(vrgNamespace, vrgName, generation)
+
+	if err := objectStore.DownloadObject(key, &captureGeneration); err != nil {
+		return nil, fmt.Errorf("failed to download capture generation %s, %w", key, err)
+	}
+
+	return &capturedObjectStore{
+		ObjectStorer: objectStore,
+		versioner:    versioner,
+		versionIDs:   captureGeneration.VersionIDs,
+	}, nil
+}
+
+func (s *capturedObjectStore) ListKeys(keyPrefix string) ([]string, error) {
+	keys := []string{}
+
+	for key := range s.versionIDs {
+		if strings.HasPrefix(key, keyPrefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys, nil
+}
+
+func (s *capturedObjectStore) DownloadObject(key string, objectPointer interface{}) error {
+	versionID, ok := s.versionIDs[key]
+	if !ok {
+		return s.ObjectStorer.DownloadObject(key, objectPointer)
+	}
+
+	return s.versioner.DownloadObjectVersion(key, versionID, objectPointer)
+}
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// versionedObjectStore keeps every version of its objects, numbered from 1, like a bucket with object versioning
+// enabled. Deleting an object hides its versions, like a delete marker.
+type versionedObjectStore struct {
+	ObjectStorer
+	versions map[string][]json.RawMessage
+	deleted  map[string]bool
+}
+
+func (s *versionedObjectStore) UploadObject(key string, object interface{}) error {
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	s.versions[key] = append(s.versions[key], encoded)
+	delete(s.deleted, key)
+
+	return nil
+}
+
+func (s *versionedObjectStore) DownloadObject(key string, objectPointer interface{}) error {
+	versions := s.versions[key]
+	if len(versions) == 0 || s.deleted[key] {
+		return fmt.Errorf("no such key %s", key)
+	}
+
+	return json.Unmarshal(versions[len(versions)-1], objectPointer)
+}
+
+func (s *versionedObjectStore) DownloadObjectVersion(key, versionID string, objectPointer interface{}) error {
+	version, err := strconv.Atoi(versionID)
+	if err != nil || version < 1 || version > len(s.versions[key]) {
+		return fmt.Errorf("no such version %s of key %s", versionID, key)
+	}
+
+	return json.Unmarshal(s.versions[key][version-1], objectPointer)
+}
+
+func (s *versionedObjectStore) ObjectVersionIDs(keyPrefix string) (map[string]string, error) {
+	versionIDs := map[string]string{}
+
+	for key, versions := range s.versions {
+		if strings.HasPrefix(key, keyPrefix) && !s.deleted[key] {
+			versionIDs[key] = strconv.Itoa(len(versions))
+		}
+	}
+
+	return versionIDs, nil
+}
+
+func (s *versionedObjectStore) ListKeys(keyPrefix string) ([]string, error) {
+	versionIDs, _ := s.ObjectVersionIDs(keyPrefix)
+	keys := make([]string, 0, len(versionIDs))
+
+	for key := range versionIDs {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys, nil
+}
+
+func (s *versionedObjectStore) DeleteObjects(keys ...string) error {
+	for _, key := range keys {
+		s.deleted[key] = true
+	}
+
+	return nil
+}
+
+var _ = Describe("Capture generations", func() {
+	const (
+		vrgNamespace = "app"
+		vrgName      = "drpc"
+	)
+
+	var (
+		objectStore *versionedObjectStore
+		versioning  *rmn.S3Versioning
+		start       time.Time
+	)
+
+	prefix := metadata.VolumeReplicationGroupPrefix(vrgNamespace, vrgName)
+
+	uploadPV := func(name, storageClassName string) {
+		Expect(objectStore.UploadObject(metadata.TypedKey(prefix, metadata.TypeNamePersistentVolume, name),
+			corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       corev1.PersistentVolumeSpec{StorageClassName: storageClassName},
+			})).To(Succeed())
+	}
+
+	uploadVRG := func(state rmn.State) {
+		Expect(VrgObjectProtect(objectStore, rmn.VolumeReplicationGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: vrgNamespace, Name: vrgName},
+			Status:     rmn.VolumeReplicationGroupStatus{State: state},
+		})).To(Succeed())
+	}
+
+	record := func(after time.Duration) int64 {
+		generation, _, err := captureGenerationRecord(objectStore, versioning, vrgNamespace, vrgName,
+			start.Add(after))
+		Expect(err).ToNot(HaveOccurred())
+
+		return generation
+	}
+
+	BeforeEach(func() {
+		objectStore = &versionedObjectStore{versions: map[string][]json.RawMessage{}, deleted: map[string]bool{}}
+		versioning = &rmn.S3Versioning{Interval: &metav1.Duration{Duration: time.Hour}, Generations: 2}
+		start = time.Now().Truncate(time.Second)
+
+		uploadPV("pv1", "good")
+		uploadVRG(rmn.PrimaryState)
+	})
+
+	It("records a generation once the interval passed and the objects changed", func() {
+		Expect(record(0)).To(Equal(int64(1)))
+		Expect(record(30 * time.Minute)).To(BeZero())
+		Expect(record(2 * time.Hour)).To(BeZero())
+
+		_, next, err := captureGenerationRecord(objectStore, versioning, vrgNamespace, vrgName,
+			start.Add(10*time.Minute))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(next).To(BeTemporally("==", start.Add(time.Hour)))
+
+		uploadPV("pv1", "corrupt")
+		Expect(record(3 * time.Hour)).To(Equal(int64(2)))
+
+		generation := metadata.CaptureGeneration{}
+		Expect(objectStore.DownloadObject(metadata.CaptureGenerationKey(vrgNamespace, vrgName, 2),
+			&generation)).To(Succeed())
+		pvKey := metadata.TypedKey(prefix, metadata.TypeNamePersistentVolume, "pv1")
+		vrgKey := metadata.TypedKey(prefix, metadata.TypeNameVolumeReplicationGroup, metadata.VolumeReplicationGroupName)
+		Expect(generation.VersionIDs).To(Equal(map[string]string{pvKey: "2", vrgKey: "1"}))
+	})
+
+	It("restores the objects as they were at a generation", func() {
+		Expect(record(0)).To(Equal(int64(1)))
+
+		uploadPV("pv1", "corrupt")
+		uploadPV("pv2", "corrupt")
+		uploadVRG(rmn.UnknownState)
+
+		generationStore, err := captureGenerationObjectStore(objectStore, vrgNamespace, vrgName, 1)
+		Expect(err).ToNot(HaveOccurred())
+
+		pvs, err := downloadPVs(generationStore, prefix)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pvs).To(HaveLen(1))
+		Expect(pvs[0].Spec.StorageClassName).To(Equal("good"))
+
+		vrg := &rmn.VolumeReplicationGroup{}
+		Expect(vrgObjectDownload(generationStore, prefix, vrg)).To(Succeed())
+		Expect(vrg.Status.State).To(Equal(rmn.PrimaryState))
+
+		_, err = captureGenerationObjectStore(objectStore, vrgNamespace, vrgName, 2)
+		Expect(err).To(MatchError(ContainSubstring("failed to download capture generation")))
+	})
+
+	It("deletes the oldest generations beyond the generations retained", func() {
+		for i, storageClassName := range []string{"a", "b", "c"} {
+			uploadPV("pv1", storageClassName)
+			Expect(record(time.Duration(i) * time.Hour)).To(Equal(int64(i + 1)))
+		}
+
+		Expect(captureGenerations(objectStore, vrgNamespace, vrgName)).To(Equal([]int64{2, 3}))
+	})
+
+	It("is not supported by an object store without versions", func() {
+		_, _, err := captureGenerationRecord(&healthObjectStore{objects: map[string]interface{}{}}, versioning,
+			vrgNamespace, vrgName, start)
+		Expect(err).To(MatchError(errObjectVersioningUnsupported))
+	})
+})
@@ -6,7 +6,6 @@ package controllers
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"os"
 	"strings"
 
@@ -180,7 +179,11 @@ func GetRamenConfigS3StoreProfile(ctx context.Context, apiReader client.Reader,
 
 	s3StoreProfile = *s3StoreProfilePointer
 
-	err = s3StoreProfileFormatCheck(&s3StoreProfile)
+	if err = s3StoreProfileFormatCheck(&s3StoreProfile); err != nil {
+		return
+	}
+
+	err = s3SecretNamespaceCheck(ramenConfig, &s3StoreProfile)
 
 	return
 }
@@ -198,19 +201,18 @@ func RamenConfigS3StoreProfilePointerGet(ramenConfig *ramendrv1alpha1.RamenConfi
 }
 
 func s3StoreProfileFormatCheck(s3StoreProfile *ramendrv1alpha1.S3StoreProfile) (err error) {
-	s3Endpoint := s3StoreProfile.S3CompatibleEndpoint
-	if s3Endpoint == "" {
-		err = fmt.Errorf("s3 endpoint has not been configured in s3 profile %s",
-			s3StoreProfile.S3ProfileName)
-
-		return err
+	backend, ok := ObjectStoreBackendGet(s3StoreProfile.Type)
+	if !ok {
+		return fmt.Errorf("unsupported object store type %s in s3 profile %s",
+			s3StoreProfile.Type, s3StoreProfile.S3ProfileName)
 	}
 
-	_, err = url.ParseRequestURI(s3Endpoint)
-	if err != nil {
-		err = fmt.Errorf("invalid s3 endpoint <%s> in "+
-			"profile %s, reason: %w", s3Endpoint, s3StoreProfile.S3ProfileName, err)
+	if s3StoreProfile.MultipartUpload != nil && !backend.Capabilities.Multipart {
+		return fmt.Errorf("multipart upload is not supported by object store type %s of s3 profile %s",
+			s3StoreProfile.Type, s3StoreProfile.S3ProfileName)
+	}
 
+	if _, err = objectStoreEndpoint(*s3StoreProfile); err != nil {
 		return err
 	}
 
@@ -222,7 +224,25 @@ func s3StoreProfileFormatCheck(s3StoreProfile *ramendrv1alpha1.S3StoreProfile) (
 		return err
 	}
 
-	return nil
+	if err = s3WebIdentityFormatCheck(s3StoreProfile); err != nil {
+		return err
+	}
+
+	if _, err = objectStoreTransport(*s3StoreProfile); err != nil {
+		return err
+	}
+
+	if _, err = objectCompressionGet(*s3StoreProfile); err != nil {
+		return err
+	}
+
+	if _, err = s3KeyPrefixGet(*s3StoreProfile); err != nil {
+		return err
+	}
+
+	_, err = s3MultipartUploadGet(*s3StoreProfile)
+
+	return err
 }
 
 func getMaxConcurrentReconciles(ramenConfig *ramendrv1alpha1.RamenConfig) int {
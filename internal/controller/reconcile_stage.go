@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+)
+
+const (
+	reconcileStageDRCluster = "drc"
+	reconcileStageDRPC      = "drpc"
+
+	stageConfigGet         = "config_get"
+	stageDeploy            = "deploy"
+	stageFenceHandling     = "fence_handling"
+	stageS3Validation      = "s3_validation"
+	stageCIDRValidation    = "cidr_validation"
+	stageMModeHandling     = "mmode_handling"
+	stagePlacementGet      = "placement_get"
+	stagePolicyValidation  = "policy_validation"
+	stageStatusConsistency = "status_consistency"
+	stageInstanceCreate    = "instance_create"
+	stageInstanceReconcile = "instance_reconcile"
+)
+
+// timeReconcileStage starts timing a stage of a reconcile of the controller, and returns the function that reports
+// its duration in the reconcile_stage_duration_seconds metric once the stage is done
+func timeReconcileStage(controller, stage string) func() {
+	start := time.Now()
+
+	return func() {
+		NewReconcileStageDurationMetric(ReconcileStageDurationLabels(controller, stage)).
+			Observe(time.Since(start).Seconds())
+	}
+}
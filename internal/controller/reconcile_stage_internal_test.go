@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var _ = Describe("Reconcile stage timings", func() {
+	observations := func(controller, stage string) uint64 {
+		families, err := metrics.Registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		labels := ReconcileStageDurationLabels(controller, stage)
+
+		for _, family := range families {
+			if family.GetName() != metricNamespace+"_"+ReconcileStageDurationSeconds {
+				continue
+			}
+
+			for _, metric := range family.GetMetric() {
+				matched := 0
+
+				for _, label := range metric.GetLabel() {
+					if labels[label.GetName()] == label.GetValue() {
+						matched++
+					}
+				}
+
+				if matched == len(labels) {
+					return metric.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+
+		return 0
+	}
+
+	It("observes the duration of a stage once it is done", func() {
+		before := observations("test-stages", stageDeploy)
+
+		stageDone := timeReconcileStage("test-stages", stageDeploy)
+		Expect(observations("test-stages", stageDeploy)).To(Equal(before))
+
+		stageDone()
+		Expect(observations("test-stages", stageDeploy)).To(Equal(before + 1))
+		Expect(observations("test-stages", stageConfigGet)).To(BeZero())
+	})
+})
@@ -0,0 +1,322 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	// defaultS3GarbageCollectionInterval is the interval between audits of the S3 profiles if the ramen config sets
+	// none
+	defaultS3GarbageCollectionInterval = 24 * time.Hour
+
+	// defaultS3GarbageCollectionRetentionPeriod is how long orphaned metadata is kept if the ramen config sets no
+	// retention period
+	defaultS3GarbageCollectionRetentionPeriod = 7 * 24 * time.Hour
+
+	// s3OrphanedPrefixKeyPrefix is the prefix of the keys of the records of the orphaned key prefixes. It starts with
+	// a dot so that it is not the key prefix of the metadata of a namespace.
+	s3OrphanedPrefixKeyPrefix = ".ramen-orphaned/"
+)
+
+func S3GarbageCollectionEnabled(ramenConfig *rmn.RamenConfig) bool {
+	return ramenConfig != nil && ramenConfig.S3GarbageCollection.Enabled
+}
+
+func S3GarbageCollectionInterval(ramenConfig *rmn.RamenConfig) time.Duration {
+	interval := ramenConfig.S3GarbageCollection.Interval
+	if interval == nil || interval.Duration <= 0 {
+		return defaultS3GarbageCollectionInterval
+	}
+
+	return interval.Duration
+}
+
+func S3GarbageCollectionRetentionPeriod(ramenConfig *rmn.RamenConfig) time.Duration {
+	retentionPeriod := ramenConfig.S3GarbageCollection.RetentionPeriod
+	if retentionPeriod == nil || retentionPeriod.Duration < 0 {
+		return defaultS3GarbageCollectionRetentionPeriod
+	}
+
+	return retentionPeriod.Duration
+}
+
+// s3OrphanedPrefix records since when the key prefix of the VRG of a deleted DRPC is orphaned. It is written only
+// by the hub deleting the DRPC, so that a hub that does not know a DRPC, like a hub recovered with new DRPC UIDs or
+// another hub sharing the bucket, never deletes its metadata.
+type s3OrphanedPrefix struct {
+	HubID         string      `json:"hubID"`
+	DRPCNamespace string      `json:"drpcNamespace"`
+	DRPCName      string      `json:"drpcName"`
+	DRPCUID       string      `json:"drpcUID,omitempty"`
+	OrphanedTime  metav1.Time `json:"orphanedTime"`
+}
+
+// s3OrphanedPrefixKey returns the key of the record of the orphaned prefix, a VRG key prefix ending with a slash
+func s3OrphanedPrefixKey(prefix string) string {
+	return s3OrphanedPrefixKeyPrefix + strings.TrimSuffix(prefix, "/")
+}
+
+// hubID returns the identity of the hub, the UID of its kube-system namespace
+func hubID(ctx context.Context, reader client.Reader) (string, error) {
+	namespace := &corev1.Namespace{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: metav1.NamespaceSystem}, namespace); err != nil {
+		return "", fmt.Errorf("failed to get hub identity: %w", err)
+	}
+
+	return string(namespace.GetUID()), nil
+}
+
+// s3OrphanedPrefixRecordIfAbsent records the prefix as orphaned in the object store, unless it is already recorded,
+// so that its retention period is not extended. The record is created conditionally, if the object store supports
+// it, so that a hub recording it concurrently does not extend it either.
+func s3OrphanedPrefixRecordIfAbsent(objectStore ObjectStorer, prefix string, record s3OrphanedPrefix) error {
+	key := s3OrphanedPrefixKey(prefix)
+
+	if writer, err := conditionalObjectWriterOf(objectStore); err == nil {
+		if err := writer.UploadObjectIfMatch(key, record, ""); err != nil && !isObjectPreconditionFailed(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	keys, err := objectStore.ListKeys(key)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(keys, key) {
+		return nil
+	}
+
+	return objectStore.UploadObject(key, record)
+}
+
+// s3OrphanedPrefixRecord records the key prefix of the VRG of the DRPC being deleted as orphaned in the S3 profiles
+// of its clusters, for the garbage collector to delete once the retention period elapses. Failures are logged only,
+// so as not to block the deletion of the DRPC; its metadata is then left in the S3 profiles.
+func (r *DRPlacementControlReconciler) s3OrphanedPrefixRecord(ctx context.Context, drpc *rmn.DRPlacementControl,
+	drPolicy *rmn.DRPolicy, vrgNamespace string, log logr.Logger,
+) {
+	_, ramenConfig, err := ConfigMapGet(ctx, r.APIReader)
+	if err != nil || !S3GarbageCollectionEnabled(ramenConfig) {
+		return
+	}
+
+	hub, err := hubID(ctx, r.APIReader)
+	if err != nil {
+		log.Info("Failed to record orphaned S3 key prefix", "error", err)
+
+		return
+	}
+
+	drClusters, err := GetDRClusters(ctx, r.Client, drPolicy)
+	if err != nil {
+		log.Info("Failed to record orphaned S3 key prefix", "error", err)
+
+		return
+	}
+
+	prefix := s3PathNamePrefix(vrgNamespace, drpc.GetName())
+	record := s3OrphanedPrefix{
+		HubID:         hub,
+		DRPCNamespace: drpc.GetNamespace(),
+		DRPCName:      drpc.GetName(),
+		DRPCUID:       string(drpc.GetUID()),
+		OrphanedTime:  metav1.Now(),
+	}
+
+	for _, profileName := range s3ProfilesMigrating(drpc, AvailableS3Profiles(drClusters)) {
+		if profileName == NoS3StoreAvailable {
+			continue
+		}
+
+		objectStore, _, err := r.ObjStoreGetter.ObjectStore(ctx, r.APIReader, profileName, "drpc deletion", log)
+		if err == nil {
+			err = s3OrphanedPrefixRecordIfAbsent(objectStore, prefix, record)
+		}
+
+		if err != nil {
+			log.Info("Failed to record orphaned S3 key prefix", "s3Profile", profileName, "prefix", prefix,
+				"error", err)
+		}
+	}
+}
+
+// S3GarbageCollector periodically audits the S3 profiles for the metadata of the VRGs of deleted DRPCs, which the
+// VRGs leave behind as they are deleted while their cluster is unavailable, or as their protection is disabled.
+// Metadata is orphaned only if this hub recorded it as its DRPC was deleted, and is deleted once it was orphaned for
+// the retention period, unless a DRPC of the same namespace and name exists again. Metadata that is merely not
+// referenced by a DRPC UID known to this hub is left as is: a recovered hub recreates its DRPCs with new UIDs, and
+// the VRGs of unavailable clusters keep the UIDs of the previous hub.
+type S3GarbageCollector struct {
+	client.Client
+	APIReader         client.Reader
+	ObjectStoreGetter ObjectStoreGetter
+	Log               logr.Logger
+	Interval          time.Duration
+	RetentionPeriod   time.Duration
+	DryRun            bool
+}
+
+// NeedLeaderElection runs the garbage collector only on the leader, alongside the hub reconcilers
+func (g *S3GarbageCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs an audit every interval until ctx is done
+func (g *S3GarbageCollector) Start(ctx context.Context) error {
+	interval := g.Interval
+	if interval <= 0 {
+		interval = defaultS3GarbageCollectionInterval
+	}
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := g.collect(ctx, time.Now()); err != nil {
+			g.Log.Error(err, "S3 garbage collection failed")
+		}
+	}, interval)
+
+	return nil
+}
+
+// collect audits the S3 profiles of the ramen config
+func (g *S3GarbageCollector) collect(ctx context.Context, now time.Time) error {
+	_, ramenConfig, err := ConfigMapGet(ctx, g.APIReader)
+	if err != nil {
+		return fmt.Errorf("failed to get ramen config: %w", err)
+	}
+
+	hub, err := hubID(ctx, g.APIReader)
+	if err != nil {
+		return err
+	}
+
+	drpcs := &rmn.DRPlacementControlList{}
+	if err := g.List(ctx, drpcs); err != nil {
+		return fmt.Errorf("failed to list DRPCs: %w", err)
+	}
+
+	drpcNames := sets.New[types.NamespacedName]()
+	for i := range drpcs.Items {
+		drpcNames.Insert(client.ObjectKeyFromObject(&drpcs.Items[i]))
+	}
+
+	var errs []error
+
+	for i := range ramenConfig.S3StoreProfiles {
+		profileName := ramenConfig.S3StoreProfiles[i].S3ProfileName
+		if err := g.collectProfile(ctx, profileName, hub, drpcNames, now); err != nil {
+			errs = append(errs, fmt.Errorf("s3 profile %s: %w", profileName, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// collectProfile deletes the key prefixes of the S3 profile recorded as orphaned by this hub whose retention period
+// elapsed
+func (g *S3GarbageCollector) collectProfile(ctx context.Context, profileName, hub string,
+	drpcNames sets.Set[types.NamespacedName], now time.Time,
+) error {
+	log := g.Log.WithValues("s3Profile", profileName)
+
+	objectStore, _, err := g.ObjectStoreGetter.ObjectStore(ctx, g.APIReader, profileName, "s3 garbage collection",
+		log)
+	if err != nil {
+		return err
+	}
+
+	keys, err := objectStore.ListKeys(s3OrphanedPrefixKeyPrefix)
+	if err != nil {
+		return err
+	}
+
+	orphaned := 0
+
+	var errs []error
+
+	for _, key := range keys {
+		prefix := strings.TrimPrefix(key, s3OrphanedPrefixKeyPrefix) + "/"
+
+		isOrphaned, err := g.collectPrefix(objectStore, profileName, prefix, hub, drpcNames, now, log)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("key prefix %s: %w", prefix, err))
+		}
+
+		if isOrphaned {
+			orphaned++
+		}
+	}
+
+	NewS3OrphanedPrefixesMetric(S3GarbageCollectionLabels(profileName)).Set(float64(orphaned))
+	log.Info("S3 garbage collection done", "orphaned", orphaned)
+
+	return errors.Join(errs...)
+}
+
+// collectPrefix returns whether the key prefix recorded as orphaned by this hub is still orphaned, deleting it if its
+// retention period elapsed. The record of a prefix whose DRPC exists again is deleted. A prefix recorded by another
+// hub is left to that hub.
+func (g *S3GarbageCollector) collectPrefix(objectStore ObjectStorer, profileName, prefix, hub string,
+	drpcNames sets.Set[types.NamespacedName], now time.Time, log logr.Logger,
+) (bool, error) {
+	record := s3OrphanedPrefix{}
+	if err := objectStore.DownloadObject(s3OrphanedPrefixKey(prefix), &record); err != nil {
+		return false, err
+	}
+
+	if record.HubID != hub {
+		log.V(1).Info("Orphaned key prefix recorded by another hub, kept", "prefix", prefix, "hubID", record.HubID)
+
+		return false, nil
+	}
+
+	if drpcNames.Has(types.NamespacedName{Namespace: record.DRPCNamespace, Name: record.DRPCName}) {
+		log.Info("Orphaned key prefix used again", "prefix", prefix)
+
+		return false, objectStore.DeleteObject(s3OrphanedPrefixKey(prefix))
+	}
+
+	if now.Sub(record.OrphanedTime.Time) < g.RetentionPeriod {
+		return true, nil
+	}
+
+	if g.DryRun {
+		log.Info("Orphaned key prefix due for deletion, kept for dry run", "prefix", prefix,
+			"orphanedTime", record.OrphanedTime)
+
+		return true, nil
+	}
+
+	if err := objectStore.DeleteObjectsWithKeyPrefix(prefix); err != nil {
+		return true, err
+	}
+
+	if err := objectStore.DeleteObject(s3OrphanedPrefixKey(prefix)); err != nil {
+		return true, err
+	}
+
+	log.Info("Deleted orphaned key prefix", "prefix", prefix, "orphanedTime", record.OrphanedTime)
+	NewS3OrphanedPrefixesDeletedMetric(S3GarbageCollectionLabels(profileName)).Inc()
+
+	return false, nil
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// gcObjectStore is an in-memory object store whose objects can be downloaded and deleted by key prefix
+type gcObjectStore struct {
+	healthObjectStore
+}
+
+func (s *gcObjectStore) DownloadObject(key string, objectPointer interface{}) error {
+	object, ok := s.objects[key]
+	if !ok {
+		return fmt.Errorf("%s: no such key", key)
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, objectPointer)
+}
+
+func (s *gcObjectStore) DeleteObjectsWithKeyPrefix(keyPrefix string) error {
+	keys, _ := s.ListKeys(keyPrefix)
+	for _, key := range keys {
+		delete(s.objects, key)
+	}
+
+	return nil
+}
+
+var _ = Describe("S3GarbageCollector", func() {
+	var (
+		fakeClient client.Client
+		store      *gcObjectStore
+		collector  *S3GarbageCollector
+		r          *DRPlacementControlReconciler
+		drPolicy   *rmn.DRPolicy
+		now        time.Time
+	)
+
+	vrgUpload := func(namespace, name, drpcUID string) {
+		vrg := &rmn.VolumeReplicationGroup{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		if drpcUID != "" {
+			vrg.Annotations = map[string]string{DRPCUIDAnnotation: drpcUID}
+		}
+
+		prefix := s3PathNamePrefix(namespace, name)
+		Expect(store.UploadObject(metadata.TypedKey(prefix, metadata.TypeNameVolumeReplicationGroup,
+			metadata.VolumeReplicationGroupName), vrg)).To(Succeed())
+		Expect(store.UploadObject(prefix+"v1.PersistentVolume/pv1", corev1.PersistentVolume{})).To(Succeed())
+	}
+
+	keys := func(keyPrefix string) []string {
+		keys, err := store.ListKeys(keyPrefix)
+		Expect(err).ToNot(HaveOccurred())
+
+		return keys
+	}
+
+	drpcDelete := func(name, uid string) {
+		r.s3OrphanedPrefixRecord(context.TODO(), &rmn.DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: name, UID: types.UID(uid)},
+		}, drPolicy, "app", logr.Discard())
+	}
+
+	BeforeEach(func() {
+		controllerType := ControllerType
+		ControllerType = rmn.DRHubType
+		DeferCleanup(func() { ControllerType = controllerType })
+
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		configMap, err := ConfigMapNew(RamenOperatorNamespace(), HubOperatorConfigMapName, &rmn.RamenConfig{
+			S3StoreProfiles:     []rmn.S3StoreProfile{{S3ProfileName: "east"}},
+			S3GarbageCollection: rmn.S3GarbageCollection{Enabled: true},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			configMap,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceSystem, UID: "hub-1"}},
+			&rmn.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "live", UID: "uid-live"}},
+			&rmn.DRCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "east"},
+				Spec:       rmn.DRClusterSpec{S3ProfileName: "east"},
+			},
+		).Build()
+
+		store = &gcObjectStore{healthObjectStore{objects: map[string]interface{}{}}}
+		collector = &S3GarbageCollector{
+			Client:            fakeClient,
+			APIReader:         fakeClient,
+			ObjectStoreGetter: qualificationObjectStoreGetter{"east": store},
+			Log:               logr.Discard(),
+			RetentionPeriod:   time.Hour,
+		}
+		r = &DRPlacementControlReconciler{
+			Client:         fakeClient,
+			APIReader:      fakeClient,
+			ObjStoreGetter: collector.ObjectStoreGetter,
+		}
+		drPolicy = &rmn.DRPolicy{Spec: rmn.DRPolicySpec{DRClusters: []string{"east"}}}
+		now = time.Now()
+
+		vrgUpload("app", "live", "uid-live")
+		vrgUpload("app", "deleted", "uid-deleted")
+		vrgUpload("app", "standalone", "")
+	})
+
+	It("deletes the metadata of the VRG of a DRPC deleted by this hub once the retention period elapses", func() {
+		drpcDelete("deleted", "uid-deleted")
+		Expect(keys(s3OrphanedPrefixKeyPrefix)).To(ConsistOf(s3OrphanedPrefixKey("app/deleted/")))
+
+		Expect(collector.collect(context.TODO(), now.Add(30*time.Minute))).To(Succeed())
+		Expect(keys("app/deleted/")).To(HaveLen(2))
+
+		Expect(collector.collect(context.TODO(), now.Add(2*time.Hour))).To(Succeed())
+		Expect(keys("app/deleted/")).To(BeEmpty())
+		Expect(keys(s3OrphanedPrefixKeyPrefix)).To(BeEmpty())
+		Expect(keys("app/live/")).To(HaveLen(2))
+		Expect(keys("app/standalone/")).To(HaveLen(2))
+	})
+
+	It("keeps the metadata of VRGs whose DRPC UID is unknown unless their DRPC was deleted by this hub", func() {
+		Expect(collector.collect(context.TODO(), now)).To(Succeed())
+		Expect(collector.collect(context.TODO(), now.Add(2*time.Hour))).To(Succeed())
+		Expect(keys("app/deleted/")).To(HaveLen(2))
+		Expect(keys(s3OrphanedPrefixKeyPrefix)).To(BeEmpty())
+	})
+
+	It("keeps the metadata of an unreachable cluster through hub recovery, which recreates DRPCs with new UIDs", func() {
+		// the VRG of the unreachable cluster keeps the DRPC UID of the previous hub
+		vrgUpload("app", "live", "uid-previous-hub")
+
+		Expect(collector.collect(context.TODO(), now)).To(Succeed())
+		Expect(collector.collect(context.TODO(), now.Add(2*time.Hour))).To(Succeed())
+		Expect(keys("app/live/")).To(HaveLen(2))
+		Expect(keys(s3OrphanedPrefixKeyPrefix)).To(BeEmpty())
+	})
+
+	It("leaves the metadata recorded as orphaned by another hub sharing the bucket to that hub", func() {
+		Expect(store.UploadObject(s3OrphanedPrefixKey("app/deleted/"), s3OrphanedPrefix{
+			HubID: "hub-2", DRPCNamespace: "app", DRPCName: "deleted", OrphanedTime: metav1.NewTime(now),
+		})).To(Succeed())
+
+		Expect(collector.collect(context.TODO(), now.Add(2*time.Hour))).To(Succeed())
+		Expect(keys("app/deleted/")).To(HaveLen(2))
+		Expect(keys(s3OrphanedPrefixKeyPrefix)).To(HaveLen(1))
+	})
+
+	It("reports the metadata due for deletion without deleting it in dry run", func() {
+		collector.DryRun = true
+		drpcDelete("deleted", "uid-deleted")
+
+		Expect(collector.collect(context.TODO(), now.Add(2*time.Hour))).To(Succeed())
+		Expect(keys("app/deleted/")).To(HaveLen(2))
+		Expect(keys(s3OrphanedPrefixKeyPrefix)).To(HaveLen(1))
+	})
+
+	It("keeps the metadata recorded as orphaned once a DRPC of the same namespace and name exists again", func() {
+		drpcDelete("live", "uid-old")
+		Expect(keys(s3OrphanedPrefixKeyPrefix)).To(HaveLen(1))
+
+		Expect(collector.collect(context.TODO(), now.Add(2*time.Hour))).To(Succeed())
+		Expect(keys("app/live/")).To(HaveLen(2))
+		Expect(keys(s3OrphanedPrefixKeyPrefix)).To(BeEmpty())
+	})
+})
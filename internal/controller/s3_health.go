@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// defaultS3ProfileHealthCheckInterval is the interval between probes of the S3 profiles if the ramen config
+	// sets none
+	defaultS3ProfileHealthCheckInterval = 5 * time.Minute
+
+	// s3ProfileHealthProbeKey is the key of the object the probe puts, lists and deletes. It starts with a dot so
+	// that it is not the key of the metadata of a namespace.
+	s3ProfileHealthProbeKey = ".ramen-health-probe"
+
+	s3ProfileAvailableReason = "Available"
+)
+
+func S3ProfileHealthCheckEnabled(ramenConfig *rmn.RamenConfig) bool {
+	return ramenConfig != nil && ramenConfig.S3ProfileHealthCheck.Enabled
+}
+
+func S3ProfileHealthCheckInterval(ramenConfig *rmn.RamenConfig) time.Duration {
+	interval := ramenConfig.S3ProfileHealthCheck.Interval
+	if interval == nil || interval.Duration <= 0 {
+		return defaultS3ProfileHealthCheckInterval
+	}
+
+	return interval.Duration
+}
+
+// s3ProfileHealth is the result of the probe of an S3 profile, with the reason and error of the operation that
+// failed, if any
+type s3ProfileHealth struct {
+	reason string
+	err    error
+}
+
+// S3ProfileHealthChecker periodically probes the S3 profiles of the ramen config and of the DRClusters, by putting,
+// listing and deleting an object, and reports their health in the conditions of the DRClusters and DRPolicies and
+// in metrics. DRClusters are otherwise only validated as they are reconciled, so that an object store outage would
+// go unnoticed until a failover needs the metadata stored in it.
+type S3ProfileHealthChecker struct {
+	client.Client
+	APIReader         client.Reader
+	ObjectStoreGetter ObjectStoreGetter
+	Log               logr.Logger
+	Interval          time.Duration
+
+	// probed are the profiles whose metrics were reported by the last pass
+	probed []string
+}
+
+// NeedLeaderElection runs the health checker only on the leader, alongside the hub reconcilers
+func (h *S3ProfileHealthChecker) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs a health check pass every interval until ctx is done
+func (h *S3ProfileHealthChecker) Start(ctx context.Context) error {
+	interval := h.Interval
+	if interval <= 0 {
+		interval = defaultS3ProfileHealthCheckInterval
+	}
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := h.check(ctx); err != nil {
+			h.Log.Error(err, "S3 profile health check failed")
+		}
+	}, interval)
+
+	return nil
+}
+
+// check probes the S3 profiles, and sets the conditions of the DRClusters and DRPolicies to their health
+func (h *S3ProfileHealthChecker) check(ctx context.Context) error {
+	_, ramenConfig, err := ConfigMapGet(ctx, h.APIReader)
+	if err != nil {
+		return fmt.Errorf("failed to get ramen config: %w", err)
+	}
+
+	drClusters := &rmn.DRClusterList{}
+	if err := h.List(ctx, drClusters); err != nil {
+		return fmt.Errorf("failed to list DRClusters: %w", err)
+	}
+
+	drPolicies := &rmn.DRPolicyList{}
+	if err := h.List(ctx, drPolicies); err != nil {
+		return fmt.Errorf("failed to list DRPolicies: %w", err)
+	}
+
+	profileNames := []string{}
+	for i := range ramenConfig.S3StoreProfiles {
+		profileNames = append(profileNames, ramenConfig.S3StoreProfiles[i].S3ProfileName)
+	}
+
+	for i := range drClusters.Items {
+		profileNames = append(profileNames, drClusters.Items[i].Spec.S3ProfileName)
+	}
+
+	sort.Strings(profileNames)
+	profileNames = slices.Compact(slices.DeleteFunc(profileNames, func(profileName string) bool {
+		return profileName == "" || profileName == NoS3StoreAvailable
+	}))
+
+	healths := map[string]s3ProfileHealth{}
+	for _, profileName := range profileNames {
+		healths[profileName] = h.probe(ctx, profileName)
+	}
+
+	h.metricsDelete(profileNames)
+
+	var errs []error
+
+	for i := range drClusters.Items {
+		errs = append(errs, h.drClusterConditionSet(ctx, &drClusters.Items[i], healths))
+	}
+
+	for i := range drPolicies.Items {
+		errs = append(errs, h.drPolicyConditionSet(ctx, &drPolicies.Items[i], drClusters.Items, healths))
+	}
+
+	return errors.Join(errs...)
+}
+
+// probe puts, lists and deletes the probe object in the S3 profile, and reports its health in metrics
+func (h *S3ProfileHealthChecker) probe(ctx context.Context, profileName string) s3ProfileHealth {
+	log := h.Log.WithValues("s3Profile", profileName)
+	labels := S3ProfileHealthLabels(profileName)
+	health := h.probeOperations(ctx, profileName, log)
+
+	if health.err != nil {
+		log.Info("S3 profile health probe failed", "reason", health.reason, "error", health.err.Error())
+		NewS3ProfileAvailableMetric(labels).Set(0)
+		NewS3ProfileProbeFailuresMetric(labels).Inc()
+
+		return health
+	}
+
+	NewS3ProfileAvailableMetric(labels).Set(1)
+	// Initializes the failures of a healthy profile to 0
+	NewS3ProfileProbeFailuresMetric(labels)
+
+	return health
+}
+
+func (h *S3ProfileHealthChecker) probeOperations(ctx context.Context, profileName string, log logr.Logger,
+) s3ProfileHealth {
+	objectStore, _, err := h.ObjectStoreGetter.ObjectStore(ctx, h.APIReader, profileName,
+		"s3 profile health check", log)
+	if err != nil {
+		if errors.Is(err, errObjectStoreTransportInvalid) {
+			return s3ProfileHealth{reason: "s3TransportInvalid", err: err}
+		}
+
+		return s3ProfileHealth{reason: "s3ConnectionFailed", err: err}
+	}
+
+	if err := objectStore.UploadObject(s3ProfileHealthProbeKey, metav1.Now()); err != nil {
+		return s3ProfileHealth{reason: "s3PutFailed", err: err}
+	}
+
+	keys, err := objectStore.ListKeys(s3ProfileHealthProbeKey)
+	if err != nil {
+		return s3ProfileHealth{reason: "s3ListFailed", err: err}
+	}
+
+	if !slices.Contains(keys, s3ProfileHealthProbeKey) {
+		return s3ProfileHealth{reason: "s3ListFailed", err: fmt.Errorf("probe object %s put is not listed",
+			s3ProfileHealthProbeKey)}
+	}
+
+	if err := objectStore.DeleteObject(s3ProfileHealthProbeKey); err != nil {
+		return s3ProfileHealth{reason: "s3DeleteFailed", err: err}
+	}
+
+	return s3ProfileHealth{reason: s3ProfileAvailableReason}
+}
+
+// metricsDelete deletes the metrics of the profiles probed by the last pass that were not probed by this one
+func (h *S3ProfileHealthChecker) metricsDelete(profileNames []string) {
+	for _, profileName := range h.probed {
+		if !slices.Contains(profileNames, profileName) {
+			DeleteS3ProfileHealthMetrics(S3ProfileHealthLabels(profileName))
+		}
+	}
+
+	h.probed = profileNames
+}
+
+func (h *S3ProfileHealthChecker) drClusterConditionSet(ctx context.Context, drCluster *rmn.DRCluster,
+	healths map[string]s3ProfileHealth,
+) error {
+	health, ok := healths[drCluster.Spec.S3ProfileName]
+	if !ok {
+		return nil
+	}
+
+	status, message := metav1.ConditionTrue, fmt.Sprintf("S3 profile %s is available", drCluster.Spec.S3ProfileName)
+	if health.err != nil {
+		status, message = metav1.ConditionFalse, fmt.Sprintf("S3 profile %s is unavailable: %v",
+			drCluster.Spec.S3ProfileName, health.err)
+	}
+
+	return h.statusConditionSet(ctx, drCluster, &drCluster.Status.Conditions,
+		rmn.DRClusterConditionTypeS3ProfileAvailable, status, health.reason, message)
+}
+
+func (h *S3ProfileHealthChecker) drPolicyConditionSet(ctx context.Context, drPolicy *rmn.DRPolicy,
+	drClusters []rmn.DRCluster, healths map[string]s3ProfileHealth,
+) error {
+	unavailable := []string{}
+	reason := s3ProfileAvailableReason
+
+	for i := range drClusters {
+		drCluster := &drClusters[i]
+		if !slices.Contains(drPolicy.Spec.DRClusters, drCluster.Name) {
+			continue
+		}
+
+		health, ok := healths[drCluster.Spec.S3ProfileName]
+		if !ok || health.err == nil {
+			continue
+		}
+
+		unavailable = append(unavailable, fmt.Sprintf("%s of cluster %s", drCluster.Spec.S3ProfileName,
+			drCluster.Name))
+		reason = health.reason
+	}
+
+	status, message := metav1.ConditionTrue, "S3 profiles of the clusters are available"
+	if len(unavailable) > 0 {
+		status, message = metav1.ConditionFalse, "S3 profiles unavailable: "+strings.Join(unavailable, ", ")
+	}
+
+	return h.statusConditionSet(ctx, drPolicy, &drPolicy.Status.Conditions,
+		rmn.DRPolicyConditionTypeS3ProfilesAvailable, status, reason, message)
+}
+
+// statusConditionSet sets the condition of obj, and patches its status if it changed. The patch fails if obj was
+// updated since it was read, so that the conditions set by its reconciler in the meantime are not reverted, and is
+// made again by the next pass.
+func (h *S3ProfileHealthChecker) statusConditionSet(ctx context.Context, obj client.Object,
+	conditions *[]metav1.Condition, conditionType string, status metav1.ConditionStatus, reason, message string,
+) error {
+	patch := client.MergeFromWithOptions(obj.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+
+	if !rmnutil.GenericStatusConditionSet(obj, conditions, conditionType, status, reason, message,
+		h.Log.V(1)) {
+		return nil
+	}
+
+	if err := h.Status().Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("failed to set condition %s of %T %s: %w", conditionType, obj, obj.GetName(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// healthObjectStore is an in-memory object store whose puts fail while putFails is set
+type healthObjectStore struct {
+	ObjectStorer
+	objects  map[string]interface{}
+	putFails bool
+}
+
+func (s *healthObjectStore) UploadObject(key string, object interface{}) error {
+	if s.putFails {
+		return fmt.Errorf("bucket unavailable")
+	}
+
+	s.objects[key] = object
+
+	return nil
+}
+
+func (s *healthObjectStore) ListKeys(keyPrefix string) ([]string, error) {
+	keys := []string{}
+
+	for key := range s.objects {
+		if strings.HasPrefix(key, keyPrefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+func (s *healthObjectStore) DeleteObject(key string) error {
+	delete(s.objects, key)
+
+	return nil
+}
+
+var _ = Describe("S3ProfileHealthChecker", func() {
+	var (
+		fakeClient client.Client
+		east, west *healthObjectStore
+		checker    *S3ProfileHealthChecker
+	)
+
+	available := func(profileName string) float64 {
+		families, err := metrics.Registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, family := range families {
+			if family.GetName() != metricNamespace+"_"+S3ProfileAvailable {
+				continue
+			}
+
+			for _, m := range family.GetMetric() {
+				if m.GetLabel()[0].GetValue() == profileName {
+					return m.GetGauge().GetValue()
+				}
+			}
+		}
+
+		return -1
+	}
+
+	condition := func(obj client.Object, conditions *[]metav1.Condition, conditionType string) metav1.Condition {
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+
+		for _, condition := range *conditions {
+			if condition.Type == conditionType {
+				return condition
+			}
+		}
+
+		Fail("condition " + conditionType + " not found")
+
+		return metav1.Condition{}
+	}
+
+	BeforeEach(func() {
+		controllerType := ControllerType
+		ControllerType = rmn.DRHubType
+		DeferCleanup(func() { ControllerType = controllerType })
+
+		scheme := runtime.NewScheme()
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		configMap, err := ConfigMapNew(RamenOperatorNamespace(), HubOperatorConfigMapName, &rmn.RamenConfig{
+			S3StoreProfiles: []rmn.S3StoreProfile{{S3ProfileName: "east"}, {S3ProfileName: "west"}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		drCluster := func(name, s3ProfileName string) *rmn.DRCluster {
+			return &rmn.DRCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       rmn.DRClusterSpec{S3ProfileName: s3ProfileName},
+			}
+		}
+		drPolicy := &rmn.DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+			Spec:       rmn.DRPolicySpec{DRClusters: []string{"east", "west"}},
+		}
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(configMap, drCluster("east", "east"), drCluster("west", "west"),
+				drCluster("sync", NoS3StoreAvailable), drPolicy).
+			WithStatusSubresource(&rmn.DRCluster{}, &rmn.DRPolicy{}).
+			Build()
+
+		east = &healthObjectStore{objects: map[string]interface{}{}}
+		west = &healthObjectStore{objects: map[string]interface{}{}}
+		checker = &S3ProfileHealthChecker{
+			Client:            fakeClient,
+			APIReader:         fakeClient,
+			ObjectStoreGetter: qualificationObjectStoreGetter{"east": east, "west": west},
+			Log:               logr.Discard(),
+		}
+	})
+
+	It("reports the profiles that pass the probe as available, and removes the probe object", func() {
+		Expect(checker.check(context.TODO())).To(Succeed())
+
+		Expect(available("east")).To(Equal(float64(1)))
+		Expect(available("west")).To(Equal(float64(1)))
+		Expect(east.objects).To(BeEmpty())
+
+		drCluster := &rmn.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "east"}}
+		Expect(condition(drCluster, &drCluster.Status.Conditions, rmn.DRClusterConditionTypeS3ProfileAvailable)).To(
+			And(HaveField("Status", metav1.ConditionTrue), HaveField("Reason", s3ProfileAvailableReason)))
+
+		drPolicy := &rmn.DRPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}}
+		Expect(condition(drPolicy, &drPolicy.Status.Conditions, rmn.DRPolicyConditionTypeS3ProfilesAvailable)).To(
+			HaveField("Status", metav1.ConditionTrue))
+
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Name: "sync"}, drCluster)).To(Succeed())
+		Expect(drCluster.Status.Conditions).To(BeEmpty())
+	})
+
+	It("reports the outage of a profile in the conditions of its clusters and their policies", func() {
+		west.putFails = true
+		Expect(checker.check(context.TODO())).To(Succeed())
+
+		Expect(available("east")).To(Equal(float64(1)))
+		Expect(available("west")).To(Equal(float64(0)))
+
+		drCluster := &rmn.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "west"}}
+		Expect(condition(drCluster, &drCluster.Status.Conditions, rmn.DRClusterConditionTypeS3ProfileAvailable)).To(
+			And(HaveField("Status", metav1.ConditionFalse), HaveField("Reason", "s3PutFailed"),
+				HaveField("Message", ContainSubstring("bucket unavailable"))))
+
+		drPolicy := &rmn.DRPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}}
+		Expect(condition(drPolicy, &drPolicy.Status.Conditions, rmn.DRPolicyConditionTypeS3ProfilesAvailable)).To(
+			And(HaveField("Status", metav1.ConditionFalse),
+				HaveField("Message", "S3 profiles unavailable: west of cluster west")))
+
+		west.putFails = false
+		Expect(checker.check(context.TODO())).To(Succeed())
+		Expect(available("west")).To(Equal(float64(1)))
+		Expect(condition(drPolicy, &drPolicy.Status.Conditions, rmn.DRPolicyConditionTypeS3ProfilesAvailable)).To(
+			HaveField("Status", metav1.ConditionTrue))
+	})
+
+	It("reports a profile that cannot be connected to as unavailable, and forgets a profile removed", func() {
+		checker.ObjectStoreGetter = qualificationObjectStoreGetter{"east": east}
+		Expect(checker.check(context.TODO())).To(Succeed())
+
+		drCluster := &rmn.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: "west"}}
+		Expect(condition(drCluster, &drCluster.Status.Conditions, rmn.DRClusterConditionTypeS3ProfileAvailable)).To(
+			And(HaveField("Status", metav1.ConditionFalse), HaveField("Reason", "s3ConnectionFailed")))
+
+		Expect(fakeClient.Delete(context.TODO(), drCluster)).To(Succeed())
+
+		configMap, err := ConfigMapNew(RamenOperatorNamespace(), HubOperatorConfigMapName, &rmn.RamenConfig{
+			S3StoreProfiles: []rmn.S3StoreProfile{{S3ProfileName: "east"}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeClient.Update(context.TODO(), configMap)).To(Succeed())
+
+		Expect(checker.check(context.TODO())).To(Succeed())
+		Expect(available("west")).To(Equal(float64(-1)))
+	})
+})
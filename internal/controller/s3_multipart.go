@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec // ETags of S3 parts are their MD5 digests
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/prometheus/client_golang/prometheus"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	s3MultipartUploadPartRetriesDefault = 3
+	s3MultipartUploadPartSizeMax        = 5 << 30
+)
+
+// s3MultipartUploadPartRetryDelay is the delay before the first retry of a part, doubled for each next one
+var s3MultipartUploadPartRetryDelay = time.Second
+
+// s3MultipartUpload is the upload in parts of the objects larger than partSize
+type s3MultipartUpload struct {
+	partSize    int64
+	partRetries int
+	retries     prometheus.Counter
+	resumed     prometheus.Counter
+}
+
+// s3MultipartUploadGet returns the multipart upload of the S3 profile, or an error if it is not supported
+func s3MultipartUploadGet(s3StoreProfile ramen.S3StoreProfile) (s3MultipartUpload, error) {
+	labels := S3UploadPartsLabels(s3StoreProfile.S3ProfileName)
+	multipartUpload := s3MultipartUpload{
+		partSize:    s3manager.MinUploadPartSize,
+		partRetries: s3MultipartUploadPartRetriesDefault,
+		retries:     NewS3UploadPartRetriesMetric(labels),
+		resumed:     NewS3UploadPartsResumedMetric(labels),
+	}
+
+	spec := s3StoreProfile.MultipartUpload
+	if spec == nil {
+		return multipartUpload, nil
+	}
+
+	if spec.PartSize != nil {
+		multipartUpload.partSize = spec.PartSize.Value()
+		if multipartUpload.partSize < s3manager.MinUploadPartSize ||
+			multipartUpload.partSize > s3MultipartUploadPartSizeMax {
+			return multipartUpload, fmt.Errorf("multipart upload part size %s of s3 profile %s is not between 5Mi "+
+				"and 5Gi", spec.PartSize.String(), s3StoreProfile.S3ProfileName)
+		}
+	}
+
+	if spec.PartRetries != nil {
+		multipartUpload.partRetries = *spec.PartRetries
+		if multipartUpload.partRetries < 0 {
+			return multipartUpload, fmt.Errorf("multipart upload part retries %d of s3 profile %s is negative",
+				multipartUpload.partRetries, s3StoreProfile.S3ProfileName)
+		}
+	}
+
+	return multipartUpload, nil
+}
+
+// uploadMultipart uploads data in parts as the object with key, resuming the upload of the object that failed last,
+// if any, without uploading again the parts it uploaded. A failed upload is left to be resumed, and is aborted by
+// the next upload of the object if it cannot be resumed, or by the lifecycle rules of the bucket.
+func (s *s3ObjectStore) uploadMultipart(key string, data []byte, contentEncoding string) error {
+	bucket := s.s3Bucket
+
+	uploadID, uploadedParts, err := s.multipartUploadResumable(key)
+	if err != nil {
+		return processAwsError(fmt.Errorf("failed to list multipart uploads of %s:%s", bucket, key), err)
+	}
+
+	if uploadID == "" {
+		input := &s3.CreateMultipartUploadInput{Bucket: &bucket, Key: &key}
+		if contentEncoding != "" {
+			input.ContentEncoding = aws.String(contentEncoding)
+		}
+
+		output, err := s3Call(func(ctx context.Context) (*s3.CreateMultipartUploadOutput, error) {
+			return s.client.CreateMultipartUploadWithContext(ctx, input)
+		})
+		if err != nil {
+			return processAwsError(fmt.Errorf("failed to create multipart upload of %s:%s", bucket, key), err)
+		}
+
+		uploadID = aws.StringValue(output.UploadId)
+	}
+
+	parts := []*s3.CompletedPart{}
+
+	for number, offset := int64(1), int64(0); offset < int64(len(data)); number, offset = number+1,
+		offset+s.multipartUpload.partSize {
+		part := data[offset:min(offset+s.multipartUpload.partSize, int64(len(data)))]
+		digest := md5.Sum(part) //nolint:gosec
+		etag := `"` + hex.EncodeToString(digest[:]) + `"`
+
+		if uploadedParts[number] == etag {
+			s.multipartUpload.resumed.Inc()
+		} else if err := s.uploadPart(key, uploadID, number, part, digest[:]); err != nil {
+			return processAwsError(fmt.Errorf("failed to upload part %d of %s:%s, to be resumed by the next "+
+				"upload", number, bucket, key), err)
+		}
+
+		parts = append(parts, &s3.CompletedPart{ETag: aws.String(etag), PartNumber: aws.Int64(number)})
+	}
+
+	if _, err := s3Call(func(ctx context.Context) (*s3.CompleteMultipartUploadOutput, error) {
+		return s.client.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          &bucket,
+			Key:             &key,
+			UploadId:        &uploadID,
+			MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+		})
+	}); err != nil {
+		return processAwsError(fmt.Errorf("failed to complete multipart upload of %s:%s", bucket, key), err)
+	}
+
+	return nil
+}
+
+// uploadPart uploads part with number, retrying it with an exponential backoff if it fails with an error that is
+// retryable, like a connection reset or a timeout
+func (s *s3ObjectStore) uploadPart(key, uploadID string, number int64, part, digest []byte) error {
+	// The timeout of each attempt grows with the size of the part
+	timeout := s3Timeout * time.Duration(1+int64(len(part))/s3manager.MinUploadPartSize)
+	delay := s3MultipartUploadPartRetryDelay
+
+	for retry := 0; ; retry++ {
+		ctx, cancel := context.WithTimeout(context.TODO(), timeout)
+		_, err := s.client.UploadPartWithContext(ctx, &s3.UploadPartInput{
+			Bucket:     &s.s3Bucket,
+			Key:        &key,
+			UploadId:   &uploadID,
+			PartNumber: aws.Int64(number),
+			Body:       bytes.NewReader(part),
+			ContentMD5: aws.String(base64.StdEncoding.EncodeToString(digest)),
+		})
+		timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded)
+
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+
+		if retry == s.multipartUpload.partRetries || !(timedOut || s3ErrorRetryable(err)) {
+			return err
+		}
+
+		s.multipartUpload.retries.Inc()
+		time.Sleep(delay)
+
+		delay *= 2
+	}
+}
+
+// s3ErrorRetryable returns whether err is a connection error, a throttling error, or a server error, that a retry may
+// not fail with
+func s3ErrorRetryable(err error) bool {
+	var requestFailure awserr.RequestFailure
+	if errors.As(err, &requestFailure) && (requestFailure.StatusCode() >= http.StatusInternalServerError ||
+		requestFailure.StatusCode() == http.StatusTooManyRequests) {
+		return true
+	}
+
+	return request.IsErrorRetryable(err) || request.IsErrorThrottle(err)
+}
+
+// multipartUploadResumable returns the id of the latest multipart upload of the object with key, and the ETags of
+// its parts by number, aborting its other multipart uploads, if any
+func (s *s3ObjectStore) multipartUploadResumable(key string) (string, map[int64]string, error) {
+	output, err := s3Call(func(ctx context.Context) (*s3.ListMultipartUploadsOutput, error) {
+		return s.client.ListMultipartUploadsWithContext(ctx, &s3.ListMultipartUploadsInput{
+			Bucket: &s.s3Bucket,
+			Prefix: &key,
+		})
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	uploads := []*s3.MultipartUpload{}
+
+	for _, upload := range output.Uploads {
+		if aws.StringValue(upload.Key) == key {
+			uploads = append(uploads, upload)
+		}
+	}
+
+	if len(uploads) == 0 {
+		return "", nil, nil
+	}
+
+	sort.Slice(uploads, func(i, j int) bool {
+		return aws.TimeValue(uploads[i].Initiated).After(aws.TimeValue(uploads[j].Initiated))
+	})
+
+	for _, upload := range uploads[1:] {
+		if _, err := s3Call(func(ctx context.Context) (*s3.AbortMultipartUploadOutput, error) {
+			return s.client.AbortMultipartUploadWithContext(ctx, &s3.AbortMultipartUploadInput{
+				Bucket:   &s.s3Bucket,
+				Key:      &key,
+				UploadId: upload.UploadId,
+			})
+		}); err != nil {
+			return "", nil, err
+		}
+	}
+
+	uploadID := aws.StringValue(uploads[0].UploadId)
+	parts := map[int64]string{}
+	ctx, cancel := context.WithTimeout(context.TODO(), s3Timeout)
+
+	defer cancel()
+
+	if err := s.client.ListPartsPagesWithContext(ctx, &s3.ListPartsInput{
+		Bucket:   &s.s3Bucket,
+		Key:      &key,
+		UploadId: &uploadID,
+	}, func(page *s3.ListPartsOutput, _ bool) bool {
+		for _, part := range page.Parts {
+			parts[aws.Int64Value(part.PartNumber)] = aws.StringValue(part.ETag)
+		}
+
+		return true
+	}); err != nil {
+		return "", nil, err
+	}
+
+	return uploadID, parts, nil
+}
+
+// s3Call calls call with a context that times out after s3Timeout
+func s3Call[T any](call func(context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), s3Timeout)
+	defer cancel()
+
+	return call(ctx)
+}
@@ -0,0 +1,292 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// fakeS3Service serves the requests of the S3 API that upload objects, in parts or not, to a single bucket
+type fakeS3Service struct {
+	mutex   sync.Mutex
+	objects map[string][]byte
+	uploads map[string]*fakeS3Upload
+
+	// failures is the number of times the next uploads of each part number fail
+	failures map[int]int
+
+	// partUploads is the number of uploads of each part number
+	partUploads map[int]int
+	uploadID    int
+}
+
+type fakeS3Upload struct {
+	key       string
+	initiated time.Time
+	parts     map[int][]byte
+}
+
+func (s *fakeS3Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+	query := r.URL.Query()
+	uploadID := query.Get("uploadId")
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		s.uploadID++
+		id := strconv.Itoa(s.uploadID)
+		s.uploads[id] = &fakeS3Upload{key: key, initiated: time.Now(), parts: map[int][]byte{}}
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><Key>%s</Key><UploadId>%s</UploadId>"+
+			"</InitiateMultipartUploadResult>", key, id)
+	case r.Method == http.MethodPut && uploadID != "":
+		s.uploadPart(w, r, uploadID)
+	case r.Method == http.MethodPost && uploadID != "":
+		s.complete(w, r, uploadID)
+	case r.Method == http.MethodGet && query.Has("uploads"):
+		s.listUploads(w, query.Get("prefix"))
+	case r.Method == http.MethodGet && uploadID != "":
+		s.listParts(w, uploadID)
+	case r.Method == http.MethodDelete && uploadID != "":
+		delete(s.uploads, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		s.objects[key], _ = io.ReadAll(r.Body)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func (s *fakeS3Service) uploadPart(w http.ResponseWriter, r *http.Request, uploadID string) {
+	number, _ := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	part, _ := io.ReadAll(r.Body)
+	s.partUploads[number]++
+
+	if s.failures[number] > 0 {
+		s.failures[number]--
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, "<Error><Code>SlowDown</Code><Message>slow down</Message></Error>")
+
+		return
+	}
+
+	s.uploads[uploadID].parts[number] = part
+	w.Header().Set("ETag", fakeS3ETag(part))
+}
+
+func (s *fakeS3Service) complete(w http.ResponseWriter, r *http.Request, uploadID string) {
+	completed := struct {
+		Parts []struct {
+			PartNumber int
+			ETag       string
+		} `xml:"Part"`
+	}{}
+	body, _ := io.ReadAll(r.Body)
+	Expect(xml.Unmarshal(body, &completed)).To(Succeed())
+
+	upload := s.uploads[uploadID]
+	object := []byte{}
+
+	for _, part := range completed.Parts {
+		data := upload.parts[part.PartNumber]
+		Expect(fakeS3ETag(data)).To(Equal(part.ETag))
+
+		object = append(object, data...)
+	}
+
+	s.objects[upload.key] = object
+	delete(s.uploads, uploadID)
+	fmt.Fprintf(w, "<CompleteMultipartUploadResult><Key>%s</Key></CompleteMultipartUploadResult>", upload.key)
+}
+
+func (s *fakeS3Service) listUploads(w http.ResponseWriter, prefix string) {
+	fmt.Fprint(w, "<ListMultipartUploadsResult>")
+
+	for id, upload := range s.uploads {
+		if strings.HasPrefix(upload.key, prefix) {
+			fmt.Fprintf(w, "<Upload><Key>%s</Key><UploadId>%s</UploadId><Initiated>%s</Initiated></Upload>",
+				upload.key, id, upload.initiated.UTC().Format(time.RFC3339Nano))
+		}
+	}
+
+	fmt.Fprint(w, "</ListMultipartUploadsResult>")
+}
+
+func (s *fakeS3Service) listParts(w http.ResponseWriter, uploadID string) {
+	numbers := []int{}
+	for number := range s.uploads[uploadID].parts {
+		numbers = append(numbers, number)
+	}
+
+	sort.Ints(numbers)
+	fmt.Fprint(w, "<ListPartsResult><IsTruncated>false</IsTruncated>")
+
+	for _, number := range numbers {
+		fmt.Fprintf(w, "<Part><PartNumber>%d</PartNumber><ETag>%s</ETag></Part>", number,
+			fakeS3ETag(s.uploads[uploadID].parts[number]))
+	}
+
+	fmt.Fprint(w, "</ListPartsResult>")
+}
+
+func fakeS3ETag(data []byte) string {
+	digest := md5.Sum(data) //nolint:gosec
+
+	return `"` + hex.EncodeToString(digest[:]) + `"`
+}
+
+var _ = Describe("Multipart upload", func() {
+	const (
+		key     = "ns/vrg/kube-objects/1/differential/delta"
+		profile = "multipart-profile"
+	)
+
+	var (
+		service *fakeS3Service
+		store   *s3ObjectStore
+		object  map[string]string
+	)
+
+	counter := func(name string) float64 {
+		families, err := metrics.Registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, family := range families {
+			if family.GetName() != metricNamespace+"_"+name {
+				continue
+			}
+
+			for _, m := range family.GetMetric() {
+				if m.GetLabel()[0].GetValue() == profile {
+					return m.GetCounter().GetValue()
+				}
+			}
+		}
+
+		return 0
+	}
+
+	BeforeEach(func() {
+		service = &fakeS3Service{
+			objects:     map[string][]byte{},
+			uploads:     map[string]*fakeS3Upload{},
+			failures:    map[int]int{},
+			partUploads: map[int]int{},
+		}
+		server := httptest.NewServer(service)
+		DeferCleanup(server.Close)
+
+		retryDelay := s3MultipartUploadPartRetryDelay
+		s3MultipartUploadPartRetryDelay = time.Millisecond
+		DeferCleanup(func() { s3MultipartUploadPartRetryDelay = retryDelay })
+
+		s3Session, err := session.NewSession(&aws.Config{
+			Credentials:      credentials.NewStaticCredentials("id", "secret", ""),
+			Endpoint:         aws.String(server.URL),
+			Region:           aws.String("us-east-1"),
+			S3ForcePathStyle: aws.Bool(true),
+			MaxRetries:       aws.Int(0),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		s3Client := s3.New(s3Session)
+		multipartUpload, err := s3MultipartUploadGet(ramen.S3StoreProfile{
+			S3ProfileName:   profile,
+			MultipartUpload: &ramen.MultipartUpload{PartRetries: aws.Int(2)},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		store = &s3ObjectStore{
+			client:          s3Client,
+			uploader:        s3manager.NewUploaderWithClient(s3Client),
+			s3Bucket:        "bucket",
+			compression:     objectCompression{Algorithm: ramen.ObjectCompressionNone},
+			multipartUpload: multipartUpload,
+		}
+		// Three parts, the last one shorter
+		object = map[string]string{"data": strings.Repeat("x", 2*int(s3manager.MinUploadPartSize)+1000)}
+	})
+
+	uploaded := func() map[string]string {
+		downloaded := map[string]string{}
+		Expect(metadata.Decode(service.objects[key], &downloaded)).To(Succeed())
+
+		return downloaded
+	}
+
+	It("uploads the objects no larger than a part whole", func() {
+		Expect(store.UploadObject(key, map[string]string{"data": "small"})).To(Succeed())
+		Expect(uploaded()).To(Equal(map[string]string{"data": "small"}))
+		Expect(service.partUploads).To(BeEmpty())
+	})
+
+	It("uploads the larger objects in parts, retrying the parts that fail", func() {
+		retries := counter(S3UploadPartRetries)
+		service.failures[2] = 2
+
+		Expect(store.UploadObject(key, object)).To(Succeed())
+		Expect(uploaded()).To(Equal(object))
+		Expect(service.partUploads).To(Equal(map[int]int{1: 1, 2: 3, 3: 1}))
+		Expect(counter(S3UploadPartRetries) - retries).To(Equal(float64(2)))
+		Expect(service.uploads).To(BeEmpty())
+	})
+
+	It("resumes a failed upload without uploading its parts again", func() {
+		resumed := counter(S3UploadPartsResumed)
+		service.failures[3] = 3
+
+		Expect(store.UploadObject(key, object)).To(MatchError(ContainSubstring(
+			"failed to upload part 3 of bucket:" + key + ", to be resumed by the next upload")))
+		Expect(service.objects).ToNot(HaveKey(key))
+		Expect(service.uploads).To(HaveLen(1))
+
+		Expect(store.UploadObject(key, object)).To(Succeed())
+		Expect(uploaded()).To(Equal(object))
+		Expect(service.partUploads).To(Equal(map[int]int{1: 1, 2: 1, 3: 4}))
+		Expect(counter(S3UploadPartsResumed) - resumed).To(Equal(float64(2)))
+		Expect(service.uploads).To(BeEmpty())
+	})
+
+	It("rejects part sizes S3 does not support and negative retries", func() {
+		partSize := resource.MustParse("1Mi")
+		_, err := s3MultipartUploadGet(ramen.S3StoreProfile{
+			S3ProfileName:   "profile",
+			MultipartUpload: &ramen.MultipartUpload{PartSize: &partSize},
+		})
+		Expect(err).To(MatchError("multipart upload part size 1Mi of s3 profile profile is not between 5Mi and 5Gi"))
+
+		_, err = s3MultipartUploadGet(ramen.S3StoreProfile{
+			S3ProfileName:   "profile",
+			MultipartUpload: &ramen.MultipartUpload{PartRetries: aws.Int(-1)},
+		})
+		Expect(err).To(MatchError("multipart upload part retries -1 of s3 profile profile is negative"))
+	})
+})
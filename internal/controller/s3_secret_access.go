@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var s3SecretImpersonation struct {
+	sync.Mutex
+	restConfig *rest.Config
+	options    client.Options
+	readers    map[ramen.ServiceAccountReference]client.Reader
+}
+
+// ConfigureS3SecretImpersonation sets the config of the clients that read the secrets of the S3 profiles as the
+// service accounts the profiles impersonate. Profiles that impersonate a service account fail to be used until it
+// is set.
+func ConfigureS3SecretImpersonation(restConfig *rest.Config, options client.Options) {
+	s3SecretImpersonation.Lock()
+	defer s3SecretImpersonation.Unlock()
+
+	s3SecretImpersonation.restConfig = restConfig
+	s3SecretImpersonation.options = options
+	s3SecretImpersonation.readers = map[ramen.ServiceAccountReference]client.Reader{}
+}
+
+// s3SecretReader returns the reader of the secret of profile, r unless the profile impersonates a service account
+func s3SecretReader(r client.Reader, profile ramen.S3StoreProfile) (client.Reader, error) {
+	serviceAccount := profile.S3SecretImpersonation
+	if serviceAccount == nil {
+		return r, nil
+	}
+
+	s3SecretImpersonation.Lock()
+	defer s3SecretImpersonation.Unlock()
+
+	if reader, ok := s3SecretImpersonation.readers[*serviceAccount]; ok {
+		return reader, nil
+	}
+
+	if s3SecretImpersonation.restConfig == nil {
+		return nil, fmt.Errorf("impersonation of service account %s/%s is not configured",
+			serviceAccount.Namespace, serviceAccount.Name)
+	}
+
+	restConfig := rest.CopyConfig(s3SecretImpersonation.restConfig)
+	restConfig.Impersonate = rest.ImpersonationConfig{
+		UserName: "system:serviceaccount:" + serviceAccount.Namespace + ":" + serviceAccount.Name,
+	}
+
+	reader, err := client.New(restConfig, s3SecretImpersonation.options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client impersonating service account %s/%s, %w",
+			serviceAccount.Namespace, serviceAccount.Name, err)
+	}
+
+	s3SecretImpersonation.readers[*serviceAccount] = reader
+
+	return reader, nil
+}
+
+// s3SecretsUtil returns secretsUtil reading the secret with secretKey as the service account that the profiles of
+// ramenConfig referencing the secret impersonate, if any
+func s3SecretsUtil(secretsUtil *util.SecretsUtil, ramenConfig *ramen.RamenConfig, secretKey string,
+) (*util.SecretsUtil, error) {
+	for i := range ramenConfig.S3StoreProfiles {
+		profile := ramenConfig.S3StoreProfiles[i]
+		if profile.S3SecretImpersonation == nil || s3SecretKey(profile) != secretKey {
+			continue
+		}
+
+		reader, err := s3SecretReader(secretsUtil.APIReader, profile)
+		if err != nil {
+			return nil, err
+		}
+
+		impersonating := *secretsUtil
+		impersonating.SecretReader = reader
+
+		return &impersonating, nil
+	}
+
+	return secretsUtil, nil
+}
+
+// s3SecretNamespacedName returns the namespaced name of the secret of profile, in the operator namespace if the
+// profile does not set it
+func s3SecretNamespacedName(profile ramen.S3StoreProfile) types.NamespacedName {
+	namespacedName := types.NamespacedName{Namespace: profile.S3SecretRef.Namespace, Name: profile.S3SecretRef.Name}
+	if namespacedName.Namespace == "" {
+		namespacedName.Namespace = RamenOperatorNamespace()
+	}
+
+	return namespacedName
+}
+
+// s3SecretNamespaceAllowed returns whether namespace is the operator namespace or one of the S3 secret namespaces of
+// ramenConfig
+func s3SecretNamespaceAllowed(ramenConfig *ramen.RamenConfig, namespace string) bool {
+	return namespace == RamenOperatorNamespace() || slices.Contains(ramenConfig.S3SecretNamespaces, namespace)
+}
+
+// s3SecretNamespaceCheck returns an error unless the secret of profile, and the service account it impersonates, if
+// any, are in the operator namespace or in one of the S3 secret namespaces of ramenConfig
+func s3SecretNamespaceCheck(ramenConfig *ramen.RamenConfig, profile *ramen.S3StoreProfile) error {
+	namespace := s3SecretNamespacedName(*profile).Namespace
+	if !s3SecretNamespaceAllowed(ramenConfig, namespace) {
+		return fmt.Errorf("secret namespace %s of s3 profile %s is neither the operator namespace nor one of the "+
+			"s3 secret namespaces", namespace, profile.S3ProfileName)
+	}
+
+	serviceAccount := profile.S3SecretImpersonation
+	if serviceAccount == nil {
+		return nil
+	}
+
+	if serviceAccount.Name == "" || serviceAccount.Namespace == "" {
+		return fmt.Errorf("s3 secret impersonation of s3 profile %s requires a service account name and namespace",
+			profile.S3ProfileName)
+	}
+
+	if !s3SecretNamespaceAllowed(ramenConfig, serviceAccount.Namespace) {
+		return fmt.Errorf("service account namespace %s of s3 profile %s is neither the operator namespace nor one "+
+			"of the s3 secret namespaces", serviceAccount.Namespace, profile.S3ProfileName)
+	}
+
+	return nil
+}
+
+// s3ProfilesForDrClusterOperator returns the S3 profiles of the hub for the dr-cluster operators, whose secrets are
+// propagated to namespace, the operator namespace of the managed clusters, and read by the operators themselves
+func s3ProfilesForDrClusterOperator(profiles []ramen.S3StoreProfile, namespace string) []ramen.S3StoreProfile {
+	if profiles == nil {
+		return nil
+	}
+
+	drClusterProfiles := make([]ramen.S3StoreProfile, len(profiles))
+
+	for i := range profiles {
+		profiles[i].DeepCopyInto(&drClusterProfiles[i])
+
+		if drClusterProfiles[i].S3SecretRef.Namespace != "" {
+			drClusterProfiles[i].S3SecretRef.Namespace = namespace
+		}
+
+		if encryption := drClusterProfiles[i].ClientSideEncryption; encryption != nil &&
+			encryption.KeySecretRef != nil && encryption.KeySecretRef.Namespace != "" {
+			encryption.KeySecretRef.Namespace = namespace
+		}
+
+		drClusterProfiles[i].S3SecretImpersonation = nil
+	}
+
+	return drClusterProfiles
+}
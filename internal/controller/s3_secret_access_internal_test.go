@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("S3 secret access", func() {
+	profile := func(namespace string) *ramen.S3StoreProfile {
+		return &ramen.S3StoreProfile{
+			S3ProfileName: "profile",
+			S3SecretRef:   corev1.SecretReference{Name: "secret", Namespace: namespace},
+		}
+	}
+
+	It("accepts secrets in the operator namespace or in the s3 secret namespaces only", func() {
+		ramenConfig := &ramen.RamenConfig{S3SecretNamespaces: []string{"credentials"}}
+
+		Expect(s3SecretNamespaceCheck(ramenConfig, profile(""))).To(Succeed())
+		Expect(s3SecretNamespaceCheck(ramenConfig, profile(RamenOperatorNamespace()))).To(Succeed())
+		Expect(s3SecretNamespaceCheck(ramenConfig, profile("credentials"))).To(Succeed())
+		Expect(s3SecretNamespaceCheck(ramenConfig, profile("other"))).To(MatchError(
+			ContainSubstring("neither the operator namespace nor one of the s3 secret namespaces")))
+
+		impersonating := profile("credentials")
+		impersonating.S3SecretImpersonation = &ramen.ServiceAccountReference{Name: "reader"}
+		Expect(s3SecretNamespaceCheck(ramenConfig, impersonating)).To(MatchError(
+			ContainSubstring("requires a service account name and namespace")))
+
+		impersonating.S3SecretImpersonation.Namespace = "other"
+		Expect(s3SecretNamespaceCheck(ramenConfig, impersonating)).To(MatchError(
+			ContainSubstring("service account namespace other of s3 profile profile is neither")))
+
+		impersonating.S3SecretImpersonation.Namespace = "credentials"
+		Expect(s3SecretNamespaceCheck(ramenConfig, impersonating)).To(Succeed())
+	})
+
+	It("reads the secrets to propagate as the service accounts their profiles impersonate", func() {
+		ConfigureS3SecretImpersonation(&rest.Config{Host: "https://127.0.0.1:6443"}, client.Options{})
+		DeferCleanup(ConfigureS3SecretImpersonation, (*rest.Config)(nil), client.Options{})
+
+		impersonating := profile("credentials")
+		impersonating.S3SecretImpersonation = &ramen.ServiceAccountReference{Name: "reader", Namespace: "credentials"}
+		ramenConfig := &ramen.RamenConfig{S3StoreProfiles: []ramen.S3StoreProfile{*profile(""), *impersonating}}
+		secretsUtil := &util.SecretsUtil{APIReader: fake.NewClientBuilder().Build()}
+
+		Expect(s3SecretsUtil(secretsUtil, ramenConfig, s3SecretKey(*profile("")))).To(BeIdenticalTo(secretsUtil))
+
+		impersonatingUtil, err := s3SecretsUtil(secretsUtil, ramenConfig, s3SecretKey(*impersonating))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(impersonatingUtil.SecretReader).To(BeIdenticalTo(
+			s3SecretImpersonation.readers[*impersonating.S3SecretImpersonation]))
+		Expect(secretsUtil.SecretReader).To(BeNil())
+	})
+
+	It("reconciles the DRClusters on changes to their secrets in the s3 secret namespaces", func() {
+		s3Profile := profile("credentials")
+		s3Profile.S3Bucket = "bucket"
+		s3Profile.S3CompatibleEndpoint = "https://s3.example.com"
+		s3Profile.S3Region = "us-east-1"
+
+		configMap, err := ConfigMapNew(RamenOperatorNamespace(), HubOperatorConfigMapName, &ramen.RamenConfig{
+			S3SecretNamespaces: []string{"credentials"},
+			S3StoreProfiles:    []ramen.S3StoreProfile{*s3Profile},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		scheme := runtime.NewScheme()
+		Expect(ramen.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap, &ramen.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "east"},
+			Spec:       ramen.DRClusterSpec{S3ProfileName: "profile"},
+		}).Build()
+		r := &DRClusterReconciler{Client: fakeClient, APIReader: fakeClient}
+
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "credentials", Name: "secret"}}
+		Expect(r.drClusterSecretMapFunc(context.TODO(), secret)).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "east"}}))
+
+		secret.Namespace = "other"
+		Expect(r.drClusterSecretMapFunc(context.TODO(), secret)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"k8s.io/apimachinery/pkg/types"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// s3SessionPoolMaxAge bounds the age of the pooled sessions, so that the operators not watching the S3 secrets, like
+// the dr-cluster operators, use the changed secrets too
+const s3SessionPoolMaxAge = 10 * time.Minute
+
+// s3Clients are the session of an S3 profile and its clients, which are safe to use concurrently
+type s3Clients struct {
+	session      *session.Session
+	client       *s3.S3
+	uploader     *s3manager.Uploader
+	downloader   *s3manager.Downloader
+	batchDeleter *s3manager.BatchDelete
+}
+
+type s3SessionPoolEntry struct {
+	profile ramen.S3StoreProfile
+	created time.Time
+	clients s3Clients
+}
+
+// s3SessionPool holds the clients of the S3 profiles by profile name, so that the object stores of a profile reuse
+// its connections instead of connecting anew for each reconcile
+var s3SessionPool = struct {
+	sync.Mutex
+	entries map[string]s3SessionPoolEntry
+}{entries: map[string]s3SessionPoolEntry{}}
+
+// s3SessionPoolGet returns the pooled clients of the S3 profile, or the clients newClients returns, pooling them,
+// if none are pooled, or the pooled ones are for a different profile or are older than the maximum age
+func s3SessionPoolGet(profile ramen.S3StoreProfile, now time.Time, newClients func() (s3Clients, error),
+) (s3Clients, error) {
+	s3SessionPool.Lock()
+	entry, ok := s3SessionPool.entries[profile.S3ProfileName]
+	s3SessionPool.Unlock()
+
+	if ok && now.Sub(entry.created) < s3SessionPoolMaxAge && reflect.DeepEqual(entry.profile, profile) {
+		return entry.clients, nil
+	}
+
+	clients, err := newClients()
+	if err != nil {
+		return clients, err
+	}
+
+	s3SessionPool.Lock()
+	s3SessionPool.entries[profile.S3ProfileName] = s3SessionPoolEntry{
+		profile: *profile.DeepCopy(),
+		created: now,
+		clients: clients,
+	}
+	s3SessionPool.Unlock()
+
+	return clients, nil
+}
+
+// s3SessionPoolInvalidate drops the pooled clients of the S3 profiles whose secret is secret, so that the next object
+// stores of these profiles read the changed secret
+func s3SessionPoolInvalidate(secret types.NamespacedName) {
+	s3SessionPool.Lock()
+	defer s3SessionPool.Unlock()
+
+	for profileName, entry := range s3SessionPool.entries {
+		if s3SecretNamespacedName(entry.profile) == secret {
+			delete(s3SessionPool.entries, profileName)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("S3 session pool", func() {
+	var (
+		profile ramen.S3StoreProfile
+		created int
+		start   time.Time
+	)
+
+	newClients := func() (s3Clients, error) {
+		created++
+
+		return s3Clients{session: &session.Session{}}, nil
+	}
+
+	get := func(after time.Duration) *session.Session {
+		clients, err := s3SessionPoolGet(profile, start.Add(after), newClients)
+		Expect(err).ToNot(HaveOccurred())
+
+		return clients.session
+	}
+
+	BeforeEach(func() {
+		profile = ramen.S3StoreProfile{
+			S3ProfileName:        "session-pool",
+			S3CompatibleEndpoint: "https://s3.example.com",
+			S3SecretRef:          corev1.SecretReference{Name: "secret"},
+		}
+		created = 0
+		start = time.Now()
+
+		DeferCleanup(s3SessionPoolInvalidate, types.NamespacedName{
+			Namespace: RamenOperatorNamespace(), Name: "secret",
+		})
+	})
+
+	It("reuses the clients of an unchanged profile until they are too old", func() {
+		pooled := get(0)
+		Expect(get(time.Minute)).To(BeIdenticalTo(pooled))
+		Expect(created).To(Equal(1))
+
+		Expect(get(s3SessionPoolMaxAge)).ToNot(BeIdenticalTo(pooled))
+		Expect(created).To(Equal(2))
+	})
+
+	It("creates new clients once the profile changes", func() {
+		pooled := get(0)
+
+		profile.S3CompatibleEndpoint = "https://s3.example.com:9000"
+		Expect(get(0)).ToNot(BeIdenticalTo(pooled))
+		Expect(created).To(Equal(2))
+	})
+
+	It("creates new clients once the secret of the profile changes", func() {
+		pooled := get(0)
+
+		s3SessionPoolInvalidate(types.NamespacedName{Namespace: RamenOperatorNamespace(), Name: "other"})
+		Expect(get(0)).To(BeIdenticalTo(pooled))
+
+		s3SessionPoolInvalidate(types.NamespacedName{Namespace: RamenOperatorNamespace(), Name: "secret"})
+		Expect(get(0)).ToNot(BeIdenticalTo(pooled))
+		Expect(created).To(Equal(2))
+	})
+
+	It("does not pool clients that failed to be created", func() {
+		_, err := s3SessionPoolGet(profile, start, func() (s3Clients, error) {
+			return s3Clients{}, errors.New("no credentials")
+		})
+		Expect(err).To(MatchError("no credentials"))
+
+		get(0)
+		Expect(created).To(Equal(1))
+	})
+})
@@ -37,7 +37,7 @@ func s3StoreAccessorsGet(
 			return nil
 		}
 
-		if s3StoreProfile.VeleroNamespaceSecretKeyRef == nil {
+		if s3StoreProfile.VeleroNamespaceSecretKeyRef == nil && s3StoreProfile.S3WebIdentity == nil {
 			s3StoreProfile.VeleroNamespaceSecretKeyRef = &v1.SecretKeySelector{
 				Key: util.VeleroSecretKeyNameDefault,
 				LocalObjectReference: v1.LocalObjectReference{
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	s3WebIdentityTokenFileDefault = "/var/run/secrets/eks.amazonaws.com/serviceaccount/token"
+
+	// s3WebIdentityExpiryWindow is how long before they expire the credentials of an assumed role are refreshed, so
+	// that the requests of an upload in progress are not signed with credentials expiring in flight
+	s3WebIdentityExpiryWindow = 5 * time.Minute
+)
+
+// s3Credentials returns the credentials of the S3 profile, the static access keys of its secret, or the credentials
+// of the role it assumes with a web identity, refreshed before they expire
+func s3Credentials(ctx context.Context, r client.Reader, s3StoreProfile ramen.S3StoreProfile,
+) (*credentials.Credentials, error) {
+	webIdentity := s3StoreProfile.S3WebIdentity
+	if webIdentity == nil {
+		accessID, secretAccessKey, err := GetS3Secret(ctx, r, s3StoreProfile.S3SecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get secret %v, %w", s3StoreProfile.S3SecretRef, err)
+		}
+
+		return credentials.NewStaticCredentials(string(accessID), string(secretAccessKey), ""), nil
+	}
+
+	transport, err := objectStoreTransport(s3StoreProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	// AssumeRoleWithWebIdentity requests are authenticated by the token instead of signed
+	stsSession, err := session.NewSession(&aws.Config{
+		Credentials:         credentials.AnonymousCredentials,
+		HTTPClient:          &http.Client{Transport: transport},
+		Region:              aws.String(s3StoreProfile.S3Region),
+		STSRegionalEndpoint: endpoints.RegionalSTSEndpoint,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sts session of region %s, %w", s3StoreProfile.S3Region, err)
+	}
+
+	tokenFile := webIdentity.TokenFile
+	if tokenFile == "" {
+		tokenFile = s3WebIdentityTokenFileDefault
+	}
+
+	roleSessionName := webIdentity.RoleSessionName
+	if roleSessionName == "" {
+		roleSessionName = s3StoreProfile.S3ProfileName
+	}
+
+	provider := stscreds.NewWebIdentityRoleProviderWithOptions(sts.New(stsSession), webIdentity.RoleARN,
+		roleSessionName, stscreds.FetchTokenPath(tokenFile), func(p *stscreds.WebIdentityRoleProvider) {
+			p.ExpiryWindow = s3WebIdentityExpiryWindow
+		})
+
+	return credentials.NewCredentials(provider), nil
+}
+
+// s3WebIdentityFormatCheck returns an error if the S3 profile assumes a role with a web identity that is not an ARN
+func s3WebIdentityFormatCheck(s3StoreProfile *ramen.S3StoreProfile) error {
+	webIdentity := s3StoreProfile.S3WebIdentity
+	if webIdentity == nil {
+		return nil
+	}
+
+	if s3StoreProfile.Type != "" && s3StoreProfile.Type != ramen.ObjectStoreTypeS3 {
+		return fmt.Errorf("s3 web identity is not supported by object store type %s of s3 profile %s",
+			s3StoreProfile.Type, s3StoreProfile.S3ProfileName)
+	}
+
+	if _, err := arn.Parse(webIdentity.RoleARN); err != nil {
+		return fmt.Errorf("invalid role arn <%s> of s3 web identity in s3 profile %s, reason: %w",
+			webIdentity.RoleARN, s3StoreProfile.S3ProfileName, err)
+	}
+
+	return nil
+}
+
+// s3ProfileHasSecret returns whether the S3 profile references a secret, which profiles that assume a role with a
+// web identity need only for their client-side encryption key
+func s3ProfileHasSecret(s3StoreProfile ramen.S3StoreProfile) bool {
+	return s3StoreProfile.S3SecretRef.Name != "" || s3StoreProfile.S3WebIdentity == nil
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("S3 web identity", func() {
+	const roleARN = "arn:aws:iam::123456789012:role/ramen"
+
+	var profile *ramen.S3StoreProfile
+
+	BeforeEach(func() {
+		profile = &ramen.S3StoreProfile{
+			S3ProfileName:        "profile",
+			S3Bucket:             "bucket",
+			S3CompatibleEndpoint: "https://s3.us-east-1.amazonaws.com",
+			S3Region:             "us-east-1",
+			S3SecretRef:          corev1.SecretReference{Name: "secret", Namespace: "ramen-system"},
+		}
+	})
+
+	It("authenticates with the access keys of the secret of profiles without a web identity", func() {
+		reader := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "ramen-system"},
+			Data: map[string][]byte{
+				util.S3AccessKeyIDKey:     []byte("id"),
+				util.S3SecretAccessKeyKey: []byte("key"),
+			},
+		}).Build()
+
+		credentials, err := s3Credentials(context.TODO(), reader, *profile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(credentials.Get()).To(And(HaveField("AccessKeyID", "id"), HaveField("SecretAccessKey", "key")))
+	})
+
+	It("assumes the role of the web identity with the token of the token file", func() {
+		profile.S3SecretRef = corev1.SecretReference{}
+		profile.S3WebIdentity = &ramen.S3WebIdentity{
+			RoleARN:   roleARN,
+			TokenFile: filepath.Join(GinkgoT().TempDir(), "token"),
+		}
+
+		credentials, err := s3Credentials(context.TODO(), fake.NewClientBuilder().Build(), *profile)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = credentials.Get()
+		Expect(err).To(MatchError(ContainSubstring("unable to read file at " + profile.S3WebIdentity.TokenFile)))
+	})
+
+	It("rejects web identities without a role arn or of object stores other than S3", func() {
+		profile.S3WebIdentity = &ramen.S3WebIdentity{RoleARN: roleARN}
+		Expect(s3StoreProfileFormatCheck(profile)).To(Succeed())
+
+		profile.S3WebIdentity.RoleARN = "ramen"
+		Expect(s3StoreProfileFormatCheck(profile)).To(MatchError(ContainSubstring("invalid role arn <ramen>")))
+
+		profile.S3WebIdentity.RoleARN = roleARN
+		profile.Type = ramen.ObjectStoreTypeAzureBlob
+		Expect(s3StoreProfileFormatCheck(profile)).To(MatchError(
+			ContainSubstring("s3 web identity is not supported by object store type AzureBlob")))
+	})
+
+	It("propagates no secret and no velero credentials for web identities without a secret", func() {
+		Expect(s3ProfileHasSecret(*profile)).To(BeTrue())
+
+		profile.S3WebIdentity = &ramen.S3WebIdentity{RoleARN: roleARN}
+		Expect(s3ProfileHasSecret(*profile)).To(BeTrue())
+
+		profile.S3SecretRef = corev1.SecretReference{}
+		Expect(s3ProfileHasSecret(*profile)).To(BeFalse())
+
+		accessors := s3StoreAccessorsGet([]string{profile.S3ProfileName},
+			func(string) (ObjectStorer, ramen.S3StoreProfile, error) { return nil, *profile, nil }, logr.Discard())
+		Expect(accessors).To(HaveLen(1))
+		Expect(accessors[0].VeleroNamespaceSecretKeyRef).To(BeNil())
+	})
+})
@@ -5,18 +5,15 @@ package controllers
 
 import (
 	"bytes"
-	"compress/gzip"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
+	"net/http"
 	"reflect"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
@@ -27,6 +24,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+	"github.com/ramendr/ramen/pkg/metadata"
 )
 
 // We have seen that valid errors from the S3 servers can take up to 2 minutes to timeout.
@@ -110,11 +109,14 @@ func S3ObjectStoreGetter() ObjectStoreGetter {
 // the ObjectStoreGetter interface.
 type s3ObjectStoreGetter struct{}
 
-// ObjectStore returns an S3 object store that satisfies the ObjectStorer
-// interface,  with a downloader and an uploader client connections, by either
-// creating a new connection or returning a previously established connection
-// for the given s3 profile.  Returns an error if s3 profile does not exists,
-// secret is not configured, or if client session creation fails.
+// ObjectStore returns an object store that satisfies the ObjectStorer
+// interface, created by the backend registered for the type of the given s3
+// profile, checksumming the objects, laying out the objects under the key
+// prefix of the profile, if any, and encrypting the objects if the profile
+// enables client-side encryption.  The secret of the profile is read as the service account the
+// profile impersonates, if any.  Returns an error if s3 profile does not
+// exists, no backend is registered for its type, secret or encryption key is
+// not configured, or if client session creation fails.
 func (s3ObjectStoreGetter) ObjectStore(ctx context.Context,
 	r client.Reader, s3ProfileName string,
 	callerTag string, log logr.Logger,
@@ -125,25 +127,112 @@ func (s3ObjectStoreGetter) ObjectStore(ctx context.Context,
 			s3ProfileName, callerTag, err)
 	}
 
-	accessID, secretAccessKey, err := GetS3Secret(ctx, r, s3StoreProfile.S3SecretRef)
+	backend, ok := ObjectStoreBackendGet(s3StoreProfile.Type)
+	if !ok {
+		return nil, s3StoreProfile, fmt.Errorf("unsupported object store type %s of profile %s for caller %s",
+			s3StoreProfile.Type, s3ProfileName, callerTag)
+	}
+
+	secretReader, err := s3SecretReader(r, s3StoreProfile)
+	if err != nil {
+		return nil, s3StoreProfile, fmt.Errorf("failed to get secret reader of profile %s for caller %s, %w",
+			s3ProfileName, callerTag, err)
+	}
+
+	objectStore, err := backend.New(ctx, secretReader, s3StoreProfile, callerTag)
 	if err != nil {
-		return nil, s3StoreProfile, fmt.Errorf("failed to get secret %v for caller %s, %w",
-			s3StoreProfile.S3SecretRef, callerTag, err)
+		return nil, s3StoreProfile, err
 	}
 
-	s3Endpoint := s3StoreProfile.S3CompatibleEndpoint
-	s3Region := s3StoreProfile.S3Region
+	objectStore = checksummingObjectStoreNew(objectStore)
+
+	objectStore, err = prefixedObjectStoreNew(objectStore, s3StoreProfile)
+	if err != nil {
+		return nil, s3StoreProfile, err
+	}
+
+	objectStore, err = encryptingObjectStoreNew(ctx, secretReader, objectStore, s3StoreProfile)
+	if err != nil {
+		return nil, s3StoreProfile, fmt.Errorf("failed to enable client-side encryption of profile %s for caller %s, %w",
+			s3ProfileName, callerTag, err)
+	}
+
+	return objectStore, s3StoreProfile, nil
+}
+
+// newS3ObjectStore returns an S3 object store for the S3 profile, with a downloader and an uploader client
+// connections
+func newS3ObjectStore(ctx context.Context, r client.Reader, s3StoreProfile ramen.S3StoreProfile, callerTag string,
+) (ObjectStorer, error) {
+	compression, err := objectCompressionGet(s3StoreProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	multipartUpload, err := s3MultipartUploadGet(s3StoreProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	s3Endpoint, err := objectStoreEndpoint(s3StoreProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := s3SessionPoolGet(s3StoreProfile, time.Now(), func() (s3Clients, error) {
+		return newS3Clients(ctx, r, s3StoreProfile, s3Endpoint, callerTag)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s3Conn := &s3ObjectStore{
+		session:         clients.session,
+		client:          clients.client,
+		uploader:        clients.uploader,
+		downloader:      clients.downloader,
+		batchDeleter:    clients.batchDeleter,
+		s3Endpoint:      s3Endpoint,
+		s3Bucket:        s3StoreProfile.S3Bucket,
+		callerTag:       callerTag,
+		name:            s3StoreProfile.S3ProfileName,
+		compression:     compression,
+		multipartUpload: multipartUpload,
+	}
+
+	return s3Conn, nil
+}
+
+// newS3Clients returns a session connecting to the S3 endpoint of the S3 profile, and its clients
+func newS3Clients(ctx context.Context, r client.Reader, s3StoreProfile ramen.S3StoreProfile, s3Endpoint,
+	callerTag string,
+) (s3Clients, error) {
+	credentials, err := s3Credentials(ctx, r, s3StoreProfile)
+	if err != nil {
+		return s3Clients{}, fmt.Errorf("failed to get credentials of profile %s for caller %s, %w",
+			s3StoreProfile.S3ProfileName, callerTag, err)
+	}
+
+	transport, err := objectStoreTransport(s3StoreProfile)
+	if err != nil {
+		return s3Clients{}, err
+	}
+
+	if err := objectStoreClientCertificateSet(ctx, r, s3StoreProfile, transport); err != nil {
+		return s3Clients{}, fmt.Errorf("failed to get client certificate of profile %s for caller %s, %w",
+			s3StoreProfile.S3ProfileName, callerTag, err)
+	}
 
 	// Create an S3 client session
 	s3Session, err := session.NewSession(&aws.Config{
-		Credentials: credentials.NewStaticCredentials(string(accessID),
-			string(secretAccessKey), ""),
+		Credentials:      credentials,
+		HTTPClient:       &http.Client{Transport: transport},
 		Endpoint:         aws.String(s3Endpoint),
-		Region:           aws.String(s3Region),
+		Region:           aws.String(s3StoreProfile.S3Region),
 		S3ForcePathStyle: aws.Bool(true),
 	})
 	if err != nil {
-		return nil, s3StoreProfile, fmt.Errorf("failed to create new session for %s for caller %s, %w",
+		return s3Clients{}, fmt.Errorf("failed to create new session for %s for caller %s, %w",
 			s3Endpoint, callerTag, err)
 	}
 
@@ -153,29 +242,33 @@ func (s3ObjectStoreGetter) ObjectStore(ctx context.Context,
 	// Also create S3 uploader and S3 downloader which can be safely used
 	// concurrently across goroutines, whereas, the s3 client session
 	// does not support concurrent writers.
-	s3Uploader := s3manager.NewUploaderWithClient(s3Client)
-	s3Downloader := s3manager.NewDownloaderWithClient(s3Client)
-	s3BatchDeleter := s3manager.NewBatchDeleteWithClient(s3Client)
-	s3Conn := &s3ObjectStore{
+	return s3Clients{
 		session:      s3Session,
 		client:       s3Client,
-		uploader:     s3Uploader,
-		downloader:   s3Downloader,
-		batchDeleter: s3BatchDeleter,
-		s3Endpoint:   s3Endpoint,
-		s3Bucket:     s3StoreProfile.S3Bucket,
-		callerTag:    callerTag,
-		name:         s3ProfileName,
-	}
-
-	return s3Conn, s3StoreProfile, nil
+		uploader:     s3manager.NewUploaderWithClient(s3Client),
+		downloader:   s3manager.NewDownloaderWithClient(s3Client),
+		batchDeleter: s3manager.NewBatchDeleteWithClient(s3Client),
+	}, nil
 }
 
 func GetS3Secret(ctx context.Context, r client.Reader,
 	secretRef corev1.SecretReference) (
 	s3AccessID, s3SecretAccessKey []byte, err error,
 ) {
-	secret := corev1.Secret{}
+	secret, err := s3SecretGet(ctx, r, secretRef)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s3AccessID = secret.Data[util.S3AccessKeyIDKey]
+	s3SecretAccessKey = secret.Data[util.S3SecretAccessKeyKey]
+
+	return
+}
+
+// s3SecretGet returns the secret of secretRef, in the operator namespace if secretRef has no namespace
+func s3SecretGet(ctx context.Context, r client.Reader, secretRef corev1.SecretReference) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
 	namepacedName := types.NamespacedName{Namespace: "", Name: secretRef.Name}
 
 	if secretRef.Namespace == "" {
@@ -184,15 +277,12 @@ func GetS3Secret(ctx context.Context, r client.Reader,
 		namepacedName.Namespace = secretRef.Namespace
 	}
 
-	if err := r.Get(ctx, namepacedName, &secret); err != nil {
-		return nil, nil, fmt.Errorf("failed to get secret %v, %w",
+	if err := r.Get(ctx, namepacedName, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %v, %w",
 			secretRef, err)
 	}
 
-	s3AccessID = secret.Data["AWS_ACCESS_KEY_ID"]
-	s3SecretAccessKey = secret.Data["AWS_SECRET_ACCESS_KEY"]
-
-	return
+	return secret, nil
 }
 
 type s3ObjectStore struct {
@@ -205,6 +295,9 @@ type s3ObjectStore struct {
 	s3Bucket     string
 	callerTag    string
 	name         string
+	compression  objectCompression
+
+	multipartUpload s3MultipartUpload
 }
 
 // CreateBucket creates the given bucket; does not return an error if the bucket
@@ -428,28 +521,34 @@ func processAwsError(errMsgPrefix, err error) error {
 func (s *s3ObjectStore) UploadObject(key string,
 	uploadContent interface{},
 ) error {
-	encodedUploadContent := &bytes.Buffer{}
 	bucket := s.s3Bucket
 
-	gzWriter := gzip.NewWriter(encodedUploadContent)
-	if err := json.NewEncoder(gzWriter).Encode(uploadContent); err != nil {
-		return fmt.Errorf("failed to json encode %s:%s, %w",
+	encodedUploadContent, err := s.compression.Encode(uploadContent)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s:%s, %w",
 			bucket, key, err)
 	}
 
-	if err := gzWriter.Close(); err != nil {
-		return fmt.Errorf("failed to close gzip writer of %s:%s, %w",
-			bucket, key, err)
+	contentEncoding := s.compression.ContentEncoding()
+
+	if int64(len(encodedUploadContent)) > s.multipartUpload.partSize {
+		return s.uploadMultipart(key, encodedUploadContent, contentEncoding)
+	}
+
+	uploadInput := &s3manager.UploadInput{
+		Bucket: &bucket,
+		Key:    &key,
+		Body:   bytes.NewReader(encodedUploadContent),
+	}
+
+	if contentEncoding != "" {
+		uploadInput.ContentEncoding = aws.String(contentEncoding)
 	}
 
 	ctx, cancel := context.WithDeadline(context.TODO(), time.Now().Add(s3Timeout))
 	defer cancel()
 
-	if _, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
-		Bucket: &bucket,
-		Key:    &key,
-		Body:   encodedUploadContent,
-	}); err != nil {
+	if _, err := s.uploader.UploadWithContext(ctx, uploadInput); err != nil {
 		errMsgPrefix := fmt.Errorf("failed to upload data of %s:%s", bucket, key)
 
 		return processAwsError(errMsgPrefix, err)
@@ -600,6 +699,20 @@ func (s *s3ObjectStore) ListKeys(keyPrefix string) (
 //     InvalidParameter (e.g., empty key), etc.
 func (s *s3ObjectStore) DownloadObject(key string,
 	downloadContent interface{},
+) error {
+	return s.downloadObject(key, nil, downloadContent)
+}
+
+// DownloadObjectVersion downloads the version with versionID of the object with key, like DownloadObject does the
+// latest version
+func (s *s3ObjectStore) DownloadObjectVersion(key, versionID string,
+	downloadContent interface{},
+) error {
+	return s.downloadObject(key, &versionID, downloadContent)
+}
+
+func (s *s3ObjectStore) downloadObject(key string, versionID *string,
+	downloadContent interface{},
 ) error {
 	bucket := s.s3Bucket
 	writerAt := &aws.WriteAtBuffer{}
@@ -608,31 +721,58 @@ func (s *s3ObjectStore) DownloadObject(key string,
 	defer cancel()
 
 	if _, err := s.downloader.DownloadWithContext(ctx, writerAt, &s3.GetObjectInput{
-		Bucket: &bucket,
-		Key:    &key,
+		Bucket:    &bucket,
+		Key:       &key,
+		VersionId: versionID,
 	}); err != nil {
 		errMsgPrefix := fmt.Errorf("failed to download data of %s:%s", bucket, key)
 
 		return processAwsError(errMsgPrefix, err)
 	}
 
-	gzReader, err := gzip.NewReader(bytes.NewReader(writerAt.Bytes()))
-	if err != nil && !errors.Is(err, io.EOF) {
-		return fmt.Errorf("failed to unzip data of %s:%s, %w",
+	if err := metadata.Decode(writerAt.Bytes(), downloadContent); err != nil {
+		return fmt.Errorf("failed to decode data of %s:%s, %w",
 			bucket, key, err)
 	}
 
-	if err := json.NewDecoder(gzReader).Decode(downloadContent); err != nil {
-		return fmt.Errorf("failed to decode json decoder of %s:%s, %w",
-			bucket, key, err)
+	return nil
+}
+
+// ObjectVersionIDs returns the version ID of the latest version of each object with the given keyPrefix in the
+// bucket. Objects whose latest version is a delete marker are left out. Returns an error if the bucket does not
+// have object versioning enabled, as the objects then have no version ID.
+func (s *s3ObjectStore) ObjectVersionIDs(keyPrefix string) (map[string]string, error) {
+	bucket := s.s3Bucket
+	versionIDs := map[string]string{}
+
+	ctx, cancel := context.WithDeadline(context.TODO(), time.Now().Add(s3Timeout))
+	defer cancel()
+
+	if err := s.client.ListObjectVersionsPagesWithContext(ctx, &s3.ListObjectVersionsInput{
+		Bucket: &bucket,
+		Prefix: &keyPrefix,
+	}, func(page *s3.ListObjectVersionsOutput, _ bool) bool {
+		for _, version := range page.Versions {
+			if aws.BoolValue(version.IsLatest) {
+				versionIDs[aws.StringValue(version.Key)] = aws.StringValue(version.VersionId)
+			}
+		}
+
+		return true
+	}); err != nil {
+		errMsgPrefix := fmt.Errorf("failed to list object versions in bucket %s", bucket)
+
+		return nil, processAwsError(errMsgPrefix, err)
 	}
 
-	if err := gzReader.Close(); err != nil {
-		return fmt.Errorf("failed to close gzip reader of %s:%s, %w",
-			bucket, key, err)
+	for key, versionID := range versionIDs {
+		if versionID == "" || versionID == s3NullVersionID {
+			return nil, fmt.Errorf("object %s of bucket %s has no version ID, is object versioning enabled?",
+				key, bucket)
+		}
 	}
 
-	return nil
+	return versionIDs, nil
 }
 
 func (s *s3ObjectStore) DeleteObject(key string) error {
@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// docgen writes the documentation of the state machines of DR resources to standard output
+package main
+
+import (
+	"fmt"
+
+	"github.com/ramendr/ramen/internal/controller/statemachine"
+)
+
+func main() {
+	fmt.Print(statemachine.Document())
+}
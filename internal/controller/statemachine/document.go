@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statemachine
+
+import (
+	"strings"
+)
+
+// Document returns the documentation of the machines of the phases of DR resources, as generated to
+// docs/state-machines.md
+func Document() string {
+	return strings.Join([]string{
+		`<!--
+SPDX-FileCopyrightText: The RamenDR authors
+SPDX-License-Identifier: Apache-2.0
+-->
+
+# State Machines
+
+The phases of DR resources, as modeled by the transition tables of
+internal/controller/statemachine. Generated with
+` + "`make docs-state-machines`" + `, do not edit.
+`,
+		DRClusterFencing.Markdown(),
+		DRPCAction.Markdown(),
+	}, "\n")
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statemachine
+
+import (
+	"slices"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// DRClusterEvent moves the fencing machine of a DRCluster
+type DRClusterEvent string
+
+const (
+	// DRClusterInitialize is the first reconcile of a DRCluster
+	DRClusterInitialize = DRClusterEvent("Initialize")
+
+	// DRClusterClean is a reconcile of a DRCluster without a clusterFence state
+	DRClusterClean = DRClusterEvent("Clean")
+
+	// DRClusterFence is a reconcile of a DRCluster to fence, that creates the NetworkFences on its peer cluster
+	DRClusterFence = DRClusterEvent("Fence")
+
+	// DRClusterUnfence is a reconcile of a DRCluster to unfence, that updates the NetworkFences on its peer cluster
+	DRClusterUnfence = DRClusterEvent("Unfence")
+
+	// DRClusterCompleted is a reconcile of a DRCluster whose NetworkFences succeeded
+	DRClusterCompleted = DRClusterEvent("Completed")
+
+	// DRClusterManuallyFence is a reconcile of a DRCluster fenced by the user
+	DRClusterManuallyFence = DRClusterEvent("ManuallyFence")
+
+	// DRClusterManuallyUnfence is a reconcile of a DRCluster unfenced by the user
+	DRClusterManuallyUnfence = DRClusterEvent("ManuallyUnfence")
+)
+
+// DRClusterFencing is the machine of the phases of a DRCluster as it is fenced and unfenced
+var DRClusterFencing = &Machine[ramen.DRClusterPhase, DRClusterEvent]{
+	Name: "DRCluster fencing",
+	Transitions: slices.Concat(
+		fromEach(DRClusterInitialize, ramen.Starting, ""),
+		fromEach(DRClusterClean, ramen.Available, ramen.Starting, ramen.Unfenced),
+		fromEach(DRClusterFence, ramen.Fencing, ramen.Starting, ramen.Available, ramen.Unfencing, ramen.Unfenced),
+		fromEach(DRClusterUnfence, ramen.Unfencing, ramen.Starting, ramen.Available, ramen.Fencing, ramen.Fenced),
+		[]Transition[ramen.DRClusterPhase, DRClusterEvent]{
+			{From: ramen.Fencing, Event: DRClusterCompleted, To: ramen.Fenced},
+			{From: ramen.Unfencing, Event: DRClusterCompleted, To: ramen.Unfenced},
+		},
+		fromEach(DRClusterManuallyFence, ramen.Fenced, ramen.Starting, ramen.Available, ramen.Unfenced),
+		fromEach(DRClusterManuallyUnfence, ramen.Unfenced, ramen.Starting, ramen.Available, ramen.Fenced),
+	),
+}
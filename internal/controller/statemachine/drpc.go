@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statemachine
+
+import (
+	"slices"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// DRPCEvent moves the action machine of a DRPlacementControl
+type DRPCEvent string
+
+const (
+	// DRPCInitiate is the start of the processing of an action, or of the initial deployment
+	DRPCInitiate = DRPCEvent("Initiate")
+
+	// DRPCDeploy is the creation of the VRG of the initial deployment on the preferred cluster
+	DRPCDeploy = DRPCEvent("Deploy")
+
+	// DRPCDeployed is the completion of the initial deployment, or the adoption of an existing one
+	DRPCDeployed = DRPCEvent("Deployed")
+
+	// DRPCFailover is the start of a Failover
+	DRPCFailover = DRPCEvent("Failover")
+
+	// DRPCFailedOver is the completion of a Failover, or the adoption of a completed one
+	DRPCFailedOver = DRPCEvent("FailedOver")
+
+	// DRPCRelocate is the start of a Relocate
+	DRPCRelocate = DRPCEvent("Relocate")
+
+	// DRPCRelocated is the completion of a Relocate, or the adoption of a completed one
+	DRPCRelocated = DRPCEvent("Relocated")
+
+	// DRPCPause is the hub recovery of a DRPC whose clusters do not determine its state, that waits for the user
+	DRPCPause = DRPCEvent("Pause")
+
+	// DRPCDryRunDeployed is the cleanup of a dry run Failover of a deployed DRPC
+	DRPCDryRunDeployed = DRPCEvent("DryRunDeployed")
+
+	// DRPCDryRunRelocated is the cleanup of a dry run Failover of a relocated DRPC
+	DRPCDryRunRelocated = DRPCEvent("DryRunRelocated")
+
+	// DRPCDelete is the deletion of a DRPC
+	DRPCDelete = DRPCEvent("Delete")
+)
+
+// DRPCAction is the machine of the phases of a DRPlacementControl as it is deployed, failed over and relocated
+var DRPCAction = &Machine[ramen.DRState, DRPCEvent]{
+	Name: "DRPlacementControl action",
+	Transitions: slices.Concat(
+		fromEach(DRPCInitiate, ramen.Initiating, "", ramen.Deployed, ramen.FailedOver, ramen.Relocated),
+		fromEach(DRPCDeploy, ramen.Deploying, "", ramen.Initiating),
+		fromEach(DRPCDeployed, ramen.Deployed, "", ramen.Initiating, ramen.Deploying),
+		fromEach(DRPCFailover, ramen.FailingOver, ramen.Initiating, ramen.Deploying, ramen.Deployed,
+			ramen.Relocating, ramen.Relocated, ramen.FailedOver, ramen.WaitForUser),
+		fromEach(DRPCFailedOver, ramen.FailedOver, "", ramen.Initiating, ramen.FailingOver, ramen.WaitForUser),
+		fromEach(DRPCRelocate, ramen.Relocating, ramen.Initiating, ramen.Deployed, ramen.FailingOver,
+			ramen.FailedOver, ramen.Relocated, ramen.WaitForUser),
+		fromEach(DRPCRelocated, ramen.Relocated, "", ramen.Initiating, ramen.Relocating, ramen.WaitForUser),
+		fromEach(DRPCPause, ramen.WaitForUser, "", ramen.Initiating),
+		fromEach(DRPCDryRunDeployed, ramen.Deployed, ramen.FailingOver, ramen.FailedOver),
+		fromEach(DRPCDryRunRelocated, ramen.Relocated, ramen.FailingOver, ramen.FailedOver),
+		fromEach(DRPCDelete, ramen.Deleting, "", ramen.Initiating, ramen.Deploying, ramen.Deployed,
+			ramen.FailingOver, ramen.FailedOver, ramen.Relocating, ramen.Relocated, ramen.WaitForUser),
+	),
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statemachine
+
+import (
+	"fmt"
+)
+
+// Simulator runs a machine through a sequence of events, recording the states it goes through
+type Simulator[S, E ~string] struct {
+	machine *Machine[S, E]
+
+	// State is the current state of the machine
+	State S
+
+	// Trace is the sequence of states the machine went through, starting with its initial state
+	Trace []S
+}
+
+// NewSimulator returns a simulator of machine in its initial state
+func NewSimulator[S, E ~string](machine *Machine[S, E]) *Simulator[S, E] {
+	return &Simulator[S, E]{machine: machine, State: machine.Initial, Trace: []S{machine.Initial}}
+}
+
+// Fire moves the machine on event, or returns ErrInvalidTransition, leaving it in its current state, if event has no
+// transition from it
+func (s *Simulator[S, E]) Fire(event E) error {
+	next, ok := s.machine.Next(s.State, event)
+	if !ok {
+		return fmt.Errorf("%s: %w: event %s in state %q", s.machine.Name, ErrInvalidTransition, event, s.State)
+	}
+
+	s.State = next
+	s.Trace = append(s.Trace, next)
+
+	return nil
+}
+
+// Run fires events in order, stopping at the first that is invalid
+func (s *Simulator[S, E]) Run(events ...E) error {
+	for _, event := range events {
+		if err := s.Fire(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
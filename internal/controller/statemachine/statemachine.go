@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package statemachine models the phases of DR resources as explicit transition tables. States and events of a
+// machine are distinct types, so that a table mixing the states of different resources, or the events of different
+// machines, does not build. Tables are validated, simulated in tests, and rendered as documentation.
+package statemachine
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidTransition is returned for an event that has no transition from the current state
+var ErrInvalidTransition = errors.New("invalid transition")
+
+// Violation is a move of a machine between two states that its transition table does not allow
+type Violation struct {
+	Machine string
+	From    string
+	To      string
+}
+
+// OnViolation, if set, is called with each move a controller makes that the transition table of its machine does
+// not allow, see Machine.Check. Tests set it to fail on such moves, which are otherwise only logged.
+var OnViolation func(Violation)
+
+// Transition moves a machine from state From to state To on Event
+type Transition[S, E ~string] struct {
+	From  S
+	Event E
+	To    S
+}
+
+// Machine is a state machine defined by its transition table
+type Machine[S, E ~string] struct {
+	// Name of the machine, as rendered in its documentation
+	Name string
+
+	// Initial state of the machine, typically the empty state of a new resource
+	Initial S
+
+	Transitions []Transition[S, E]
+}
+
+// Next returns the state that event moves the machine to from state from, and whether there is such a transition
+func (m *Machine[S, E]) Next(from S, event E) (S, bool) {
+	for _, transition := range m.Transitions {
+		if transition.From == from && transition.Event == event {
+			return transition.To, true
+		}
+	}
+
+	return from, false
+}
+
+// Allowed returns whether the machine may move from state from to state to, on any event. Staying in a state is
+// always allowed.
+func (m *Machine[S, E]) Allowed(from, to S) bool {
+	if from == to {
+		return true
+	}
+
+	return slices.ContainsFunc(m.Transitions, func(transition Transition[S, E]) bool {
+		return transition.From == from && transition.To == to
+	})
+}
+
+// Check returns whether the machine may move from state from to state to, as Allowed, and reports the move to
+// OnViolation, if set, if it may not
+func (m *Machine[S, E]) Check(from, to S) bool {
+	if m.Allowed(from, to) {
+		return true
+	}
+
+	if OnViolation != nil {
+		OnViolation(Violation{Machine: m.Name, From: string(from), To: string(to)})
+	}
+
+	return false
+}
+
+// States returns the states of the machine, the initial state first and the others in the order they appear in its
+// transitions
+func (m *Machine[S, E]) States() []S {
+	states := []S{m.Initial}
+
+	for _, transition := range m.Transitions {
+		for _, state := range []S{transition.From, transition.To} {
+			if !slices.Contains(states, state) {
+				states = append(states, state)
+			}
+		}
+	}
+
+	return states
+}
+
+// Validate returns an error if an event has more than one transition from a state, or if a state is not reachable
+// from the initial state
+func (m *Machine[S, E]) Validate() error {
+	errs := []error{}
+
+	for i, transition := range m.Transitions {
+		if slices.ContainsFunc(m.Transitions[:i], func(other Transition[S, E]) bool {
+			return other.From == transition.From && other.Event == transition.Event
+		}) {
+			errs = append(errs, fmt.Errorf("%s: event %s has more than one transition from state %q",
+				m.Name, transition.Event, transition.From))
+		}
+	}
+
+	reachable := []S{m.Initial}
+
+	for i := 0; i < len(reachable); i++ {
+		for _, transition := range m.Transitions {
+			if transition.From == reachable[i] && !slices.Contains(reachable, transition.To) {
+				reachable = append(reachable, transition.To)
+			}
+		}
+	}
+
+	for _, state := range m.States() {
+		if !slices.Contains(reachable, state) {
+			errs = append(errs, fmt.Errorf("%s: state %q is not reachable from state %q", m.Name, state, m.Initial))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Markdown renders the machine as a mermaid state diagram followed by its transition table
+func (m *Machine[S, E]) Markdown() string {
+	name := func(state S) string {
+		if state == "" {
+			return "[*]"
+		}
+
+		return string(state)
+	}
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "## %s\n\n```mermaid\nstateDiagram-v2\n", m.Name)
+
+	for _, transition := range m.Transitions {
+		fmt.Fprintf(&builder, "    %s --> %s: %s\n", name(transition.From), name(transition.To), transition.Event)
+	}
+
+	builder.WriteString("```\n\n| From | Event | To |\n| --- | --- | --- |\n")
+
+	for _, transition := range m.Transitions {
+		fmt.Fprintf(&builder, "| %s | %s | %s |\n", name(transition.From), transition.Event, name(transition.To))
+	}
+
+	return builder.String()
+}
+
+// fromEach returns the transitions on event to state to from each of states froms
+func fromEach[S, E ~string](event E, to S, froms ...S) []Transition[S, E] {
+	transitions := make([]Transition[S, E], 0, len(froms))
+
+	for _, from := range froms {
+		transitions = append(transitions, Transition[S, E]{From: from, Event: event, To: to})
+	}
+
+	return transitions
+}
@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statemachine_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestStatemachine(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Statemachine Suite")
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statemachine_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	sm "github.com/ramendr/ramen/internal/controller/statemachine"
+)
+
+var _ = Describe("Statemachine", func() {
+	It("validates the transition tables", func() {
+		Expect(sm.DRClusterFencing.Validate()).To(Succeed())
+		Expect(sm.DRPCAction.Validate()).To(Succeed())
+	})
+
+	It("detects ambiguous events and unreachable states", func() {
+		machine := &sm.Machine[ramen.DRClusterPhase, sm.DRClusterEvent]{
+			Name: "invalid",
+			Transitions: []sm.Transition[ramen.DRClusterPhase, sm.DRClusterEvent]{
+				{From: "", Event: sm.DRClusterInitialize, To: ramen.Starting},
+				{From: "", Event: sm.DRClusterInitialize, To: ramen.Available},
+				{From: ramen.Fencing, Event: sm.DRClusterCompleted, To: ramen.Fenced},
+			},
+		}
+
+		err := machine.Validate()
+		Expect(err).To(MatchError(ContainSubstring("event Initialize has more than one transition")))
+		Expect(err).To(MatchError(ContainSubstring(`state "Fencing" is not reachable`)))
+		Expect(err).To(MatchError(ContainSubstring(`state "Fenced" is not reachable`)))
+	})
+
+	It("simulates fencing and unfencing a DRCluster", func() {
+		simulator := sm.NewSimulator(sm.DRClusterFencing)
+		Expect(simulator.Run(sm.DRClusterInitialize, sm.DRClusterClean, sm.DRClusterFence, sm.DRClusterCompleted,
+			sm.DRClusterUnfence, sm.DRClusterCompleted, sm.DRClusterClean)).To(Succeed())
+		Expect(simulator.Trace).To(Equal([]ramen.DRClusterPhase{
+			"", ramen.Starting, ramen.Available, ramen.Fencing, ramen.Fenced, ramen.Unfencing, ramen.Unfenced,
+			ramen.Available,
+		}))
+
+		Expect(simulator.Fire(sm.DRClusterCompleted)).To(MatchError(sm.ErrInvalidTransition))
+		Expect(simulator.State).To(Equal(ramen.Available))
+	})
+
+	It("simulates deploying, failing over and relocating a DRPC", func() {
+		simulator := sm.NewSimulator(sm.DRPCAction)
+		Expect(simulator.Run(sm.DRPCInitiate, sm.DRPCDeploy, sm.DRPCDeployed, sm.DRPCInitiate, sm.DRPCFailover,
+			sm.DRPCFailedOver, sm.DRPCInitiate, sm.DRPCRelocate, sm.DRPCRelocated, sm.DRPCDelete)).To(Succeed())
+		Expect(simulator.State).To(Equal(ramen.Deleting))
+
+		simulator = sm.NewSimulator(sm.DRPCAction)
+		Expect(simulator.Run(sm.DRPCDeploy, sm.DRPCRelocated)).To(MatchError(sm.ErrInvalidTransition))
+		Expect(simulator.State).To(Equal(ramen.Deploying))
+	})
+
+	It("allows the transitions of the tables and staying in a state only", func() {
+		Expect(sm.DRPCAction.Allowed(ramen.FailingOver, ramen.FailedOver)).To(BeTrue())
+		Expect(sm.DRPCAction.Allowed(ramen.Relocating, ramen.Relocating)).To(BeTrue())
+		Expect(sm.DRPCAction.Allowed(ramen.Deleting, ramen.Deployed)).To(BeFalse())
+		Expect(sm.DRClusterFencing.Allowed(ramen.Fenced, ramen.Available)).To(BeFalse())
+	})
+
+	It("reports the moves the tables do not allow", func() {
+		violations := []sm.Violation{}
+		sm.OnViolation = func(violation sm.Violation) { violations = append(violations, violation) }
+		DeferCleanup(func() { sm.OnViolation = nil })
+
+		Expect(sm.DRPCAction.Check(ramen.FailingOver, ramen.FailedOver)).To(BeTrue())
+		Expect(sm.DRClusterFencing.Check(ramen.Fenced, ramen.Available)).To(BeFalse())
+		Expect(violations).To(Equal([]sm.Violation{
+			{Machine: sm.DRClusterFencing.Name, From: string(ramen.Fenced), To: string(ramen.Available)},
+		}))
+	})
+
+	It("generates docs/state-machines.md", func() {
+		document, err := os.ReadFile("../../../docs/state-machines.md")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(document)).To(Equal(sm.Document()), "run make docs-state-machines")
+	})
+})
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/statemachine"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+// The suite fails any spec in which the controllers make a phase transition not in the transition tables, so these
+// specs drive the phase setters of the controllers through the tables and along runs of the simulator.
+var _ = Describe("Phase state machines", func() {
+	drCluster := func(phase rmn.DRClusterPhase) *drclusterInstance {
+		return &drclusterInstance{
+			log:    logr.Discard(),
+			object: &rmn.DRCluster{Status: rmn.DRClusterStatus{Phase: phase}},
+		}
+	}
+
+	// drClusterFire moves u on event as the DRCluster reconciler does, advancing to the next phase as an operation
+	// completes, and setting the phase the event moves to otherwise
+	drClusterFire := func(u *drclusterInstance, event statemachine.DRClusterEvent) {
+		if event == statemachine.DRClusterCompleted {
+			u.advanceToNextPhase()
+
+			return
+		}
+
+		next, ok := statemachine.DRClusterFencing.Next(u.getLastDRClusterPhase(), event)
+		Expect(ok).To(BeTrue(), "event %s in phase %q", event, u.getLastDRClusterPhase())
+		u.setDRClusterPhase(next)
+	}
+
+	drpc := func(phase rmn.DRState) *DRPCInstance {
+		return &DRPCInstance{
+			reconciler: &DRPlacementControlReconciler{
+				eventRecorder: rmnutil.NewEventReporter(record.NewFakeRecorder(100)),
+			},
+			log: logr.Discard(),
+			instance: &rmn.DRPlacementControl{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc"},
+				Status:     rmn.DRPlacementControlStatus{Phase: phase},
+			},
+		}
+	}
+
+	It("moves a DRCluster through each transition of the fencing table", func() {
+		for _, transition := range statemachine.DRClusterFencing.Transitions {
+			u := drCluster(transition.From)
+			drClusterFire(u, transition.Event)
+			Expect(u.object.Status.Phase).To(Equal(transition.To), "%+v", transition)
+		}
+	})
+
+	It("moves a DRPC through each transition of the action table", func() {
+		for _, transition := range statemachine.DRPCAction.Transitions {
+			d := drpc(transition.From)
+			d.setDRState(transition.To)
+			Expect(d.instance.Status.Phase).To(Equal(transition.To), "%+v", transition)
+		}
+	})
+
+	It("follows the simulator as a DRCluster is fenced, unfenced, and fenced and unfenced manually", func() {
+		simulator := statemachine.NewSimulator(statemachine.DRClusterFencing)
+		u := drCluster(simulator.State)
+
+		for _, event := range []statemachine.DRClusterEvent{
+			statemachine.DRClusterInitialize, statemachine.DRClusterClean, statemachine.DRClusterFence,
+			statemachine.DRClusterUnfence, statemachine.DRClusterCompleted, statemachine.DRClusterFence,
+			statemachine.DRClusterCompleted, statemachine.DRClusterManuallyUnfence, statemachine.DRClusterManuallyFence,
+			statemachine.DRClusterUnfence, statemachine.DRClusterCompleted, statemachine.DRClusterClean,
+		} {
+			Expect(simulator.Fire(event)).To(Succeed())
+			drClusterFire(u, event)
+			Expect(u.object.Status.Phase).To(Equal(simulator.State), "event %s", event)
+		}
+	})
+
+	It("follows the simulator as a DRPC is deployed, failed over, relocated, paused and deleted", func() {
+		simulator := statemachine.NewSimulator(statemachine.DRPCAction)
+		d := drpc(simulator.State)
+
+		Expect(simulator.Run(statemachine.DRPCInitiate, statemachine.DRPCDeploy, statemachine.DRPCDeployed,
+			statemachine.DRPCInitiate, statemachine.DRPCFailover, statemachine.DRPCDryRunDeployed,
+			statemachine.DRPCFailover, statemachine.DRPCFailedOver, statemachine.DRPCRelocate,
+			statemachine.DRPCFailover, statemachine.DRPCFailedOver, statemachine.DRPCInitiate,
+			statemachine.DRPCRelocate, statemachine.DRPCRelocated, statemachine.DRPCDelete)).To(Succeed())
+
+		for _, phase := range simulator.Trace[1:] {
+			d.setDRState(phase)
+			Expect(d.instance.Status.Phase).To(Equal(phase))
+		}
+
+		d = drpc("")
+		for _, phase := range []rmn.DRState{rmn.Initiating, rmn.WaitForUser, rmn.FailingOver, rmn.FailedOver} {
+			d.setDRState(phase)
+		}
+
+		Expect(d.instance.Status.Phase).To(Equal(rmn.FailedOver))
+	})
+})
@@ -94,6 +94,8 @@ const (
 	VRGConditionReasonClusterDataAnnotationFailed = "AnnotationFailed"
 	VRGConditionReasonPeerClassNotFound           = "PeerClassNotFound"
 	VRGConditionReasonStorageIDNotFound           = "StorageIDNotFound"
+	// Indicates an object of the capture to restore does not match its checksum, or was truncated
+	VRGConditionReasonCaptureCorrupt = "CaptureCorrupt"
 	// Indicates a conflict in cluster data detected on the primary cluster.
 	VRGConditionReasonClusterDataConflictPrimary = "ClusterDataConflictPrimary"
 
@@ -113,6 +115,53 @@ const (
 	// Hook-specific condition reasons for better visibility of hook failures
 	VRGConditionReasonHookExecuted = "HookExecuted"
 	VRGConditionReasonHookFailed   = "HookFailed"
+
+	// DRPC LocalFailover condition reasons
+	ReasonLocalFailoverArmed      = "Armed"
+	ReasonLocalFailoverInvalid    = "InvalidPlan"
+	ReasonLocalFailoverExecuted   = "Executed"
+	ReasonLocalFailoverReconciled = "Reconciled"
+
+	// DRPC ClusterUnavailable condition reasons
+	ReasonManifestWorkHeld      = "ManifestWorkHeld"
+	ReasonManifestWorkReapplied = "ManifestWorkReapplied"
+
+	// DRPC FailoverDependencies condition reasons
+	ReasonFailoverDependenciesPending   = "DependenciesPending"
+	ReasonFailoverDependenciesSatisfied = "DependenciesSatisfied"
+
+	// DRPC PreflightPassed condition reasons
+	ReasonPreflightChecksPassed = "ChecksPassed"
+	ReasonPreflightChecksFailed = "ChecksFailed"
+	ReasonPreflightChecksForced = "ChecksForced"
+
+	// DRPC Qualified condition reasons
+	ReasonQualificationSucceeded = "QualificationSucceeded"
+	ReasonQualificationFailed    = "QualificationFailed"
+
+	// AgentUnavailable condition reasons
+	ReasonAgentUnavailable = "AgentUnavailable"
+	ReasonAgentAvailable   = "AgentAvailable"
+
+	// VRGViewUnavailable condition reasons
+	ReasonVRGViewProcessing         = "ViewProcessing"
+	ReasonVRGViewStale              = "ViewStale"
+	ReasonVRGViewForbidden          = "ViewForbidden"
+	ReasonVRGViewClusterUnreachable = "ClusterUnreachable"
+	ReasonVRGViewFailed             = "ViewFailed"
+
+	// OperandHealthy condition reasons
+	ReasonOperandHealthy = "NoOperandErrors"
+	ReasonOperandErrors  = "OperandErrors"
+
+	// ManifestWorkSplit condition reasons
+	ReasonManifestWorkSplit    = "ManifestWorkSplit"
+	ReasonManifestWorkNotSplit = "ManifestWorkNotSplit"
+	ReasonManifestTooLarge     = "ManifestTooLarge"
+
+	// DanglingS3ProfileReference condition reasons
+	ReasonS3ProfileNotFound = "S3ProfileNotFound"
+	ReasonS3ProfileFound    = "S3ProfileFound"
 )
 
 const (
@@ -354,6 +403,17 @@ func setVRGClusterDataErrorCondition(conditions *[]metav1.Condition, observedGen
 	})
 }
 
+// sets conditions when the PV cluster data to restore is corrupt in every S3 store that has it
+func setVRGClusterDataCorruptCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	util.SetStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeClusterDataReady,
+		Reason:             VRGConditionReasonCaptureCorrupt,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
+
 // sets conditions when PV cluster data is protected
 func setVRGClusterDataProtectedCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
 	util.SetStatusCondition(conditions, *newVRGClusterDataProtectedCondition(observedGeneration, message))
@@ -7,6 +7,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -49,6 +50,7 @@ import (
 	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
 	ramencontrollers "github.com/ramendr/ramen/internal/controller"
 	argocdv1alpha1hack "github.com/ramendr/ramen/internal/controller/argocd"
+	"github.com/ramendr/ramen/internal/controller/statemachine"
 	testutils "github.com/ramendr/ramen/internal/controller/testutils"
 	"github.com/ramendr/ramen/internal/controller/util"
 	// +kubebuilder:scaffold:imports
@@ -95,6 +97,29 @@ var (
 	ramenNamespace = "ns-envtest"
 )
 
+var (
+	phaseTransitionViolationsMutex sync.Mutex
+	phaseTransitionViolations      []statemachine.Violation
+)
+
+// phaseTransitionViolationRecord records a phase transition that a controller made outside of the transition table
+// of its state machine, failing the spec it is made in
+func phaseTransitionViolationRecord(violation statemachine.Violation) {
+	phaseTransitionViolationsMutex.Lock()
+	defer phaseTransitionViolationsMutex.Unlock()
+
+	phaseTransitionViolations = append(phaseTransitionViolations, violation)
+}
+
+var _ = AfterEach(func() {
+	phaseTransitionViolationsMutex.Lock()
+	violations := phaseTransitionViolations
+	phaseTransitionViolations = nil
+	phaseTransitionViolationsMutex.Unlock()
+
+	Expect(violations).To(BeEmpty(), "phase transitions not in the state machine transition tables")
+})
+
 func TestAPIs(t *testing.T) {
 	RegisterFailHandler(Fail)
 
@@ -132,6 +157,8 @@ var _ = BeforeSuite(func() {
 	// default controller type to DRHubType
 	ramencontrollers.ControllerType = ramendrv1alpha1.DRHubType
 
+	statemachine.OnViolation = phaseTransitionViolationRecord
+
 	if _, set := os.LookupEnv("KUBEBUILDER_ASSETS"); !set {
 		testLog.Info("Setting up KUBEBUILDER_ASSETS for envtest")
 
@@ -345,6 +372,11 @@ var _ = BeforeSuite(func() {
 	err = util.IndexFieldsForVSHandler(context.TODO(), k8sManager.GetFieldIndexer())
 	Expect(err).ToNot(HaveOccurred())
 
+	// Index fields that are required to list ManifestWorks by parent DRPC or by type
+	err = util.IndexFieldsForManifestWorks(context.TODO(), k8sManager.GetFieldIndexer(),
+		ramencontrollers.DRPCNameAnnotation, ramencontrollers.DRPCNamespaceAnnotation)
+	Expect(err).ToNot(HaveOccurred())
+
 	rateLimiter := workqueue.NewTypedMaxOfRateLimiter(
 		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](10*time.Millisecond, 100*time.Millisecond),
 	)
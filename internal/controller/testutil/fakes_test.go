@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testutil_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/testutil"
+)
+
+var _ = Describe("FakeManagedClusterViewGetter", func() {
+	var getter *testutil.FakeManagedClusterViewGetter
+
+	vrg := &rmn.VolumeReplicationGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec:       rmn.VolumeReplicationGroupSpec{ReplicationState: rmn.Primary},
+	}
+
+	BeforeEach(func() {
+		getter = testutil.NewFakeManagedClusterViewGetter()
+	})
+
+	It("views the objects of a cluster it is programmed with, and keeps a view of each", func() {
+		Expect(getter.SetObject("east", vrg)).To(Succeed())
+
+		viewed, err := getter.GetVRGFromManagedCluster("app", "ns", "east", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(viewed.Spec.ReplicationState).To(Equal(rmn.Primary))
+
+		_, err = getter.GetVRGFromManagedCluster("app", "ns", "west", nil)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		views := getter.Views("east")
+		Expect(views).To(HaveLen(1))
+
+		viewed = &rmn.VolumeReplicationGroup{}
+		Expect(getter.GetResource(&views[0], viewed)).To(Succeed())
+		Expect(viewed.Name).To(Equal("app"))
+
+		Expect(getter.DeleteVRGManagedClusterView("app", "ns", "east", "")).To(Succeed())
+		Expect(getter.Views("east")).To(BeEmpty())
+	})
+
+	It("lists the classes of a cluster, and the views of the classes of a kind", func() {
+		for _, name := range []string{"b", "a"} {
+			Expect(getter.SetObject("east", &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: name}})).
+				To(Succeed())
+		}
+
+		scs, err := getter.ListSClassesFromManagedCluster("east", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(scs.Items).To(HaveLen(2))
+		Expect(scs.Items[0].Name).To(Equal("a"))
+
+		_, err = getter.GetSClassFromManagedCluster("a", "east", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		views, err := getter.ListSClassMCVs("east")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(views.Items).To(HaveLen(1))
+	})
+
+	It("records the calls, and injects errors and latency in them", func() {
+		errInjected := errors.New("injected")
+		getter.InjectError("GetVRGFromManagedCluster", errInjected)
+		getter.SetLatency(10 * time.Millisecond)
+
+		start := time.Now()
+		_, err := getter.GetVRGFromManagedCluster("app", "ns", "east", nil)
+		Expect(err).To(MatchError(errInjected))
+		Expect(time.Since(start)).To(BeNumerically(">=", 10*time.Millisecond))
+
+		getter.InjectError("GetVRGFromManagedCluster", nil)
+		_, err = getter.GetVRGFromManagedCluster("app", "ns", "east", nil)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		calls := getter.Calls("GetVRGFromManagedCluster")
+		Expect(calls).To(HaveLen(2))
+		Expect(calls[0].Args).To(Equal([]any{"app", "ns", "east"}))
+	})
+})
+
+var _ = Describe("FakeObjectStoreGetter", func() {
+	var getter *testutil.FakeObjectStoreGetter
+
+	BeforeEach(func() {
+		getter = testutil.NewFakeObjectStoreGetter()
+		getter.AddProfile(rmn.S3StoreProfile{S3ProfileName: "s3-east", S3Bucket: "bucket"})
+	})
+
+	It("returns the object store of a profile it is programmed with", func() {
+		storer, profile, err := getter.ObjectStore(context.TODO(), nil, "s3-east", "test", logr.Discard())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(profile.S3Bucket).To(Equal("bucket"))
+
+		Expect(storer.UploadObject("ns/app/a", map[string]string{"key": "value"})).To(Succeed())
+
+		downloaded := map[string]string{}
+		Expect(storer.DownloadObject("ns/app/a", &downloaded)).To(Succeed())
+		Expect(downloaded).To(HaveKeyWithValue("key", "value"))
+
+		var awsErr awserr.Error
+		err = storer.DownloadObject("ns/app/b", &downloaded)
+		Expect(errors.As(err, &awsErr)).To(BeTrue())
+		Expect(awsErr.Code()).To(Equal(s3.ErrCodeNoSuchKey))
+
+		Expect(storer.ListKeys("ns/")).To(Equal([]string{"ns/app/a"}))
+		Expect(storer.DeleteObjectsWithKeyPrefix("ns/")).To(Succeed())
+		Expect(getter.ObjectStorer("s3-east").Keys()).To(BeEmpty())
+
+		_, _, err = getter.ObjectStore(context.TODO(), nil, "s3-west", "test", logr.Discard())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("injects errors in the calls of any method", func() {
+		storer := getter.ObjectStorer("s3-east")
+		errInjected := errors.New("injected")
+		storer.InjectError(testutil.AnyMethod, errInjected)
+
+		Expect(storer.UploadObject("a", "o")).To(MatchError(errInjected))
+		Expect(storer.DeleteObject("a")).To(MatchError(errInjected))
+		Expect(storer.Calls(testutil.AnyMethod)).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,545 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
+	volrep "github.com/csi-addons/kubernetes-csi-addons/api/replication.storage/v1alpha1"
+	"github.com/go-logr/logr"
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	recipev1 "github.com/ramendr/recipe/api/v1alpha1"
+	groupsnapv1beta1 "github.com/red-hat-storage/external-snapshotter/client/v8/apis/volumegroupsnapshot/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/internal/controller/util"
+)
+
+// FakeManagedClusterViewGetter is a ManagedClusterViewGetter of the objects of the managed clusters it is programmed
+// with. Like the getter of the controllers, it keeps a ManagedClusterView, named and labeled alike, of each object
+// it is asked for, which it lists and deletes, and reads the object of with GetResource. An object the getter has
+// not been programmed with is not found.
+type FakeManagedClusterViewGetter struct {
+	Recorder
+
+	mutex   sync.Mutex
+	objects map[fakeObjectKey][]byte
+	views   map[string]map[string]*viewv1beta1.ManagedClusterView
+}
+
+// fakeObjectKey identifies an object of a managed cluster
+type fakeObjectKey struct {
+	cluster, kind, namespace, name string
+}
+
+// fakeView describes the view of an object, as the getter of the controllers creates it
+type fakeView struct {
+	name, kind, label string
+}
+
+var _ rmnutil.ManagedClusterViewGetter = &FakeManagedClusterViewGetter{}
+
+func NewFakeManagedClusterViewGetter() *FakeManagedClusterViewGetter {
+	return &FakeManagedClusterViewGetter{
+		objects: map[fakeObjectKey][]byte{},
+		views:   map[string]map[string]*viewv1beta1.ManagedClusterView{},
+	}
+}
+
+// fakeObjectKind returns the kind of object, which is the name of its type
+func fakeObjectKind(object any) string {
+	return reflect.TypeOf(object).Elem().Name()
+}
+
+// SetObject makes a copy of object viewable on cluster, replacing the object of the same kind and name, if any
+func (f *FakeManagedClusterViewGetter) SetObject(cluster string, object client.Object) error {
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s %s: %w", fakeObjectKind(object), object.GetName(), err)
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.objects[fakeObjectKey{cluster, fakeObjectKind(object), object.GetNamespace(), object.GetName()}] = encoded
+
+	return nil
+}
+
+// DeleteObject makes object no longer viewable on cluster
+func (f *FakeManagedClusterViewGetter) DeleteObject(cluster string, object client.Object) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	delete(f.objects, fakeObjectKey{cluster, fakeObjectKind(object), object.GetNamespace(), object.GetName()})
+}
+
+// Views returns the views the getter keeps of the objects of cluster, sorted by name
+func (f *FakeManagedClusterViewGetter) Views(cluster string) []viewv1beta1.ManagedClusterView {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.viewsWithLabel(cluster, "")
+}
+
+// viewsWithLabel returns the views of cluster with label, or all of them if it is empty, sorted by name
+func (f *FakeManagedClusterViewGetter) viewsWithLabel(cluster, label string) []viewv1beta1.ManagedClusterView {
+	views := []viewv1beta1.ManagedClusterView{}
+
+	for _, view := range f.views[cluster] {
+		if _, ok := view.Labels[label]; label == "" || ok {
+			views = append(views, *view.DeepCopy())
+		}
+	}
+
+	slices.SortFunc(views, func(a, b viewv1beta1.ManagedClusterView) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return views
+}
+
+// view records the view of the object of cluster, and decodes the object into object, or returns a not found error
+// if the getter has no such object
+func (f *FakeManagedClusterViewGetter) view(cluster, namespace, name string, view fakeView, object any) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.recordView(cluster, namespace, name, view)
+
+	return f.decode(fakeObjectKey{cluster, view.kind, namespace, name}, object)
+}
+
+// recordView records the view of the object of cluster, or of all the objects of its kind if name is empty
+func (f *FakeManagedClusterViewGetter) recordView(cluster, namespace, name string, view fakeView) {
+	if f.views[cluster] == nil {
+		f.views[cluster] = map[string]*viewv1beta1.ManagedClusterView{}
+	}
+
+	mcv := &viewv1beta1.ManagedClusterView{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      view.name,
+			Namespace: cluster,
+			Labels:    map[string]string{rmnutil.CreatedByRamenLabel: "true"},
+		},
+		Spec: viewv1beta1.ViewSpec{
+			Scope: viewv1beta1.ViewScope{Kind: view.kind, Name: name, Namespace: namespace},
+		},
+	}
+
+	if view.label != "" {
+		mcv.Labels[view.label] = ""
+	}
+
+	f.views[cluster][view.name] = mcv
+}
+
+// decode decodes the object of key into object, or returns a not found error if the getter has no such object
+func (f *FakeManagedClusterViewGetter) decode(key fakeObjectKey, object any) error {
+	encoded, ok := f.objects[key]
+	if !ok {
+		return k8serrors.NewNotFound(schema.GroupResource{Resource: strings.ToLower(key.kind)}, key.name)
+	}
+
+	return json.Unmarshal(encoded, object)
+}
+
+// list records the list-type view of the objects of kind of cluster, and decodes them into list, sorted by name
+func (f *FakeManagedClusterViewGetter) list(cluster, kind, resourceType string, list any) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.recordView(cluster, "", "", fakeView{
+		name: rmnutil.BuildManagedClusterViewName(resourceType, "", rmnutil.ListMCVSuffix),
+		kind: kind,
+	})
+
+	keys := []fakeObjectKey{}
+
+	for key := range f.objects {
+		if key.cluster == cluster && key.kind == kind {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b fakeObjectKey) int {
+		return strings.Compare(a.namespace+"/"+a.name, b.namespace+"/"+b.name)
+	})
+
+	items := make([]json.RawMessage, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, f.objects[key])
+	}
+
+	encoded, err := json.Marshal(map[string]any{"items": items})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(encoded, list)
+}
+
+// deleteView deletes the view named name of cluster, if any
+func (f *FakeManagedClusterViewGetter) deleteView(cluster, name string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	delete(f.views[cluster], name)
+}
+
+func (f *FakeManagedClusterViewGetter) GetVRGFromManagedCluster(resourceName, resourceNamespace,
+	managedCluster string, annotations map[string]string,
+) (*rmn.VolumeReplicationGroup, error) {
+	vrg := &rmn.VolumeReplicationGroup{}
+
+	if err := f.record("GetVRGFromManagedCluster", resourceName, resourceNamespace, managedCluster); err != nil {
+		return vrg, err
+	}
+
+	return vrg, f.view(managedCluster, resourceNamespace, resourceName, fakeView{
+		name: rmnutil.BuildManagedClusterViewName(resourceName, resourceNamespace, rmnutil.MWTypeVRG),
+		kind: "VolumeReplicationGroup",
+	}, vrg)
+}
+
+func (f *FakeManagedClusterViewGetter) GetNFFromManagedCluster(resourceName, networkFenceClassName,
+	resourceNamespace, managedCluster string, annotations map[string]string,
+) (*csiaddonsv1alpha1.NetworkFence, error) {
+	nf := &csiaddonsv1alpha1.NetworkFence{}
+
+	if err := f.record("GetNFFromManagedCluster", resourceName, networkFenceClassName, resourceNamespace,
+		managedCluster); err != nil {
+		return nf, err
+	}
+
+	name := strings.Join([]string{rmnutil.NetworkFencePrefix, resourceName}, "-")
+	if networkFenceClassName != "" {
+		name = strings.Join([]string{rmnutil.NetworkFencePrefix, networkFenceClassName, resourceName}, "-")
+	}
+
+	return nf, f.view(managedCluster, resourceNamespace, name, fakeView{
+		name: rmnutil.BuildManagedClusterViewName(name, resourceNamespace, rmnutil.MWTypeNF),
+		kind: "NetworkFence",
+	}, nf)
+}
+
+func (f *FakeManagedClusterViewGetter) GetMModeFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*rmn.MaintenanceMode, error) {
+	mMode := &rmn.MaintenanceMode{}
+
+	if err := f.record("GetMModeFromManagedCluster", resourceName, managedCluster); err != nil {
+		return mMode, err
+	}
+
+	return mMode, f.view(managedCluster, "", resourceName, fakeView{
+		name:  rmnutil.BuildManagedClusterViewName(resourceName, "", rmnutil.MWTypeMMode),
+		kind:  "MaintenanceMode",
+		label: rmnutil.MModesLabel,
+	}, mMode)
+}
+
+func (f *FakeManagedClusterViewGetter) ListMModesMCVs(managedCluster string) (
+	*viewv1beta1.ManagedClusterViewList, error,
+) {
+	return f.listViews("ListMModesMCVs", managedCluster, rmnutil.MModesLabel)
+}
+
+// listViews records a call of method, and returns the views of cluster with label
+func (f *FakeManagedClusterViewGetter) listViews(method, cluster, label string) (
+	*viewv1beta1.ManagedClusterViewList, error,
+) {
+	if err := f.record(method, cluster); err != nil {
+		return nil, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return &viewv1beta1.ManagedClusterViewList{Items: f.viewsWithLabel(cluster, label)}, nil
+}
+
+func (f *FakeManagedClusterViewGetter) GetDRClusterConfigFromManagedCluster(resourceName string,
+	annotations map[string]string,
+) (*rmn.DRClusterConfig, error) {
+	drcConfig := &rmn.DRClusterConfig{}
+
+	if err := f.record("GetDRClusterConfigFromManagedCluster", resourceName); err != nil {
+		return drcConfig, err
+	}
+
+	return drcConfig, f.view(resourceName, "", resourceName, fakeView{
+		name: rmnutil.BuildManagedClusterViewName(resourceName, "", rmnutil.MWTypeDRCConfig),
+		kind: "DRClusterConfig",
+	}, drcConfig)
+}
+
+func (f *FakeManagedClusterViewGetter) DeleteDRClusterConfigManagedClusterView(clusterName string) error {
+	if err := f.record("DeleteDRClusterConfigManagedClusterView", clusterName); err != nil {
+		return err
+	}
+
+	f.deleteView(clusterName, rmnutil.BuildManagedClusterViewName(clusterName, "", rmnutil.MWTypeDRCConfig))
+
+	return nil
+}
+
+// getClass records a call of method, and views the class named resourceName of managedCluster into class
+func (f *FakeManagedClusterViewGetter) getClass(method, resourceName, managedCluster, mwType, label string,
+	class client.Object,
+) error {
+	if err := f.record(method, resourceName, managedCluster); err != nil {
+		return err
+	}
+
+	return f.view(managedCluster, "", resourceName, fakeView{
+		name:  rmnutil.BuildManagedClusterViewName(resourceName, "", mwType),
+		kind:  fakeObjectKind(class),
+		label: label,
+	}, class)
+}
+
+// listClasses records a call of method, and lists the classes of kind of managedCluster into list
+func (f *FakeManagedClusterViewGetter) listClasses(method, managedCluster, kind, mwType string, list any) error {
+	if err := f.record(method, managedCluster); err != nil {
+		return err
+	}
+
+	return f.list(managedCluster, kind, mwType, list)
+}
+
+func (f *FakeManagedClusterViewGetter) GetSClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*storagev1.StorageClass, error) {
+	sc := &storagev1.StorageClass{}
+
+	return sc, f.getClass("GetSClassFromManagedCluster", resourceName, managedCluster, rmnutil.MWTypeSClass,
+		rmnutil.SClassLabel, sc)
+}
+
+func (f *FakeManagedClusterViewGetter) ListSClassMCVs(managedCluster string) (
+	*viewv1beta1.ManagedClusterViewList, error,
+) {
+	return f.listViews("ListSClassMCVs", managedCluster, rmnutil.SClassLabel)
+}
+
+func (f *FakeManagedClusterViewGetter) ListSClassesFromManagedCluster(managedCluster string,
+	annotations map[string]string,
+) (*storagev1.StorageClassList, error) {
+	scs := &storagev1.StorageClassList{}
+
+	return scs, f.listClasses("ListSClassesFromManagedCluster", managedCluster, "StorageClass",
+		rmnutil.MWTypeSClass, scs)
+}
+
+func (f *FakeManagedClusterViewGetter) GetNFClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*csiaddonsv1alpha1.NetworkFenceClass, error) {
+	nfc := &csiaddonsv1alpha1.NetworkFenceClass{}
+
+	return nfc, f.getClass("GetNFClassFromManagedCluster", resourceName, managedCluster, rmnutil.MWTypeNFClass,
+		rmnutil.NFClassLabel, nfc)
+}
+
+func (f *FakeManagedClusterViewGetter) ListNFClassMCVs(managedCluster string) (
+	*viewv1beta1.ManagedClusterViewList, error,
+) {
+	return f.listViews("ListNFClassMCVs", managedCluster, rmnutil.NFClassLabel)
+}
+
+func (f *FakeManagedClusterViewGetter) ListNFClassesFromManagedCluster(managedCluster string,
+	annotations map[string]string,
+) (*csiaddonsv1alpha1.NetworkFenceClassList, error) {
+	nfcs := &csiaddonsv1alpha1.NetworkFenceClassList{}
+
+	return nfcs, f.listClasses("ListNFClassesFromManagedCluster", managedCluster, "NetworkFenceClass",
+		rmnutil.MWTypeNFClass, nfcs)
+}
+
+func (f *FakeManagedClusterViewGetter) GetVSClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*snapv1.VolumeSnapshotClass, error) {
+	vsc := &snapv1.VolumeSnapshotClass{}
+
+	return vsc, f.getClass("GetVSClassFromManagedCluster", resourceName, managedCluster, rmnutil.MWTypeVSClass,
+		rmnutil.VSClassLabel, vsc)
+}
+
+func (f *FakeManagedClusterViewGetter) ListVSClassMCVs(managedCluster string) (
+	*viewv1beta1.ManagedClusterViewList, error,
+) {
+	return f.listViews("ListVSClassMCVs", managedCluster, rmnutil.VSClassLabel)
+}
+
+func (f *FakeManagedClusterViewGetter) GetVRClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*volrep.VolumeReplicationClass, error) {
+	vrc := &volrep.VolumeReplicationClass{}
+
+	return vrc, f.getClass("GetVRClassFromManagedCluster", resourceName, managedCluster, rmnutil.MWTypeVRClass,
+		rmnutil.VRClassLabel, vrc)
+}
+
+func (f *FakeManagedClusterViewGetter) ListVRClassMCVs(managedCluster string) (
+	*viewv1beta1.ManagedClusterViewList, error,
+) {
+	return f.listViews("ListVRClassMCVs", managedCluster, rmnutil.VRClassLabel)
+}
+
+func (f *FakeManagedClusterViewGetter) ListVRClassesFromManagedCluster(managedCluster string,
+	annotations map[string]string,
+) (*volrep.VolumeReplicationClassList, error) {
+	vrcs := &volrep.VolumeReplicationClassList{}
+
+	return vrcs, f.listClasses("ListVRClassesFromManagedCluster", managedCluster, "VolumeReplicationClass",
+		rmnutil.MWTypeVRClass, vrcs)
+}
+
+func (f *FakeManagedClusterViewGetter) GetVGSClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*groupsnapv1beta1.VolumeGroupSnapshotClass, error) {
+	vgsc := &groupsnapv1beta1.VolumeGroupSnapshotClass{}
+
+	return vgsc, f.getClass("GetVGSClassFromManagedCluster", resourceName, managedCluster, rmnutil.MWTypeVGSClass,
+		rmnutil.VGSClassLabel, vgsc)
+}
+
+func (f *FakeManagedClusterViewGetter) ListVGSClassMCVs(managedCluster string) (
+	*viewv1beta1.ManagedClusterViewList, error,
+) {
+	return f.listViews("ListVGSClassMCVs", managedCluster, rmnutil.VGSClassLabel)
+}
+
+func (f *FakeManagedClusterViewGetter) GetVGRClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*volrep.VolumeGroupReplicationClass, error) {
+	vgrc := &volrep.VolumeGroupReplicationClass{}
+
+	return vgrc, f.getClass("GetVGRClassFromManagedCluster", resourceName, managedCluster, rmnutil.MWTypeVGRClass,
+		rmnutil.VGRClassLabel, vgrc)
+}
+
+func (f *FakeManagedClusterViewGetter) ListVGRClassMCVs(managedCluster string) (
+	*viewv1beta1.ManagedClusterViewList, error,
+) {
+	return f.listViews("ListVGRClassMCVs", managedCluster, rmnutil.VGRClassLabel)
+}
+
+func (f *FakeManagedClusterViewGetter) GetNSFromManagedCluster(managedCluster, resourceName string,
+) (*corev1.Namespace, error) {
+	namespace := &corev1.Namespace{}
+
+	if err := f.record("GetNSFromManagedCluster", managedCluster, resourceName); err != nil {
+		return namespace, err
+	}
+
+	return namespace, f.view(managedCluster, "", resourceName, fakeView{
+		name: rmnutil.BuildManagedClusterViewName(resourceName, "", rmnutil.MWTypeNS),
+		kind: "Namespace",
+	}, namespace)
+}
+
+func (f *FakeManagedClusterViewGetter) GetRecipeFromManagedCluster(managedCluster, resourceName,
+	resourceNamespace string,
+) (*recipev1.Recipe, error) {
+	recipe := &recipev1.Recipe{}
+
+	if err := f.record("GetRecipeFromManagedCluster", managedCluster, resourceName, resourceNamespace); err != nil {
+		return recipe, err
+	}
+
+	return recipe, f.view(managedCluster, resourceNamespace, resourceName, fakeView{
+		name: rmnutil.BuildManagedClusterViewName(resourceName, resourceNamespace, rmnutil.MWTypeRecipe),
+		kind: "Recipe",
+	}, recipe)
+}
+
+// GetResource decodes the object in the scope of mcv into resource, or returns a not found error if the getter has
+// no such object
+func (f *FakeManagedClusterViewGetter) GetResource(mcv *viewv1beta1.ManagedClusterView, resource interface{}) error {
+	scope := mcv.Spec.Scope
+
+	if err := f.record("GetResource", mcv.Namespace, mcv.Name); err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.decode(fakeObjectKey{mcv.Namespace, scope.Kind, scope.Namespace, scope.Name}, resource)
+}
+
+func (f *FakeManagedClusterViewGetter) DeleteManagedClusterView(clusterName, mcvName string, logger logr.Logger,
+) error {
+	if err := f.record("DeleteManagedClusterView", clusterName, mcvName); err != nil {
+		return err
+	}
+
+	f.deleteView(clusterName, mcvName)
+
+	return nil
+}
+
+func (f *FakeManagedClusterViewGetter) DeleteVRGManagedClusterView(resourceName, resourceNamespace, clusterName,
+	resourceType string,
+) error {
+	if err := f.record("DeleteVRGManagedClusterView", resourceName, resourceNamespace, clusterName); err != nil {
+		return err
+	}
+
+	f.deleteView(clusterName, rmnutil.BuildManagedClusterViewName(resourceName, resourceNamespace, rmnutil.MWTypeVRG))
+
+	return nil
+}
+
+func (f *FakeManagedClusterViewGetter) DeleteNamespaceManagedClusterView(resourceName, resourceNamespace,
+	clusterName, resourceType string,
+) error {
+	if err := f.record("DeleteNamespaceManagedClusterView", resourceName, resourceNamespace,
+		clusterName); err != nil {
+		return err
+	}
+
+	f.deleteView(clusterName, rmnutil.BuildManagedClusterViewName(resourceName, resourceNamespace, rmnutil.MWTypeNS))
+
+	return nil
+}
+
+func (f *FakeManagedClusterViewGetter) DeleteNFManagedClusterView(resourceName, resourceNamespace, clusterName,
+	resourceType string,
+) error {
+	if err := f.record("DeleteNFManagedClusterView", resourceName, resourceNamespace, clusterName); err != nil {
+		return err
+	}
+
+	f.deleteView(clusterName, rmnutil.BuildManagedClusterViewName(resourceName, resourceNamespace, rmnutil.MWTypeNF))
+
+	return nil
+}
+
+func (f *FakeManagedClusterViewGetter) DeleteRecipeManagedClusterView(resourceName, resourceNamespace,
+	clusterName string,
+) error {
+	if err := f.record("DeleteRecipeManagedClusterView", resourceName, resourceNamespace, clusterName); err != nil {
+		return err
+	}
+
+	f.deleteView(clusterName,
+		rmnutil.BuildManagedClusterViewName(resourceName, resourceNamespace, rmnutil.MWTypeRecipe))
+
+	return nil
+}
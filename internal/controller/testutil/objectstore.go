@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	controllers "github.com/ramendr/ramen/internal/controller"
+)
+
+// FakeObjectStoreGetter is an ObjectStoreGetter of the S3 profiles it is programmed with, each of which has an
+// in-memory FakeObjectStorer
+type FakeObjectStoreGetter struct {
+	Recorder
+
+	mutex    sync.Mutex
+	profiles map[string]ramen.S3StoreProfile
+	storers  map[string]*FakeObjectStorer
+}
+
+var _ controllers.ObjectStoreGetter = &FakeObjectStoreGetter{}
+
+func NewFakeObjectStoreGetter() *FakeObjectStoreGetter {
+	return &FakeObjectStoreGetter{
+		profiles: map[string]ramen.S3StoreProfile{},
+		storers:  map[string]*FakeObjectStorer{},
+	}
+}
+
+// AddProfile makes the getter return an empty object store for profile, and returns it
+func (g *FakeObjectStoreGetter) AddProfile(profile ramen.S3StoreProfile) *FakeObjectStorer {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	storer := &FakeObjectStorer{objects: map[string][]byte{}}
+	g.profiles[profile.S3ProfileName] = profile
+	g.storers[profile.S3ProfileName] = storer
+
+	return storer
+}
+
+// RemoveProfile makes the getter fail to return an object store for the profile named s3ProfileName
+func (g *FakeObjectStoreGetter) RemoveProfile(s3ProfileName string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	delete(g.profiles, s3ProfileName)
+	delete(g.storers, s3ProfileName)
+}
+
+// ObjectStorer returns the object store of the profile named s3ProfileName, or nil if the getter has no such profile
+func (g *FakeObjectStoreGetter) ObjectStorer(s3ProfileName string) *FakeObjectStorer {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.storers[s3ProfileName]
+}
+
+// ObjectStore returns the object store of the profile named s3Profile, as the getter was programmed
+func (g *FakeObjectStoreGetter) ObjectStore(ctx context.Context, r client.Reader, s3Profile string, callerTag string,
+	log logr.Logger,
+) (controllers.ObjectStorer, ramen.S3StoreProfile, error) {
+	if err := g.record("ObjectStore", s3Profile, callerTag); err != nil {
+		return nil, ramen.S3StoreProfile{}, err
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	profile, ok := g.profiles[s3Profile]
+	if !ok {
+		return nil, profile, fmt.Errorf("failed to get profile %s for caller %s: profile not found", s3Profile,
+			callerTag)
+	}
+
+	return g.storers[s3Profile], profile, nil
+}
+
+// FakeObjectStorer is an in-memory ObjectStorer, which stores the objects JSON encoded as the S3 stores do, and fails
+// to download an object it does not have with the NoSuchKey error of S3
+type FakeObjectStorer struct {
+	Recorder
+
+	mutex   sync.Mutex
+	objects map[string][]byte
+}
+
+var _ controllers.ObjectStorer = &FakeObjectStorer{}
+
+func (s *FakeObjectStorer) UploadObject(key string, object interface{}) error {
+	if err := s.record("UploadObject", key, object); err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.objects[key] = encoded
+
+	return nil
+}
+
+func (s *FakeObjectStorer) DownloadObject(key string, objectPointer interface{}) error {
+	if err := s.record("DownloadObject", key); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	encoded, ok := s.objects[key]
+	s.mutex.Unlock()
+
+	if !ok {
+		return fmt.Errorf("failed to download %s: %w", key,
+			awserr.New(s3.ErrCodeNoSuchKey, "The specified key does not exist.", nil))
+	}
+
+	if err := json.Unmarshal(encoded, objectPointer); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// ListKeys returns the keys of the objects with keyPrefix, sorted
+func (s *FakeObjectStorer) ListKeys(keyPrefix string) ([]string, error) {
+	if err := s.record("ListKeys", keyPrefix); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keys := []string{}
+
+	for key := range s.objects {
+		if strings.HasPrefix(key, keyPrefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys, nil
+}
+
+func (s *FakeObjectStorer) DeleteObject(key string) error {
+	if err := s.record("DeleteObject", key); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.objects, key)
+
+	return nil
+}
+
+func (s *FakeObjectStorer) DeleteObjects(keys ...string) error {
+	if err := s.record("DeleteObjects", keys); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, key := range keys {
+		delete(s.objects, key)
+	}
+
+	return nil
+}
+
+func (s *FakeObjectStorer) DeleteObjectsWithKeyPrefix(keyPrefix string) error {
+	if err := s.record("DeleteObjectsWithKeyPrefix", keyPrefix); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for key := range s.objects {
+		if strings.HasPrefix(key, keyPrefix) {
+			delete(s.objects, key)
+		}
+	}
+
+	return nil
+}
+
+// Keys returns the keys of all the objects, sorted, without recording a call
+func (s *FakeObjectStorer) Keys() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testutil provides fakes of the interfaces the ramen controllers read the managed clusters and the S3
+// stores with, ManagedClusterViewGetter and ObjectStoreGetter, for the controllers to be unit tested without envtest
+// or object stores. The fakes respond with the objects they are programmed with, record their calls, and inject
+// latency and errors in them.
+//
+// The package imports the controllers package, so it is for the external test packages of the controllers, and for
+// the tests of other packages.
+package testutil
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// AnyMethod is the method an error injected for applies to all the methods of a fake
+const AnyMethod = ""
+
+// Call is a call of a method of a fake, with its arguments
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Recorder records the calls of the methods of a fake, and injects latency and errors in them. The zero value is
+// ready to use.
+type Recorder struct {
+	mutex   sync.Mutex
+	calls   []Call
+	latency time.Duration
+	errors  map[string]error
+}
+
+// SetLatency delays each call by latency
+func (r *Recorder) SetLatency(latency time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.latency = latency
+}
+
+// InjectError fails each call of method, or of any method if it is AnyMethod, with err, until it is injected again
+// with a nil error. An error injected for a method takes precedence over one injected for any method.
+func (r *Recorder) InjectError(method string, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err == nil {
+		delete(r.errors, method)
+
+		return
+	}
+
+	if r.errors == nil {
+		r.errors = map[string]error{}
+	}
+
+	r.errors[method] = err
+}
+
+// Calls returns the calls of method, or of all the methods if it is AnyMethod, in the order they were made
+func (r *Recorder) Calls(method string) []Call {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if method == AnyMethod {
+		return slices.Clone(r.calls)
+	}
+
+	calls := []Call{}
+
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// ResetCalls forgets the calls recorded
+func (r *Recorder) ResetCalls() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.calls = nil
+}
+
+// record records a call of method with args, delays it by the latency set, and returns the error injected for it, if
+// any
+func (r *Recorder) record(method string, args ...any) error {
+	r.mutex.Lock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+	latency := r.latency
+
+	err, ok := r.errors[method]
+	if !ok {
+		err = r.errors[AnyMethod]
+	}
+
+	r.mutex.Unlock()
+
+	time.Sleep(latency)
+
+	return err
+}
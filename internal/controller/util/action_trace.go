@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// The action trace annotations are set on the resources ramen generates for the managed clusters on behalf of the
+// actions of DRPCs, such as NetworkFences and MaintenanceModes, and on their ManifestWorks, for the admins of the
+// managed clusters to trace them back to the hub actions that created them
+const (
+	// ActionCorrelationIDAnnotation lists, comma separated, the correlation IDs of the actions, see
+	// ActionCorrelationID
+	ActionCorrelationIDAnnotation = "ramendr.openshift.io/action-correlation-ids"
+
+	// ActionDRPCsAnnotation lists, comma separated, the DRPCs of the actions, as <namespace>/<name>
+	ActionDRPCsAnnotation = "ramendr.openshift.io/action-drpcs"
+
+	// ActionTypeAnnotation is the type of the actions, e.g. Failover
+	ActionTypeAnnotation = "ramendr.openshift.io/action-type"
+)
+
+// ActionTrace identifies the actions of DRPCs, of a type, a resource was generated for
+type ActionTrace struct {
+	Action         rmn.DRAction
+	DRPCs          []string
+	CorrelationIDs []string
+}
+
+// ActionCorrelationID returns the ID that correlates the resources generated for the current action of drpc,
+// <uid>-<start time>, where start time is the Unix time the action started at, or the uid of drpc alone if the start
+// of the action is not recorded
+func ActionCorrelationID(drpc *rmn.DRPlacementControl) string {
+	if drpc.Status.ActionStartTime == nil {
+		return string(drpc.GetUID())
+	}
+
+	return fmt.Sprintf("%s-%d", drpc.GetUID(), drpc.Status.ActionStartTime.Unix())
+}
+
+// Add adds the current action of drpc to the trace, unless it is already traced, keeping the DRPCs sorted
+func (t *ActionTrace) Add(drpc *rmn.DRPlacementControl) {
+	name := drpc.GetNamespace() + "/" + drpc.GetName()
+	correlationID := ActionCorrelationID(drpc)
+
+	if !slices.Contains(t.DRPCs, name) {
+		t.DRPCs = append(t.DRPCs, name)
+		slices.Sort(t.DRPCs)
+	}
+
+	if !slices.Contains(t.CorrelationIDs, correlationID) {
+		t.CorrelationIDs = append(t.CorrelationIDs, correlationID)
+		slices.Sort(t.CorrelationIDs)
+	}
+}
+
+// Traces returns whether the current action of drpc is traced
+func (t ActionTrace) Traces(drpc *rmn.DRPlacementControl) bool {
+	return slices.Contains(t.CorrelationIDs, ActionCorrelationID(drpc))
+}
+
+// ActionTraceFromAnnotations returns the action trace obj is annotated with, which is empty if it is not
+func ActionTraceFromAnnotations(obj client.Object) ActionTrace {
+	annotations := obj.GetAnnotations()
+	split := func(key string) []string {
+		if annotations[key] == "" {
+			return nil
+		}
+
+		return strings.Split(annotations[key], ",")
+	}
+
+	return ActionTrace{
+		Action:         rmn.DRAction(annotations[ActionTypeAnnotation]),
+		DRPCs:          split(ActionDRPCsAnnotation),
+		CorrelationIDs: split(ActionCorrelationIDAnnotation),
+	}
+}
+
+// ActionTraceAnnotate annotates obj with trace, removing the action trace annotations of obj that trace does not
+// set. Returns true if obj was modified.
+func ActionTraceAnnotate(obj client.Object, trace ActionTrace) bool {
+	desired := map[string]string{
+		ActionTypeAnnotation:          string(trace.Action),
+		ActionDRPCsAnnotation:         strings.Join(trace.DRPCs, ","),
+		ActionCorrelationIDAnnotation: strings.Join(trace.CorrelationIDs, ","),
+	}
+
+	annotations := obj.GetAnnotations()
+	updated := false
+
+	for key, value := range desired {
+		current, ok := annotations[key]
+
+		switch {
+		case value == "" && ok:
+			delete(annotations, key)
+
+			updated = true
+		case value != "" && value != current:
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+
+			annotations[key] = value
+			updated = true
+		}
+	}
+
+	if updated {
+		obj.SetAnnotations(annotations)
+	}
+
+	return updated
+}
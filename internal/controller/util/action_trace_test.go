@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("ActionTrace", func() {
+	start := metav1.NewTime(time.Unix(1700000000, 0))
+
+	newDRPC := func(namespace, name, uid string) *rmn.DRPlacementControl {
+		return &rmn.DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: k8stypes.UID("uid-" + uid)},
+			Status:     rmn.DRPlacementControlStatus{ActionStartTime: &start},
+		}
+	}
+
+	It("correlates the resources of an action by the uid of its DRPC and its start time", func() {
+		drpc := newDRPC("app", "drpc", "1")
+		Expect(util.ActionCorrelationID(drpc)).To(Equal("uid-1-1700000000"))
+
+		drpc.Status.ActionStartTime = nil
+		Expect(util.ActionCorrelationID(drpc)).To(Equal("uid-1"))
+	})
+
+	It("annotates a resource with the actions of several DRPCs, and reads them back", func() {
+		trace := util.ActionTrace{Action: rmn.ActionFailover}
+		trace.Add(newDRPC("b", "drpc", "2"))
+		trace.Add(newDRPC("a", "drpc", "1"))
+		trace.Add(newDRPC("a", "drpc", "1"))
+
+		mMode := &rmn.MaintenanceMode{}
+		Expect(util.ActionTraceAnnotate(mMode, trace)).To(BeTrue())
+		Expect(mMode.GetAnnotations()).To(Equal(map[string]string{
+			util.ActionTypeAnnotation:          "Failover",
+			util.ActionDRPCsAnnotation:         "a/drpc,b/drpc",
+			util.ActionCorrelationIDAnnotation: "uid-1-1700000000,uid-2-1700000000",
+		}))
+		Expect(util.ActionTraceAnnotate(mMode, trace)).To(BeFalse())
+
+		read := util.ActionTraceFromAnnotations(mMode)
+		Expect(read).To(Equal(trace))
+		Expect(read.Traces(newDRPC("a", "drpc", "1"))).To(BeTrue())
+		Expect(read.Traces(newDRPC("c", "drpc", "3"))).To(BeFalse())
+	})
+
+	It("removes the trace annotations of a resource no longer traced, keeping the others", func() {
+		mMode := &rmn.MaintenanceMode{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			util.ActionTypeAnnotation: "Failover",
+			"other":                   "kept",
+		}}}
+
+		Expect(util.ActionTraceAnnotate(mMode, util.ActionTrace{})).To(BeTrue())
+		Expect(mMode.GetAnnotations()).To(Equal(map[string]string{"other": "kept"}))
+		Expect(util.ActionTraceFromAnnotations(mMode)).To(Equal(util.ActionTrace{}))
+	})
+})
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+	"time"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	backupWindowStartLayout = "15:04"
+	backupWindowDay         = 24 * time.Hour
+)
+
+// backupWindowStart returns the start of window on the day of t, in UTC
+func backupWindowStart(window rmn.BackupWindow, t time.Time) (time.Time, error) {
+	start, err := time.Parse(backupWindowStartLayout, window.Start)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("backup window start %q is not hh:mm: %w", window.Start, err)
+	}
+
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), t.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC), nil
+}
+
+// BackupWindowEnd returns the end of the latest ending window of windows that t is in, or the zero time if t is in
+// none of them
+func BackupWindowEnd(windows []rmn.BackupWindow, t time.Time) time.Time {
+	end := time.Time{}
+
+	for _, window := range windows {
+		start, err := backupWindowStart(window, t)
+		if err != nil {
+			continue
+		}
+
+		// the window of the day before may extend into the day of t
+		for _, start := range []time.Time{start.Add(-backupWindowDay), start} {
+			windowEnd := start.Add(window.Duration.Duration)
+			if !t.Before(start) && t.Before(windowEnd) && windowEnd.After(end) {
+				end = windowEnd
+			}
+		}
+	}
+
+	return end
+}
+
+// BackupWindowDelay returns the time from t until the end of the window of windows that t is in, or 0 if t is in
+// none of them
+func BackupWindowDelay(windows []rmn.BackupWindow, t time.Time) time.Duration {
+	end := BackupWindowEnd(windows, t)
+	if end.IsZero() {
+		return 0
+	}
+
+	return end.Sub(t)
+}
+
+// BackupWindowsValidate returns an error if a window of the backup windows of drPolicy is malformed, or leaves less
+// than the scheduling interval of the policy of the day outside of the window, for replication to keep up. A cron
+// schedule of the policy is left to be aligned with the windows by the user.
+func BackupWindowsValidate(drPolicy *rmn.DRPolicy) error {
+	if len(drPolicy.Spec.BackupWindows) == 0 {
+		return nil
+	}
+
+	interval := time.Duration(0)
+
+	if !SchedulingIntervalIsCron(drPolicy.Spec.SchedulingInterval) {
+		intervalSeconds, err := GetSecondsFromSchedulingInterval(drPolicy)
+		if err != nil {
+			return fmt.Errorf("scheduling interval %q is invalid: %w", drPolicy.Spec.SchedulingInterval, err)
+		}
+
+		interval = time.Duration(intervalSeconds * float64(time.Second))
+	}
+
+	for _, window := range drPolicy.Spec.BackupWindows {
+		if _, err := backupWindowStart(window, time.Time{}); err != nil {
+			return err
+		}
+
+		if window.Duration.Duration <= 0 {
+			return fmt.Errorf("backup window starting at %s has no duration", window.Start)
+		}
+
+		if window.Duration.Duration+interval > backupWindowDay {
+			return fmt.Errorf("backup window starting at %s for %v leaves less than the scheduling interval %s "+
+				"of the day outside of it", window.Start, window.Duration.Duration, drPolicy.Spec.SchedulingInterval)
+		}
+	}
+
+	return nil
+}
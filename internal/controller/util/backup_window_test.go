@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("BackupWindows", func() {
+	window := func(start string, duration time.Duration) rmn.BackupWindow {
+		return rmn.BackupWindow{Start: start, Duration: metav1.Duration{Duration: duration}}
+	}
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, time.March, 10, hour, minute, 0, 0, time.UTC)
+	}
+
+	drPolicy := func(interval string, windows ...rmn.BackupWindow) *rmn.DRPolicy {
+		return &rmn.DRPolicy{Spec: rmn.DRPolicySpec{SchedulingInterval: interval, BackupWindows: windows}}
+	}
+
+	It("delays until the end of the window the time is in", func() {
+		windows := []rmn.BackupWindow{window("01:00", 2*time.Hour)}
+
+		Expect(util.BackupWindowDelay(windows, at(0, 59))).To(BeZero())
+		Expect(util.BackupWindowDelay(windows, at(1, 0))).To(Equal(2 * time.Hour))
+		Expect(util.BackupWindowDelay(windows, at(2, 30))).To(Equal(30 * time.Minute))
+		Expect(util.BackupWindowDelay(windows, at(3, 0))).To(BeZero())
+	})
+
+	It("delays until the end of a window started the day before", func() {
+		windows := []rmn.BackupWindow{window("23:00", 2*time.Hour)}
+
+		Expect(util.BackupWindowEnd(windows, at(0, 30))).To(Equal(at(1, 0)))
+		Expect(util.BackupWindowEnd(windows, at(23, 30))).To(Equal(at(1, 0).Add(24 * time.Hour)))
+		Expect(util.BackupWindowEnd(windows, at(1, 0)).IsZero()).To(BeTrue())
+	})
+
+	It("validates the windows against the scheduling interval", func() {
+		Expect(util.BackupWindowsValidate(drPolicy("1h"))).To(Succeed())
+		Expect(util.BackupWindowsValidate(drPolicy("1h", window("01:00", 23*time.Hour)))).To(Succeed())
+		Expect(util.BackupWindowsValidate(drPolicy("1h", window("01:00", 23*time.Hour+time.Minute)))).ToNot(Succeed())
+		Expect(util.BackupWindowsValidate(drPolicy("1h", window("25:00", time.Hour)))).ToNot(Succeed())
+		Expect(util.BackupWindowsValidate(drPolicy("1h", window("01:00", 0)))).ToNot(Succeed())
+		Expect(util.BackupWindowsValidate(drPolicy("0 6 * * *", window("07:00", 23*time.Hour)))).To(Succeed())
+	})
+})
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CSIAddonsFencingKinds are the csi-addons kinds used to fence clusters and to detect the fencing classes and clients
+var CSIAddonsFencingKinds = []schema.GroupVersionKind{
+	csiaddonsv1alpha1.GroupVersion.WithKind("NetworkFence"),
+	csiaddonsv1alpha1.GroupVersion.WithKind("NetworkFenceClass"),
+	csiaddonsv1alpha1.GroupVersion.WithKind("CSIAddonsNode"),
+}
+
+// KindsInstalled returns true if the API server serves all of the kinds, and false if any of them is not installed
+func KindsInstalled(mapper meta.RESTMapper, gvks ...schema.GroupVersionKind) (bool, error) {
+	for _, gvk := range gvks {
+		if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			if meta.IsNoMatchError(err) {
+				return false, nil
+			}
+
+			return false, fmt.Errorf("failed to look up kind %s: %w", gvk, err)
+		}
+	}
+
+	return true, nil
+}
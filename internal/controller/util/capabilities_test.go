@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("KindsInstalled", func() {
+	It("reports whether all the csi-addons fencing kinds are served", func() {
+		mapper := meta.NewDefaultRESTMapper(nil)
+
+		installed, err := util.KindsInstalled(mapper, util.CSIAddonsFencingKinds...)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(installed).To(BeFalse())
+
+		for _, gvk := range util.CSIAddonsFencingKinds[:len(util.CSIAddonsFencingKinds)-1] {
+			mapper.Add(gvk, meta.RESTScopeRoot)
+		}
+
+		installed, err = util.KindsInstalled(mapper, util.CSIAddonsFencingKinds...)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(installed).To(BeFalse())
+
+		mapper.Add(util.CSIAddonsFencingKinds[len(util.CSIAddonsFencingKinds)-1], meta.RESTScopeRoot)
+
+		installed, err = util.KindsInstalled(mapper, util.CSIAddonsFencingKinds...)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(installed).To(BeTrue())
+	})
+})
@@ -0,0 +1,393 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
+	volrep "github.com/csi-addons/kubernetes-csi-addons/api/replication.storage/v1alpha1"
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	recipev1 "github.com/ramendr/recipe/api/v1alpha1"
+	groupsnapv1beta1 "github.com/red-hat-storage/external-snapshotter/client/v8/apis/volumegroupsnapshot/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	// clusterProxyTokenKey is the key of the token in the secret of a ManagedServiceAccount
+	clusterProxyTokenKey = "token"
+
+	// clusterProxyTokenRecheckInterval is how often the token of a managed cluster is read again, to pick up
+	// rotated tokens
+	clusterProxyTokenRecheckInterval = 5 * time.Minute
+)
+
+// errClusterProxyUnavailable is returned when a managed cluster cannot be accessed through the cluster-proxy, and
+// its objects are to be read using ManagedClusterViews instead
+var errClusterProxyUnavailable = errors.New("cluster-proxy unavailable")
+
+// ManagedClusterObjectReader reads objects on the managed clusters directly, supporting queries, such as lists
+// selected by labels, that ManagedClusterViews cannot express
+type ManagedClusterObjectReader interface {
+	GetFromManagedCluster(ctx context.Context, managedCluster string, key client.ObjectKey, obj client.Object) error
+
+	ListFromManagedCluster(ctx context.Context, managedCluster string, list client.ObjectList,
+		opts ...client.ListOption) error
+}
+
+// ClusterProxyGetter reads VRGs, NetworkFences, DRClusterConfigs, classes, namespaces and recipes on the managed
+// clusters through the user server of the OCM cluster-proxy addon, without the latency of ManagedClusterViews. Their
+// views are kept, as their events trigger the reconciles of the DRPCs and DRClusters. Objects that the cluster-proxy
+// fails to read, and MaintenanceModes, whose status is tracked using their views, are read using ManagedClusterViews.
+type ClusterProxyGetter struct {
+	ManagedClusterViewGetterImpl
+
+	proxyConfig rmn.ClusterProxyConfig
+	scheme      *runtime.Scheme
+	clients     *clusterProxyClients
+}
+
+type clusterProxyClients struct {
+	sync.Mutex
+	byCluster map[string]*clusterProxyClient
+}
+
+type clusterProxyClient struct {
+	client.Client
+	token     string
+	checkedAt time.Time
+}
+
+// NewClusterProxyGetter returns a ClusterProxyGetter configured by config, reading through mcvGetter the tokens of
+// the managed clusters and the objects that are not read through the cluster-proxy
+func NewClusterProxyGetter(mcvGetter ManagedClusterViewGetterImpl, config rmn.ClusterProxyConfig,
+) ClusterProxyGetter {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(rmn.AddToScheme(scheme))
+	utilruntime.Must(csiaddonsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(volrep.AddToScheme(scheme))
+	utilruntime.Must(snapv1.AddToScheme(scheme))
+	utilruntime.Must(groupsnapv1beta1.AddToScheme(scheme))
+	utilruntime.Must(recipev1.AddToScheme(scheme))
+
+	return ClusterProxyGetter{
+		ManagedClusterViewGetterImpl: mcvGetter,
+		proxyConfig:                  config,
+		scheme:                       scheme,
+		clients:                      &clusterProxyClients{byCluster: map[string]*clusterProxyClient{}},
+	}
+}
+
+// GetFromManagedCluster reads the object named key on managedCluster through the cluster-proxy
+func (m ClusterProxyGetter) GetFromManagedCluster(ctx context.Context, managedCluster string,
+	key client.ObjectKey, obj client.Object,
+) error {
+	c, err := m.clusterClient(ctx, managedCluster)
+	if err != nil {
+		return err
+	}
+
+	return m.clusterProxyError(managedCluster, c.Get(ctx, key, obj))
+}
+
+// ListFromManagedCluster lists the objects on managedCluster selected by opts through the cluster-proxy
+func (m ClusterProxyGetter) ListFromManagedCluster(ctx context.Context, managedCluster string,
+	list client.ObjectList, opts ...client.ListOption,
+) error {
+	c, err := m.clusterClient(ctx, managedCluster)
+	if err != nil {
+		return err
+	}
+
+	return m.clusterProxyError(managedCluster, c.List(ctx, list, opts...))
+}
+
+// clusterClient returns the client of managedCluster, or errClusterProxyUnavailable if the secret with the token
+// of the ManagedServiceAccount does not exist in the namespace of the cluster
+func (m ClusterProxyGetter) clusterClient(ctx context.Context, managedCluster string) (client.Client, error) {
+	m.clients.Lock()
+	defer m.clients.Unlock()
+
+	cached := m.clients.byCluster[managedCluster]
+	if cached != nil && time.Since(cached.checkedAt) < clusterProxyTokenRecheckInterval {
+		return cached.Client, nil
+	}
+
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Name: m.proxyConfig.ManagedServiceAccountName, Namespace: managedCluster}
+
+	if err := m.APIReader.Get(ctx, key, secret); err != nil {
+		delete(m.clients.byCluster, managedCluster)
+
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: secret %s not found", errClusterProxyUnavailable, key)
+		}
+
+		return nil, fmt.Errorf("failed to get secret %s: %w", key, err)
+	}
+
+	token := string(secret.Data[clusterProxyTokenKey])
+	if token == "" {
+		return nil, fmt.Errorf("%w: secret %s has no token", errClusterProxyUnavailable, key)
+	}
+
+	if cached != nil && cached.token == token {
+		cached.checkedAt = time.Now()
+
+		return cached.Client, nil
+	}
+
+	c, err := client.New(m.clusterRESTConfig(managedCluster, token), client.Options{Scheme: m.scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster-proxy client of cluster %s: %w", managedCluster, err)
+	}
+
+	m.clients.byCluster[managedCluster] = &clusterProxyClient{Client: c, token: token, checkedAt: time.Now()}
+
+	return c, nil
+}
+
+// clusterRESTConfig returns the config of the requests to managedCluster through the cluster-proxy user server
+func (m ClusterProxyGetter) clusterRESTConfig(managedCluster, token string) *rest.Config {
+	config := &rest.Config{
+		Host:            strings.TrimSuffix(m.proxyConfig.ServerURL, "/") + "/" + managedCluster,
+		BearerToken:     token,
+		TLSClientConfig: rest.TLSClientConfig{CAFile: m.proxyConfig.CAFile},
+	}
+
+	if m.proxyConfig.ImpersonateUser != "" {
+		config.Impersonate = rest.ImpersonationConfig{UserName: m.proxyConfig.ImpersonateUser}
+	}
+
+	return config
+}
+
+// clusterProxyError classifies an error reading through the cluster-proxy as the errors of ManagedClusterViews, so
+// that callers handle both alike. A client whose token is rejected is dropped, to read the token again.
+func (m ClusterProxyGetter) clusterProxyError(managedCluster string, err error) error {
+	var urlErr *url.Error
+
+	switch {
+	case err == nil:
+		return nil
+	case k8serrors.IsNotFound(err):
+		return newMCVError(MCVErrorReasonNotFound, time.Time{}, err)
+	case k8serrors.IsForbidden(err):
+		return newMCVError(MCVErrorReasonForbidden, time.Time{}, err)
+	case k8serrors.IsUnauthorized(err):
+		m.clients.Lock()
+		delete(m.clients.byCluster, managedCluster)
+		m.clients.Unlock()
+	case k8serrors.IsServiceUnavailable(err), k8serrors.IsTimeout(err), k8serrors.IsServerTimeout(err),
+		errors.As(err, &urlErr):
+		return newMCVError(MCVErrorReasonClusterUnreachable, time.Time{}, err)
+	}
+
+	return fmt.Errorf("failed to read from cluster %s through cluster-proxy: %w", managedCluster, err)
+}
+
+// clusterProxyGet reads the object named name in namespace on managedCluster through the cluster-proxy, keeping its
+// view, whose events trigger the reconciles of its consumers, with viewGet. The object is read with viewGet instead
+// if the cluster-proxy fails to read it.
+func clusterProxyGet[T any, PT interface {
+	*T
+	client.Object
+}](m ClusterProxyGetter, managedCluster, name, namespace string,
+	viewGet func(ManagedClusterViewGetterImpl) (PT, error),
+) (PT, error) {
+	obj := PT(new(T))
+
+	err := m.GetFromManagedCluster(context.TODO(), managedCluster, client.ObjectKey{Name: name, Namespace: namespace},
+		obj)
+	if clusterProxyFallback(err) {
+		return viewGet(m.ManagedClusterViewGetterImpl)
+	}
+
+	if _, viewErr := viewGet(m.viewKeeper()); viewErr != nil {
+		return obj, viewErr
+	}
+
+	return obj, err
+}
+
+// clusterProxyList lists the objects on managedCluster through the cluster-proxy, keeping their list view with
+// viewList. The objects are listed with viewList instead if the cluster-proxy fails to list them.
+func clusterProxyList[T any, PT interface {
+	*T
+	client.ObjectList
+}](m ClusterProxyGetter, managedCluster string, viewList func(ManagedClusterViewGetterImpl) (PT, error),
+) (PT, error) {
+	list := PT(new(T))
+
+	err := m.ListFromManagedCluster(context.TODO(), managedCluster, list)
+	if clusterProxyFallback(err) {
+		return viewList(m.ManagedClusterViewGetterImpl)
+	}
+
+	if _, viewErr := viewList(m.viewKeeper()); viewErr != nil {
+		return list, viewErr
+	}
+
+	return list, err
+}
+
+// clusterProxyFallback returns whether an object is to be read using its ManagedClusterView, as the cluster-proxy
+// failed to read it for another reason than the object not existing on the managed cluster
+func clusterProxyFallback(err error) bool {
+	return err != nil && !IsMCVNotFound(err)
+}
+
+// viewKeeper returns the getter of the ManagedClusterViews that creates or updates the views without reading them
+func (m ClusterProxyGetter) viewKeeper() ManagedClusterViewGetterImpl {
+	views := m.ManagedClusterViewGetterImpl
+	views.viewOnly = true
+	views.ReadObserver = nil
+
+	return views
+}
+
+func (m ClusterProxyGetter) GetVRGFromManagedCluster(resourceName, resourceNamespace, managedCluster string,
+	annotations map[string]string,
+) (*rmn.VolumeReplicationGroup, error) {
+	return clusterProxyGet[rmn.VolumeReplicationGroup](m, managedCluster, resourceName, resourceNamespace,
+		func(views ManagedClusterViewGetterImpl) (*rmn.VolumeReplicationGroup, error) {
+			return views.GetVRGFromManagedCluster(resourceName, resourceNamespace, managedCluster, annotations)
+		})
+}
+
+func (m ClusterProxyGetter) GetNFFromManagedCluster(targetCluster, networkFenceClassName,
+	resourceNamespace, managedCluster string, annotations map[string]string,
+) (*csiaddonsv1alpha1.NetworkFence, error) {
+	resourceName := strings.Join([]string{NetworkFencePrefix, targetCluster}, "-")
+	if networkFenceClassName != "" {
+		resourceName = strings.Join([]string{NetworkFencePrefix, networkFenceClassName, targetCluster}, "-")
+	}
+
+	return clusterProxyGet[csiaddonsv1alpha1.NetworkFence](m, managedCluster, resourceName, "",
+		func(views ManagedClusterViewGetterImpl) (*csiaddonsv1alpha1.NetworkFence, error) {
+			return views.GetNFFromManagedCluster(targetCluster, networkFenceClassName, resourceNamespace,
+				managedCluster, annotations)
+		})
+}
+
+func (m ClusterProxyGetter) GetDRClusterConfigFromManagedCluster(clusterName string,
+	annotations map[string]string,
+) (*rmn.DRClusterConfig, error) {
+	return clusterProxyGet[rmn.DRClusterConfig](m, clusterName, clusterName, "",
+		func(views ManagedClusterViewGetterImpl) (*rmn.DRClusterConfig, error) {
+			return views.GetDRClusterConfigFromManagedCluster(clusterName, annotations)
+		})
+}
+
+func (m ClusterProxyGetter) GetSClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*storagev1.StorageClass, error) {
+	return clusterProxyGet[storagev1.StorageClass](m, managedCluster, resourceName, "",
+		func(views ManagedClusterViewGetterImpl) (*storagev1.StorageClass, error) {
+			return views.GetSClassFromManagedCluster(resourceName, managedCluster, annotations)
+		})
+}
+
+func (m ClusterProxyGetter) ListSClassesFromManagedCluster(managedCluster string,
+	annotations map[string]string,
+) (*storagev1.StorageClassList, error) {
+	return clusterProxyList[storagev1.StorageClassList](m, managedCluster,
+		func(views ManagedClusterViewGetterImpl) (*storagev1.StorageClassList, error) {
+			return views.ListSClassesFromManagedCluster(managedCluster, annotations)
+		})
+}
+
+func (m ClusterProxyGetter) GetNFClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*csiaddonsv1alpha1.NetworkFenceClass, error) {
+	return clusterProxyGet[csiaddonsv1alpha1.NetworkFenceClass](m, managedCluster, resourceName, "",
+		func(views ManagedClusterViewGetterImpl) (*csiaddonsv1alpha1.NetworkFenceClass, error) {
+			return views.GetNFClassFromManagedCluster(resourceName, managedCluster, annotations)
+		})
+}
+
+func (m ClusterProxyGetter) ListNFClassesFromManagedCluster(managedCluster string,
+	annotations map[string]string,
+) (*csiaddonsv1alpha1.NetworkFenceClassList, error) {
+	return clusterProxyList[csiaddonsv1alpha1.NetworkFenceClassList](m, managedCluster,
+		func(views ManagedClusterViewGetterImpl) (*csiaddonsv1alpha1.NetworkFenceClassList, error) {
+			return views.ListNFClassesFromManagedCluster(managedCluster, annotations)
+		})
+}
+
+func (m ClusterProxyGetter) GetVSClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*snapv1.VolumeSnapshotClass, error) {
+	return clusterProxyGet[snapv1.VolumeSnapshotClass](m, managedCluster, resourceName, "",
+		func(views ManagedClusterViewGetterImpl) (*snapv1.VolumeSnapshotClass, error) {
+			return views.GetVSClassFromManagedCluster(resourceName, managedCluster, annotations)
+		})
+}
+
+func (m ClusterProxyGetter) GetVRClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*volrep.VolumeReplicationClass, error) {
+	return clusterProxyGet[volrep.VolumeReplicationClass](m, managedCluster, resourceName, "",
+		func(views ManagedClusterViewGetterImpl) (*volrep.VolumeReplicationClass, error) {
+			return views.GetVRClassFromManagedCluster(resourceName, managedCluster, annotations)
+		})
+}
+
+func (m ClusterProxyGetter) ListVRClassesFromManagedCluster(managedCluster string,
+	annotations map[string]string,
+) (*volrep.VolumeReplicationClassList, error) {
+	return clusterProxyList[volrep.VolumeReplicationClassList](m, managedCluster,
+		func(views ManagedClusterViewGetterImpl) (*volrep.VolumeReplicationClassList, error) {
+			return views.ListVRClassesFromManagedCluster(managedCluster, annotations)
+		})
+}
+
+func (m ClusterProxyGetter) GetVGSClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*groupsnapv1beta1.VolumeGroupSnapshotClass, error) {
+	return clusterProxyGet[groupsnapv1beta1.VolumeGroupSnapshotClass](m, managedCluster, resourceName, "",
+		func(views ManagedClusterViewGetterImpl) (*groupsnapv1beta1.VolumeGroupSnapshotClass, error) {
+			return views.GetVGSClassFromManagedCluster(resourceName, managedCluster, annotations)
+		})
+}
+
+func (m ClusterProxyGetter) GetVGRClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*volrep.VolumeGroupReplicationClass, error) {
+	return clusterProxyGet[volrep.VolumeGroupReplicationClass](m, managedCluster, resourceName, "",
+		func(views ManagedClusterViewGetterImpl) (*volrep.VolumeGroupReplicationClass, error) {
+			return views.GetVGRClassFromManagedCluster(resourceName, managedCluster, annotations)
+		})
+}
+
+func (m ClusterProxyGetter) GetNSFromManagedCluster(managedCluster, resourceName string) (*corev1.Namespace, error) {
+	return clusterProxyGet[corev1.Namespace](m, managedCluster, resourceName, "",
+		func(views ManagedClusterViewGetterImpl) (*corev1.Namespace, error) {
+			return views.GetNSFromManagedCluster(managedCluster, resourceName)
+		})
+}
+
+func (m ClusterProxyGetter) GetRecipeFromManagedCluster(managedCluster, resourceName, resourceNamespace string,
+) (*recipev1.Recipe, error) {
+	return clusterProxyGet[recipev1.Recipe](m, managedCluster, resourceName, resourceNamespace,
+		func(views ManagedClusterViewGetterImpl) (*recipev1.Recipe, error) {
+			return views.GetRecipeFromManagedCluster(managedCluster, resourceName, resourceNamespace)
+		})
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"context"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("ClusterProxyGetter", func() {
+	newGetter := func(serverURL string, objects ...client.Object) util.ClusterProxyGetter {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(viewv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+		return util.NewClusterProxyGetter(
+			util.ManagedClusterViewGetterImpl{Client: fakeClient, APIReader: fakeClient},
+			rmn.ClusterProxyConfig{ServerURL: serverURL, ManagedServiceAccountName: "ramen-reader"},
+		)
+	}
+
+	It("reads using a ManagedClusterView from a cluster without a token", func() {
+		getter := newGetter("https://cluster-proxy.invalid:9092")
+
+		_, err := getter.GetVRGFromManagedCluster("vrg", "app", "cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+
+		mcv := &viewv1beta1.ManagedClusterView{}
+		Expect(getter.Get(context.TODO(), types.NamespacedName{
+			Name:      util.BuildManagedClusterViewName("vrg", "app", "vrg"),
+			Namespace: "cluster1",
+		}, mcv)).To(Succeed())
+	})
+
+	It("reads using a ManagedClusterView from a cluster whose proxy cannot be reached", func() {
+		server := httptest.NewServer(nil)
+		serverURL := server.URL
+		server.Close()
+
+		getter := newGetter(serverURL, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ramen-reader", Namespace: "cluster1"},
+			Data:       map[string][]byte{"token": []byte("token")},
+		})
+
+		_, err := getter.GetVRGFromManagedCluster("vrg", "app", "cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue(), "%v", err)
+
+		mcv := &viewv1beta1.ManagedClusterView{}
+		Expect(getter.Get(context.TODO(), types.NamespacedName{
+			Name:      util.BuildManagedClusterViewName("vrg", "app", "vrg"),
+			Namespace: "cluster1",
+		}, mcv)).To(Succeed())
+	})
+})
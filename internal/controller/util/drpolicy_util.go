@@ -11,8 +11,10 @@ import (
 	"strconv"
 	"time"
 
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -98,6 +100,17 @@ func GetSecondsFromSchedulingInterval(drpolicy *rmn.DRPolicy) (float64, error) {
 		return 0, nil
 	}
 
+	if SchedulingIntervalIsCron(schedulingInterval) {
+		schedule, err := SchedulingIntervalCronSchedule(schedulingInterval)
+		if err != nil {
+			return 0, err
+		}
+
+		gap, err := CronScheduleGapMax(schedule, time.Now())
+
+		return gap.Seconds(), err
+	}
+
 	intervalFormat := schedulingInterval[len(schedulingInterval)-1:] // extracts m|h|d string
 	interval := schedulingInterval[:len(schedulingInterval)-1]       // extracts numerical value of schedulingInterval
 	dayInSeconds := 24 * 60 * 60
@@ -115,6 +128,63 @@ func GetSecondsFromSchedulingInterval(drpolicy *rmn.DRPolicy) (float64, error) {
 	}
 }
 
+// StorageClassSchedulingInterval returns the scheduling interval of the first of the overrides whose selector matches
+// the labels of storageClass, or schedulingInterval if none does
+func StorageClassSchedulingInterval(overrides []rmn.StorageClassSchedulingInterval, storageClass *storagev1.StorageClass,
+	schedulingInterval string,
+) string {
+	for i := range overrides {
+		selector, err := metav1.LabelSelectorAsSelector(&overrides[i].StorageClassSelector)
+		if err != nil {
+			continue
+		}
+
+		if selector.Matches(labels.Set(storageClass.GetLabels())) {
+			return overrides[i].SchedulingInterval
+		}
+	}
+
+	return schedulingInterval
+}
+
+// StorageClassSchedulingIntervalsValidate returns an error if the storage class selector of a scheduling interval
+// override of drPolicy is invalid, or the policy overrides the scheduling interval of a metro policy
+func StorageClassSchedulingIntervalsValidate(drPolicy *rmn.DRPolicy) error {
+	if len(drPolicy.Spec.StorageClassSchedulingIntervals) != 0 && drPolicy.Spec.SchedulingInterval == "" {
+		return fmt.Errorf("storage class scheduling intervals require a scheduling interval")
+	}
+
+	for i := range drPolicy.Spec.StorageClassSchedulingIntervals {
+		override := &drPolicy.Spec.StorageClassSchedulingIntervals[i]
+
+		if _, err := metav1.LabelSelectorAsSelector(&override.StorageClassSelector); err != nil {
+			return fmt.Errorf("storage class selector of scheduling interval %s is invalid: %w",
+				override.SchedulingInterval, err)
+		}
+	}
+
+	return nil
+}
+
+// DRPolicySchedulingIntervals returns the scheduling interval of drPolicy, if any, followed by the distinct
+// scheduling intervals of its storage class overrides
+func DRPolicySchedulingIntervals(drPolicy *rmn.DRPolicy) []string {
+	intervals := []string{}
+
+	if drPolicy.Spec.SchedulingInterval != "" {
+		intervals = append(intervals, drPolicy.Spec.SchedulingInterval)
+	}
+
+	for i := range drPolicy.Spec.StorageClassSchedulingIntervals {
+		if interval := drPolicy.Spec.StorageClassSchedulingIntervals[i].SchedulingInterval; interval != "" &&
+			!slices.Contains(intervals, interval) {
+			intervals = append(intervals, interval)
+		}
+	}
+
+	return intervals
+}
+
 func DrpolicyContainsDrcluster(drpolicy *rmn.DRPolicy, drcluster string) bool {
 	return slices.Contains(DRPolicyClusterNames(drpolicy), drcluster)
 }
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("StorageClassSchedulingIntervals", func() {
+	override := func(backend, interval string) rmn.StorageClassSchedulingInterval {
+		return rmn.StorageClassSchedulingInterval{
+			StorageClassSelector: metav1.LabelSelector{MatchLabels: map[string]string{"backend": backend}},
+			SchedulingInterval:   interval,
+		}
+	}
+
+	storageClass := func(backend string) *storagev1.StorageClass {
+		return &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"backend": backend}}}
+	}
+
+	drPolicy := &rmn.DRPolicy{Spec: rmn.DRPolicySpec{
+		SchedulingInterval: "5m",
+		StorageClassSchedulingIntervals: []rmn.StorageClassSchedulingInterval{
+			override("array", "1h"), override("array", "2h"), override("rbd", "5m"),
+		},
+	}}
+
+	It("overrides the scheduling interval of the storage classes selected first", func() {
+		overrides := drPolicy.Spec.StorageClassSchedulingIntervals
+
+		Expect(util.StorageClassSchedulingInterval(overrides, storageClass("array"), "5m")).To(Equal("1h"))
+		Expect(util.StorageClassSchedulingInterval(overrides, storageClass("nfs"), "5m")).To(Equal("5m"))
+	})
+
+	It("lists the distinct scheduling intervals of the policy", func() {
+		Expect(util.DRPolicySchedulingIntervals(drPolicy)).To(Equal([]string{"5m", "1h", "2h"}))
+		Expect(util.StorageClassSchedulingIntervalsValidate(drPolicy)).To(Succeed())
+	})
+
+	It("rejects overrides of a policy without a scheduling interval", func() {
+		metroPolicy := drPolicy.DeepCopy()
+		metroPolicy.Spec.SchedulingInterval = ""
+
+		Expect(util.StorageClassSchedulingIntervalsValidate(metroPolicy)).ToNot(Succeed())
+	})
+})
@@ -55,6 +55,20 @@ const (
 	// EventReasonSecondarySuccess is an event generated when VRG is successfully
 	// processed as Primary.
 	EventReasonDeleteSuccess = "VRGDeleteSuccess"
+
+	// EventReasonLocalFailoverExecuted is generated when a VRG promotes itself per a pre-approved local
+	// failover plan while the hub is unreachable, and when the hub later observes it
+	EventReasonLocalFailoverExecuted = "LocalFailoverExecuted"
+
+	// EventReasonFinalizationHooksExecuted is generated when the finalization hooks of a VRG being deleted succeed
+	EventReasonFinalizationHooksExecuted = "VRGFinalizationHooksExecuted"
+
+	// EventReasonFinalizationHooksFailed is generated when a finalization hook of a VRG being deleted fails
+	EventReasonFinalizationHooksFailed = "VRGFinalizationHooksFailed"
+
+	// EventReasonFinalizationHooksSkipped is generated when the finalization hooks of a VRG being deleted are
+	// skipped, by annotation or on timeout
+	EventReasonFinalizationHooksSkipped = "VRGFinalizationHooksSkipped"
 	// TODO: Add any additional events (or remove one of existing ones above) if necessary.
 
 	// Events for DRPC Reconciler
@@ -90,6 +104,14 @@ const (
 	// EventReasonSwitchFailed is generated when DRPC fails to switch the cluster
 	// where the app is placed
 	EventReasonSwitchFailed = "DRPCClusterSwitchFailed"
+
+	// EventReasonVRGSpecDowngraded is generated when DRPC leaves fields of the VRG spec that the cluster of the VRG
+	// does not support out of it
+	EventReasonVRGSpecDowngraded = "DRPCVRGSpecDowngraded"
+
+	// EventReasonClusterFenceRequested is generated when DRPC requests the cluster it fails over from to be fenced,
+	// as its DRPolicy prefers automatic fencing
+	EventReasonClusterFenceRequested = "DRPCClusterFenceRequested"
 )
 
 // EventReporter is custom events reporter type which allows user to limit the events
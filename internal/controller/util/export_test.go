@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Exported for the util_test package only
+
+type JSONPatchOperation = jsonPatchOperation
+
+var ManifestWorkSpecPatchOperations = manifestWorkSpecPatchOperations
+
+func (mwu *MWUtil) PropagateMetadata(obj client.Object) bool {
+	return mwu.propagateMetadata(obj)
+}
+
+func (mwu *MWUtil) CreateOrUpdateManifestWork(mw *ocmworkv1.ManifestWork, cluster string,
+) (ctrlutil.OperationResult, error) {
+	return mwu.createOrUpdateManifestWork(mw, cluster)
+}
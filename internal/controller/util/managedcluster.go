@@ -5,15 +5,37 @@ package util
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
 	ocmv1 "open-cluster-management.io/api/cluster/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// ManagedClusterLeaseName is the lease in the managed cluster namespace on the hub, renewed by the klusterlet
+	// registration agent of the managed cluster
+	ManagedClusterLeaseName = "managed-cluster-lease"
+
+	// WorkManagerAddOnName is the add-on that reports the health of the work agent of a managed cluster
+	WorkManagerAddOnName = "work-manager"
+
+	// agentLeaseGraceFactor is the number of lease durations after which a lease that was not renewed is considered
+	// stale. The hub marks the cluster unavailable only after 5 lease durations.
+	agentLeaseGraceFactor = 2
+
+	defaultLeaseDurationSeconds = 60
+)
+
 const (
 	// Prefixes for various ClusterClaims
 	CCSCPrefix  = "storage.class"
@@ -115,3 +137,113 @@ func (mci *ManagedClusterInstance) VolumeSnapshotClassClaims() []string {
 func (mci *ManagedClusterInstance) VolumeReplicationClassClaims() []string {
 	return mci.classClaims(CCVRCPrefix)
 }
+
+// ClusterUnavailableError is returned for ManifestWork operations that are held back because the target
+// ManagedCluster is not available, for example when it is hibernated or temporarily detached
+type ClusterUnavailableError struct {
+	Cluster string
+}
+
+func (e ClusterUnavailableError) Error() string {
+	return fmt.Sprintf("cluster (%s) is unavailable", e.Cluster)
+}
+
+// IsClusterUnavailable returns true if err, or any error it wraps, is a ClusterUnavailableError
+func IsClusterUnavailable(err error) bool {
+	var clusterUnavailableErr ClusterUnavailableError
+
+	return errors.As(err, &clusterUnavailableErr)
+}
+
+// ManagedClusterAvailable returns false only if the ManagedCluster for cluster reports that it is not available.
+// A ManagedCluster that cannot be read, or that does not report its availability, is assumed to be available.
+func ManagedClusterAvailable(ctx context.Context, reader client.Reader, cluster string) bool {
+	mc := &ocmv1.ManagedCluster{}
+
+	if err := reader.Get(ctx, types.NamespacedName{Name: cluster}, mc); err != nil {
+		return true
+	}
+
+	return ManagedClusterObjectAvailable(mc)
+}
+
+// ManagedClusterObjectAvailable returns false only if the passed in ManagedCluster reports that it is not available,
+// or that its availability is Unknown, as the hub lost contact with the registration agent of the cluster
+func ManagedClusterObjectAvailable(mc *ocmv1.ManagedCluster) bool {
+	condition := meta.FindStatusCondition(mc.Status.Conditions, ocmv1.ManagedClusterConditionAvailable)
+
+	return condition == nil || condition.Status == v1.ConditionTrue
+}
+
+// ManagedClusterAgentUnavailable returns why the klusterlet agents of cluster appear to be unavailable, or an empty
+// string if they appear healthy. Agents are unavailable if the ManagedCluster is not available, its lease was not
+// renewed recently, or the work-manager add-on is not available. Health that cannot be determined, for example as the
+// add-on is not installed, is not reported as unavailable. Use an uncached reader, as leases are not cached.
+func ManagedClusterAgentUnavailable(ctx context.Context, reader client.Reader, cluster string) string {
+	mc := &ocmv1.ManagedCluster{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: cluster}, mc); err != nil {
+		return ""
+	}
+
+	condition := meta.FindStatusCondition(mc.Status.Conditions, ocmv1.ManagedClusterConditionAvailable)
+	if condition != nil && condition.Status != v1.ConditionTrue {
+		return fmt.Sprintf("cluster %s is not available: %s", cluster, condition.Message)
+	}
+
+	lease := &coordinationv1.Lease{}
+
+	err := reader.Get(ctx, types.NamespacedName{Namespace: cluster, Name: ManagedClusterLeaseName}, lease)
+	if err == nil && lease.Spec.RenewTime != nil {
+		leaseDuration := time.Duration(mc.Spec.LeaseDurationSeconds) * time.Second
+		if leaseDuration == 0 {
+			leaseDuration = defaultLeaseDurationSeconds * time.Second
+		}
+
+		if age := time.Since(lease.Spec.RenewTime.Time); age > agentLeaseGraceFactor*leaseDuration {
+			return fmt.Sprintf("cluster %s lease was not renewed for %v", cluster, age.Round(time.Second))
+		}
+	}
+
+	if condition := workManagerAddOnAvailableCondition(ctx, reader, cluster); condition != nil &&
+		condition.Status != v1.ConditionTrue {
+		return fmt.Sprintf("cluster %s %s add-on is not available: %s", cluster, WorkManagerAddOnName,
+			condition.Message)
+	}
+
+	return ""
+}
+
+// ManagedClusterAgentAvailableAlternate returns the first of the candidate clusters whose klusterlet agents appear to
+// be available, to suggest in place of a cluster whose agents are unavailable, or an empty string if there is none.
+// Candidates without a ManagedCluster are skipped, as their agents cannot be assumed to be available.
+func ManagedClusterAgentAvailableAlternate(ctx context.Context, reader client.Reader, candidates []string) string {
+	for _, cluster := range candidates {
+		if err := reader.Get(ctx, types.NamespacedName{Name: cluster}, &ocmv1.ManagedCluster{}); err != nil {
+			continue
+		}
+
+		if ManagedClusterAgentUnavailable(ctx, reader, cluster) == "" {
+			return cluster
+		}
+	}
+
+	return ""
+}
+
+// workManagerAddOnAvailableCondition returns the Available condition of the work-manager add-on of cluster, or nil
+// if it cannot be read. The add-on is read as unstructured, as add-on types are not registered with the scheme.
+func workManagerAddOnAvailableCondition(ctx context.Context, reader client.Reader, cluster string) *v1.Condition {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(addonv1alpha1.SchemeGroupVersion.WithKind("ManagedClusterAddOn"))
+
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: cluster, Name: WorkManagerAddOnName}, obj); err != nil {
+		return nil
+	}
+
+	addon := &addonv1alpha1.ManagedClusterAddOn{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, addon); err != nil {
+		return nil
+	}
+
+	return meta.FindStatusCondition(addon.Status.Conditions, addonv1alpha1.ManagedClusterAddOnConditionAvailable)
+}
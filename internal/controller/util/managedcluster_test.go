@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	ocmv1 "open-cluster-management.io/api/cluster/v1"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("Klusterlet agent availability", func() {
+	var objects []client.Object
+
+	managedCluster := func(name string, available metav1.ConditionStatus) *ocmv1.ManagedCluster {
+		return &ocmv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: ocmv1.ManagedClusterStatus{Conditions: []metav1.Condition{{
+				Type:    ocmv1.ManagedClusterConditionAvailable,
+				Status:  available,
+				Message: "Registration agent stopped updating its lease.",
+			}}},
+		}
+	}
+
+	lease := func(cluster string, renewed time.Time) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cluster, Name: util.ManagedClusterLeaseName},
+			Spec:       coordinationv1.LeaseSpec{RenewTime: &metav1.MicroTime{Time: renewed}},
+		}
+	}
+
+	reader := func() client.Reader {
+		scheme := runtime.NewScheme()
+		Expect(ocmv1.AddToScheme(scheme)).To(Succeed())
+		Expect(coordinationv1.AddToScheme(scheme)).To(Succeed())
+		Expect(addonv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	}
+
+	unavailable := func(cluster string) string {
+		return util.ManagedClusterAgentUnavailable(context.TODO(), reader(), cluster)
+	}
+
+	BeforeEach(func() {
+		objects = []client.Object{
+			managedCluster("east", metav1.ConditionTrue),
+			lease("east", time.Now()),
+		}
+	})
+
+	It("reports the agents of an available cluster with a renewed lease as available", func() {
+		Expect(unavailable("east")).To(BeEmpty())
+	})
+
+	It("reports the agents of a cluster that is not available as unavailable", func() {
+		objects[0] = managedCluster("east", metav1.ConditionFalse)
+
+		Expect(unavailable("east")).To(ContainSubstring("cluster east is not available"))
+	})
+
+	It("reports the agents of a cluster whose lease was not renewed as unavailable", func() {
+		objects[1] = lease("east", time.Now().Add(-5*time.Minute))
+
+		Expect(unavailable("east")).To(ContainSubstring("lease was not renewed"))
+	})
+
+	It("reports the agents of a cluster whose work-manager add-on is not available as unavailable", func() {
+		objects = append(objects, &addonv1alpha1.ManagedClusterAddOn{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "east", Name: util.WorkManagerAddOnName},
+			Status: addonv1alpha1.ManagedClusterAddOnStatus{Conditions: []metav1.Condition{{
+				Type:    addonv1alpha1.ManagedClusterAddOnConditionAvailable,
+				Status:  metav1.ConditionFalse,
+				Reason:  "ManagedClusterAddOnLeaseUpdateStopped",
+				Message: "Addon stopped updating its lease.",
+			}}},
+		})
+
+		Expect(unavailable("east")).To(ContainSubstring("work-manager add-on is not available"))
+	})
+
+	It("suggests the first candidate with available agents as an alternate", func() {
+		objects = append(objects,
+			managedCluster("west", metav1.ConditionFalse),
+			managedCluster("north", metav1.ConditionTrue),
+		)
+
+		Expect(util.ManagedClusterAgentAvailableAlternate(context.TODO(), reader(),
+			[]string{"missing", "west", "north", "east"})).To(Equal("north"))
+		Expect(util.ManagedClusterAgentAvailableAlternate(context.TODO(), reader(),
+			[]string{"missing", "west"})).To(BeEmpty())
+	})
+})
+
+var _ = Describe("ManagedCluster availability", func() {
+	managedCluster := func(conditions ...metav1.Condition) *ocmv1.ManagedCluster {
+		return &ocmv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "east"},
+			Status:     ocmv1.ManagedClusterStatus{Conditions: conditions},
+		}
+	}
+
+	available := func(status metav1.ConditionStatus) metav1.Condition {
+		return metav1.Condition{Type: ocmv1.ManagedClusterConditionAvailable, Status: status}
+	}
+
+	DescribeTable("is reported by the Available condition of the ManagedCluster",
+		func(mc *ocmv1.ManagedCluster, expected bool) {
+			Expect(util.ManagedClusterObjectAvailable(mc)).To(Equal(expected))
+		},
+		Entry("available", managedCluster(available(metav1.ConditionTrue)), true),
+		Entry("not available", managedCluster(available(metav1.ConditionFalse)), false),
+		Entry("unknown, as the hub lost contact with the cluster", managedCluster(available(metav1.ConditionUnknown)),
+			false),
+		Entry("not reported yet", managedCluster(), true),
+	)
+
+	Context("of ManifestWorks", func() {
+		var (
+			fakeClient client.Client
+			mwu        *util.MWUtil
+		)
+
+		createOrUpdate := func() error {
+			return mwu.CreateOrUpdateNamespaceManifestWork("drpc", "app", "east", nil, nil)
+		}
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(ocmv1.AddToScheme(scheme)).To(Succeed())
+			Expect(ocmworkv1.AddToScheme(scheme)).To(Succeed())
+
+			fakeClient = fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(managedCluster(available(metav1.ConditionUnknown))).Build()
+			mwu = &util.MWUtil{Client: fakeClient, APIReader: fakeClient, Ctx: context.TODO(), Log: logr.Discard()}
+		})
+
+		It("holds their operations while the cluster is unavailable, until it is available again", func() {
+			err := createOrUpdate()
+			Expect(util.IsClusterUnavailable(err)).To(BeTrue())
+			Expect(err).To(MatchError(util.ClusterUnavailableError{Cluster: "east"}))
+
+			works := &ocmworkv1.ManifestWorkList{}
+			Expect(fakeClient.List(context.TODO(), works)).To(Succeed())
+			Expect(works.Items).To(BeEmpty())
+
+			mc := &ocmv1.ManagedCluster{}
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Name: "east"}, mc)).To(Succeed())
+			mc.Status.Conditions = []metav1.Condition{available(metav1.ConditionTrue)}
+			Expect(fakeClient.Update(context.TODO(), mc)).To(Succeed())
+			Expect(createOrUpdate()).To(Succeed())
+
+			Expect(fakeClient.List(context.TODO(), works)).To(Succeed())
+			Expect(works.Items).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"sync"
+	"time"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
+	"github.com/go-logr/logr"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// DefaultManagedClusterViewCacheTTLs are the durations, per resource type, that CachingManagedClusterViewGetter
+// serves a read from its cache
+var DefaultManagedClusterViewCacheTTLs = map[string]time.Duration{
+	MWTypeDRCConfig: 10 * time.Second,
+	MWTypeSClass:    30 * time.Second,
+	MWTypeNFClass:   30 * time.Second,
+}
+
+// ManagedClusterViewCacheInvalidator is implemented by ManagedClusterViewGetters that cache reads, to drop the
+// cached reads of a managed cluster when they may be outdated, such as when a ManifestWork of the cluster is updated
+type ManagedClusterViewCacheInvalidator interface {
+	InvalidateManagedCluster(managedCluster string)
+}
+
+// CachingManagedClusterViewGetter is a read-through cache of the DRClusterConfig, StorageClass and
+// NetworkFenceClass reads, and the lists of those classes and of their ManagedClusterViews, of a
+// ManagedClusterViewGetter. Only
+// successful reads are cached, each for the TTL of its resource type; resource types without a TTL are not cached.
+type CachingManagedClusterViewGetter struct {
+	ManagedClusterViewGetter
+	cache *mcvCache
+}
+
+func NewCachingManagedClusterViewGetter(getter ManagedClusterViewGetter, ttls map[string]time.Duration,
+) CachingManagedClusterViewGetter {
+	return CachingManagedClusterViewGetter{
+		ManagedClusterViewGetter: getter,
+		cache: &mcvCache{
+			ttls:    ttls,
+			entries: map[mcvCacheKey]mcvCacheEntry{},
+		},
+	}
+}
+
+// WithGetter returns a CachingManagedClusterViewGetter of getter that shares the cache of m, for a consumer of the
+// same views as that of m
+func (m CachingManagedClusterViewGetter) WithGetter(getter ManagedClusterViewGetter,
+) CachingManagedClusterViewGetter {
+	return CachingManagedClusterViewGetter{ManagedClusterViewGetter: getter, cache: m.cache}
+}
+
+func (m CachingManagedClusterViewGetter) InvalidateManagedCluster(managedCluster string) {
+	m.cache.invalidate(managedCluster)
+}
+
+// acquire records the consumer of the getter as a consumer of the view of the object of kind named name on cluster,
+// as a read served from the cache, possibly filled by another consumer, does not reach the getter
+func (m CachingManagedClusterViewGetter) acquire(cluster string, gvk schema.GroupVersionKind, name string) {
+	if acquirer, ok := m.ManagedClusterViewGetter.(ManagedClusterViewAcquirer); ok {
+		acquirer.AcquireManagedClusterView(ManagedClusterViewKey{
+			Cluster: cluster,
+			GVK:     gvk,
+			Name:    types.NamespacedName{Name: name},
+		})
+	}
+}
+
+func (m CachingManagedClusterViewGetter) GetDRClusterConfigFromManagedCluster(clusterName string,
+	annotations map[string]string,
+) (*rmn.DRClusterConfig, error) {
+	m.acquire(clusterName, rmn.GroupVersion.WithKind("DRClusterConfig"), clusterName)
+
+	return mcvCacheGet(m.cache, mcvCacheKey{clusterName, MWTypeDRCConfig, clusterName},
+		func() (*rmn.DRClusterConfig, error) {
+			return m.ManagedClusterViewGetter.GetDRClusterConfigFromManagedCluster(clusterName, annotations)
+		})
+}
+
+func (m CachingManagedClusterViewGetter) DeleteDRClusterConfigManagedClusterView(clusterName string) error {
+	m.cache.delete(mcvCacheKey{clusterName, MWTypeDRCConfig, clusterName})
+
+	return m.ManagedClusterViewGetter.DeleteDRClusterConfigManagedClusterView(clusterName)
+}
+
+func (m CachingManagedClusterViewGetter) GetSClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*storagev1.StorageClass, error) {
+	m.acquire(managedCluster, storagev1.SchemeGroupVersion.WithKind("StorageClass"), resourceName)
+
+	return mcvCacheGet(m.cache, mcvCacheKey{managedCluster, MWTypeSClass, resourceName},
+		func() (*storagev1.StorageClass, error) {
+			return m.ManagedClusterViewGetter.GetSClassFromManagedCluster(resourceName, managedCluster, annotations)
+		})
+}
+
+func (m CachingManagedClusterViewGetter) ListSClassMCVs(cluster string) (*viewv1beta1.ManagedClusterViewList, error) {
+	return mcvCacheGet(m.cache, mcvCacheKey{cluster, MWTypeSClass, ""},
+		func() (*viewv1beta1.ManagedClusterViewList, error) {
+			return m.ManagedClusterViewGetter.ListSClassMCVs(cluster)
+		})
+}
+
+func (m CachingManagedClusterViewGetter) ListSClassesFromManagedCluster(managedCluster string,
+	annotations map[string]string,
+) (*storagev1.StorageClassList, error) {
+	m.acquire(managedCluster, storagev1.SchemeGroupVersion.WithKind("StorageClass"), "")
+
+	return mcvCacheGet(m.cache, mcvCacheKey{managedCluster, MWTypeSClass, mcvCacheAllNames},
+		func() (*storagev1.StorageClassList, error) {
+			return m.ManagedClusterViewGetter.ListSClassesFromManagedCluster(managedCluster, annotations)
+		})
+}
+
+func (m CachingManagedClusterViewGetter) GetNFClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*csiaddonsv1alpha1.NetworkFenceClass, error) {
+	m.acquire(managedCluster, csiaddonsv1alpha1.GroupVersion.WithKind("NetworkFenceClass"), resourceName)
+
+	return mcvCacheGet(m.cache, mcvCacheKey{managedCluster, MWTypeNFClass, resourceName},
+		func() (*csiaddonsv1alpha1.NetworkFenceClass, error) {
+			return m.ManagedClusterViewGetter.GetNFClassFromManagedCluster(resourceName, managedCluster, annotations)
+		})
+}
+
+func (m CachingManagedClusterViewGetter) ListNFClassMCVs(cluster string) (*viewv1beta1.ManagedClusterViewList, error) {
+	return mcvCacheGet(m.cache, mcvCacheKey{cluster, MWTypeNFClass, ""},
+		func() (*viewv1beta1.ManagedClusterViewList, error) {
+			return m.ManagedClusterViewGetter.ListNFClassMCVs(cluster)
+		})
+}
+
+func (m CachingManagedClusterViewGetter) ListNFClassesFromManagedCluster(managedCluster string,
+	annotations map[string]string,
+) (*csiaddonsv1alpha1.NetworkFenceClassList, error) {
+	m.acquire(managedCluster, csiaddonsv1alpha1.GroupVersion.WithKind("NetworkFenceClass"), "")
+
+	return mcvCacheGet(m.cache, mcvCacheKey{managedCluster, MWTypeNFClass, mcvCacheAllNames},
+		func() (*csiaddonsv1alpha1.NetworkFenceClassList, error) {
+			return m.ManagedClusterViewGetter.ListNFClassesFromManagedCluster(managedCluster, annotations)
+		})
+}
+
+func (m CachingManagedClusterViewGetter) DeleteManagedClusterView(clusterName, mcvName string,
+	logger logr.Logger,
+) error {
+	m.cache.invalidate(clusterName)
+
+	return m.ManagedClusterViewGetter.DeleteManagedClusterView(clusterName, mcvName, logger)
+}
+
+// mcvCacheAllNames is the name of the cached list of all the resources of a resource type
+const mcvCacheAllNames = "*"
+
+// mcvCacheKey identifies a cached read; a list of the ManagedClusterViews of a resource type has an empty name
+type mcvCacheKey struct {
+	cluster      string
+	resourceType string
+	name         string
+}
+
+type mcvCacheEntry struct {
+	object  any
+	expires time.Time
+}
+
+type mcvCache struct {
+	mutex   sync.Mutex
+	ttls    map[string]time.Duration
+	entries map[mcvCacheKey]mcvCacheEntry
+}
+
+// mcvCacheGet returns a copy of the cached object for key if it has not expired, otherwise a copy of the object read
+// by get, caching it if the read succeeds
+func mcvCacheGet[T interface{ DeepCopy() T }](cache *mcvCache, key mcvCacheKey, get func() (T, error)) (T, error) {
+	if object, ok := cache.get(key); ok {
+		if typed, ok := object.(T); ok {
+			return typed.DeepCopy(), nil
+		}
+	}
+
+	object, err := get()
+	if err != nil {
+		return object, err
+	}
+
+	cache.set(key, object.DeepCopy())
+
+	return object, nil
+}
+
+func (c *mcvCache) get(key mcvCacheKey) (any, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !time.Now().Before(entry.expires) {
+		delete(c.entries, key)
+
+		return nil, false
+	}
+
+	return entry.object, true
+}
+
+func (c *mcvCache) set(key mcvCacheKey, object any) {
+	ttl, ok := c.ttls[key.resourceType]
+	if !ok || ttl <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[key] = mcvCacheEntry{object: object, expires: time.Now().Add(ttl)}
+}
+
+func (c *mcvCache) delete(key mcvCacheKey) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, key)
+}
+
+func (c *mcvCache) invalidate(cluster string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key := range c.entries {
+		if key.cluster == cluster {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	storagev1 "k8s.io/api/storage/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+type countingMCVGetter struct {
+	util.ManagedClusterViewGetter
+	reads int
+	err   error
+}
+
+func (m *countingMCVGetter) GetDRClusterConfigFromManagedCluster(clusterName string, annotations map[string]string,
+) (*rmn.DRClusterConfig, error) {
+	m.reads++
+
+	return &rmn.DRClusterConfig{Spec: rmn.DRClusterConfigSpec{ClusterID: clusterName}}, m.err
+}
+
+func (m *countingMCVGetter) GetSClassFromManagedCluster(resourceName, managedCluster string,
+	annotations map[string]string,
+) (*storagev1.StorageClass, error) {
+	m.reads++
+
+	return &storagev1.StorageClass{Provisioner: resourceName}, m.err
+}
+
+var _ = Describe("CachingManagedClusterViewGetter", func() {
+	var getter *countingMCVGetter
+
+	BeforeEach(func() {
+		getter = &countingMCVGetter{}
+	})
+
+	It("serves repeated reads from the cache until invalidated", func() {
+		cached := util.NewCachingManagedClusterViewGetter(getter, util.DefaultManagedClusterViewCacheTTLs)
+
+		for range 3 {
+			drcConfig, err := cached.GetDRClusterConfigFromManagedCluster("cluster1", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(drcConfig.Spec.ClusterID).To(Equal("cluster1"))
+
+			drcConfig.Spec.ClusterID = "modified"
+		}
+
+		Expect(getter.reads).To(Equal(1))
+
+		cached.InvalidateManagedCluster("cluster2")
+		_, _ = cached.GetDRClusterConfigFromManagedCluster("cluster1", nil)
+		Expect(getter.reads).To(Equal(1))
+
+		cached.InvalidateManagedCluster("cluster1")
+		_, _ = cached.GetDRClusterConfigFromManagedCluster("cluster1", nil)
+		Expect(getter.reads).To(Equal(2))
+	})
+
+	It("reads again once the TTL of the resource type expires", func() {
+		cached := util.NewCachingManagedClusterViewGetter(getter,
+			map[string]time.Duration{util.MWTypeSClass: time.Millisecond})
+
+		_, _ = cached.GetSClassFromManagedCluster("sc", "cluster1", nil)
+		time.Sleep(2 * time.Millisecond)
+		_, _ = cached.GetSClassFromManagedCluster("sc", "cluster1", nil)
+		Expect(getter.reads).To(Equal(2))
+	})
+
+	It("does not cache failed reads or resource types without a TTL", func() {
+		cached := util.NewCachingManagedClusterViewGetter(getter, map[string]time.Duration{})
+
+		_, _ = cached.GetSClassFromManagedCluster("sc", "cluster1", nil)
+		_, _ = cached.GetSClassFromManagedCluster("sc", "cluster1", nil)
+		Expect(getter.reads).To(Equal(2))
+
+		getter.err = fmt.Errorf("failed")
+		cached = util.NewCachingManagedClusterViewGetter(getter, util.DefaultManagedClusterViewCacheTTLs)
+
+		_, err := cached.GetDRClusterConfigFromManagedCluster("cluster1", nil)
+		Expect(err).To(HaveOccurred())
+		_, _ = cached.GetDRClusterConfigFromManagedCluster("cluster1", nil)
+		Expect(getter.reads).To(Equal(4))
+	})
+})
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+)
+
+// MCVErrorReason classifies why a resource could not be read from a ManagedClusterView
+type MCVErrorReason string
+
+const (
+	// MCVErrorReasonNotFound is returned when the viewed resource does not exist on the managed cluster
+	MCVErrorReasonNotFound MCVErrorReason = "NotFound"
+
+	// MCVErrorReasonProcessing is returned when the view has not reported a result yet
+	MCVErrorReasonProcessing MCVErrorReason = "Processing"
+
+	// MCVErrorReasonStale is returned when the view stopped refreshing its result from the managed cluster
+	MCVErrorReasonStale MCVErrorReason = "Stale"
+
+	// MCVErrorReasonForbidden is returned when the agent on the managed cluster is not permitted to read the resource
+	MCVErrorReasonForbidden MCVErrorReason = "Forbidden"
+
+	// MCVErrorReasonClusterUnreachable is returned when the view has no current result and the managed cluster is
+	// not available, so the view cannot be refreshed until the cluster is available again
+	MCVErrorReasonClusterUnreachable MCVErrorReason = "ClusterUnreachable"
+)
+
+// MCVError is a typed error returned by ManagedClusterView getters. Age is how long the view has been in its
+// current state, e.g. how long since a stale view last refreshed its result. The wrapped error is preserved, so
+// k8serrors.IsNotFound continues to work for MCVErrorReasonNotFound.
+type MCVError struct {
+	Reason MCVErrorReason
+	Age    time.Duration
+	Err    error
+}
+
+func (e *MCVError) Error() string {
+	if e.Age == 0 {
+		return fmt.Sprintf("ManagedClusterView %s: %v", e.Reason, e.Err)
+	}
+
+	return fmt.Sprintf("ManagedClusterView %s for %v: %v", e.Reason, e.Age.Round(time.Second), e.Err)
+}
+
+func (e *MCVError) Unwrap() error {
+	return e.Err
+}
+
+func newMCVError(reason MCVErrorReason, since time.Time, err error) *MCVError {
+	mcvErr := &MCVError{Reason: reason, Err: err}
+
+	if !since.IsZero() {
+		mcvErr.Age = time.Since(since)
+	}
+
+	return mcvErr
+}
+
+// MCVErrorFrom returns the MCVError in err's chain, or nil if there is none
+func MCVErrorFrom(err error) *MCVError {
+	var mcvErr *MCVError
+
+	if errors.As(err, &mcvErr) {
+		return mcvErr
+	}
+
+	return nil
+}
+
+func isMCVErrorReason(err error, reason MCVErrorReason) bool {
+	mcvErr := MCVErrorFrom(err)
+
+	return mcvErr != nil && mcvErr.Reason == reason
+}
+
+// IsMCVNotFound returns true if the viewed resource does not exist on the managed cluster
+func IsMCVNotFound(err error) bool {
+	return isMCVErrorReason(err, MCVErrorReasonNotFound)
+}
+
+// IsMCVProcessing returns true if the view has not reported a result yet
+func IsMCVProcessing(err error) bool {
+	return isMCVErrorReason(err, MCVErrorReasonProcessing)
+}
+
+// IsMCVForbidden returns true if the agent on the managed cluster is not permitted to read the viewed resource
+func IsMCVForbidden(err error) bool {
+	return isMCVErrorReason(err, MCVErrorReasonForbidden)
+}
+
+// IsMCVClusterUnreachable returns true if the view has no current result as the managed cluster is not available
+func IsMCVClusterUnreachable(err error) bool {
+	return isMCVErrorReason(err, MCVErrorReasonClusterUnreachable)
+}
+
+// IsMCVStale returns true, and how long the view has been stale, if the view stopped refreshing its result
+func IsMCVStale(err error) (time.Duration, bool) {
+	mcvErr := MCVErrorFrom(err)
+	if mcvErr == nil || mcvErr.Reason != MCVErrorReasonStale {
+		return 0, false
+	}
+
+	return mcvErr.Age, true
+}
+
+// ManagedClusterViewResourceNotFound returns true, and how long the view has reported it, if the resource viewed by
+// mcv does not exist on the managed cluster
+func ManagedClusterViewResourceNotFound(mcv *viewv1beta1.ManagedClusterView) (time.Duration, bool) {
+	if len(mcv.Status.Conditions) != 1 {
+		return 0, false
+	}
+
+	mcvErr := MCVErrorFrom(mcvConditionError(mcv))
+	if mcvErr == nil || mcvErr.Reason != MCVErrorReasonNotFound {
+		return 0, false
+	}
+
+	return mcvErr.Age, true
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"sync"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// ClusterViewIntervals tracks the refresh intervals of the ManagedClusterViews of each cluster, as set in the
+// ViewRefresh of its DRCluster, and the holders, such as a DRPC that is failing over, that need the views of the
+// cluster to be refreshed at the active interval. It is shared by the controllers, which update it as their actions
+// start and complete, and the getters, which apply the interval in effect to the views they create or update.
+// A nil *ClusterViewIntervals tracks nothing.
+type ClusterViewIntervals struct {
+	mutex     sync.Mutex
+	intervals map[string]rmn.ViewRefreshSpec
+	active    map[string]map[string]struct{}
+	holders   map[string][]string
+}
+
+func NewClusterViewIntervals() *ClusterViewIntervals {
+	return &ClusterViewIntervals{
+		intervals: map[string]rmn.ViewRefreshSpec{},
+		active:    map[string]map[string]struct{}{},
+		holders:   map[string][]string{},
+	}
+}
+
+// SetIntervals sets the view refresh intervals of the cluster, or clears them if spec is nil
+func (c *ClusterViewIntervals) SetIntervals(cluster string, spec *rmn.ViewRefreshSpec) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if spec == nil {
+		delete(c.intervals, cluster)
+
+		return
+	}
+
+	c.intervals[cluster] = *spec
+}
+
+// SetActive records that holder needs the views of the clusters to be refreshed at their active interval, replacing
+// the clusters it recorded before. Passing no cluster releases the clusters of holder.
+func (c *ClusterViewIntervals) SetActive(holder string, clusters ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, cluster := range c.holders[holder] {
+		delete(c.active[cluster], holder)
+
+		if len(c.active[cluster]) == 0 {
+			delete(c.active, cluster)
+		}
+	}
+
+	if len(clusters) == 0 {
+		delete(c.holders, holder)
+
+		return
+	}
+
+	c.holders[holder] = append([]string(nil), clusters...)
+
+	for _, cluster := range clusters {
+		if c.active[cluster] == nil {
+			c.active[cluster] = map[string]struct{}{}
+		}
+
+		c.active[cluster][holder] = struct{}{}
+	}
+}
+
+// Interval returns the refresh interval in effect for the views of the cluster, and whether its DRCluster sets one
+func (c *ClusterViewIntervals) Interval(cluster string) (int32, bool) {
+	if c == nil {
+		return 0, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	spec, ok := c.intervals[cluster]
+	if !ok {
+		return 0, false
+	}
+
+	interval := spec.SteadyIntervalSeconds
+	if len(c.active[cluster]) != 0 && spec.ActiveIntervalSeconds != 0 {
+		interval = spec.ActiveIntervalSeconds
+	}
+
+	return interval, interval != 0
+}
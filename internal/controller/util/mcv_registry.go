@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"slices"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+)
+
+// ManagedClusterViewKey identifies the object on a managed cluster that a ManagedClusterView reads; the view of a
+// list of all the objects of a kind has an empty name
+type ManagedClusterViewKey struct {
+	Cluster string
+	GVK     schema.GroupVersionKind
+	Name    types.NamespacedName
+}
+
+// ManagedClusterViewKeyFromScope returns the key of the view of the object in scope on cluster
+func ManagedClusterViewKeyFromScope(cluster string, scope viewv1beta1.ViewScope) ManagedClusterViewKey {
+	return ManagedClusterViewKey{
+		Cluster: cluster,
+		GVK:     schema.GroupVersionKind{Group: scope.Group, Version: scope.Version, Kind: scope.Kind},
+		Name:    types.NamespacedName{Namespace: scope.Namespace, Name: scope.Name},
+	}
+}
+
+// ManagedClusterViewAcquirer is implemented by ManagedClusterViewGetters that record their consumer in a
+// ManagedClusterViewRegistry, for reads served without the getter, such as from a cache shared with other consumers
+type ManagedClusterViewAcquirer interface {
+	AcquireManagedClusterView(key ManagedClusterViewKey)
+}
+
+// ManagedClusterViewRegistry counts the consumers, such as the DRPolicy, DRCluster and DRPC controllers, of each
+// ManagedClusterView, so that a view read by several of them is deleted only once the last one releases it. A
+// consumer acquires a view as it reads through it. The registry is not persisted, so after the operator restarts a
+// view is known to a consumer only once it reads through it again. A nil *ManagedClusterViewRegistry tracks nothing,
+// and every release deletes the view.
+type ManagedClusterViewRegistry struct {
+	mutex     sync.Mutex
+	consumers map[ManagedClusterViewKey]map[string]struct{}
+}
+
+func NewManagedClusterViewRegistry() *ManagedClusterViewRegistry {
+	return &ManagedClusterViewRegistry{consumers: map[ManagedClusterViewKey]map[string]struct{}{}}
+}
+
+// Acquire records consumer as a consumer of the view of key
+func (r *ManagedClusterViewRegistry) Acquire(key ManagedClusterViewKey, consumer string) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.consumers[key] == nil {
+		r.consumers[key] = map[string]struct{}{}
+	}
+
+	r.consumers[key][consumer] = struct{}{}
+}
+
+// Release drops consumer from the consumers of the view of key, and returns the consumers that still use the view,
+// which is to be deleted only if there are none
+func (r *ManagedClusterViewRegistry) Release(key ManagedClusterViewKey, consumer string) []string {
+	if r == nil {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.consumers[key], consumer)
+
+	if len(r.consumers[key]) == 0 {
+		delete(r.consumers, key)
+
+		return nil
+	}
+
+	consumers := make([]string, 0, len(r.consumers[key]))
+	for other := range r.consumers[key] {
+		consumers = append(consumers, other)
+	}
+
+	slices.Sort(consumers)
+
+	return consumers
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+)
+
+// ManagedClusterViewRequiredAnnotationsAnnotation records, as a json map, the annotations ramen sets on a
+// ManagedClusterView to route its events to its owner, so that they can be restored if edited or removed
+const ManagedClusterViewRequiredAnnotationsAnnotation = "ramendr.openshift.io/mcv-required-annotations"
+
+// managedClusterViewRequiredAnnotations returns the annotations recorded as required in annotations, or nil if none
+// are, or the record cannot be decoded
+func managedClusterViewRequiredAnnotations(annotations map[string]string) map[string]string {
+	record, ok := annotations[ManagedClusterViewRequiredAnnotationsAnnotation]
+	if !ok {
+		return nil
+	}
+
+	required := map[string]string{}
+	if err := json.Unmarshal([]byte(record), &required); err != nil {
+		return nil
+	}
+
+	return required
+}
+
+// withManagedClusterViewRequiredAnnotations returns annotations, with required added to the annotations recorded as
+// required in them
+func withManagedClusterViewRequiredAnnotations(annotations, required map[string]string) map[string]string {
+	if len(required) == 0 {
+		return annotations
+	}
+
+	recorded := managedClusterViewRequiredAnnotations(annotations)
+	if recorded == nil {
+		recorded = map[string]string{}
+	}
+
+	maps.Copy(recorded, required)
+
+	record, err := json.Marshal(recorded)
+	if err != nil {
+		return annotations
+	}
+
+	merged := maps.Clone(annotations)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+
+	merged[ManagedClusterViewRequiredAnnotationsAnnotation] = string(record)
+
+	return merged
+}
+
+// IsRamenManagedClusterView returns whether mcv was created by ramen, from its label or, if the label was removed,
+// from the record of its required annotations
+func IsRamenManagedClusterView(mcv *viewv1beta1.ManagedClusterView) bool {
+	if mcv.GetLabels()[CreatedByRamenLabel] == "true" {
+		return true
+	}
+
+	_, ok := mcv.GetAnnotations()[ManagedClusterViewRequiredAnnotationsAnnotation]
+
+	return ok
+}
+
+// RepairManagedClusterView restores the CreatedByRamenLabel and the required annotations of a ManagedClusterView
+// created by ramen that were edited or removed, and returns the sorted keys of the label and annotations restored
+func RepairManagedClusterView(mcv *viewv1beta1.ManagedClusterView) []string {
+	repaired := []string{}
+
+	if AddLabel(mcv, CreatedByRamenLabel, "true") {
+		repaired = append(repaired, CreatedByRamenLabel)
+	}
+
+	for key, value := range managedClusterViewRequiredAnnotations(mcv.GetAnnotations()) {
+		if AddAnnotation(mcv, key, value) {
+			repaired = append(repaired, key)
+		}
+	}
+
+	slices.Sort(repaired)
+
+	return repaired
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ManagedClusterViewSubscriptions records the interest of the objects reconciled by controllers, the subscribers, in
+// the results of ManagedClusterViews, so that a controller is enqueued to reconcile an object as soon as the result of
+// a view it reads changes, instead of requeueing to poll the view. Unlike the annotations of a view, which name the
+// object of the consumer that created it only, subscriptions are kept for each consumer of a view shared by several.
+// They are not persisted, so after the operator restarts a subscriber is notified only once it subscribes again. A nil
+// *ManagedClusterViewSubscriptions notifies no subscriber.
+type ManagedClusterViewSubscriptions struct {
+	mutex       sync.Mutex
+	subscribers map[ManagedClusterViewKey]map[string]map[types.NamespacedName]struct{}
+}
+
+func NewManagedClusterViewSubscriptions() *ManagedClusterViewSubscriptions {
+	return &ManagedClusterViewSubscriptions{
+		subscribers: map[ManagedClusterViewKey]map[string]map[types.NamespacedName]struct{}{},
+	}
+}
+
+// Subscribe records that subscriber is to reconcile request when the result of the view of key changes
+func (s *ManagedClusterViewSubscriptions) Subscribe(key ManagedClusterViewKey, subscriber string,
+	request types.NamespacedName,
+) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.subscribers[key] == nil {
+		s.subscribers[key] = map[string]map[types.NamespacedName]struct{}{}
+	}
+
+	if s.subscribers[key][subscriber] == nil {
+		s.subscribers[key][subscriber] = map[types.NamespacedName]struct{}{}
+	}
+
+	s.subscribers[key][subscriber][request] = struct{}{}
+}
+
+// Unsubscribe drops the subscriptions of subscriber to reconcile request, such as once the object is deleted
+func (s *ManagedClusterViewSubscriptions) Unsubscribe(subscriber string, request types.NamespacedName) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for key, subscribers := range s.subscribers {
+		delete(subscribers[subscriber], request)
+
+		if len(subscribers[subscriber]) == 0 {
+			delete(subscribers, subscriber)
+		}
+
+		if len(subscribers) == 0 {
+			delete(s.subscribers, key)
+		}
+	}
+}
+
+// Requests returns the requests that subscriber is to reconcile when the result of mcv changes
+func (s *ManagedClusterViewSubscriptions) Requests(mcv *viewv1beta1.ManagedClusterView, subscriber string,
+) []reconcile.Request {
+	if s == nil {
+		return []reconcile.Request{}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	subscriptions := s.subscribers[ManagedClusterViewKeyFromScope(mcv.Namespace, mcv.Spec.Scope)][subscriber]
+	requests := make([]reconcile.Request, 0, len(subscriptions))
+
+	for request := range subscriptions {
+		requests = append(requests, reconcile.Request{NamespacedName: request})
+	}
+
+	return requests
+}
+
+// EventHandler returns the handler of a watch of ManagedClusterViews by the controller of subscriber, to be used with
+// a ManagedClusterViewResultVersionUpdatePredicate, that enqueues the requests subscribed to the view
+func (s *ManagedClusterViewSubscriptions) EventHandler(subscriber string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+		mcv, ok := obj.(*viewv1beta1.ManagedClusterView)
+		if !ok {
+			return []reconcile.Request{}
+		}
+
+		return s.Requests(mcv, subscriber)
+	})
+}
+
+// ManagedClusterViewResultVersion returns the resourceVersion of the object in the result of mcv, or the result
+// itself if it has none, such as a list, so that it differs whenever the result does
+func ManagedClusterViewResultVersion(mcv *viewv1beta1.ManagedClusterView) string {
+	result := struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}{}
+
+	if err := json.Unmarshal(mcv.Status.Result.Raw, &result); err != nil || result.Metadata.ResourceVersion == "" {
+		return string(mcv.Status.Result.Raw)
+	}
+
+	return result.Metadata.ResourceVersion
+}
+
+// ManagedClusterViewResultVersionUpdatePredicate passes the updates of a ManagedClusterView that change the version
+// of its result only
+type ManagedClusterViewResultVersionUpdatePredicate struct{}
+
+func (ManagedClusterViewResultVersionUpdatePredicate) Create(event.CreateEvent) bool   { return false }
+func (ManagedClusterViewResultVersionUpdatePredicate) Delete(event.DeleteEvent) bool   { return false }
+func (ManagedClusterViewResultVersionUpdatePredicate) Generic(event.GenericEvent) bool { return false }
+func (ManagedClusterViewResultVersionUpdatePredicate) Update(e event.UpdateEvent) bool {
+	oldMCV, ok := e.ObjectOld.(*viewv1beta1.ManagedClusterView)
+	if !ok {
+		return false
+	}
+
+	newMCV, ok := e.ObjectNew.(*viewv1beta1.ManagedClusterView)
+	if !ok {
+		return false
+	}
+
+	return ManagedClusterViewResultVersion(oldMCV) != ManagedClusterViewResultVersion(newMCV)
+}
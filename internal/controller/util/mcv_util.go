@@ -6,9 +6,12 @@ package util
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
 	volrep "github.com/csi-addons/kubernetes-csi-addons/api/replication.storage/v1alpha1"
@@ -31,6 +34,9 @@ import (
 
 const (
 	NetworkFencePrefix = "network-fence"
+
+	// ListMCVSuffix is the resource of the name of a list-type ManagedClusterView, such as "nfc-list-mcv"
+	ListMCVSuffix = "list"
 )
 
 //nolint:interfacebloat
@@ -61,12 +67,20 @@ type ManagedClusterViewGetter interface {
 
 	ListSClassMCVs(managedCluster string) (*viewv1beta1.ManagedClusterViewList, error)
 
+	ListSClassesFromManagedCluster(
+		managedCluster string,
+		annotations map[string]string) (*storagev1.StorageClassList, error)
+
 	GetNFClassFromManagedCluster(
 		resourceName, managedCluster string,
 		annotations map[string]string) (*csiaddonsv1alpha1.NetworkFenceClass, error)
 
 	ListNFClassMCVs(managedCluster string) (*viewv1beta1.ManagedClusterViewList, error)
 
+	ListNFClassesFromManagedCluster(
+		managedCluster string,
+		annotations map[string]string) (*csiaddonsv1alpha1.NetworkFenceClassList, error)
+
 	GetVSClassFromManagedCluster(
 		resourceName, managedCluster string,
 		annotations map[string]string) (*snapv1.VolumeSnapshotClass, error)
@@ -79,6 +93,10 @@ type ManagedClusterViewGetter interface {
 
 	ListVRClassMCVs(managedCluster string) (*viewv1beta1.ManagedClusterViewList, error)
 
+	ListVRClassesFromManagedCluster(
+		managedCluster string,
+		annotations map[string]string) (*volrep.VolumeReplicationClassList, error)
+
 	GetVGSClassFromManagedCluster(
 		resourceName, managedCluster string,
 		annotations map[string]string) (*groupsnapv1beta1.VolumeGroupSnapshotClass, error)
@@ -113,6 +131,30 @@ type ManagedClusterViewGetter interface {
 type ManagedClusterViewGetterImpl struct {
 	client.Client
 	APIReader client.Reader
+
+	// Config is the ramen config of the views the getter creates
+	Config rmn.ManagedClusterViewsConfig
+
+	// Intervals holds the refresh intervals of the views of each cluster, which override the config
+	Intervals *ClusterViewIntervals
+
+	// Registry counts the consumers of each view, shared with the getters of the other consumers
+	Registry *ManagedClusterViewRegistry
+
+	// Consumer names the consumer of the views read through the getter in the Registry
+	Consumer string
+
+	// ReadObserver, if set, is called with the result of each read of an object on a managed cluster through a view
+	ReadObserver func(managedCluster string, err error)
+
+	// viewOnly creates or updates the views without reading their results, for objects read from another source
+	// whose views are kept for their events
+	viewOnly bool
+}
+
+// AcquireManagedClusterView records the consumer of the getter as a consumer of the view of key
+func (m ManagedClusterViewGetterImpl) AcquireManagedClusterView(key ManagedClusterViewKey) {
+	m.Registry.Acquire(key, m.Consumer)
 }
 
 // getResourceFromManagedCluster gets the resource named resourceName in the resourceNamespace (empty if cluster scoped)
@@ -146,9 +188,48 @@ func (m ManagedClusterViewGetterImpl) getResourceFromManagedCluster(
 		mcvViewscope.Namespace = resourceNamespace
 	}
 
+	if err := m.configureViewScope(&mcvViewscope, managedCluster); err != nil {
+		return err
+	}
+
+	m.AcquireManagedClusterView(ManagedClusterViewKeyFromScope(managedCluster, mcvViewscope))
+
 	return m.getManagedClusterResource(mcvMeta, mcvViewscope, resource, logger)
 }
 
+// configureViewScope applies the ramen config of the views of the kind of viewscope to it, and the refresh interval
+// of the views of managedCluster over that of the config. It fails for an object in a namespace that the views of
+// its kind are restricted from.
+func (m ManagedClusterViewGetterImpl) configureViewScope(viewscope *viewv1beta1.ViewScope, managedCluster string,
+) error {
+	viewscope.UpdateIntervalSeconds = m.Config.UpdateIntervalSeconds
+
+	for i := range m.Config.Kinds {
+		kindConfig := &m.Config.Kinds[i]
+		if kindConfig.Kind != viewscope.Kind {
+			continue
+		}
+
+		if kindConfig.UpdateIntervalSeconds != 0 {
+			viewscope.UpdateIntervalSeconds = kindConfig.UpdateIntervalSeconds
+		}
+
+		viewscope.Resource = kindConfig.Resource
+
+		if viewscope.Namespace != "" && len(kindConfig.Namespaces) != 0 &&
+			!slices.Contains(kindConfig.Namespaces, viewscope.Namespace) {
+			return fmt.Errorf("ManagedClusterViews of %s in namespace %s are not permitted by the ramen config",
+				viewscope.Kind, viewscope.Namespace)
+		}
+	}
+
+	if interval, ok := m.Intervals.Interval(managedCluster); ok {
+		viewscope.UpdateIntervalSeconds = interval
+	}
+
+	return nil
+}
+
 func (m ManagedClusterViewGetterImpl) GetVRGFromManagedCluster(resourceName, resourceNamespace, managedCluster string,
 	annotations map[string]string,
 ) (*rmn.VolumeReplicationGroup, error) {
@@ -226,12 +307,27 @@ func (m ManagedClusterViewGetterImpl) listMCVsWithLabel(cluster string, matchLab
 		client.MatchingLabels(matchLabels),
 	}
 
+	return ListManagedClusterViewsPaginated(context.TODO(), m.APIReader, listOptions...)
+}
+
+// ListManagedClusterViewsPaginated lists ManagedClusterViews from the API server in pages of listPageSize
+func ListManagedClusterViewsPaginated(ctx context.Context, reader client.Reader, opts ...client.ListOption,
+) (*viewv1beta1.ManagedClusterViewList, error) {
 	mcvs := &viewv1beta1.ManagedClusterViewList{}
-	if err := m.APIReader.List(context.TODO(), mcvs, listOptions...); err != nil {
-		return nil, err
-	}
+	page := &viewv1beta1.ManagedClusterViewList{}
+
+	for {
+		pageOpts := append([]client.ListOption{client.Limit(listPageSize), client.Continue(page.Continue)}, opts...)
+		if err := reader.List(ctx, page, pageOpts...); err != nil {
+			return nil, err
+		}
+
+		mcvs.Items = append(mcvs.Items, page.Items...)
 
-	return mcvs, nil
+		if page.Continue == "" {
+			return mcvs, nil
+		}
+	}
 }
 
 func (m ManagedClusterViewGetterImpl) ListMModesMCVs(cluster string) (*viewv1beta1.ManagedClusterViewList, error) {
@@ -448,6 +544,88 @@ func (m ManagedClusterViewGetterImpl) ListVRClassMCVs(cluster string) (*viewv1be
 	return m.listMCVsWithLabel(cluster, map[string]string{VRClassLabel: ""})
 }
 
+// ListSClassesFromManagedCluster lists all StorageClasses on the managedCluster using a single list-type view
+func (m ManagedClusterViewGetterImpl) ListSClassesFromManagedCluster(managedCluster string,
+	annotations map[string]string,
+) (*storagev1.StorageClassList, error) {
+	scs := &storagev1.StorageClassList{}
+
+	err := m.listResourcesFromManagedCluster(
+		managedCluster,
+		annotations,
+		MWTypeSClass,
+		"StorageClass",
+		storagev1.SchemeGroupVersion.Group,
+		storagev1.SchemeGroupVersion.Version,
+		scs,
+	)
+
+	return scs, err
+}
+
+// ListNFClassesFromManagedCluster lists all NetworkFenceClasses on the managedCluster using a single list-type view
+func (m ManagedClusterViewGetterImpl) ListNFClassesFromManagedCluster(managedCluster string,
+	annotations map[string]string,
+) (*csiaddonsv1alpha1.NetworkFenceClassList, error) {
+	nfcs := &csiaddonsv1alpha1.NetworkFenceClassList{}
+
+	err := m.listResourcesFromManagedCluster(
+		managedCluster,
+		annotations,
+		MWTypeNFClass,
+		"NetworkFenceClass",
+		csiaddonsv1alpha1.GroupVersion.Group,
+		csiaddonsv1alpha1.GroupVersion.Version,
+		nfcs,
+	)
+
+	return nfcs, err
+}
+
+// ListVRClassesFromManagedCluster lists all VolumeReplicationClasses on the managedCluster using a single list-type
+// view
+func (m ManagedClusterViewGetterImpl) ListVRClassesFromManagedCluster(managedCluster string,
+	annotations map[string]string,
+) (*volrep.VolumeReplicationClassList, error) {
+	vrcs := &volrep.VolumeReplicationClassList{}
+
+	err := m.listResourcesFromManagedCluster(
+		managedCluster,
+		annotations,
+		MWTypeVRClass,
+		"VolumeReplicationClass",
+		volrep.GroupVersion.Group,
+		volrep.GroupVersion.Version,
+		vrcs,
+	)
+
+	return vrcs, err
+}
+
+// listResourcesFromManagedCluster lists all cluster scoped resources with the passed in group, version, and kind on
+// the managedCluster into the passed in "list", using a list-type ManagedClusterView whose scope names no resource.
+// The view is named for the resource type, and carries no class label so that pruning the views of individual
+// classes leaves it in place.
+func (m ManagedClusterViewGetterImpl) listResourcesFromManagedCluster(
+	managedCluster string,
+	annotations map[string]string,
+	resourceType, kind, group, version string,
+	list interface{},
+) error {
+	return m.getResourceFromManagedCluster(
+		"",
+		"",
+		managedCluster,
+		annotations,
+		nil,
+		BuildManagedClusterViewName(resourceType, "", ListMCVSuffix),
+		kind,
+		group,
+		version,
+		list,
+	)
+}
+
 // outputs a string for use in creating a ManagedClusterView name
 // example: when looking for a vrg with name 'demo' in the namespace 'ramen', input: ("demo", "ramen", "vrg")
 // this will give output "demo-ramen-vrg-mcv"
@@ -485,10 +663,35 @@ func (m ManagedClusterViewGetterImpl) getManagedClusterResource(
 		return fmt.Errorf("getManagedClusterResource failed: %w", err)
 	}
 
+	if m.viewOnly {
+		return nil
+	}
+
 	logger.Info(fmt.Sprintf("Get managedClusterResource Returned the following MCV Conditions: %v",
 		mcv.Status.Conditions))
 
-	return m.GetResource(mcv, resource)
+	err = m.clusterUnreachableError(meta.Namespace, m.GetResource(mcv, resource))
+
+	if m.ReadObserver != nil {
+		m.ReadObserver(meta.Namespace, err)
+	}
+
+	return err
+}
+
+// clusterUnreachableError returns err as an MCVErrorReasonClusterUnreachable error if the view has no current result,
+// as it is processing or stale, and the managedCluster is not available; otherwise it returns err
+func (m ManagedClusterViewGetterImpl) clusterUnreachableError(managedCluster string, err error) error {
+	if _, stale := IsMCVStale(err); !stale && !IsMCVProcessing(err) {
+		return err
+	}
+
+	if ManagedClusterAvailable(context.TODO(), m.APIReader, managedCluster) {
+		return err
+	}
+
+	return newMCVError(MCVErrorReasonClusterUnreachable, time.Time{},
+		fmt.Errorf("cluster %s is not available: %w", managedCluster, err))
 }
 
 // This function is temporarily used to parse the MCV.Status.Conditions[0].Messagefield for known error strings,
@@ -517,6 +720,11 @@ func parseErrorMessage(message string) error {
 		return k8serrors.NewNotFound(schema.GroupResource{}, "requested resource not found in ManagedCluster")
 	}
 
+	if strings.Contains(message, "forbidden") {
+		return k8serrors.NewForbidden(schema.GroupResource{}, "requested resource in ManagedCluster",
+			errors.New(extractLastError(message)))
+	}
+
 	return fmt.Errorf("err: %s", extractLastError(message))
 }
 
@@ -526,16 +734,10 @@ func (m ManagedClusterViewGetterImpl) GetResource(mcv *viewv1beta1.ManagedCluste
 	// want single recent Condition with correct Type; otherwise: bad path
 	switch len(mcv.Status.Conditions) {
 	case 0:
-		err = fmt.Errorf("missing ManagedClusterView conditions")
+		err = newMCVError(MCVErrorReasonProcessing, mcv.CreationTimestamp.Time,
+			fmt.Errorf("missing ManagedClusterView conditions"))
 	case 1:
-		switch {
-		case mcv.Status.Conditions[0].Type != viewv1beta1.ConditionViewProcessing:
-			err = fmt.Errorf("found invalid condition (%s) in ManagedClusterView", mcv.Status.Conditions[0].Type)
-		case mcv.Status.Conditions[0].Reason == viewv1beta1.ReasonGetResourceFailed:
-			err = parseErrorMessage(mcv.Status.Conditions[0].Message)
-		case mcv.Status.Conditions[0].Status != metav1.ConditionTrue:
-			err = fmt.Errorf("ManagedClusterView is not ready (reason: %s)", mcv.Status.Conditions[0].Reason)
-		}
+		err = mcvConditionError(mcv)
 	default:
 		err = fmt.Errorf("found multiple status conditions with ManagedClusterView")
 	}
@@ -553,6 +755,40 @@ func (m ManagedClusterViewGetterImpl) GetResource(mcv *viewv1beta1.ManagedCluste
 	return nil // success
 }
 
+// mcvConditionError returns a typed error for the single ManagedClusterView condition, or nil if the view result is
+// current. A view that has a result from an earlier refresh, but failed to refresh since, is reported as stale.
+func mcvConditionError(mcv *viewv1beta1.ManagedClusterView) error {
+	condition := mcv.Status.Conditions[0]
+	hasResult := len(mcv.Status.Result.Raw) != 0
+
+	switch {
+	case condition.Type != viewv1beta1.ConditionViewProcessing:
+		return fmt.Errorf("found invalid condition (%s) in ManagedClusterView", condition.Type)
+	case condition.Reason == viewv1beta1.ReasonGetResourceFailed:
+		err := parseErrorMessage(condition.Message)
+
+		switch {
+		case k8serrors.IsNotFound(err):
+			return newMCVError(MCVErrorReasonNotFound, condition.LastTransitionTime.Time, err)
+		case k8serrors.IsForbidden(err):
+			return newMCVError(MCVErrorReasonForbidden, condition.LastTransitionTime.Time, err)
+		case hasResult:
+			return newMCVError(MCVErrorReasonStale, condition.LastTransitionTime.Time, err)
+		}
+
+		return err
+	case condition.Status != metav1.ConditionTrue:
+		err := fmt.Errorf("ManagedClusterView is not ready (reason: %s)", condition.Reason)
+		if hasResult {
+			return newMCVError(MCVErrorReasonStale, condition.LastTransitionTime.Time, err)
+		}
+
+		return newMCVError(MCVErrorReasonProcessing, condition.LastTransitionTime.Time, err)
+	}
+
+	return nil
+}
+
 /*
 Description: create a new ManagedClusterView object, or update the existing one with the same name.
 Requires:
@@ -575,6 +811,7 @@ func (m ManagedClusterViewGetterImpl) getOrCreateManagedClusterView(
 	}
 
 	AddLabel(mcv, CreatedByRamenLabel, "true")
+	mcv.Annotations = withManagedClusterViewRequiredAnnotations(meta.Annotations, meta.Annotations)
 
 	err := m.Get(context.TODO(), key, mcv)
 	if err != nil {
@@ -605,6 +842,9 @@ func (m ManagedClusterViewGetterImpl) getOrCreateManagedClusterView(
 	maps.Copy(mergedAnnotations, mcv.Annotations)
 	maps.Copy(mergedAnnotations, meta.Annotations)
 
+	// Record the annotations routing the events of the view, for the garbage collector to restore them if edited.
+	mergedAnnotations = withManagedClusterViewRequiredAnnotations(mergedAnnotations, meta.Annotations)
+
 	// Check if annotations actually changed.
 	if !maps.Equal(mcv.Annotations, mergedAnnotations) {
 		// Expected once when uprading ramen if annotations have changed.
@@ -680,6 +920,14 @@ func (m ManagedClusterViewGetterImpl) DeleteManagedClusterView(clusterName, mcvN
 		return fmt.Errorf("failed to retrieve ManagedClusterView for type: %s. Error: %w", mcvName, err)
 	}
 
+	if consumers := m.Registry.Release(ManagedClusterViewKeyFromScope(clusterName, mcv.Spec.Scope),
+		m.Consumer); len(consumers) != 0 {
+		logger.Info("Keeping ManagedClusterView in use", "name", mcv.Name, "namespace", mcv.Namespace,
+			"consumers", consumers)
+
+		return nil
+	}
+
 	logger.Info("Deleting ManagedClusterView", "name", mcv.Name, "namespace", mcv.Namespace)
 
 	return m.Delete(context.TODO(), mcv)
@@ -0,0 +1,333 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("ManagedClusterView errors", func() {
+	mcvWith := func(conditions []metav1.Condition, result []byte) *viewv1beta1.ManagedClusterView {
+		return &viewv1beta1.ManagedClusterView{
+			Status: viewv1beta1.ViewStatus{
+				Conditions: conditions,
+				Result:     runtime.RawExtension{Raw: result},
+			},
+		}
+	}
+
+	processing := func(status metav1.ConditionStatus, reason, message string) []metav1.Condition {
+		return []metav1.Condition{{
+			Type:               viewv1beta1.ConditionViewProcessing,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		}}
+	}
+
+	result := []byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"test"}}`)
+
+	DescribeTable("GetResource",
+		func(mcv *viewv1beta1.ManagedClusterView, reason util.MCVErrorReason) {
+			err := util.ManagedClusterViewGetterImpl{}.GetResource(mcv, &corev1.Namespace{})
+			if reason == "" {
+				Expect(err).ToNot(HaveOccurred())
+
+				return
+			}
+
+			Expect(util.MCVErrorFrom(err)).ToNot(BeNil())
+			Expect(util.MCVErrorFrom(err).Reason).To(Equal(reason))
+		},
+		Entry("missing conditions is processing", mcvWith(nil, nil), util.MCVErrorReasonProcessing),
+		Entry("not ready without a result is processing",
+			mcvWith(processing(metav1.ConditionFalse, viewv1beta1.ReasonGetResource, ""), nil),
+			util.MCVErrorReasonProcessing),
+		Entry("not ready with a result is stale",
+			mcvWith(processing(metav1.ConditionFalse, viewv1beta1.ReasonGetResource, ""), result),
+			util.MCVErrorReasonStale),
+		Entry("failed with a result is stale",
+			mcvWith(processing(metav1.ConditionFalse, viewv1beta1.ReasonGetResourceFailed, "err: timeout"), result),
+			util.MCVErrorReasonStale),
+		Entry("not found",
+			mcvWith(processing(metav1.ConditionFalse, viewv1beta1.ReasonGetResourceFailed,
+				`err: namespaces "test" not found`), result),
+			util.MCVErrorReasonNotFound),
+		Entry("forbidden",
+			mcvWith(processing(metav1.ConditionFalse, viewv1beta1.ReasonGetResourceFailed,
+				`err: namespaces "test" is forbidden: User "klusterlet" cannot get resource`), nil),
+			util.MCVErrorReasonForbidden),
+		Entry("ready", mcvWith(processing(metav1.ConditionTrue, viewv1beta1.ReasonGetResource, ""), result),
+			util.MCVErrorReason("")),
+	)
+
+	It("preserves NotFound for existing callers", func() {
+		err := util.ManagedClusterViewGetterImpl{}.GetResource(
+			mcvWith(processing(metav1.ConditionFalse, viewv1beta1.ReasonGetResourceFailed,
+				`err: namespaces "test" not found`), nil), &corev1.Namespace{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		Expect(util.IsMCVNotFound(err)).To(BeTrue())
+	})
+
+	It("reports forbidden views as Forbidden errors", func() {
+		err := util.ManagedClusterViewGetterImpl{}.GetResource(
+			mcvWith(processing(metav1.ConditionFalse, viewv1beta1.ReasonGetResourceFailed,
+				`err: namespaces "test" is forbidden: User "klusterlet" cannot get resource`), nil), &corev1.Namespace{})
+		Expect(k8serrors.IsForbidden(err)).To(BeTrue())
+		Expect(util.IsMCVForbidden(err)).To(BeTrue())
+		Expect(util.IsMCVNotFound(err)).To(BeFalse())
+	})
+})
+
+var _ = Describe("ManagedClusterView config", func() {
+	config := rmn.ManagedClusterViewsConfig{
+		UpdateIntervalSeconds: 60,
+		Kinds: []rmn.ManagedClusterViewKindConfig{{
+			Kind:                  "VolumeReplicationGroup",
+			UpdateIntervalSeconds: 300,
+			Resource:              "volumereplicationgroups",
+			Namespaces:            []string{"app"},
+		}},
+	}
+
+	newGetter := func() util.ManagedClusterViewGetterImpl {
+		scheme := runtime.NewScheme()
+		Expect(viewv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		return util.ManagedClusterViewGetterImpl{Client: fakeClient, APIReader: fakeClient, Config: config}
+	}
+
+	viewScope := func(getter util.ManagedClusterViewGetterImpl, name string) viewv1beta1.ViewScope {
+		mcv := &viewv1beta1.ManagedClusterView{}
+		Expect(getter.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "cluster1"}, mcv)).To(Succeed())
+
+		return mcv.Spec.Scope
+	}
+
+	It("applies the config of the kind, and else the global config, to the scope of the views", func() {
+		getter := newGetter()
+
+		_, err := getter.GetVRGFromManagedCluster("vrg", "app", "cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+
+		scope := viewScope(getter, util.BuildManagedClusterViewName("vrg", "app", "vrg"))
+		Expect(scope.UpdateIntervalSeconds).To(Equal(int32(300)))
+		Expect(scope.Resource).To(Equal("volumereplicationgroups"))
+
+		_, err = getter.GetMModeFromManagedCluster("mmode", "cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+
+		scope = viewScope(getter, util.BuildManagedClusterViewName("mmode", "", util.MWTypeMMode))
+		Expect(scope.UpdateIntervalSeconds).To(Equal(int32(60)))
+		Expect(scope.Resource).To(BeEmpty())
+	})
+
+	It("applies the refresh intervals of the cluster over the config, as its actions start and complete", func() {
+		getter := newGetter()
+		getter.Intervals = util.NewClusterViewIntervals()
+		getter.Intervals.SetIntervals("cluster1", &rmn.ViewRefreshSpec{
+			SteadyIntervalSeconds: 600,
+			ActiveIntervalSeconds: 10,
+		})
+		mcvName := util.BuildManagedClusterViewName("vrg", "app", "vrg")
+
+		_, err := getter.GetVRGFromManagedCluster("vrg", "app", "cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+		Expect(viewScope(getter, mcvName).UpdateIntervalSeconds).To(Equal(int32(600)))
+
+		getter.Intervals.SetActive("drpc1", "cluster1", "cluster2")
+		getter.Intervals.SetActive("drpc2", "cluster1")
+
+		_, err = getter.GetVRGFromManagedCluster("vrg", "app", "cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+		Expect(viewScope(getter, mcvName).UpdateIntervalSeconds).To(Equal(int32(10)))
+
+		getter.Intervals.SetActive("drpc1")
+
+		_, err = getter.GetVRGFromManagedCluster("vrg", "app", "cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+		Expect(viewScope(getter, mcvName).UpdateIntervalSeconds).To(Equal(int32(10)))
+
+		getter.Intervals.SetActive("drpc2")
+
+		_, err = getter.GetVRGFromManagedCluster("vrg", "app", "cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+		Expect(viewScope(getter, mcvName).UpdateIntervalSeconds).To(Equal(int32(600)))
+
+		getter.Intervals.SetIntervals("cluster1", nil)
+
+		_, err = getter.GetVRGFromManagedCluster("vrg", "app", "cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+		Expect(viewScope(getter, mcvName).UpdateIntervalSeconds).To(Equal(int32(300)))
+	})
+
+	It("does not create views of objects in namespaces the kind is restricted from", func() {
+		getter := newGetter()
+
+		_, err := getter.GetVRGFromManagedCluster("vrg", "other", "cluster1", nil)
+		Expect(err).To(HaveOccurred())
+
+		mcvs := &viewv1beta1.ManagedClusterViewList{}
+		Expect(getter.List(context.TODO(), mcvs)).To(Succeed())
+		Expect(mcvs.Items).To(BeEmpty())
+	})
+})
+
+var _ = Describe("ManagedClusterView registry", func() {
+	var drp, drc util.ManagedClusterViewGetterImpl
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(viewv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		registry := util.NewManagedClusterViewRegistry()
+
+		drp = util.ManagedClusterViewGetterImpl{
+			Client: fakeClient, APIReader: fakeClient, Registry: registry, Consumer: "drp",
+		}
+		drc = util.ManagedClusterViewGetterImpl{
+			Client: fakeClient, APIReader: fakeClient, Registry: registry, Consumer: "drc",
+		}
+	})
+
+	viewExists := func(name string) bool {
+		mcv := &viewv1beta1.ManagedClusterView{}
+		err := drp.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "cluster1"}, mcv)
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+
+		return err == nil
+	}
+
+	It("deletes a view read by several consumers once the last of them releases it", func() {
+		mcvName := util.BuildManagedClusterViewName("cluster1", "", util.MWTypeDRCConfig)
+
+		_, err := drp.GetDRClusterConfigFromManagedCluster("cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+
+		_, err = drc.GetDRClusterConfigFromManagedCluster("cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+
+		Expect(drc.DeleteDRClusterConfigManagedClusterView("cluster1")).To(Succeed())
+		Expect(viewExists(mcvName)).To(BeTrue())
+
+		Expect(drp.DeleteDRClusterConfigManagedClusterView("cluster1")).To(Succeed())
+		Expect(viewExists(mcvName)).To(BeFalse())
+	})
+
+	It("counts the consumers whose reads are served by a shared cache", func() {
+		mcvName := util.BuildManagedClusterViewName("sc1", "", util.MWTypeSClass)
+		sc := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "sc1"}, Provisioner: "p"}
+		drpCached := util.NewCachingManagedClusterViewGetter(drp, util.DefaultManagedClusterViewCacheTTLs)
+		drcCached := drpCached.WithGetter(drc)
+
+		_, err := drpCached.GetSClassFromManagedCluster("sc1", "cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+
+		mcv := &viewv1beta1.ManagedClusterView{}
+		Expect(drp.Get(context.TODO(), types.NamespacedName{Name: mcvName, Namespace: "cluster1"}, mcv)).To(Succeed())
+		mcv.Status.Result.Raw, err = json.Marshal(sc)
+		Expect(err).ToNot(HaveOccurred())
+		mcv.Status.Conditions = []metav1.Condition{{
+			Type:               viewv1beta1.ConditionViewProcessing,
+			Status:             metav1.ConditionTrue,
+			Reason:             viewv1beta1.ReasonGetResource,
+			LastTransitionTime: metav1.Now(),
+		}}
+		Expect(drp.Update(context.TODO(), mcv)).To(Succeed())
+
+		_, err = drpCached.GetSClassFromManagedCluster("sc1", "cluster1", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = drcCached.GetSClassFromManagedCluster("sc1", "cluster1", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(drpCached.DeleteManagedClusterView("cluster1", mcvName, logr.Discard())).To(Succeed())
+		Expect(viewExists(mcvName)).To(BeTrue())
+
+		Expect(drcCached.DeleteManagedClusterView("cluster1", mcvName, logr.Discard())).To(Succeed())
+		Expect(viewExists(mcvName)).To(BeFalse())
+	})
+})
+
+var _ = Describe("ManagedClusterView subscriptions", func() {
+	request := types.NamespacedName{Name: "cluster1"}
+
+	mcvWithResult := func(mcv *viewv1beta1.ManagedClusterView, resourceVersion string) *viewv1beta1.ManagedClusterView {
+		mcv = mcv.DeepCopy()
+		drcConfig := &rmn.DRClusterConfig{ObjectMeta: metav1.ObjectMeta{Name: "cluster1", ResourceVersion: resourceVersion}}
+
+		var err error
+
+		mcv.Status.Result.Raw, err = json.Marshal(drcConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		return mcv
+	}
+
+	It("enqueues the subscribers of a view as the resource version of its result changes", func() {
+		scheme := runtime.NewScheme()
+		Expect(viewv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		getter := util.ManagedClusterViewGetterImpl{Client: fakeClient, APIReader: fakeClient}
+
+		_, err := getter.GetDRClusterConfigFromManagedCluster("cluster1", nil)
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+
+		mcv := &viewv1beta1.ManagedClusterView{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{
+			Name:      util.BuildManagedClusterViewName("cluster1", "", util.MWTypeDRCConfig),
+			Namespace: "cluster1",
+		}, mcv)).To(Succeed())
+
+		subscriptions := util.NewManagedClusterViewSubscriptions()
+		subscriptions.Subscribe(util.ManagedClusterViewKey{
+			Cluster: "cluster1",
+			GVK:     rmn.GroupVersion.WithKind("DRClusterConfig"),
+			Name:    types.NamespacedName{Name: "cluster1"},
+		}, "DRCluster", request)
+
+		Expect(subscriptions.Requests(mcv, "DRCluster")).To(Equal([]reconcile.Request{{NamespacedName: request}}))
+		Expect(subscriptions.Requests(mcv, "DRPlacementControl")).To(BeEmpty())
+
+		predicate := util.ManagedClusterViewResultVersionUpdatePredicate{}
+		v1, v2 := mcvWithResult(mcv, "1"), mcvWithResult(mcv, "2")
+		v1Processed := v1.DeepCopy()
+		v1Processed.Status.Conditions = []metav1.Condition{{
+			Type:   viewv1beta1.ConditionViewProcessing,
+			Status: metav1.ConditionTrue,
+			Reason: viewv1beta1.ReasonGetResource,
+		}}
+
+		Expect(predicate.Update(event.UpdateEvent{ObjectOld: mcv, ObjectNew: v1})).To(BeTrue())
+		Expect(predicate.Update(event.UpdateEvent{ObjectOld: v1, ObjectNew: v1Processed})).To(BeFalse())
+		Expect(predicate.Update(event.UpdateEvent{ObjectOld: v1Processed, ObjectNew: v2})).To(BeTrue())
+
+		subscriptions.Unsubscribe("DRCluster", request)
+		Expect(subscriptions.Requests(mcv, "DRCluster")).To(BeEmpty())
+	})
+})
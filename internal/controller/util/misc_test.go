@@ -61,3 +61,12 @@ var _ = Describe("misc", func() {
 	originalPVCName := util.GetLocalServiceNameForRD("normal-pvc-name")
 	Expect(originalPVCName).Should(Equal("volsync-rsync-tls-dst-normal-pvc-name"))
 })
+
+var _ = DescribeTable("ManifestWorkType",
+	func(mwName, mwType string) {
+		Expect(util.ManifestWorkType(mwName)).To(Equal(mwType))
+	},
+	Entry("vrg", util.ManifestWorkName("app-drpc", "app-ns", util.MWTypeVRG), util.MWTypeVRG),
+	Entry("drcconfig", "drcconfig-mw", util.MWTypeDRCConfig),
+	Entry("dr-cluster", util.DrClusterManifestWorkName, ""),
+)
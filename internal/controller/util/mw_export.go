@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	// DefaultManifestExportDirectory is the directory the manifests are exported into if the Export manifest
+	// transport does not set one
+	DefaultManifestExportDirectory = "/var/lib/ramen/manifests"
+
+	// exportedManifestWorkHeader starts the first line of an exported ManifestWork, a YAML comment, ignored by GitOps
+	// agents, with the metadata of the ManifestWork for the hub to read it back
+	exportedManifestWorkHeader = "# ramendr.openshift.io/manifestwork: "
+
+	exportedManifestWorkReason = "Exported"
+)
+
+// exportedManifestWorkMeta is the metadata of an exported ManifestWork, which is not part of its manifests
+type exportedManifestWorkMeta struct {
+	Labels       map[string]string       `json:"labels,omitempty"`
+	Annotations  map[string]string       `json:"annotations,omitempty"`
+	DeleteOption *ocmworkv1.DeleteOption `json:"deleteOption,omitempty"`
+}
+
+// SetManifestTransport selects whether ManifestWorks are created on the hub, or their manifests are exported into the
+// directory of transport, one directory per cluster and one file per ManifestWork. Exported ManifestWorks do not
+// report status feedback, so the status of their resources is read back with ManagedClusterViews.
+func (mwu *MWUtil) SetManifestTransport(transport rmn.ManifestTransport) {
+	mwu.ExportDirectory = ""
+
+	if transport.Mode != rmn.ManifestTransportModeExport {
+		return
+	}
+
+	mwu.ExportDirectory = transport.Directory
+	if mwu.ExportDirectory == "" {
+		mwu.ExportDirectory = DefaultManifestExportDirectory
+	}
+
+	mwu.StatusFeedback = false
+}
+
+func (mwu *MWUtil) exporting() bool {
+	return mwu.ExportDirectory != ""
+}
+
+func (mwu *MWUtil) exportedManifestWorkPath(name, cluster string) string {
+	return filepath.Join(mwu.ExportDirectory, cluster, name+".yaml")
+}
+
+// renderManifestWork renders the manifests of mw as a multi-document YAML, headed by the metadata of mw
+func renderManifestWork(mw *ocmworkv1.ManifestWork) ([]byte, error) {
+	header, err := json.Marshal(exportedManifestWorkMeta{
+		Labels:       mw.GetLabels(),
+		Annotations:  mw.GetAnnotations(),
+		DeleteOption: mw.Spec.DeleteOption,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ManifestWork %s metadata: %w", mw.Name, err)
+	}
+
+	data := bytes.Buffer{}
+	data.WriteString(exportedManifestWorkHeader)
+	data.Write(header)
+	data.WriteString("\n")
+
+	for idx, manifest := range mw.Spec.Workload.Manifests {
+		raw := manifest.Raw
+		if raw == nil {
+			if raw, err = json.Marshal(manifest.Object); err != nil {
+				return nil, fmt.Errorf("failed to marshal manifest %d of ManifestWork %s: %w", idx, mw.Name, err)
+			}
+		}
+
+		document, err := yaml.JSONToYAML(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render manifest %d of ManifestWork %s: %w", idx, mw.Name, err)
+		}
+
+		data.WriteString("---\n")
+		data.Write(document)
+	}
+
+	return data.Bytes(), nil
+}
+
+// parseExportedManifestWork returns the ManifestWork name for cluster rendered into data by renderManifestWork. Its
+// manifests are reported applied, as they are applied by the GitOps agent of the cluster, whose progress is not
+// reported to the hub.
+func parseExportedManifestWork(name, cluster string, data []byte) (*ocmworkv1.ManifestWork, error) {
+	header, body, _ := bytes.Cut(data, []byte("\n"))
+
+	metaJSON, ok := bytes.CutPrefix(header, []byte(exportedManifestWorkHeader))
+	if !ok {
+		return nil, fmt.Errorf("exported ManifestWork %s/%s has no header", cluster, name)
+	}
+
+	exportedMeta := exportedManifestWorkMeta{}
+	if err := json.Unmarshal(metaJSON, &exportedMeta); err != nil {
+		return nil, fmt.Errorf("failed to parse exported ManifestWork %s/%s header: %w", cluster, name, err)
+	}
+
+	mw := &ocmworkv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   cluster,
+			Labels:      exportedMeta.Labels,
+			Annotations: exportedMeta.Annotations,
+		},
+		Spec: ocmworkv1.ManifestWorkSpec{DeleteOption: exportedMeta.DeleteOption},
+	}
+
+	// documents are split on separators at the start of a line, which the YAML of a manifest only contains indented
+	for idx, document := range bytes.Split(append([]byte("\n"), body...), []byte("\n---\n")) {
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+
+		raw, err := yaml.YAMLToJSON(document)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse document %d of exported ManifestWork %s/%s: %w",
+				idx, cluster, name, err)
+		}
+
+		mw.Spec.Workload.Manifests = append(mw.Spec.Workload.Manifests,
+			ocmworkv1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}})
+	}
+
+	for _, conditionType := range []string{ocmworkv1.WorkApplied, ocmworkv1.WorkAvailable} {
+		mw.Status.Conditions = append(mw.Status.Conditions, metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  exportedManifestWorkReason,
+			Message: "Manifests exported for the GitOps agent of the cluster",
+		})
+	}
+
+	return mw, nil
+}
+
+// exportManifestWork renders the manifests of mw for cluster into its file, which is replaced atomically, for the
+// publishing sidecar not to read a partial file
+func (mwu *MWUtil) exportManifestWork(mw *ocmworkv1.ManifestWork, cluster string) (ctrlutil.OperationResult, error) {
+	data, err := renderManifestWork(mw)
+	if err != nil {
+		return ctrlutil.OperationResultNone, err
+	}
+
+	path := mwu.exportedManifestWorkPath(mw.Name, cluster)
+	result := ctrlutil.OperationResultUpdated
+
+	current, err := os.ReadFile(path)
+
+	switch {
+	case err == nil && bytes.Equal(current, data):
+		return ctrlutil.OperationResultNone, nil
+	case errors.Is(err, fs.ErrNotExist):
+		result = ctrlutil.OperationResultCreated
+	case err != nil:
+		return ctrlutil.OperationResultNone, fmt.Errorf("failed to read exported ManifestWork %s: %w", path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return ctrlutil.OperationResultNone, fmt.Errorf("failed to create export directory of %s: %w", cluster, err)
+	}
+
+	// a dot file without the yaml extension, which GitOps agents skip
+	temp := filepath.Join(filepath.Dir(path), "."+mw.Name+".tmp")
+	if err := os.WriteFile(temp, data, 0o644); err != nil { //nolint:gosec
+		return ctrlutil.OperationResultNone, fmt.Errorf("failed to export ManifestWork %s: %w", path, err)
+	}
+
+	if err := os.Rename(temp, path); err != nil {
+		return ctrlutil.OperationResultNone, fmt.Errorf("failed to export ManifestWork %s: %w", path, err)
+	}
+
+	mwu.Log.Info("Exported ManifestWork", "name", mw.Name, "namespace", cluster, "result", result)
+
+	return result, nil
+}
+
+func (mwu *MWUtil) findExportedManifestWork(name, cluster string) (*ocmworkv1.ManifestWork, error) {
+	data, err := os.ReadFile(mwu.exportedManifestWorkPath(name, cluster))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, k8serrors.NewNotFound(ocmworkv1.Resource("manifestworks"), name)
+		}
+
+		return nil, fmt.Errorf("failed to read exported ManifestWork %s/%s: %w", cluster, name, err)
+	}
+
+	return parseExportedManifestWork(name, cluster, data)
+}
+
+// listExportedManifestWorks returns the ManifestWorks exported for cluster whose labels match selector
+func (mwu *MWUtil) listExportedManifestWorks(cluster string,
+	selector labels.Selector,
+) (*ocmworkv1.ManifestWorkList, error) {
+	mws := &ocmworkv1.ManifestWorkList{}
+
+	entries, err := os.ReadDir(filepath.Join(mwu.ExportDirectory, cluster))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return mws, nil
+		}
+
+		return nil, fmt.Errorf("failed to list exported ManifestWorks of %s: %w", cluster, err)
+	}
+
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".yaml")
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		mw, err := mwu.findExportedManifestWork(name, cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		if selector.Matches(labels.Set(mw.GetLabels())) {
+			mws.Items = append(mws.Items, *mw)
+		}
+	}
+
+	return mws, nil
+}
+
+func (mwu *MWUtil) unexportManifestWork(name, cluster string) error {
+	err := os.Remove(mwu.exportedManifestWorkPath(name, cluster))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to remove exported ManifestWork %s/%s: %w", cluster, name, err)
+	}
+
+	mwu.Log.Info("Removed exported ManifestWork", "name", name, "namespace", cluster)
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("ManifestTransport Export", func() {
+	var (
+		mwu       *util.MWUtil
+		directory string
+	)
+
+	mwName := func() string {
+		return (&util.MWUtil{}).BuildManifestWorkName(util.MWTypeDRCConfig)
+	}
+
+	BeforeEach(func() {
+		directory = GinkgoT().TempDir()
+		mwu = &util.MWUtil{Ctx: context.TODO(), Log: logr.Discard(), StatusFeedback: true}
+		mwu.SetManifestTransport(rmn.ManifestTransport{Mode: rmn.ManifestTransportModeExport, Directory: directory})
+	})
+
+	It("does not export in the ManifestWork mode", func() {
+		mwu.SetManifestTransport(rmn.ManifestTransport{Directory: directory})
+		Expect(mwu.ExportDirectory).To(BeEmpty())
+	})
+
+	It("exports to the default directory, without status feedback", func() {
+		mwu.SetManifestTransport(rmn.ManifestTransport{Mode: rmn.ManifestTransportModeExport})
+		Expect(mwu.ExportDirectory).To(Equal(util.DefaultManifestExportDirectory))
+		Expect(mwu.StatusFeedback).To(BeFalse())
+	})
+
+	It("renders the manifests of a ManifestWork into a file per cluster and reads them back", func() {
+		cConfig := rmn.DRClusterConfig{
+			TypeMeta:   metav1.TypeMeta{Kind: "DRClusterConfig", APIVersion: rmn.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "east"},
+			Spec:       rmn.DRClusterConfigSpec{ClusterID: "east-id"},
+		}
+		Expect(mwu.CreateOrUpdateDRCConfigManifestWork("east", cConfig)).To(Succeed())
+
+		data, err := os.ReadFile(filepath.Join(directory, "east", mwName()+".yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("kind: DRClusterConfig"))
+		Expect(string(data)).To(ContainSubstring("clusterID: east-id"))
+
+		mw, err := mwu.FindManifestWork(mwName(), "east")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(util.IsManifestInAppliedState(mw)).To(BeTrue())
+
+		exported, err := util.ExtractDRCConfigFromManifestWork(mw)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exported.Spec.ClusterID).To(Equal("east-id"))
+	})
+
+	It("lists the MaintenanceModes exported for a cluster by their labels", func() {
+		mMode := rmn.MaintenanceMode{
+			TypeMeta:   metav1.TypeMeta{Kind: "MaintenanceMode", APIVersion: rmn.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "mmode"},
+		}
+		Expect(mwu.CreateOrUpdateMModeManifestWork("mmode", "west", mMode, nil)).To(Succeed())
+		Expect(mwu.CreateOrUpdateDRCConfigManifestWork("west", rmn.DRClusterConfig{})).To(Succeed())
+
+		mws, err := mwu.ListMModeManifests("west")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mws.Items).To(HaveLen(1))
+
+		exported, err := util.ExtractMModeFromManifestWork(&mws.Items[0])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exported.Name).To(Equal("mmode"))
+
+		mws, err = mwu.ListMModeManifests("east")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mws.Items).To(BeEmpty())
+	})
+
+	It("removes the file of a ManifestWork deleted", func() {
+		Expect(mwu.CreateOrUpdateDRCConfigManifestWork("east", rmn.DRClusterConfig{})).To(Succeed())
+		Expect(mwu.DeleteManifestWork(mwName(), "east")).To(Succeed())
+		Expect(mwu.DeleteManifestWork(mwName(), "east")).To(Succeed())
+
+		_, err := mwu.FindManifestWork(mwName(), "east")
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+})
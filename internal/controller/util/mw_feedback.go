@@ -0,0 +1,333 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	viewv1beta1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/view/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/yaml"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	// VRGSpecHashAnnotation is set by the hub on VRGs delivered with status feedback, with a hash of the VRG spec.
+	// The VRG reports it back as status.observedSpecHash, to tell whether its status is of the current spec.
+	VRGSpecHashAnnotation = "ramendr.openshift.io/vrg-spec-hash"
+
+	// manifestStatusFeedbackSynced is the manifest condition the work agent sets once all the status feedback
+	// values of the manifest are synced
+	manifestStatusFeedbackSynced = "StatusFeedbackSynced"
+)
+
+// errStatusFeedbackUnavailable is returned when the status of a manifest cannot be read from status feedback, and
+// is to be read using a ManagedClusterView instead
+var errStatusFeedbackUnavailable = errors.New("status feedback unavailable")
+
+// SetStatusFeedback selects whether ManifestWorks delivering NetworkFences and VRGs request their status feedback
+func (mwu *MWUtil) SetStatusFeedback(source rmn.ManagedClusterStatusSource) {
+	mwu.StatusFeedback = source == rmn.ManagedClusterStatusSourceFeedback
+}
+
+// statusFeedbackFields returns the json names of the top level fields of the status struct
+func statusFeedbackFields(status interface{}) []string {
+	statusType := reflect.TypeOf(status)
+	fields := make([]string, 0, statusType.NumField())
+
+	for idx := range statusType.NumField() {
+		name, _, _ := strings.Cut(statusType.Field(idx).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+
+	return fields
+}
+
+// statusFeedbackManifestConfig requests the status feedback of each top level field of status of the resource
+func statusFeedbackManifestConfig(group, resource, name, namespace string, status interface{},
+) ocmworkv1.ManifestConfigOption {
+	jsonPaths := []ocmworkv1.JsonPath{}
+	for _, field := range statusFeedbackFields(status) {
+		jsonPaths = append(jsonPaths, ocmworkv1.JsonPath{Name: field, Path: "." + field})
+	}
+
+	return ocmworkv1.ManifestConfigOption{
+		ResourceIdentifier: ocmworkv1.ResourceIdentifier{
+			Group:     group,
+			Resource:  resource,
+			Name:      name,
+			Namespace: namespace,
+		},
+		FeedbackRules: []ocmworkv1.FeedbackRule{{Type: ocmworkv1.JSONPathsType, JsonPaths: jsonPaths}},
+	}
+}
+
+// vrgSpecHash returns the hash of the VRG spec set as VRGSpecHashAnnotation
+func vrgSpecHash(spec *rmn.VolumeReplicationGroupSpec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal VRG spec: %w", err)
+	}
+
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// vrgStatusFeedbackPrepare annotates vrg with the hash of its spec, and returns the status feedback configuration of
+// the VRG manifest
+func vrgStatusFeedbackPrepare(vrg *rmn.VolumeReplicationGroup) (ocmworkv1.ManifestConfigOption, error) {
+	hash, err := vrgSpecHash(&vrg.Spec)
+	if err != nil {
+		return ocmworkv1.ManifestConfigOption{}, err
+	}
+
+	vrg.SetAnnotations(maps.Clone(vrg.GetAnnotations()))
+	AddAnnotation(vrg, VRGSpecHashAnnotation, hash)
+
+	return statusFeedbackManifestConfig(rmn.GroupVersion.Group, "volumereplicationgroups", vrg.Name, vrg.Namespace,
+		rmn.VolumeReplicationGroupStatus{}), nil
+}
+
+func nfStatusFeedbackManifestConfig(nf *csiaddonsv1alpha1.NetworkFence) ocmworkv1.ManifestConfigOption {
+	return statusFeedbackManifestConfig(csiaddonsv1alpha1.GroupVersion.Group, "networkfences", nf.Name, "",
+		csiaddonsv1alpha1.NetworkFenceStatus{})
+}
+
+// feedbackValueJSON returns the json encoding of a status feedback value
+func feedbackValueJSON(value ocmworkv1.FieldValue) (json.RawMessage, error) {
+	switch {
+	case value.Type == ocmworkv1.Integer && value.Integer != nil:
+		return json.Marshal(*value.Integer)
+	case value.Type == ocmworkv1.String && value.String != nil:
+		return json.Marshal(*value.String)
+	case value.Type == ocmworkv1.Boolean && value.Boolean != nil:
+		return json.Marshal(*value.Boolean)
+	case value.Type == ocmworkv1.JsonRaw && value.JsonRaw != nil:
+		return json.RawMessage(*value.JsonRaw), nil
+	}
+
+	return nil, fmt.Errorf("invalid status feedback value of type %s", value.Type)
+}
+
+// manifestStatusFeedback returns the manifest of the resource of kind, name and namespace in mw, and its status
+// assembled from the status feedback values. Returns errStatusFeedbackUnavailable if the status feedback is not
+// synced, and a processing error if mw is not applied at its current generation yet.
+func manifestStatusFeedback(mw *ocmworkv1.ManifestWork, group, kind, name, namespace string,
+) (*ocmworkv1.Manifest, json.RawMessage, error) {
+	applied := meta.FindStatusCondition(mw.Status.Conditions, ocmworkv1.WorkApplied)
+	if applied == nil || applied.ObservedGeneration != mw.Generation {
+		return nil, nil, newMCVError(MCVErrorReasonProcessing, mw.CreationTimestamp.Time,
+			fmt.Errorf("ManifestWork %s/%s generation %d not applied yet", mw.Namespace, mw.Name, mw.Generation))
+	}
+
+	for _, manifest := range mw.Status.ResourceStatus.Manifests {
+		resource := manifest.ResourceMeta
+		if resource.Group != group || resource.Kind != kind || resource.Name != name ||
+			resource.Namespace != namespace {
+			continue
+		}
+
+		if !meta.IsStatusConditionTrue(manifest.Conditions, manifestStatusFeedbackSynced) ||
+			int(resource.Ordinal) >= len(mw.Spec.Workload.Manifests) {
+			return nil, nil, errStatusFeedbackUnavailable
+		}
+
+		status := map[string]json.RawMessage{}
+
+		for _, value := range manifest.StatusFeedbacks.Values {
+			data, err := feedbackValueJSON(value.Value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s %s status feedback %s: %w", kind, name, value.Name, err)
+			}
+
+			status[value.Name] = data
+		}
+
+		data, err := json.Marshal(status)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal %s %s status: %w", kind, name, err)
+		}
+
+		return &mw.Spec.Workload.Manifests[resource.Ordinal], data, nil
+	}
+
+	return nil, nil, errStatusFeedbackUnavailable
+}
+
+// ExtractVRGFromManifestWorkFeedback returns the VRG of the VRG ManifestWork, with its status from status feedback.
+// As the feedback carries no metadata, the VRG generation is set to its observed generation only if the VRG reports
+// the spec hash of the ManifestWork VRG, else to the next generation.
+func ExtractVRGFromManifestWorkFeedback(mw *ocmworkv1.ManifestWork, name, namespace string,
+) (*rmn.VolumeReplicationGroup, error) {
+	manifest, status, err := manifestStatusFeedback(mw, rmn.GroupVersion.Group, "VolumeReplicationGroup",
+		name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	vrg := &rmn.VolumeReplicationGroup{}
+	if err := yaml.Unmarshal(manifest.Raw, vrg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal VRG object (%w)", err)
+	}
+
+	if err := json.Unmarshal(status, &vrg.Status); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal VRG status feedback (%w)", err)
+	}
+
+	// a VRG that has not reported a spec hash is read using a ManagedClusterView, as its generation is unknown
+	if vrg.Status.ObservedSpecHash == "" {
+		return nil, errStatusFeedbackUnavailable
+	}
+
+	vrg.Generation = vrg.Status.ObservedGeneration
+	if vrg.Status.ObservedSpecHash != vrg.GetAnnotations()[VRGSpecHashAnnotation] {
+		vrg.Generation++
+	}
+
+	return vrg, nil
+}
+
+// ExtractNFFromManifestWorkFeedback returns the NetworkFence of the NetworkFence ManifestWork, with its status from
+// status feedback. As the feedback carries no metadata, a status reporting the success of the opposite fence
+// operation is reported as processing, as it predates the current spec.
+func ExtractNFFromManifestWorkFeedback(mw *ocmworkv1.ManifestWork, name string,
+) (*csiaddonsv1alpha1.NetworkFence, error) {
+	manifest, status, err := manifestStatusFeedback(mw, csiaddonsv1alpha1.GroupVersion.Group, "NetworkFence", name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	nf := &csiaddonsv1alpha1.NetworkFence{}
+	if err := yaml.Unmarshal(manifest.Raw, nf); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal NetworkFence object (%w)", err)
+	}
+
+	if err := json.Unmarshal(status, &nf.Status); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal NetworkFence status feedback (%w)", err)
+	}
+
+	staleMessage := csiaddonsv1alpha1.UnFenceOperationSuccessfulMessage
+	if nf.Spec.FenceState == csiaddonsv1alpha1.Unfenced {
+		staleMessage = csiaddonsv1alpha1.FenceOperationSuccessfulMessage
+	}
+
+	if nf.Status.Message == staleMessage {
+		return nil, newMCVError(MCVErrorReasonProcessing, mw.CreationTimestamp.Time,
+			fmt.Errorf("NetworkFence %s status predates fence state %s", name, nf.Spec.FenceState))
+	}
+
+	return nf, nil
+}
+
+// ManifestWorkFeedbackGetter reads NetworkFences and VRGs from the status feedback of the ManifestWorks that deliver
+// them, using the cached ManifestWorks that the reconcilers watch, instead of creating a ManagedClusterView for each.
+// Objects without a ManifestWork, or whose status feedback is unavailable, are read using ManagedClusterViews.
+type ManifestWorkFeedbackGetter struct {
+	ManagedClusterViewGetterImpl
+}
+
+func (m ManifestWorkFeedbackGetter) GetVRGFromManagedCluster(resourceName, resourceNamespace, managedCluster string,
+	annotations map[string]string,
+) (*rmn.VolumeReplicationGroup, error) {
+	mcvName := BuildManagedClusterViewName(resourceName, resourceNamespace, "vrg")
+
+	mw, err := m.manifestWorkGet(ManifestWorkName(resourceName, resourceNamespace, MWTypeVRG), managedCluster)
+	if err == nil {
+		var vrg *rmn.VolumeReplicationGroup
+
+		vrg, err = ExtractVRGFromManifestWorkFeedback(mw, resourceName, resourceNamespace)
+		if err == nil {
+			m.viewDeleteIfExists(managedCluster, mcvName)
+
+			return vrg, nil
+		}
+	}
+
+	if !errors.Is(err, errStatusFeedbackUnavailable) {
+		return nil, err
+	}
+
+	return m.ManagedClusterViewGetterImpl.GetVRGFromManagedCluster(resourceName, resourceNamespace, managedCluster,
+		annotations)
+}
+
+func (m ManifestWorkFeedbackGetter) GetNFFromManagedCluster(targetCluster, networkFenceClassName,
+	resourceNamespace, managedCluster string, annotations map[string]string,
+) (*csiaddonsv1alpha1.NetworkFence, error) {
+	name := targetCluster
+	resourceName := strings.Join([]string{NetworkFencePrefix, targetCluster}, "-")
+
+	if networkFenceClassName != "" {
+		name += "-" + networkFenceClassName
+		resourceName = strings.Join([]string{NetworkFencePrefix, networkFenceClassName, targetCluster}, "-")
+	}
+
+	mcvName := BuildManagedClusterViewName(resourceName, resourceNamespace, "nf")
+
+	mw, err := m.manifestWorkGet(fmt.Sprintf(ManifestWorkNameFormat, name, managedCluster, MWTypeNF), managedCluster)
+	if err == nil {
+		var nf *csiaddonsv1alpha1.NetworkFence
+
+		nf, err = ExtractNFFromManifestWorkFeedback(mw, resourceName)
+		if err == nil {
+			m.viewDeleteIfExists(managedCluster, mcvName)
+
+			return nf, nil
+		}
+	}
+
+	if !errors.Is(err, errStatusFeedbackUnavailable) {
+		return nil, err
+	}
+
+	return m.ManagedClusterViewGetterImpl.GetNFFromManagedCluster(targetCluster, networkFenceClassName,
+		resourceNamespace, managedCluster, annotations)
+}
+
+// manifestWorkGet returns the cached ManifestWork, or errStatusFeedbackUnavailable if it does not exist, as the
+// object it delivered may remain on the managed cluster
+func (m ManifestWorkFeedbackGetter) manifestWorkGet(name, namespace string) (*ocmworkv1.ManifestWork, error) {
+	mw := &ocmworkv1.ManifestWork{}
+
+	if err := m.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, mw); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, errStatusFeedbackUnavailable
+		}
+
+		return nil, fmt.Errorf("failed to get ManifestWork %s/%s: %w", namespace, name, err)
+	}
+
+	return mw, nil
+}
+
+// viewDeleteIfExists deletes a ManagedClusterView no longer required as the object is read from another source
+func (m ManagedClusterViewGetterImpl) viewDeleteIfExists(clusterName, mcvName string) {
+	mcv := &viewv1beta1.ManagedClusterView{}
+	if err := m.Get(context.TODO(), types.NamespacedName{Name: mcvName, Namespace: clusterName}, mcv); err != nil {
+		return
+	}
+
+	logger := ctrl.Log.WithName("MCV").WithValues("name", mcvName, "cluster", clusterName)
+
+	if err := m.DeleteManagedClusterView(clusterName, mcvName, logger); err != nil {
+		logger.Info("Failed to delete ManagedClusterView no longer required", "error", err)
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"encoding/json"
+
+	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+func feedbackManifestWork(object interface{}, kind string, values ...ocmworkv1.FeedbackValue,
+) *ocmworkv1.ManifestWork {
+	raw, err := json.Marshal(object)
+	Expect(err).ToNot(HaveOccurred())
+
+	meta, ok := object.(metav1.Object)
+	Expect(ok).To(BeTrue())
+
+	mw := &ocmworkv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+	mw.Spec.Workload.Manifests = []ocmworkv1.Manifest{{RawExtension: runtime.RawExtension{Raw: raw}}}
+	mw.Status.Conditions = []metav1.Condition{
+		{Type: ocmworkv1.WorkApplied, Status: metav1.ConditionTrue, ObservedGeneration: 2},
+	}
+	mw.Status.ResourceStatus.Manifests = []ocmworkv1.ManifestCondition{{
+		ResourceMeta: ocmworkv1.ManifestResourceMeta{
+			Kind: kind, Name: meta.GetName(), Namespace: meta.GetNamespace(),
+			Group: object.(runtime.Object).GetObjectKind().GroupVersionKind().Group,
+		},
+		StatusFeedbacks: ocmworkv1.StatusFeedbackResult{Values: values},
+		Conditions:      []metav1.Condition{{Type: "StatusFeedbackSynced", Status: metav1.ConditionTrue}},
+	}}
+
+	return mw
+}
+
+func stringFeedback(name, value string) ocmworkv1.FeedbackValue {
+	return ocmworkv1.FeedbackValue{Name: name, Value: ocmworkv1.FieldValue{Type: ocmworkv1.String, String: &value}}
+}
+
+func integerFeedback(name string, value int64) ocmworkv1.FeedbackValue {
+	return ocmworkv1.FeedbackValue{Name: name, Value: ocmworkv1.FieldValue{Type: ocmworkv1.Integer, Integer: &value}}
+}
+
+func jsonFeedback(name, value string) ocmworkv1.FeedbackValue {
+	return ocmworkv1.FeedbackValue{Name: name, Value: ocmworkv1.FieldValue{Type: ocmworkv1.JsonRaw, JsonRaw: &value}}
+}
+
+var _ = Describe("ExtractVRGFromManifestWorkFeedback", func() {
+	vrg := func(specHash string) *rmn.VolumeReplicationGroup {
+		return &rmn.VolumeReplicationGroup{
+			TypeMeta: metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: rmn.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "app", Namespace: "app-ns",
+				Annotations: map[string]string{util.VRGSpecHashAnnotation: specHash},
+			},
+			Spec: rmn.VolumeReplicationGroupSpec{ReplicationState: rmn.Primary},
+		}
+	}
+
+	It("assembles the status, at the observed generation, of a VRG that observed the current spec", func() {
+		mw := feedbackManifestWork(vrg("hash"), "VolumeReplicationGroup",
+			stringFeedback("state", string(rmn.PrimaryState)),
+			integerFeedback("observedGeneration", 3),
+			stringFeedback("observedSpecHash", "hash"),
+			jsonFeedback("conditions", `[{"type":"DataReady","status":"True","observedGeneration":3}]`),
+		)
+
+		result, err := util.ExtractVRGFromManifestWorkFeedback(mw, "app", "app-ns")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Spec.ReplicationState).To(Equal(rmn.Primary))
+		Expect(result.Status.State).To(Equal(rmn.PrimaryState))
+		Expect(result.Status.Conditions).To(HaveLen(1))
+		Expect(result.Generation).To(BeEquivalentTo(3))
+	})
+
+	It("reports a VRG that has not observed the current spec at the next generation", func() {
+		mw := feedbackManifestWork(vrg("new"), "VolumeReplicationGroup",
+			integerFeedback("observedGeneration", 3),
+			stringFeedback("observedSpecHash", "old"),
+		)
+
+		result, err := util.ExtractVRGFromManifestWorkFeedback(mw, "app", "app-ns")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Generation).To(BeEquivalentTo(4))
+	})
+
+	It("fails as processing until the ManifestWork is applied", func() {
+		mw := feedbackManifestWork(vrg("hash"), "VolumeReplicationGroup")
+		mw.Generation = 3
+
+		_, err := util.ExtractVRGFromManifestWorkFeedback(mw, "app", "app-ns")
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+	})
+
+	It("fails if the status feedback is not synced", func() {
+		mw := feedbackManifestWork(vrg("hash"), "VolumeReplicationGroup")
+		mw.Status.ResourceStatus.Manifests[0].Conditions[0].Status = metav1.ConditionFalse
+
+		_, err := util.ExtractVRGFromManifestWorkFeedback(mw, "app", "app-ns")
+		Expect(err).To(HaveOccurred())
+		Expect(util.MCVErrorFrom(err)).To(BeNil())
+	})
+})
+
+var _ = Describe("ExtractNFFromManifestWorkFeedback", func() {
+	nf := func(state csiaddonsv1alpha1.FenceState) *csiaddonsv1alpha1.NetworkFence {
+		return &csiaddonsv1alpha1.NetworkFence{
+			TypeMeta:   metav1.TypeMeta{Kind: "NetworkFence", APIVersion: csiaddonsv1alpha1.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "network-fence-cluster1"},
+			Spec:       csiaddonsv1alpha1.NetworkFenceSpec{FenceState: state},
+		}
+	}
+
+	It("assembles the status of a NetworkFence", func() {
+		mw := feedbackManifestWork(nf(csiaddonsv1alpha1.Fenced), "NetworkFence",
+			stringFeedback("result", string(csiaddonsv1alpha1.FencingOperationResultSucceeded)),
+			stringFeedback("message", csiaddonsv1alpha1.FenceOperationSuccessfulMessage),
+		)
+
+		result, err := util.ExtractNFFromManifestWorkFeedback(mw, "network-fence-cluster1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Spec.FenceState).To(Equal(csiaddonsv1alpha1.Fenced))
+		Expect(result.Status.Result).To(Equal(csiaddonsv1alpha1.FencingOperationResultSucceeded))
+	})
+
+	It("fails as processing while the status reports the opposite operation", func() {
+		mw := feedbackManifestWork(nf(csiaddonsv1alpha1.Unfenced), "NetworkFence",
+			stringFeedback("result", string(csiaddonsv1alpha1.FencingOperationResultSucceeded)),
+			stringFeedback("message", csiaddonsv1alpha1.FenceOperationSuccessfulMessage),
+		)
+
+		_, err := util.ExtractNFFromManifestWorkFeedback(mw, "network-fence-cluster1")
+		Expect(util.IsMCVProcessing(err)).To(BeTrue())
+	})
+})
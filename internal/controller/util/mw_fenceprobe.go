@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+)
+
+// FenceProbeManifestWorkName returns the name of the ManifestWork delivering the probe that verifies the fence of
+// fencedCluster to peerCluster
+func FenceProbeManifestWorkName(fencedCluster, peerCluster string) string {
+	return fmt.Sprintf(ManifestWorkNameFormat, fencedCluster, peerCluster, MWTypeFenceProbe)
+}
+
+// CreateOrUpdateFenceProbeManifestWork delivers the probe Job that verifies the fence of fencedCluster to
+// peerCluster, requesting the status feedback of its succeeded and failed pod counts
+func (mwu *MWUtil) CreateOrUpdateFenceProbeManifestWork(fencedCluster, peerCluster string,
+	probe *unstructured.Unstructured, annotations map[string]string,
+) error {
+	if probe.GroupVersionKind() != batchv1.SchemeGroupVersion.WithKind("Job") {
+		return fmt.Errorf("fence probe %s is a %s, not a batch/v1 Job", probe.GetName(), probe.GroupVersionKind())
+	}
+
+	manifest, err := mwu.GenerateManifest(probe)
+	if err != nil {
+		return err
+	}
+
+	mw := mwu.newManifestWork(FenceProbeManifestWorkName(fencedCluster, peerCluster), peerCluster,
+		map[string]string{"app": "FenceProbe"}, []ocmworkv1.Manifest{*manifest}, annotations)
+	mw.Spec.ManifestConfigs = []ocmworkv1.ManifestConfigOption{{
+		ResourceIdentifier: ocmworkv1.ResourceIdentifier{
+			Group:     batchv1.SchemeGroupVersion.Group,
+			Resource:  "jobs",
+			Name:      probe.GetName(),
+			Namespace: probe.GetNamespace(),
+		},
+		FeedbackRules: []ocmworkv1.FeedbackRule{{
+			Type: ocmworkv1.JSONPathsType,
+			JsonPaths: []ocmworkv1.JsonPath{
+				{Name: "succeeded", Path: ".succeeded"},
+				{Name: "failed", Path: ".failed"},
+			},
+		}},
+	}}
+
+	_, err = mwu.createOrUpdateManifestWork(mw, peerCluster)
+
+	return err
+}
+
+// ExtractFenceProbeFromManifestWork returns the status of the probe Job of the fence probe ManifestWork from its
+// status feedback, or a nil status if the feedback is not synced yet, as before the Job starts any pod
+func ExtractFenceProbeFromManifestWork(mw *ocmworkv1.ManifestWork) (*batchv1.JobStatus, error) {
+	if len(mw.Spec.Workload.Manifests) == 0 {
+		return nil, fmt.Errorf("fence probe ManifestWork %s/%s has no manifest", mw.Namespace, mw.Name)
+	}
+
+	probe := &unstructured.Unstructured{}
+	if err := probe.UnmarshalJSON(mw.Spec.Workload.Manifests[0].Raw); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal fence probe (%w)", err)
+	}
+
+	_, status, err := manifestStatusFeedback(mw, batchv1.SchemeGroupVersion.Group, "Job", probe.GetName(),
+		probe.GetNamespace())
+	if err != nil {
+		if errors.Is(err, errStatusFeedbackUnavailable) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	jobStatus := &batchv1.JobStatus{}
+	if err := json.Unmarshal(status, jobStatus); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal fence probe status feedback (%w)", err)
+	}
+
+	return jobStatus, nil
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ManifestWorkParentIndexName indexes ManifestWorks by the namespaced name of the resource they were created for
+	ManifestWorkParentIndexName = "ramen.manifestwork.parent"
+
+	// ManifestWorkTypeIndexName indexes ManifestWorks by their type, e.g. MWTypeVRG
+	ManifestWorkTypeIndexName = "ramen.manifestwork.type"
+
+	// listPageSize is the number of items fetched per request by the paginated list helpers
+	listPageSize = 100
+)
+
+// ManifestWorkType returns the type of a ManifestWork from its name, see ManifestWorkNameFormat, or an empty
+// string if the name does not carry a type
+func ManifestWorkType(mwName string) string {
+	trimmed, found := strings.CutSuffix(mwName, "-mw")
+	if !found {
+		return ""
+	}
+
+	return trimmed[strings.LastIndex(trimmed, "-")+1:]
+}
+
+// IndexFieldsForManifestWorks adds ManifestWork indexes by parent, as found in the passed in parent name and
+// namespace annotations, and by type. These allow listing the ManifestWorks of a parent, or of a type, without
+// scanning all ManifestWorks across all managed cluster namespaces.
+func IndexFieldsForManifestWorks(ctx context.Context, fieldIndexer client.FieldIndexer,
+	parentNameAnnotation, parentNamespaceAnnotation string,
+) error {
+	err := fieldIndexer.IndexField(ctx, &ocmworkv1.ManifestWork{}, ManifestWorkParentIndexName,
+		func(o client.Object) []string {
+			name := o.GetAnnotations()[parentNameAnnotation]
+			if name == "" {
+				return nil
+			}
+
+			return []string{
+				types.NamespacedName{Namespace: o.GetAnnotations()[parentNamespaceAnnotation], Name: name}.String(),
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return fieldIndexer.IndexField(ctx, &ocmworkv1.ManifestWork{}, ManifestWorkTypeIndexName,
+		func(o client.Object) []string {
+			mwType := ManifestWorkType(o.GetName())
+			if mwType == "" {
+				return nil
+			}
+
+			return []string{mwType}
+		})
+}
+
+// ListManifestWorksByParent lists the ManifestWorks created for the parent across all managed clusters. Requires
+// the indexes from IndexFieldsForManifestWorks.
+func (mwu *MWUtil) ListManifestWorksByParent(parentNamespace, parentName string) (
+	*ocmworkv1.ManifestWorkList, error,
+) {
+	mws := &ocmworkv1.ManifestWorkList{}
+
+	err := mwu.Client.List(mwu.Ctx, mws, client.MatchingFields{
+		ManifestWorkParentIndexName: types.NamespacedName{Namespace: parentNamespace, Name: parentName}.String(),
+	})
+
+	return mws, err
+}
+
+// ListManifestWorksByType lists the ManifestWorks of a type for a managed cluster. Requires the indexes from
+// IndexFieldsForManifestWorks. The passed in options further filter the list, e.g. by label.
+func (mwu *MWUtil) ListManifestWorksByType(cluster, mwType string, opts ...client.ListOption,
+) (*ocmworkv1.ManifestWorkList, error) {
+	mws := &ocmworkv1.ManifestWorkList{}
+
+	err := mwu.Client.List(mwu.Ctx, mws, append([]client.ListOption{client.InNamespace(cluster),
+		client.MatchingFields{ManifestWorkTypeIndexName: mwType}}, opts...)...)
+
+	return mws, err
+}
+
+// ListManifestWorksPaginated lists ManifestWorks from the API server in pages of listPageSize
+func ListManifestWorksPaginated(ctx context.Context, reader client.Reader, opts ...client.ListOption,
+) (*ocmworkv1.ManifestWorkList, error) {
+	mws := &ocmworkv1.ManifestWorkList{}
+	page := &ocmworkv1.ManifestWorkList{}
+
+	for {
+		pageOpts := append([]client.ListOption{client.Limit(listPageSize), client.Continue(page.Continue)}, opts...)
+		if err := reader.List(ctx, page, pageOpts...); err != nil {
+			return nil, err
+		}
+
+		mws.Items = append(mws.Items, page.Items...)
+
+		if page.Continue == "" {
+			return mws, nil
+		}
+	}
+}
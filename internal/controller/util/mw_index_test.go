@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("ManifestWork indexes", func() {
+	var mwu *util.MWUtil
+
+	mw := func(namespace, name string, labels map[string]string) *ocmworkv1.ManifestWork {
+		return &ocmworkv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(ocmworkv1.AddToScheme(scheme)).To(Succeed())
+
+		mmodeLabels := map[string]string{util.MModesLabel: ""}
+
+		// No API reader is set, so that the lists are served from the indexed client only
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+			WithIndex(&ocmworkv1.ManifestWork{}, util.ManifestWorkTypeIndexName, func(o client.Object) []string {
+				return []string{util.ManifestWorkType(o.GetName())}
+			}).
+			WithObjects(
+				mw("east", "id1-mmode-mw", mmodeLabels),
+				mw("east", "id2-mmode-mw", nil),
+				mw("west", "id3-mmode-mw", mmodeLabels),
+				mw("east", util.ManifestWorkName("drpc", "app", util.MWTypeVRG), mmodeLabels),
+			).Build()
+
+		mwu = &util.MWUtil{Client: fakeClient, Ctx: context.TODO(), Log: logr.Discard()}
+	})
+
+	It("lists the maintenance mode ManifestWorks of a cluster by type and label", func() {
+		mws, err := mwu.ListMModeManifests("east")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mws.Items).To(HaveLen(1))
+		Expect(mws.Items[0].Name).To(Equal("id1-mmode-mw"))
+	})
+
+	It("lists the ManifestWorks of a type of a cluster", func() {
+		mws, err := mwu.ListManifestWorksByType("east", util.MWTypeMMode)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mws.Items).To(HaveLen(2))
+
+		mws, err = mwu.ListManifestWorksByType("east", util.MWTypeVRG)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mws.Items).To(HaveLen(1))
+	})
+
+	It("creates maintenance mode ManifestWorks of the indexed type", func() {
+		Expect(mwu.CreateOrUpdateMModeManifestWork("id4", "west", rmn.MaintenanceMode{
+			ObjectMeta: metav1.ObjectMeta{Name: "id4"},
+		}, nil)).To(Succeed())
+
+		mws, err := mwu.ListMModeManifests("west")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mws.Items).To(HaveLen(2))
+	})
+})
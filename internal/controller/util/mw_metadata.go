@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// ReapplyAnnotation on a DRPC or DRCluster forces the manifests of its ManifestWorks to be reapplied on the managed
+// clusters whenever its value changes, e.g. to a timestamp, to restore resources that were mutated or deleted out of
+// band. The value is set as an annotation on the ManifestWorks and their manifests, which changes the ManifestWork
+// spec and causes the work agent to reapply every manifest.
+const ReapplyAnnotation = "ramendr.openshift.io/reapply"
+
+// SetReapplyToken records the ReapplyAnnotation value of owner, to be set on the ManifestWorks, and their
+// manifests, created or updated by mwu
+func (mwu *MWUtil) SetReapplyToken(owner client.Object) {
+	mwu.ReapplyToken = owner.GetAnnotations()[ReapplyAnnotation]
+}
+
+// SetPropagatedMetadata selects the labels and annotations of owner that are allowed by propagation, to be copied
+// onto the ManifestWorks, and their manifests, created or updated by mwu
+func (mwu *MWUtil) SetPropagatedMetadata(owner client.Object, propagation rmn.MetadataPropagation) {
+	mwu.PropagatedLabels = selectKeys(owner.GetLabels(), propagation.Labels)
+	mwu.PropagatedAnnotations = selectKeys(owner.GetAnnotations(), propagation.Annotations)
+}
+
+func selectKeys(from map[string]string, keys []string) map[string]string {
+	selected := map[string]string{}
+
+	for _, key := range keys {
+		if value, ok := from[key]; ok {
+			selected[key] = value
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil
+	}
+
+	return selected
+}
+
+// PropagatedMetadataAnnotation records the keys of the labels and annotations propagated onto an object, as
+// comma separated lists, so that keys no longer propagated are removed from it, while keys set by others are
+// retained
+const PropagatedMetadataAnnotation = "ramendr.openshift.io/propagated-metadata"
+
+// propagatedMetadataKeys are the keys of the labels and annotations propagated onto an object
+type propagatedMetadataKeys struct {
+	Labels      []string `json:"labels,omitempty"`
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// propagateMetadata sets the propagated labels and annotations on obj, updating the values of the keys it
+// propagated earlier and removing those no longer propagated, and the reapply token. Keys that obj already has, and
+// which were not propagated, are retained. Returns true if obj was modified.
+func (mwu *MWUtil) propagateMetadata(obj client.Object) bool {
+	owned := propagatedMetadataKeys{}
+	if value, ok := obj.GetAnnotations()[PropagatedMetadataAnnotation]; ok {
+		// An unparsable record is treated as empty, retaining the keys as set by others
+		_ = json.Unmarshal([]byte(value), &owned)
+	}
+
+	labels, labelKeys, labelsUpdated := propagateKeys(obj.GetLabels(), mwu.PropagatedLabels, owned.Labels)
+	if labelsUpdated {
+		obj.SetLabels(labels)
+	}
+
+	annotations, annotationKeys, annotationsUpdated := propagateKeys(obj.GetAnnotations(),
+		mwu.PropagatedAnnotations, owned.Annotations)
+	if annotationsUpdated {
+		obj.SetAnnotations(annotations)
+	}
+
+	updated := labelsUpdated || annotationsUpdated
+
+	if len(labelKeys) == 0 && len(annotationKeys) == 0 {
+		if HasAnnotation(obj, PropagatedMetadataAnnotation) {
+			annotations := obj.GetAnnotations()
+			delete(annotations, PropagatedMetadataAnnotation)
+			obj.SetAnnotations(annotations)
+
+			updated = true
+		}
+	} else {
+		// Marshalling a struct of string slices cannot fail
+		record, _ := json.Marshal(propagatedMetadataKeys{Labels: labelKeys, Annotations: annotationKeys})
+		updated = AddAnnotation(obj, PropagatedMetadataAnnotation, string(record)) || updated
+	}
+
+	if mwu.ReapplyToken != "" {
+		updated = AddAnnotation(obj, ReapplyAnnotation, mwu.ReapplyToken) || updated
+	}
+
+	return updated
+}
+
+// propagateKeys sets the propagated keys on current, unless they were set by others, i.e. are present in current
+// but not in owned, and removes the owned keys that are no longer propagated. Returns the resulting map, the sorted
+// keys it owns, and whether it differs from current.
+func propagateKeys(current, propagated map[string]string, owned []string,
+) (map[string]string, []string, bool) {
+	result := make(map[string]string, len(current)+len(propagated))
+	for key, value := range current {
+		result[key] = value
+	}
+
+	updated := false
+
+	for _, key := range owned {
+		if _, ok := propagated[key]; ok {
+			continue
+		}
+
+		if _, ok := result[key]; ok {
+			delete(result, key)
+
+			updated = true
+		}
+	}
+
+	keys := []string{}
+
+	for key, value := range propagated {
+		currentValue, ok := current[key]
+		if ok && !slices.Contains(owned, key) {
+			continue
+		}
+
+		if !ok || currentValue != value {
+			result[key] = value
+
+			updated = true
+		}
+
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return result, keys, updated
+}
+
+// propagateMetadataToManifest adds the propagated labels and annotations to the resource in manifest
+func (mwu *MWUtil) propagateMetadataToManifest(manifest *ocmworkv1.Manifest) error {
+	if len(mwu.PropagatedLabels) == 0 && len(mwu.PropagatedAnnotations) == 0 && mwu.ReapplyToken == "" {
+		return nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+		return fmt.Errorf("failed to unmarshal manifest: %w", err)
+	}
+
+	if !mwu.propagateMetadata(obj) {
+		return nil
+	}
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	manifest.Raw = raw
+	manifest.Object = nil
+
+	return nil
+}
+
+// propagateMetadataToManifestWork adds the propagated labels and annotations to mw and to its manifests
+func (mwu *MWUtil) propagateMetadataToManifestWork(mw *ocmworkv1.ManifestWork) error {
+	mwu.propagateMetadata(mw)
+
+	for idx := range mw.Spec.Workload.Manifests {
+		if err := mwu.propagateMetadataToManifest(&mw.Spec.Workload.Manifests[idx]); err != nil {
+			return fmt.Errorf("ManifestWork %s/%s: %w", mw.Namespace, mw.Name, err)
+		}
+	}
+
+	return nil
+}
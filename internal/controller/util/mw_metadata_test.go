@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("SetPropagatedMetadata", func() {
+	owner := &rmn.DRPlacementControl{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"cost-center": "1234", "team": "storage", "internal": "true"},
+			Annotations: map[string]string{"owner": "storage-team", "note": "internal"},
+		},
+	}
+
+	It("selects only allow-listed keys that are present on the owner", func() {
+		mwu := util.MWUtil{}
+		mwu.SetPropagatedMetadata(owner, rmn.MetadataPropagation{
+			Labels:      []string{"cost-center", "team", "missing"},
+			Annotations: []string{"owner"},
+		})
+		Expect(mwu.PropagatedLabels).To(Equal(map[string]string{"cost-center": "1234", "team": "storage"}))
+		Expect(mwu.PropagatedAnnotations).To(Equal(map[string]string{"owner": "storage-team"}))
+	})
+
+	It("propagates nothing by default", func() {
+		mwu := util.MWUtil{}
+		mwu.SetPropagatedMetadata(owner, rmn.MetadataPropagation{})
+		Expect(mwu.PropagatedLabels).To(BeNil())
+		Expect(mwu.PropagatedAnnotations).To(BeNil())
+	})
+})
+
+var _ = Describe("SetReapplyToken", func() {
+	It("records the reapply annotation of the owner", func() {
+		owner := &rmn.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{util.ReapplyAnnotation: "2026-10-16T10:00:00Z"},
+			},
+		}
+
+		mwu := util.MWUtil{}
+		mwu.SetReapplyToken(owner)
+		Expect(mwu.ReapplyToken).To(Equal("2026-10-16T10:00:00Z"))
+	})
+})
+
+var _ = Describe("propagateMetadata", func() {
+	var mw *ocmworkv1.ManifestWork
+
+	propagate := func(labels, annotations map[string]string) bool {
+		mwu := util.MWUtil{PropagatedLabels: labels, PropagatedAnnotations: annotations}
+
+		return mwu.PropagateMetadata(mw)
+	}
+
+	BeforeEach(func() {
+		mw = &ocmworkv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{util.CreatedByRamenLabel: "true"}},
+		}
+
+		Expect(propagate(map[string]string{"cost-center": "1234", "team": "storage"},
+			map[string]string{"owner": "storage-team"})).To(BeTrue())
+		Expect(mw.Labels).To(HaveKeyWithValue("cost-center", "1234"))
+		Expect(mw.Annotations).To(HaveKeyWithValue("owner", "storage-team"))
+		Expect(mw.Annotations).To(HaveKey(util.PropagatedMetadataAnnotation))
+	})
+
+	It("does not modify an object whose propagated metadata is up to date", func() {
+		Expect(propagate(map[string]string{"cost-center": "1234", "team": "storage"},
+			map[string]string{"owner": "storage-team"})).To(BeFalse())
+	})
+
+	It("updates the value of a propagated key that changed on the owner", func() {
+		Expect(propagate(map[string]string{"cost-center": "5678", "team": "storage"},
+			map[string]string{"owner": "storage-team"})).To(BeTrue())
+		Expect(mw.Labels).To(HaveKeyWithValue("cost-center", "5678"))
+	})
+
+	It("removes the keys that are no longer propagated", func() {
+		Expect(propagate(map[string]string{"team": "storage"}, nil)).To(BeTrue())
+		Expect(mw.Labels).To(Equal(map[string]string{util.CreatedByRamenLabel: "true", "team": "storage"}))
+		Expect(mw.Annotations).ToNot(HaveKey("owner"))
+
+		Expect(propagate(nil, nil)).To(BeTrue())
+		Expect(mw.Labels).To(Equal(map[string]string{util.CreatedByRamenLabel: "true"}))
+		Expect(mw.Annotations).To(BeEmpty())
+	})
+
+	It("retains the keys set by others", func() {
+		Expect(propagate(map[string]string{util.CreatedByRamenLabel: "false"}, nil)).To(BeTrue())
+		Expect(mw.Labels).To(Equal(map[string]string{util.CreatedByRamenLabel: "true"}))
+	})
+})
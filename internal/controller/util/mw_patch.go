@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// manifestsEqual compares manifests semantically, as the API server may reorder keys of the raw manifests
+func manifestsEqual(a, b ocmworkv1.Manifest) bool {
+	if bytes.Equal(a.Raw, b.Raw) {
+		return true
+	}
+
+	var aObj, bObj interface{}
+
+	if err := json.Unmarshal(a.Raw, &aObj); err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal(b.Raw, &bObj); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(aObj, bObj)
+}
+
+// manifestWorkSpecPatchOperations returns JSON patch operations that update only the manifests, and other spec
+// fields, of the found ManifestWork that differ from the desired spec
+func manifestWorkSpecPatchOperations(found, desired *ocmworkv1.ManifestWorkSpec) []jsonPatchOperation {
+	ops := []jsonPatchOperation{}
+
+	foundManifests := found.Workload.Manifests
+	desiredManifests := desired.Workload.Manifests
+
+	for idx := range desiredManifests {
+		if idx >= len(foundManifests) {
+			ops = append(ops, jsonPatchOperation{
+				Op: "add", Path: "/spec/workload/manifests/-", Value: desiredManifests[idx],
+			})
+
+			continue
+		}
+
+		if !manifestsEqual(foundManifests[idx], desiredManifests[idx]) {
+			ops = append(ops, jsonPatchOperation{
+				Op: "replace", Path: fmt.Sprintf("/spec/workload/manifests/%d", idx), Value: desiredManifests[idx],
+			})
+		}
+	}
+
+	// Remove from the end, so that indexes of the remaining manifests to remove do not shift
+	for idx := len(foundManifests) - 1; idx >= len(desiredManifests); idx-- {
+		ops = append(ops, jsonPatchOperation{Op: "remove", Path: fmt.Sprintf("/spec/workload/manifests/%d", idx)})
+	}
+
+	ops = appendFieldPatchOperation(ops, "/spec/deleteOption",
+		found.DeleteOption == nil, desired.DeleteOption == nil,
+		reflect.DeepEqual(found.DeleteOption, desired.DeleteOption), desired.DeleteOption)
+	ops = appendFieldPatchOperation(ops, "/spec/manifestConfigs",
+		len(found.ManifestConfigs) == 0, len(desired.ManifestConfigs) == 0,
+		reflect.DeepEqual(found.ManifestConfigs, desired.ManifestConfigs), desired.ManifestConfigs)
+	ops = appendFieldPatchOperation(ops, "/spec/executor",
+		found.Executor == nil, desired.Executor == nil,
+		reflect.DeepEqual(found.Executor, desired.Executor), desired.Executor)
+
+	return ops
+}
+
+func appendFieldPatchOperation(ops []jsonPatchOperation, path string,
+	foundEmpty, desiredEmpty, equal bool, value interface{},
+) []jsonPatchOperation {
+	switch {
+	case equal || (foundEmpty && desiredEmpty):
+		return ops
+	case desiredEmpty:
+		return append(ops, jsonPatchOperation{Op: "remove", Path: path})
+	default:
+		// add replaces an existing member of an object
+		return append(ops, jsonPatchOperation{Op: "add", Path: path, Value: value})
+	}
+}
+
+// patchManifestWorkSpec patches the ManifestWork with key to the spec desired returns for its current spec, sending
+// only the manifests and spec fields that changed. The patch is guarded by the resourceVersion the changes were
+// computed against, and the desired spec is recomputed from the ManifestWork if it changed in the meantime. Returns
+// false if there was nothing to patch.
+func (mwu *MWUtil) patchManifestWorkSpec(key types.NamespacedName,
+	desired func(found *ocmworkv1.ManifestWorkSpec) *ocmworkv1.ManifestWorkSpec,
+) (bool, error) {
+	patched := false
+
+	err := retry.OnError(retry.DefaultBackoff, func(err error) bool {
+		// A failed resourceVersion test operation is reported as invalid
+		return k8serrors.IsInvalid(err) || k8serrors.IsConflict(err)
+	}, func() error {
+		found := &ocmworkv1.ManifestWork{}
+		if err := mwu.Client.Get(mwu.Ctx, key, found); err != nil {
+			return err
+		}
+
+		ops := manifestWorkSpecPatchOperations(&found.Spec, desired(found.Spec.DeepCopy()))
+		if len(ops) == 0 {
+			return nil
+		}
+
+		ops = append([]jsonPatchOperation{{
+			Op: "test", Path: "/metadata/resourceVersion", Value: found.ResourceVersion,
+		}}, ops...)
+
+		data, err := json.Marshal(ops)
+		if err != nil {
+			return fmt.Errorf("failed to marshal ManifestWork %s patch: %w", key, err)
+		}
+
+		if err := mwu.Client.Patch(mwu.Ctx, found, client.RawPatch(types.JSONPatchType, data)); err != nil {
+			return err
+		}
+
+		patched = true
+
+		return nil
+	})
+
+	return patched, err
+}
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("ManifestWork spec patch", func() {
+	manifest := func(name string) ocmworkv1.Manifest {
+		return ocmworkv1.Manifest{RawExtension: runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"` + name + `"}}`),
+		}}
+	}
+
+	spec := func(names ...string) *ocmworkv1.ManifestWorkSpec {
+		spec := &ocmworkv1.ManifestWorkSpec{}
+		for _, name := range names {
+			spec.Workload.Manifests = append(spec.Workload.Manifests, manifest(name))
+		}
+
+		return spec
+	}
+
+	Describe("ManifestWorkSpecPatchOperations", func() {
+		It("returns no operations for semantically equal specs", func() {
+			found := spec("a")
+			found.Workload.Manifests[0].Raw = []byte(`{"metadata":{"name":"a"},"kind":"ConfigMap","apiVersion":"v1"}`)
+
+			Expect(util.ManifestWorkSpecPatchOperations(found, spec("a"))).To(BeEmpty())
+		})
+
+		It("replaces only the manifests that changed", func() {
+			Expect(util.ManifestWorkSpecPatchOperations(spec("a", "b"), spec("a", "c"))).To(Equal([]util.JSONPatchOperation{
+				{Op: "replace", Path: "/spec/workload/manifests/1", Value: manifest("c")},
+			}))
+		})
+
+		It("adds new manifests, and removes extra manifests from the end", func() {
+			Expect(util.ManifestWorkSpecPatchOperations(spec("a"), spec("a", "b", "c"))).To(Equal([]util.JSONPatchOperation{
+				{Op: "add", Path: "/spec/workload/manifests/-", Value: manifest("b")},
+				{Op: "add", Path: "/spec/workload/manifests/-", Value: manifest("c")},
+			}))
+			Expect(util.ManifestWorkSpecPatchOperations(spec("a", "b", "c"), spec("a"))).To(Equal([]util.JSONPatchOperation{
+				{Op: "remove", Path: "/spec/workload/manifests/2"},
+				{Op: "remove", Path: "/spec/workload/manifests/1"},
+			}))
+		})
+
+		It("adds, and removes, the delete option", func() {
+			orphan := spec("a")
+			orphan.DeleteOption = &ocmworkv1.DeleteOption{PropagationPolicy: ocmworkv1.DeletePropagationPolicyTypeOrphan}
+
+			Expect(util.ManifestWorkSpecPatchOperations(spec("a"), orphan)).To(Equal([]util.JSONPatchOperation{
+				{Op: "add", Path: "/spec/deleteOption", Value: orphan.DeleteOption},
+			}))
+			Expect(util.ManifestWorkSpecPatchOperations(orphan, spec("a"))).To(Equal([]util.JSONPatchOperation{
+				{Op: "remove", Path: "/spec/deleteOption"},
+			}))
+		})
+	})
+
+	Describe("UpdateVRGManifestWork", func() {
+		var (
+			fakeClient client.Client
+			mwu        *util.MWUtil
+			cached     *ocmworkv1.ManifestWork
+			conflicts  int
+		)
+
+		key := types.NamespacedName{Namespace: "cluster1", Name: util.ManifestWorkName("vrg", "app", util.MWTypeVRG)}
+
+		// update updates the ManifestWork on the server, as another reconciler would
+		update := func(ctx context.Context, c client.Client, names ...string) {
+			mw := &ocmworkv1.ManifestWork{}
+			Expect(c.Get(ctx, key, mw)).To(Succeed())
+
+			mw.Spec.Workload.Manifests = append(mw.Spec.Workload.Manifests[:1], spec(names...).Workload.Manifests...)
+			Expect(c.Update(ctx, mw)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(ocmworkv1.AddToScheme(scheme)).To(Succeed())
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+			mw := &ocmworkv1.ManifestWork{
+				ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+				Spec:       *spec("vrg", "ns"),
+			}
+
+			conflicts = 0
+			fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(mw).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+						opts ...client.PatchOption,
+					) error {
+						if conflicts > 0 {
+							conflicts--
+							update(ctx, c, "ns", "concurrent")
+
+							return k8serrors.NewConflict(schema.GroupResource{Resource: "manifestworks"}, key.Name, nil)
+						}
+
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).Build()
+
+			mwu = &util.MWUtil{Client: fakeClient, APIReader: fakeClient, Ctx: context.TODO(), Log: logr.Discard()}
+
+			cached = mw.DeepCopy()
+		})
+
+		vrg := &rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "vrg"},
+			Spec:       rmn.VolumeReplicationGroupSpec{ReplicationState: rmn.Secondary},
+		}
+
+		expectVRGWithManifests := func(names ...string) {
+			mw := &ocmworkv1.ManifestWork{}
+			Expect(fakeClient.Get(context.TODO(), key, mw)).To(Succeed())
+
+			found, err := util.ExtractVRGFromManifestWork(mw)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.Spec.ReplicationState).To(Equal(rmn.Secondary))
+
+			Expect(mw.Spec.Workload.Manifests[1:]).To(Equal(spec(names...).Workload.Manifests))
+			Expect(cached.Spec).To(Equal(mw.Spec))
+		}
+
+		It("keeps the manifests of the ManifestWork updated since it was cached", func() {
+			update(context.TODO(), fakeClient, "ns", "other")
+
+			Expect(mwu.UpdateVRGManifestWork(vrg, cached)).To(Succeed())
+			expectVRGWithManifests("ns", "other")
+		})
+
+		It("recomputes the patch from the current ManifestWork on a conflict", func() {
+			conflicts = 1
+
+			Expect(mwu.UpdateVRGManifestWork(vrg, cached)).To(Succeed())
+			Expect(conflicts).To(BeZero())
+			expectVRGWithManifests("ns", "concurrent")
+		})
+	})
+})
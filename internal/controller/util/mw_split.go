@@ -0,0 +1,499 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ManifestWorkSizeLimit is the maximum encoded size of the manifests of a ManifestWork accepted by OCM
+	ManifestWorkSizeLimit = 500 * 1024
+
+	// ManifestWorkGroupLabel is set on all the ManifestWorks a ManifestWork was split into, with the name of the
+	// first ManifestWork, or a hash of it if the name is not a valid label value
+	ManifestWorkGroupLabel = "ramendr.openshift.io/manifestwork-group"
+
+	// ManifestWorkPartsAnnotation is set on the first of the ManifestWorks a ManifestWork was split into, with the
+	// number of ManifestWorks. The remaining ManifestWorks are named by ManifestWorkPartName.
+	ManifestWorkPartsAnnotation = "ramendr.openshift.io/manifestwork-parts"
+)
+
+// ManifestWorkTooLargeError is returned when a single manifest exceeds ManifestWorkSizeLimit, and hence the
+// ManifestWork cannot be split to fit the limit
+type ManifestWorkTooLargeError struct {
+	Name     string
+	Manifest int
+	Size     int
+	Limit    int
+}
+
+func (e ManifestWorkTooLargeError) Error() string {
+	return fmt.Sprintf("manifest %d of ManifestWork %s is %d bytes, exceeding the ManifestWork size limit of %d bytes",
+		e.Manifest, e.Name, e.Size, e.Limit)
+}
+
+// ManifestWorkPartName returns the name of part index of a ManifestWork split into multiple ManifestWorks. The first
+// part retains the ManifestWork name.
+func ManifestWorkPartName(name string, index int) string {
+	if index == 0 {
+		return name
+	}
+
+	return fmt.Sprintf("%s-%d", name, index)
+}
+
+// ManifestWorkParts returns the number of ManifestWorks the ManifestWork was split into, 1 if it was not split
+func ManifestWorkParts(mw *ocmworkv1.ManifestWork) int {
+	parts, err := strconv.Atoi(mw.GetAnnotations()[ManifestWorkPartsAnnotation])
+	if err != nil || parts < 1 {
+		return 1
+	}
+
+	return parts
+}
+
+func manifestWorkGroupLabelValue(name string) string {
+	if len(validation.IsValidLabelValue(name)) == 0 {
+		return name
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
+
+func manifestSize(manifest ocmworkv1.Manifest) (int, error) {
+	if manifest.Raw != nil {
+		return len(manifest.Raw), nil
+	}
+
+	raw, err := json.Marshal(manifest.Object)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	return len(raw), nil
+}
+
+// SplitManifests splits manifests, in order, into the fewest groups whose encoded size does not exceed limit, by
+// starting a new group whenever the next manifest would not fit. Returns the index of a manifest that exceeds the
+// limit by itself with a ManifestWorkTooLargeError.
+func SplitManifests(manifests []ocmworkv1.Manifest, limit int) ([][]ocmworkv1.Manifest, error) {
+	groups := [][]ocmworkv1.Manifest{{}}
+	groupSize := 0
+
+	for idx, manifest := range manifests {
+		size, err := manifestSize(manifest)
+		if err != nil {
+			return nil, err
+		}
+
+		if size > limit {
+			return nil, ManifestWorkTooLargeError{Manifest: idx, Size: size, Limit: limit}
+		}
+
+		if groupSize+size > limit {
+			groups = append(groups, []ocmworkv1.Manifest{})
+			groupSize = 0
+		}
+
+		groups[len(groups)-1] = append(groups[len(groups)-1], manifest)
+		groupSize += size
+	}
+
+	return groups, nil
+}
+
+// SplitManifestsStable splits manifests like SplitManifests, but keeps each manifest in the group of current, the
+// groups the manifests were split into before, that it is in, as long as it fits, so that updating the manifests
+// does not move them between groups. Manifests that no longer fit their group, and new manifests, are added to the
+// last group, or to new groups, and a group left empty is replaced by the last group. Manifests that fit a single
+// group are not split.
+func SplitManifestsStable(manifests []ocmworkv1.Manifest, current [][]ocmworkv1.Manifest, limit int,
+) ([][]ocmworkv1.Manifest, error) {
+	groups, err := SplitManifests(manifests, limit)
+	if err != nil || len(groups) == 1 || len(current) == 0 {
+		return groups, err
+	}
+
+	currentGroups := map[string]int{}
+
+	for idx, group := range current {
+		for _, manifest := range group {
+			currentGroups[manifestIdentity(manifest)] = idx
+		}
+	}
+
+	groups = make([][]ocmworkv1.Manifest, len(current))
+	groupSizes := make([]int, len(current))
+	pending := []ocmworkv1.Manifest{}
+	pendingSizes := []int{}
+
+	for _, manifest := range manifests {
+		size, err := manifestSize(manifest)
+		if err != nil {
+			return nil, err
+		}
+
+		if idx, ok := currentGroups[manifestIdentity(manifest)]; ok && groupSizes[idx]+size <= limit {
+			groups[idx] = append(groups[idx], manifest)
+			groupSizes[idx] += size
+
+			continue
+		}
+
+		pending = append(pending, manifest)
+		pendingSizes = append(pendingSizes, size)
+	}
+
+	for idx, manifest := range pending {
+		if groupSizes[len(groups)-1]+pendingSizes[idx] > limit {
+			groups = append(groups, []ocmworkv1.Manifest{})
+			groupSizes = append(groupSizes, 0)
+		}
+
+		groups[len(groups)-1] = append(groups[len(groups)-1], manifest)
+		groupSizes[len(groups)-1] += pendingSizes[idx]
+	}
+
+	for idx := 0; idx < len(groups); {
+		last := len(groups) - 1
+
+		switch {
+		case len(groups[last]) == 0:
+			groups = groups[:last]
+		case len(groups[idx]) == 0:
+			groups[idx] = groups[last]
+			groups = groups[:last]
+		default:
+			idx++
+		}
+	}
+
+	return groups, nil
+}
+
+// manifestIdentity returns the identity of the resource of manifest, as reported in the resource status of a
+// ManifestWork, or the encoded manifest if it cannot be decoded
+func manifestIdentity(manifest ocmworkv1.Manifest) string {
+	raw := manifest.Raw
+	if raw == nil {
+		raw, _ = json.Marshal(manifest.Object)
+	}
+
+	object := metav1.PartialObjectMetadata{}
+	if err := json.Unmarshal(raw, &object); err != nil || object.Kind == "" {
+		return string(raw)
+	}
+
+	groupVersion, _ := schema.ParseGroupVersion(object.APIVersion)
+
+	return resourceIdentity(groupVersion.Group, object.Kind, object.Namespace, object.Name)
+}
+
+func resourceIdentity(group, kind, namespace, name string) string {
+	return strings.Join([]string{group, kind, namespace, name}, "/")
+}
+
+// manifestWorkSplit splits mw into ManifestWorks whose manifests do not exceed ManifestWorkSizeLimit, keeping the
+// manifests in the ManifestWorks of current, the ManifestWorks mw was split into before, they are in. Returns mw as
+// is if it need not be split.
+func manifestWorkSplit(mw *ocmworkv1.ManifestWork, current []*ocmworkv1.ManifestWork,
+) ([]*ocmworkv1.ManifestWork, error) {
+	currentManifests := make([][]ocmworkv1.Manifest, len(current))
+
+	for idx, part := range current {
+		if part != nil {
+			currentManifests[idx] = part.Spec.Workload.Manifests
+		}
+	}
+
+	groups, err := SplitManifestsStable(mw.Spec.Workload.Manifests, currentManifests, ManifestWorkSizeLimit)
+	if err != nil {
+		return nil, manifestWorkSplitError(mw, err)
+	}
+
+	if len(groups) == 1 {
+		return []*ocmworkv1.ManifestWork{mw}, nil
+	}
+
+	mws := make([]*ocmworkv1.ManifestWork, len(groups))
+
+	for idx, manifests := range groups {
+		part := mw.DeepCopy()
+		part.Name = ManifestWorkPartName(mw.Name, idx)
+		part.Spec.Workload.Manifests = manifests
+
+		AddLabel(part, ManifestWorkGroupLabel, manifestWorkGroupLabelValue(mw.Name))
+
+		if idx == 0 {
+			AddAnnotation(part, ManifestWorkPartsAnnotation, strconv.Itoa(len(groups)))
+		}
+
+		mws[idx] = part
+	}
+
+	return mws, nil
+}
+
+func manifestWorkSplitError(mw *ocmworkv1.ManifestWork, err error) error {
+	if tooLarge, ok := err.(ManifestWorkTooLargeError); ok {
+		tooLarge.Name = mw.Namespace + "/" + mw.Name
+
+		return tooLarge
+	}
+
+	return fmt.Errorf("ManifestWork %s/%s: %w", mw.Namespace, mw.Name, err)
+}
+
+// manifestWorkPartsGet returns found, the first ManifestWork, and the remaining ManifestWorks it was split into, nil
+// for those not found
+func (mwu *MWUtil) manifestWorkPartsGet(found *ocmworkv1.ManifestWork) ([]*ocmworkv1.ManifestWork, error) {
+	if found == nil {
+		return nil, nil
+	}
+
+	parts := []*ocmworkv1.ManifestWork{found}
+
+	for idx := 1; idx < ManifestWorkParts(found); idx++ {
+		part := &ocmworkv1.ManifestWork{}
+		key := types.NamespacedName{Name: ManifestWorkPartName(found.Name, idx), Namespace: found.Namespace}
+
+		if err := mwu.Client.Get(mwu.Ctx, key, part); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to fetch ManifestWork %s: %w", key, err)
+			}
+
+			part = nil
+		}
+
+		parts = append(parts, part)
+	}
+
+	return parts, nil
+}
+
+// manifestWorkMoves returns, for each of the current ManifestWorks, the identities of the manifests that move from
+// it to each of the desired ManifestWorks
+func manifestWorkMoves(current, desired []*ocmworkv1.ManifestWork) map[int]map[int]sets.Set[string] {
+	desiredParts := map[string]int{}
+
+	for idx, part := range desired {
+		for _, manifest := range part.Spec.Workload.Manifests {
+			desiredParts[manifestIdentity(manifest)] = idx
+		}
+	}
+
+	moves := map[int]map[int]sets.Set[string]{}
+
+	for from, part := range current {
+		if part == nil {
+			continue
+		}
+
+		for _, manifest := range part.Spec.Workload.Manifests {
+			identity := manifestIdentity(manifest)
+
+			to, ok := desiredParts[identity]
+			if !ok || to == from {
+				continue
+			}
+
+			if moves[from] == nil {
+				moves[from] = map[int]sets.Set[string]{}
+			}
+
+			if moves[from][to] == nil {
+				moves[from][to] = sets.New[string]()
+			}
+
+			moves[from][to].Insert(identity)
+		}
+	}
+
+	return moves
+}
+
+// manifestWorkMovesApplied returns whether the manifests moving from a ManifestWork are applied by the current
+// ManifestWorks they move to. The work agent deletes the resources of the manifests removed from a ManifestWork,
+// unless another ManifestWork applied them too, so a ManifestWork is not to release its manifests before then.
+func manifestWorkMovesApplied(current []*ocmworkv1.ManifestWork, moves map[int]sets.Set[string]) bool {
+	for to, identities := range moves {
+		if to >= len(current) || !manifestsApplied(current[to], identities) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// manifestsApplied returns whether mw applied the resources with identities, as reported in its resource status
+func manifestsApplied(mw *ocmworkv1.ManifestWork, identities sets.Set[string]) bool {
+	if mw == nil {
+		return false
+	}
+
+	applied := sets.New[string]()
+
+	for _, manifest := range mw.Status.ResourceStatus.Manifests {
+		if meta.IsStatusConditionTrue(manifest.Conditions, ocmworkv1.ManifestApplied) {
+			resource := manifest.ResourceMeta
+			applied.Insert(resourceIdentity(resource.Group, resource.Kind, resource.Namespace, resource.Name))
+		}
+	}
+
+	return applied.IsSuperset(identities)
+}
+
+// deleteManifestWorkParts deletes the parts, from index from onwards, of the ManifestWork found split into parts
+func (mwu *MWUtil) deleteManifestWorkParts(found *ocmworkv1.ManifestWork, from int) error {
+	for idx := max(from, 1); idx < ManifestWorkParts(found); idx++ {
+		if err := mwu.DeleteManifestWork(ManifestWorkPartName(found.Name, idx), found.Namespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// patchManifestWorkParts updates the group label and parts annotation of the found first ManifestWork to those of
+// desired, removing them if desired was not split
+func (mwu *MWUtil) patchManifestWorkParts(found, desired *ocmworkv1.ManifestWork) error {
+	valueOrNil := func(values map[string]string, key string) interface{} {
+		if value, ok := values[key]; ok {
+			return value
+		}
+
+		return nil
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{
+				ManifestWorkGroupLabel: valueOrNil(desired.GetLabels(), ManifestWorkGroupLabel),
+			},
+			"annotations": map[string]interface{}{
+				ManifestWorkPartsAnnotation: valueOrNil(desired.GetAnnotations(), ManifestWorkPartsAnnotation),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal ManifestWork %s/%s patch: %w", found.Namespace, found.Name, err)
+	}
+
+	if err := mwu.Client.Patch(mwu.Ctx, found, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return fmt.Errorf("failed to update ManifestWork %s/%s parts: %w", found.Namespace, found.Name, err)
+	}
+
+	return nil
+}
+
+// ManifestWorkGroupGet returns mw and the remaining ManifestWorks it was split into, if any
+func (mwu *MWUtil) ManifestWorkGroupGet(mw *ocmworkv1.ManifestWork) ([]*ocmworkv1.ManifestWork, error) {
+	mws := []*ocmworkv1.ManifestWork{mw}
+
+	for idx := 1; idx < ManifestWorkParts(mw); idx++ {
+		part, err := mwu.FindManifestWork(ManifestWorkPartName(mw.Name, idx), mw.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		mws = append(mws, part)
+	}
+
+	return mws, nil
+}
+
+// ManifestWorkGroupStatus reassembles the status of the ManifestWorks a ManifestWork was split into. The resource
+// statuses are concatenated, with their ordinals offset to those of the manifests before splitting. The Applied and
+// Available conditions are true only if true for every ManifestWork, and the Degraded condition is true if true for
+// any ManifestWork.
+func ManifestWorkGroupStatus(mws []*ocmworkv1.ManifestWork) ocmworkv1.ManifestWorkStatus {
+	status := ocmworkv1.ManifestWorkStatus{}
+
+	offset := 0
+
+	for _, mw := range mws {
+		for _, manifest := range mw.Status.ResourceStatus.Manifests {
+			manifest.ResourceMeta.Ordinal += int32(offset)
+			status.ResourceStatus.Manifests = append(status.ResourceStatus.Manifests, manifest)
+		}
+
+		offset += len(mw.Spec.Workload.Manifests)
+	}
+
+	for _, conditionType := range []string{ocmworkv1.WorkApplied, ocmworkv1.WorkAvailable} {
+		conditionsMerge(&status.Conditions, mws, conditionType, metav1.ConditionFalse)
+	}
+
+	conditionsMerge(&status.Conditions, mws, ocmworkv1.WorkDegraded, metav1.ConditionTrue)
+
+	return status
+}
+
+// conditionsMerge sets the conditionType condition from the first ManifestWork whose condition has status
+// dominant, or is missing, else from the first ManifestWork
+func conditionsMerge(conditions *[]metav1.Condition, mws []*ocmworkv1.ManifestWork, conditionType string,
+	dominant metav1.ConditionStatus,
+) {
+	var merged *metav1.Condition
+
+	for _, mw := range mws {
+		condition := meta.FindStatusCondition(mw.Status.Conditions, conditionType)
+		if condition == nil {
+			if dominant == metav1.ConditionTrue {
+				continue
+			}
+
+			return
+		}
+
+		if merged == nil || condition.Status == dominant {
+			merged = condition
+		}
+
+		if condition.Status == dominant {
+			break
+		}
+	}
+
+	if merged != nil {
+		*conditions = append(*conditions, *merged)
+	}
+}
+
+// IsManifestWorkGroupInAppliedState returns true if mw, and the remaining ManifestWorks it was split into, if any,
+// are applied
+func (mwu *MWUtil) IsManifestWorkGroupInAppliedState(mw *ocmworkv1.ManifestWork) (bool, error) {
+	if ManifestWorkParts(mw) == 1 {
+		return IsManifestInAppliedState(mw), nil
+	}
+
+	mws, err := mwu.ManifestWorkGroupGet(mw)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return IsManifestInAppliedState(&ocmworkv1.ManifestWork{Status: ManifestWorkGroupStatus(mws)}), nil
+}
@@ -0,0 +1,364 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ocmworkv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+func manifestOfSize(size int) ocmworkv1.Manifest {
+	return ocmworkv1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(strings.Repeat("x", size))}}
+}
+
+var _ = Describe("SplitManifests", func() {
+	It("does not split manifests within the limit", func() {
+		groups, err := util.SplitManifests([]ocmworkv1.Manifest{manifestOfSize(40), manifestOfSize(60)}, 100)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(groups).To(HaveLen(1))
+		Expect(groups[0]).To(HaveLen(2))
+	})
+
+	It("splits manifests in order once the limit is exceeded", func() {
+		groups, err := util.SplitManifests([]ocmworkv1.Manifest{
+			manifestOfSize(40), manifestOfSize(40), manifestOfSize(40), manifestOfSize(100),
+		}, 100)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(groups).To(HaveLen(3))
+		Expect(groups[0]).To(HaveLen(2))
+		Expect(groups[1]).To(HaveLen(1))
+		Expect(groups[2]).To(HaveLen(1))
+	})
+
+	It("fails for a manifest exceeding the limit by itself", func() {
+		_, err := util.SplitManifests([]ocmworkv1.Manifest{manifestOfSize(40), manifestOfSize(101)}, 100)
+		Expect(err).To(MatchError(util.ManifestWorkTooLargeError{Manifest: 1, Size: 101, Limit: 100}))
+	})
+})
+
+// namedManifest returns a ConfigMap manifest named name, of about size bytes
+func namedManifest(name string, size int) ocmworkv1.Manifest {
+	raw, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"namespace": "app", "name": name},
+		"data":       map[string]string{"data": strings.Repeat("x", size)},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	return ocmworkv1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}}
+}
+
+var _ = Describe("SplitManifestsStable", func() {
+	names := func(groups [][]ocmworkv1.Manifest) [][]string {
+		groupNames := [][]string{}
+
+		for _, group := range groups {
+			manifestNames := []string{}
+
+			for _, manifest := range group {
+				object := metav1.PartialObjectMetadata{}
+				Expect(json.Unmarshal(manifest.Raw, &object)).To(Succeed())
+
+				manifestNames = append(manifestNames, object.Name)
+			}
+
+			groupNames = append(groupNames, manifestNames)
+		}
+
+		return groupNames
+	}
+
+	current := [][]ocmworkv1.Manifest{
+		{namedManifest("a", 300), namedManifest("b", 300)},
+		{namedManifest("c", 300)},
+	}
+
+	It("keeps manifests in their groups while they fit", func() {
+		groups, err := util.SplitManifestsStable([]ocmworkv1.Manifest{
+			namedManifest("c", 350), namedManifest("a", 300), namedManifest("b", 350),
+		}, current, 1000)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names(groups)).To(Equal([][]string{{"a", "b"}, {"c"}}))
+	})
+
+	It("moves manifests that no longer fit their group, and new manifests, to the last or new groups", func() {
+		groups, err := util.SplitManifestsStable([]ocmworkv1.Manifest{
+			namedManifest("a", 600), namedManifest("b", 300), namedManifest("c", 300), namedManifest("d", 600),
+		}, current, 1000)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names(groups)).To(Equal([][]string{{"a"}, {"c", "b"}, {"d"}}))
+	})
+
+	It("replaces a group left empty by the last group", func() {
+		groups, err := util.SplitManifestsStable([]ocmworkv1.Manifest{
+			namedManifest("c", 600), namedManifest("d", 600),
+		}, current, 1000)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names(groups)).To(Equal([][]string{{"d"}, {"c"}}))
+	})
+})
+
+var _ = Describe("Split ManifestWork update", func() {
+	const (
+		cluster = "cluster1"
+		kib     = 1024
+	)
+
+	var (
+		fakeClient client.Client
+		mwu        *util.MWUtil
+		retained   []string
+	)
+
+	manifestWork := func(manifests ...ocmworkv1.Manifest) *ocmworkv1.ManifestWork {
+		return &ocmworkv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cluster, Name: "mw"},
+			Spec: ocmworkv1.ManifestWorkSpec{
+				Workload: ocmworkv1.ManifestsTemplate{Manifests: manifests},
+			},
+		}
+	}
+
+	// parts returns the names of the manifests of each of the ManifestWorks on the server, by ManifestWork name
+	parts := func(ctx context.Context, c client.Client) map[string][]string {
+		mws := &ocmworkv1.ManifestWorkList{}
+		Expect(c.List(ctx, mws, client.InNamespace(cluster))).To(Succeed())
+
+		manifestNames := map[string][]string{}
+
+		for _, mw := range mws.Items {
+			names := []string{}
+
+			for _, manifest := range mw.Spec.Workload.Manifests {
+				object := metav1.PartialObjectMetadata{}
+				Expect(json.Unmarshal(manifest.Raw, &object)).To(Succeed())
+
+				names = append(names, object.Name)
+			}
+
+			manifestNames[mw.Name] = names
+		}
+
+		return manifestNames
+	}
+
+	// expectRetained fails unless every retained manifest is in at least one ManifestWork
+	expectRetained := func(ctx context.Context, c client.Client) {
+		all := []string{}
+		for _, names := range parts(ctx, c) {
+			all = append(all, names...)
+		}
+
+		Expect(all).To(ContainElements(retained), "a manifest is absent from every ManifestWork part")
+	}
+
+	// apply reports the manifests of every ManifestWork applied, as the work agent would
+	apply := func() {
+		mws := &ocmworkv1.ManifestWorkList{}
+		Expect(fakeClient.List(context.TODO(), mws, client.InNamespace(cluster))).To(Succeed())
+
+		for idx := range mws.Items {
+			mw := &mws.Items[idx]
+			mw.Status.ResourceStatus.Manifests = nil
+
+			for ordinal, manifest := range mw.Spec.Workload.Manifests {
+				object := metav1.PartialObjectMetadata{}
+				Expect(json.Unmarshal(manifest.Raw, &object)).To(Succeed())
+
+				mw.Status.ResourceStatus.Manifests = append(mw.Status.ResourceStatus.Manifests,
+					ocmworkv1.ManifestCondition{
+						ResourceMeta: ocmworkv1.ManifestResourceMeta{
+							Ordinal: int32(ordinal), Version: "v1", Kind: "ConfigMap",
+							Namespace: object.Namespace, Name: object.Name,
+						},
+						Conditions: []metav1.Condition{{
+							Type: ocmworkv1.ManifestApplied, Status: metav1.ConditionTrue, Reason: "Applied",
+						}},
+					})
+			}
+
+			Expect(fakeClient.Status().Update(context.TODO(), mw)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(ocmworkv1.AddToScheme(scheme)).To(Succeed())
+
+		retained = nil
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&ocmworkv1.ManifestWork{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object,
+					opts ...client.CreateOption,
+				) error {
+					err := c.Create(ctx, obj, opts...)
+					expectRetained(ctx, c)
+
+					return err
+				},
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object,
+					opts ...client.UpdateOption,
+				) error {
+					err := c.Update(ctx, obj, opts...)
+					expectRetained(ctx, c)
+
+					return err
+				},
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+					opts ...client.PatchOption,
+				) error {
+					err := c.Patch(ctx, obj, patch, opts...)
+					expectRetained(ctx, c)
+
+					return err
+				},
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object,
+					opts ...client.DeleteOption,
+				) error {
+					err := c.Delete(ctx, obj, opts...)
+					expectRetained(ctx, c)
+
+					return err
+				},
+			}).Build()
+
+		mwu = &util.MWUtil{Client: fakeClient, APIReader: fakeClient, Ctx: context.TODO(), Log: logr.Discard()}
+
+		_, err := mwu.CreateOrUpdateManifestWork(manifestWork(
+			namedManifest("a", 200*kib), namedManifest("b", 200*kib), namedManifest("c", 200*kib)), cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parts(context.TODO(), fakeClient)).To(Equal(map[string][]string{
+			"mw": {"a", "b"}, "mw-1": {"c"},
+		}))
+		apply()
+	})
+
+	It("releases a manifest moved to another part only once that part applied it", func() {
+		retained = []string{"a", "b", "c"}
+		update := manifestWork(namedManifest("a", 350*kib), namedManifest("b", 200*kib), namedManifest("c", 200*kib))
+
+		_, err := mwu.CreateOrUpdateManifestWork(update.DeepCopy(), cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parts(context.TODO(), fakeClient)).To(Equal(map[string][]string{
+			"mw": {"a", "b"}, "mw-1": {"c", "b"},
+		}))
+
+		apply()
+
+		_, err = mwu.CreateOrUpdateManifestWork(update.DeepCopy(), cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parts(context.TODO(), fakeClient)).To(Equal(map[string][]string{
+			"mw": {"a"}, "mw-1": {"b", "c"},
+		}))
+	})
+
+	It("deletes a part whose manifests moved only once the parts they moved to applied them", func() {
+		retained = []string{"a", "c"}
+		update := manifestWork(namedManifest("a", 100*kib), namedManifest("c", 100*kib))
+
+		_, err := mwu.CreateOrUpdateManifestWork(update.DeepCopy(), cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parts(context.TODO(), fakeClient)).To(Equal(map[string][]string{
+			"mw": {"a", "c"}, "mw-1": {"c"},
+		}))
+
+		apply()
+
+		_, err = mwu.CreateOrUpdateManifestWork(update.DeepCopy(), cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parts(context.TODO(), fakeClient)).To(Equal(map[string][]string{"mw": {"a", "c"}}))
+
+		mw := &ocmworkv1.ManifestWork{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster, Name: "mw"}, mw)).To(Succeed())
+		Expect(util.ManifestWorkParts(mw)).To(Equal(1))
+	})
+})
+
+var _ = Describe("UpdateVRGManifestWork", func() {
+	It("fails for a VRG exceeding the ManifestWork size limit", func() {
+		mwu := &util.MWUtil{Ctx: context.TODO(), Log: logr.Discard()}
+		mw := &ocmworkv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "cluster1", Name: "vrg-mw"},
+			Spec: ocmworkv1.ManifestWorkSpec{
+				Workload: ocmworkv1.ManifestsTemplate{Manifests: []ocmworkv1.Manifest{manifestOfSize(1)}},
+			},
+		}
+		vrg := &rmn.VolumeReplicationGroup{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "app",
+			Name:        "vrg",
+			Annotations: map[string]string{"large": strings.Repeat("x", util.ManifestWorkSizeLimit)},
+		}}
+
+		err := mwu.UpdateVRGManifestWork(vrg, mw)
+
+		var tooLargeErr util.ManifestWorkTooLargeError
+		Expect(errors.As(err, &tooLargeErr)).To(BeTrue(), "%v", err)
+		Expect(tooLargeErr.Name).To(Equal("cluster1/vrg-mw"))
+		Expect(mw.Spec.Workload.Manifests).To(Equal([]ocmworkv1.Manifest{manifestOfSize(1)}))
+	})
+})
+
+var _ = Describe("ManifestWorkGroupStatus", func() {
+	manifestWork := func(manifests int, applied, degraded metav1.ConditionStatus) *ocmworkv1.ManifestWork {
+		mw := &ocmworkv1.ManifestWork{}
+
+		for idx := range manifests {
+			mw.Spec.Workload.Manifests = append(mw.Spec.Workload.Manifests, manifestOfSize(1))
+			mw.Status.ResourceStatus.Manifests = append(mw.Status.ResourceStatus.Manifests,
+				ocmworkv1.ManifestCondition{ResourceMeta: ocmworkv1.ManifestResourceMeta{Ordinal: int32(idx)}})
+		}
+
+		mw.Status.Conditions = []metav1.Condition{
+			{Type: ocmworkv1.WorkApplied, Status: applied},
+			{Type: ocmworkv1.WorkAvailable, Status: applied},
+			{Type: ocmworkv1.WorkDegraded, Status: degraded},
+		}
+
+		return mw
+	}
+
+	It("offsets resource status ordinals of later parts", func() {
+		status := util.ManifestWorkGroupStatus([]*ocmworkv1.ManifestWork{
+			manifestWork(2, metav1.ConditionTrue, metav1.ConditionFalse),
+			manifestWork(1, metav1.ConditionTrue, metav1.ConditionFalse),
+		})
+		Expect(status.ResourceStatus.Manifests).To(HaveLen(3))
+		Expect(status.ResourceStatus.Manifests[2].ResourceMeta.Ordinal).To(BeEquivalentTo(2))
+		Expect(util.IsManifestInAppliedState(&ocmworkv1.ManifestWork{Status: status})).To(BeTrue())
+	})
+
+	It("is not applied unless every part is applied", func() {
+		status := util.ManifestWorkGroupStatus([]*ocmworkv1.ManifestWork{
+			manifestWork(1, metav1.ConditionTrue, metav1.ConditionFalse),
+			manifestWork(1, metav1.ConditionFalse, metav1.ConditionFalse),
+		})
+		Expect(util.IsManifestInAppliedState(&ocmworkv1.ManifestWork{Status: status})).To(BeFalse())
+	})
+
+	It("is degraded if any part is degraded", func() {
+		status := util.ManifestWorkGroupStatus([]*ocmworkv1.ManifestWork{
+			manifestWork(1, metav1.ConditionTrue, metav1.ConditionFalse),
+			manifestWork(1, metav1.ConditionTrue, metav1.ConditionTrue),
+		})
+		Expect(util.IsManifestInAppliedState(&ocmworkv1.ManifestWork{Status: status})).To(BeFalse())
+	})
+})
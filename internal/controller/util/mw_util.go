@@ -8,19 +8,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 
 	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/api/csiaddons/v1alpha1"
 	"github.com/go-logr/logr"
 	recipev1 "github.com/ramendr/recipe/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/util/retry"
 	ocmworkv1 "open-cluster-management.io/api/work/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -43,18 +45,20 @@ const (
 	ManifestWorkNameTypeFormat         string = "%s-mw"
 
 	// ManifestWork Types
-	MWTypeVRG       string = "vrg"
-	MWTypeNS        string = "ns"
-	MWTypeNF        string = "nf"
-	MWTypeMMode     string = "mmode"
-	MWTypeSClass    string = "sc"
-	MWTypeNFClass   string = "nfc"
-	MWTypeVSClass   string = "vsc"
-	MWTypeVGSClass  string = "vgsc"
-	MWTypeVRClass   string = "vrc"
-	MWTypeVGRClass  string = "vgrc"
-	MWTypeDRCConfig string = "drcconfig"
-	MWTypeRecipe    string = "recipe"
+	MWTypeVRG        string = "vrg"
+	MWTypeNS         string = "ns"
+	MWTypeNF         string = "nf"
+	MWTypeMMode      string = "mmode"
+	MWTypeSClass     string = "sc"
+	MWTypeNFClass    string = "nfc"
+	MWTypeVSClass    string = "vsc"
+	MWTypeVGSClass   string = "vgsc"
+	MWTypeVRClass    string = "vrc"
+	MWTypeVGRClass   string = "vgrc"
+	MWTypeDRCConfig  string = "drcconfig"
+	MWTypeRecipe     string = "recipe"
+	MWTypeNetPol     string = "netpol"
+	MWTypeFenceProbe string = "fenceprobe"
 )
 
 type MWUtil struct {
@@ -64,6 +68,26 @@ type MWUtil struct {
 	Log             logr.Logger
 	InstName        string
 	TargetNamespace string
+
+	// PropagatedLabels and PropagatedAnnotations are copied onto the ManifestWorks, and their manifests, if not
+	// already set, see SetPropagatedMetadata
+	PropagatedLabels      map[string]string
+	PropagatedAnnotations map[string]string
+
+	// ReapplyToken is set on the ManifestWorks, and their manifests, to force reapplying them, see SetReapplyToken
+	ReapplyToken string
+
+	// SplitManifestWorks are the number of ManifestWorks, by ManifestWork name, that ManifestWorks created or
+	// updated were split into as they exceeded ManifestWorkSizeLimit
+	SplitManifestWorks map[string]int
+
+	// StatusFeedback requests the status feedback of NetworkFences and VRGs from ManifestWorks delivering them, see
+	// SetStatusFeedback
+	StatusFeedback bool
+
+	// ExportDirectory, if set, is the directory the manifests of the ManifestWorks are exported into, instead of
+	// creating the ManifestWorks, see SetManifestTransport
+	ExportDirectory string
 }
 
 func ManifestWorkName(name, namespace, mwType string) string {
@@ -89,6 +113,10 @@ func (mwu *MWUtil) FindManifestWork(mwName, managedCluster string) (*ocmworkv1.M
 		return nil, fmt.Errorf("invalid cluster for MW %s", mwName)
 	}
 
+	if mwu.exporting() {
+		return mwu.findExportedManifestWork(mwName, managedCluster)
+	}
+
 	mw := &ocmworkv1.ManifestWork{}
 
 	err := mwu.Client.Get(mwu.Ctx, types.NamespacedName{Name: mwName, Namespace: managedCluster}, mw)
@@ -142,6 +170,17 @@ func (mwu *MWUtil) CreateOrUpdateVRGManifestWork(
 func (mwu *MWUtil) generateVRGManifestWork(name, namespace, homeCluster string,
 	vrg rmn.VolumeReplicationGroup, annotations map[string]string,
 ) (*ocmworkv1.ManifestWork, error) {
+	var manifestConfigs []ocmworkv1.ManifestConfigOption
+
+	if mwu.StatusFeedback {
+		manifestConfig, err := vrgStatusFeedbackPrepare(&vrg)
+		if err != nil {
+			return nil, err
+		}
+
+		manifestConfigs = append(manifestConfigs, manifestConfig)
+	}
+
 	vrgClientManifest, err := mwu.generateVRGManifest(vrg)
 	if err != nil {
 		mwu.Log.Error(err, "failed to generate VolumeReplicationGroup manifest")
@@ -151,11 +190,14 @@ func (mwu *MWUtil) generateVRGManifestWork(name, namespace, homeCluster string,
 
 	manifests := []ocmworkv1.Manifest{*vrgClientManifest}
 
-	return mwu.newManifestWork(
+	mw := mwu.newManifestWork(
 		fmt.Sprintf(ManifestWorkNameFormat, name, namespace, MWTypeVRG),
 		homeCluster,
 		map[string]string{},
-		manifests, annotations), nil
+		manifests, annotations)
+	mw.Spec.ManifestConfigs = manifestConfigs
+
+	return mw, nil
 }
 
 func (mwu *MWUtil) generateVRGManifest(vrg rmn.VolumeReplicationGroup) (*ocmworkv1.Manifest, error) {
@@ -189,13 +231,17 @@ func (mwu *MWUtil) generateMModeManifestWork(name, cluster string,
 
 	manifests := []ocmworkv1.Manifest{*mModeManifest}
 
-	return mwu.newManifestWork(
+	mw := mwu.newManifestWork(
 		fmt.Sprintf(ManifestWorkNameFormatClusterScope, name, MWTypeMMode),
 		cluster,
 		map[string]string{
 			MModesLabel: "",
 		},
-		manifests, annotations), nil
+		manifests, annotations)
+
+	ActionTraceAnnotate(mw, ActionTraceFromAnnotations(&mMode))
+
+	return mw, nil
 }
 
 func (mwu *MWUtil) generateMModeManifest(mMode rmn.MaintenanceMode) (*ocmworkv1.Manifest, error) {
@@ -206,15 +252,12 @@ func (mwu *MWUtil) ListMModeManifests(cluster string) (*ocmworkv1.ManifestWorkLi
 	matchLabels := map[string]string{
 		MModesLabel: "",
 	}
-	listOptions := []client.ListOption{
-		client.InNamespace(cluster),
-		client.MatchingLabels(matchLabels),
-	}
 
-	mModeMWs := &ocmworkv1.ManifestWorkList{}
-	err := mwu.APIReader.List(context.TODO(), mModeMWs, listOptions...)
+	if mwu.exporting() {
+		return mwu.listExportedManifestWorks(cluster, labels.SelectorFromSet(matchLabels))
+	}
 
-	return mModeMWs, err
+	return mwu.ListManifestWorksByType(cluster, MWTypeMMode, client.MatchingLabels(matchLabels))
 }
 
 func ExtractMModeFromManifestWork(mw *ocmworkv1.ManifestWork) (*rmn.MaintenanceMode, error) {
@@ -280,11 +323,19 @@ func (mwu *MWUtil) generateNFManifestWork(name, homeCluster string,
 	// name: name of the resource received from higher layer
 	//       that wants to create the csiaddonsv1alpha1.NetworkFence resource
 	// type: type of the resource for this ManifestWork
-	return mwu.newManifestWork(
+	mw := mwu.newManifestWork(
 		fmt.Sprintf(ManifestWorkNameFormat, name, homeCluster, MWTypeNF),
 		homeCluster,
 		map[string]string{"app": "NF"},
-		manifests, annotations), nil
+		manifests, annotations)
+
+	ActionTraceAnnotate(mw, ActionTraceFromAnnotations(&nf))
+
+	if mwu.StatusFeedback {
+		mw.Spec.ManifestConfigs = []ocmworkv1.ManifestConfigOption{nfStatusFeedbackManifestConfig(&nf)}
+	}
+
+	return mw, nil
 }
 
 func (mwu *MWUtil) generateNFManifest(nf csiaddonsv1alpha1.NetworkFence) (*ocmworkv1.Manifest, error) {
@@ -347,7 +398,10 @@ func (mwu *MWUtil) IsManifestApplied(cluster, mwType string) bool {
 		return false
 	}
 
-	deployed := IsManifestInAppliedState(mw)
+	deployed, err := mwu.IsManifestWorkGroupInAppliedState(mw)
+	if err != nil {
+		return false
+	}
 
 	return deployed
 }
@@ -388,6 +442,28 @@ func (mwu *MWUtil) CreateOrUpdateNamespaceManifestWork(
 	return err
 }
 
+// CreateOrUpdateNetworkPolicyManifestWork delivers the NetworkPolicy to the managedClusterNamespace. Deleting the
+// ManifestWork deletes the NetworkPolicy.
+func (mwu *MWUtil) CreateOrUpdateNetworkPolicyManifestWork(
+	name, namespace, managedClusterNamespace string, policy *networkingv1.NetworkPolicy,
+) error {
+	manifest, err := mwu.GenerateManifest(policy)
+	if err != nil {
+		return err
+	}
+
+	manifestWork := mwu.newManifestWork(
+		ManifestWorkName(name, namespace, MWTypeNetPol),
+		managedClusterNamespace,
+		map[string]string{},
+		[]ocmworkv1.Manifest{*manifest},
+		map[string]string{})
+
+	_, err = mwu.createOrUpdateManifestWork(manifestWork, managedClusterNamespace)
+
+	return err
+}
+
 func Namespace(name string) *corev1.Namespace {
 	return &corev1.Namespace{
 		TypeMeta: metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
@@ -496,20 +572,24 @@ func (mwu *MWUtil) GetDrClusterManifestWork(clusterName string) (*ocmworkv1.Mani
 	return mw, nil
 }
 
+// DrClusterManifestWorkObjects returns the objects that the drcluster ManifestWork deploys to every managed cluster,
+// ahead of the objects appended to them by CreateOrUpdateDrClusterManifestWork
+func DrClusterManifestWorkObjects() []interface{} {
+	return []interface{}{
+		vrgClusterRole,
+		mModeClusterRole,
+		drClusterConfigRole,
+		networkFenceClusterRole,
+		recipeClusterRole,
+		networkPolicyClusterRole,
+	}
+}
+
 func (mwu *MWUtil) CreateOrUpdateDrClusterManifestWork(
 	clusterName string,
 	objectsToAppend []interface{}, annotations map[string]string,
 ) error {
-	objects := append(
-		[]interface{}{
-			vrgClusterRole,
-			mModeClusterRole,
-			drClusterConfigRole,
-			networkFenceClusterRole,
-			recipeClusterRole,
-		},
-		objectsToAppend...,
-	)
+	objects := append(DrClusterManifestWorkObjects(), objectsToAppend...)
 
 	manifests := make([]ocmworkv1.Manifest, len(objects))
 
@@ -537,6 +617,19 @@ func (mwu *MWUtil) CreateOrUpdateDrClusterManifestWork(
 	return err
 }
 
+// ManifestObjectKey returns the group, kind, namespace and name that identify object among the manifests of a
+// ManifestWork, or an empty string if object is not a Kubernetes object
+func ManifestObjectKey(object interface{}) string {
+	obj, ok := object.(client.Object)
+	if !ok {
+		return ""
+	}
+
+	gvk := obj.GetObjectKind().GroupVersionKind()
+
+	return strings.Join([]string{gvk.Group, gvk.Kind, obj.GetNamespace(), obj.GetName()}, "/")
+}
+
 var (
 	vrgClusterRole = &rbacv1.ClusterRole{
 		TypeMeta: metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
@@ -627,6 +720,24 @@ var (
 			},
 		},
 	}
+
+	networkPolicyClusterRole = &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "open-cluster-management:klusterlet-work-sa:agent:networkpolicy-edit",
+			Labels: map[string]string{
+				ClusterRoleAggregateLabel: "true",
+				CreatedByRamenLabel:       "true",
+			},
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{networkingv1.GroupName},
+				Resources: []string{"networkpolicies"},
+				Verbs:     []string{"create", "get", "list", "update", "delete"},
+			},
+		},
+	}
 )
 
 func (mwu *MWUtil) GenerateManifest(obj interface{}) (*ocmworkv1.Manifest, error) {
@@ -666,9 +777,132 @@ func (mwu *MWUtil) newManifestWork(name string, mcNamespace string,
 	return mw
 }
 
+// createOrUpdateManifestWork creates or updates mw, split into multiple ManifestWorks if its manifests exceed
+// ManifestWorkSizeLimit, and deletes ManifestWorks it was previously split into that are no longer required
 func (mwu *MWUtil) createOrUpdateManifestWork(
 	mw *ocmworkv1.ManifestWork,
 	managedClusternamespace string,
+) (ctrlutil.OperationResult, error) {
+	if err := mwu.propagateMetadataToManifestWork(mw); err != nil {
+		return ctrlutil.OperationResultNone, err
+	}
+
+	if mwu.exporting() {
+		return mwu.exportManifestWork(mw, managedClusternamespace)
+	}
+
+	found := &ocmworkv1.ManifestWork{}
+
+	err := mwu.Client.Get(mwu.Ctx, types.NamespacedName{Name: mw.Name, Namespace: managedClusternamespace}, found)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return ctrlutil.OperationResultNone, fmt.Errorf("failed to fetch ManifestWork %s/%s: %w",
+				managedClusternamespace, mw.Name, err)
+		}
+
+		found = nil
+	}
+
+	current, err := mwu.manifestWorkPartsGet(found)
+	if err != nil {
+		return ctrlutil.OperationResultNone, err
+	}
+
+	mws, err := manifestWorkSplit(mw, current)
+	if err != nil {
+		return ctrlutil.OperationResultNone, err
+	}
+
+	mwu.splitManifestWorkRecord(mw.Name, len(mws))
+
+	return mwu.createOrUpdateManifestWorkParts(found, current, mws, managedClusternamespace)
+}
+
+// createOrUpdateManifestWorkParts creates or updates the ManifestWorks mws that the ManifestWork found, split into
+// current before, is split into. The parts are written last to first, and a part that manifests move from is
+// updated, or deleted, only once the parts they move to applied them, so that the work agent does not delete their
+// resources in between. The parts held are updated as the ManifestWorks applying the moved manifests report it.
+func (mwu *MWUtil) createOrUpdateManifestWorkParts(found *ocmworkv1.ManifestWork, current,
+	mws []*ocmworkv1.ManifestWork, managedClusternamespace string,
+) (ctrlutil.OperationResult, error) {
+	// Record new parts on the first part before creating them, so that they are deleted along with it
+	if found != nil && len(mws) > ManifestWorkParts(found) {
+		if err := mwu.patchManifestWorkParts(found, mws[0]); err != nil {
+			return ctrlutil.OperationResultNone, err
+		}
+	}
+
+	moves := manifestWorkMoves(current, mws)
+	result := ctrlutil.OperationResultNone
+	held := false
+
+	for idx := len(mws) - 1; idx >= 0; idx-- {
+		if !manifestWorkMovesApplied(current, moves[idx]) {
+			mwu.Log.Info("Holding ManifestWork update until the manifests it releases are applied by other parts",
+				"name", mws[idx].Name, "namespace", managedClusternamespace)
+
+			held = true
+
+			continue
+		}
+
+		partResult, err := mwu.createOrUpdateManifestWorkPart(mws[idx], managedClusternamespace)
+		if err != nil {
+			return ctrlutil.OperationResultNone, err
+		}
+
+		if partResult != ctrlutil.OperationResultNone {
+			result = partResult
+		}
+	}
+
+	if found == nil || ManifestWorkParts(found) <= len(mws) {
+		return result, nil
+	}
+
+	for idx := len(mws); idx < len(current); idx++ {
+		held = held || !manifestWorkMovesApplied(current, moves[idx])
+	}
+
+	if held {
+		mwu.Log.Info("Holding ManifestWork parts deletion until the manifests they release are applied by other parts",
+			"name", found.Name, "namespace", managedClusternamespace)
+
+		return result, nil
+	}
+
+	// Delete the parts before dropping them from the first part, which bounds the parts to delete
+	if err := mwu.deleteManifestWorkParts(found, len(mws)); err != nil {
+		return ctrlutil.OperationResultNone, err
+	}
+
+	if err := mwu.patchManifestWorkParts(found, mws[0]); err != nil {
+		return ctrlutil.OperationResultNone, err
+	}
+
+	return ctrlutil.OperationResultUpdated, nil
+}
+
+func (mwu *MWUtil) splitManifestWorkRecord(name string, parts int) {
+	if parts == 1 {
+		delete(mwu.SplitManifestWorks, name)
+
+		return
+	}
+
+	if mwu.SplitManifestWorks == nil {
+		mwu.SplitManifestWorks = map[string]int{}
+	}
+
+	mwu.SplitManifestWorks[name] = parts
+
+	mwu.Log.Info("Split ManifestWork exceeding the size limit", "name", name, "parts", parts,
+		"limit", ManifestWorkSizeLimit)
+}
+
+func (mwu *MWUtil) createOrUpdateManifestWorkPart(
+	mw *ocmworkv1.ManifestWork,
+	managedClusternamespace string,
 ) (ctrlutil.OperationResult, error) {
 	key := types.NamespacedName{Name: mw.Name, Namespace: managedClusternamespace}
 	foundMW := &ocmworkv1.ManifestWork{}
@@ -679,6 +913,10 @@ func (mwu *MWUtil) createOrUpdateManifestWork(
 			return ctrlutil.OperationResultNone, fmt.Errorf("failed to fetch ManifestWork %s: %w", key, err)
 		}
 
+		if err := mwu.holdIfClusterUnavailable(managedClusternamespace); err != nil {
+			return ctrlutil.OperationResultNone, err
+		}
+
 		if err := mwu.Create(mwu.Ctx, mw); err != nil {
 			return ctrlutil.OperationResultNone, err
 		}
@@ -689,29 +927,93 @@ func (mwu *MWUtil) createOrUpdateManifestWork(
 	}
 
 	if !reflect.DeepEqual(foundMW.Spec, mw.Spec) {
-		err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-			if err := mwu.Client.Get(mwu.Ctx, key, foundMW); err != nil {
-				return err
-			}
-
-			mw.Spec.DeepCopyInto(&foundMW.Spec)
+		if err := mwu.holdIfClusterUnavailable(managedClusternamespace); err != nil {
+			return ctrlutil.OperationResultNone, err
+		}
 
-			return mwu.Client.Update(mwu.Ctx, foundMW)
+		patched, err := mwu.patchManifestWorkSpec(key, func(*ocmworkv1.ManifestWorkSpec) *ocmworkv1.ManifestWorkSpec {
+			return &mw.Spec
 		})
 		if err != nil {
 			return ctrlutil.OperationResultNone,
 				fmt.Errorf("failed to update ManifestWork %s: %w", key, err)
 		}
 
+		if !patched {
+			return ctrlutil.OperationResultNone, nil
+		}
+
 		mwu.Log.Info("Updated ManifestWork", "name", mw.Name, "namespace", foundMW.Namespace)
 
+		if err := mwu.patchActionTrace(foundMW, mw); err != nil {
+			return ctrlutil.OperationResultNone, err
+		}
+
+		if _, err := mwu.patchPropagatedMetadata(foundMW); err != nil {
+			return ctrlutil.OperationResultNone, err
+		}
+
 		return ctrlutil.OperationResultUpdated, nil
 	}
 
-	return ctrlutil.OperationResultNone, nil
+	if err := mwu.patchActionTrace(foundMW, mw); err != nil {
+		return ctrlutil.OperationResultNone, err
+	}
+
+	return mwu.patchPropagatedMetadata(foundMW)
+}
+
+// patchActionTrace sets the action trace annotations of mw on found, its existing ManifestWork, whose annotations
+// are otherwise set only as it is created
+func (mwu *MWUtil) patchActionTrace(found, mw *ocmworkv1.ManifestWork) error {
+	patch := client.MergeFrom(found.DeepCopy())
+
+	if !ActionTraceAnnotate(found, ActionTraceFromAnnotations(mw)) {
+		return nil
+	}
+
+	if err := mwu.Client.Patch(mwu.Ctx, found, patch); err != nil {
+		return fmt.Errorf("failed to update ManifestWork %s/%s action trace: %w", found.Namespace, found.Name, err)
+	}
+
+	return nil
+}
+
+// patchPropagatedMetadata updates the propagated labels and annotations, and the reapply token, of an existing
+// ManifestWork
+func (mwu *MWUtil) patchPropagatedMetadata(mw *ocmworkv1.ManifestWork) (ctrlutil.OperationResult, error) {
+	patch := client.MergeFrom(mw.DeepCopy())
+
+	if !mwu.propagateMetadata(mw) {
+		return ctrlutil.OperationResultNone, nil
+	}
+
+	if err := mwu.Client.Patch(mwu.Ctx, mw, patch); err != nil {
+		return ctrlutil.OperationResultNone,
+			fmt.Errorf("failed to update ManifestWork %s/%s metadata: %w", mw.Namespace, mw.Name, err)
+	}
+
+	return ctrlutil.OperationResultUpdated, nil
+}
+
+// holdIfClusterUnavailable returns a ClusterUnavailableError if the ManifestWork for cluster should not be
+// created or updated as the cluster is unavailable. Callers are expected to reapply the ManifestWork once the
+// cluster is available again.
+func (mwu *MWUtil) holdIfClusterUnavailable(cluster string) error {
+	if ManagedClusterAvailable(mwu.Ctx, mwu.Client, cluster) {
+		return nil
+	}
+
+	mwu.Log.Info("Holding ManifestWork operation, cluster is unavailable", "cluster", cluster)
+
+	return ClusterUnavailableError{Cluster: cluster}
 }
 
 func (mwu *MWUtil) DeleteNamespaceManifestWork(mwName string, clusterName string) error {
+	if mwu.exporting() {
+		return mwu.unexportManifestWork(mwName, clusterName)
+	}
+
 	mw := &ocmworkv1.ManifestWork{}
 
 	err := mwu.Client.Get(mwu.Ctx, types.NamespacedName{Name: mwName, Namespace: clusterName}, mw)
@@ -778,6 +1080,11 @@ func (mwu *MWUtil) ensureOrphanDeleteOption(mwName string, clusterName string) e
 
 func (mwu *MWUtil) DeleteRecipeManifestWork(clusterName string) error {
 	mwName := mwu.BuildManifestWorkName(MWTypeRecipe)
+
+	if mwu.exporting() {
+		return mwu.unexportManifestWork(mwName, clusterName)
+	}
+
 	mw := &ocmworkv1.ManifestWork{}
 
 	err := mwu.Client.Get(mwu.Ctx, types.NamespacedName{Name: mwName, Namespace: clusterName}, mw)
@@ -802,6 +1109,10 @@ func (mwu *MWUtil) DeleteRecipeManifestWork(clusterName string) error {
 }
 
 func (mwu *MWUtil) DeleteManifestWork(mwName, mwNamespace string) error {
+	if mwu.exporting() {
+		return mwu.unexportManifestWork(mwName, mwNamespace)
+	}
+
 	mw := &ocmworkv1.ManifestWork{}
 
 	err := mwu.Client.Get(mwu.Ctx, types.NamespacedName{Name: mwName, Namespace: mwNamespace}, mw)
@@ -813,6 +1124,10 @@ func (mwu *MWUtil) DeleteManifestWork(mwName, mwNamespace string) error {
 		return fmt.Errorf("failed to retrieve manifestwork for type: %s. Error: %w", mwName, err)
 	}
 
+	if err := mwu.deleteManifestWorkParts(mw, 1); err != nil {
+		return err
+	}
+
 	err = mwu.Client.Delete(mwu.Ctx, mw)
 	if err != nil && !k8serrors.IsNotFound(err) {
 		return fmt.Errorf("failed to delete MW. Error %w", err)
@@ -831,13 +1146,55 @@ func (mwu *MWUtil) UpdateVRGManifestWork(vrg *rmn.VolumeReplicationGroup, mw *oc
 		return fmt.Errorf("failed to generate VRG manifest (%w)", err)
 	}
 
-	mw.Spec.Workload.Manifests[0] = *vrgClientManifest
+	if err := mwu.propagateMetadataToManifest(vrgClientManifest); err != nil {
+		return fmt.Errorf("failed to generate VRG manifest (%w)", err)
+	}
 
-	err = mwu.Client.Update(mwu.Ctx, mw)
-	if err != nil {
+	// The VRG is the only manifest of its ManifestWork, which hence is never split, but may exceed the limit
+	if _, err := SplitManifests([]ocmworkv1.Manifest{*vrgClientManifest}, ManifestWorkSizeLimit); err != nil {
+		return manifestWorkSplitError(mw, err)
+	}
+
+	if mwu.exporting() {
+		desiredSpec := mw.Spec.DeepCopy()
+		desiredSpec.Workload.Manifests[0] = *vrgClientManifest
+
+		exported := mw.DeepCopy()
+		exported.Spec = *desiredSpec
+
+		if _, err := mwu.exportManifestWork(exported, mw.GetNamespace()); err != nil {
+			return err
+		}
+
+		mw.Spec = *desiredSpec
+
+		return nil
+	}
+
+	if err := mwu.holdIfClusterUnavailable(mw.GetNamespace()); err != nil {
+		return err
+	}
+
+	// Replace the VRG of the current ManifestWork rather than of mw, which may be stale, so that concurrent
+	// updates of its other manifests are not reverted
+	var desiredSpec *ocmworkv1.ManifestWorkSpec
+
+	if _, err := mwu.patchManifestWorkSpec(client.ObjectKeyFromObject(mw),
+		func(found *ocmworkv1.ManifestWorkSpec) *ocmworkv1.ManifestWorkSpec {
+			desiredSpec = found
+			if len(desiredSpec.Workload.Manifests) == 0 {
+				desiredSpec.Workload.Manifests = []ocmworkv1.Manifest{*vrgClientManifest}
+			} else {
+				desiredSpec.Workload.Manifests[0] = *vrgClientManifest
+			}
+
+			return desiredSpec
+		}); err != nil {
 		return fmt.Errorf("failed to update MW (%w)", err)
 	}
 
+	mw.Spec = *desiredSpec
+
 	mwu.Log.Info(fmt.Sprintf("Added VRG %s to MW %s for cluster %s", vrg.GetName(), mw.GetName(), mw.GetNamespace()))
 
 	return nil
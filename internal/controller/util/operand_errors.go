@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// OperandErrorsTTL is how long an error of the ramen operator of a cluster is reported after it was last reported
+const OperandErrorsTTL = 24 * time.Hour
+
+// OperandErrorReporter collects the most recent errors the reconcilers of the ramen operator of a managed cluster
+// report, for the DRClusterConfig reconciler to publish them in the DRClusterConfig status, and so to the hub. It
+// notifies of each error reported on its events channel, for the DRClusterConfig to be reconciled. A nil reporter
+// discards the errors reported.
+type OperandErrorReporter struct {
+	mutex  sync.Mutex
+	errors []rmn.OperandError
+	events chan event.GenericEvent
+}
+
+func NewOperandErrorReporter() *OperandErrorReporter {
+	return &OperandErrorReporter{events: make(chan event.GenericEvent, 1)}
+}
+
+// Report records the error message of source, in the form "<kind> <namespace>/<name>", as the most recent error,
+// counting it once more if it was already recorded. The least recent errors are dropped beyond OperandErrorsMax.
+func (r *OperandErrorReporter) Report(source, message string) {
+	if r == nil {
+		return
+	}
+
+	// at the precision it is serialized with, for the status it is published in to compare equal once read back
+	reported := rmn.OperandError{
+		Time:    metav1.NewTime(time.Now().Truncate(time.Second)),
+		Source:  source,
+		Message: StorageMessage(message),
+		Count:   1,
+	}
+
+	r.mutex.Lock()
+
+	index := slices.IndexFunc(r.errors, func(recorded rmn.OperandError) bool {
+		return recorded.Source == reported.Source && recorded.Message == reported.Message
+	})
+	if index != -1 {
+		reported.Count += r.errors[index].Count
+		r.errors = slices.Delete(r.errors, index, index+1)
+	}
+
+	r.errors = slices.Insert(r.errors, 0, reported)
+	if len(r.errors) > rmn.OperandErrorsMax {
+		r.errors = r.errors[:rmn.OperandErrorsMax]
+	}
+
+	r.mutex.Unlock()
+
+	// a pending notification is reconciled along with this error
+	select {
+	case r.events <- event.GenericEvent{Object: &rmn.DRClusterConfig{}}:
+	default:
+	}
+}
+
+// Errors returns the errors recorded that were reported within OperandErrorsTTL of now, most recent first, or nil if
+// there are none
+func (r *OperandErrorReporter) Errors(now time.Time) []rmn.OperandError {
+	if r == nil {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.errors = slices.DeleteFunc(r.errors, func(recorded rmn.OperandError) bool {
+		return now.Sub(recorded.Time.Time) >= OperandErrorsTTL
+	})
+
+	if len(r.errors) == 0 {
+		return nil
+	}
+
+	errors := make([]rmn.OperandError, len(r.errors))
+	for i := range r.errors {
+		r.errors[i].DeepCopyInto(&errors[i])
+	}
+
+	return errors
+}
+
+// Events returns the channel the reporter notifies of each error reported on
+func (r *OperandErrorReporter) Events() <-chan event.GenericEvent {
+	return r.events
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("OperandErrorReporter", func() {
+	var reporter *util.OperandErrorReporter
+
+	BeforeEach(func() {
+		reporter = util.NewOperandErrorReporter()
+	})
+
+	It("reports the most recent errors first, counting those reported again", func() {
+		reporter.Report("VolumeReplicationGroup app/vrg1", "DataReady: failed")
+		reporter.Report("VolumeReplicationGroup app/vrg2", "DataReady: failed")
+		reporter.Report("VolumeReplicationGroup app/vrg1", "DataReady: failed")
+
+		errors := reporter.Errors(time.Now())
+		Expect(errors).To(HaveLen(2))
+		Expect(errors[0].Source).To(Equal("VolumeReplicationGroup app/vrg1"))
+		Expect(errors[0].Count).To(Equal(int32(2)))
+		Expect(errors[1].Source).To(Equal("VolumeReplicationGroup app/vrg2"))
+		Expect(errors[1].Count).To(Equal(int32(1)))
+	})
+
+	It("keeps the most recent errors only", func() {
+		for i := range rmn.OperandErrorsMax + 2 {
+			reporter.Report(fmt.Sprintf("VolumeReplicationGroup app/vrg%d", i), "DataReady: failed")
+		}
+
+		errors := reporter.Errors(time.Now())
+		Expect(errors).To(HaveLen(rmn.OperandErrorsMax))
+		Expect(errors[0].Source).To(Equal(fmt.Sprintf("VolumeReplicationGroup app/vrg%d", rmn.OperandErrorsMax+1)))
+	})
+
+	It("drops the errors not reported for a while", func() {
+		reporter.Report("VolumeReplicationGroup app/vrg1", "DataReady: failed")
+
+		Expect(reporter.Errors(time.Now().Add(util.OperandErrorsTTL))).To(BeNil())
+		Expect(reporter.Errors(time.Now())).To(BeNil())
+	})
+
+	It("redacts the credentials of the errors", func() {
+		reporter.Report("VolumeReplicationGroup app/vrg1", "upload to s3://user:secret@host failed")
+
+		Expect(reporter.Errors(time.Now())[0].Message).ToNot(ContainSubstring("secret"))
+	})
+
+	It("notifies of the errors reported", func() {
+		reporter.Report("VolumeReplicationGroup app/vrg1", "DataReady: failed")
+		reporter.Report("VolumeReplicationGroup app/vrg2", "DataReady: failed")
+
+		Eventually(reporter.Events()).Should(Receive())
+		Consistently(reporter.Events()).ShouldNot(Receive())
+	})
+
+	It("discards the errors reported to a nil reporter", func() {
+		var none *util.OperandErrorReporter
+
+		none.Report("VolumeReplicationGroup app/vrg1", "DataReady: failed")
+		Expect(none.Errors(time.Now())).To(BeNil())
+	})
+})
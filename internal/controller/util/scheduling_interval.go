@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	// cronScheduleGapsMax is the most consecutive times of a cron schedule its gaps are measured over, enough for a
+	// schedule of every minute to be measured over a week, for schedules of weekdays to be measured over a weekend
+	cronScheduleGapsMax = 7 * 24 * 60
+
+	// cronScheduleSpan is the span a cron schedule is measured over, for schedules of days of the month or of months
+	cronScheduleSpan = 366 * 24 * time.Hour
+)
+
+// SchedulingIntervalIsCron returns whether schedulingInterval is a standard 5-field cron expression rather than an
+// interval of the form <num><m,h,d>
+func SchedulingIntervalIsCron(schedulingInterval string) bool {
+	return strings.Contains(schedulingInterval, " ")
+}
+
+// SchedulingIntervalCronSchedule parses schedulingInterval as a standard 5-field cron expression
+func SchedulingIntervalCronSchedule(schedulingInterval string) (cron.Schedule, error) {
+	if len(strings.Fields(schedulingInterval)) != 5 { //nolint:mnd
+		return nil, fmt.Errorf("cron expression %q does not have 5 fields", schedulingInterval)
+	}
+
+	schedule, err := cron.ParseStandard(schedulingInterval)
+	if err != nil {
+		return nil, fmt.Errorf("cron expression %q is invalid: %w", schedulingInterval, err)
+	}
+
+	return schedule, nil
+}
+
+// CronScheduleGapMax returns the longest gap between consecutive times of schedule from t, which is how far behind the
+// data replicated on the schedule may be
+func CronScheduleGapMax(schedule cron.Schedule, t time.Time) (time.Duration, error) {
+	previous := schedule.Next(t)
+	if previous.IsZero() {
+		return 0, fmt.Errorf("cron schedule has no next time")
+	}
+
+	gapMax := time.Duration(0)
+	end := previous.Add(cronScheduleSpan)
+
+	for i := 0; i < cronScheduleGapsMax && previous.Before(end); i++ {
+		next := schedule.Next(previous)
+		if next.IsZero() {
+			break
+		}
+
+		gapMax = max(gapMax, next.Sub(previous))
+		previous = next
+	}
+
+	if gapMax == 0 {
+		return 0, fmt.Errorf("cron schedule has no times within %v", cronScheduleSpan)
+	}
+
+	return gapMax, nil
+}
+
+// SchedulingIntervalsValidate returns an error if a scheduling interval of drPolicy is a cron expression that is
+// invalid or has no times
+func SchedulingIntervalsValidate(drPolicy *rmn.DRPolicy) error {
+	for _, interval := range DRPolicySchedulingIntervals(drPolicy) {
+		if !SchedulingIntervalIsCron(interval) {
+			continue
+		}
+
+		schedule, err := SchedulingIntervalCronSchedule(interval)
+		if err != nil {
+			return err
+		}
+
+		if _, err := CronScheduleGapMax(schedule, time.Now()); err != nil {
+			return fmt.Errorf("cron expression %q: %w", interval, err)
+		}
+	}
+
+	return nil
+}
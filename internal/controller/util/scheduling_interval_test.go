@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("SchedulingInterval", func() {
+	drPolicy := func(interval string) *rmn.DRPolicy {
+		return &rmn.DRPolicy{Spec: rmn.DRPolicySpec{SchedulingInterval: interval}}
+	}
+
+	seconds := func(interval string) float64 {
+		s, err := util.GetSecondsFromSchedulingInterval(drPolicy(interval))
+		Expect(err).ToNot(HaveOccurred())
+
+		return s
+	}
+
+	It("tells cron expressions from intervals", func() {
+		Expect(util.SchedulingIntervalIsCron("5m")).To(BeFalse())
+		Expect(util.SchedulingIntervalIsCron("*/5 * * * *")).To(BeTrue())
+	})
+
+	It("converts intervals and cron expressions to the longest gap between replications", func() {
+		Expect(seconds("2h")).To(Equal((2 * time.Hour).Seconds()))
+		Expect(seconds("1d")).To(Equal((24 * time.Hour).Seconds()))
+		Expect(seconds("*/15 * * * *")).To(Equal((15 * time.Minute).Seconds()))
+		// from Friday 20:00 to Monday 08:00
+		Expect(seconds("0 8-20/4 * * 1-5")).To(Equal((60 * time.Hour).Seconds()))
+	})
+
+	It("rejects cron expressions that are invalid or have no times", func() {
+		Expect(util.SchedulingIntervalsValidate(drPolicy("0 */4 * * 1-5"))).To(Succeed())
+		Expect(util.SchedulingIntervalsValidate(drPolicy("5m"))).To(Succeed())
+		Expect(util.SchedulingIntervalsValidate(drPolicy("0 */4 * * mon-xyz"))).ToNot(Succeed())
+		Expect(util.SchedulingIntervalsValidate(drPolicy("0 0 30 2 *"))).ToNot(Succeed())
+	})
+})
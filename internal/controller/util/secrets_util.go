@@ -44,6 +44,21 @@ const (
 	SecretPolicyFinalizer string = "drpolicies.ramendr.openshift.io/policy-protection"
 
 	VeleroSecretKeyNameDefault = "ramengenerated"
+
+	// Keys of the credentials in the secret of an S3 profile
+	S3AccessKeyIDKey     = "AWS_ACCESS_KEY_ID"
+	S3SecretAccessKeyKey = "AWS_SECRET_ACCESS_KEY"
+
+	// Keys of the credentials in the secret of an AzureBlob profile
+	AzureStorageAccountNameKey = "AZURE_STORAGE_ACCOUNT_NAME"
+	AzureStorageAccountKeyKey  = "AZURE_STORAGE_ACCOUNT_KEY"
+
+	// Key of the key encryption key of the client-side encryption in the secret of a profile
+	EncryptionKeyKey = "RAMEN_ENCRYPTION_KEY"
+
+	// Keys of the TLS client certificate and private key in the secret of a profile
+	TLSClientCertificateKey = corev1.TLSCertKey
+	TLSClientPrivateKeyKey  = corev1.TLSPrivateKeyKey
 )
 
 // TargetSecretFormat defines the secret format to deliver to the cluster
@@ -68,8 +83,19 @@ const (
 type SecretsUtil struct {
 	client.Client
 	APIReader client.Reader
-	Ctx       context.Context
-	Log       logr.Logger
+	// SecretReader, if set, reads the secrets to propagate instead of Client, for example as a service account that
+	// is allowed to read them
+	SecretReader client.Reader
+	Ctx          context.Context
+	Log          logr.Logger
+}
+
+func (sutil *SecretsUtil) secretReader() client.Reader {
+	if sutil.SecretReader != nil {
+		return sutil.SecretReader
+	}
+
+	return sutil.Client
 }
 
 // GeneratePolicyResourceNames returns names (in order) for policy resources that are created,
@@ -273,7 +299,38 @@ func (in *localSecret) DeepCopyInto(out *localSecret) {
 	}
 }
 
-func newS3ConfigurationSecret(s3SecretRef corev1.SecretReference, targetns string) *localSecret {
+// s3SecretDataKeys returns the keys of the key encryption key in secret, if it has only that, or otherwise the keys
+// of the credentials in secret, those of an Azure storage account if it has them, or those of an S3 store otherwise,
+// and the keys of the TLS client certificate and private key if it has them
+func s3SecretDataKeys(secret *corev1.Secret) []string {
+	_, hasS3Credentials := secret.Data[S3AccessKeyIDKey]
+	_, hasAzureCredentials := secret.Data[AzureStorageAccountNameKey]
+
+	if _, ok := secret.Data[EncryptionKeyKey]; ok && !hasS3Credentials && !hasAzureCredentials {
+		return []string{EncryptionKeyKey}
+	}
+
+	dataKeys := []string{S3AccessKeyIDKey, S3SecretAccessKeyKey}
+	if hasAzureCredentials {
+		dataKeys = []string{AzureStorageAccountNameKey, AzureStorageAccountKeyKey}
+	}
+
+	if _, ok := secret.Data[TLSClientCertificateKey]; ok {
+		dataKeys = append(dataKeys, TLSClientCertificateKey, TLSClientPrivateKeyKey)
+	}
+
+	return dataKeys
+}
+
+func newS3ConfigurationSecret(s3SecretRef corev1.SecretReference, targetns string, dataKeys []string) *localSecret {
+	data := make(map[string]string, len(dataKeys))
+	for _, dataKey := range dataKeys {
+		data[dataKey] = "{{hub fromSecret " +
+			"\"" + s3SecretRef.Namespace + "\"" + " " +
+			"\"" + s3SecretRef.Name + "\"" + " " +
+			"\"" + dataKey + "\" hub}}"
+	}
+
 	localsecret := &localSecret{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Secret",
@@ -283,16 +340,7 @@ func newS3ConfigurationSecret(s3SecretRef corev1.SecretReference, targetns strin
 			Name:      s3SecretRef.Name,
 			Namespace: targetns,
 		},
-		Data: map[string]string{
-			"AWS_ACCESS_KEY_ID": "{{hub fromSecret " +
-				"\"" + s3SecretRef.Namespace + "\"" + " " +
-				"\"" + s3SecretRef.Name + "\"" + " " +
-				"\"AWS_ACCESS_KEY_ID\" hub}}",
-			"AWS_SECRET_ACCESS_KEY": "{{hub fromSecret " +
-				"\"" + s3SecretRef.Namespace + "\"" + " " +
-				"\"" + s3SecretRef.Name + "\"" + " " +
-				"\"AWS_SECRET_ACCESS_KEY\" hub}}",
-		},
+		Data: data,
 	}
 
 	AddLabel(localsecret, CreatedByRamenLabel, "true")
@@ -424,7 +472,7 @@ func (sutil *SecretsUtil) createPolicyResources(
 
 	// Create a Policy object for the secret
 	configObject := newConfigurationPolicy(configPolicyName,
-		sutil.policyObject(secret.Name, namespace, targetNS, format, veleroNS))
+		sutil.policyObject(secret.Name, namespace, targetNS, format, veleroNS, s3SecretDataKeys(secret)))
 	AddLabel(configObject, CreatedByRamenLabel, "true")
 
 	sutil.Log.Info("Initializing secret policy trigger", "secret", secret.Name, "trigger", secret.ResourceVersion)
@@ -456,6 +504,7 @@ func (sutil *SecretsUtil) policyObject(
 	secretName, secretNS, targetNS string,
 	format TargetSecretFormat,
 	veleroNS string,
+	dataKeys []string,
 ) *runtime.RawExtension {
 	var object *runtime.RawExtension
 
@@ -463,7 +512,7 @@ func (sutil *SecretsUtil) policyObject(
 
 	switch format {
 	case SecretFormatRamen:
-		object = &runtime.RawExtension{Object: newS3ConfigurationSecret(s3SecretRef, targetNS)}
+		object = &runtime.RawExtension{Object: newS3ConfigurationSecret(s3SecretRef, targetNS, dataKeys)}
 	case SecretFormatVelero:
 		object = &runtime.RawExtension{
 			Object: newVeleroSecret(s3SecretRef, targetNS, veleroNS, VeleroSecretKeyNameDefault),
@@ -665,7 +714,7 @@ func (sutil *SecretsUtil) ensureS3SecretResources(
 	format TargetSecretFormat,
 ) (*corev1.Secret, error) {
 	secret := corev1.Secret{}
-	if err := sutil.Client.Get(sutil.Ctx,
+	if err := sutil.secretReader().Get(sutil.Ctx,
 		types.NamespacedName{Namespace: namespace, Name: secretName},
 		&secret); err != nil {
 		if !k8serrors.IsNotFound(err) {
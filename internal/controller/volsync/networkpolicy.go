@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package volsync
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// RsyncTLSPort is the port the rsync-tls mover of a VolSync ReplicationDestination listens on
+	RsyncTLSPort = 8000
+
+	// moverLabelKey and moverLabelValue label the pods of the VolSync movers
+	moverLabelKey   = "app.kubernetes.io/created-by"
+	moverLabelValue = "volsync"
+)
+
+// ReplicationNetworkPolicyName returns the name of the NetworkPolicy that protects the VolSync movers of a VRG
+func ReplicationNetworkPolicyName(vrgName string) string {
+	return fmt.Sprintf("%s-vs-replication", vrgName)
+}
+
+// ReplicationNetworkPolicy returns a NetworkPolicy that allows ingress to the VolSync movers in the namespace only
+// from the peerCIDRs, on the rsync-tls port. Egress of the movers is not restricted.
+func ReplicationNetworkPolicy(vrgName, namespace string, peerCIDRs []string) *networkingv1.NetworkPolicy {
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(peerCIDRs))
+	for _, cidr := range peerCIDRs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+	}
+
+	protocol := corev1.ProtocolTCP
+	port := intstr.FromInt32(RsyncTLSPort)
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: networkingv1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ReplicationNetworkPolicyName(vrgName),
+			Namespace: namespace,
+			Labels:    map[string]string{util.CreatedByRamenLabel: "true"},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{moverLabelKey: moverLabelValue},
+			},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From:  peers,
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &protocol, Port: &port}},
+			}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
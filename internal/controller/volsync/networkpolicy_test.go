@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package volsync_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/ramendr/ramen/internal/controller/volsync"
+)
+
+var _ = Describe("ReplicationNetworkPolicy", func() {
+	It("allows ingress to the movers only from the peer CIDRs on the rsync-tls port", func() {
+		policy := volsync.ReplicationNetworkPolicy("app", "app-ns", []string{"10.0.0.0/16", "10.1.0.0/16"})
+
+		Expect(policy.Name).To(Equal(volsync.ReplicationNetworkPolicyName("app")))
+		Expect(policy.Namespace).To(Equal("app-ns"))
+		Expect(policy.Spec.PodSelector.MatchLabels).To(HaveKeyWithValue("app.kubernetes.io/created-by", "volsync"))
+		Expect(policy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+
+		Expect(policy.Spec.Ingress).To(HaveLen(1))
+		Expect(policy.Spec.Ingress[0].From).To(HaveLen(2))
+		Expect(policy.Spec.Ingress[0].From[1].IPBlock.CIDR).To(Equal("10.1.0.0/16"))
+		Expect(policy.Spec.Ingress[0].Ports).To(HaveLen(1))
+		Expect(policy.Spec.Ingress[0].Ports[0].Port.IntValue()).To(Equal(volsync.RsyncTLSPort))
+	})
+})
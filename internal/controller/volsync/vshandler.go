@@ -82,6 +82,7 @@ type VSHandler struct {
 	log                         logr.Logger
 	owner                       metav1.Object
 	schedulingInterval          string
+	schedulingIntervals         []ramendrv1alpha1.StorageClassSchedulingInterval
 	volumeSnapshotClassSelector metav1.LabelSelector // volume snapshot classes to be filtered label selector
 	defaultCephFSCSIDriverName  string
 	destinationCopyMethod       volsyncv1alpha1.CopyMethodType
@@ -89,6 +90,7 @@ type VSHandler struct {
 	vrgInAdminNamespace         bool
 	workloadStatus              string
 	moverConfig                 []ramendrv1alpha1.MoverConfig
+	volSyncTuning               *ramendrv1alpha1.VolSyncTuning
 }
 
 func NewVSHandler(ctx context.Context, client client.Client, log logr.Logger, owner metav1.Object,
@@ -108,7 +110,13 @@ func NewVSHandler(ctx context.Context, client client.Client, log logr.Logger, ow
 
 	if asyncSpec != nil {
 		vsHandler.schedulingInterval = asyncSpec.SchedulingInterval
+		vsHandler.schedulingIntervals = asyncSpec.StorageClassSchedulingIntervals
 		vsHandler.volumeSnapshotClassSelector = asyncSpec.VolumeSnapshotClassSelector
+		vsHandler.volSyncTuning = asyncSpec.VolSync
+
+		if asyncSpec.VolSync != nil && asyncSpec.VolSync.CopyMethod != "" {
+			vsHandler.destinationCopyMethod = volsyncv1alpha1.CopyMethodType(asyncSpec.VolSync.CopyMethod)
+		}
 	}
 
 	vrg, ok := owner.(*ramendrv1alpha1.VolumeReplicationGroup)
@@ -145,7 +153,9 @@ func (v *VSHandler) SetWorkloadStatus(status string) {
 	v.workloadStatus = status
 }
 
-func buildMoverConfig(moverConfigSpec *ramendrv1alpha1.MoverConfig) volsyncv1alpha1.MoverConfig {
+func buildMoverConfig(moverConfigSpec *ramendrv1alpha1.MoverConfig,
+	volSyncTuning *ramendrv1alpha1.VolSyncTuning,
+) volsyncv1alpha1.MoverConfig {
 	mc := volsyncv1alpha1.MoverConfig{
 		MoverPodLabels: map[string]string{
 			util.CreatedByRamenLabel: "true",
@@ -157,9 +167,52 @@ func buildMoverConfig(moverConfigSpec *ramendrv1alpha1.MoverConfig) volsyncv1alp
 		mc.MoverServiceAccount = moverConfigSpec.MoverServiceAccount
 	}
 
+	if volSyncTuning != nil {
+		mc.MoverResources = volSyncTuning.MoverResources
+	}
+
 	return mc
 }
 
+// cacheCapacity returns the capacity of the point-in-time copy a ReplicationSource syncs from, or nil for VolSync to
+// default it to the capacity of the PVC replicated
+func (v *VSHandler) cacheCapacity() *resource.Quantity {
+	if v.volSyncTuning == nil {
+		return nil
+	}
+
+	return v.volSyncTuning.CacheCapacity
+}
+
+// reconcilePrivilegedMovers annotates namespace for VolSync to run the movers of its ReplicationSources and
+// ReplicationDestinations privileged, or unprivileged, as tuned. The annotation is left as is if not tuned.
+func (v *VSHandler) reconcilePrivilegedMovers(namespace string) error {
+	if v.volSyncTuning == nil || v.volSyncTuning.PrivilegedMovers == nil {
+		return nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := v.client.Get(v.ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+
+	patch := client.MergeFrom(ns.DeepCopy())
+
+	if !util.AddAnnotation(ns, volsyncv1alpha1.PrivilegedMoversNamespaceAnnotation,
+		strconv.FormatBool(*v.volSyncTuning.PrivilegedMovers)) {
+		return nil
+	}
+
+	if err := v.client.Patch(v.ctx, ns, patch); err != nil {
+		return fmt.Errorf("failed to annotate namespace %s for privileged movers: %w", namespace, err)
+	}
+
+	v.log.Info("Annotated namespace for privileged movers", "namespace", namespace,
+		"privileged", *v.volSyncTuning.PrivilegedMovers)
+
+	return nil
+}
+
 // returns replication destination only if create/update is successful and the RD is considered available.
 // Callers should assume getting a nil replication destination back means they should retry/requeue.
 //
@@ -399,6 +452,10 @@ func (v *VSHandler) createOrUpdateRD(
 		pvcAccessModes = rdSpec.ProtectedPVC.AccessModes
 	}
 
+	if err := v.reconcilePrivilegedMovers(rdSpec.ProtectedPVC.Namespace); err != nil {
+		return nil, err
+	}
+
 	rd := &volsyncv1alpha1.ReplicationDestination{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      util.GetReplicationDestinationName(rdSpec.ProtectedPVC.Name),
@@ -414,7 +471,7 @@ func (v *VSHandler) createOrUpdateRD(
 		util.AddAnnotation(rd, OwnerNameAnnotation, v.owner.GetName())
 		util.AddAnnotation(rd, OwnerNamespaceAnnotation, v.owner.GetNamespace())
 
-		moverConfig := buildMoverConfig(moverConfigSpec)
+		moverConfig := buildMoverConfig(moverConfigSpec, v.volSyncTuning)
 
 		if util.IsDiffSyncEnabled(v.owner.GetAnnotations()) {
 			params := map[string]string{
@@ -656,6 +713,10 @@ func (v *VSHandler) createOrUpdateRS(rsSpec ramendrv1alpha1.VolSyncReplicationSo
 		return nil, err
 	}
 
+	if err := v.reconcilePrivilegedMovers(rsSpec.ProtectedPVC.Namespace); err != nil {
+		return nil, err
+	}
+
 	rs := &volsyncv1alpha1.ReplicationSource{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      getReplicationSourceName(rsSpec.ProtectedPVC.Name),
@@ -683,7 +744,7 @@ func (v *VSHandler) createOrUpdateRS(rsSpec ramendrv1alpha1.VolSyncReplicationSo
 			return err
 		}
 
-		moverConfig := buildMoverConfig(moverConfigSpec)
+		moverConfig := buildMoverConfig(moverConfigSpec, v.volSyncTuning)
 
 		if util.IsDiffSyncEnabled(v.owner.GetAnnotations()) {
 			rs.Spec.External = &volsyncv1alpha1.ReplicationSourceExternalSpec{
@@ -710,6 +771,7 @@ func (v *VSHandler) createOrUpdateRS(rsSpec ramendrv1alpha1.VolSyncReplicationSo
 					VolumeSnapshotClassName: &volumeSnapshotClassName,
 					StorageClassName:        rsSpec.ProtectedPVC.StorageClassName,
 					AccessModes:             rsSpec.ProtectedPVC.AccessModes,
+					Capacity:                v.cacheCapacity(),
 				},
 				MoverConfig: moverConfig,
 			}
@@ -988,7 +1050,7 @@ func (v *VSHandler) configureReplicationSourceSpec(rs *volsyncv1alpha1.Replicati
 		}
 	} else {
 		// Set schedule trigger
-		scheduleCronSpec, err := v.getScheduleCronSpec()
+		scheduleCronSpec, err := v.getScheduleCronSpec(rsSpec.ProtectedPVC.StorageClassName)
 		if err != nil {
 			v.log.Error(err, "unable to parse schedulingInterval")
 
@@ -2502,9 +2564,23 @@ func (v *VSHandler) GetVolumeSnapshotClasses() ([]snapv1.VolumeSnapshotClass, er
 	return v.volumeSnapshotClassList.Items, nil
 }
 
-func (v *VSHandler) getScheduleCronSpec() (*string, error) {
+// getScheduleCronSpec returns the cronspec of the scheduling interval of the PVCs of the storage class, as overridden
+// for the storage class, if it is
+func (v *VSHandler) getScheduleCronSpec(storageClassName *string) (*string, error) {
 	if v.schedulingInterval != "" {
-		return ConvertSchedulingIntervalToCronSpec(v.schedulingInterval)
+		schedulingInterval := v.schedulingInterval
+
+		if len(v.schedulingIntervals) != 0 {
+			storageClass, err := v.getStorageClass(storageClassName)
+			if err != nil {
+				return nil, err
+			}
+
+			schedulingInterval = util.StorageClassSchedulingInterval(v.schedulingIntervals, storageClass,
+				schedulingInterval)
+		}
+
+		return ConvertSchedulingIntervalToCronSpec(schedulingInterval)
 	}
 
 	// Use default value if not specified
@@ -2516,7 +2592,16 @@ func (v *VSHandler) getScheduleCronSpec() (*string, error) {
 
 // Convert from schedulingInterval which is in the format of <num><m,h,d>
 // to the format VolSync expects, which is cronspec: https://en.wikipedia.org/wiki/Cron#Overview
+// A schedulingInterval that already is a cronspec is returned as is.
 func ConvertSchedulingIntervalToCronSpec(schedulingInterval string) (*string, error) {
+	if util.SchedulingIntervalIsCron(schedulingInterval) {
+		if _, err := util.SchedulingIntervalCronSchedule(schedulingInterval); err != nil {
+			return nil, fmt.Errorf("scheduling interval %s is invalid: %w", schedulingInterval, err)
+		}
+
+		return &schedulingInterval, nil
+	}
+
 	// format needs to have at least 1 number and end with m or h or d
 	if len(schedulingInterval) < SchedulingIntervalMinLength {
 		return nil, fmt.Errorf("scheduling interval %s is invalid", schedulingInterval)
@@ -2762,7 +2847,7 @@ func (v *VSHandler) reconcileLocalRD(rdSpec ramendrv1alpha1.VolSyncReplicationDe
 			pvcAccessModes = rdSpec.ProtectedPVC.AccessModes
 		}
 
-		moverConfig := buildMoverConfig(moverConfigSpec)
+		moverConfig := buildMoverConfig(moverConfigSpec, v.volSyncTuning)
 
 		lrd.Spec.External = nil
 		lrd.Spec.RsyncTLS = &volsyncv1alpha1.ReplicationDestinationRsyncTLSSpec{
@@ -2834,7 +2919,7 @@ func (v *VSHandler) reconcileLocalRS(rd *volsyncv1alpha1.ReplicationDestination,
 
 		lrs.Spec.SourcePVC = pvc.GetName()
 
-		moverConfig := buildMoverConfig(moverConfigSpec)
+		moverConfig := buildMoverConfig(moverConfigSpec, v.volSyncTuning)
 
 		lrs.Spec.External = nil
 		lrs.Spec.RsyncTLS = &volsyncv1alpha1.ReplicationSourceRsyncTLSSpec{
@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
@@ -74,6 +75,16 @@ var _ = Describe("VolSync Handler - utils", func() {
 			_, err := volsync.ConvertSchedulingIntervalToCronSpec("123")
 			Expect(err).To((HaveOccurred()))
 		})
+		It("Should pass through an interval specified as a cronspec", func() {
+			cronSpecSchedule, err := volsync.ConvertSchedulingIntervalToCronSpec("0 */4 * * 1-5")
+			Expect(err).NotTo((HaveOccurred()))
+			Expect(cronSpecSchedule).ToNot(BeNil())
+			Expect(*cronSpecSchedule).To(Equal("0 */4 * * 1-5"))
+		})
+		It("Should fail if interval is an invalid cronspec", func() {
+			_, err := volsync.ConvertSchedulingIntervalToCronSpec("0 */4 * * mon-xyz")
+			Expect(err).To((HaveOccurred()))
+		})
 	})
 })
 
@@ -455,6 +466,45 @@ var _ = Describe("VolSync_Handler", func() {
 					})
 				})
 
+				Context("When reconciling RD with the VolSync replication tuned", func() {
+					moverResources := corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("100m"),
+							corev1.ResourceMemory: resource.MustParse("256Mi"),
+						},
+					}
+
+					JustBeforeEach(func() {
+						tunedAsyncSpec := asyncSpec.DeepCopy()
+						tunedAsyncSpec.VolSync = &ramendrv1alpha1.VolSyncTuning{
+							MoverResources:   &moverResources,
+							PrivilegedMovers: ptr.To(false),
+						}
+						tunedVSHandler := volsync.NewVSHandler(ctx, k8sClient, logger, owner, tunedAsyncSpec, "none",
+							"Snapshot", false)
+
+						_, _, err := tunedVSHandler.ReconcileRD(rdSpec, nil)
+						Expect(err).ToNot(HaveOccurred())
+
+						Eventually(func() error {
+							return k8sClient.Get(ctx, types.NamespacedName{
+								Name:      rdSpec.ProtectedPVC.Name,
+								Namespace: testNamespace.GetName(),
+							}, createdRD)
+						}, maxWait, interval).Should(Succeed())
+					})
+
+					It("Should create the RD with the mover resources, and annotate the namespace for its movers", func() {
+						Expect(createdRD.Spec.RsyncTLS).NotTo(BeNil())
+						Expect(createdRD.Spec.RsyncTLS.MoverResources).To(Equal(&moverResources))
+
+						ns := &corev1.Namespace{}
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(testNamespace), ns)).To(Succeed())
+						Expect(ns.GetAnnotations()).To(HaveKeyWithValue(
+							volsyncv1alpha1.PrivilegedMoversNamespaceAnnotation, "false"))
+					})
+				})
+
 				Context("When reconciling RD with no previous RS", func() {
 					JustBeforeEach(func() {
 						// Run ReconcileRD
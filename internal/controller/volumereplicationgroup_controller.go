@@ -63,6 +63,9 @@ type VolumeReplicationGroupReconciler struct {
 	excludedResourcesMgr    *velero.ExcludedResourcesManager
 	excludedResourcesMutex  sync.RWMutex
 	cachedExcludedResources []string
+
+	// OperandErrors collects the errors the conditions of the VRGs raise, for the DRClusterConfig to report them
+	OperandErrors *util.OperandErrorReporter
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -417,6 +420,7 @@ func filterPVC(reader client.Reader, pvc *corev1.PersistentVolumeClaim, log logr
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch;create;update
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=volumeattachments,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update
 // +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch;update
@@ -430,6 +434,7 @@ func filterPVC(reader client.Reader, pvc *corev1.PersistentVolumeClaim, log logr
 // +kubebuilder:rbac:groups=volsync.backube,resources=replicationsources/finalizers,verbs=update
 // +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotcontents,verbs=get;list;create;delete
 // +kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceexports,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=core,resources=endpoints,verbs=get;list;watch;update;patch
@@ -440,9 +445,13 @@ func filterPVC(reader client.Reader, pvc *corev1.PersistentVolumeClaim, log logr
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=list;watch;get;create;patch;update
 // +kubebuilder:rbac:groups="apiextensions.k8s.io",resources=customresourcedefinitions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups=core,resources=resourcequotas,verbs=get;list
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;create;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;update
 // +kubebuilder:rbac:groups="kubevirt.io",resources=virtualmachines,verbs=get;list;watch;patch;update;delete
 // +kubebuilder:rbac:groups="kubevirt.io",resources=virtualmachineinstances,verbs=get;list;watch
 // +kubebuilder:rbac:groups="cdi.kubevirt.io",resources=datavolumes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=*,resources=*,verbs=get;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -573,7 +582,10 @@ type VRGInstance struct {
 	namespacedName       string
 	volSyncHandler       *volsync.VSHandler
 	objectStorers        map[string]cachedObjectStorer
+	objectStorersMutex   sync.Mutex
+	s3UploadFailed       map[string]bool
 	s3StoreAccessors     []s3StoreAccessor
+	nodes                []corev1.Node
 	result               ctrl.Result
 }
 
@@ -595,6 +607,10 @@ const (
 	RestoreAnnotation                = "volumereplicationgroups.ramendr.openshift.io/ramen-restore"
 	RestoredByRamen                  = "True"
 
+	// PVC annotation excluding the PVC from protection, even though it matches the PVC selector of the VRG
+	PVCExcludeFromProtectionAnnotation = "ramendr.openshift.io/exclude-from-protection"
+	PVCExcludedFromProtection          = "true"
+
 	// StorageClass label
 	StorageIDLabel = "ramendr.openshift.io/storageid"
 
@@ -623,6 +639,10 @@ func (v *VRGInstance) requeue() {
 
 //nolint:cyclop
 func (v *VRGInstance) processVRG() ctrl.Result {
+	if v.instance.Spec.Drill != nil {
+		return v.processDrill()
+	}
+
 	if err := v.validateVRGState(); err != nil {
 		// No requeue, as there is no reconcile till user changes desired spec to a valid value
 		return v.invalid(err, "VolumeReplicationGroup state is invalid", false)
@@ -655,6 +675,10 @@ func (v *VRGInstance) processVRG() ctrl.Result {
 		return v.invalid(err, "Failed to process list of PVCs to protect", true)
 	}
 
+	if err := v.pdbsQuiesceReconcile(); err != nil {
+		return v.dataError(err, "Failed to override or restore pod disruption budgets", true)
+	}
+
 	v.log = v.log.WithValues("State", v.instance.Spec.ReplicationState)
 	v.s3StoreAccessorsGet()
 
@@ -669,12 +693,13 @@ func (v *VRGInstance) processVRG() ctrl.Result {
 	}
 
 	v.updateVRGAutoCleanupCondition()
+	v.localFailoverEvaluate()
 
 	switch {
 	case v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary:
-		return v.processAsPrimary()
+		return v.localFailoverRequeue(v.processAsPrimary())
 	default: // Secondary, not primary and not deleted
-		return v.processAsSecondary()
+		return v.localFailoverRequeue(v.processAsSecondary())
 	}
 }
 
@@ -777,6 +802,13 @@ func (v *VRGInstance) updatePVCList() error {
 		return err
 	}
 
+	if !util.ResourceIsDeleted(v.instance) {
+		v.instance.Status.ExcludedPVCs = pvcsExcludedFilter(pvcList)
+		if len(v.instance.Status.ExcludedPVCs) != 0 {
+			v.log.Info("PersistentVolumeClaims excluded from protection", "pvcs", v.instance.Status.ExcludedPVCs)
+		}
+	}
+
 	if v.instance.Spec.Async == nil {
 		return v.updateSyncPVCs(pvcList)
 	}
@@ -784,6 +816,30 @@ func (v *VRGInstance) updatePVCList() error {
 	return v.updateAsyncPVCs(pvcList)
 }
 
+// pvcsExcludedFilter removes the PVCs annotated for exclusion from protection from pvcList, and returns their
+// namespaced names, sorted
+func pvcsExcludedFilter(pvcList *corev1.PersistentVolumeClaimList) []string {
+	var excluded []string
+
+	selected := pvcList.Items[:0]
+
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		if pvc.GetAnnotations()[PVCExcludeFromProtectionAnnotation] != PVCExcludedFromProtection {
+			selected = append(selected, *pvc)
+
+			continue
+		}
+
+		excluded = append(excluded, client.ObjectKeyFromObject(pvc).String())
+	}
+
+	pvcList.Items = selected
+	slices.Sort(excluded)
+
+	return excluded
+}
+
 func (v *VRGInstance) updateSyncPVCs(pvcList *corev1.PersistentVolumeClaimList) error {
 	err := v.validateSyncPVCs(pvcList)
 	if err != nil {
@@ -1337,6 +1393,10 @@ func (v *VRGInstance) processForDeletion() ctrl.Result {
 
 	defer v.log.Info("Exiting processing VolumeReplicationGroup")
 
+	if proceed, result := v.finalizationHooksExecute(); !proceed {
+		return result
+	}
+
 	if err := v.disownPVCs(); err != nil {
 		v.log.Info("Disowning PVCs failed", "error", err)
 
@@ -1846,6 +1906,12 @@ func (v *VRGInstance) dataError(err error, msg string, requeue bool) ctrl.Result
 }
 
 func (v *VRGInstance) clusterDataError(err error, msg string, result ctrl.Result) ctrl.Result {
+	if isObjectCorrupt(err) {
+		v.errorConditionLogAndSet(err, msg, setVRGClusterDataCorruptCondition)
+
+		return v.updateVRGStatus(result)
+	}
+
 	v.errorConditionLogAndSet(err, msg, setVRGClusterDataErrorCondition)
 
 	return v.updateVRGStatus(result)
@@ -1902,8 +1968,10 @@ func (v *VRGInstance) updateVRGStatus(result ctrl.Result) ctrl.Result {
 	}
 
 	v.updateStatusState()
+	v.reportConditionErrors()
 
 	v.instance.Status.ObservedGeneration = v.instance.Generation
+	v.instance.Status.ObservedSpecHash = v.instance.GetAnnotations()[util.VRGSpecHashAnnotation]
 
 	if !reflect.DeepEqual(v.savedInstanceStatus, v.instance.Status) {
 		v.instance.Status.LastUpdateTime = metav1.Now()
@@ -2281,6 +2349,26 @@ func isVRGReasonError(condition *metav1.Condition) bool {
 		condition.Reason == VRGConditionReasonClusterDataConflictSecondary
 }
 
+// reportConditionErrors reports the conditions of the VRG that turned to an error since its status was read, or
+// changed their error, as errors of the operator of the cluster
+func (v *VRGInstance) reportConditionErrors() {
+	for i := range v.instance.Status.Conditions {
+		condition := &v.instance.Status.Conditions[i]
+		if condition.Status != metav1.ConditionFalse || !isVRGReasonError(condition) {
+			continue
+		}
+
+		saved := util.FindCondition(v.savedInstanceStatus.Conditions, condition.Type)
+		if saved != nil && saved.Status == condition.Status && saved.Reason == condition.Reason &&
+			saved.Message == condition.Message {
+			continue
+		}
+
+		v.reconciler.OperandErrors.Report("VolumeReplicationGroup "+v.namespacedName,
+			condition.Type+": "+condition.Message)
+	}
+}
+
 func (v *VRGInstance) s3StoreAccessorsGet() {
 	vrg := v.instance
 	v.s3StoreAccessors = s3StoreAccessorsGet(
@@ -0,0 +1,536 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/kubeobjects"
+	"github.com/ramendr/ramen/internal/controller/util"
+	"github.com/ramendr/ramen/internal/controller/volsync"
+)
+
+// errVRGDrillFailed fails a drill, which is not retried
+var errVRGDrillFailed = errors.New("drill failed")
+
+// drillExcludedResources are not restored by the kube objects recovery of a drill, as the drill restores the PVCs
+// from their replicas, and the volumes and snapshots they bind to belong to the protected workload
+var drillExcludedResources = []string{
+	"persistentvolumeclaims",
+	"persistentvolumes",
+	"volumesnapshots.snapshot.storage.k8s.io",
+	"volumesnapshotcontents.snapshot.storage.k8s.io",
+}
+
+// kubeObjectsSourceVRGName returns the name of the VRG whose captures the kube objects are recovered from: the VRG a
+// drill VRG drills, else the VRG itself
+func (v *VRGInstance) kubeObjectsSourceVRGName() string {
+	if v.instance.Spec.Drill != nil {
+		return v.instance.Spec.Drill.SourceVRGName
+	}
+
+	return v.instance.Name
+}
+
+// processDrill restores, into the drill namespace, the PVCs replicated by VolSync to the source VRG from their latest
+// snapshots and the kube objects of its latest capture, without touching the source VRG or its namespace. The drill
+// namespace, and the snapshot contents the PVCs are restored from, are deleted with the drill VRG.
+func (v *VRGInstance) processDrill() ctrl.Result {
+	v.log = v.log.WithValues("drill", v.instance.Spec.Drill.Namespace)
+	v.s3StoreAccessorsGet()
+
+	if util.ResourceIsDeleted(v.instance) {
+		return v.drillFinalize()
+	}
+
+	if v.instance.Status.Drill == nil {
+		v.instance.Status.Drill = &ramen.VRGDrillStatus{Phase: ramen.VRGDrillRestoring}
+	}
+
+	status := v.instance.Status.Drill
+	if status.Phase != ramen.VRGDrillRestoring {
+		return v.drillStatusUpdate(ctrl.Result{})
+	}
+
+	if err := v.addFinalizer(vrgFinalizerName); err != nil {
+		return v.dataError(err, "Failed to add finalizer to VolumeReplicationGroup", true)
+	}
+
+	restored, err := v.drillRestore()
+
+	switch {
+	case errors.Is(err, errVRGDrillFailed):
+		v.log.Info("Drill failed", "error", err)
+		status.Phase, status.Message = ramen.VRGDrillFailed, err.Error()
+
+		return v.drillStatusUpdate(ctrl.Result{})
+	case err != nil:
+		v.log.Info("Drill restoring", "waiting", err)
+		status.Message = err.Error()
+	case !restored:
+		status.Message = "waiting for the restored PVCs to bind"
+	default:
+		v.log.Info("Drill restored", "pvcs", status.RestoredPVCs, "kubeObjects", status.KubeObjectsRestored)
+		status.Phase, status.Message = ramen.VRGDrillRestored, "restored"
+
+		return v.drillStatusUpdate(ctrl.Result{})
+	}
+
+	return v.drillStatusUpdate(ctrl.Result{RequeueAfter: drDrillRequeueInterval})
+}
+
+func (v *VRGInstance) drillStatusUpdate(result ctrl.Result) ctrl.Result {
+	v.instance.Status.ObservedGeneration = v.instance.Generation
+
+	if reflect.DeepEqual(v.savedInstanceStatus, v.instance.Status) {
+		return result
+	}
+
+	v.instance.Status.LastUpdateTime = metav1.Now()
+	if err := v.reconciler.Status().Update(v.ctx, v.instance); err != nil {
+		v.log.Info(fmt.Sprintf("Failed to update VRG status (%v/%s)", err, v.instance.Name))
+
+		result.Requeue = true
+	}
+
+	return result
+}
+
+// drillRestore restores the drill namespace, and returns whether its PVCs are bound and its kube objects recovered
+func (v *VRGInstance) drillRestore() (bool, error) {
+	drill := v.instance.Spec.Drill
+	status := v.instance.Status.Drill
+
+	if v.instance.Spec.ProtectedNamespaces != nil && len(*v.instance.Spec.ProtectedNamespaces) != 0 {
+		return false, fmt.Errorf("%w: drills of VRGs protecting multiple namespaces are not supported",
+			errVRGDrillFailed)
+	}
+
+	if drill.Namespace == v.instance.Namespace {
+		return false, fmt.Errorf("%w: namespace %s is that of the workload", errVRGDrillFailed, drill.Namespace)
+	}
+
+	source := &ramen.VolumeReplicationGroup{}
+	if err := v.reconciler.APIReader.Get(v.ctx, types.NamespacedName{
+		Namespace: v.instance.Namespace, Name: drill.SourceVRGName,
+	}, source); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, fmt.Errorf("%w: VRG %s not found", errVRGDrillFailed, drill.SourceVRGName)
+		}
+
+		return false, fmt.Errorf("VRG %s get: %w", drill.SourceVRGName, err)
+	}
+
+	if err := v.drillNamespaceEnsure(); err != nil {
+		return false, err
+	}
+
+	status.SkippedPVCs = nil
+
+	for i := range source.Status.ProtectedPVCs {
+		if !source.Status.ProtectedPVCs[i].ProtectedByVolSync {
+			status.SkippedPVCs = append(status.SkippedPVCs, source.Status.ProtectedPVCs[i].Name)
+		}
+	}
+
+	status.RestoredPVCs = nil
+
+	for i := range v.instance.Spec.VolSync.RDSpec {
+		bound, err := v.drillPVCRestore(&v.instance.Spec.VolSync.RDSpec[i].ProtectedPVC)
+		if err != nil {
+			return false, err
+		}
+
+		if bound {
+			status.RestoredPVCs = append(status.RestoredPVCs, v.instance.Spec.VolSync.RDSpec[i].ProtectedPVC.Name)
+		}
+	}
+
+	kubeObjectsDisabled := v.kubeObjectProtectionDisabled("drill")
+	if !kubeObjectsDisabled && !status.KubeObjectsRestored {
+		if err := v.drillKubeObjectsRestore(source); err != nil {
+			return false, err
+		}
+
+		status.KubeObjectsRestored = true
+	}
+
+	return len(status.RestoredPVCs) == len(v.instance.Spec.VolSync.RDSpec) &&
+		(kubeObjectsDisabled || status.KubeObjectsRestored), nil
+}
+
+// drillNamespaceOwned returns whether namespace was created by the drill VRG
+func (v *VRGInstance) drillNamespaceOwned(namespace *corev1.Namespace) bool {
+	for key, value := range util.OwnerLabels(v.instance) {
+		if namespace.Labels[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// drillNamespaceEnsure creates the drill namespace, and fails the drill if it exists and was not created by it
+func (v *VRGInstance) drillNamespaceEnsure() error {
+	name := v.instance.Spec.Drill.Namespace
+
+	namespace := &corev1.Namespace{}
+	if err := v.reconciler.Client.Get(v.ctx, types.NamespacedName{Name: name}, namespace); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("namespace %s get: %w", name, err)
+		}
+
+		namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: util.OwnerLabels(v.instance)}}
+		if err := v.reconciler.Client.Create(v.ctx, namespace); err != nil {
+			return fmt.Errorf("namespace %s create: %w", name, err)
+		}
+
+		v.log.Info("Drill namespace created")
+
+		return nil
+	}
+
+	if !v.drillNamespaceOwned(namespace) {
+		return fmt.Errorf("%w: namespace %s exists", errVRGDrillFailed, name)
+	}
+
+	if util.ResourceIsDeleted(namespace) {
+		return fmt.Errorf("namespace %s is being deleted", name)
+	}
+
+	return nil
+}
+
+// drillSnapshotContentName returns the name of the snapshot content the drill restores the PVC of pvcName from
+func drillSnapshotContentName(namespace, pvcName string) string {
+	return fmt.Sprintf("ramen-drill-%s-%s", namespace, pvcName)
+}
+
+// drillPVCRestore restores, into the drill namespace, the PVC protectedPVC from the latest snapshot its
+// ReplicationDestination replicated, and returns whether it is bound or waits for its first consumer to bind. As
+// snapshots are namespaced, the PVC is restored from a snapshot of a copy of the content of the latest snapshot, whose
+// Retain deletion policy keeps the snapshot of the storage, which the ReplicationDestination owns.
+func (v *VRGInstance) drillPVCRestore(protectedPVC *ramen.ProtectedPVC) (bool, error) {
+	namespace := v.instance.Spec.Drill.Namespace
+
+	pvc := &corev1.PersistentVolumeClaim{}
+
+	err := v.reconciler.Client.Get(v.ctx, types.NamespacedName{Namespace: namespace, Name: protectedPVC.Name}, pvc)
+	if err == nil {
+		return v.drillPVCBound(pvc)
+	}
+
+	if !k8serrors.IsNotFound(err) {
+		return false, fmt.Errorf("PVC %s/%s get: %w", namespace, protectedPVC.Name, err)
+	}
+
+	snapshot, err := v.drillLatestSnapshot(protectedPVC)
+	if err != nil {
+		return false, err
+	}
+
+	if err := v.drillSnapshotCopy(protectedPVC, snapshot); err != nil {
+		return false, err
+	}
+
+	restoreSize := protectedPVC.Resources.Requests.Storage()
+	if snapshot.Status.RestoreSize != nil && (restoreSize == nil || snapshot.Status.RestoreSize.Cmp(*restoreSize) > 0) {
+		restoreSize = snapshot.Status.RestoreSize
+	}
+
+	accessModes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	if len(protectedPVC.AccessModes) != 0 {
+		accessModes = protectedPVC.AccessModes
+	}
+
+	pvc = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: protectedPVC.Name, Namespace: namespace,
+			Labels: maps.Clone(protectedPVC.Labels)},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      accessModes,
+			StorageClassName: protectedPVC.StorageClassName,
+			VolumeMode:       protectedPVC.VolumeMode,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: *restoreSize},
+			},
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: ptr.To(snapv1.SchemeGroupVersion.Group),
+				Kind:     volsync.VolumeSnapshotKind,
+				Name:     protectedPVC.Name,
+			},
+		},
+	}
+
+	util.ObjectOwnerSet(pvc, v.instance)
+
+	if err := v.reconciler.Client.Create(v.ctx, pvc); err != nil {
+		return false, fmt.Errorf("PVC %s/%s create: %w", namespace, pvc.Name, err)
+	}
+
+	v.log.Info("Drill PVC created", "pvc", pvc.Name, "snapshot", snapshot.Name, "size", restoreSize)
+
+	return false, nil
+}
+
+// drillLatestSnapshot returns the latest snapshot the ReplicationDestination of protectedPVC replicated
+func (v *VRGInstance) drillLatestSnapshot(protectedPVC *ramen.ProtectedPVC) (*snapv1.VolumeSnapshot, error) {
+	rd, err := volsync.GetRD(v.ctx, v.reconciler.Client, protectedPVC.Name, protectedPVC.Namespace, v.log)
+	if err != nil {
+		return nil, err
+	}
+
+	if rd == nil || rd.Status == nil || rd.Status.LatestImage == nil ||
+		rd.Status.LatestImage.Kind != volsync.VolumeSnapshotKind {
+		return nil, fmt.Errorf("waiting for a replica of PVC %s", protectedPVC.Name)
+	}
+
+	snapshot := &snapv1.VolumeSnapshot{}
+	if err := v.reconciler.Client.Get(v.ctx, types.NamespacedName{
+		Namespace: protectedPVC.Namespace, Name: rd.Status.LatestImage.Name,
+	}, snapshot); err != nil {
+		return nil, fmt.Errorf("snapshot %s/%s get: %w", protectedPVC.Namespace, rd.Status.LatestImage.Name, err)
+	}
+
+	if snapshot.Status == nil || snapshot.Status.BoundVolumeSnapshotContentName == nil ||
+		snapshot.Status.ReadyToUse == nil || !*snapshot.Status.ReadyToUse {
+		return nil, fmt.Errorf("waiting for snapshot %s/%s to be ready", snapshot.Namespace, snapshot.Name)
+	}
+
+	return snapshot, nil
+}
+
+// drillSnapshotCopy creates, in the drill namespace, a snapshot of the name of the PVC of protectedPVC bound to a copy
+// of the content of snapshot
+func (v *VRGInstance) drillSnapshotCopy(protectedPVC *ramen.ProtectedPVC, snapshot *snapv1.VolumeSnapshot) error {
+	namespace := v.instance.Spec.Drill.Namespace
+
+	content := &snapv1.VolumeSnapshotContent{}
+	if err := v.reconciler.Client.Get(v.ctx, types.NamespacedName{
+		Name: *snapshot.Status.BoundVolumeSnapshotContentName,
+	}, content); err != nil {
+		return fmt.Errorf("snapshot content %s get: %w", *snapshot.Status.BoundVolumeSnapshotContentName, err)
+	}
+
+	if content.Status == nil || content.Status.SnapshotHandle == nil {
+		return fmt.Errorf("waiting for snapshot content %s to have a handle", content.Name)
+	}
+
+	contentCopy := &snapv1.VolumeSnapshotContent{
+		ObjectMeta: metav1.ObjectMeta{Name: drillSnapshotContentName(namespace, protectedPVC.Name)},
+		Spec: snapv1.VolumeSnapshotContentSpec{
+			DeletionPolicy:          snapv1.VolumeSnapshotContentRetain,
+			Driver:                  content.Spec.Driver,
+			Source:                  snapv1.VolumeSnapshotContentSource{SnapshotHandle: content.Status.SnapshotHandle},
+			VolumeSnapshotClassName: content.Spec.VolumeSnapshotClassName,
+			SourceVolumeMode:        content.Spec.SourceVolumeMode,
+			VolumeSnapshotRef:       corev1.ObjectReference{Namespace: namespace, Name: protectedPVC.Name},
+		},
+	}
+
+	util.ObjectOwnerSet(contentCopy, v.instance)
+
+	if err := v.reconciler.Client.Create(v.ctx, contentCopy); client.IgnoreAlreadyExists(err) != nil {
+		return fmt.Errorf("snapshot content %s create: %w", contentCopy.Name, err)
+	}
+
+	snapshotCopy := &snapv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: protectedPVC.Name, Namespace: namespace},
+		Spec: snapv1.VolumeSnapshotSpec{
+			Source:                  snapv1.VolumeSnapshotSource{VolumeSnapshotContentName: &contentCopy.Name},
+			VolumeSnapshotClassName: snapshot.Spec.VolumeSnapshotClassName,
+		},
+	}
+
+	util.ObjectOwnerSet(snapshotCopy, v.instance)
+
+	if err := v.reconciler.Client.Create(v.ctx, snapshotCopy); client.IgnoreAlreadyExists(err) != nil {
+		return fmt.Errorf("snapshot %s/%s create: %w", namespace, snapshotCopy.Name, err)
+	}
+
+	return nil
+}
+
+// drillPVCBound returns whether pvc is bound, or waits for its first consumer to bind, which the drill may not
+// restore if the kube objects are not protected
+func (v *VRGInstance) drillPVCBound(pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	if pvc.Status.Phase == corev1.ClaimBound {
+		return true, nil
+	}
+
+	if pvc.Spec.StorageClassName == nil {
+		return false, nil
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	if err := v.reconciler.Client.Get(v.ctx, types.NamespacedName{Name: *pvc.Spec.StorageClassName},
+		storageClass); err != nil {
+		return false, fmt.Errorf("storage class %s get: %w", *pvc.Spec.StorageClassName, err)
+	}
+
+	return storageClass.VolumeBindingMode != nil &&
+		*storageClass.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer, nil
+}
+
+// drillRecoverWorkflow returns the groups of workflow, recovering the kube objects of namespace, mapped to recover
+// them into the drill namespace instead. Hooks are skipped, as they run in the namespace of the workload.
+func drillRecoverWorkflow(workflow []kubeobjects.RecoverSpec, namespace, drillNamespace string,
+) ([]kubeobjects.RecoverSpec, error) {
+	groups := []kubeobjects.RecoverSpec{}
+
+	for _, group := range workflow {
+		if group.IsHook {
+			continue
+		}
+
+		for _, includedNamespace := range group.IncludedNamespaces {
+			if includedNamespace != namespace {
+				return nil, fmt.Errorf("%w: group %s recovers namespace %s", errVRGDrillFailed, group.BackupName,
+					includedNamespace)
+			}
+		}
+
+		group.IncludedNamespaces = []string{namespace}
+		group.NamespaceMapping = map[string]string{namespace: drillNamespace}
+		group.ExcludedResources = append(slices.Clone(group.ExcludedResources), drillExcludedResources...)
+		group.IncludeClusterResources = ptr.To(false)
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// drillKubeObjectsRestore recovers the kube objects of the latest capture of source into the drill namespace, from
+// the first S3 profile that has it
+func (v *VRGInstance) drillKubeObjectsRestore(source *ramen.VolumeReplicationGroup) error {
+	if len(v.s3StoreAccessors) == 0 {
+		return fmt.Errorf("%w: no S3 profile to recover the kube objects from", errVRGDrillFailed)
+	}
+
+	recipeElements, err := RecipeElementsGet(v.ctx, v.reconciler.Client, *source, *v.ramenConfig, v.log)
+	if err != nil {
+		return fmt.Errorf("%w: recipe get: %v", errVRGDrillFailed, err)
+	}
+
+	recipeElements.RecoverWorkflow, err = drillRecoverWorkflow(recipeElements.RecoverWorkflow, source.Namespace,
+		v.instance.Spec.Drill.Namespace)
+	if err != nil {
+		return err
+	}
+
+	v.recipeElements = recipeElements
+
+	for _, accessor := range v.s3StoreAccessors {
+		err = v.drillKubeObjectsRestoreFromS3(accessor)
+		if err == nil || errors.Is(err, errVRGDrillFailed) {
+			return err
+		}
+
+		v.log.Info("Drill kube objects recover", "profile", accessor.S3ProfileName, "error", err)
+	}
+
+	return fmt.Errorf("waiting for the kube objects to be recovered: %w", err)
+}
+
+func (v *VRGInstance) drillKubeObjectsRestoreFromS3(accessor s3StoreAccessor) error {
+	sourceVrg, err := v.getVRGFromS3Profile(accessor.S3ProfileName)
+	if err != nil {
+		return err
+	}
+
+	captureToRecoverFrom := sourceVrg.Status.KubeObjectProtection.CaptureToRecoverFrom
+	if captureToRecoverFrom == nil {
+		return fmt.Errorf("no capture of the kube objects in S3 profile %s", accessor.S3ProfileName)
+	}
+
+	captureRequests, err := v.getCaptureRequests()
+	if err != nil {
+		return err
+	}
+
+	recoverRequests, err := v.getRecoverRequests()
+	if err != nil {
+		return err
+	}
+
+	result := ctrl.Result{}
+	requests := make([]kubeobjects.Request, len(v.recipeElements.RecoverWorkflow))
+	log := v.log.WithValues("number", captureToRecoverFrom.Number, "profile", accessor.S3ProfileName)
+
+	allEssentialStepsFailed, err := v.executeRecoverSteps(&result, accessor, captureToRecoverFrom, captureRequests,
+		recoverRequests, requests, log)
+	if err != nil {
+		return err
+	}
+
+	if allEssentialStepsFailed {
+		return fmt.Errorf("%w: essential kube objects groups failed to recover", errVRGDrillFailed)
+	}
+
+	return v.kubeObjectsRecoverRequestsDelete(&result, v.veleroNamespaceName(), util.OwnerLabels(v.instance))
+}
+
+// drillFinalize deletes the drill namespace, once deleted the snapshot contents its PVCs were restored from, and the
+// kube objects recover requests, and removes the finalizer of the drill VRG
+func (v *VRGInstance) drillFinalize() ctrl.Result {
+	name := v.instance.Spec.Drill.Namespace
+
+	namespace := &corev1.Namespace{}
+
+	err := v.reconciler.Client.Get(v.ctx, types.NamespacedName{Name: name}, namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return v.dataError(err, "Failed to get the drill namespace", true)
+	}
+
+	if err == nil && v.drillNamespaceOwned(namespace) {
+		if !util.ResourceIsDeleted(namespace) {
+			if err := v.reconciler.Client.Delete(v.ctx, namespace); client.IgnoreNotFound(err) != nil {
+				return v.dataError(err, "Failed to delete the drill namespace", true)
+			}
+
+			v.log.Info("Drill namespace deleted")
+		}
+
+		return ctrl.Result{RequeueAfter: drDrillRequeueInterval}
+	}
+
+	contents := &snapv1.VolumeSnapshotContentList{}
+	if err := v.reconciler.Client.List(v.ctx, contents, client.MatchingLabels(util.OwnerLabels(v.instance))); err != nil {
+		return v.dataError(err, "Failed to list the drill snapshot contents", true)
+	}
+
+	for i := range contents.Items {
+		if err := v.reconciler.Client.Delete(v.ctx, &contents.Items[i]); client.IgnoreNotFound(err) != nil {
+			return v.dataError(err, "Failed to delete a drill snapshot content", true)
+		}
+	}
+
+	if !v.kubeObjectProtectionDisabled("drill cleanup") {
+		result := ctrl.Result{}
+		if err := v.kubeObjectsRecoverRequestsDelete(&result, v.veleroNamespaceName(),
+			util.OwnerLabels(v.instance)); err != nil {
+			return v.dataError(err, "Failed to delete the drill kube objects recover requests", true)
+		}
+	}
+
+	if err := v.removeFinalizer(vrgFinalizerName); err != nil {
+		return v.dataError(err, "Failed to remove finalizer from VolumeReplicationGroup", true)
+	}
+
+	return ctrl.Result{}
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/go-logr/logr"
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("VRG drill", func() {
+	const drillNamespace = "app-drill"
+
+	var (
+		fakeClient client.Client
+		v          *VRGInstance
+	)
+
+	drillPVC := func() *corev1.PersistentVolumeClaim {
+		pvc := &corev1.PersistentVolumeClaim{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: drillNamespace, Name: "pvc"}, pvc)).
+			To(Succeed())
+
+		return pvc
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(ramen.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(snapv1.AddToScheme(scheme)).To(Succeed())
+		Expect(volsyncv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		drillVRG := &ramen.VolumeReplicationGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc-drill"},
+			Spec: ramen.VolumeReplicationGroupSpec{
+				ReplicationState: ramen.Secondary,
+				VolSync: ramen.VolSyncSpec{RDSpec: []ramen.VolSyncReplicationDestinationSpec{{
+					ProtectedPVC: ramen.ProtectedPVC{
+						Namespace:        "app",
+						Name:             "pvc",
+						StorageClassName: ptr.To("cephfs"),
+						Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("1Gi"),
+						}},
+					},
+				}}},
+				Drill: &ramen.VRGDrillSpec{SourceVRGName: "drpc", Namespace: drillNamespace},
+			},
+			Status: ramen.VolumeReplicationGroupStatus{
+				Drill: &ramen.VRGDrillStatus{Phase: ramen.VRGDrillRestoring},
+			},
+		}
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			drillVRG,
+			&ramen.VolumeReplicationGroup{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "drpc"},
+				Status: ramen.VolumeReplicationGroupStatus{ProtectedPVCs: []ramen.ProtectedPVC{
+					{Namespace: "app", Name: "pvc", ProtectedByVolSync: true},
+					{Namespace: "app", Name: "rbd"},
+				}},
+			},
+			&volsyncv1alpha1.ReplicationDestination{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: util.GetReplicationDestinationName("pvc")},
+				Status: &volsyncv1alpha1.ReplicationDestinationStatus{
+					LatestImage: &corev1.TypedLocalObjectReference{
+						APIGroup: ptr.To(snapv1.SchemeGroupVersion.Group), Kind: "VolumeSnapshot", Name: "snap",
+					},
+				},
+			},
+			&snapv1.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "snap"},
+				Spec:       snapv1.VolumeSnapshotSpec{VolumeSnapshotClassName: ptr.To("cephfs")},
+				Status: &snapv1.VolumeSnapshotStatus{
+					BoundVolumeSnapshotContentName: ptr.To("content"),
+					ReadyToUse:                     ptr.To(true),
+					RestoreSize:                    ptr.To(resource.MustParse("2Gi")),
+				},
+			},
+			&snapv1.VolumeSnapshotContent{
+				ObjectMeta: metav1.ObjectMeta{Name: "content"},
+				Spec: snapv1.VolumeSnapshotContentSpec{
+					DeletionPolicy: snapv1.VolumeSnapshotContentDelete,
+					Driver:         "cephfs.csi.ceph.com",
+				},
+				Status: &snapv1.VolumeSnapshotContentStatus{SnapshotHandle: ptr.To("handle")},
+			},
+		).Build()
+
+		v = &VRGInstance{
+			reconciler:  &VolumeReplicationGroupReconciler{Client: fakeClient, APIReader: fakeClient},
+			ctx:         context.TODO(),
+			log:         logr.Discard(),
+			instance:    drillVRG,
+			ramenConfig: &ramen.RamenConfig{},
+		}
+	})
+
+	It("restores the PVCs replicated by VolSync from a copy of their latest snapshot", func() {
+		restored, err := v.drillRestore()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(restored).To(BeFalse())
+		Expect(v.instance.Status.Drill.SkippedPVCs).To(Equal([]string{"rbd"}))
+
+		namespace := &corev1.Namespace{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Name: drillNamespace}, namespace)).To(Succeed())
+		Expect(v.drillNamespaceOwned(namespace)).To(BeTrue())
+
+		content := &snapv1.VolumeSnapshotContent{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Name: drillSnapshotContentName(drillNamespace, "pvc")},
+			content)).To(Succeed())
+		Expect(content.Spec.DeletionPolicy).To(Equal(snapv1.VolumeSnapshotContentRetain))
+		Expect(content.Spec.Source.SnapshotHandle).To(Equal(ptr.To("handle")))
+		Expect(content.Spec.VolumeSnapshotRef.Namespace).To(Equal(drillNamespace))
+
+		pvc := drillPVC()
+		Expect(pvc.Spec.DataSource.Name).To(Equal("pvc"))
+		Expect(pvc.Spec.Resources.Requests.Storage().String()).To(Equal("2Gi"))
+
+		pvc.Status.Phase = corev1.ClaimBound
+		Expect(fakeClient.Status().Update(context.TODO(), pvc)).To(Succeed())
+
+		restored, err = v.drillRestore()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(restored).To(BeTrue())
+		Expect(v.instance.Status.Drill.RestoredPVCs).To(Equal([]string{"pvc"}))
+	})
+
+	It("fails if the drill namespace exists", func() {
+		Expect(fakeClient.Create(context.TODO(), &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: drillNamespace},
+		})).To(Succeed())
+
+		_, err := v.drillRestore()
+		Expect(err).To(MatchError(errVRGDrillFailed))
+	})
+
+	It("deletes the drill namespace and snapshot contents before the drill VRG", func() {
+		_, err := v.drillRestore()
+		Expect(err).ToNot(HaveOccurred())
+
+		v.instance.Finalizers = []string{vrgFinalizerName}
+		Expect(fakeClient.Update(context.TODO(), v.instance)).To(Succeed())
+
+		Expect(v.drillFinalize().RequeueAfter).ToNot(BeZero())
+		Expect(k8serrors.IsNotFound(fakeClient.Get(context.TODO(), types.NamespacedName{Name: drillNamespace},
+			&corev1.Namespace{}))).To(BeTrue())
+		Expect(v.instance.Finalizers).To(ContainElement(vrgFinalizerName))
+
+		Expect(v.drillFinalize().RequeueAfter).To(BeZero())
+		Expect(k8serrors.IsNotFound(fakeClient.Get(context.TODO(),
+			types.NamespacedName{Name: drillSnapshotContentName(drillNamespace, "pvc")},
+			&snapv1.VolumeSnapshotContent{}))).To(BeTrue())
+		Expect(v.instance.Finalizers).ToNot(ContainElement(vrgFinalizerName))
+	})
+})
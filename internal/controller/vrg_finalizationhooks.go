@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/hooks"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// finalizationHooksExecutedAnnotation records on a VRG that its finalization hooks were run, so that they are
+	// not run again while the remaining deletion steps are retried
+	finalizationHooksExecutedAnnotation = "ramendr.openshift.io/finalization-hooks-executed"
+
+	finalizationHooksRetryInterval = 30 * time.Second
+
+	// finalizationHooksTimeoutDefault is the timeout of finalization hooks whose timeoutSeconds is not set, as in
+	// VRGs created before it was defaulted
+	finalizationHooksTimeoutDefault = 600 * time.Second
+)
+
+// finalizationHooksExecute runs the finalization hooks of a primary VRG that is being deleted, before its protection
+// is removed. Hooks are skipped if the skip annotation is set, or once the timeout since the deletion elapsed.
+// Returns true if deletion may proceed, else the result to requeue with.
+func (v *VRGInstance) finalizationHooksExecute() (bool, ctrl.Result) {
+	spec := v.instance.Spec.FinalizationHooks
+	if spec == nil || v.instance.Spec.ReplicationState != ramen.Primary ||
+		util.HasAnnotation(v.instance, finalizationHooksExecutedAnnotation) {
+		return true, ctrl.Result{}
+	}
+
+	log := v.log.WithValues("finalizationHooks", spec.Hooks)
+
+	if v.instance.GetAnnotations()[SkipFinalizationHooksAnnotation] == SkipFinalizationHooksAnnotationVal {
+		log.Info("Finalization hooks skipped by annotation")
+		util.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeNormal,
+			util.EventReasonFinalizationHooksSkipped,
+			"Finalization hooks skipped by annotation "+SkipFinalizationHooksAnnotation)
+
+		return true, ctrl.Result{}
+	}
+
+	timeout := finalizationHooksTimeout(spec)
+	if deleted := v.instance.GetDeletionTimestamp(); deleted != nil && time.Since(deleted.Time) > timeout {
+		log.Info("Finalization hooks timed out", "timeout", timeout)
+		util.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+			util.EventReasonFinalizationHooksSkipped,
+			fmt.Sprintf("Finalization hooks did not succeed within %v, proceeding with deletion", timeout))
+
+		return true, ctrl.Result{}
+	}
+
+	if v.recipeElements.RecipeWithParams == nil {
+		log.Info("Finalization hooks skipped as the VRG has no recipe")
+
+		return true, ctrl.Result{}
+	}
+
+	for _, name := range spec.Hooks {
+		if err := v.finalizationHookExecute(name); err != nil {
+			log.Info("Finalization hook failed", "hook", name, "error", err)
+			util.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+				util.EventReasonFinalizationHooksFailed,
+				fmt.Sprintf("Finalization hook %s failed: %v", name, err))
+
+			return false, ctrl.Result{RequeueAfter: finalizationHooksRetryInterval}
+		}
+	}
+
+	if err := v.finalizationHooksExecutedMark(); err != nil {
+		log.Info("Failed to record finalization hooks execution", "error", err)
+
+		return false, ctrl.Result{Requeue: true}
+	}
+
+	log.Info("Finalization hooks executed")
+	util.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance,
+		corev1.EventTypeNormal,
+		util.EventReasonFinalizationHooksExecuted,
+		"Finalization hooks executed")
+
+	return true, ctrl.Result{}
+}
+
+// finalizationHooksTimeout returns the timeout of the finalization hooks of spec, the default if it is not set
+func finalizationHooksTimeout(spec *ramen.FinalizationHooksSpec) time.Duration {
+	if spec.TimeoutSeconds <= 0 {
+		return finalizationHooksTimeoutDefault
+	}
+
+	return time.Duration(spec.TimeoutSeconds) * time.Second
+}
+
+func (v *VRGInstance) finalizationHookExecute(name string) error {
+	prefix, suffix, err := validateAndGetHookDetails(name)
+	if err != nil {
+		return err
+	}
+
+	hook, err := getHookFromRecipe(v.recipeElements.RecipeWithParams, prefix)
+	if err != nil {
+		return err
+	}
+
+	executor, err := hooks.GetHookExecutor(hooks.HookContext{
+		Hook:           getHookSpecFromHook(*hook, suffix),
+		Client:         v.reconciler.Client,
+		Reader:         v.reconciler.APIReader,
+		Scheme:         v.reconciler.Scheme,
+		RecipeElements: v.recipeElements,
+	})
+	if err != nil {
+		return err
+	}
+
+	return executor.Execute(v.log.WithValues("hook", name))
+}
+
+func (v *VRGInstance) finalizationHooksExecutedMark() error {
+	patch := client.MergeFrom(v.instance.DeepCopy())
+
+	util.AddAnnotation(v.instance, finalizationHooksExecutedAnnotation, time.Now().UTC().Format(time.RFC3339))
+
+	return v.reconciler.Client.Patch(v.ctx, v.instance, patch)
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	recipev1 "github.com/ramendr/recipe/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("Finalization hooks", func() {
+	var v *VRGInstance
+
+	deletedAgo := func(ago time.Duration) {
+		v.instance.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-ago)}
+	}
+
+	BeforeEach(func() {
+		v = &VRGInstance{
+			reconciler: &VolumeReplicationGroupReconciler{
+				eventRecorder: util.NewEventReporter(record.NewFakeRecorder(10)),
+			},
+			ctx: context.TODO(),
+			log: logr.Discard(),
+			instance: &ramen.VolumeReplicationGroup{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "vrg"},
+				Spec: ramen.VolumeReplicationGroupSpec{
+					ReplicationState:  ramen.Primary,
+					FinalizationHooks: &ramen.FinalizationHooksSpec{Hooks: []string{"missing/op"}},
+				},
+			},
+			recipeElements: util.RecipeElements{RecipeWithParams: &recipev1.Recipe{}},
+		}
+	})
+
+	It("defaults the timeout to 600 seconds if it is omitted", func() {
+		Expect(finalizationHooksTimeout(v.instance.Spec.FinalizationHooks)).To(Equal(600 * time.Second))
+
+		v.instance.Spec.FinalizationHooks.TimeoutSeconds = 60
+		Expect(finalizationHooksTimeout(v.instance.Spec.FinalizationHooks)).To(Equal(time.Minute))
+	})
+
+	It("retries the failing hooks of a VRG deleted within the default timeout", func() {
+		deletedAgo(5 * time.Minute)
+
+		proceed, result := v.finalizationHooksExecute()
+		Expect(proceed).To(BeFalse())
+		Expect(result.RequeueAfter).To(Equal(finalizationHooksRetryInterval))
+	})
+
+	It("proceeds with the deletion once the default timeout elapsed", func() {
+		deletedAgo(11 * time.Minute)
+
+		proceed, _ := v.finalizationHooksExecute()
+		Expect(proceed).To(BeTrue())
+	})
+})
@@ -0,0 +1,268 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/kubeobjects"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+const (
+	// helmReleasesGroupName is the name of the capture group of the Helm release secrets
+	helmReleasesGroupName = "helm-releases"
+
+	helmReleaseSecretOwnerLabel   = "owner"
+	helmReleaseSecretOwner        = "helm"
+	helmReleaseSecretNameLabel    = "name"
+	helmReleaseSecretVersionLabel = "version"
+
+	helmManagedByLabel             = "app.kubernetes.io/managed-by"
+	helmManagedBy                  = "Helm"
+	helmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	helmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+)
+
+var helmManifestSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// helmRelease is the part of a Helm release, as stored in its release secrets, that is needed to re-adopt its objects
+type helmRelease struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   int    `json:"version"`
+	Manifest  string `json:"manifest"`
+	Info      struct {
+		Status string `json:"status"`
+	} `json:"info"`
+}
+
+// helmReleasesSelector returns the label selector of the release secrets of the Helm releases named releaseNames
+func helmReleasesSelector(releaseNames []string) *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchLabels: map[string]string{helmReleaseSecretOwnerLabel: helmReleaseSecretOwner},
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      helmReleaseSecretNameLabel,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   releaseNames,
+		}},
+	}
+}
+
+// helmReleasesWorkflowsAppend appends a group capturing and recovering the release secrets of the Helm releases of
+// vrg to its capture and recover workflows, so that they are protected whether or not the kube object selector or the
+// recipe select them
+func helmReleasesWorkflowsAppend(recipeElements *util.RecipeElements, vrg ramen.VolumeReplicationGroup,
+	ramenConfig ramen.RamenConfig,
+) {
+	if len(vrg.Spec.KubeObjectProtection.HelmReleases) == 0 {
+		return
+	}
+
+	spec := func() kubeobjects.Spec {
+		return kubeobjects.Spec{
+			KubeResourcesSpec: kubeobjects.KubeResourcesSpec{
+				IncludedNamespaces: kubeObjectsProtectedNamespaces(vrg, ramenConfig),
+				IncludedResources:  []string{"secrets"},
+			},
+			LabelSelector: helmReleasesSelector(vrg.Spec.KubeObjectProtection.HelmReleases),
+		}
+	}
+
+	recipeElements.CaptureWorkflow = append(recipeElements.CaptureWorkflow,
+		kubeobjects.CaptureSpec{Name: helmReleasesGroupName, Spec: spec()})
+	recipeElements.RecoverWorkflow = append(recipeElements.RecoverWorkflow,
+		kubeobjects.RecoverSpec{BackupName: helmReleasesGroupName, Spec: spec()})
+}
+
+// helmReleasesAdopt re-adopts the recovered kube objects of each Helm release of the VRG into the release, setting
+// the Helm ownership metadata of each object of the manifest of its latest revision that is missing or mismatched,
+// so that Helm manages them on this cluster as it did on the cluster they were captured on
+func (v *VRGInstance) helmReleasesAdopt(log logr.Logger) error {
+	for _, namespaceName := range kubeObjectsProtectedNamespaces(*v.instance, *v.ramenConfig) {
+		for _, releaseName := range v.instance.Spec.KubeObjectProtection.HelmReleases {
+			release, err := v.helmReleaseLatestGet(namespaceName, releaseName)
+			if err != nil {
+				return err
+			}
+
+			if release == nil {
+				continue
+			}
+
+			log1 := log.WithValues("release", releaseName, "namespace", namespaceName, "version", release.Version)
+
+			if strings.HasPrefix(release.Info.Status, "pending-") {
+				log1.Info("Helm release recovered with an operation in progress, roll it back before upgrading it",
+					"status", release.Info.Status)
+			}
+
+			adopted, err := v.helmReleaseObjectsAdopt(release, namespaceName)
+			if err != nil {
+				return fmt.Errorf("helm release %s/%s re-adoption error: %w", namespaceName, releaseName, err)
+			}
+
+			log1.Info("Helm release re-adopted", "objects", adopted)
+		}
+	}
+
+	return nil
+}
+
+// helmReleaseLatestGet returns the latest revision of the Helm release releaseName in the namespace, or nil if it
+// has no release secret
+func (v *VRGInstance) helmReleaseLatestGet(namespaceName, releaseName string) (*helmRelease, error) {
+	secrets := &corev1.SecretList{}
+	if err := v.reconciler.APIReader.List(v.ctx, secrets, client.InNamespace(namespaceName), client.MatchingLabels{
+		helmReleaseSecretOwnerLabel: helmReleaseSecretOwner,
+		helmReleaseSecretNameLabel:  releaseName,
+	}); err != nil {
+		return nil, fmt.Errorf("helm release %s/%s secrets list error: %w", namespaceName, releaseName, err)
+	}
+
+	var latest *corev1.Secret
+
+	latestVersion := -1
+
+	for i := range secrets.Items {
+		version, err := strconv.Atoi(secrets.Items[i].Labels[helmReleaseSecretVersionLabel])
+		if err != nil || version <= latestVersion {
+			continue
+		}
+
+		latest, latestVersion = &secrets.Items[i], version
+	}
+
+	if latest == nil {
+		return nil, nil
+	}
+
+	release, err := helmReleaseDecode(latest.Data["release"])
+	if err != nil {
+		return nil, fmt.Errorf("helm release secret %s/%s decode error: %w", namespaceName, latest.Name, err)
+	}
+
+	return release, nil
+}
+
+// helmReleaseDecode decodes a Helm release as encoded in its release secret: base64 encoded, and gzip compressed
+// unless it is plain JSON
+func helmReleaseDecode(data []byte) (*helmRelease, error) {
+	decoded, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(decoded, []byte{0x1f, 0x8b}) {
+		reader, err := gzip.NewReader(bytes.NewReader(decoded))
+		if err != nil {
+			return nil, err
+		}
+
+		defer reader.Close()
+
+		if decoded, err = io.ReadAll(reader); err != nil {
+			return nil, err
+		}
+	}
+
+	release := &helmRelease{}
+
+	return release, json.Unmarshal(decoded, release)
+}
+
+// helmManifestObjects returns the objects of a Helm release manifest, defaulting their namespace to namespaceName
+func helmManifestObjects(manifest, namespaceName string) ([]*unstructured.Unstructured, error) {
+	objects := []*unstructured.Unstructured{}
+
+	for _, document := range helmManifestSeparator.Split(manifest, -1) {
+		object := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(document), &object.Object); err != nil {
+			return nil, err
+		}
+
+		if len(object.Object) == 0 || object.GetKind() == "" || object.GetName() == "" {
+			continue
+		}
+
+		if object.GetNamespace() == "" {
+			object.SetNamespace(namespaceName)
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// helmReleaseObjectsAdopt sets the Helm ownership metadata of the objects of release that exist in the cluster and
+// lack it, and returns the number of objects adopted. Objects that were not recovered, or whose kind the cluster does
+// not serve, are skipped.
+func (v *VRGInstance) helmReleaseObjectsAdopt(release *helmRelease, namespaceName string) (int, error) {
+	objects, err := helmManifestObjects(release.Manifest, namespaceName)
+	if err != nil {
+		return 0, fmt.Errorf("manifest parse error: %w", err)
+	}
+
+	adopted := 0
+
+	for _, manifestObject := range objects {
+		object := &unstructured.Unstructured{}
+		object.SetGroupVersionKind(manifestObject.GroupVersionKind())
+
+		key := types.NamespacedName{Namespace: manifestObject.GetNamespace(), Name: manifestObject.GetName()}
+		if err := v.reconciler.APIReader.Get(v.ctx, key, object); err != nil {
+			if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+
+			return adopted, fmt.Errorf("%s %s get error: %w", object.GetKind(), key, err)
+		}
+
+		if helmObjectOwnedBy(object, release.Name, namespaceName) {
+			continue
+		}
+
+		patch := client.MergeFrom(object.DeepCopy())
+
+		util.AddLabel(object, helmManagedByLabel, helmManagedBy)
+		util.AddAnnotation(object, helmReleaseNameAnnotation, release.Name)
+		util.AddAnnotation(object, helmReleaseNamespaceAnnotation, namespaceName)
+
+		if err := v.reconciler.Client.Patch(v.ctx, object, patch); err != nil {
+			return adopted, fmt.Errorf("%s %s patch error: %w", object.GetKind(), key, err)
+		}
+
+		adopted++
+	}
+
+	return adopted, nil
+}
+
+// helmObjectOwnedBy returns whether the Helm ownership metadata of object designates the release releaseName of the
+// namespace as its owner
+func helmObjectOwnedBy(object client.Object, releaseName, namespaceName string) bool {
+	return object.GetLabels()[helmManagedByLabel] == helmManagedBy &&
+		object.GetAnnotations()[helmReleaseNameAnnotation] == releaseName &&
+		object.GetAnnotations()[helmReleaseNamespaceAnnotation] == namespaceName
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("Helm releases", func() {
+	const namespaceName = "app"
+
+	vrg := func() *ramen.VolumeReplicationGroup {
+		return &ramen.VolumeReplicationGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "vrg"},
+			Spec: ramen.VolumeReplicationGroupSpec{
+				KubeObjectProtection: &ramen.KubeObjectProtectionSpec{HelmReleases: []string{"rel"}},
+			},
+		}
+	}
+
+	releaseSecret := func(version int, manifest string) *corev1.Secret {
+		release, err := json.Marshal(helmRelease{
+			Name: "rel", Namespace: namespaceName, Version: version, Manifest: manifest,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		compressed := &bytes.Buffer{}
+		writer := gzip.NewWriter(compressed)
+		_, err = writer.Write(release)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(writer.Close()).To(Succeed())
+
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespaceName,
+				Name:      "sh.helm.release.v1.rel.v" + strconv.Itoa(version),
+				Labels: map[string]string{
+					helmReleaseSecretOwnerLabel:   helmReleaseSecretOwner,
+					helmReleaseSecretNameLabel:    "rel",
+					helmReleaseSecretVersionLabel: strconv.Itoa(version),
+				},
+			},
+			Type: "helm.sh/release.v1",
+			Data: map[string][]byte{"release": []byte(base64.StdEncoding.EncodeToString(compressed.Bytes()))},
+		}
+	}
+
+	It("captures and recovers the release secrets of the Helm releases", func() {
+		recipeElements := util.RecipeElements{
+			CaptureWorkflow: captureWorkflowDefault(*vrg(), ramen.RamenConfig{}),
+			RecoverWorkflow: recoverWorkflowDefault(*vrg(), ramen.RamenConfig{}),
+		}
+
+		helmReleasesWorkflowsAppend(&recipeElements, *vrg(), ramen.RamenConfig{})
+
+		Expect(recipeElements.CaptureWorkflow).To(HaveLen(2))
+		Expect(recipeElements.CaptureWorkflow[1].Name).To(Equal(helmReleasesGroupName))
+		Expect(recipeElements.CaptureWorkflow[1].IncludedNamespaces).To(Equal([]string{namespaceName}))
+		Expect(recipeElements.CaptureWorkflow[1].IncludedResources).To(Equal([]string{"secrets"}))
+		Expect(recipeElements.CaptureWorkflow[1].LabelSelector).To(Equal(helmReleasesSelector([]string{"rel"})))
+		Expect(recipeElements.RecoverWorkflow).To(HaveLen(2))
+		Expect(recipeElements.RecoverWorkflow[1].BackupName).To(Equal(helmReleasesGroupName))
+	})
+
+	It("re-adopts the recovered objects of the latest revision of a Helm release", func() {
+		manifest := `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: orphaned
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: owned
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: not-recovered
+`
+		owned := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespaceName,
+			Name:        "owned",
+			Labels:      map[string]string{helmManagedByLabel: helmManagedBy},
+			Annotations: map[string]string{helmReleaseNameAnnotation: "rel", helmReleaseNamespaceAnnotation: namespaceName},
+		}}
+
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			releaseSecret(1, ""),
+			releaseSecret(2, manifest),
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "orphaned"}},
+			owned,
+		).Build()
+
+		v := &VRGInstance{
+			reconciler:  &VolumeReplicationGroupReconciler{Client: fakeClient, APIReader: fakeClient},
+			ctx:         context.TODO(),
+			log:         logr.Discard(),
+			instance:    vrg(),
+			ramenConfig: &ramen.RamenConfig{},
+		}
+
+		Expect(v.helmReleasesAdopt(v.log)).To(Succeed())
+
+		for _, name := range []string{"orphaned", "owned"} {
+			configMap := &corev1.ConfigMap{}
+			Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: name},
+				configMap)).To(Succeed())
+			Expect(helmObjectOwnedBy(configMap, "rel", namespaceName)).To(BeTrue(), name)
+		}
+	})
+})
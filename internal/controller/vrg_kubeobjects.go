@@ -23,6 +23,7 @@ import (
 	"github.com/ramendr/ramen/internal/controller/hooks"
 	"github.com/ramendr/ramen/internal/controller/kubeobjects"
 	"github.com/ramendr/ramen/internal/controller/util"
+	"github.com/ramendr/ramen/pkg/metadata"
 )
 
 var ErrWorkflowNotFound = fmt.Errorf("backup or restore workflow not found")
@@ -37,13 +38,33 @@ func kubeObjectsCaptureInterval(kubeObjectProtectionSpec *ramen.KubeObjectProtec
 	return kubeObjectProtectionSpec.CaptureInterval.Duration
 }
 
+// kubeObjectsCaptureDelay returns how long after now the capture following the one started at startTime is due: once
+// interval elapsed since, or, if the VRG sets no capture interval and replicates on a cron schedule, at the next time
+// of the schedule, for the captures to align with the replication of the volumes
+func kubeObjectsCaptureDelay(vrg *ramen.VolumeReplicationGroup, startTime time.Time, interval time.Duration,
+	now time.Time,
+) time.Duration {
+	kubeObjectProtectionSpec := vrg.Spec.KubeObjectProtection
+	if kubeObjectProtectionSpec.CaptureInterval != nil || kubeObjectProtectionSpec.Differential != nil ||
+		vrg.Spec.Async == nil || !util.SchedulingIntervalIsCron(vrg.Spec.Async.SchedulingInterval) {
+		return interval - now.Sub(startTime)
+	}
+
+	schedule, err := util.SchedulingIntervalCronSchedule(vrg.Spec.Async.SchedulingInterval)
+	if err != nil {
+		return interval - now.Sub(startTime)
+	}
+
+	return schedule.Next(startTime).Sub(now)
+}
+
 func kubeObjectsCapturePathNamesAndNamePrefix(
 	namespaceName, vrgName string, captureNumber int64, kubeObjects kubeobjects.RequestsManager,
 ) (string, string, string) {
 	const numberBase = 10
 
 	number := strconv.FormatInt(captureNumber, numberBase)
-	pathName := s3PathNamePrefix(namespaceName, vrgName) + "kube-objects/" + number + "/"
+	pathName := metadata.KubeObjectsCapturePrefix(namespaceName, vrgName, captureNumber)
 
 	return pathName,
 		pathName + kubeObjects.ProtectsPath(),
@@ -95,6 +116,22 @@ func (v *VRGInstance) kubeObjectsProtect(
 		return
 	}
 
+	if s3ProfileName := kubeObjectsUnsupportedStore(v.s3StoreAccessors); s3ProfileName != "" {
+		v.kubeObjectsCaptureStatusFalse("KubeObjectsCaptureUnsupportedStore",
+			fmt.Sprintf("Kube objects capture is not supported by the object store type of s3 profile %s",
+				s3ProfileName))
+
+		return
+	}
+
+	if s3ProfileName := kubeObjectsUnencryptedStore(v.s3StoreAccessors); s3ProfileName != "" {
+		v.kubeObjectsCaptureStatusFalse("KubeObjectsCaptureUnencrypted",
+			fmt.Sprintf("Kube objects captured by Velero cannot be encrypted with the clientSideEncryption of "+
+				"s3 profile %s", s3ProfileName))
+
+		return
+	}
+
 	vrg := v.instance
 	status := &vrg.Status.KubeObjectProtection
 
@@ -111,6 +148,18 @@ func (v *VRGInstance) kubeObjectsProtect(
 	)
 }
 
+// kubeObjectsUnsupportedStore returns the name of the first of the S3 profiles whose object store type does not
+// support kube objects capture, or an empty string if all do
+func kubeObjectsUnsupportedStore(s3StoreAccessors []s3StoreAccessor) string {
+	for _, s3StoreAccessor := range s3StoreAccessors {
+		if backend, ok := ObjectStoreBackendGet(s3StoreAccessor.Type); !ok || !backend.Capabilities.KubeObjects {
+			return s3StoreAccessor.S3ProfileName
+		}
+	}
+
+	return ""
+}
+
 func (v *VRGInstance) kubeObjectsCaptureStartOrResumeOrDelay(
 	result *ctrl.Result,
 	captureToRecoverFrom *ramen.KubeObjectsCaptureIdentifier,
@@ -147,20 +196,41 @@ func (v *VRGInstance) kubeObjectsCaptureStartOrResumeOrDelay(
 	}
 
 	// requeue with a delay if the time for the next capture has not yet arrived
-	if delay := interval - time.Since(captureToRecoverFrom.StartTime.Time); delay > 0 {
+	fullCaptureInterval := kubeObjectsFullCaptureInterval(vrg.Spec.KubeObjectProtection)
+	if delay := kubeObjectsCaptureDelay(vrg, captureToRecoverFrom.StartTime.Time, fullCaptureInterval,
+		time.Now()); delay > 0 {
+		if v.kubeObjectsDifferentialCapture(result, captureToRecoverFrom, interval) != nil {
+			return
+		}
+
 		v.log.Info("delaying kube objects capture start as per capture interval", "delay", delay,
-			"interval", interval)
+			"interval", fullCaptureInterval)
 		delaySetIfLess(result, delay, v.log)
 		v.kubeObjectsCaptureStatusTrue(VRGConditionReasonUploaded, kubeObjectsClusterDataProtectedTrueMessage)
 
 		return
 	}
 
+	// requeue with a delay if a previous capture exists and a storage backup window is in progress
+	if vrg.Spec.Async != nil && !captureToRecoverFrom.StartTime.IsZero() {
+		if delay := util.BackupWindowDelay(vrg.Spec.Async.BackupWindows, time.Now()); delay > 0 {
+			v.log.Info("delaying kube objects capture start until the end of the backup window", "delay", delay)
+			delaySetIfLess(result, delay, v.log)
+			v.kubeObjectsCaptureStatusTrue(VRGConditionReasonUploaded, kubeObjectsClusterDataProtectedTrueMessage)
+
+			return
+		}
+	}
+
 	// before starting a new capture, delete the previous one with the same number
 	if v.kubeObjectsCapturesDelete(result, number, capturePathName) != nil {
 		return
 	}
 
+	if v.kubeObjectsDifferentialBaselineUpload(result, pathName) != nil {
+		return
+	}
+
 	// start the capture
 	generation := vrg.GetGeneration()
 
@@ -436,8 +506,9 @@ func (v *VRGInstance) kubeObjectsGroupCapture(
 
 			if _, err := v.reconciler.kubeObjects.ProtectRequestCreate(
 				v.ctx, v.reconciler.Client, v.log,
-				s3StoreAccessor.S3CompatibleEndpoint, s3StoreAccessor.S3Bucket, s3StoreAccessor.S3Region,
-				pathName, s3StoreAccessor.VeleroNamespaceSecretKeyRef, s3StoreAccessor.CACertificates,
+				s3ProfileEndpoint(s3StoreAccessor.S3StoreProfile), s3StoreAccessor.S3Bucket, s3StoreAccessor.S3Region,
+				s3ProfileKeyPrefix(s3StoreAccessor.S3StoreProfile)+pathName,
+				s3StoreAccessor.VeleroNamespaceSecretKeyRef, s3StoreAccessor.CACertificates,
 				captureSpec, veleroNamespaceName, requestName,
 				labels, annotations,
 			); err != nil {
@@ -512,6 +583,9 @@ func (v *VRGInstance) kubeObjectsCaptureComplete(
 	}
 
 	captureToRecoverFromIdentifierCurrent := *captureToRecoverFromIdentifier
+	differentialCaptureToRecoverFrom := &vrg.Status.KubeObjectProtection.DifferentialCaptureToRecoverFrom
+	differentialCaptureToRecoverFromCurrent := *differentialCaptureToRecoverFrom
+	*differentialCaptureToRecoverFrom = nil
 	*captureToRecoverFromIdentifier = &ramen.KubeObjectsCaptureIdentifier{
 		Number:    captureNumber,
 		StartTime: startTime,
@@ -533,6 +607,7 @@ func (v *VRGInstance) kubeObjectsCaptureComplete(
 		},
 		func() {
 			*captureToRecoverFromIdentifier = captureToRecoverFromIdentifierCurrent
+			*differentialCaptureToRecoverFrom = differentialCaptureToRecoverFromCurrent
 		},
 	)
 }
@@ -603,10 +678,9 @@ func (v *VRGInstance) kubeObjectsCaptureStatus(status metav1.ConditionStatus, re
 }
 
 func (v *VRGInstance) getVRGFromS3Profile(s3ProfileName string) (*ramen.VolumeReplicationGroup, error) {
-	pathName := s3PathNamePrefix(v.instance.Namespace, v.instance.Name)
+	pathName := s3PathNamePrefix(v.instance.Namespace, v.kubeObjectsSourceVRGName())
 
-	objectStore, _, err := v.reconciler.ObjStoreGetter.ObjectStore(
-		v.ctx, v.reconciler.APIReader, s3ProfileName, v.namespacedName, v.log)
+	objectStore, err := v.restoreObjectStore(s3ProfileName)
 	if err != nil {
 		return nil, fmt.Errorf("object store inaccessible for profile %v: %v", s3ProfileName, err)
 	}
@@ -614,7 +688,7 @@ func (v *VRGInstance) getVRGFromS3Profile(s3ProfileName string) (*ramen.VolumeRe
 	vrg := &ramen.VolumeReplicationGroup{}
 	if err := vrgObjectDownload(objectStore, pathName, vrg); err != nil {
 		return nil, fmt.Errorf("vrg download failed, vrg namespace:%v, vrg name: %v, s3Profile: %v, error: %v",
-			v.instance.Namespace, v.instance.Name, s3ProfileName, err)
+			v.instance.Namespace, v.kubeObjectsSourceVRGName(), s3ProfileName, err)
 	}
 
 	return vrg, nil
@@ -648,7 +722,12 @@ func (v *VRGInstance) kubeObjectsRecoverFromS3(result *ctrl.Result, accessor s3S
 	v.instance.Status.KubeObjectProtection.CaptureToRecoverFrom = captureToRecoverFromIdentifier
 	log := v.log.WithValues("number", captureToRecoverFromIdentifier.Number, "profile", s3ProfileName)
 
-	return v.kubeObjectsRecoveryStartOrResume(result, s3ProfileName, captureToRecoverFromIdentifier, log)
+	if err := v.kubeObjectsRecoveryStartOrResume(result, s3ProfileName, captureToRecoverFromIdentifier,
+		log); err != nil {
+		return err
+	}
+
+	return v.kubeObjectsDifferentialRecover(accessor.ObjectStorer, sourceVrg, log)
 }
 
 func (v *VRGInstance) kubeObjectsRecover(result *ctrl.Result) error {
@@ -678,6 +757,12 @@ func (v *VRGInstance) kubeObjectsRecover(result *ctrl.Result) error {
 		if err := v.kubeObjectsRecoverFromS3(result, s3StoreAccessor); err != nil {
 			v.log.Info("Kube objects restore error", "profile", s3StoreAccessor.S3ProfileName, "error", err)
 
+			if errors.Is(err, errNamespaceQuotaExceeded) {
+				result.Requeue = true
+
+				return err
+			}
+
 			continue
 		}
 
@@ -724,7 +809,8 @@ func (v *VRGInstance) getRecoverOrProtectRequest(
 
 			return v.reconciler.kubeObjects.RecoverRequestCreate(
 				v.ctx, v.reconciler.Client, v.log,
-				s3StoreAccessor.S3CompatibleEndpoint, s3StoreAccessor.S3Bucket, s3StoreAccessor.S3Region, pathName,
+				s3ProfileEndpoint(s3StoreAccessor.S3StoreProfile), s3StoreAccessor.S3Bucket, s3StoreAccessor.S3Region,
+				s3ProfileKeyPrefix(s3StoreAccessor.S3StoreProfile)+pathName,
 				s3StoreAccessor.VeleroNamespaceSecretKeyRef,
 				s3StoreAccessor.CACertificates,
 				recoverGroup, v.veleroNamespaceName(),
@@ -801,6 +887,12 @@ func (v *VRGInstance) kubeObjectsRecoveryStartOrResume(
 	duration := time.Since(startTime.Time)
 	log.Info("Kube objects recovered", "groups", len(steps), "start", startTime, "duration", duration)
 
+	if err := v.helmReleasesAdopt(log); err != nil {
+		result.Requeue = true
+
+		return err
+	}
+
 	return v.kubeObjectsRecoverRequestsDelete(result, v.veleroNamespaceName(), labels)
 }
 
@@ -895,7 +987,7 @@ func (v *VRGInstance) executeRecoverGroup(result *ctrl.Result, s3StoreAccessor s
 	labels map[string]string, groupNumber int,
 	rg kubeobjects.RecoverSpec, requests []kubeobjects.Request, log1 logr.Logger,
 ) error {
-	sourceVrgName := v.instance.Name
+	sourceVrgName := v.kubeObjectsSourceVRGName()
 	sourceVrgNamespaceName := v.instance.Namespace
 	request, ok, submit, cleanup := v.getRecoverOrProtectRequest(
 		captureRequests, recoverRequests, s3StoreAccessor,
@@ -907,6 +999,14 @@ func (v *VRGInstance) executeRecoverGroup(result *ctrl.Result, s3StoreAccessor s
 	var err error
 
 	if !ok {
+		if err = v.namespaceQuotasRecoverBefore(groupNumber, requests); err != nil {
+			log1.Info("Kube objects group recover delayed", "reason", err.Error())
+
+			result.Requeue = true
+
+			return err
+		}
+
 		_, err = submit()
 		if err == nil {
 			log1.Info("Kube objects group recover request submitted")
@@ -0,0 +1,366 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// Differential kube objects capture
+//
+// When a full capture starts, an index of the hashes of the objects of the differential resources is uploaded with
+// it. Every capture interval until the next full capture, the objects created or changed since, and the references
+// of the objects deleted since, are uploaded, replacing the previous differential capture of the same full capture.
+// Recovery restores the full capture, then applies the differential capture over it.
+// The keys of the index and differential capture are those of the metadata package, under the "differential/" path.
+const (
+	kubeObjectsDifferentialPathName = "differential/"
+
+	kubeObjectsDifferentialFieldOwner = "ramen-kube-objects-differential"
+)
+
+type kubeObjectReference = metadata.KubeObjectReference
+
+// kubeObjectsIndexEntry is the hash of the content of an object
+type kubeObjectsIndexEntry = metadata.KubeObjectsIndexEntry
+
+// kubeObjectsDelta holds the objects created or changed, and the references of the objects deleted, since a full
+// capture
+type kubeObjectsDelta = metadata.KubeObjectsDelta
+
+func kubeObjectsFullCaptureInterval(kubeObjectProtectionSpec *ramen.KubeObjectProtectionSpec) time.Duration {
+	if kubeObjectProtectionSpec.Differential == nil {
+		return kubeObjectsCaptureInterval(kubeObjectProtectionSpec)
+	}
+
+	if kubeObjectProtectionSpec.Differential.BaselineInterval == nil {
+		return ramen.KubeObjectsDifferentialBaselineIntervalDefault
+	}
+
+	return kubeObjectProtectionSpec.Differential.BaselineInterval.Duration
+}
+
+func kubeObjectReferenceOf(object *unstructured.Unstructured) kubeObjectReference {
+	return kubeObjectReference{
+		APIVersion: object.GetAPIVersion(),
+		Kind:       object.GetKind(),
+		Namespace:  object.GetNamespace(),
+		Name:       object.GetName(),
+	}
+}
+
+// kubeObjectSanitize removes the fields of an object that are set by the cluster it was read from
+func kubeObjectSanitize(object *unstructured.Unstructured) {
+	for _, field := range []string{"resourceVersion", "uid", "generation", "creationTimestamp", "managedFields"} {
+		unstructured.RemoveNestedField(object.Object, "metadata", field)
+	}
+
+	unstructured.RemoveNestedField(object.Object, "status")
+}
+
+// kubeObjectHash hashes the content of a sanitized object
+func kubeObjectHash(object *unstructured.Unstructured) (string, error) {
+	data, err := json.Marshal(object.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %v: %w", kubeObjectReferenceOf(object), err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func kubeObjectsIndexCompute(objects []unstructured.Unstructured) ([]kubeObjectsIndexEntry, error) {
+	index := make([]kubeObjectsIndexEntry, 0, len(objects))
+
+	for idx := range objects {
+		hash, err := kubeObjectHash(&objects[idx])
+		if err != nil {
+			return nil, err
+		}
+
+		index = append(index, kubeObjectsIndexEntry{KubeObjectReference: kubeObjectReferenceOf(&objects[idx]), Hash: hash})
+	}
+
+	return index, nil
+}
+
+// kubeObjectsDeltaCompute returns the objects that are not in the baseline index, or whose hash differs, and the
+// references of the objects in the baseline index that no longer exist
+func kubeObjectsDeltaCompute(baseline []kubeObjectsIndexEntry, objects []unstructured.Unstructured,
+) (kubeObjectsDelta, error) {
+	delta := kubeObjectsDelta{}
+	hashes := make(map[kubeObjectReference]string, len(baseline))
+
+	for _, entry := range baseline {
+		hashes[entry.KubeObjectReference] = entry.Hash
+	}
+
+	for idx := range objects {
+		object := &objects[idx]
+		reference := kubeObjectReferenceOf(object)
+
+		hash, err := kubeObjectHash(object)
+		if err != nil {
+			return delta, err
+		}
+
+		if baselineHash, ok := hashes[reference]; !ok || baselineHash != hash {
+			delta.Objects = append(delta.Objects, *object)
+		}
+
+		delete(hashes, reference)
+	}
+
+	for _, entry := range baseline {
+		if _, ok := hashes[entry.KubeObjectReference]; ok {
+			delta.Deleted = append(delta.Deleted, entry.KubeObjectReference)
+		}
+	}
+
+	return delta, nil
+}
+
+// kubeObjectsDifferentialObjectsList lists the sanitized objects of the differential resources in the protected
+// namespaces that match the kube object selector, if any
+func (v *VRGInstance) kubeObjectsDifferentialObjectsList(resources []string) ([]unstructured.Unstructured, error) {
+	namespaces := []string{v.instance.Namespace}
+	if v.isDiscoveredApp() {
+		namespaces = *v.instance.Spec.ProtectedNamespaces
+	}
+
+	selector := labels.Everything()
+
+	if kubeObjectSelector := v.instance.Spec.KubeObjectProtection.KubeObjectSelector; kubeObjectSelector != nil {
+		var err error
+
+		if selector, err = metav1.LabelSelectorAsSelector(kubeObjectSelector); err != nil {
+			return nil, fmt.Errorf("invalid kube object selector: %w", err)
+		}
+	}
+
+	objects := []unstructured.Unstructured{}
+
+	for _, resource := range resources {
+		gvk, err := v.reconciler.Client.RESTMapper().KindFor(schema.ParseGroupResource(resource).WithVersion(""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to map resource %s to a kind: %w", resource, err)
+		}
+
+		for _, namespace := range namespaces {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+			if err := v.reconciler.APIReader.List(v.ctx, list, client.InNamespace(namespace),
+				client.MatchingLabelsSelector{Selector: selector}); err != nil {
+				return nil, fmt.Errorf("failed to list %s in namespace %s: %w", resource, namespace, err)
+			}
+
+			for idx := range list.Items {
+				kubeObjectSanitize(&list.Items[idx])
+			}
+
+			objects = append(objects, list.Items...)
+		}
+	}
+
+	return objects, nil
+}
+
+// kubeObjectsDifferentialBaselineUpload uploads the index of the objects of the differential resources for the full
+// capture starting at pathName, replacing the index and differential capture of a previous full capture at the same
+// path. Does nothing if differential capture is disabled.
+func (v *VRGInstance) kubeObjectsDifferentialBaselineUpload(result *ctrl.Result, pathName string) error {
+	differential := v.instance.Spec.KubeObjectProtection.Differential
+	if differential == nil {
+		return nil
+	}
+
+	objects, err := v.kubeObjectsDifferentialObjectsList(differential.Resources)
+	if err != nil {
+		return v.kubeObjectsDifferentialError(result, "KubeObjectsDifferentialListError", err)
+	}
+
+	index, err := kubeObjectsIndexCompute(objects)
+	if err != nil {
+		return v.kubeObjectsDifferentialError(result, "KubeObjectsDifferentialIndexError", err)
+	}
+
+	for _, s3StoreAccessor := range v.s3StoreAccessors {
+		objectStorer := s3StoreAccessor.ObjectStorer
+
+		if err := objectStorer.DeleteObjectsWithKeyPrefix(pathName + kubeObjectsDifferentialPathName); err != nil {
+			return v.kubeObjectsDifferentialError(result, "KubeObjectsReplicaDeleteError", err)
+		}
+
+		if err := objectStorer.UploadObject(metadata.KubeObjectsDifferentialBaselineKey(pathName), index); err != nil {
+			return v.kubeObjectsDifferentialError(result, "KubeObjectsDifferentialUploadError", err)
+		}
+	}
+
+	v.log.Info("Kube objects differential capture baseline uploaded", "objects", len(index))
+
+	return nil
+}
+
+// kubeObjectsDifferentialCapture uploads the objects changed since the full capture to recover from, if differential
+// capture is enabled and the capture interval elapsed since the previous capture, full or differential. Returns an
+// error if the differential capture failed.
+func (v *VRGInstance) kubeObjectsDifferentialCapture(
+	result *ctrl.Result,
+	captureToRecoverFrom *ramen.KubeObjectsCaptureIdentifier,
+	interval time.Duration,
+) error {
+	vrg := v.instance
+	differential := vrg.Spec.KubeObjectProtection.Differential
+
+	if differential == nil {
+		return nil
+	}
+
+	status := &vrg.Status.KubeObjectProtection
+	previousStartTime := captureToRecoverFrom.StartTime
+
+	if current := status.DifferentialCaptureToRecoverFrom; current != nil &&
+		current.BaselineNumber == captureToRecoverFrom.Number {
+		previousStartTime = current.StartTime
+	}
+
+	if delay := interval - time.Since(previousStartTime.Time); delay > 0 {
+		delaySetIfLess(result, delay, v.log)
+
+		return nil
+	}
+
+	log := v.log.WithValues("number", captureToRecoverFrom.Number)
+	startTime := metav1.Now()
+	pathName, _, _ := kubeObjectsCapturePathNamesAndNamePrefix(vrg.Namespace, vrg.Name, captureToRecoverFrom.Number,
+		v.reconciler.kubeObjects)
+
+	baseline := []kubeObjectsIndexEntry{}
+
+	if err := v.s3StoreAccessors[0].ObjectStorer.DownloadObject(
+		metadata.KubeObjectsDifferentialBaselineKey(pathName), &baseline); err != nil {
+		// e.g. the full capture was taken before differential capture was enabled
+		log.Info("Kube objects differential capture baseline unavailable, awaiting the next full capture",
+			"error", err)
+		delaySetIfLess(result, interval, v.log)
+
+		return nil
+	}
+
+	objects, err := v.kubeObjectsDifferentialObjectsList(differential.Resources)
+	if err != nil {
+		return v.kubeObjectsDifferentialError(result, "KubeObjectsDifferentialListError", err)
+	}
+
+	delta, err := kubeObjectsDeltaCompute(baseline, objects)
+	if err != nil {
+		return v.kubeObjectsDifferentialError(result, "KubeObjectsDifferentialIndexError", err)
+	}
+
+	deltaKey := metadata.KubeObjectsDifferentialDeltaKey(pathName)
+
+	for _, s3StoreAccessor := range v.s3StoreAccessors {
+		if err := s3StoreAccessor.ObjectStorer.UploadObject(deltaKey, delta); err != nil {
+			return v.kubeObjectsDifferentialError(result, "KubeObjectsDifferentialUploadError", err)
+		}
+	}
+
+	current := status.DifferentialCaptureToRecoverFrom
+	status.DifferentialCaptureToRecoverFrom = &ramen.KubeObjectsDifferentialCaptureIdentifier{
+		BaselineNumber: captureToRecoverFrom.Number,
+		StartTime:      startTime,
+		Changed:        int32(len(delta.Objects) + len(delta.Deleted)),
+	}
+
+	v.vrgObjectProtectThrottled(
+		result,
+		func() {
+			log.Info("Kube objects differential capture uploaded", "changed", len(delta.Objects),
+				"deleted", len(delta.Deleted), "duration", time.Since(startTime.Time))
+			delaySetIfLess(result, interval, v.log)
+		},
+		func() {
+			status.DifferentialCaptureToRecoverFrom = current
+		},
+	)
+
+	return nil
+}
+
+func (v *VRGInstance) kubeObjectsDifferentialError(result *ctrl.Result, reason string, err error) error {
+	v.log.Error(err, "Kube objects differential capture error")
+	v.kubeObjectsCaptureStatusFalse(reason, err.Error())
+
+	result.Requeue = true
+
+	return err
+}
+
+// kubeObjectsDifferentialRecover applies the differential capture of the source VRG, if any, over the full capture
+// it is relative to, which is expected to be recovered already. Objects created or changed since the full capture are
+// applied, and objects deleted since are deleted.
+func (v *VRGInstance) kubeObjectsDifferentialRecover(
+	objectStorer ObjectStorer, sourceVrg *ramen.VolumeReplicationGroup, log logr.Logger,
+) error {
+	status := sourceVrg.Status.KubeObjectProtection
+	if status.DifferentialCaptureToRecoverFrom == nil || status.CaptureToRecoverFrom == nil ||
+		status.DifferentialCaptureToRecoverFrom.BaselineNumber != status.CaptureToRecoverFrom.Number {
+		return nil
+	}
+
+	pathName, _, _ := kubeObjectsCapturePathNamesAndNamePrefix(sourceVrg.Namespace, sourceVrg.Name,
+		status.CaptureToRecoverFrom.Number, v.reconciler.kubeObjects)
+	delta := kubeObjectsDelta{}
+
+	if err := objectStorer.DownloadObject(metadata.KubeObjectsDifferentialDeltaKey(pathName), &delta); err != nil {
+		return fmt.Errorf("kube objects differential capture download error: %w", err)
+	}
+
+	for idx := range delta.Objects {
+		object := &delta.Objects[idx]
+
+		if err := v.reconciler.Client.Patch(v.ctx, object, client.Apply, client.ForceOwnership,
+			client.FieldOwner(kubeObjectsDifferentialFieldOwner)); err != nil {
+			return fmt.Errorf("kube objects differential capture apply error for %v: %w",
+				kubeObjectReferenceOf(object), err)
+		}
+	}
+
+	for _, reference := range delta.Deleted {
+		object := &unstructured.Unstructured{}
+		object.SetAPIVersion(reference.APIVersion)
+		object.SetKind(reference.Kind)
+		object.SetNamespace(reference.Namespace)
+		object.SetName(reference.Name)
+
+		if err := v.reconciler.Client.Delete(v.ctx, object); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("kube objects differential capture delete error for %v: %w", reference, err)
+		}
+	}
+
+	v.instance.Status.KubeObjectProtection.DifferentialCaptureToRecoverFrom = status.DifferentialCaptureToRecoverFrom
+
+	log.Info("Kube objects differential capture recovered", "changed", len(delta.Objects),
+		"deleted", len(delta.Deleted))
+
+	return nil
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("kubeObjectsDeltaCompute", func() {
+	configMap := func(name, value string) unstructured.Unstructured {
+		object := unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"namespace":       "app",
+				"name":            name,
+				"resourceVersion": value,
+			},
+			"data":   map[string]interface{}{"key": value},
+			"status": map[string]interface{}{"observed": value},
+		}}
+		kubeObjectSanitize(&object)
+
+		return object
+	}
+
+	It("captures created and changed objects, and references of deleted objects", func() {
+		baseline, err := kubeObjectsIndexCompute([]unstructured.Unstructured{
+			configMap("unchanged", "1"), configMap("changed", "1"), configMap("deleted", "1"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		delta, err := kubeObjectsDeltaCompute(baseline, []unstructured.Unstructured{
+			configMap("unchanged", "1"), configMap("changed", "2"), configMap("created", "1"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(delta.Objects).To(HaveLen(2))
+		Expect(delta.Objects[0].GetName()).To(Equal("changed"))
+		Expect(delta.Objects[1].GetName()).To(Equal("created"))
+		Expect(delta.Deleted).To(Equal([]kubeObjectReference{
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "app", Name: "deleted"},
+		}))
+	})
+
+	It("removes cluster set fields before hashing", func() {
+		object := configMap("unchanged", "1")
+		Expect(object.GetResourceVersion()).To(BeEmpty())
+		Expect(object.Object).ToNot(HaveKey("status"))
+	})
+})
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("kubeObjectsCaptureDelay", func() {
+	startTime := time.Date(2024, time.March, 8, 12, 0, 0, 0, time.UTC) // Friday
+	now := startTime.Add(time.Hour)
+
+	vrg := func(interval string, captureInterval *metav1.Duration) *ramen.VolumeReplicationGroup {
+		return &ramen.VolumeReplicationGroup{Spec: ramen.VolumeReplicationGroupSpec{
+			Async:                &ramen.VRGAsyncSpec{SchedulingInterval: interval},
+			KubeObjectProtection: &ramen.KubeObjectProtectionSpec{CaptureInterval: captureInterval},
+		}}
+	}
+
+	It("delays the next capture by the capture interval", func() {
+		Expect(kubeObjectsCaptureDelay(vrg("5m", nil), startTime, 2*time.Hour, now)).To(Equal(time.Hour))
+		Expect(kubeObjectsCaptureDelay(vrg("0 8 * * 1-5", &metav1.Duration{Duration: 2 * time.Hour}), startTime,
+			2*time.Hour, now)).To(Equal(time.Hour))
+	})
+
+	It("aligns the next capture with the cron schedule of the VRG if it sets no capture interval", func() {
+		Expect(kubeObjectsCaptureDelay(vrg("0 8 * * 1-5", nil), startTime, 2*time.Hour, now)).
+			To(Equal(67 * time.Hour)) // Monday 08:00
+	})
+
+	It("captures right away if no capture was started", func() {
+		Expect(kubeObjectsCaptureDelay(vrg("0 8 * * 1-5", nil), time.Time{}, 2*time.Hour, now)).
+			To(BeNumerically("<=", 0))
+	})
+})
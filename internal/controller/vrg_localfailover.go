@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// LocalFailoverPlan is the local failover plan, and the hub heartbeat, that the hub uploads next to the VRG
+// in the S3 stores. A managed cluster that loses connectivity to the hub can still read it from the stores.
+// It is a type of its own, rather than an alias, as its type name is part of its key.
+type LocalFailoverPlan metadata.LocalFailoverPlan
+
+// LocalFailoverPrimaryHeartbeat is the heartbeat that the primary VRG of a local failover plan uploads next to the
+// VRG, so that the failover cluster does not promote its VRG while the primary cluster is still running
+type LocalFailoverPrimaryHeartbeat metadata.LocalFailoverPrimaryHeartbeat
+
+const (
+	localFailoverPlanS3ObjectNameSuffix             = metadata.LocalFailoverPlanName
+	localFailoverPrimaryHeartbeatS3ObjectNameSuffix = metadata.LocalFailoverPrimaryHeartbeatName
+)
+
+// localFailoverPrimaryHeartbeatTimes tracks, per VRG, when the primary VRG last uploaded its heartbeat
+var localFailoverPrimaryHeartbeatTimes sync.Map
+
+func LocalFailoverPlanUpload(objectStorer ObjectStorer, vrgNamespace, vrgName string, plan LocalFailoverPlan) error {
+	return uploadTypedObject(objectStorer, s3PathNamePrefix(vrgNamespace, vrgName),
+		localFailoverPlanS3ObjectNameSuffix, plan)
+}
+
+func localFailoverPlanDownload(objectStorer ObjectStorer, pathName string, plan *LocalFailoverPlan) error {
+	return DownloadTypedObject(objectStorer, pathName, localFailoverPlanS3ObjectNameSuffix, plan)
+}
+
+// localFailoverHeartbeatStale returns true if the heartbeat is older than the threshold
+func localFailoverHeartbeatStale(lastHeartbeat time.Time, threshold time.Duration, now time.Time) bool {
+	return now.Sub(lastHeartbeat) > threshold
+}
+
+// localFailoverEvaluate executes the pre-approved local failover plan of a secondary VRG if both the hub heartbeat
+// and the heartbeat of the primary VRG, as read from the S3 stores, are stale beyond the plan's threshold, so that
+// the primary cluster is not left running alongside this one. Once executed, the VRG is processed as a failed over
+// primary until the hub changes the VRG spec, failing over, relocating or otherwise reasserting its ownership of the
+// VRG, or removes the plan. A plan or heartbeat that cannot be read is never a reason to fail over. A primary VRG
+// with a plan uploads its heartbeat instead.
+func (v *VRGInstance) localFailoverEvaluate() {
+	plan := v.instance.Spec.LocalFailover
+	if plan == nil {
+		v.instance.Status.LocalFailover = nil
+
+		return
+	}
+
+	if v.instance.Status.LocalFailover == nil {
+		v.instance.Status.LocalFailover = &ramen.LocalFailoverStatus{}
+	}
+
+	status := v.instance.Status.LocalFailover
+
+	if status.ExecutedTime != nil && status.ExecutedGeneration != v.instance.Generation {
+		v.log.Info("Hub changed the VRG spec since the local failover, dropping the local failover",
+			"executedTime", status.ExecutedTime, "executedGeneration", status.ExecutedGeneration,
+			"generation", v.instance.Generation)
+
+		status.ExecutedTime = nil
+		status.ExecutedGeneration = 0
+	}
+
+	if status.ExecutedTime == nil {
+		switch v.instance.Spec.ReplicationState {
+		case ramen.Primary:
+			v.localFailoverPrimaryHeartbeatUpload(plan)
+		case ramen.Secondary:
+			v.localFailoverExecuteIfUnreachable(plan, status)
+		}
+	}
+
+	if status.ExecutedTime == nil {
+		return
+	}
+
+	// The work agent reverts the VRG spec to the hub's intent once connectivity returns; keep the promotion
+	// in memory until the hub has caught up
+	v.instance.Spec.ReplicationState = ramen.Primary
+	v.instance.Spec.Action = ramen.VRGActionFailover
+}
+
+// localFailoverExecuteIfUnreachable records the local failover as executed if the hub and the primary VRG are both
+// unreachable
+func (v *VRGInstance) localFailoverExecuteIfUnreachable(plan *ramen.LocalFailoverSpec,
+	status *ramen.LocalFailoverStatus,
+) {
+	threshold := plan.HubUnreachableThreshold.Duration
+	now := time.Now()
+
+	v.localFailoverHeartbeatUpdate(status)
+
+	if status.LastHubHeartbeat == nil || !localFailoverHeartbeatStale(status.LastHubHeartbeat.Time, threshold, now) {
+		return
+	}
+
+	if !v.localFailoverPrimaryUnreachable(status, threshold, now) {
+		v.log.Info("Hub heartbeat is stale, but the primary VRG may still be running; not executing local failover",
+			"lastHubHeartbeat", status.LastHubHeartbeat, "lastPrimaryHeartbeat", status.LastPrimaryHeartbeat)
+
+		return
+	}
+
+	executedTime := metav1.NewTime(now)
+	status.ExecutedTime = &executedTime
+	status.ExecutedGeneration = v.instance.Generation
+
+	msg := fmt.Sprintf("Hub heartbeat last seen at %v, primary heartbeat last seen at %v, executing local failover",
+		status.LastHubHeartbeat, status.LastPrimaryHeartbeat)
+	v.log.Info(msg)
+	util.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+		util.EventReasonLocalFailoverExecuted, msg)
+}
+
+// localFailoverHeartbeatUpdate records the most recent hub heartbeat found across the S3 stores
+func (v *VRGInstance) localFailoverHeartbeatUpdate(status *ramen.LocalFailoverStatus) {
+	for _, s3StoreAccessor := range v.s3StoreAccessors {
+		plan := LocalFailoverPlan{}
+
+		if err := localFailoverPlanDownload(s3StoreAccessor.ObjectStorer, v.s3KeyPrefix(), &plan); err != nil {
+			v.log.Info("Local failover plan download failed", "profile", s3StoreAccessor.S3ProfileName,
+				"error", err)
+
+			continue
+		}
+
+		if status.LastHubHeartbeat == nil || plan.HubHeartbeat.After(status.LastHubHeartbeat.Time) {
+			heartbeat := plan.HubHeartbeat
+			status.LastHubHeartbeat = &heartbeat
+		}
+	}
+}
+
+// localFailoverPrimaryUnreachable records the most recent heartbeat of the primary VRG found across the S3 stores,
+// and returns true if it is stale beyond the threshold, or was never uploaded. It returns false unless at least one
+// store was read, so that stores that cannot be read are not mistaken for a primary that stopped.
+func (v *VRGInstance) localFailoverPrimaryUnreachable(status *ramen.LocalFailoverStatus, threshold time.Duration,
+	now time.Time,
+) bool {
+	read := false
+
+	for _, s3StoreAccessor := range v.s3StoreAccessors {
+		heartbeat, found, err := localFailoverPrimaryHeartbeatDownload(s3StoreAccessor.ObjectStorer, v.s3KeyPrefix())
+		if err != nil {
+			v.log.Info("Local failover primary heartbeat download failed", "profile", s3StoreAccessor.S3ProfileName,
+				"error", err)
+
+			continue
+		}
+
+		read = true
+
+		if found && (status.LastPrimaryHeartbeat == nil || heartbeat.Heartbeat.After(status.LastPrimaryHeartbeat.Time)) {
+			lastHeartbeat := heartbeat.Heartbeat
+			status.LastPrimaryHeartbeat = &lastHeartbeat
+		}
+	}
+
+	return read && (status.LastPrimaryHeartbeat == nil ||
+		localFailoverHeartbeatStale(status.LastPrimaryHeartbeat.Time, threshold, now))
+}
+
+// localFailoverPrimaryHeartbeatUpload uploads the heartbeat of the primary VRG to all S3 stores, at most once per
+// heartbeat interval
+func (v *VRGInstance) localFailoverPrimaryHeartbeatUpload(plan *ramen.LocalFailoverSpec) {
+	if lastTime, ok := localFailoverPrimaryHeartbeatTimes.Load(v.namespacedName); ok &&
+		time.Since(lastTime.(time.Time)) < localFailoverHeartbeatInterval(plan) {
+		return
+	}
+
+	heartbeat := LocalFailoverPrimaryHeartbeat{Heartbeat: metav1.Now()}
+
+	for _, s3StoreAccessor := range v.s3StoreAccessors {
+		if err := uploadTypedObject(s3StoreAccessor.ObjectStorer, v.s3KeyPrefix(),
+			localFailoverPrimaryHeartbeatS3ObjectNameSuffix, heartbeat); err != nil {
+			v.log.Info("Local failover primary heartbeat upload failed", "profile", s3StoreAccessor.S3ProfileName,
+				"error", err)
+
+			return
+		}
+	}
+
+	localFailoverPrimaryHeartbeatTimes.Store(v.namespacedName, time.Now())
+}
+
+// localFailoverPrimaryHeartbeatDownload returns the heartbeat of the primary VRG, and whether it was uploaded
+func localFailoverPrimaryHeartbeatDownload(objectStorer ObjectStorer, pathName string,
+) (LocalFailoverPrimaryHeartbeat, bool, error) {
+	heartbeat := LocalFailoverPrimaryHeartbeat{}
+	key := TypedObjectKey(pathName, localFailoverPrimaryHeartbeatS3ObjectNameSuffix, heartbeat)
+
+	keys, err := objectStorer.ListKeys(key)
+	if err != nil {
+		return heartbeat, false, err
+	}
+
+	if !slices.Contains(keys, key) {
+		return heartbeat, false, nil
+	}
+
+	return heartbeat, true, objectStorer.DownloadObject(key, &heartbeat)
+}
+
+// localFailoverRequeue ensures a VRG with a local failover plan keeps checking the hub heartbeat as a secondary, and
+// keeps uploading its heartbeat as a primary
+func (v *VRGInstance) localFailoverRequeue(result ctrl.Result) ctrl.Result {
+	plan := v.instance.Spec.LocalFailover
+	if plan == nil || result.Requeue || result.RequeueAfter != 0 {
+		return result
+	}
+
+	result.RequeueAfter = plan.HubUnreachableThreshold.Duration / localFailoverHeartbeatsPerThreshold
+
+	return result
+}
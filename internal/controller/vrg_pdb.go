@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+// PDBOriginalSpecAnnotation holds the spec of a pod disruption budget overridden to quiesce the workload, which is
+// restored once the final sync completes
+const PDBOriginalSpecAnnotation = "ramendr.openshift.io/pdb-original-spec"
+
+// pdbsQuiesceReconcile overrides the pod disruption budgets in the namespaces of the protected PVCs to allow all their
+// pods to be disrupted while the VRG, as primary, prepares for or runs the final sync with the strategy to override
+// them, and restores them otherwise. The overridden budgets are reported in the VRG status.
+func (v *VRGInstance) pdbsQuiesceReconcile() error {
+	vrg := v.instance
+	override := !util.ResourceIsDeleted(vrg) &&
+		vrg.Spec.ReplicationState == ramen.Primary &&
+		(vrg.Spec.PrepareForFinalSync || vrg.Spec.RunFinalSync) &&
+		vrg.Spec.QuiesceStrategy == ramen.QuiesceStrategyOverridePodDisruptionBudgets
+
+	overridden := []string{}
+
+	for _, namespace := range v.recipeElements.PvcSelector.NamespaceNames {
+		pdbList := &policyv1.PodDisruptionBudgetList{}
+		if err := v.reconciler.APIReader.List(v.ctx, pdbList, client.InNamespace(namespace)); err != nil {
+			return fmt.Errorf("failed to list pod disruption budgets in namespace %s, %w", namespace, err)
+		}
+
+		for i := range pdbList.Items {
+			pdb := &pdbList.Items[i]
+
+			changed, err := pdbQuiesceSet(pdb, override)
+			if err != nil {
+				return err
+			}
+
+			if changed {
+				if err := v.reconciler.Client.Update(v.ctx, pdb); err != nil {
+					return fmt.Errorf("failed to update pod disruption budget %s/%s, %w", pdb.Namespace, pdb.Name, err)
+				}
+
+				v.log.Info("Pod disruption budget updated to quiesce workload", "namespace", pdb.Namespace,
+					"name", pdb.Name, "overridden", override)
+			}
+
+			if _, ok := pdb.Annotations[PDBOriginalSpecAnnotation]; ok {
+				overridden = append(overridden, pdb.Namespace+"/"+pdb.Name)
+			}
+		}
+	}
+
+	if len(overridden) == 0 {
+		vrg.Status.OverriddenPDBs = nil
+
+		return nil
+	}
+
+	slices.Sort(overridden)
+	vrg.Status.OverriddenPDBs = overridden
+
+	return nil
+}
+
+// pdbQuiesceSet overrides the pod disruption budget to allow all its pods to be disrupted, saving its spec in an
+// annotation, if override is set, and restores its saved spec otherwise. Returns whether the budget changed.
+func pdbQuiesceSet(pdb *policyv1.PodDisruptionBudget, override bool) (bool, error) {
+	originalSpec, overridden := pdb.Annotations[PDBOriginalSpecAnnotation]
+
+	if override {
+		if overridden {
+			return false, nil
+		}
+
+		encoded, err := json.Marshal(pdb.Spec)
+		if err != nil {
+			return false, fmt.Errorf("failed to encode spec of pod disruption budget %s/%s, %w",
+				pdb.Namespace, pdb.Name, err)
+		}
+
+		if pdb.Annotations == nil {
+			pdb.Annotations = map[string]string{}
+		}
+
+		pdb.Annotations[PDBOriginalSpecAnnotation] = string(encoded)
+		maxUnavailable := intstr.FromString("100%")
+		pdb.Spec.MinAvailable = nil
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+
+		return true, nil
+	}
+
+	if !overridden {
+		return false, nil
+	}
+
+	spec := policyv1.PodDisruptionBudgetSpec{}
+	if err := json.Unmarshal([]byte(originalSpec), &spec); err != nil {
+		return false, fmt.Errorf("failed to decode annotation %s of pod disruption budget %s/%s, %w",
+			PDBOriginalSpecAnnotation, pdb.Namespace, pdb.Name, err)
+	}
+
+	pdb.Spec = spec
+	delete(pdb.Annotations, PDBOriginalSpecAnnotation)
+
+	return true, nil
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("Pod disruption budgets quiesce", func() {
+	const namespaceName = "app"
+
+	var (
+		fakeClient client.Client
+		v          *VRGInstance
+	)
+
+	pdb := func(name string, minAvailable intstr.IntOrString) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: name},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MinAvailable: &minAvailable,
+				Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			},
+		}
+	}
+
+	pdbGet := func(name string) *policyv1.PodDisruptionBudget {
+		pdb := &policyv1.PodDisruptionBudget{}
+		Expect(fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespaceName, Name: name}, pdb)).
+			To(Succeed())
+
+		return pdb
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(policyv1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			pdb("db", intstr.FromInt32(1)),
+			pdb("web", intstr.FromString("100%")),
+		).Build()
+
+		v = &VRGInstance{
+			reconciler: &VolumeReplicationGroupReconciler{Client: fakeClient, APIReader: fakeClient},
+			ctx:        context.TODO(),
+			log:        logr.Discard(),
+			instance: &ramen.VolumeReplicationGroup{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "vrg"},
+				Spec: ramen.VolumeReplicationGroupSpec{
+					ReplicationState:    ramen.Primary,
+					PrepareForFinalSync: true,
+					QuiesceStrategy:     ramen.QuiesceStrategyOverridePodDisruptionBudgets,
+				},
+			},
+			recipeElements: util.RecipeElements{
+				PvcSelector: util.PvcSelector{NamespaceNames: []string{namespaceName}},
+			},
+		}
+	})
+
+	It("overrides the budgets while preparing for and running the final sync, and restores them afterwards", func() {
+		Expect(v.pdbsQuiesceReconcile()).To(Succeed())
+		Expect(v.instance.Status.OverriddenPDBs).To(Equal([]string{"app/db", "app/web"}))
+
+		overridden := pdbGet("db")
+		Expect(overridden.Spec.MinAvailable).To(BeNil())
+		Expect(*overridden.Spec.MaxUnavailable).To(Equal(intstr.FromString("100%")))
+		Expect(overridden.Spec.Selector.MatchLabels).To(Equal(map[string]string{"app": "db"}))
+
+		v.instance.Spec.PrepareForFinalSync = false
+		v.instance.Spec.RunFinalSync = true
+		Expect(v.pdbsQuiesceReconcile()).To(Succeed())
+		Expect(pdbGet("db").Annotations[PDBOriginalSpecAnnotation]).To(Equal(
+			overridden.Annotations[PDBOriginalSpecAnnotation]))
+
+		v.instance.Spec.ReplicationState = ramen.Secondary
+		v.instance.Spec.RunFinalSync = false
+		Expect(v.pdbsQuiesceReconcile()).To(Succeed())
+		Expect(v.instance.Status.OverriddenPDBs).To(BeNil())
+
+		for name, expected := range map[string]intstr.IntOrString{
+			"db":  intstr.FromInt32(1),
+			"web": intstr.FromString("100%"),
+		} {
+			restored := pdbGet(name)
+			Expect(restored.Annotations).ToNot(HaveKey(PDBOriginalSpecAnnotation))
+			Expect(restored.Spec.MaxUnavailable).To(BeNil())
+			Expect(*restored.Spec.MinAvailable).To(Equal(expected))
+		}
+	})
+
+	It("leaves the budgets as they are with the default strategy", func() {
+		v.instance.Spec.QuiesceStrategy = ramen.QuiesceStrategyDefault
+
+		Expect(v.pdbsQuiesceReconcile()).To(Succeed())
+		Expect(v.instance.Status.OverriddenPDBs).To(BeNil())
+		Expect(pdbGet("db").Annotations).ToNot(HaveKey(PDBOriginalSpecAnnotation))
+		Expect(*pdbGet("db").Spec.MinAvailable).To(Equal(intstr.FromInt32(1)))
+	})
+
+	It("fails to restore a budget whose saved spec is corrupt", func() {
+		corrupt := pdb("db", intstr.FromInt32(1))
+		corrupt.Annotations = map[string]string{PDBOriginalSpecAnnotation: "{"}
+
+		_, err := pdbQuiesceSet(corrupt, false)
+		Expect(err).To(MatchError(ContainSubstring("failed to decode annotation")))
+	})
+})
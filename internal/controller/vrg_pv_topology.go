@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// pvTopologyKeys are the node labels, besides the keys of the topology mappings of the VRG, whose constraints in the
+// node affinity of a restored PV are validated against the nodes of the cluster
+var pvTopologyKeys = []string{
+	corev1.LabelTopologyZone,
+	corev1.LabelTopologyRegion,
+	corev1.LabelFailureDomainBetaZone,
+	corev1.LabelFailureDomainBetaRegion,
+}
+
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+// cleanupPVForRestoreFromObjectStore cleans up pv, protected on another cluster, for its restore to this cluster,
+// rewriting its topology to that of this cluster and validating that it is schedulable here
+func (v *VRGInstance) cleanupPVForRestoreFromObjectStore(pv *corev1.PersistentVolume) error {
+	rewritePVTopology(pv, v.instance.Spec.PVTopologyMappings)
+
+	if err := v.validatePVTopology(pv); err != nil {
+		return err
+	}
+
+	return v.cleanupPVForRestore(pv)
+}
+
+// rewritePVTopology rewrites the values of the topology labels in the node affinity and the labels of pv, from the
+// topology of the cluster pv was protected on to that of this cluster, as mapped by mappings
+func rewritePVTopology(pv *corev1.PersistentVolume, mappings []rmn.TopologyMapping) {
+	if len(mappings) == 0 {
+		return
+	}
+
+	for key, value := range pv.Labels {
+		pv.Labels[key] = mapTopologyValue(mappings, key, value)
+	}
+
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return
+	}
+
+	for i := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		term := &pv.Spec.NodeAffinity.Required.NodeSelectorTerms[i]
+
+		for j := range term.MatchExpressions {
+			expression := &term.MatchExpressions[j]
+
+			for k := range expression.Values {
+				expression.Values[k] = mapTopologyValue(mappings, expression.Key, expression.Values[k])
+			}
+		}
+	}
+}
+
+// mapTopologyValue returns the value of the topology label key on this cluster that mappings map value to, or value
+// if it is not mapped
+func mapTopologyValue(mappings []rmn.TopologyMapping, key, value string) string {
+	for i := range mappings {
+		if mappings[i].Key == key && mappings[i].From == value {
+			return mappings[i].To
+		}
+	}
+
+	return value
+}
+
+// validatePVTopology returns an error if the topology constraints in the node affinity of pv, about to be restored,
+// match no node of the cluster, as a workload using the PV would not be schedulable. Constraints on labels other than
+// the topology labels are not validated.
+func (v *VRGInstance) validatePVTopology(pv *corev1.PersistentVolume) error {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return nil
+	}
+
+	topologyKeys := slices.Clone(pvTopologyKeys)
+	for i := range v.instance.Spec.PVTopologyMappings {
+		topologyKeys = append(topologyKeys, v.instance.Spec.PVTopologyMappings[i].Key)
+	}
+
+	selectors, err := pvTopologySelectors(pv.Spec.NodeAffinity.Required, topologyKeys)
+	if err != nil || len(selectors) == 0 {
+		return err
+	}
+
+	nodes, err := v.listNodes()
+	if err != nil {
+		return err
+	}
+
+	for _, selector := range selectors {
+		for i := range nodes {
+			if selector.Matches(labels.Set(nodes[i].Labels)) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("PV %s is not schedulable on this cluster, no node matches the topology of its node affinity "+
+		"%v; map the topology of the clusters in the topologyMappings of the DRPolicy", pv.Name, selectors)
+}
+
+// pvTopologySelectors returns a selector of the constraints on topologyKeys of each term of nodeSelector, or none if
+// a term has no such constraint, as it does not restrict the topology of the nodes of the PV
+func pvTopologySelectors(nodeSelector *corev1.NodeSelector, topologyKeys []string) ([]labels.Selector, error) {
+	selectors := make([]labels.Selector, 0, len(nodeSelector.NodeSelectorTerms))
+
+	for i := range nodeSelector.NodeSelectorTerms {
+		selector := labels.NewSelector()
+
+		for _, expression := range nodeSelector.NodeSelectorTerms[i].MatchExpressions {
+			if !slices.Contains(topologyKeys, expression.Key) {
+				continue
+			}
+
+			requirement, err := labels.NewRequirement(expression.Key, nodeSelectorOperators[expression.Operator],
+				expression.Values)
+			if err != nil {
+				return nil, fmt.Errorf("invalid node affinity requirement on %s: %w", expression.Key, err)
+			}
+
+			selector = selector.Add(*requirement)
+		}
+
+		if selector.Empty() {
+			return nil, nil
+		}
+
+		selectors = append(selectors, selector)
+	}
+
+	return selectors, nil
+}
+
+// listNodes returns the nodes of the cluster, listed once per reconcile
+func (v *VRGInstance) listNodes() ([]corev1.Node, error) {
+	if v.nodes != nil {
+		return v.nodes, nil
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := v.reconciler.APIReader.List(v.ctx, nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	v.nodes = append([]corev1.Node{}, nodes.Items...)
+
+	return v.nodes, nil
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("PV topology", func() {
+	mappings := []rmn.TopologyMapping{
+		{Key: corev1.LabelTopologyZone, From: "east-a", To: "west-a"},
+		{Key: corev1.LabelTopologyZone, From: "west-a", To: "east-a"},
+		{Key: corev1.LabelTopologyRegion, From: "east", To: "west"},
+	}
+
+	pvWithAffinity := func(expressions ...corev1.NodeSelectorRequirement) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "pv1",
+				Labels: map[string]string{corev1.LabelTopologyZone: "east-a", "app": "east-a"},
+			},
+			Spec: corev1.PersistentVolumeSpec{
+				NodeAffinity: &corev1.VolumeNodeAffinity{
+					Required: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: expressions}},
+					},
+				},
+			},
+		}
+	}
+
+	in := func(key string, values ...string) corev1.NodeSelectorRequirement {
+		return corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpIn, Values: values}
+	}
+
+	vrgInstance := func(nodeZones ...string) *VRGInstance {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		for _, zone := range nodeZones {
+			builder = builder.WithObjects(&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:   "node-" + zone,
+				Labels: map[string]string{corev1.LabelTopologyZone: zone, corev1.LabelTopologyRegion: "west"},
+			}})
+		}
+
+		fakeClient := builder.Build()
+
+		return &VRGInstance{
+			reconciler: &VolumeReplicationGroupReconciler{Client: fakeClient, APIReader: fakeClient},
+			ctx:        context.TODO(),
+			log:        logr.Discard(),
+			instance: &rmn.VolumeReplicationGroup{
+				Spec: rmn.VolumeReplicationGroupSpec{PVTopologyMappings: mappings},
+			},
+		}
+	}
+
+	It("rewrites the topology of the node affinity and labels of a PV to that of this cluster", func() {
+		pv := pvWithAffinity(in(corev1.LabelTopologyZone, "east-a", "east-b"), in(corev1.LabelTopologyRegion, "east"),
+			in("kubernetes.io/hostname", "east-a"))
+
+		rewritePVTopology(pv, mappings)
+
+		expressions := pv.Spec.NodeAffinity.Required.NodeSelectorTerms[0].MatchExpressions
+		Expect(expressions).To(Equal([]corev1.NodeSelectorRequirement{
+			in(corev1.LabelTopologyZone, "west-a", "east-b"),
+			in(corev1.LabelTopologyRegion, "west"),
+			in("kubernetes.io/hostname", "east-a"),
+		}))
+		Expect(pv.Labels).To(Equal(map[string]string{corev1.LabelTopologyZone: "west-a", "app": "east-a"}))
+	})
+
+	It("validates a PV whose rewritten topology matches a node of this cluster", func() {
+		pv := pvWithAffinity(in(corev1.LabelTopologyZone, "east-a"), in("kubernetes.io/hostname", "node1"))
+
+		v := vrgInstance("west-a", "west-b")
+		Expect(v.validatePVTopology(pv)).ToNot(Succeed())
+
+		rewritePVTopology(pv, v.instance.Spec.PVTopologyMappings)
+		Expect(v.validatePVTopology(pv)).To(Succeed())
+	})
+
+	It("does not restore a PV whose topology matches no node of this cluster", func() {
+		pv := pvWithAffinity(in(corev1.LabelTopologyZone, "east-b"))
+
+		Expect(vrgInstance("west-a", "west-b").validatePVTopology(pv)).To(MatchError(ContainSubstring(
+			"PV pv1 is not schedulable on this cluster")))
+	})
+
+	It("does not validate node affinity without topology constraints", func() {
+		pv := pvWithAffinity(in("kubernetes.io/hostname", "node1"))
+
+		Expect(vrgInstance().validatePVTopology(pv)).To(Succeed())
+	})
+})
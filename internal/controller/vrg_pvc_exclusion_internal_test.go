@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("PVC exclusion from protection", func() {
+	pvc := func(namespace, name, excluded string) corev1.PersistentVolumeClaim {
+		pvc := corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		if excluded != "" {
+			pvc.Annotations = map[string]string{PVCExcludeFromProtectionAnnotation: excluded}
+		}
+
+		return pvc
+	}
+
+	pvcNames := func(pvcList *corev1.PersistentVolumeClaimList) []string {
+		names := []string{}
+		for i := range pvcList.Items {
+			names = append(names, pvcList.Items[i].Name)
+		}
+
+		return names
+	}
+
+	It("removes the annotated PVCs from the selected PVCs, and returns their names", func() {
+		pvcList := &corev1.PersistentVolumeClaimList{Items: []corev1.PersistentVolumeClaim{
+			pvc("app", "data", ""),
+			pvc("app", "scratch", PVCExcludedFromProtection),
+			pvc("app", "cache", PVCExcludedFromProtection),
+			pvc("app", "logs", "false"),
+		}}
+
+		Expect(pvcsExcludedFilter(pvcList)).To(Equal([]string{"app/cache", "app/scratch"}))
+		Expect(pvcNames(pvcList)).To(Equal([]string{"data", "logs"}))
+	})
+
+	It("returns no names when no PVC is annotated", func() {
+		pvcList := &corev1.PersistentVolumeClaimList{Items: []corev1.PersistentVolumeClaim{pvc("app", "data", "")}}
+
+		Expect(pvcsExcludedFilter(pvcList)).To(BeNil())
+		Expect(pvcNames(pvcList)).To(Equal([]string{"data"}))
+	})
+})
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/kubeobjects"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+// namespaceQuotasGroupName is the name of the capture group of the ResourceQuotas and LimitRanges
+const namespaceQuotasGroupName = "namespace-quotas"
+
+// errNamespaceQuotaExceeded is wrapped by the error of a recovery that a quota of the cluster recovered to would
+// reject, which no other S3 profile would recover from
+var errNamespaceQuotaExceeded = errors.New("namespace quota exceeded")
+
+// namespaceQuotasWorkflowsPrepend prepends a group capturing and recovering the ResourceQuotas and LimitRanges of the
+// protected namespaces of vrg to its capture and recover workflows, so that they are recovered before the workload
+// objects they constrain, whether or not the kube object selector or the recipe select them
+func namespaceQuotasWorkflowsPrepend(recipeElements *util.RecipeElements, vrg ramen.VolumeReplicationGroup,
+	ramenConfig ramen.RamenConfig,
+) {
+	if !vrg.Spec.KubeObjectProtection.NamespaceQuotas {
+		return
+	}
+
+	spec := func() kubeobjects.Spec {
+		return kubeobjects.Spec{
+			KubeResourcesSpec: kubeobjects.KubeResourcesSpec{
+				IncludedNamespaces: kubeObjectsProtectedNamespaces(vrg, ramenConfig),
+				IncludedResources:  []string{"resourcequotas", "limitranges"},
+			},
+		}
+	}
+
+	recipeElements.CaptureWorkflow = append([]kubeobjects.CaptureSpec{{Name: namespaceQuotasGroupName, Spec: spec()}},
+		recipeElements.CaptureWorkflow...)
+	recipeElements.RecoverWorkflow = append(
+		[]kubeobjects.RecoverSpec{{BackupName: namespaceQuotasGroupName, Spec: spec()}},
+		recipeElements.RecoverWorkflow...)
+}
+
+// namespaceQuotasRecoverBefore returns an error if the recover group numbered groupNumber is not to be recovered yet:
+// if the namespace quotas group, recovered first, is not recovered, or if a quota of the protected namespaces is
+// exceeded already, by the volumes recovered before the kube objects, so that the recovery does not fail halfway
+// through with the objects of the group rejected by the quota
+func (v *VRGInstance) namespaceQuotasRecoverBefore(groupNumber int, requests []kubeobjects.Request) error {
+	if !v.instance.Spec.KubeObjectProtection.NamespaceQuotas || groupNumber == 0 {
+		return nil
+	}
+
+	if requests[0] == nil {
+		return kubeobjects.RequestProcessingErrorCreate("namespace quotas not recovered yet")
+	}
+
+	return v.namespaceQuotasVerify()
+}
+
+// namespaceQuotasVerify returns an error if a ResourceQuota of the protected namespaces is not computed yet, or if its
+// usage exceeds its limit for a resource
+func (v *VRGInstance) namespaceQuotasVerify() error {
+	for _, namespaceName := range kubeObjectsProtectedNamespaces(*v.instance, *v.ramenConfig) {
+		quotas := &corev1.ResourceQuotaList{}
+		if err := v.reconciler.APIReader.List(v.ctx, quotas, client.InNamespace(namespaceName)); err != nil {
+			return fmt.Errorf("namespace %s resource quotas list error: %w", namespaceName, err)
+		}
+
+		for i := range quotas.Items {
+			if err := namespaceQuotaVerify(&quotas.Items[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// namespaceQuotaVerify returns an error if quota is not computed yet, or if its usage exceeds its limit for a resource
+func namespaceQuotaVerify(quota *corev1.ResourceQuota) error {
+	if len(quota.Spec.Hard) != 0 && len(quota.Status.Hard) == 0 {
+		return kubeobjects.RequestProcessingErrorCreate(
+			fmt.Sprintf("resource quota %s/%s usage not computed yet", quota.Namespace, quota.Name))
+	}
+
+	exceeded := []string{}
+
+	for name, hard := range quota.Status.Hard {
+		used, ok := quota.Status.Used[name]
+		if ok && used.Cmp(hard) > 0 {
+			exceeded = append(exceeded, fmt.Sprintf("%s used %s of %s", name, used.String(), hard.String()))
+		}
+	}
+
+	if len(exceeded) == 0 {
+		return nil
+	}
+
+	slices.Sort(exceeded)
+
+	return fmt.Errorf("%w: resource quota %s/%s: %s", errNamespaceQuotaExceeded, quota.Namespace, quota.Name,
+		strings.Join(exceeded, ", "))
+}
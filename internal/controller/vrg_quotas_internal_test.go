@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/kubeobjects"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("Namespace quotas", func() {
+	const namespaceName = "app"
+
+	vrg := func() *ramen.VolumeReplicationGroup {
+		return &ramen.VolumeReplicationGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: "vrg"},
+			Spec: ramen.VolumeReplicationGroupSpec{
+				KubeObjectProtection: &ramen.KubeObjectProtectionSpec{NamespaceQuotas: true},
+			},
+		}
+	}
+
+	quota := func(name, hard, used string) *corev1.ResourceQuota {
+		quota := &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName, Name: name},
+			Spec: corev1.ResourceQuotaSpec{
+				Hard: corev1.ResourceList{corev1.ResourceRequestsStorage: resource.MustParse(hard)},
+			},
+		}
+
+		if used != "" {
+			quota.Status = corev1.ResourceQuotaStatus{
+				Hard: quota.Spec.Hard.DeepCopy(),
+				Used: corev1.ResourceList{corev1.ResourceRequestsStorage: resource.MustParse(used)},
+			}
+		}
+
+		return quota
+	}
+
+	vrgInstance := func(objects ...client.Object) *VRGInstance {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+		return &VRGInstance{
+			reconciler:  &VolumeReplicationGroupReconciler{Client: fakeClient, APIReader: fakeClient},
+			ctx:         context.TODO(),
+			log:         logr.Discard(),
+			instance:    vrg(),
+			ramenConfig: &ramen.RamenConfig{},
+		}
+	}
+
+	It("captures the quotas and recovers them before the other kube objects", func() {
+		recipeElements := util.RecipeElements{
+			CaptureWorkflow: captureWorkflowDefault(*vrg(), ramen.RamenConfig{}),
+			RecoverWorkflow: recoverWorkflowDefault(*vrg(), ramen.RamenConfig{}),
+		}
+
+		namespaceQuotasWorkflowsPrepend(&recipeElements, *vrg(), ramen.RamenConfig{})
+
+		Expect(recipeElements.CaptureWorkflow).To(HaveLen(2))
+		Expect(recipeElements.CaptureWorkflow[0].Name).To(Equal(namespaceQuotasGroupName))
+		Expect(recipeElements.CaptureWorkflow[0].IncludedNamespaces).To(Equal([]string{namespaceName}))
+		Expect(recipeElements.CaptureWorkflow[0].IncludedResources).To(
+			Equal([]string{"resourcequotas", "limitranges"}))
+		Expect(recipeElements.CaptureWorkflow[0].LabelSelector).To(BeNil())
+		Expect(recipeElements.RecoverWorkflow).To(HaveLen(2))
+		Expect(recipeElements.RecoverWorkflow[0].BackupName).To(Equal(namespaceQuotasGroupName))
+	})
+
+	It("delays the recovery of the other kube objects until the quotas are recovered and computed", func() {
+		v := vrgInstance(quota("storage", "10Gi", ""))
+		requests := make([]kubeobjects.Request, 2)
+
+		Expect(v.namespaceQuotasRecoverBefore(0, requests)).To(Succeed())
+		Expect(errors.Is(v.namespaceQuotasRecoverBefore(1, requests), kubeobjects.RequestProcessingError{})).To(
+			BeTrue())
+
+		requests[0] = struct{ kubeobjects.Request }{}
+		err := v.namespaceQuotasRecoverBefore(1, requests)
+		Expect(errors.Is(err, kubeobjects.RequestProcessingError{})).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("app/storage usage not computed yet"))
+	})
+
+	It("fails the recovery once the recovered volumes exceed a quota", func() {
+		Expect(vrgInstance(quota("storage", "10Gi", "8Gi")).namespaceQuotasVerify()).To(Succeed())
+
+		err := vrgInstance(quota("storage", "10Gi", "12Gi")).namespaceQuotasVerify()
+		Expect(errors.Is(err, errNamespaceQuotaExceeded)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("app/storage: requests.storage used 12Gi of 10Gi"))
+	})
+})
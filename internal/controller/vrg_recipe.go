@@ -33,13 +33,18 @@ const (
 	WorkflowFullError      = "full-error"
 )
 
-func captureWorkflowDefault(vrg ramen.VolumeReplicationGroup, ramenConfig ramen.RamenConfig) []kubeobjects.CaptureSpec {
-	namespaces := []string{vrg.Namespace}
-
+// kubeObjectsProtectedNamespaces returns the namespaces of the kube objects protected by vrg
+func kubeObjectsProtectedNamespaces(vrg ramen.VolumeReplicationGroup, ramenConfig ramen.RamenConfig) []string {
 	if vrg.Namespace == RamenOperandsNamespace(ramenConfig) {
-		namespaces = *vrg.Spec.ProtectedNamespaces
+		return *vrg.Spec.ProtectedNamespaces
 	}
 
+	return []string{vrg.Namespace}
+}
+
+func captureWorkflowDefault(vrg ramen.VolumeReplicationGroup, ramenConfig ramen.RamenConfig) []kubeobjects.CaptureSpec {
+	namespaces := kubeObjectsProtectedNamespaces(vrg, ramenConfig)
+
 	captureSpecs := []kubeobjects.CaptureSpec{
 		{
 			Spec: kubeobjects.Spec{
@@ -58,11 +63,7 @@ func captureWorkflowDefault(vrg ramen.VolumeReplicationGroup, ramenConfig ramen.
 }
 
 func recoverWorkflowDefault(vrg ramen.VolumeReplicationGroup, ramenConfig ramen.RamenConfig) []kubeobjects.RecoverSpec {
-	namespaces := []string{vrg.Namespace}
-
-	if vrg.Namespace == RamenOperandsNamespace(ramenConfig) {
-		namespaces = *vrg.Spec.ProtectedNamespaces
-	}
+	namespaces := kubeObjectsProtectedNamespaces(vrg, ramenConfig)
 
 	recoverSpecs := []kubeobjects.RecoverSpec{
 		{
@@ -123,6 +124,9 @@ func RecipeElementsGet(ctx context.Context, reader client.Reader, vrg ramen.Volu
 			RestoreFailOn:   WorkflowAnyError,
 		}
 
+		helmReleasesWorkflowsAppend(&recipeElements, vrg, ramenConfig)
+		namespaceQuotasWorkflowsPrepend(&recipeElements, vrg, ramenConfig)
+
 		return recipeElements, nil
 	}
 
@@ -166,6 +170,9 @@ func RecipeElementsGet(ctx context.Context, reader client.Reader, vrg ramen.Volu
 		return recipeElements, fmt.Errorf("recipe %v namespaces validation error: %w", recipeNamespacedName.String(), err)
 	}
 
+	helmReleasesWorkflowsAppend(&recipeElements, vrg, ramenConfig)
+	namespaceQuotasWorkflowsPrepend(&recipeElements, vrg, ramenConfig)
+
 	return recipeElements, nil
 }
 
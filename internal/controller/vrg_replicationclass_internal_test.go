@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	volrep "github.com/csi-addons/kubernetes-csi-addons/api/replication.storage/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("Replication class selection by name", func() {
+	replicationClass := func(name string, isDefault bool) client.Object {
+		replicationClass := &volrep.VolumeReplicationClass{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if isDefault {
+			replicationClass.Annotations = map[string]string{defaultVRCAnnotationKey: "true"}
+		}
+
+		return replicationClass
+	}
+
+	vrgInstance := func(names ...string) *VRGInstance {
+		return &VRGInstance{
+			log: logr.Discard(),
+			instance: &ramen.VolumeReplicationGroup{Spec: ramen.VolumeReplicationGroupSpec{
+				Async: &ramen.VRGAsyncSpec{ReplicationClassNames: names},
+			}},
+		}
+	}
+
+	names := func(replicationClasses []client.Object) []string {
+		names := []string{}
+		for _, replicationClass := range replicationClasses {
+			names = append(names, replicationClass.GetName())
+		}
+
+		return names
+	}
+
+	matching := []client.Object{replicationClass("fast", true), replicationClass("array", false)}
+
+	It("selects the matching class named by the DRPolicy over the default one", func() {
+		Expect(names(vrgInstance("array", "other-cluster-array").filterNamedVRC(matching))).To(
+			Equal([]string{"array"}))
+	})
+
+	It("falls back to the default class when the DRPolicy names none of the matching classes", func() {
+		v := vrgInstance("other")
+		Expect(names(v.filterNamedVRC(matching))).To(Equal([]string{"fast", "array"}))
+
+		selected, err := v.filterDefaultVRC(v.filterNamedVRC(matching), "VolumeReplicationClass")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(selected.GetName()).To(Equal("fast"))
+	})
+})
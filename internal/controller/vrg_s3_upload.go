@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"slices"
+	"sync"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// s3ProfilesUpload runs upload for each of the S3 profiles concurrently, so that a slow object store does not delay
+// the uploads to the others. Returns the profiles upload succeeded for, and the errors of the profiles it failed for,
+// both in the order of the profiles.
+func s3ProfilesUpload(s3ProfileNames []string, upload func(s3ProfileName string) error) ([]string, []error) {
+	errs := make([]error, len(s3ProfileNames))
+
+	var wg sync.WaitGroup
+
+	for i, s3ProfileName := range s3ProfileNames {
+		wg.Go(func() {
+			errs[i] = upload(s3ProfileName)
+		})
+	}
+
+	wg.Wait()
+
+	succeeded := make([]string, 0, len(s3ProfileNames))
+	failed := []error{}
+
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+
+			continue
+		}
+
+		succeeded = append(succeeded, s3ProfileNames[i])
+	}
+
+	return succeeded, failed
+}
+
+// s3UploadPolicySatisfied returns whether the metadata uploaded to succeeded of total S3 profiles is protected by
+// the upload policy
+func s3UploadPolicySatisfied(policy ramen.S3UploadPolicy, succeeded, total int) bool {
+	if succeeded == total {
+		return true
+	}
+
+	return policy == ramen.S3UploadPolicyQuorum && succeeded > total/2
+}
+
+// uploadToS3Profiles runs upload for each of the S3 profiles concurrently, as s3ProfilesUpload does, and records the
+// result for each of the profiles in the VRG status
+func (v *VRGInstance) uploadToS3Profiles(s3ProfileNames []string, upload func(s3ProfileName string) error,
+) ([]string, []error) {
+	succeeded, errs := s3ProfilesUpload(s3ProfileNames, upload)
+
+	v.s3ProfileUploadsRecord(s3ProfileNames, succeeded, errs)
+
+	return succeeded, errs
+}
+
+// s3ProfileUploadsRecord records the profiles uploads failed for as not uploaded, and those they succeeded for as
+// uploaded, unless another upload to the profile failed in the same reconcile. The errors are those of the failed
+// profiles, in the order of the profiles.
+func (v *VRGInstance) s3ProfileUploadsRecord(s3ProfileNames, succeeded []string, errs []error) {
+	uploads := slices.DeleteFunc(v.instance.Status.S3ProfileUploads, func(upload ramen.S3ProfileUploadStatus) bool {
+		return !slices.Contains(v.instance.Spec.S3Profiles, upload.S3ProfileName)
+	})
+
+	if v.s3UploadFailed == nil {
+		v.s3UploadFailed = map[string]bool{}
+	}
+
+	failed := 0
+
+	for _, s3ProfileName := range s3ProfileNames {
+		upload := ramen.S3ProfileUploadStatus{S3ProfileName: s3ProfileName, Uploaded: true}
+
+		switch {
+		case !slices.Contains(succeeded, s3ProfileName):
+			upload.Uploaded = false
+			upload.Message = errs[failed].Error()
+			failed++
+
+			v.s3UploadFailed[s3ProfileName] = true
+		case v.s3UploadFailed[s3ProfileName]:
+			continue
+		}
+
+		idx := slices.IndexFunc(uploads, func(upload ramen.S3ProfileUploadStatus) bool {
+			return upload.S3ProfileName == s3ProfileName
+		})
+		if idx == -1 {
+			uploads = append(uploads, upload)
+
+			continue
+		}
+
+		uploads[idx] = upload
+	}
+
+	v.instance.Status.S3ProfileUploads = uploads
+}
+
+// s3ProfilesLagging returns the S3 profiles of the VRG that are lagging behind the others, as reported in its status
+func s3ProfilesLagging(vrg *ramen.VolumeReplicationGroup) []string {
+	lagging := []string{}
+
+	for _, upload := range vrg.Status.S3ProfileUploads {
+		if !upload.Uploaded {
+			lagging = append(lagging, upload.S3ProfileName)
+		}
+	}
+
+	return lagging
+}
+
+// s3ProfilesRestoreOrder returns the S3 profiles of the VRG in the order to restore from, the profiles that are
+// lagging behind the others last, be it as reported by the hub for the primary VRG, or in the status of the VRG
+func (v *VRGInstance) s3ProfilesRestoreOrder() []string {
+	lagging := append(slices.Clone(v.instance.Spec.LaggingS3Profiles), s3ProfilesLagging(v.instance)...)
+
+	preferred := []string{}
+	last := []string{}
+
+	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
+		if slices.Contains(lagging, s3ProfileName) {
+			last = append(last, s3ProfileName)
+
+			continue
+		}
+
+		preferred = append(preferred, s3ProfileName)
+	}
+
+	return append(preferred, last...)
+}
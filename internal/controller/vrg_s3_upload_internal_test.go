@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/internal/controller/util"
+)
+
+var _ = Describe("S3 profiles upload", func() {
+	It("uploads to the profiles concurrently, and returns the profiles it succeeded and failed for", func() {
+		var started sync.WaitGroup
+
+		started.Add(3)
+
+		succeeded, errs := s3ProfilesUpload([]string{"a", "b", "c"}, func(s3ProfileName string) error {
+			started.Done()
+
+			// none returns until all started, so that a sequential upload would time out
+			allStarted := make(chan struct{})
+			go func() { started.Wait(); close(allStarted) }()
+
+			select {
+			case <-allStarted:
+			case <-time.After(10 * time.Second):
+				return errors.New("timed out waiting for the other uploads")
+			}
+
+			if s3ProfileName == "b" {
+				return errors.New("bucket b unavailable")
+			}
+
+			return nil
+		})
+
+		Expect(succeeded).To(Equal([]string{"a", "c"}))
+		Expect(errs).To(ConsistOf(MatchError("bucket b unavailable")))
+	})
+
+	DescribeTable("accepts the uploads that satisfy the upload policy",
+		func(policy ramen.S3UploadPolicy, succeeded, total int, satisfied bool) {
+			Expect(s3UploadPolicySatisfied(policy, succeeded, total)).To(Equal(satisfied))
+		},
+		Entry("all, of all", ramen.S3UploadPolicyAll, 2, 2, true),
+		Entry("all, of some", ramen.S3UploadPolicyAll, 1, 2, false),
+		Entry("unset, of some", ramen.S3UploadPolicy(""), 2, 3, false),
+		Entry("quorum, of a majority", ramen.S3UploadPolicyQuorum, 2, 3, true),
+		Entry("quorum, of half", ramen.S3UploadPolicyQuorum, 1, 2, false),
+		Entry("quorum, of none", ramen.S3UploadPolicyQuorum, 0, 0, true),
+	)
+
+	Context("of the VRG", func() {
+		var (
+			v        *VRGInstance
+			recorder *record.FakeRecorder
+			stores   map[string]*healthObjectStore
+			results  map[string]bool
+		)
+
+		protect := func(policy ramen.S3UploadPolicy) *metav1.Condition {
+			v.instance.Spec.S3UploadPolicy = policy
+			results = map[string]bool{}
+			result := ctrl.Result{}
+
+			v.vrgObjectProtectThrottled(&result,
+				func() { results["success"] = true },
+				func() { results["failure"] = true },
+			)
+
+			Expect(result.Requeue).To(Equal(results["failure"]))
+
+			return v.vrgObjectProtected
+		}
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(10)
+			stores = map[string]*healthObjectStore{}
+			v = &VRGInstance{
+				reconciler: &VolumeReplicationGroupReconciler{eventRecorder: util.NewEventReporter(recorder)},
+				log:        logr.Discard(),
+				instance: &ramen.VolumeReplicationGroup{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "upload", ResourceVersion: "1"},
+					Spec:       ramen.VolumeReplicationGroupSpec{S3Profiles: []string{"east", "west", "central"}},
+				},
+				namespacedName: "app/upload",
+			}
+
+			for _, name := range []string{"east", "west", "central"} {
+				stores[name] = &healthObjectStore{objects: map[string]interface{}{}}
+				v.s3StoreAccessors = append(v.s3StoreAccessors, s3StoreAccessor{
+					ObjectStorer:   stores[name],
+					S3StoreProfile: ramen.S3StoreProfile{S3ProfileName: name},
+				})
+			}
+
+			DeferCleanup(vrgLastUploadVersion.Delete, v.namespacedName)
+		})
+
+		It("is protected once uploaded to all the profiles", func() {
+			condition := protect(ramen.S3UploadPolicyAll)
+			Expect(results).To(Equal(map[string]bool{"success": true}))
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+			for _, store := range stores {
+				Expect(store.objects).To(HaveLen(1))
+			}
+
+			lastUploadVersion, ok := vrgLastUploadVersion.Load(v.namespacedName)
+			Expect(ok).To(BeTrue())
+			Expect(lastUploadVersion).To(Equal("1"))
+		})
+
+		It("is protected by a quorum of the profiles only with the quorum policy, retrying the others", func() {
+			stores["west"].putFails = true
+
+			condition := protect(ramen.S3UploadPolicyAll)
+			Expect(results).To(Equal(map[string]bool{"failure": true}))
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(recorder.Events).To(Receive(ContainSubstring("failed to upload VRG to s3 profile west")))
+
+			condition = protect(ramen.S3UploadPolicyQuorum)
+			Expect(results).To(Equal(map[string]bool{"success": true}))
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring(
+				"to a quorum of 2 of 3 S3 profiles: [east central], lagging: [west]"))
+			Expect(v.instance.Status.S3ProfileUploads).To(ConsistOf(
+				ramen.S3ProfileUploadStatus{S3ProfileName: "east", Uploaded: true},
+				HaveField("S3ProfileName", "west"),
+				ramen.S3ProfileUploadStatus{S3ProfileName: "central", Uploaded: true},
+			))
+			Expect(s3ProfilesLagging(v.instance)).To(Equal([]string{"west"}))
+
+			_, ok := vrgLastUploadVersion.Load(v.namespacedName)
+			Expect(ok).To(BeFalse())
+
+			stores["central"].putFails = true
+
+			condition = protect(ramen.S3UploadPolicyQuorum)
+			Expect(results).To(Equal(map[string]bool{"failure": true}))
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(recorder.Events).To(Receive(ContainSubstring("failed to upload VRG to s3 profile central")))
+		})
+	})
+
+	Context("results", func() {
+		var v *VRGInstance
+
+		BeforeEach(func() {
+			v = &VRGInstance{instance: &ramen.VolumeReplicationGroup{
+				Spec: ramen.VolumeReplicationGroupSpec{S3Profiles: []string{"east", "west", "central"}},
+				Status: ramen.VolumeReplicationGroupStatus{S3ProfileUploads: []ramen.S3ProfileUploadStatus{
+					{S3ProfileName: "removed", Uploaded: false},
+				}},
+			}}
+		})
+
+		It("are recorded for each profile, a failure lasting for the rest of the reconcile", func() {
+			v.s3ProfileUploadsRecord([]string{"east", "west", "central"}, []string{"east", "central"},
+				[]error{errors.New("bucket west unavailable")})
+			v.s3ProfileUploadsRecord([]string{"east", "west", "central"}, []string{"east", "west"},
+				[]error{errors.New("bucket central unavailable")})
+
+			Expect(v.instance.Status.S3ProfileUploads).To(Equal([]ramen.S3ProfileUploadStatus{
+				{S3ProfileName: "east", Uploaded: true},
+				{S3ProfileName: "west", Uploaded: false, Message: "bucket west unavailable"},
+				{S3ProfileName: "central", Uploaded: false, Message: "bucket central unavailable"},
+			}))
+
+			// a later reconcile
+			v.s3UploadFailed = nil
+			v.s3ProfileUploadsRecord([]string{"east", "west", "central"}, []string{"east", "west", "central"}, nil)
+			Expect(s3ProfilesLagging(v.instance)).To(BeEmpty())
+		})
+
+		It("order the profiles to restore from, the lagging ones last", func() {
+			Expect(v.s3ProfilesRestoreOrder()).To(Equal([]string{"east", "west", "central"}))
+
+			v.instance.Spec.LaggingS3Profiles = []string{"east"}
+			v.instance.Status.S3ProfileUploads = []ramen.S3ProfileUploadStatus{
+				{S3ProfileName: "west", Uploaded: false},
+				{S3ProfileName: "central", Uploaded: true},
+			}
+			Expect(v.s3ProfilesRestoreOrder()).To(Equal([]string{"central", "east", "west"}))
+		})
+
+		It("are passed in to the VRGs by the hub, as reported by the primary VRG", func() {
+			d := &DRPCInstance{vrgs: map[string]*ramen.VolumeReplicationGroup{
+				"west": {Spec: ramen.VolumeReplicationGroupSpec{ReplicationState: ramen.Secondary}},
+			}}
+			vrg := &ramen.VolumeReplicationGroup{
+				Spec: ramen.VolumeReplicationGroupSpec{LaggingS3Profiles: []string{"central"}},
+			}
+
+			d.setVRGLaggingS3Profiles(vrg)
+			Expect(vrg.Spec.LaggingS3Profiles).To(Equal([]string{"central"}))
+
+			v.s3ProfileUploadsRecord([]string{"east", "west"}, []string{"east"}, []error{errors.New("unavailable")})
+			v.instance.Spec.ReplicationState = ramen.Primary
+			d.vrgs["east"] = v.instance
+
+			d.setVRGLaggingS3Profiles(vrg)
+			Expect(vrg.Spec.LaggingS3Profiles).To(Equal([]string{"west"}))
+
+			v.s3UploadFailed = nil
+			v.s3ProfileUploadsRecord([]string{"east", "west"}, []string{"east", "west"}, nil)
+
+			d.setVRGLaggingS3Profiles(vrg)
+			Expect(vrg.Spec.LaggingS3Profiles).To(BeNil())
+		})
+	})
+})
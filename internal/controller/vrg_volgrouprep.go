@@ -236,19 +236,18 @@ func (v *VRGInstance) uploadVGRandVGRCtoS3Stores(vrNamespacedName types.Namespac
 	}
 
 	s3Profiles, err := v.UploadVGRandVGRCtoS3Stores(vgr, log)
-	if err != nil {
+	numProfilesUploaded := len(s3Profiles)
+
+	if !s3UploadPolicySatisfied(v.instance.Spec.S3UploadPolicy, numProfilesUploaded, numProfilesToUpload) {
 		return fmt.Errorf("failed to upload VGR/VGRC with error (%w). Uploaded to %v S3 profile(s)", err, s3Profiles)
 	}
 
-	numProfilesUploaded := len(s3Profiles)
-
 	if numProfilesUploaded != numProfilesToUpload {
-		// Merely defensive as we don't expect to reach here
-		msg := fmt.Sprintf("uploaded VGR/VGRC cluster data to only  %d of %d S3 profile(s): %v",
-			numProfilesUploaded, numProfilesToUpload, s3Profiles)
-		v.log.Info(msg)
+		// Not archived, so that the uploads to the other profiles are retried
+		v.log.Info(fmt.Sprintf("Uploaded VGR/VGRC cluster data to a quorum of %d of %d S3 profile(s): %v",
+			numProfilesUploaded, numProfilesToUpload, s3Profiles), "error", err)
 
-		return errors.New(msg)
+		return nil
 	}
 
 	if err := v.addArchivedAnnotationForVGRandVGRC(vgr, log); err != nil {
@@ -314,21 +313,17 @@ func (v *VRGInstance) UploadVGRAndVGRCtoS3(s3ProfileName string, objectStore Obj
 func (v *VRGInstance) UploadVGRandVGRCtoS3Stores(vgr *volrep.VolumeGroupReplication,
 	log logr.Logger,
 ) ([]string, error) {
-	succeededProfiles := []string{}
 	// Upload the VGR and VGRC to all the S3 profiles in the VRG spec
-	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
-		err := v.UploadVGRandVGRCtoS3Store(s3ProfileName, vgr)
-		if err != nil {
-			rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
-				rmnutil.EventReasonUploadFailed, err.Error())
+	succeededProfiles, errs := v.uploadToS3Profiles(v.instance.Spec.S3Profiles, func(s3ProfileName string) error {
+		return v.UploadVGRandVGRCtoS3Store(s3ProfileName, vgr)
+	})
 
-			return succeededProfiles, err
-		}
-
-		succeededProfiles = append(succeededProfiles, s3ProfileName)
+	for _, err := range errs {
+		rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonUploadFailed, err.Error())
 	}
 
-	return succeededProfiles, nil
+	return succeededProfiles, errors.Join(errs...)
 }
 
 func (v *VRGInstance) getVGRCFromVGR(vgr *volrep.VolumeGroupReplication) (volrep.VolumeGroupReplicationContent, error) {
@@ -1082,7 +1077,10 @@ func (v *VRGInstance) restoreVGRsAndVGRCsFromS3(result *ctrl.Result) error {
 	err := errors.New("s3Profiles empty")
 	NoS3 := false
 
-	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
+	var corruptErr error
+
+	// Restore from the profiles the metadata was fully uploaded to first, as the others may miss VGRs and VGRCs
+	for _, s3ProfileName := range v.s3ProfilesRestoreOrder() {
 		if s3ProfileName == NoS3StoreAvailable {
 			v.log.Info("NoS3 available to fetch")
 
@@ -1101,15 +1099,29 @@ func (v *VRGInstance) restoreVGRsAndVGRCsFromS3(result *ctrl.Result) error {
 			continue
 		}
 
-		var vgrcCount, vgrCount int
+		var (
+			vgrcList            []volrep.VolumeGroupReplicationContent
+			vgrList             []volrep.VolumeGroupReplication
+			vgrcCount, vgrCount int
+		)
+
+		// Download the VGRCs and VGRs before restoring any, so that a corrupt capture is not partly restored
+		vgrcList, vgrList, err = v.downloadVGRCsAndVGRs(objectStore, s3ProfileName)
+		if err != nil {
+			if isObjectCorrupt(err) {
+				corruptErr = err
+			}
+
+			continue
+		}
 
 		// Restore all VGRCs found in the s3 store. If any failure, the next profile will be retried
-		vgrcCount, err = v.restoreVGRCsFromObjectStore(objectStore, s3ProfileName)
+		vgrcCount, err = v.restoreVGRCsFromObjectStore(vgrcList, s3ProfileName)
 		if err != nil {
 			continue
 		}
 
-		vgrCount, err = v.restoreVGRsFromObjectStore(objectStore, s3ProfileName)
+		vgrCount, err = v.restoreVGRsFromObjectStore(vgrList)
 		if err != nil || vgrcCount != vgrCount {
 			v.log.Info(fmt.Sprintf("Warning: Mismatch in VGRC/VGR count %d/%d (%v)",
 				vgrcCount, vgrCount, err))
@@ -1128,20 +1140,41 @@ func (v *VRGInstance) restoreVGRsAndVGRCsFromS3(result *ctrl.Result) error {
 
 	result.Requeue = true
 
+	if corruptErr != nil {
+		return corruptErr
+	}
+
 	return err
 }
 
-func (v *VRGInstance) restoreVGRCsFromObjectStore(objectStore ObjectStorer, s3ProfileName string) (int, error) {
+// downloadVGRCsAndVGRs downloads the VGRCs and VGRs of the VRG from objectStore, returning an errObjectCorrupt error
+// if any does not match its checksum
+func (v *VRGInstance) downloadVGRCsAndVGRs(objectStore ObjectStorer, s3ProfileName string,
+) ([]volrep.VolumeGroupReplicationContent, []volrep.VolumeGroupReplication, error) {
 	vgrcList, err := downloadVGRCs(objectStore, v.s3KeyPrefix())
 	if err != nil {
 		v.log.Error(err, fmt.Sprintf("error fetching VGRC cluster data from S3 profile %s", s3ProfileName))
 
-		return 0, err
+		return nil, nil, err
+	}
+
+	vgrList, err := downloadVGRs(objectStore, v.s3KeyPrefix())
+	if err != nil {
+		v.log.Error(err, fmt.Sprintf("error fetching VGR cluster data from S3 profile %s", s3ProfileName))
+
+		return nil, nil, err
 	}
 
-	v.log.Info(fmt.Sprintf("Found %d VGRCs in s3 store using profile %s", len(vgrcList), s3ProfileName))
+	v.log.Info(fmt.Sprintf("Found %d VGRCs and %d VGRs in s3 store using profile %s", len(vgrcList), len(vgrList),
+		s3ProfileName))
 
-	if err = v.checkVGRCClusterData(vgrcList); err != nil {
+	return vgrcList, vgrList, nil
+}
+
+func (v *VRGInstance) restoreVGRCsFromObjectStore(vgrcList []volrep.VolumeGroupReplicationContent,
+	s3ProfileName string,
+) (int, error) {
+	if err := v.checkVGRCClusterData(vgrcList); err != nil {
 		errMsg := fmt.Sprintf("Error found in VGRC cluster data in S3 store %s", s3ProfileName)
 		v.log.Info(errMsg)
 		v.log.Error(err, fmt.Sprintf("Resolve VGRC conflict in the S3 store %s to deploy the application", s3ProfileName))
@@ -1152,16 +1185,7 @@ func (v *VRGInstance) restoreVGRCsFromObjectStore(objectStore ObjectStorer, s3Pr
 	return restoreClusterDataObjects(v, vgrcList, "VGRC", v.cleanupVGRCForRestore, v.validateExistingVGRC)
 }
 
-func (v *VRGInstance) restoreVGRsFromObjectStore(objectStore ObjectStorer, s3ProfileName string) (int, error) {
-	vgrList, err := downloadVGRs(objectStore, v.s3KeyPrefix())
-	if err != nil {
-		v.log.Error(err, fmt.Sprintf("error fetching VGR cluster data from S3 profile %s", s3ProfileName))
-
-		return 0, err
-	}
-
-	v.log.Info(fmt.Sprintf("Found %d VGRs in s3 store using profile %s", len(vgrList), s3ProfileName))
-
+func (v *VRGInstance) restoreVGRsFromObjectStore(vgrList []volrep.VolumeGroupReplication) (int, error) {
 	return restoreClusterDataObjects(v, vgrList, "VGR", v.cleanupVGRForRestore, v.validateExistingVGR)
 }
 
@@ -747,20 +747,21 @@ func (v *VRGInstance) uploadPVandPVCtoS3Stores(pvc *corev1.PersistentVolumeClaim
 	}
 
 	s3Profiles, err := v.UploadPVandPVCtoS3Stores(pvc, log)
-	if err != nil {
+	numProfilesUploaded := len(s3Profiles)
+
+	if !s3UploadPolicySatisfied(v.instance.Spec.S3UploadPolicy, numProfilesUploaded, numProfilesToUpload) {
 		return fmt.Errorf("failed to upload PV/PVC with error (%w). Uploaded to %v S3 profile(s)", err, s3Profiles)
 	}
 
-	numProfilesUploaded := len(s3Profiles)
-
 	if numProfilesUploaded != numProfilesToUpload {
-		msg := fmt.Sprintf("uploaded PV/PVC cluster data to only  %d of %d S3 profile(s): %v",
-			numProfilesUploaded, numProfilesToUpload, s3Profiles)
-		v.log.Info(msg)
+		// Not archived, so that the uploads to the other profiles are retried
+		msg := fmt.Sprintf("Uploaded PV/PVC cluster data to a quorum of %d of %d S3 profile(s): %v, lagging: %v",
+			numProfilesUploaded, numProfilesToUpload, s3Profiles, s3ProfilesLagging(v.instance))
+		v.log.Info(msg, "error", err)
 		v.updatePVCClusterDataProtectedCondition(pvc.Namespace, pvc.Name,
-			VRGConditionReasonUploadError, msg)
+			VRGConditionReasonUploaded, msg)
 
-		return errors.New(msg)
+		return nil
 	}
 
 	if err := v.addArchivedAnnotationForPVC(pvc, log); err != nil {
@@ -929,22 +930,18 @@ func (v *VRGInstance) UploadPVAndPVCtoS3(s3ProfileName string, objectStore Objec
 func (v *VRGInstance) UploadPVandPVCtoS3Stores(pvc *corev1.PersistentVolumeClaim,
 	log logr.Logger,
 ) ([]string, error) {
-	succeededProfiles := []string{}
 	// Upload the PV to all the S3 profiles in the VRG spec
-	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
-		err := v.UploadPVandPVCtoS3Store(s3ProfileName, pvc)
-		if err != nil {
-			v.updatePVCClusterDataProtectedCondition(pvc.Namespace, pvc.Name, VRGConditionReasonUploadError, err.Error())
-			rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
-				rmnutil.EventReasonUploadFailed, err.Error())
-
-			return succeededProfiles, err
-		}
+	succeededProfiles, errs := v.uploadToS3Profiles(v.instance.Spec.S3Profiles, func(s3ProfileName string) error {
+		return v.UploadPVandPVCtoS3Store(s3ProfileName, pvc)
+	})
 
-		succeededProfiles = append(succeededProfiles, s3ProfileName)
+	for _, err := range errs {
+		v.updatePVCClusterDataProtectedCondition(pvc.Namespace, pvc.Name, VRGConditionReasonUploadError, err.Error())
+		rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonUploadFailed, err.Error())
 	}
 
-	return succeededProfiles, nil
+	return succeededProfiles, errors.Join(errs...)
 }
 
 func (v *VRGInstance) getPVFromPVC(pvc *corev1.PersistentVolumeClaim) (corev1.PersistentVolume, error) {
@@ -983,6 +980,9 @@ func (v *VRGInstance) getObjectStorer(s3ProfileName string) (ObjectStorer, error
 }
 
 func (v *VRGInstance) getCachedObjectStorer(s3ProfileName string) (ObjectStorer, error) {
+	v.objectStorersMutex.Lock()
+	defer v.objectStorersMutex.Unlock()
+
 	if cachedObjectStore, ok := v.objectStorers[s3ProfileName]; ok {
 		return cachedObjectStore.storer, cachedObjectStore.err
 	}
@@ -991,6 +991,9 @@ func (v *VRGInstance) getCachedObjectStorer(s3ProfileName string) (ObjectStorer,
 }
 
 func (v *VRGInstance) cacheObjectStorer(s3ProfileName string, objectStore ObjectStorer, err error) {
+	v.objectStorersMutex.Lock()
+	defer v.objectStorersMutex.Unlock()
+
 	v.objectStorers[s3ProfileName] = cachedObjectStorer{
 		storer: objectStore,
 		err:    err,
@@ -1619,12 +1622,15 @@ func (v *VRGInstance) selectVolumeReplicationClass(
 			pvc.GetName(), err)
 	}
 
+	schedulingInterval := rmnutil.StorageClassSchedulingInterval(v.instance.Spec.Async.StorageClassSchedulingIntervals,
+		storageClass, v.instance.Spec.Async.SchedulingInterval)
+
 	matchingReplicationClassList := []client.Object{}
 
 	filterMatchingReplicationClass := func(replicationClass client.Object, parameters map[string]string,
 		provisioner string, rIDLabel string,
 	) {
-		schedulingInterval, found := parameters[ReplicationClassScheduleKey]
+		replicationClassSchedulingInterval, found := parameters[ReplicationClassScheduleKey]
 
 		if storageClass.Provisioner != provisioner || !found {
 			// skip this replication class if provisioner does not match or if schedule not found
@@ -1632,7 +1638,7 @@ func (v *VRGInstance) selectVolumeReplicationClass(
 		}
 
 		// ReplicationClass that matches both VRG schedule and pvc provisioner
-		if schedulingInterval != v.instance.Spec.Async.SchedulingInterval {
+		if replicationClassSchedulingInterval != schedulingInterval {
 			return
 		}
 
@@ -1688,17 +1694,45 @@ func (v *VRGInstance) selectVolumeReplicationClass(
 	switch len(matchingReplicationClassList) {
 	case 0:
 		v.log.Info(fmt.Sprintf("No %s found to match provisioner and schedule %s/%s", objType,
-			storageClass.Provisioner, v.instance.Spec.Async.SchedulingInterval))
+			storageClass.Provisioner, schedulingInterval))
 
 		return nil, fmt.Errorf("no %s found to match provisioner and schedule", objType)
 	case 1:
 		v.log.Info(fmt.Sprintf("Found %s that matches provisioner and schedule %s/%s", objType,
-			storageClass.Provisioner, v.instance.Spec.Async.SchedulingInterval))
+			storageClass.Provisioner, schedulingInterval))
 
 		return matchingReplicationClassList[0], nil
 	}
 
-	return v.filterDefaultVRC(matchingReplicationClassList, objType)
+	namedReplicationClassList := v.filterNamedVRC(matchingReplicationClassList)
+	if len(namedReplicationClassList) == 1 {
+		v.log.Info(fmt.Sprintf("Found %s named by the DRPolicy that matches provisioner and schedule %s/%s", objType,
+			storageClass.Provisioner, schedulingInterval))
+
+		return namedReplicationClassList[0], nil
+	}
+
+	return v.filterDefaultVRC(namedReplicationClassList, objType)
+}
+
+// filterNamedVRC filters the VRC list to return the VRCs named by the replication class names of the VRG, as set
+// from its DRPolicy, or the list if the VRG names none of them
+func (v *VRGInstance) filterNamedVRC(replicationClassList []client.Object) []client.Object {
+	names := v.instance.Spec.Async.ReplicationClassNames
+
+	filteredVRCs := []client.Object{}
+
+	for index := range replicationClassList {
+		if slices.Contains(names, replicationClassList[index].GetName()) {
+			filteredVRCs = append(filteredVRCs, replicationClassList[index])
+		}
+	}
+
+	if len(filteredVRCs) == 0 {
+		return replicationClassList
+	}
+
+	return filteredVRCs
 }
 
 // filterDefaultVRC filters the VRC list to return VRCs with default annotation
@@ -2129,13 +2163,24 @@ func isVRConditionMet(volRep client.Object, status *volrep.VolumeReplicationStat
 	}
 
 	if volRepCondition.Status != desiredStatus {
-		// csi-addons > 0.10.0 returns detailed error message
-		return !met, conditionKnown, volRepCondition.Message
+		// csi-addons > 0.10.0 returns detailed error message, which is passed through to the conditions of the VRG,
+		// and from there to the hub
+		return !met, conditionKnown, rmnutil.StorageMessage(volRepCondition.Message)
 	}
 
 	return met, conditionKnown, ""
 }
 
+// protectedPVCErrorMessage returns the message of the error condition of protectedPVC, such as the error of its
+// storage driver, to be appended to the message of the corresponding condition of the VRG, if it has one
+func protectedPVCErrorMessage(protectedPVC *ramendrv1alpha1.ProtectedPVC, condition *metav1.Condition) string {
+	if condition.Message == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(": PVC %s/%s: %s", protectedPVC.Namespace, protectedPVC.Name, condition.Message)
+}
+
 func (v *VRGInstance) updatePVCDataReadyCondition(pvcNamespace, pvcName, reason, message string) {
 	protectedPVC := v.findProtectedPVC(pvcNamespace, pvcName)
 	if protectedPVC == nil {
@@ -2414,7 +2459,10 @@ func (v *VRGInstance) restorePVsAndPVCsFromS3(result *ctrl.Result) (int, error)
 	err := errors.New("s3Profiles empty")
 	NoS3 := false
 
-	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
+	var corruptErr error
+
+	// Restore from the profiles the metadata was fully uploaded to first, as the others may miss PVs and PVCs
+	for _, s3ProfileName := range v.s3ProfilesRestoreOrder() {
 		if s3ProfileName == NoS3StoreAvailable {
 			v.log.Info("NoS3 available to fetch")
 
@@ -2425,18 +2473,32 @@ func (v *VRGInstance) restorePVsAndPVCsFromS3(result *ctrl.Result) (int, error)
 
 		var objectStore ObjectStorer
 
-		objectStore, _, err = v.reconciler.ObjStoreGetter.ObjectStore(
-			v.ctx, v.reconciler.APIReader, s3ProfileName, v.namespacedName, v.log)
+		objectStore, err = v.restoreObjectStore(s3ProfileName)
 		if err != nil {
 			v.log.Error(err, "Kube objects recovery object store inaccessible", "profile", s3ProfileName)
 
 			continue
 		}
 
-		var pvCount, pvcCount int
+		var (
+			pvList            []corev1.PersistentVolume
+			pvcList           []corev1.PersistentVolumeClaim
+			pvCount, pvcCount int
+		)
+
+		// Download the PVs and PVCs before restoring any, so that a corrupt capture is not partly restored. If any
+		// failure, the next profile will be retried
+		pvList, pvcList, err = v.downloadPVsAndPVCs(objectStore, s3ProfileName)
+		if err != nil {
+			if isObjectCorrupt(err) {
+				corruptErr = err
+			}
+
+			continue
+		}
 
 		// Restore all PVs found in the s3 store. If any failure, the next profile will be retried
-		pvCount, err = v.restorePVsFromObjectStore(objectStore, s3ProfileName)
+		pvCount, err = v.restorePVsFromObjectStore(pvList, s3ProfileName)
 		if err != nil {
 			continue
 		}
@@ -2448,7 +2510,7 @@ func (v *VRGInstance) restorePVsAndPVCsFromS3(result *ctrl.Result) (int, error)
 		// CrunchyDB is responsible for creating and managing the lifecycle of their own PVCs, a newly created
 		// PVC may cause a new PV to be created.
 		// Ignoring PVC restore errors helps with the upgrade from ODF-4.12.x to 4.13
-		pvcCount, err = v.restorePVCsFromObjectStore(objectStore, s3ProfileName)
+		pvcCount, err = v.restorePVCsFromObjectStore(pvcList)
 		if err != nil || pvCount != pvcCount {
 			v.log.Info(fmt.Sprintf("Warning: Mismatch in PV/PVC count %d/%d (%v)",
 				pvCount, pvcCount, err))
@@ -2467,20 +2529,39 @@ func (v *VRGInstance) restorePVsAndPVCsFromS3(result *ctrl.Result) (int, error)
 
 	result.Requeue = true
 
+	if corruptErr != nil {
+		return 0, corruptErr
+	}
+
 	return 0, err
 }
 
-func (v *VRGInstance) restorePVsFromObjectStore(objectStore ObjectStorer, s3ProfileName string) (int, error) {
+// downloadPVsAndPVCs downloads the PVs and PVCs of the VRG from objectStore, returning an errObjectCorrupt error if
+// any does not match its checksum
+func (v *VRGInstance) downloadPVsAndPVCs(objectStore ObjectStorer, s3ProfileName string,
+) ([]corev1.PersistentVolume, []corev1.PersistentVolumeClaim, error) {
 	pvList, err := downloadPVs(objectStore, v.s3KeyPrefix())
 	if err != nil {
 		v.log.Error(err, fmt.Sprintf("error fetching PV cluster data from S3 profile %s", s3ProfileName))
 
-		return 0, err
+		return nil, nil, err
 	}
 
-	v.log.Info(fmt.Sprintf("Found %d PVs in s3 store using profile %s", len(pvList), s3ProfileName))
+	pvcList, err := downloadPVCs(objectStore, v.s3KeyPrefix())
+	if err != nil {
+		v.log.Error(err, fmt.Sprintf("error fetching PVC cluster data from S3 profile %s", s3ProfileName))
+
+		return nil, nil, err
+	}
+
+	v.log.Info(fmt.Sprintf("Found %d PVs and %d PVCs in s3 store using profile %s", len(pvList), len(pvcList),
+		s3ProfileName))
+
+	return pvList, pvcList, nil
+}
 
-	if err = v.checkPVClusterData(pvList); err != nil {
+func (v *VRGInstance) restorePVsFromObjectStore(pvList []corev1.PersistentVolume, s3ProfileName string) (int, error) {
+	if err := v.checkPVClusterData(pvList); err != nil {
 		errMsg := fmt.Sprintf("Error found in PV cluster data in S3 store %s", s3ProfileName)
 		v.log.Info(errMsg)
 		v.log.Error(err, fmt.Sprintf("Resolve PV conflict in the S3 store %s to deploy the application", s3ProfileName))
@@ -2488,19 +2569,10 @@ func (v *VRGInstance) restorePVsFromObjectStore(objectStore ObjectStorer, s3Prof
 		return 0, fmt.Errorf("%s: %w", errMsg, err)
 	}
 
-	return restoreClusterDataObjects(v, pvList, "PV", v.cleanupPVForRestore, v.validateExistingPV)
+	return restoreClusterDataObjects(v, pvList, "PV", v.cleanupPVForRestoreFromObjectStore, v.validateExistingPV)
 }
 
-func (v *VRGInstance) restorePVCsFromObjectStore(objectStore ObjectStorer, s3ProfileName string) (int, error) {
-	pvcList, err := downloadPVCs(objectStore, v.s3KeyPrefix())
-	if err != nil {
-		v.log.Error(err, fmt.Sprintf("error fetching PVC cluster data from S3 profile %s", s3ProfileName))
-
-		return 0, err
-	}
-
-	v.log.Info(fmt.Sprintf("Found %d PVCs in s3 store using profile %s", len(pvcList), s3ProfileName))
-
+func (v *VRGInstance) restorePVCsFromObjectStore(pvcList []corev1.PersistentVolumeClaim) (int, error) {
 	v.volRepPVCs = append(v.volRepPVCs, pvcList...)
 
 	return restoreClusterDataObjects(v, pvcList, "PVC", cleanupPVCForRestore, v.validateExistingPVC)
@@ -2936,6 +3008,7 @@ func (v *VRGInstance) aggregateVolRepDataReadyCondition() *metav1.Condition {
 
 	vrgReady := len(v.instance.Status.ProtectedPVCs) != 0
 	vrgProgressing := false
+	pvcError := ""
 
 	for _, protectedPVC := range v.instance.Status.ProtectedPVCs {
 		if protectedPVC.ProtectedByVolSync {
@@ -2971,6 +3044,7 @@ func (v *VRGInstance) aggregateVolRepDataReadyCondition() *metav1.Condition {
 			// then entire VRG should mark its condition as error. Set
 			// vrgPogressing to false.
 			vrgProgressing = false
+			pvcError = protectedPVCErrorMessage(&protectedPVC, condition)
 
 			v.log.Info(fmt.Sprintf("Condition %s has error reason %s for vrg %s/%s", VRGConditionTypeDataReady,
 				condition.Reason, v.instance.Name, v.instance.Namespace))
@@ -2995,7 +3069,7 @@ func (v *VRGInstance) aggregateVolRepDataReadyCondition() *metav1.Condition {
 	// Set Error condition for VRG.
 	v.log.Info("Marking VRG not DataReady with error. All PVCs are not ready")
 
-	msg := "All PVCs of the VolumeReplicationGroup are not ready"
+	msg := "All PVCs of the VolumeReplicationGroup are not ready" + pvcError
 
 	return newVRGDataErrorCondition(v.instance.Generation, msg)
 }
@@ -3024,6 +3098,7 @@ func (v *VRGInstance) aggregateVolRepDataProtectedCondition() *metav1.Condition
 
 	vrgProtected := true
 	vrgReplicating := false
+	pvcError := ""
 
 	for _, protectedPVC := range v.instance.Status.ProtectedPVCs {
 		if protectedPVC.ProtectedByVolSync {
@@ -3056,6 +3131,7 @@ func (v *VRGInstance) aggregateVolRepDataProtectedCondition() *metav1.Condition
 			// Even a single pvc seeing error means, entire VRG marks this
 			// condition as error. Set vrgReplicating to false
 			vrgReplicating = false
+			pvcError = protectedPVCErrorMessage(&protectedPVC, condition)
 
 			v.log.Info(fmt.Sprintf("Condition %s has error reason %s for vrg",
 				VRGConditionTypeDataProtected, condition.Reason))
@@ -3083,7 +3159,7 @@ func (v *VRGInstance) aggregateVolRepDataProtectedCondition() *metav1.Condition
 	// VRG is neither Data Protected nor Replicating
 	v.log.Info("Marking VRG data not protected with error. All PVCs are not ready")
 
-	msg := "All PVCs of the VolumeReplicationGroup are not ready"
+	msg := "All PVCs of the VolumeReplicationGroup are not ready" + pvcError
 
 	return newVRGAsDataNotProtectedCondition(v.instance.Generation, msg)
 }
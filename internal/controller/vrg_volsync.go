@@ -816,7 +816,7 @@ func (v *VRGInstance) buildDataProtectedCondition() *metav1.Condition {
 	return dataProtectedCondition
 }
 
-func (v VRGInstance) isVolSyncProtectedPVCConditionReady(conType string) bool {
+func (v *VRGInstance) isVolSyncProtectedPVCConditionReady(conType string) bool {
 	ready := len(v.instance.Status.ProtectedPVCs) != 0
 
 	for _, protectedPVC := range v.instance.Status.ProtectedPVCs {
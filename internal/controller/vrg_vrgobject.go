@@ -4,13 +4,17 @@
 package controllers
 
 import (
+	"fmt"
 	"sync"
+	"time"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	ramen "github.com/ramendr/ramen/api/v1alpha1"
 	"github.com/ramendr/ramen/internal/controller/util"
+	"github.com/ramendr/ramen/pkg/metadata"
 )
 
 var vrgLastUploadVersion sync.Map
@@ -43,37 +47,115 @@ func (v *VRGInstance) vrgObjectProtectThrottled(result *ctrl.Result,
 	eventReporter := v.reconciler.eventRecorder
 	log := v.log
 
-	for _, s3StoreAccessor := range v.s3StoreAccessors {
-		log1 := log.WithValues("profile", s3StoreAccessor.S3ProfileName)
+	s3ProfileNames := make([]string, len(v.s3StoreAccessors))
+	s3StoreAccessors := make(map[string]s3StoreAccessor, len(v.s3StoreAccessors))
+
+	for i, s3StoreAccessor := range v.s3StoreAccessors {
+		s3ProfileNames[i] = s3StoreAccessor.S3ProfileName
+		s3StoreAccessors[s3StoreAccessor.S3ProfileName] = s3StoreAccessor
+	}
+
+	succeeded, errs := v.uploadToS3Profiles(s3ProfileNames, func(s3ProfileName string) error {
+		s3StoreAccessor := s3StoreAccessors[s3ProfileName]
+		log1 := log.WithValues("profile", s3ProfileName)
 
 		if err := VrgObjectProtect(s3StoreAccessor.ObjectStorer, *vrg); err != nil {
-			util.ReportIfNotPresent(
-				eventReporter, vrg, corev1.EventTypeWarning, util.EventReasonVrgUploadFailed, err.Error(),
-			)
+			log1.Error(err, "VRG Kube object protect error")
 
-			const message = "VRG Kube object protect error"
+			return fmt.Errorf("failed to upload VRG to s3 profile %s, %w", s3ProfileName, err)
+		}
 
-			log1.Error(err, message)
+		log1.Info("VRG Kube object protected")
+		v.captureGenerationRecord(s3StoreAccessor, log1)
 
-			v.vrgObjectProtected = newVRGClusterDataUnprotectedCondition(vrg.Generation,
-				"VolumeReplicationGroupObjectCaptureError", message)
-			result.Requeue = true
+		return nil
+	})
 
-			failure()
+	for _, err := range errs {
+		util.ReportIfNotPresent(
+			eventReporter, vrg, corev1.EventTypeWarning, util.EventReasonVrgUploadFailed, err.Error(),
+		)
+	}
 
-			return
-		}
+	if !s3UploadPolicySatisfied(vrg.Spec.S3UploadPolicy, len(succeeded), len(s3ProfileNames)) {
+		v.vrgObjectProtected = newVRGClusterDataUnprotectedCondition(vrg.Generation,
+			"VolumeReplicationGroupObjectCaptureError", "VRG Kube object protect error")
+		result.Requeue = true
 
-		log1.Info("VRG Kube object protected")
+		failure()
 
-		vrgLastUploadVersion.Store(v.namespacedName, vrg.ResourceVersion)
-		v.vrgObjectProtected = newVRGClusterDataProtectedCondition(vrg.Generation, vrgClusterDataProtectedTrueMessage)
+		return
+	}
+
+	if len(s3ProfileNames) != 0 {
+		message := vrgClusterDataProtectedTrueMessage
+		if len(errs) == 0 {
+			vrgLastUploadVersion.Store(v.namespacedName, vrg.ResourceVersion)
+		} else {
+			// the uploads to the other profiles are retried, as the version uploaded is not recorded
+			message = fmt.Sprintf("%s to a quorum of %d of %d S3 profiles: %v, lagging: %v", message, len(succeeded),
+				len(s3ProfileNames), succeeded, s3ProfilesLagging(v.instance))
+		}
+
+		v.vrgObjectProtected = newVRGClusterDataProtectedCondition(vrg.Generation, message)
 	}
 
 	success()
 }
 
-const vrgS3ObjectNameSuffix = "a"
+// vrgCaptureGenerationNext holds, by VRG and S3 profile, the time before which no capture generation is due
+var vrgCaptureGenerationNext sync.Map
+
+// captureGenerationRecord records a capture generation of the objects of the VRG, once due, if the S3 profile
+// enables versioning. A failure is logged only, as the latest objects are protected regardless.
+func (v *VRGInstance) captureGenerationRecord(accessor s3StoreAccessor, log logr.Logger) {
+	versioning := accessor.S3StoreProfile.Versioning
+	if versioning == nil {
+		return
+	}
+
+	cacheKey := v.namespacedName + "/" + accessor.S3ProfileName
+	now := time.Now()
+
+	if next, ok := vrgCaptureGenerationNext.Load(cacheKey); ok && now.Before(next.(time.Time)) {
+		return
+	}
+
+	generation, next, err := captureGenerationRecord(accessor.ObjectStorer, versioning, v.instance.Namespace,
+		v.instance.Name, now)
+	if err != nil {
+		log.Info("VRG capture generation record failed", "error", err)
+
+		return
+	}
+
+	vrgCaptureGenerationNext.Store(cacheKey, next)
+
+	if generation != 0 {
+		log.Info("VRG capture generation recorded", "generation", generation)
+	}
+}
+
+// restoreObjectStore returns the object store of the S3 profile to restore the objects of the VRG from, reading them
+// as they were at the capture generation the VRG selects, if any
+func (v *VRGInstance) restoreObjectStore(s3ProfileName string) (ObjectStorer, error) {
+	objectStore, _, err := v.reconciler.ObjStoreGetter.ObjectStore(
+		v.ctx, v.reconciler.APIReader, s3ProfileName, v.namespacedName, v.log)
+	if err != nil {
+		return nil, err
+	}
+
+	generation := v.instance.Spec.RestoreCaptureGeneration
+	if generation == 0 {
+		return objectStore, nil
+	}
+
+	v.log.Info("Restoring from capture generation", "generation", generation, "profile", s3ProfileName)
+
+	return captureGenerationObjectStore(objectStore, v.instance.Namespace, v.kubeObjectsSourceVRGName(), generation)
+}
+
+const vrgS3ObjectNameSuffix = metadata.VolumeReplicationGroupName
 
 func VrgObjectProtect(objectStorer ObjectStorer, vrg ramen.VolumeReplicationGroup) error {
 	return uploadTypedObject(objectStorer, s3PathNamePrefix(vrg.Namespace, vrg.Name), vrgS3ObjectNameSuffix, vrg)
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrObjectCorrupt is returned, wrapped, when an object read does not match its checksum, or is not a json document
+// once decompressed, as when it was truncated
+var ErrObjectCorrupt = errors.New("object corrupt")
+
+// Checksum returns the envelope of object along with the hash of its json encoding
+func Checksum(object interface{}) (ChecksummedObject, error) {
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return ChecksummedObject{}, fmt.Errorf("failed to json encode, %w", err)
+	}
+
+	return ChecksummedObject{Format: ChecksummedObjectFormat, SHA256: sha256Hex(encoded), Object: encoded}, nil
+}
+
+// ChecksummedObjectOf returns the envelope that the json document data is, and whether it is one
+func ChecksummedObjectOf(data []byte) (ChecksummedObject, bool) {
+	envelope := ChecksummedObject{}
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Format != ChecksummedObjectFormat {
+		return ChecksummedObject{}, false
+	}
+
+	return envelope, true
+}
+
+// Verify returns the json encoding of the object of the envelope, read from the object with key, or an
+// ErrObjectCorrupt error if it does not match its hash
+func (c ChecksummedObject) Verify(key string) ([]byte, error) {
+	if sum := sha256Hex(c.Object); sum != c.SHA256 {
+		return nil, fmt.Errorf("%w: object %s has checksum %s instead of %s", ErrObjectCorrupt, key, sum, c.SHA256)
+	}
+
+	return c.Object, nil
+}
+
+// Unchecksummed returns data, a json document read from the object with key, verified and unwrapped if it is a
+// ChecksummedObject envelope, or as is otherwise, as objects written before checksums were recorded have none
+func Unchecksummed(key string, data []byte) ([]byte, error) {
+	envelope, ok := ChecksummedObjectOf(data)
+	if !ok {
+		return data, nil
+	}
+
+	return envelope.Verify(key)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// gzipMagic starts every gzip stream, and no json document, so that compressed and uncompressed objects are told apart
+// on read whatever the compression of the profile they were written with
+const gzipMagic = "\x1f\x8b"
+
+// Compression is the compression of the objects written with an S3 profile, gzip with its default level for the zero
+// value
+type Compression struct {
+	Algorithm ramen.ObjectCompressionAlgorithm
+	Level     int
+}
+
+// ContentEncoding returns the content encoding of the objects compressed with c, empty if they are not compressed
+func (c Compression) ContentEncoding() string {
+	if c.Algorithm == ramen.ObjectCompressionNone {
+		return ""
+	}
+
+	return "gzip"
+}
+
+// Compress returns data compressed with c
+func (c Compression) Compress(data []byte) ([]byte, error) {
+	if c.Algorithm == ramen.ObjectCompressionNone {
+		return data, nil
+	}
+
+	level := c.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	compressed := &bytes.Buffer{}
+
+	gzWriter, err := gzip.NewWriterLevel(compressed, level)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := gzWriter.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := gzWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close gzip writer, %w", err)
+	}
+
+	return compressed.Bytes(), nil
+}
+
+// Encode returns object json encoded and compressed with c
+func (c Compression) Encode(object interface{}) ([]byte, error) {
+	encoded := &bytes.Buffer{}
+	if err := json.NewEncoder(encoded).Encode(object); err != nil {
+		return nil, fmt.Errorf("failed to json encode, %w", err)
+	}
+
+	return c.Compress(encoded.Bytes())
+}
+
+// Decompress returns data decompressed if it is compressed, or as is otherwise
+func Decompress(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(gzipMagic)) {
+		return data, nil
+	}
+
+	gzReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to unzip, %w", ErrObjectCorrupt, err)
+	}
+
+	decompressed, err := io.ReadAll(gzReader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to unzip, %w", ErrObjectCorrupt, err)
+	}
+
+	return decompressed, gzReader.Close()
+}
+
+// Decode decodes data, decompressed if it is compressed, into objectPointer. It does not decrypt EncryptedObject
+// envelopes, see DecodeObject.
+func Decode(data []byte, objectPointer interface{}) error {
+	decompressed, err := Decompress(data)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(decompressed, objectPointer); err != nil {
+		return fmt.Errorf("%w: failed to decode json, %w", ErrObjectCorrupt, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metadata describes the DR metadata that Ramen stores in the S3 stores of the DR clusters, and reads and
+// writes it, so that recovery tooling, like break-glass scripts restoring a workload without a hub, can parse it
+// without importing the controllers.
+//
+// The objects of a VolumeReplicationGroup are stored under the prefix "<vrg namespace>/<vrg name>/":
+//
+//	v1alpha1.VolumeReplicationGroup/a                   the VRG
+//	controllers.LocalFailoverPlan/a                     the local failover plan and hub heartbeat
+//	controllers.LocalFailoverPrimaryHeartbeat/a         the heartbeat of the primary VRG of a local failover plan
+//	controllers.CaptureGeneration/<generation>          the version IDs of the objects at a capture generation
+//	v1.PersistentVolume/<pv name>                       the PVs of the protected PVCs
+//	v1.PersistentVolumeClaim/<pvc namespace>/<pvc name> the protected PVCs
+//	v1alpha1.VolumeGroupReplication/<namespace>/<name>  the volume group replications
+//	v1alpha1.VolumeGroupReplicationContent/<name>       the volume group replication contents
+//	kube-objects/<capture number>/differential/baseline the index of a full kube objects capture
+//	kube-objects/<capture number>/differential/delta    the differential capture over the full capture
+//
+// The full kube objects captures are Velero backups, stored under the kube-objects/<capture number>/ prefix in the
+// format of Velero, which this package does not read.
+//
+// Each object is json encoded, stored as an EncryptedObject envelope if the S3 profile enables client-side encryption,
+// wrapped in a ChecksummedObject envelope with the hash of its json encoding, and gzip compressed unless the S3
+// profile disables the compression. DecodeObject reads the objects in any of these forms, returning ErrObjectCorrupt
+// if one does not match its checksum.
+package metadata
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+)
+
+// DataKeySize is the size of the AES-256 data keys of the encrypted objects, and of the key encryption keys of the
+// Secret key provider
+const DataKeySize = 32
+
+// KeyWrapper wraps the data keys of the encrypted objects with a key encryption key
+type KeyWrapper interface {
+	WrapKey(dataKey []byte) ([]byte, error)
+}
+
+// KeyUnwrapper unwraps the data keys of the encrypted objects with a key encryption key
+type KeyUnwrapper interface {
+	UnwrapKey(wrappedKey []byte) ([]byte, error)
+}
+
+// AESKeyEncrypter wraps and unwraps data keys with AES-GCM, like the Secret key provider does with the key of the
+// secret of the S3 profile
+type AESKeyEncrypter []byte
+
+func (k AESKeyEncrypter) WrapKey(dataKey []byte) ([]byte, error) {
+	return aesGCMSeal(k, dataKey, nil)
+}
+
+func (k AESKeyEncrypter) UnwrapKey(wrappedKey []byte) ([]byte, error) {
+	return aesGCMOpen(k, wrappedKey, nil)
+}
+
+// Encrypt encrypts plaintext with a new data key, authenticated along with the key of the object so that the object
+// cannot be swapped with another one, and returns it in an envelope with the data key wrapped by keyWrapper. The
+// caller sets the key provider, key id and content encoding of the envelope.
+func Encrypt(key string, plaintext []byte, keyWrapper KeyWrapper) (EncryptedObject, error) {
+	dataKey := make([]byte, DataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return EncryptedObject{}, fmt.Errorf("failed to generate data key of object %s, %w", key, err)
+	}
+
+	ciphertext, err := aesGCMSeal(dataKey, plaintext, []byte(key))
+	if err != nil {
+		return EncryptedObject{}, fmt.Errorf("failed to encrypt object %s, %w", key, err)
+	}
+
+	wrappedKey, err := keyWrapper.WrapKey(dataKey)
+	if err != nil {
+		return EncryptedObject{}, fmt.Errorf("failed to wrap data key of object %s, %w", key, err)
+	}
+
+	return EncryptedObject{Format: EncryptedObjectFormat, WrappedKey: wrappedKey, Ciphertext: ciphertext}, nil
+}
+
+// Decrypt returns the plaintext of the envelope of the object with key, unwrapping its data key with keyUnwrapper
+func Decrypt(key string, envelope EncryptedObject, keyUnwrapper KeyUnwrapper) ([]byte, error) {
+	dataKey, err := keyUnwrapper.UnwrapKey(envelope.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key of object %s with key %s of provider %s, %w",
+			key, envelope.KeyID, envelope.KeyProvider, err)
+	}
+
+	plaintext, err := aesGCMOpen(dataKey, envelope.Ciphertext, []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt object %s, %w", key, err)
+	}
+
+	return plaintext, nil
+}
+
+// EncryptedObjectOf returns the envelope that the json document data is, and whether it is one
+func EncryptedObjectOf(data []byte) (EncryptedObject, bool) {
+	envelope := EncryptedObject{}
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Format != EncryptedObjectFormat {
+		return EncryptedObject{}, false
+	}
+
+	return envelope, true
+}
+
+// DecodeObject decodes data, as read from the object with key, into objectPointer, verifying its checksum if it is a
+// ChecksummedObject envelope, and decrypting it with keyUnwrapper if it is an EncryptedObject envelope. keyUnwrapper may be nil if the objects are not encrypted.
+func DecodeObject(key string, data []byte, keyUnwrapper KeyUnwrapper, objectPointer interface{}) error {
+	decompressed, err := Decompress(data)
+	if err != nil {
+		return fmt.Errorf("failed to decode object %s, %w", key, err)
+	}
+
+	decompressed, err = Unchecksummed(key, decompressed)
+	if err != nil {
+		return err
+	}
+
+	envelope, ok := EncryptedObjectOf(decompressed)
+	if !ok {
+		return Decode(decompressed, objectPointer)
+	}
+
+	if keyUnwrapper == nil {
+		return fmt.Errorf("object %s is encrypted with key %s of provider %s, and no key was given",
+			key, envelope.KeyID, envelope.KeyProvider)
+	}
+
+	plaintext, err := Decrypt(key, envelope, keyUnwrapper)
+	if err != nil {
+		return err
+	}
+
+	return Decode(plaintext, objectPointer)
+}
+
+// aesGCMSeal returns the nonce followed by the encrypted plaintext, authenticated along with additionalData
+func aesGCMSeal(key, plaintext, additionalData []byte) ([]byte, error) {
+	gcm, err := aesGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func aesGCMOpen(key, sealed, additionalData []byte) ([]byte, error) {
+	gcm, err := aesGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted data is %d bytes long, shorter than its nonce", len(sealed))
+	}
+
+	return gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], additionalData)
+}
+
+func aesGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
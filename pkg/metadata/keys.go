@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import "strconv"
+
+// SchemaVersion is the version of the layout and formats of the DR metadata described by this package. It changes
+// only with changes that the readers of the previous version cannot parse.
+const SchemaVersion = "v1"
+
+// The type names of the keys of the typed objects of a VolumeReplicationGroup
+const (
+	TypeNameVolumeReplicationGroup        = "v1alpha1.VolumeReplicationGroup"
+	TypeNameLocalFailoverPlan             = "controllers.LocalFailoverPlan"
+	TypeNameLocalFailoverPrimaryHeartbeat = "controllers.LocalFailoverPrimaryHeartbeat"
+	TypeNameCaptureGeneration             = "controllers.CaptureGeneration"
+	TypeNamePersistentVolume              = "v1.PersistentVolume"
+	TypeNamePersistentVolumeClaim         = "v1.PersistentVolumeClaim"
+	TypeNameVolumeGroupReplication        = "v1alpha1.VolumeGroupReplication"
+	TypeNameVolumeGroupReplicationContent = "v1alpha1.VolumeGroupReplicationContent"
+)
+
+const (
+	// VolumeReplicationGroupName is the name of the VolumeReplicationGroup object of a VolumeReplicationGroup
+	VolumeReplicationGroupName = "a"
+
+	// LocalFailoverPlanName is the name of the LocalFailoverPlan object of a VolumeReplicationGroup
+	LocalFailoverPlanName = "a"
+
+	// LocalFailoverPrimaryHeartbeatName is the name of the LocalFailoverPrimaryHeartbeat object of a
+	// VolumeReplicationGroup
+	LocalFailoverPrimaryHeartbeatName = "a"
+
+	kubeObjectsPathName                 = "kube-objects/"
+	kubeObjectsDifferentialBaselineName = "differential/baseline"
+	kubeObjectsDifferentialDeltaName    = "differential/delta"
+)
+
+// VolumeReplicationGroupPrefix returns the prefix of the keys of the objects of the VolumeReplicationGroup
+func VolumeReplicationGroupPrefix(namespace, name string) string {
+	return namespace + "/" + name + "/"
+}
+
+// TypedKey returns the key of the object with typeName and name under prefix
+func TypedKey(prefix, typeName, name string) string {
+	return TypedKeyPrefix(prefix, typeName) + name
+}
+
+// TypedKeyPrefix returns the prefix of the keys of the objects with typeName under prefix
+func TypedKeyPrefix(prefix, typeName string) string {
+	return prefix + typeName + "/"
+}
+
+// CaptureGenerationKey returns the key of the capture generation with generation of the VolumeReplicationGroup
+func CaptureGenerationKey(namespace, name string, generation int64) string {
+	return TypedKey(VolumeReplicationGroupPrefix(namespace, name), TypeNameCaptureGeneration,
+		strconv.FormatInt(generation, 10))
+}
+
+// NamespacedName returns the name of the objects of namespaced resources, like PVCs, in their keys
+func NamespacedName(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// KubeObjectsPrefix returns the prefix of the keys of the kube objects captures of the VolumeReplicationGroup
+func KubeObjectsPrefix(namespace, name string) string {
+	return VolumeReplicationGroupPrefix(namespace, name) + kubeObjectsPathName
+}
+
+// KubeObjectsCapturePrefix returns the prefix of the keys of the kube objects capture with captureNumber of the
+// VolumeReplicationGroup
+func KubeObjectsCapturePrefix(namespace, name string, captureNumber int64) string {
+	return KubeObjectsPrefix(namespace, name) + strconv.FormatInt(captureNumber, 10) + "/"
+}
+
+// KubeObjectsDifferentialBaselineKey returns the key of the index of the full kube objects capture with prefix
+func KubeObjectsDifferentialBaselineKey(capturePrefix string) string {
+	return capturePrefix + kubeObjectsDifferentialBaselineName
+}
+
+// KubeObjectsDifferentialDeltaKey returns the key of the differential capture over the full kube objects capture
+// with prefix
+func KubeObjectsDifferentialDeltaKey(capturePrefix string) string {
+	return capturePrefix + kubeObjectsDifferentialDeltaName
+}
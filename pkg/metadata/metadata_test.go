@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/pkg/metadata"
+)
+
+// bucket holds the objects in memory
+type bucket map[string][]byte
+
+func (b bucket) Get(_ context.Context, key string) ([]byte, error) {
+	data, ok := b[key]
+	if !ok {
+		return nil, fmt.Errorf("no object %s", key)
+	}
+
+	return data, nil
+}
+
+func (b bucket) List(_ context.Context, prefix string) ([]string, error) {
+	keys := []string{}
+
+	for key := range b {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (b bucket) Put(_ context.Context, key string, data []byte) error {
+	b[key] = data
+
+	return nil
+}
+
+func writeAndRead(t *testing.T, writer metadata.Writer, reader metadata.Reader) {
+	t.Helper()
+
+	ctx := context.TODO()
+	vrg := ramen.VolumeReplicationGroup{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "vrg"}}
+	pvcs := []corev1.PersistentVolumeClaim{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "pvc1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "pvc2"}},
+	}
+
+	if err := writer.VolumeReplicationGroup(ctx, vrg); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pvc := range pvcs {
+		if err := writer.PersistentVolumeClaim(ctx, vrg.Namespace, vrg.Name, pvc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	delta := metadata.KubeObjectsDelta{Deleted: []metadata.KubeObjectReference{{APIVersion: "v1", Kind: "ConfigMap"}}}
+	if err := writer.KubeObjectsDifferentialDelta(ctx, vrg.Namespace, vrg.Name, 1, delta); err != nil {
+		t.Fatal(err)
+	}
+
+	readVRG, err := reader.VolumeReplicationGroup(ctx, vrg.Namespace, vrg.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if readVRG.Name != vrg.Name {
+		t.Errorf("expected vrg %s, got %s", vrg.Name, readVRG.Name)
+	}
+
+	readPVCs, err := reader.PersistentVolumeClaims(ctx, vrg.Namespace, vrg.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(readPVCs) != 2 || readPVCs[0].Name != "pvc1" || readPVCs[1].Name != "pvc2" {
+		t.Errorf("expected pvcs pvc1 and pvc2, got %v", readPVCs)
+	}
+
+	readDelta, err := reader.KubeObjectsDifferentialDelta(ctx, vrg.Namespace, vrg.Name, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(readDelta.Deleted) != 1 || readDelta.Deleted[0] != delta.Deleted[0] {
+		t.Errorf("expected deleted %v, got %v", delta.Deleted, readDelta.Deleted)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	for _, keys := range [][2]string{
+		{
+			metadata.TypedKey(metadata.VolumeReplicationGroupPrefix("ns", "vrg"),
+				metadata.TypeNamePersistentVolumeClaim, metadata.NamespacedName("app", "pvc")),
+			"ns/vrg/v1.PersistentVolumeClaim/app/pvc",
+		},
+		{
+			metadata.KubeObjectsDifferentialBaselineKey(metadata.KubeObjectsCapturePrefix("ns", "vrg", 3)),
+			"ns/vrg/kube-objects/3/differential/baseline",
+		},
+	} {
+		if keys[0] != keys[1] {
+			t.Errorf("expected key %s, got %s", keys[1], keys[0])
+		}
+	}
+}
+
+func TestWriteAndReadCompressed(t *testing.T) {
+	objects := bucket{}
+	writeAndRead(t, metadata.Writer{Bucket: objects}, metadata.Reader{Bucket: objects})
+
+	if data := objects["ns/vrg/v1alpha1.VolumeReplicationGroup/a"]; !bytes.HasPrefix(data, []byte("\x1f\x8b")) {
+		t.Errorf("expected a gzip compressed vrg, got %q", data)
+	}
+}
+
+func TestWriteAndReadUncompressed(t *testing.T) {
+	objects := bucket{}
+	writeAndRead(t,
+		metadata.Writer{Bucket: objects, Compression: metadata.Compression{Algorithm: ramen.ObjectCompressionNone}},
+		metadata.Reader{Bucket: objects},
+	)
+
+	if data := objects["ns/vrg/v1alpha1.VolumeReplicationGroup/a"]; !bytes.HasPrefix(data, []byte("{")) {
+		t.Errorf("expected a json vrg, got %q", data)
+	}
+}
+
+func TestWriteAndReadEncrypted(t *testing.T) {
+	objects := bucket{}
+	key := metadata.AESKeyEncrypter(bytes.Repeat([]byte{1}, metadata.DataKeySize))
+	writeAndRead(t,
+		metadata.Writer{Bucket: objects, Encryption: &metadata.Encryption{KeyWrapper: key, KeyID: "key1"}},
+		metadata.Reader{Bucket: objects, KeyUnwrapper: key},
+	)
+
+	vrgKey := "ns/vrg/v1alpha1.VolumeReplicationGroup/a"
+	checksummed := metadata.ChecksummedObject{}
+	envelope := metadata.EncryptedObject{}
+
+	if err := metadata.Decode(objects[vrgKey], &checksummed); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := metadata.Decode(checksummed.Object, &envelope); err != nil {
+		t.Fatal(err)
+	}
+
+	if envelope.Format != metadata.EncryptedObjectFormat || envelope.KeyID != "key1" {
+		t.Errorf("expected an envelope of key key1, got %+v", envelope)
+	}
+
+	err := metadata.Reader{Bucket: objects}.Object(context.TODO(), vrgKey, &ramen.VolumeReplicationGroup{})
+	if err == nil || !strings.Contains(err.Error(), "no key was given") {
+		t.Errorf("expected an error reading an encrypted object without a key, got %v", err)
+	}
+
+	// An object cannot be read under the key of another one
+	objects["ns/vrg2/v1alpha1.VolumeReplicationGroup/a"] = objects[vrgKey]
+
+	_, err = metadata.Reader{Bucket: objects, KeyUnwrapper: key}.VolumeReplicationGroup(context.TODO(), "ns", "vrg2")
+	if err == nil || !strings.Contains(err.Error(), "failed to decrypt") {
+		t.Errorf("expected an error decrypting a swapped object, got %v", err)
+	}
+}
+
+func TestReadCorrupt(t *testing.T) {
+	objects := bucket{}
+	writer := metadata.Writer{Bucket: objects, Compression: metadata.Compression{Algorithm: ramen.ObjectCompressionNone}}
+	reader := metadata.Reader{Bucket: objects}
+	ctx := context.TODO()
+	vrgKey := "ns/vrg/v1alpha1.VolumeReplicationGroup/a"
+
+	if err := writer.VolumeReplicationGroup(ctx, ramen.VolumeReplicationGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "vrg"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	written := objects[vrgKey]
+
+	// An object altered in the store does not match its checksum
+	objects[vrgKey] = bytes.Replace(written, []byte(`"name":"vrg"`), []byte(`"name":"vrx"`), 1)
+
+	_, err := reader.VolumeReplicationGroup(ctx, "ns", "vrg")
+	if !errors.Is(err, metadata.ErrObjectCorrupt) || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("expected a checksum mismatch reading an altered object, got %v", err)
+	}
+
+	// A truncated object is not a json document
+	objects[vrgKey] = written[:len(written)/2]
+
+	_, err = reader.VolumeReplicationGroup(ctx, "ns", "vrg")
+	if !errors.Is(err, metadata.ErrObjectCorrupt) {
+		t.Errorf("expected a corrupt object reading a truncated object, got %v", err)
+	}
+
+	// An object written before checksums were recorded is read as is
+	objects[vrgKey] = []byte(`{"metadata":{"namespace":"ns","name":"vrg"}}`)
+
+	vrg, err := reader.VolumeReplicationGroup(ctx, "ns", "vrg")
+	if err != nil || vrg.Name != "vrg" {
+		t.Errorf("expected vrg vrg read from an object without a checksum, got %v, %v", vrg, err)
+	}
+}
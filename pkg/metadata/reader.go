@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"context"
+	"fmt"
+
+	volrep "github.com/csi-addons/kubernetes-csi-addons/api/replication.storage/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// Bucket reads the objects of an S3 bucket, or of any other store holding the same keys
+type Bucket interface {
+	// Get returns the content of the object with key
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// List returns the keys of the objects starting with prefix
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Reader reads the DR metadata of the VolumeReplicationGroups from a bucket
+type Reader struct {
+	Bucket Bucket
+
+	// KeyUnwrapper unwraps the data keys of the encrypted objects, nil if none are
+	KeyUnwrapper KeyUnwrapper
+}
+
+// Object reads the object with key into objectPointer
+func (r Reader) Object(ctx context.Context, key string, objectPointer interface{}) error {
+	data, err := r.Bucket.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to get object %s, %w", key, err)
+	}
+
+	return DecodeObject(key, data, r.KeyUnwrapper, objectPointer)
+}
+
+// VolumeReplicationGroup reads the VolumeReplicationGroup with namespace and name
+func (r Reader) VolumeReplicationGroup(ctx context.Context, namespace, name string,
+) (*ramen.VolumeReplicationGroup, error) {
+	vrg := &ramen.VolumeReplicationGroup{}
+
+	return vrg, r.Object(ctx, TypedKey(VolumeReplicationGroupPrefix(namespace, name), TypeNameVolumeReplicationGroup,
+		VolumeReplicationGroupName), vrg)
+}
+
+// LocalFailoverPlan reads the local failover plan of the VolumeReplicationGroup with namespace and name
+func (r Reader) LocalFailoverPlan(ctx context.Context, namespace, name string) (*LocalFailoverPlan, error) {
+	plan := &LocalFailoverPlan{}
+
+	return plan, r.Object(ctx, TypedKey(VolumeReplicationGroupPrefix(namespace, name), TypeNameLocalFailoverPlan,
+		LocalFailoverPlanName), plan)
+}
+
+// LocalFailoverPrimaryHeartbeat reads the heartbeat of the primary VolumeReplicationGroup with namespace and name
+func (r Reader) LocalFailoverPrimaryHeartbeat(ctx context.Context, namespace, name string,
+) (*LocalFailoverPrimaryHeartbeat, error) {
+	heartbeat := &LocalFailoverPrimaryHeartbeat{}
+
+	return heartbeat, r.Object(ctx, TypedKey(VolumeReplicationGroupPrefix(namespace, name),
+		TypeNameLocalFailoverPrimaryHeartbeat, LocalFailoverPrimaryHeartbeatName), heartbeat)
+}
+
+// PersistentVolumes reads the PVs of the VolumeReplicationGroup with namespace and name
+func (r Reader) PersistentVolumes(ctx context.Context, namespace, name string) ([]corev1.PersistentVolume, error) {
+	return readTyped[corev1.PersistentVolume](ctx, r, namespace, name, TypeNamePersistentVolume)
+}
+
+// PersistentVolumeClaims reads the PVCs of the VolumeReplicationGroup with namespace and name
+func (r Reader) PersistentVolumeClaims(ctx context.Context, namespace, name string,
+) ([]corev1.PersistentVolumeClaim, error) {
+	return readTyped[corev1.PersistentVolumeClaim](ctx, r, namespace, name, TypeNamePersistentVolumeClaim)
+}
+
+// VolumeGroupReplications reads the volume group replications of the VolumeReplicationGroup with namespace and name
+func (r Reader) VolumeGroupReplications(ctx context.Context, namespace, name string,
+) ([]volrep.VolumeGroupReplication, error) {
+	return readTyped[volrep.VolumeGroupReplication](ctx, r, namespace, name, TypeNameVolumeGroupReplication)
+}
+
+// VolumeGroupReplicationContents reads the volume group replication contents of the VolumeReplicationGroup with
+// namespace and name
+func (r Reader) VolumeGroupReplicationContents(ctx context.Context, namespace, name string,
+) ([]volrep.VolumeGroupReplicationContent, error) {
+	return readTyped[volrep.VolumeGroupReplicationContent](ctx, r, namespace, name,
+		TypeNameVolumeGroupReplicationContent)
+}
+
+// KubeObjectsDifferentialBaseline reads the index of the full kube objects capture with captureNumber of the
+// VolumeReplicationGroup with namespace and name
+func (r Reader) KubeObjectsDifferentialBaseline(ctx context.Context, namespace, name string, captureNumber int64,
+) ([]KubeObjectsIndexEntry, error) {
+	index := []KubeObjectsIndexEntry{}
+
+	return index, r.Object(ctx,
+		KubeObjectsDifferentialBaselineKey(KubeObjectsCapturePrefix(namespace, name, captureNumber)), &index)
+}
+
+// KubeObjectsDifferentialDelta reads the differential capture over the full kube objects capture with captureNumber
+// of the VolumeReplicationGroup with namespace and name
+func (r Reader) KubeObjectsDifferentialDelta(ctx context.Context, namespace, name string, captureNumber int64,
+) (*KubeObjectsDelta, error) {
+	delta := &KubeObjectsDelta{}
+
+	return delta, r.Object(ctx,
+		KubeObjectsDifferentialDeltaKey(KubeObjectsCapturePrefix(namespace, name, captureNumber)), delta)
+}
+
+// readTyped reads the objects with typeName of the VolumeReplicationGroup with namespace and name
+func readTyped[T any](ctx context.Context, r Reader, namespace, name, typeName string) ([]T, error) {
+	prefix := TypedKeyPrefix(VolumeReplicationGroupPrefix(namespace, name), typeName)
+
+	keys, err := r.Bucket.List(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects with prefix %s, %w", prefix, err)
+	}
+
+	objects := make([]T, len(keys))
+
+	for idx, key := range keys {
+		if err := r.Object(ctx, key, &objects[idx]); err != nil {
+			return nil, err
+		}
+	}
+
+	return objects, nil
+}
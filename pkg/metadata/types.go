@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// KubeObjectReference identifies a kube object of a differential kube objects capture
+type KubeObjectReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+}
+
+// KubeObjectsIndexEntry is the hash of the content of an object of a full kube objects capture
+type KubeObjectsIndexEntry struct {
+	KubeObjectReference `json:",inline"`
+	Hash                string `json:"hash"`
+}
+
+// KubeObjectsDelta holds the objects created or changed, and the references of the objects deleted, since a full
+// kube objects capture
+type KubeObjectsDelta struct {
+	Objects []unstructured.Unstructured `json:"objects,omitempty"`
+	Deleted []KubeObjectReference       `json:"deleted,omitempty"`
+}
+
+// LocalFailoverPlan is the local failover plan, and the hub heartbeat, that the hub uploads next to the VRG
+type LocalFailoverPlan struct {
+	FailoverCluster         string          `json:"failoverCluster"`
+	HubUnreachableThreshold metav1.Duration `json:"hubUnreachableThreshold"`
+	HubHeartbeat            metav1.Time     `json:"hubHeartbeat"`
+}
+
+// LocalFailoverPrimaryHeartbeat is the heartbeat that the primary VRG of a local failover plan uploads next to the VRG
+type LocalFailoverPrimaryHeartbeat struct {
+	Heartbeat metav1.Time `json:"heartbeat"`
+}
+
+// CaptureGeneration records the version IDs of the objects of a VolumeReplicationGroup, in a bucket with object
+// versioning enabled, so that the objects can be restored as they were at Time
+type CaptureGeneration struct {
+	Generation int64             `json:"generation"`
+	Time       metav1.Time       `json:"time"`
+	VersionIDs map[string]string `json:"versionIDs"`
+}
+
+// EncryptedObjectFormat is the format of the EncryptedObject envelopes
+const EncryptedObjectFormat = "ramen.envelope.v1"
+
+// EncryptedObject is the envelope of an object encrypted with a data key of its own, that is stored wrapped with the
+// key encryption key along with the object
+type EncryptedObject struct {
+	Format      string `json:"format"`
+	KeyProvider string `json:"keyProvider,omitempty"`
+	KeyID       string `json:"keyID,omitempty"`
+	WrappedKey  []byte `json:"wrappedKey"`
+	Ciphertext  []byte `json:"ciphertext"`
+
+	// ContentEncoding is the compression of the plaintext, as ciphertexts do not compress
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+}
+
+// ChecksummedObjectFormat is the format of the ChecksummedObject envelopes
+const ChecksummedObjectFormat = "ramen.checksum.v1"
+
+// ChecksummedObject is the envelope of an object stored along with the SHA-256 hash of its json encoding, so that an
+// object that was truncated or altered in the store is detected before it is restored
+type ChecksummedObject struct {
+	Format string          `json:"format"`
+	SHA256 string          `json:"sha256"`
+	Object json.RawMessage `json:"object"`
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"context"
+	"fmt"
+
+	volrep "github.com/csi-addons/kubernetes-csi-addons/api/replication.storage/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// WritableBucket reads and writes the objects of an S3 bucket, or of any other store holding the same keys
+type WritableBucket interface {
+	Bucket
+
+	// Put stores data as the content of the object with key
+	Put(ctx context.Context, key string, data []byte) error
+}
+
+// Encryption is the client-side encryption of the objects written
+type Encryption struct {
+	KeyWrapper  KeyWrapper
+	KeyProvider string
+	KeyID       string
+}
+
+// Writer writes the DR metadata of the VolumeReplicationGroups to a bucket, the way Ramen does, for instance to seed
+// the stores of a recovered cluster
+type Writer struct {
+	Bucket      WritableBucket
+	Compression Compression
+
+	// Encryption encrypts the objects written, nil if they are not
+	Encryption *Encryption
+}
+
+// Object writes object as the object with key
+func (w Writer) Object(ctx context.Context, key string, object interface{}) error {
+	data, err := w.encode(key, object)
+	if err != nil {
+		return err
+	}
+
+	if err := w.Bucket.Put(ctx, key, data); err != nil {
+		return fmt.Errorf("failed to put object %s, %w", key, err)
+	}
+
+	return nil
+}
+
+// VolumeReplicationGroup writes vrg
+func (w Writer) VolumeReplicationGroup(ctx context.Context, vrg ramen.VolumeReplicationGroup) error {
+	return w.Object(ctx, TypedKey(VolumeReplicationGroupPrefix(vrg.Namespace, vrg.Name),
+		TypeNameVolumeReplicationGroup, VolumeReplicationGroupName), vrg)
+}
+
+// LocalFailoverPlan writes the local failover plan of the VolumeReplicationGroup with namespace and name
+func (w Writer) LocalFailoverPlan(ctx context.Context, namespace, name string, plan LocalFailoverPlan) error {
+	return w.Object(ctx, TypedKey(VolumeReplicationGroupPrefix(namespace, name), TypeNameLocalFailoverPlan,
+		LocalFailoverPlanName), plan)
+}
+
+// LocalFailoverPrimaryHeartbeat writes the heartbeat of the primary VolumeReplicationGroup with namespace and name
+func (w Writer) LocalFailoverPrimaryHeartbeat(ctx context.Context, namespace, name string,
+	heartbeat LocalFailoverPrimaryHeartbeat,
+) error {
+	return w.Object(ctx, TypedKey(VolumeReplicationGroupPrefix(namespace, name),
+		TypeNameLocalFailoverPrimaryHeartbeat, LocalFailoverPrimaryHeartbeatName), heartbeat)
+}
+
+// PersistentVolume writes pv as a PV of the VolumeReplicationGroup with namespace and name
+func (w Writer) PersistentVolume(ctx context.Context, namespace, name string, pv corev1.PersistentVolume) error {
+	return w.Object(ctx, TypedKey(VolumeReplicationGroupPrefix(namespace, name), TypeNamePersistentVolume, pv.Name),
+		pv)
+}
+
+// PersistentVolumeClaim writes pvc as a PVC of the VolumeReplicationGroup with namespace and name
+func (w Writer) PersistentVolumeClaim(ctx context.Context, namespace, name string,
+	pvc corev1.PersistentVolumeClaim,
+) error {
+	return w.Object(ctx, TypedKey(VolumeReplicationGroupPrefix(namespace, name), TypeNamePersistentVolumeClaim,
+		NamespacedName(pvc.Namespace, pvc.Name)), pvc)
+}
+
+// VolumeGroupReplication writes vgr as a volume group replication of the VolumeReplicationGroup with namespace and
+// name
+func (w Writer) VolumeGroupReplication(ctx context.Context, namespace, name string,
+	vgr volrep.VolumeGroupReplication,
+) error {
+	return w.Object(ctx, TypedKey(VolumeReplicationGroupPrefix(namespace, name), TypeNameVolumeGroupReplication,
+		NamespacedName(vgr.Namespace, vgr.Name)), vgr)
+}
+
+// VolumeGroupReplicationContent writes vgrc as a volume group replication content of the VolumeReplicationGroup with
+// namespace and name
+func (w Writer) VolumeGroupReplicationContent(ctx context.Context, namespace, name string,
+	vgrc volrep.VolumeGroupReplicationContent,
+) error {
+	return w.Object(ctx, TypedKey(VolumeReplicationGroupPrefix(namespace, name),
+		TypeNameVolumeGroupReplicationContent, vgrc.Name), vgrc)
+}
+
+// KubeObjectsDifferentialBaseline writes index as the index of the full kube objects capture with captureNumber of
+// the VolumeReplicationGroup with namespace and name
+func (w Writer) KubeObjectsDifferentialBaseline(ctx context.Context, namespace, name string, captureNumber int64,
+	index []KubeObjectsIndexEntry,
+) error {
+	return w.Object(ctx,
+		KubeObjectsDifferentialBaselineKey(KubeObjectsCapturePrefix(namespace, name, captureNumber)), index)
+}
+
+// KubeObjectsDifferentialDelta writes delta as the differential capture over the full kube objects capture with
+// captureNumber of the VolumeReplicationGroup with namespace and name
+func (w Writer) KubeObjectsDifferentialDelta(ctx context.Context, namespace, name string, captureNumber int64,
+	delta KubeObjectsDelta,
+) error {
+	return w.Object(ctx,
+		KubeObjectsDifferentialDeltaKey(KubeObjectsCapturePrefix(namespace, name, captureNumber)), delta)
+}
+
+// encode returns object json encoded, compressed, and encrypted if w encrypts the objects. The envelope of an
+// encrypted object is itself compressed, as the stores compress every object they upload.
+func (w Writer) encode(key string, object interface{}) ([]byte, error) {
+	if w.Encryption != nil {
+		data, err := w.Compression.Encode(object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode object %s, %w", key, err)
+		}
+
+		envelope, err := Encrypt(key, data, w.Encryption.KeyWrapper)
+		if err != nil {
+			return nil, err
+		}
+
+		envelope.KeyProvider = w.Encryption.KeyProvider
+		envelope.KeyID = w.Encryption.KeyID
+		envelope.ContentEncoding = w.Compression.ContentEncoding()
+		object = envelope
+	}
+
+	checksummed, err := Checksum(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to checksum object %s, %w", key, err)
+	}
+
+	return w.Compression.Encode(checksummed)
+}